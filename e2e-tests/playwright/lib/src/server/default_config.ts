@@ -214,6 +214,9 @@ const defaultServerConfig: AdminConfig = {
         MaximumURLLength: 2048,
         ScheduledPosts: true,
         EnableWebHubChannelIteration: false,
+        EnableWebSocketCompression: false,
+        WebSocketCompressionThresholdBytes: 1024,
+        WebSocketCompressionLevel: 1,
         FrameAncestors: '',
         DeleteAccountLink: '',
     },
@@ -309,6 +312,7 @@ const defaultServerConfig: AdminConfig = {
         ExtractContent: true,
         ExtractContentTimeout: 10,
         ArchiveRecursion: false,
+        DeduplicateFiles: false,
         PublicLinkSalt: '',
         InitialFont: 'nunito-bold.ttf',
         AmazonS3AccessKeyId: '',
@@ -475,6 +479,9 @@ const defaultServerConfig: AdminConfig = {
         ButtonColor: '#145DBF',
         UsePreferredUsername: false,
     },
+    OpenIdProvidersSettings: {
+        Providers: [],
+    },
     LdapSettings: {
         Enable: false,
         EnableSync: false,
@@ -745,6 +752,13 @@ const defaultServerConfig: AdminConfig = {
         RemoteImageProxyURL: '',
         RemoteImageProxyOptions: '',
     },
+    FileScanSettings: {
+        Enable: false,
+        Type: 'clamd',
+        Address: '',
+        TimeoutSeconds: 60,
+        AllowDownloadOfFailedScans: false,
+    },
     CloudSettings: {
         CWSURL: 'https://customers.mattermost.com',
         CWSAPIURL: 'https://portal.internal.prod.cloud.mattermost.com',
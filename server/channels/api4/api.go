@@ -471,6 +471,7 @@ func InitLocal(srv *app.Server) *API {
 	api.BaseRoutes.PostsForChannel = api.BaseRoutes.Channel.PathPrefix("/posts").Subrouter()
 
 	api.BaseRoutes.Roles = api.BaseRoutes.APIRoot.PathPrefix("/roles").Subrouter()
+	api.BaseRoutes.Schemes = api.BaseRoutes.APIRoot.PathPrefix("/schemes").Subrouter()
 
 	api.BaseRoutes.Uploads = api.BaseRoutes.APIRoot.PathPrefix("/uploads").Subrouter()
 	api.BaseRoutes.Upload = api.BaseRoutes.Uploads.PathPrefix("/{upload_id:[A-Za-z0-9]+}").Subrouter()
@@ -507,6 +508,7 @@ func InitLocal(srv *app.Server) *API {
 	api.InitPostLocal()
 	api.InitPreferenceLocal()
 	api.InitRoleLocal()
+	api.InitSchemeLocal()
 	api.InitUploadLocal()
 	api.InitImportLocal()
 	api.InitExportLocal()
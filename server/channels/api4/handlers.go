@@ -22,6 +22,11 @@ type APIHandlerOption string
 
 const (
 	handlerParamFileAPI = APIHandlerOption("fileAPI")
+
+	handlerParamRateLimitLogin      = APIHandlerOption("rateLimitLogin")
+	handlerParamRateLimitPostCreate = APIHandlerOption("rateLimitPostCreate")
+	handlerParamRateLimitFileUpload = APIHandlerOption("rateLimitFileUpload")
+	handlerParamRateLimitSearch     = APIHandlerOption("rateLimitSearch")
 )
 
 // APIHandler provides a handler for API endpoints which do not require the user to be logged in order for access to be
@@ -251,6 +256,14 @@ func setHandlerOpts(handler *web.Handler, opts ...APIHandlerOption) {
 		switch option {
 		case handlerParamFileAPI:
 			handler.FileAPI = true
+		case handlerParamRateLimitLogin:
+			handler.RateLimitClass = app.RateLimitClassLogin
+		case handlerParamRateLimitPostCreate:
+			handler.RateLimitClass = app.RateLimitClassPostCreate
+		case handlerParamRateLimitFileUpload:
+			handler.RateLimitClass = app.RateLimitClassFileUpload
+		case handlerParamRateLimitSearch:
+			handler.RateLimitClass = app.RateLimitClassSearch
 		}
 	}
 }
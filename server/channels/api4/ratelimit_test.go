@@ -144,7 +144,7 @@ func TestRateLimitingVaryByUser(t *testing.T) {
 	th := Setup(t)
 
 	// Install a rate limiter after setup: VaryByUser=true, PerSec=1, MaxBurst=1 → limit of 2
-	rl, err := app.NewRateLimiter(&model.RateLimitSettings{
+	settings := &model.RateLimitSettings{
 		Enable:           new(true),
 		PerSec:           new(1),
 		MaxBurst:         new(1),
@@ -152,7 +152,9 @@ func TestRateLimitingVaryByUser(t *testing.T) {
 		VaryByRemoteAddr: new(false),
 		VaryByUser:       new(true),
 		VaryByHeader:     "",
-	}, nil)
+	}
+	settings.SetDefaults()
+	rl, err := app.NewRateLimiter(settings, nil)
 	require.NoError(t, err)
 	th.App.Srv().RateLimiter = rl
 
@@ -0,0 +1,16 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import "net/http"
+
+func (api *API) InitSchemeLocal() {
+	api.BaseRoutes.Schemes.Handle("", api.APILocal(getSchemes)).Methods(http.MethodGet)
+	api.BaseRoutes.Schemes.Handle("", api.APILocal(createScheme)).Methods(http.MethodPost)
+	api.BaseRoutes.Schemes.Handle("/{scheme_id:[A-Za-z0-9]+}", api.APILocal(deleteScheme)).Methods(http.MethodDelete)
+	api.BaseRoutes.Schemes.Handle("/{scheme_id:[A-Za-z0-9]+}", api.APILocal(getScheme)).Methods(http.MethodGet)
+	api.BaseRoutes.Schemes.Handle("/{scheme_id:[A-Za-z0-9]+}/patch", api.APILocal(patchScheme)).Methods(http.MethodPut)
+	api.BaseRoutes.Schemes.Handle("/{scheme_id:[A-Za-z0-9]+}/teams", api.APILocal(getTeamsForScheme)).Methods(http.MethodGet)
+	api.BaseRoutes.Schemes.Handle("/{scheme_id:[A-Za-z0-9]+}/channels", api.APILocal(getChannelsForScheme)).Methods(http.MethodGet)
+}
@@ -962,3 +962,76 @@ func TestUpdateTeamSchemeWithTeamMembers(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestSchemesLocal(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.Srv().SetLicense(model.NewTestLicense("custom_permissions_schemes"))
+
+	err := th.App.SetPhase2PermissionsMigrationStatus(true)
+	require.NoError(t, err)
+
+	teamScheme, resp, err := th.LocalClient.CreateScheme(context.Background(), &model.Scheme{
+		DisplayName: model.NewId(),
+		Name:        model.NewId(),
+		Scope:       model.SchemeScopeTeam,
+	})
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+
+	channelScheme, _, err := th.LocalClient.CreateScheme(context.Background(), &model.Scheme{
+		DisplayName: model.NewId(),
+		Name:        model.NewId(),
+		Scope:       model.SchemeScopeChannel,
+	})
+	require.NoError(t, err)
+
+	t.Run("get and list", func(t *testing.T) {
+		scheme, _, err := th.LocalClient.GetScheme(context.Background(), teamScheme.Id)
+		require.NoError(t, err)
+		assert.Equal(t, teamScheme.Name, scheme.Name)
+
+		schemes, _, err := th.LocalClient.GetSchemes(context.Background(), model.SchemeScopeChannel, 0, 100)
+		require.NoError(t, err)
+		ids := []string{}
+		for _, s := range schemes {
+			ids = append(ids, s.Id)
+		}
+		assert.Contains(t, ids, channelScheme.Id)
+		assert.NotContains(t, ids, teamScheme.Id)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		scheme, _, err := th.LocalClient.PatchScheme(context.Background(), teamScheme.Id, &model.SchemePatch{DisplayName: model.NewPointer("patched")})
+		require.NoError(t, err)
+		assert.Equal(t, "patched", scheme.DisplayName)
+	})
+
+	t.Run("assign to a team and a channel", func(t *testing.T) {
+		_, err := th.LocalClient.UpdateTeamScheme(context.Background(), th.BasicTeam.Id, teamScheme.Id)
+		require.NoError(t, err)
+
+		teams, _, err := th.LocalClient.GetTeamsForScheme(context.Background(), teamScheme.Id, 0, 100)
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+		assert.Equal(t, th.BasicTeam.Id, teams[0].Id)
+
+		_, err = th.LocalClient.UpdateChannelScheme(context.Background(), th.BasicChannel.Id, channelScheme.Id)
+		require.NoError(t, err)
+
+		channels, _, err := th.LocalClient.GetChannelsForScheme(context.Background(), channelScheme.Id, 0, 100)
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+		assert.Equal(t, th.BasicChannel.Id, channels[0].Id)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := th.LocalClient.DeleteScheme(context.Background(), channelScheme.Id)
+		require.NoError(t, err)
+
+		scheme, _, err := th.SystemAdminClient.GetScheme(context.Background(), channelScheme.Id)
+		require.NoError(t, err)
+		assert.NotZero(t, scheme.DeleteAt)
+	})
+}
@@ -46,6 +46,9 @@ func (api *API) InitSystem() {
 	api.BaseRoutes.APIRoot.Handle("/audits", api.APISessionRequired(getAudits)).Methods(http.MethodGet)
 	api.BaseRoutes.APIRoot.Handle("/notifications/test", api.APISessionRequired(testNotifications)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/email/test", api.APISessionRequired(testEmail)).Methods(http.MethodPost)
+	api.BaseRoutes.APIRoot.Handle("/email/dead_letters", api.APISessionRequired(getFailedOutgoingEmails)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/email/dead_letters/{outgoing_email_id:[A-Za-z0-9]+}/retry", api.APISessionRequired(retryOutgoingEmail)).Methods(http.MethodPost)
+	api.BaseRoutes.APIRoot.Handle("/email/dead_letters/{outgoing_email_id:[A-Za-z0-9]+}", api.APISessionRequired(deleteOutgoingEmail)).Methods(http.MethodDelete)
 	api.BaseRoutes.APIRoot.Handle("/site_url/test", api.APISessionRequired(testSiteURL)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/file/test", api.APISessionRequired(testFileStore)).Methods(http.MethodPost)
 	// Deprecated: use /file/test instead. Kept as a thin compatibility wrapper.
@@ -269,6 +272,75 @@ func testEmail(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func getFailedOutgoingEmails(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionToAndNotRestrictedAdmin(*c.AppContext.Session(), model.PermissionSysconsoleReadEnvironmentSMTP) {
+		c.SetPermissionError(model.PermissionSysconsoleReadEnvironmentSMTP)
+		return
+	}
+
+	emails, appErr := c.App.GetFailedOutgoingEmails(c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(emails); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func retryOutgoingEmail(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOutgoingEmailId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventRetryOutgoingEmail, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "outgoing_email_id", c.Params.OutgoingEmailId)
+
+	if !c.App.SessionHasPermissionToAndNotRestrictedAdmin(*c.AppContext.Session(), model.PermissionSysconsoleWriteEnvironmentSMTP) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteEnvironmentSMTP)
+		return
+	}
+
+	email, appErr := c.App.RetryOutgoingEmail(c.Params.OutgoingEmailId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(email); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteOutgoingEmail(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireOutgoingEmailId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteOutgoingEmail, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "outgoing_email_id", c.Params.OutgoingEmailId)
+
+	if !c.App.SessionHasPermissionToAndNotRestrictedAdmin(*c.AppContext.Session(), model.PermissionSysconsoleWriteEnvironmentSMTP) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteEnvironmentSMTP)
+		return
+	}
+
+	if appErr := c.App.DeleteOutgoingEmail(c.Params.OutgoingEmailId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
 func testSiteURL(c *Context, w http.ResponseWriter, r *http.Request) {
 	if !c.App.SessionHasPermissionToAndNotRestrictedAdmin(*c.AppContext.Session(), model.PermissionTestSiteURL) {
 		c.SetPermissionError(model.PermissionTestSiteURL)
@@ -222,6 +222,105 @@ func TestEmailTest(t *testing.T) {
 	})
 }
 
+func TestOutgoingEmailDeadLetters(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.EnableMailQueue = true })
+
+	saveEmail := func(t *testing.T, status string) *model.OutgoingEmail {
+		t.Helper()
+		email, err := th.App.Srv().Store().OutgoingEmail().Save(&model.OutgoingEmail{
+			To:        "user@example.com",
+			Subject:   "Subject",
+			HTMLBody:  "<p>Body</p>",
+			Status:    status,
+			Attempts:  10,
+			LastError: "connection refused",
+		})
+		require.NoError(t, err)
+		return email
+	}
+
+	t.Run("get", func(t *testing.T) {
+		failed := saveEmail(t, model.OutgoingEmailStatusFailed)
+		pending := saveEmail(t, model.OutgoingEmailStatusPending)
+
+		_, resp, err := client.GetFailedOutgoingEmails(context.Background(), 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		emails, _, err := th.SystemAdminClient.GetFailedOutgoingEmails(context.Background(), 0, 60)
+		require.NoError(t, err)
+
+		var ids []string
+		for _, email := range emails {
+			ids = append(ids, email.Id)
+			assert.Empty(t, email.HTMLBody)
+		}
+		assert.Contains(t, ids, failed.Id)
+		assert.NotContains(t, ids, pending.Id)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		failed := saveEmail(t, model.OutgoingEmailStatusFailed)
+
+		_, resp, err := client.RetryOutgoingEmail(context.Background(), failed.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		email, _, err := th.SystemAdminClient.RetryOutgoingEmail(context.Background(), failed.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.OutgoingEmailStatusPending, email.Status)
+		assert.Zero(t, email.Attempts)
+
+		_, resp, err = th.SystemAdminClient.RetryOutgoingEmail(context.Background(), failed.Id)
+		require.Error(t, err)
+		CheckErrorID(t, err, "app.outgoing_email.retry.not_failed.app_error")
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.RetryOutgoingEmail(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("retry with the mail queue disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.EnableMailQueue = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.EnableMailQueue = true })
+
+		failed := saveEmail(t, model.OutgoingEmailStatusFailed)
+
+		_, resp, err := th.SystemAdminClient.RetryOutgoingEmail(context.Background(), failed.Id)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		failed := saveEmail(t, model.OutgoingEmailStatusFailed)
+
+		resp, err := client.DeleteOutgoingEmail(context.Background(), failed.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, err = th.SystemAdminClient.DeleteOutgoingEmail(context.Background(), failed.Id)
+		require.NoError(t, err)
+
+		resp, err = th.SystemAdminClient.DeleteOutgoingEmail(context.Background(), failed.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("as restricted system admin", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ExperimentalSettings.RestrictSystemAdmin = false })
+
+		_, resp, err := th.SystemAdminClient.GetFailedOutgoingEmails(context.Background(), 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
 func TestGenerateSupportPacket(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t)
@@ -4,7 +4,6 @@
 package api4
 
 import (
-	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -1646,16 +1645,21 @@ func importTeam(c *Context, w http.ResponseWriter, r *http.Request) {
 	model.AddEventParameterToAuditRec(auditRec, "filesize", fileSize)
 	model.AddEventParameterToAuditRec(auditRec, "from", importFrom)
 
-	var log *bytes.Buffer
 	data := map[string]string{}
 	switch importFrom {
 	case "slack":
-		var err *model.AppError
-		if err, log = c.App.SlackImport(c.AppContext, fileData, fileSize, c.Params.TeamId); err != nil {
-			c.Err = err
+		appErr, report := c.App.SlackImport(c.AppContext, fileData, fileSize, c.Params.TeamId)
+		if appErr != nil {
+			c.Err = appErr
 			c.Err.StatusCode = http.StatusBadRequest
 		}
-		data["results"] = base64.StdEncoding.EncodeToString(log.Bytes())
+		data["results"] = base64.StdEncoding.EncodeToString([]byte(report.String()))
+		reportJSON, err := json.Marshal(report)
+		if err != nil {
+			c.Logger.Warn("Error while marshalling the Slack import report", mlog.Err(err))
+		} else {
+			data["report"] = string(reportJSON)
+		}
 	default:
 		c.Err = model.NewAppError("importTeam", "api.team.import_team.unknown_import_from.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -65,8 +65,14 @@ func (api *API) InitUser() {
 
 	api.BaseRoutes.User.Handle("/mfa", api.APISessionRequiredMfa(updateUserMfa)).Methods(http.MethodPut)
 	api.BaseRoutes.User.Handle("/mfa/generate", api.APISessionRequiredMfa(generateMfaSecret)).Methods(http.MethodPost)
-
-	api.BaseRoutes.Users.Handle("/login", api.RateLimitedHandler(api.APIHandler(login), model.RateLimitSettings{PerSec: new(5), MaxBurst: new(10)})).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/mfa/webauthn", api.APISessionRequiredMfa(getWebAuthnCredentials)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/mfa/webauthn", api.APISessionRequiredMfa(finishWebAuthnRegistration)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/mfa/webauthn/begin", api.APISessionRequiredMfa(beginWebAuthnRegistration)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/mfa/webauthn/{webauthn_credential_id:[A-Za-z0-9]+}", api.APISessionRequiredMfa(deleteWebAuthnCredential)).Methods(http.MethodDelete)
+	api.BaseRoutes.User.Handle("/mfa/recovery_codes", api.APISessionRequiredMfa(generateMfaRecoveryCodes)).Methods(http.MethodPost)
+
+	api.BaseRoutes.Users.Handle("/login", api.APIHandler(login, handlerParamRateLimitLogin)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/login/webauthn", api.APIHandler(beginWebAuthnLogin, handlerParamRateLimitLogin)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/sso/code-exchange", api.APIHandler(loginSSOCodeExchange)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/desktop_token", api.RateLimitedHandler(api.APIHandler(loginWithDesktopToken), model.RateLimitSettings{PerSec: new(2), MaxBurst: new(1)})).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/switch", api.APIHandler(switchAccountType)).Methods(http.MethodPost)
@@ -83,6 +89,8 @@ func (api *API) InitUser() {
 	api.BaseRoutes.User.Handle("/sessions/revoke/all", api.APISessionRequired(revokeAllSessionsForUser)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/sessions/revoke/all", api.APISessionRequired(revokeAllSessionsAllUsers)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/sessions/device", api.APISessionRequired(handleDeviceProps)).Methods(http.MethodPut)
+	api.BaseRoutes.Users.Handle("/sessions/web_push", api.APISessionRequired(attachWebPushSubscription)).Methods(http.MethodPut)
+	api.BaseRoutes.Users.Handle("/sessions/web_push", api.APISessionRequired(detachWebPushSubscription)).Methods(http.MethodDelete)
 	api.BaseRoutes.Users.Handle("/sessions/attributes/manifest", api.APIHandler(getSessionAttributesManifest)).Methods(http.MethodGet)
 	api.BaseRoutes.User.Handle("/audits", api.APISessionRequired(getUserAudits)).Methods(http.MethodGet)
 
@@ -1875,6 +1883,23 @@ func updateUserAuth(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// checkUserMfaPermission checks that the session can manage the second factors of the
+// user of the request, which OAuth apps never can.
+func checkUserMfaPermission(c *Context) bool {
+	if c.AppContext.Session().IsOAuth {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return false
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return false
+	}
+
+	return true
+}
+
 func updateUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
@@ -1884,14 +1909,7 @@ func updateUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec := c.MakeAuditRecord(model.AuditEventUpdateUserMfa, model.AuditStatusFail)
 	defer c.LogAuditRec(auditRec)
 
-	if c.AppContext.Session().IsOAuth {
-		c.SetPermissionError(model.PermissionEditOtherUsers)
-		c.Err.DetailedError += ", attempted access by oauth app"
-		return
-	}
-
-	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
-		c.SetPermissionError(model.PermissionEditOtherUsers)
+	if !checkUserMfaPermission(c) {
 		return
 	}
 
@@ -1940,14 +1958,7 @@ func generateMfaSecret(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if c.AppContext.Session().IsOAuth {
-		c.SetPermissionError(model.PermissionEditOtherUsers)
-		c.Err.DetailedError += ", attempted access by oauth app"
-		return
-	}
-
-	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
-		c.SetPermissionError(model.PermissionEditOtherUsers)
+	if !checkUserMfaPermission(c) {
 		return
 	}
 
@@ -1965,6 +1976,145 @@ func generateMfaSecret(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func getWebAuthnCredentials(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !checkUserMfaPermission(c) {
+		return
+	}
+
+	credentials, appErr := c.App.GetWebAuthnCredentials(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(credentials); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func beginWebAuthnRegistration(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !checkUserMfaPermission(c) {
+		return
+	}
+
+	options, appErr := c.App.BeginWebAuthnRegistration(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func finishWebAuthnRegistration(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	var registration model.WebAuthnRegistration
+	if err := json.NewDecoder(r.Body).Decode(&registration); err != nil {
+		c.SetInvalidParamWithErr("registration", err)
+		return
+	}
+
+	if registration.Credential == nil {
+		c.SetInvalidParam("credential")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventRegisterWebAuthnCredential, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+	model.AddEventParameterToAuditRec(auditRec, "name", registration.Name)
+
+	if !checkUserMfaPermission(c) {
+		return
+	}
+
+	credential, appErr := c.App.FinishWebAuthnRegistration(c.AppContext, c.Params.UserId, &registration)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("webauthn_credential_id", credential.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(credential); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteWebAuthnCredential(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId().RequireWebAuthnCredentialId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteWebAuthnCredential, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+	model.AddEventParameterToAuditRec(auditRec, "webauthn_credential_id", c.Params.WebAuthnCredentialId)
+
+	if !checkUserMfaPermission(c) {
+		return
+	}
+
+	if appErr := c.App.DeleteWebAuthnCredential(c.AppContext, c.Params.UserId, c.Params.WebAuthnCredentialId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
+
+func generateMfaRecoveryCodes(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventGenerateMfaRecoveryCodes, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+
+	if !checkUserMfaPermission(c) {
+		return
+	}
+
+	codes, appErr := c.App.GenerateMfaRecoveryCodes(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	if err := json.NewEncoder(w).Encode(codes); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func updatePassword(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
@@ -2090,6 +2240,28 @@ func sendPasswordReset(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+// beginWebAuthnLogin returns the options of a security key assertion for the user with
+// the given login id. The assertion is then sent as the MFA token of the login request.
+func beginWebAuthnLogin(c *Context, w http.ResponseWriter, r *http.Request) {
+	props := model.MapFromJSON(r.Body)
+	loginId := props["login_id"]
+	if loginId == "" {
+		c.SetInvalidParam("login_id")
+		return
+	}
+
+	options, appErr := c.App.BeginWebAuthnLogin(c.AppContext, loginId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func login(c *Context, w http.ResponseWriter, r *http.Request) {
 	// Mask all sensitive errors, with the exception of the following
 	defer func() {
@@ -2715,6 +2887,38 @@ func handleDeviceProps(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func attachWebPushSubscription(c *Context, w http.ResponseWriter, r *http.Request) {
+	var subscription model.WebPushSubscription
+	if err := json.NewDecoder(r.Body).Decode(&subscription); err != nil {
+		c.SetInvalidParamWithErr("subscription", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventAttachWebPushSubscription, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if err := c.App.AttachWebPushSubscription(c.AppContext.Session(), &subscription); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func detachWebPushSubscription(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord(model.AuditEventDetachWebPushSubscription, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if err := c.App.DetachWebPushSubscription(c.AppContext.Session()); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
 func attachDeviceIds(c *Context, w http.ResponseWriter, r *http.Request, deviceId, voIPDeviceId string) {
 	auditRec := c.MakeAuditRecord(model.AuditEventAttachDeviceId, model.AuditStatusFail)
 	defer c.LogAuditRec(auditRec)
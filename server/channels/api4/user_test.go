@@ -6,6 +6,8 @@ package api4
 import (
 	"bytes"
 	"context"
+	"crypto/ecdh"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
@@ -4220,6 +4222,200 @@ func TestGenerateMfaSecret(t *testing.T) {
 	CheckUnauthorizedStatus(t, resp)
 }
 
+func TestWebAuthnCredentials(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.SiteURL = "https://mattermost.example.com"
+	})
+
+	t.Run("begin registration", func(t *testing.T) {
+		options, _, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.NotEmpty(t, options.Challenge)
+		assert.Equal(t, "mattermost.example.com", options.RP.Id)
+		assert.Equal(t, th.BasicUser.Username, options.User.Name)
+		assert.Empty(t, options.ExcludeCredentials)
+	})
+
+	t.Run("invalid registration", func(t *testing.T) {
+		_, resp, err := th.Client.FinishWebAuthnRegistration(context.Background(), th.BasicUser.Id, &model.WebAuthnRegistration{Name: "Security key"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = th.Client.FinishWebAuthnRegistration(context.Background(), th.BasicUser.Id, &model.WebAuthnRegistration{
+			Name: "Security key",
+			Credential: &model.WebAuthnAttestation{
+				Type: "public-key",
+				Response: model.WebAuthnAttestationResponse{
+					ClientDataJSON: "e30",
+				},
+			},
+		})
+		require.Error(t, err)
+		CheckErrorID(t, err, "app.webauthn.invalid_response.app_error")
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("get and delete", func(t *testing.T) {
+		credential, err := th.App.Srv().Store().WebAuthnCredential().Save(&model.WebAuthnCredential{
+			UserId:       th.BasicUser.Id,
+			Name:         "Security key",
+			CredentialId: model.NewId(),
+			PublicKey:    []byte{1, 2, 3},
+		})
+		require.NoError(t, err)
+
+		credentials, _, err := th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		assert.Equal(t, credential.Id, credentials[0].Id)
+		assert.Equal(t, "Security key", credentials[0].Name)
+
+		_, resp, err := th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.Client.DeleteWebAuthnCredential(context.Background(), th.BasicUser2.Id, credential.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.Client.DeleteWebAuthnCredential(context.Background(), th.BasicUser.Id, model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		_, err = th.SystemAdminClient.DeleteWebAuthnCredential(context.Background(), th.BasicUser.Id, credential.Id)
+		require.NoError(t, err)
+
+		credentials, _, err = th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Empty(t, credentials)
+	})
+
+	t.Run("deleting the last security key deactivates MFA", func(t *testing.T) {
+		user := th.CreateUser(t)
+		credential, err := th.App.Srv().Store().WebAuthnCredential().Save(&model.WebAuthnCredential{
+			UserId:       user.Id,
+			Name:         "Security key",
+			CredentialId: model.NewId(),
+			PublicKey:    []byte{1, 2, 3},
+		})
+		require.NoError(t, err)
+		require.NoError(t, th.App.Srv().Store().User().UpdateMfaActive(user.Id, true))
+
+		_, err = th.SystemAdminClient.DeleteWebAuthnCredential(context.Background(), user.Id, credential.Id)
+		require.NoError(t, err)
+
+		user, appErr := th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.False(t, user.MfaActive)
+	})
+
+	t.Run("mfa disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+		_, resp, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
+
+func TestBeginWebAuthnLogin(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.SiteURL = "https://mattermost.example.com"
+	})
+
+	credential, err := th.App.Srv().Store().WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       th.BasicUser.Id,
+		Name:         "Security key",
+		CredentialId: model.NewId(),
+		PublicKey:    []byte{1, 2, 3},
+	})
+	require.NoError(t, err)
+	require.NoError(t, th.App.Srv().Store().User().UpdateMfaActive(th.BasicUser.Id, true))
+
+	options, _, err := th.Client.BeginWebAuthnLogin(context.Background(), th.BasicUser.Email)
+	require.NoError(t, err)
+	assert.NotEmpty(t, options.Challenge)
+	assert.Equal(t, "mattermost.example.com", options.RPId)
+	require.Len(t, options.AllowCredentials, 1)
+	assert.Equal(t, credential.CredentialId, options.AllowCredentials[0].Id)
+
+	options, _, err = th.Client.BeginWebAuthnLogin(context.Background(), "unknown@example.com")
+	require.NoError(t, err)
+	assert.NotEmpty(t, options.Challenge)
+	assert.Empty(t, options.AllowCredentials)
+
+	_, resp, err := th.Client.BeginWebAuthnLogin(context.Background(), "")
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	t.Run("invalid assertion", func(t *testing.T) {
+		assertion, err := json.Marshal(&model.WebAuthnAssertion{
+			Id:    credential.CredentialId,
+			RawId: credential.CredentialId,
+			Type:  "public-key",
+		})
+		require.NoError(t, err)
+
+		user, _, err := th.Client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, string(assertion))
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+		assert.Nil(t, user)
+	})
+}
+
+func TestGenerateMfaRecoveryCodes(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMultifactorAuthentication = true })
+
+	_, resp, err := th.Client.GenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id)
+	require.Error(t, err)
+	CheckErrorID(t, err, "app.mfa_recovery_code.mfa_inactive.app_error")
+	CheckBadRequestStatus(t, resp)
+
+	_, resp, err = th.Client.GenerateMfaRecoveryCodes(context.Background(), th.BasicUser2.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	require.NoError(t, th.App.Srv().Store().User().UpdateMfaActive(th.BasicUser.Id, true))
+
+	codes, _, err := th.Client.GenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, codes.RecoveryCodes, model.MfaRecoveryCodeCount)
+
+	client := th.CreateClient()
+
+	t.Run("login with a recovery code", func(t *testing.T) {
+		user, _, err := client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, codes.RecoveryCodes[0])
+		require.NoError(t, err)
+		assert.Equal(t, th.BasicUser.Id, user.Id)
+	})
+
+	t.Run("recovery codes can only be used once", func(t *testing.T) {
+		user, _, err := client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, codes.RecoveryCodes[0])
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+		assert.Nil(t, user)
+	})
+
+	t.Run("generating new codes replaces the old ones", func(t *testing.T) {
+		_, _, err := th.Client.GenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+
+		user, _, err := client.LoginWithMFA(context.Background(), th.BasicUser.Email, th.BasicUser.Password, codes.RecoveryCodes[1])
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+		assert.Nil(t, user)
+	})
+}
+
 func TestUpdateUserPassword(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic(t)
@@ -4975,6 +5171,70 @@ func TestAttachDeviceId(t *testing.T) {
 	})
 }
 
+func TestAttachWebPushSubscription(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	subscription := &model.WebPushSubscription{
+		Endpoint: "https://push.example.com/send/" + model.NewId(),
+		Keys: model.WebPushSubscriptionKeys{
+			P256dh: base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+			Auth:   base64.RawURLEncoding.EncodeToString(make([]byte, 16)),
+		},
+	}
+
+	client := th.CreateClient()
+	th.LoginBasicWithClient(t, client)
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.SendWebPushNotifications = false })
+
+		resp, err := client.AttachWebPushSubscription(context.Background(), subscription)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.EmailSettings.SendWebPushNotifications = true })
+
+	t.Run("invalid subscription", func(t *testing.T) {
+		resp, err := client.AttachWebPushSubscription(context.Background(), &model.WebPushSubscription{Endpoint: "http://push.example.com"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("attach and detach", func(t *testing.T) {
+		_, err := client.AttachWebPushSubscription(context.Background(), subscription)
+		require.NoError(t, err)
+
+		session, appErr := th.App.GetSession(client.AuthToken)
+		require.Nil(t, appErr)
+		assert.Equal(t, subscription, session.GetWebPushSubscription())
+
+		sessions, err := th.Server.Store().Session().GetSessionsWithWebPushSubscriptions(th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, session.Id, sessions[0].Id)
+
+		_, err = client.DetachWebPushSubscription(context.Background())
+		require.NoError(t, err)
+
+		session, appErr = th.App.GetSession(client.AuthToken)
+		require.Nil(t, appErr)
+		assert.Nil(t, session.GetWebPushSubscription())
+	})
+
+	t.Run("not logged in", func(t *testing.T) {
+		_, err := client.Logout(context.Background())
+		require.NoError(t, err)
+
+		resp, err := client.AttachWebPushSubscription(context.Background(), subscription)
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+}
+
 func TestGetUserAudits(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic(t)
@@ -6,6 +6,7 @@ package api4
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -413,16 +414,39 @@ func getPostsForView(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// TODO: In the future, this will filter posts based on the view's configuration
-	// (e.g., property values, sort order). For now, it returns all posts in the channel.
-	options := model.GetPostsOptions{
-		ChannelId: c.Params.ChannelId,
-		Page:      c.Params.Page,
-		PerPage:   c.Params.PerPage,
-		UserId:    c.AppContext.Session().UserId,
+	var list *model.PostList
+	switch view.Type {
+	case model.ViewTypeTable:
+		list, appErr = c.App.GetPostsForTableView(c.AppContext, view, model.ViewPostsQueryOpts{
+			Page:    c.Params.Page,
+			PerPage: c.Params.PerPage,
+		}, c.AppContext.Session().UserId)
+	case model.ViewTypeCalendar:
+		query := r.URL.Query()
+		start, err := strconv.ParseInt(query.Get("start"), 10, 64)
+		if err != nil {
+			c.SetInvalidURLParam("start")
+			return
+		}
+		end, err := strconv.ParseInt(query.Get("end"), 10, 64)
+		if err != nil {
+			c.SetInvalidURLParam("end")
+			return
+		}
+		list, appErr = c.App.GetPostsForCalendarView(c.AppContext, view, model.ViewPostsQueryOpts{
+			Page:    c.Params.Page,
+			PerPage: c.Params.PerPage,
+			Start:   start,
+			End:     end,
+		}, c.AppContext.Session().UserId)
+	default:
+		list, appErr = c.App.GetPostsForView(c.AppContext, model.GetPostsOptions{
+			ChannelId: c.Params.ChannelId,
+			Page:      c.Params.Page,
+			PerPage:   c.Params.PerPage,
+			UserId:    c.AppContext.Session().UserId,
+		})
 	}
-
-	list, appErr := c.App.GetPostsForView(c.AppContext, options)
 	if appErr != nil {
 		c.Err = appErr
 		return
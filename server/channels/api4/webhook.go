@@ -24,6 +24,7 @@ func (api *API) InitWebhook() {
 	api.BaseRoutes.OutgoingHook.Handle("", api.APISessionRequired(updateOutgoingHook)).Methods(http.MethodPut)
 	api.BaseRoutes.OutgoingHook.Handle("", api.APISessionRequired(deleteOutgoingHook)).Methods(http.MethodDelete)
 	api.BaseRoutes.OutgoingHook.Handle("/regen_token", api.APISessionRequired(regenOutgoingHookToken)).Methods(http.MethodPost)
+	api.BaseRoutes.OutgoingHook.Handle("/deliveries", api.APISessionRequired(getOutgoingHookDeliveries)).Methods(http.MethodGet)
 }
 
 func createIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -614,6 +615,39 @@ func getOutgoingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func getOutgoingHookDeliveries(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	hook, appErr := c.App.GetOutgoingWebhook(c.Params.HookId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOwnOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOwnOutgoingWebhooks)
+		return
+	}
+
+	if c.AppContext.Session().UserId != hook.CreatorId && !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), hook.TeamId, model.PermissionManageOthersOutgoingWebhooks) {
+		c.SetPermissionError(model.PermissionManageOthersOutgoingWebhooks)
+		return
+	}
+
+	deliveries, appErr := c.App.GetOutgoingWebhookDeliveries(hook.Id, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(deliveries); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func regenOutgoingHookToken(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireHookId()
 	if c.Err != nil {
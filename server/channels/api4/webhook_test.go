@@ -1296,6 +1296,58 @@ func TestRegenOutgoingHookToken(t *testing.T) {
 	CheckNotImplementedStatus(t, resp)
 }
 
+func TestGetOutgoingHookDeliveries(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = true })
+
+	hook := &model.OutgoingWebhook{ChannelId: th.BasicChannel.Id, TeamId: th.BasicChannel.TeamId, CallbackURLs: []string{"http://nowhere.com"}}
+	rhook, _, err := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), hook)
+	require.NoError(t, err)
+
+	for range 3 {
+		_, err = th.App.Srv().Store().Webhook().SaveOutgoingDelivery(&model.OutgoingWebhookDelivery{
+			HookId:      rhook.Id,
+			PostId:      th.BasicPost.Id,
+			CallbackURL: "http://nowhere.com",
+			ContentType: "application/x-www-form-urlencoded",
+			Payload:     "text=hello",
+		})
+		require.NoError(t, err)
+	}
+
+	deliveries, _, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+	for _, delivery := range deliveries {
+		require.Equal(t, rhook.Id, delivery.HookId)
+		require.Empty(t, delivery.Payload, "payload should not be exposed")
+	}
+
+	deliveries, _, err = th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	_, resp, err := th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), "junk", 0, 10)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), model.NewId(), 0, 10)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	_, resp, err = client.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, 0, 10)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = false })
+	_, resp, err = th.SystemAdminClient.GetOutgoingWebhookDeliveries(context.Background(), rhook.Id, 0, 10)
+	require.Error(t, err)
+	CheckNotImplementedStatus(t, resp)
+}
+
 func TestUpdateOutgoingHook(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic(t)
@@ -4,11 +4,11 @@
 package api4
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
-	"github.com/gorilla/websocket"
-
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/app/platform"
@@ -21,6 +21,8 @@ const (
 	postedAckParam         = "posted_ack"
 	disconnectErrCodeParam = "disconnect_err_code"
 
+	lastEventIDHeader = "Last-Event-ID"
+
 	clientPingTimeoutErrCode      = 4000
 	clientSequenceMismatchErrCode = 4001
 )
@@ -52,16 +54,14 @@ func validateDisconnectErrCode(errCode string) bool {
 func (api *API) InitWebSocket() {
 	// Optionally supports a trailing slash
 	api.BaseRoutes.APIRoot.Handle("/{websocket:websocket(?:\\/)?}", api.APIHandlerTrustRequester(connectWebSocket)).Methods(http.MethodGet)
+
+	// Server-Sent Events fallback for clients that can't upgrade to a websocket.
+	api.BaseRoutes.APIRoot.Handle("/websocket/events", api.APISessionRequired(connectEventStream)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/websocket/events", api.APISessionRequired(postEventStreamAction)).Methods(http.MethodPost)
 }
 
 func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
-	upgrader := websocket.Upgrader{
-		ReadBufferSize:  model.SocketMaxMessageSizeKb,
-		WriteBufferSize: model.SocketMaxMessageSizeKb,
-		CheckOrigin:     c.App.OriginChecker(),
-	}
-
-	ws, err := upgrader.Upgrade(w, r, nil)
+	ws, err := c.App.Srv().Platform().UpgradeWebSocket(w, r, c.App.OriginChecker())
 	if err != nil {
 		params := map[string]any{
 			"BlockedOrigin": r.Header.Get("Origin"),
@@ -123,3 +123,102 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	wc.Pump()
 }
+
+func connectEventStream(c *Context, w http.ResponseWriter, r *http.Request) {
+	cfg := &platform.WebConnConfig{
+		Session:       *c.AppContext.Session(),
+		TFunc:         c.AppContext.T,
+		Locale:        "",
+		Active:        true,
+		PostedAck:     r.URL.Query().Get(postedAckParam) == "true",
+		RemoteAddress: c.AppContext.IPAddress(),
+		XForwardedFor: c.AppContext.XForwardedFor(),
+	}
+
+	disconnectErrCode := r.URL.Query().Get(disconnectErrCodeParam)
+	if codeValid := validateDisconnectErrCode(disconnectErrCode); codeValid {
+		cfg.DisconnectErrCode = disconnectErrCode
+	}
+
+	if c.AppContext.Session().IsMobileApp() {
+		cfg.OriginClient = "mobile"
+	} else {
+		cfg.OriginClient = string(web.GetOriginClient(r))
+	}
+
+	// Browsers resend the ID of the last event they received when reconnecting,
+	// which is made of the connection ID and its sequence number. Clients that
+	// handle reconnections themselves can use the same parameters as for websockets.
+	connectionID := r.URL.Query().Get(connectionIDParam)
+	seqVal := r.URL.Query().Get(sequenceNumberParam)
+	if lastEventID := r.Header.Get(lastEventIDHeader); lastEventID != "" {
+		var err error
+		connectionID, seqVal, err = platform.ParseEventStreamID(lastEventID)
+		if err != nil {
+			c.SetInvalidParamWithErr(lastEventIDHeader, err)
+			return
+		}
+	}
+
+	cfg.ConnectionID = connectionID
+	if cfg.ConnectionID == "" {
+		cfg.ConnectionID = model.NewId()
+	} else {
+		var err error
+		cfg, err = c.App.Srv().Platform().PopulateWebConnConfig(c.AppContext.Session(), cfg, seqVal)
+		if err != nil {
+			c.SetInvalidParamWithErr(connectionIDParam, err)
+			return
+		}
+	}
+
+	es, err := platform.NewEventStream(w, r)
+	if err != nil {
+		c.Logger.Warn("Unable to start event stream", mlog.Err(err))
+		return
+	}
+	cfg.EventStream = es
+
+	wc := c.App.Srv().Platform().NewWebConn(cfg, c.App, c.App.Srv().Channels())
+	err = c.App.Srv().Platform().HubRegister(wc)
+	if err != nil {
+		c.Logger.Error("Error while registering to hub", mlog.String("id", cfg.ConnectionID), mlog.Err(err))
+		es.Close()
+		return
+	}
+
+	wc.Pump()
+}
+
+func postEventStreamAction(c *Context, w http.ResponseWriter, r *http.Request) {
+	connectionID := r.URL.Query().Get(connectionIDParam)
+	if !model.IsValidId(connectionID) {
+		c.SetInvalidURLParam(connectionIDParam)
+		return
+	}
+
+	var req model.WebSocketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.SetInvalidParamWithErr("request", err)
+		return
+	}
+
+	// Only connections established over an event stream accept actions
+	// over HTTP, and only from their own user.
+	wc := c.App.Srv().Platform().GetWebConn(c.AppContext.Session().UserId, connectionID)
+	if wc == nil || !wc.IsEventStream() {
+		c.Err = model.NewAppError("postEventStreamAction", "api.web_socket.event_stream.connection_not_found.app_error", nil, "", http.StatusNotFound)
+		return
+	}
+
+	if err := wc.SubmitRequest(&req); err != nil {
+		if errors.Is(err, platform.ErrEventStreamClosed) {
+			c.Err = model.NewAppError("postEventStreamAction", "api.web_socket.event_stream.connection_not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+			return
+		}
+		c.Err = model.NewAppError("postEventStreamAction", "api.web_socket.event_stream.submit.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	ReturnStatusOK(w)
+}
@@ -4,6 +4,7 @@
 package api4
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"net/http"
@@ -669,3 +670,101 @@ func TestWebSocketMFAEnforcement(t *testing.T) {
 		}
 	})
 }
+
+func TestWebSocketEventStream(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	// readEvent returns the ID and data of the next event in the stream, skipping keep-alive comments.
+	readEvent := func(t *testing.T, scanner *bufio.Scanner) (string, string) {
+		t.Helper()
+		var id, data string
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "" && data != "":
+				return id, data
+			case strings.HasPrefix(line, "id: "):
+				id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				data += strings.TrimPrefix(line, "data: ")
+			}
+		}
+		require.NoError(t, scanner.Err())
+		require.Fail(t, "event stream ended unexpectedly")
+		return "", ""
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resp, err := th.Client.DoAPIGet(ctx, "/websocket/events", "")
+	require.NoError(t, err)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	scanner := bufio.NewScanner(resp.Body)
+
+	id, data := readEvent(t, scanner)
+	hello, err := model.WebSocketEventFromJSON(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, model.WebsocketEventHello, hello.EventType())
+	connID := hello.GetData()["connection_id"].(string)
+	require.Equal(t, connID+":0", id)
+
+	t.Run("post action", func(t *testing.T) {
+		action := &model.WebSocketRequest{
+			Seq:    1,
+			Action: string(model.WebsocketEventTyping),
+			Data:   map[string]any{"channel_id": th.BasicChannel.Id},
+		}
+		actionResp, err := th.Client.DoAPIPostJSON(context.Background(), "/websocket/events?connection_id="+connID, action)
+		require.NoError(t, err)
+		closeBody(actionResp)
+
+		// Responses don't carry an ID.
+		id, data := readEvent(t, scanner)
+		require.Empty(t, id)
+		wsResp, err := model.WebSocketResponseFromJSON(strings.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, model.StatusOk, wsResp.Status)
+		require.Equal(t, int64(1), wsResp.SeqReply)
+	})
+
+	t.Run("post action to unknown connection", func(t *testing.T) {
+		action := &model.WebSocketRequest{Seq: 1, Action: string(model.WebsocketEventTyping)}
+		actionResp, err := th.Client.DoAPIPostJSON(context.Background(), "/websocket/events?connection_id="+model.NewId(), action)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, model.BuildResponse(actionResp))
+	})
+
+	cancel()
+	closeBody(resp)
+
+	t.Run("resume with last event id", func(t *testing.T) {
+		// Wait for the previous connection to be unregistered.
+		require.Eventually(t, func() bool {
+			return th.App.Srv().Platform().GetWebConn(th.BasicUser.Id, connID) == nil
+		}, 5*time.Second, 100*time.Millisecond)
+
+		th.App.Srv().Platform().Publish(model.NewWebSocketEvent(model.WebsocketEventUserUpdated, "", "", th.BasicUser.Id, nil, ""))
+		// Give the hub time to queue the event for the inactive connection.
+		time.Sleep(500 * time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		resp, err := th.Client.DoAPIRequestWithHeaders(ctx, http.MethodGet, "/websocket/events", "", map[string]string{
+			"Last-Event-ID": connID + ":0",
+		})
+		require.NoError(t, err)
+		defer closeBody(resp)
+		scanner := bufio.NewScanner(resp.Body)
+
+		// The stream continues right after the last event that was received.
+		for seq := 1; ; seq++ {
+			id, data := readEvent(t, scanner)
+			require.Equal(t, fmt.Sprintf("%s:%d", connID, seq), id)
+			ev, err := model.WebSocketEventFromJSON(strings.NewReader(data))
+			require.NoError(t, err)
+			if ev.EventType() == model.WebsocketEventUserUpdated {
+				break
+			}
+		}
+	})
+}
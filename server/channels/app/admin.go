@@ -14,6 +14,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/email"
 	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
 )
@@ -171,7 +172,18 @@ func (a *App) TestSiteURL(rctx request.CTX, siteURL string) *model.AppError {
 }
 
 func (a *App) TestEmail(rctx request.CTX, userID string, cfg *model.Config) *model.AppError {
-	if *cfg.EmailSettings.SMTPServer == "" {
+	if *cfg.EmailSettings.MailTransport == model.MailTransportHTTP {
+		if *cfg.EmailSettings.HTTPMailTransportURL == "" {
+			return model.NewAppError("testEmail", "api.admin.test_email.missing_server", nil, i18n.T("api.context.invalid_param.app_error", map[string]any{"Name": "HTTPMailTransportURL"}), http.StatusBadRequest)
+		}
+
+		if *cfg.EmailSettings.HTTPMailTransportAPIKey == model.FakeSetting {
+			if *cfg.EmailSettings.HTTPMailTransportURL != *a.Config().EmailSettings.HTTPMailTransportURL {
+				return model.NewAppError("testEmail", "api.admin.test_email.reenter_password", nil, "", http.StatusBadRequest)
+			}
+			*cfg.EmailSettings.HTTPMailTransportAPIKey = *a.Config().EmailSettings.HTTPMailTransportAPIKey
+		}
+	} else if *cfg.EmailSettings.SMTPServer == "" {
 		return model.NewAppError("testEmail", "api.admin.test_email.missing_server", nil, i18n.T("api.context.invalid_param.app_error", map[string]any{"Name": "SMTPServer"}), http.StatusBadRequest)
 	}
 
@@ -192,9 +204,9 @@ func (a *App) TestEmail(rctx request.CTX, userID string, cfg *model.Config) *mod
 	}
 
 	T := i18n.GetUserTranslations(user.Locale)
-	license := a.Srv().License()
 	mailConfig := a.Srv().MailServiceConfig()
-	if err := mail.SendMailUsingConfig(user.Email, T("api.admin.test_email.subject"), T("api.admin.test_email.body"), mailConfig, license != nil && *license.Features.Compliance, "", "", "", "", ""); err != nil {
+	msg := mail.NewMessage(mailConfig, user.Email, T("api.admin.test_email.subject"), T("api.admin.test_email.body"), "")
+	if err := email.NewMailTransport(cfg, mailConfig).Send(msg); err != nil {
 		return model.NewAppError("testEmail", "app.admin.test_email.failure", map[string]any{"Error": err.Error()}, "", http.StatusInternalServerError)
 	}
 
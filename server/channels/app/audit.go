@@ -159,7 +159,39 @@ func (s *Server) configureAudit(adt *audit.Audit, bAllowAdvancedLogging bool) er
 		cfg.Append(cfgAdditional)
 	}
 
-	return adt.Configure(cfg)
+	if err := adt.Configure(cfg); err != nil {
+		return err
+	}
+
+	return s.configureAuditHashChain(adt)
+}
+
+// configureAuditHashChain enables or disables the hash chain of the audit records, loading
+// the key that signs its checkpoints from the config store.
+func (s *Server) configureAuditHashChain(adt *audit.Audit) error {
+	settings := s.platform.Config().ExperimentalAuditSettings
+	if !*settings.HashChainEnabled {
+		adt.DisableHashChain()
+		return nil
+	}
+
+	opts := audit.HashChainOptions{
+		CheckpointInterval: *settings.HashChainCheckpointInterval,
+	}
+
+	// records are still chained when the key can't be loaded, only the checkpoints are unsigned.
+	var keyErr error
+	if *settings.HashChainSigningKey != "" {
+		data, err := s.platform.GetConfigFile(*settings.HashChainSigningKey)
+		if err != nil {
+			keyErr = fmt.Errorf("cannot read audit hash chain signing key, %w", err)
+		} else if opts.SigningKey, err = audit.ParseSigningKey(data); err != nil {
+			keyErr = fmt.Errorf("invalid audit hash chain signing key, %w", err)
+		}
+	}
+
+	adt.EnableHashChain(opts)
+	return keyErr
 }
 
 func (s *Server) onAuditTargetQueueFull(qname string, maxQSize int) bool {
@@ -363,6 +363,23 @@ func (a *App) CheckUserMfa(rctx request.CTX, user *model.User, token string) *mo
 		return model.NewAppError("CheckUserMfa", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
+	// The token is either a security key assertion, a recovery code or a TOTP code.
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(token), "{"):
+		return a.checkWebAuthnAssertion(rctx, user, token)
+	case mfa.IsRecoveryCode(token):
+		return a.useMfaRecoveryCode(user, token)
+	}
+
+	// Users might only have registered security keys. Clients rely on the error of a
+	// missing token to ask for the second factor, as with TOTP.
+	if user.MfaSecret == "" {
+		if strings.TrimSpace(token) == "" {
+			return model.NewAppError("CheckUserMfa", "mfa.validate_token.authenticate.app_error", nil, "", http.StatusBadRequest)
+		}
+		return model.NewAppError("checkUserMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+	}
+
 	ok, err := mfa.New(a.Srv().Store().User()).ValidateToken(user, token)
 	if err != nil {
 		return model.NewAppError("CheckUserMfa", "mfa.validate_token.authenticate.app_error", nil, "", http.StatusBadRequest).Wrap(err)
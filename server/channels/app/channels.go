@@ -100,6 +100,9 @@ type Channels struct {
 	postReminderMut  sync.Mutex
 	postReminderTask *model.ScheduledTask
 
+	pendingFileScansMut  sync.Mutex
+	pendingFileScansTask *model.ScheduledTask
+
 	interruptQuitChan chan struct{}
 	scheduledPostMut  sync.Mutex
 	scheduledPostTask *model.ScheduledTask
@@ -324,6 +327,8 @@ func (ch *Channels) Stop() error {
 	}
 	ch.dndTaskMut.Unlock()
 
+	cancelTask(&ch.pendingFileScansMut, &ch.pendingFileScansTask)
+
 	close(ch.interruptQuitChan)
 
 	return nil
@@ -20,7 +20,6 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
-	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/templates"
 
 	"github.com/microcosm-cc/bluemonday"
@@ -912,39 +911,45 @@ func (es *Service) sendMail(to, subject, htmlBody, category string) error {
 }
 
 func (es *Service) sendEmailWithCustomReplyTo(to, subject, htmlBody, replyToAddress, category string) error {
-	license := es.license()
-	mailConfig := es.mailServiceConfig(replyToAddress)
-
-	category = getSendGridCategory(category, license.IsCloud())
-
-	return mail.SendMailUsingConfig(to, subject, htmlBody, mailConfig, license != nil && *license.Features.Compliance, "", "", "", "", category)
+	return es.sendOutgoingEmail(&model.OutgoingEmail{
+		To:       to,
+		ReplyTo:  replyToAddress,
+		Subject:  subject,
+		HTMLBody: htmlBody,
+		Category: getSendGridCategory(category, es.license().IsCloud()),
+	}, nil)
 }
 
 func (es *Service) sendMailWithCC(to, subject, htmlBody, ccMail, category string) error {
-	license := es.license()
-	mailConfig := es.mailServiceConfig("")
-
-	category = getSendGridCategory(category, license.IsCloud())
-
-	return mail.SendMailUsingConfig(to, subject, htmlBody, mailConfig, license != nil && *license.Features.Compliance, "", "", "", ccMail, category)
+	return es.sendOutgoingEmail(&model.OutgoingEmail{
+		To:       to,
+		Cc:       ccMail,
+		Subject:  subject,
+		HTMLBody: htmlBody,
+		Category: getSendGridCategory(category, es.license().IsCloud()),
+	}, nil)
 }
 
 func (es *Service) SendMailWithEmbeddedFilesAndCustomReplyTo(to, subject, htmlBody, replyToAddress string, embeddedFiles map[string]io.Reader, category string) error {
-	license := es.license()
-	mailConfig := es.mailServiceConfig(replyToAddress)
-
-	category = getSendGridCategory(category, license.IsCloud())
-
-	return mail.SendMailWithEmbeddedFilesUsingConfig(to, subject, htmlBody, embeddedFiles, mailConfig, license != nil && *license.Features.Compliance, "", "", "", "", category)
+	return es.sendOutgoingEmail(&model.OutgoingEmail{
+		To:       to,
+		ReplyTo:  replyToAddress,
+		Subject:  subject,
+		HTMLBody: htmlBody,
+		Category: getSendGridCategory(category, es.license().IsCloud()),
+	}, embeddedFiles)
 }
 
 func (es *Service) SendMailWithEmbeddedFiles(to, subject, htmlBody string, embeddedFiles map[string]io.Reader, messageID string, inReplyTo string, references string, category string) error {
-	license := es.license()
-	mailConfig := es.mailServiceConfig("")
-
-	category = getSendGridCategory(category, license.IsCloud())
-
-	return mail.SendMailWithEmbeddedFilesUsingConfig(to, subject, htmlBody, embeddedFiles, mailConfig, license != nil && *license.Features.Compliance, messageID, inReplyTo, references, "", category)
+	return es.sendOutgoingEmail(&model.OutgoingEmail{
+		To:         to,
+		Subject:    subject,
+		HTMLBody:   htmlBody,
+		MessageId:  messageID,
+		InReplyTo:  inReplyTo,
+		References: references,
+		Category:   getSendGridCategory(category, es.license().IsCloud()),
+	}, embeddedFiles)
 }
 
 func (es *Service) InvalidateVerifyEmailTokensForUser(userID string) *model.AppError {
@@ -11,18 +11,21 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
 )
 
-const (
-	EmailBatchingTaskName = "Email Batching"
-)
+// maxBatchedEmailAttempts is how many times sending a batched email is attempted before its
+// notifications are dropped.
+const maxBatchedEmailAttempts = 5
 
 type postData struct {
 	SenderName               string
@@ -37,204 +40,220 @@ type postData struct {
 	MessageAttachments       []*EmailMessageAttachment
 }
 
-func (es *Service) InitEmailBatching() {
-	if *es.config().EmailSettings.EnableEmailBatching {
-		if es.EmailBatching == nil {
-			es.EmailBatching = NewEmailBatchingJob(es, *es.config().EmailSettings.EmailBatchingBufferSize)
-		}
-
-		// note that we don't support changing EmailBatchingBufferSize without restarting the server
-
-		es.EmailBatching.Start()
-	}
-}
-
 func (es *Service) AddNotificationEmailToBatch(user *model.User, post *model.Post, team *model.Team) *model.AppError {
 	if !*es.config().EmailSettings.EnableEmailBatching {
 		return model.NewAppError("AddNotificationEmailToBatch", "api.email_batching.add_notification_email_to_batch.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	if !es.EmailBatching.Add(user, post, team) {
-		mlog.Error("Email batching job's receiving buffer was full. Please increase the EmailBatchingBufferSize. Falling back to sending immediate mail.")
-		return model.NewAppError("AddNotificationEmailToBatch", "api.email_batching.add_notification_email_to_batch.channel_full.app_error", nil, "", http.StatusInternalServerError)
+	if err := es.EmailBatching.Add(user, post, team); err != nil {
+		mlog.Error("Unable to queue the notification for batched email. Falling back to sending immediate mail.", mlog.String("user_id", user.Id), mlog.String("post_id", post.Id), mlog.Err(err))
+		return model.NewAppError("AddNotificationEmailToBatch", "api.email_batching.add_notification_email_to_batch.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
 	return nil
 }
 
+// SendBatchedEmailNotifications sends the batched emails of every user whose
+// batching interval has passed since their oldest pending notification.
+func (es *Service) SendBatchedEmailNotifications() error {
+	return es.EmailBatching.CheckPendingEmails()
+}
+
 type batchedNotification struct {
 	userID   string
 	post     *model.Post
+	teamID   string
 	teamName string
 }
 
+// EmailBatchingJob queues notifications in the store and sends them as a single
+// email once the user's batching interval has passed. Since the pending
+// notifications are persisted, they survive restarts and are sent by whichever
+// node runs the email batching job.
 type EmailBatchingJob struct {
 	config  func() *model.Config
 	service *Service
-
-	newNotifications     chan *batchedNotification
-	pendingNotifications map[string][]*batchedNotification
-	task                 *model.ScheduledTask
-	taskMutex            sync.Mutex
 }
 
-func NewEmailBatchingJob(es *Service, bufferSize int) *EmailBatchingJob {
+func NewEmailBatchingJob(es *Service) *EmailBatchingJob {
 	return &EmailBatchingJob{
-		config:               es.config,
-		service:              es,
-		newNotifications:     make(chan *batchedNotification, bufferSize),
-		pendingNotifications: make(map[string][]*batchedNotification),
+		config:  es.config,
+		service: es,
 	}
 }
 
-func (job *EmailBatchingJob) Start() {
-	mlog.Debug("Email batching job starting. Checking for pending emails periodically.", mlog.Int("interval_in_seconds", *job.config().EmailSettings.EmailBatchingInterval))
-	newTask := model.CreateRecurringTask(EmailBatchingTaskName, job.CheckPendingEmails, time.Duration(*job.config().EmailSettings.EmailBatchingInterval)*time.Second)
-
-	job.taskMutex.Lock()
-	oldTask := job.task
-	job.task = newTask
-	job.taskMutex.Unlock()
-
-	if oldTask != nil {
-		oldTask.Cancel()
-	}
+func (job *EmailBatchingJob) Add(user *model.User, post *model.Post, team *model.Team) error {
+	_, err := job.service.store.PendingEmailNotification().Save(&model.PendingEmailNotification{
+		UserId: user.Id,
+		PostId: post.Id,
+		TeamId: team.Id,
+	})
+	return err
 }
 
-// Stop will cancel the task properly, flushing out any pending notifications.
-// Although this still won't send those notifications which are yet to be sent
-// due to a user's PreferenceNameEmailInterval.
-func (job *EmailBatchingJob) Stop() {
-	job.taskMutex.Lock()
-	if task := job.task; task != nil {
-		task.Cancel()
-	}
-	job.taskMutex.Unlock()
+func (job *EmailBatchingJob) CheckPendingEmails() error {
+	// it's a bit weird to pass the send email function through here, but it makes it so that we can test
+	// without actually sending emails
+	return job.checkPendingNotifications(time.Now(), job.service.sendBatchedEmailNotification)
 }
 
-func (job *EmailBatchingJob) Add(user *model.User, post *model.Post, team *model.Team) bool {
-	notification := &batchedNotification{
-		userID:   user.Id,
-		post:     post,
-		teamName: team.Name,
+func (job *EmailBatchingJob) checkPendingNotifications(now time.Time, handler func(string, []*batchedNotification) error) error {
+	batchStartTimes, err := job.service.store.PendingEmailNotification().GetBatchStartTimes()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the pending batched email notifications")
 	}
 
-	select {
-	case job.newNotifications <- notification:
-		return true
-	default:
-		// return false if we couldn't queue the email notification so that we can send an immediate email
-		return false
-	}
-}
-
-func (job *EmailBatchingJob) CheckPendingEmails() {
-	job.handleNewNotifications()
+	for userID, batchStartTime := range batchStartTimes {
+		// Ignore if it isn't time yet to send.
+		if now.Sub(time.UnixMilli(batchStartTime)) <= job.getBatchingInterval(userID) {
+			continue
+		}
 
-	// it's a bit weird to pass the send email function through here, but it makes it so that we can test
-	// without actually sending emails
-	job.checkPendingNotifications(time.Now(), job.service.sendBatchedEmailNotification)
+		pending, err := job.service.store.PendingEmailNotification().GetForUser(userID)
+		if err != nil {
+			mlog.Error("Unable to get pending notifications for batched email", mlog.String("user_id", userID), mlog.Err(err))
+			continue
+		}
 
-	mlog.Debug("Email batching job ran. Notifications might be still pending.", mlog.Int("number_of_users", len(job.pendingNotifications)))
-}
+		notifications, err := job.loadNotifications(pending)
+		if err != nil {
+			mlog.Error("Unable to load pending notifications for batched email", mlog.String("user_id", userID), mlog.Err(err))
+			continue
+		}
 
-func (job *EmailBatchingJob) handleNewNotifications() {
-	receiving := true
+		// If the user has viewed any channels in these teams since the notifications were queued,
+		// don't send them
+		if len(notifications) > 0 && job.hasViewedChannelsSince(userID, notifications, batchStartTime) {
+			mlog.Debug("Deleted notifications for user", mlog.String("user_id", userID))
+			notifications = nil
+		}
 
-	// read in new notifications to send
-	for receiving {
-		select {
-		case notification := <-job.newNotifications:
-			userID := notification.userID
+		ids := make([]string, 0, len(pending))
+		attempts := 0
+		for _, notification := range pending {
+			ids = append(ids, notification.Id)
+			attempts = max(attempts, notification.Attempts)
+		}
 
-			if _, ok := job.pendingNotifications[userID]; !ok {
-				job.pendingNotifications[userID] = []*batchedNotification{notification}
-			} else {
-				job.pendingNotifications[userID] = append(job.pendingNotifications[userID], notification)
+		if len(notifications) > 0 {
+			if err := handler(userID, notifications); err != nil {
+				// The notifications are kept to be sent on the next run, unless the email
+				// can't ever be sent or ran out of attempts.
+				if !mail.IsPermanentError(err) && attempts+1 < maxBatchedEmailAttempts {
+					mlog.Warn("Unable to send batched email notification, it will be retried", mlog.String("user_id", userID), mlog.Err(err))
+					if err := job.service.store.PendingEmailNotification().IncrementAttempts(ids); err != nil {
+						mlog.Error("Unable to record the failed attempt at sending batched email", mlog.String("user_id", userID), mlog.Err(err))
+					}
+					continue
+				}
+				mlog.Error("Unable to send batched email notification, dropping it", mlog.String("user_id", userID), mlog.Int("attempts", attempts+1), mlog.Err(err))
 			}
-		default:
-			receiving = false
 		}
-	}
-}
 
-func (job *EmailBatchingJob) checkPendingNotifications(now time.Time, handler func(string, []*batchedNotification)) {
-	for userID, notifications := range job.pendingNotifications {
-		// Defensive code.
-		if len(notifications) == 0 {
-			mlog.Warn("Unexpected result. Got 0 pending notifications for batched email.", mlog.String("user_id", userID))
-			continue
+		if _, err := job.service.store.PendingEmailNotification().Delete(ids); err != nil {
+			mlog.Error("Unable to delete pending notifications for batched email", mlog.String("user_id", userID), mlog.Err(err))
 		}
+	}
 
-		// get how long we need to wait to send notifications to the user
-		var interval int64
-		preference, err := job.service.store.Preference().Get(userID, model.PreferenceCategoryNotifications, model.PreferenceNameEmailInterval)
-		if err != nil {
-			// use the default batching interval if an error occurs while fetching user preferences
+	mlog.Debug("Email batching job ran. Notifications might be still pending.", mlog.Int("number_of_users", len(batchStartTimes)))
+
+	return nil
+}
+
+// getBatchingInterval returns how long we need to wait to send notifications to the user.
+func (job *EmailBatchingJob) getBatchingInterval(userID string) time.Duration {
+	var interval int64
+	preference, err := job.service.store.Preference().Get(userID, model.PreferenceCategoryNotifications, model.PreferenceNameEmailInterval)
+	if err != nil {
+		// use the default batching interval if an error occurs while fetching user preferences
+		interval, _ = strconv.ParseInt(model.PreferenceEmailIntervalBatchingSeconds, 10, 64)
+	} else {
+		if value, err := strconv.ParseInt(preference.Value, 10, 64); err != nil {
+			// use the default batching interval if an error occurs while deserializing user preferences
 			interval, _ = strconv.ParseInt(model.PreferenceEmailIntervalBatchingSeconds, 10, 64)
 		} else {
-			if value, err := strconv.ParseInt(preference.Value, 10, 64); err != nil {
-				// // use the default batching interval if an error occurs while deserializing user preferences
-				interval, _ = strconv.ParseInt(model.PreferenceEmailIntervalBatchingSeconds, 10, 64)
-			} else {
-				interval = value
-			}
+			interval = value
 		}
+	}
 
-		batchStartTime := notifications[0].post.CreateAt
-		// Ignore if it isn't time yet to send.
-		if now.Sub(time.UnixMilli(batchStartTime)) <= time.Duration(interval)*time.Second {
-			continue
-		}
+	return time.Duration(interval) * time.Second
+}
 
-		// If the user has viewed any channels in this team since the notification was queued, delete
-		// all queued notifications
-		inspectedTeamNames := make(map[string]string)
-		for _, notification := range notifications {
-			// at most, we'll do one check for each team that notifications were sent for
-			if inspectedTeamNames[notification.teamName] != "" {
-				continue
-			}
+// loadNotifications fetches the posts and teams of the pending notifications, skipping
+// those whose post has been deleted since the notification was queued.
+func (job *EmailBatchingJob) loadNotifications(pending []*model.PendingEmailNotification) ([]*batchedNotification, error) {
+	postIDs := make([]string, 0, len(pending))
+	for _, notification := range pending {
+		postIDs = append(postIDs, notification.PostId)
+	}
 
-			team, nErr := job.service.store.Team().GetByName(notifications[0].teamName)
-			if nErr != nil {
-				mlog.Error("Unable to find Team id for notification", mlog.Err(nErr))
-				continue
-			}
+	posts, err := job.service.store.Post().GetPostsByIds(postIDs)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get the posts of the notifications")
+	}
 
-			if team != nil {
-				inspectedTeamNames[notification.teamName] = team.Id
-			}
+	postsByID := make(map[string]*model.Post, len(posts))
+	for _, post := range posts {
+		postsByID[post.Id] = post
+	}
+
+	teamNames := make(map[string]string)
+	notifications := make([]*batchedNotification, 0, len(pending))
+	for _, notification := range pending {
+		post, ok := postsByID[notification.PostId]
+		if !ok || post.DeleteAt != 0 {
+			continue
+		}
 
-			channelMembers, err := job.service.store.Channel().GetMembersForUser(inspectedTeamNames[notification.teamName], userID)
+		teamName, ok := teamNames[notification.TeamId]
+		if !ok {
+			team, err := job.service.store.Team().Get(notification.TeamId)
 			if err != nil {
-				mlog.Error("Unable to find ChannelMembers for user", mlog.Err(err))
+				mlog.Error("Unable to find Team for notification", mlog.String("team_id", notification.TeamId), mlog.Err(err))
 				continue
 			}
+			teamName = team.Name
+			teamNames[notification.TeamId] = teamName
+		}
 
-			deleted := false
-			for _, channelMember := range channelMembers {
-				if channelMember.LastViewedAt >= batchStartTime {
-					mlog.Debug("Deleted notifications for user", mlog.String("user_id", userID))
-					delete(job.pendingNotifications, userID)
-					deleted = true
-					break
-				}
-			}
-			if deleted {
-				break
-			}
+		notifications = append(notifications, &batchedNotification{
+			userID:   notification.UserId,
+			post:     post,
+			teamID:   notification.TeamId,
+			teamName: teamName,
+		})
+	}
+
+	return notifications, nil
+}
+
+func (job *EmailBatchingJob) hasViewedChannelsSince(userID string, notifications []*batchedNotification, since int64) bool {
+	inspectedTeamIDs := make(map[string]bool)
+	for _, notification := range notifications {
+		// at most, we'll do one check for each team that notifications were sent for
+		if inspectedTeamIDs[notification.teamID] {
+			continue
 		}
+		inspectedTeamIDs[notification.teamID] = true
 
-		// The notifications might have been cleared from the above step.
-		// We need to check again.
-		if len(job.pendingNotifications[userID]) == 0 {
+		channelMembers, err := job.service.store.Channel().GetMembersForUser(notification.teamID, userID)
+		if err != nil {
+			mlog.Error("Unable to find ChannelMembers for user", mlog.Err(err))
 			continue
 		}
 
-		handler(userID, job.pendingNotifications[userID])
-		delete(job.pendingNotifications, userID)
+		for _, channelMember := range channelMembers {
+			if channelMember.LastViewedAt >= since {
+				return true
+			}
+		}
 	}
+
+	return false
 }
 
 /**
@@ -249,11 +268,10 @@ func truncateUserNames(name string, i int) string {
 	return name
 }
 
-func (es *Service) sendBatchedEmailNotification(userID string, notifications []*batchedNotification) {
+func (es *Service) sendBatchedEmailNotification(userID string, notifications []*batchedNotification) error {
 	user, err := es.userService.GetUser(userID)
 	if err != nil {
-		mlog.Warn("Unable to find recipient for batched email notification")
-		return
+		return errors.Wrap(err, "unable to find recipient for batched email notification")
 	}
 
 	translateFunc := i18n.GetUserTranslations(user.Locale)
@@ -374,6 +392,8 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 	}
 
 	if nErr := es.SendMailWithEmbeddedFiles(user.Email, subject, renderedPage, embeddedFiles, "", "", "", "BatchedEmailNotification"); nErr != nil {
-		mlog.Warn("Unable to send batched email notification", mlog.String("email", user.Email), mlog.Err(nErr))
+		return errors.Wrapf(nErr, "unable to send batched email notification to %s", user.Email)
 	}
+
+	return nil
 }
@@ -4,7 +4,7 @@
 package email
 
 import (
-	"sync/atomic"
+	"errors"
 	"testing"
 	"time"
 
@@ -12,274 +12,314 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
 )
 
-func TestHandleNewNotifications(t *testing.T) {
-	mainHelper.Parallel(t)
-
-	th := SetupWithStoreMock(t)
-
-	id1 := model.NewId()
-	id2 := model.NewId()
-	id3 := model.NewId()
-
-	// test queueing of received posts by user
-	job := NewEmailBatchingJob(th.service, 128)
-
-	job.handleNewNotifications()
-
-	require.Empty(t, job.pendingNotifications, "shouldn't have added any pending notifications")
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	require.Empty(t, job.pendingNotifications, "shouldn't have added any pending notifications")
-
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 1, "should have received posts for 1 user")
-	require.Len(t, job.pendingNotifications[id1], 1, "should have received 1 post for user")
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 1, "should have received posts for 1 user")
-	require.Len(t, job.pendingNotifications[id1], 2, "should have received 2 posts for user1")
-
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 2, "should have received posts for 2 users")
-	require.Len(t, job.pendingNotifications[id1], 2, "should have received 2 posts for user1")
-	require.Len(t, job.pendingNotifications[id2], 1, "should have received 1 post for user2")
-
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id2, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id3, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id3}, &model.Post{UserId: id3, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id2, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 3, "should have received posts for 3 users")
-	require.Len(t, job.pendingNotifications[id1], 3, "should have received 3 posts for user1")
-	require.Len(t, job.pendingNotifications[id2], 3, "should have received 3 posts for user2")
-	require.Len(t, job.pendingNotifications[id3], 1, "should have received 1 post for user3")
-
-	// test ordering of received posts
-	job = NewEmailBatchingJob(th.service, 128)
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test1"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test2"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test3"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test4"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test5"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	assert.Equal(t, job.pendingNotifications[id1][0].post.Message, "test1", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id1][1].post.Message, "test2", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id1][2].post.Message, "test4", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id2][0].post.Message, "test3", "incorrect order of received posts for user2")
-	assert.Equal(t, job.pendingNotifications[id2][1].post.Message, "test5", "incorrect order of received posts for user2")
-}
+// queueNotification creates a post in the basic channel and queues a notification
+// about it for the basic user, as if it had been queued at the given time.
+func queueNotification(tb testing.TB, th *TestHelper, createAt int64, message string) *model.Post {
+	post, err := th.store.Post().Save(th.Context, &model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		CreateAt:  createAt,
+		Message:   message,
+	})
+	require.NoError(tb, err)
 
-func TestCheckPendingNotifications(t *testing.T) {
-	mainHelper.Parallel(t)
-	th := Setup(t).InitBasic(t)
+	_, err = th.store.PendingEmailNotification().Save(&model.PendingEmailNotification{
+		UserId:   th.BasicUser.Id,
+		PostId:   post.Id,
+		TeamId:   th.BasicTeam.Id,
+		CreateAt: createAt,
+	})
+	require.NoError(tb, err)
 
-	job := NewEmailBatchingJob(th.service, 128)
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
+	return post
+}
 
+func setLastViewedAt(tb testing.TB, th *TestHelper, lastViewedAt int64) {
 	channelMember, err := th.store.Channel().GetMember(th.Context, th.BasicChannel.Id, th.BasicUser.Id)
-	require.NoError(t, err)
-	channelMember.LastViewedAt = 9999999
+	require.NoError(tb, err)
+	channelMember.LastViewedAt = lastViewedAt
 	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
-	require.NoError(t, err)
+	require.NoError(tb, err)
+}
 
-	nErr := th.store.Preference().Save(model.Preferences{{
+func setEmailInterval(tb testing.TB, th *TestHelper, value string) {
+	err := th.store.Preference().Save(model.Preferences{{
 		UserId:   th.BasicUser.Id,
 		Category: model.PreferenceCategoryNotifications,
 		Name:     model.PreferenceNameEmailInterval,
-		Value:    "60",
+		Value:    value,
 	}})
-	require.NoError(t, nErr)
+	require.NoError(tb, err)
+}
+
+// The tests below aren't run in parallel since the job goes through the pending
+// notifications of every user.
+
+func TestAddNotificationEmailToBatch(t *testing.T) {
+	th := Setup(t).InitBasic(t)
+
+	post := &model.Post{Id: model.NewId(), UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel.Id}
+
+	t.Run("should fail when email batching is disabled", func(t *testing.T) {
+		th.UpdateConfig(t, func(cfg *model.Config) {
+			*cfg.EmailSettings.EnableEmailBatching = false
+		})
+
+		appErr := th.service.AddNotificationEmailToBatch(th.BasicUser, post, th.BasicTeam)
+		require.NotNil(t, appErr)
+
+		pending, err := th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+
+	t.Run("should persist the notification", func(t *testing.T) {
+		th.UpdateConfig(t, func(cfg *model.Config) {
+			*cfg.EmailSettings.EnableEmailBatching = true
+		})
+
+		appErr := th.service.AddNotificationEmailToBatch(th.BasicUser, post, th.BasicTeam)
+		require.Nil(t, appErr)
+		appErr = th.service.AddNotificationEmailToBatch(th.BasicUser2, post, th.BasicTeam)
+		require.Nil(t, appErr)
+
+		pending, err := th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, post.Id, pending[0].PostId)
+		assert.Equal(t, th.BasicTeam.Id, pending[0].TeamId)
+
+		pending, err = th.store.PendingEmailNotification().GetForUser(th.BasicUser2.Id)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+	})
+}
+
+func TestCheckPendingNotifications(t *testing.T) {
+	th := Setup(t).InitBasic(t)
+
+	job := NewEmailBatchingJob(th.service)
+	queueNotification(t, th, 10000000, "")
+	setLastViewedAt(t, th, 9999999)
+	setEmailInterval(t, th, "60")
 
 	// test that notifications aren't sent before interval
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
+	err := job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) error { return nil })
+	require.NoError(t, err)
 
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	pending, err := th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "shouldn't have sent queued post")
 
 	// test that notifications are cleared if the user has acted
-	channelMember, err = th.store.Channel().GetMember(th.Context, th.BasicChannel.Id, th.BasicUser.Id)
+	setLastViewedAt(t, th, 10001000)
+
+	// We reset the interval to something shorter
+	setEmailInterval(t, th, "10")
+
+	wasCalled := false
+	err = job.checkPendingNotifications(time.Unix(10050, 0), func(userID string, _ []*batchedNotification) error {
+		wasCalled = wasCalled || userID == th.BasicUser.Id
+		return nil
+	})
 	require.NoError(t, err)
-	channelMember.LastViewedAt = 10001000
-	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
+	require.False(t, wasCalled, "email handler should not have been called")
+
+	pending, err = th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
 	require.NoError(t, err)
+	require.Empty(t, pending, "should've remove queued post since user acted")
 
-	// We reset the interval to something shorter
-	nErr = th.store.Preference().Save(model.Preferences{{
-		UserId:   th.BasicUser.Id,
-		Category: model.PreferenceCategoryNotifications,
-		Name:     model.PreferenceNameEmailInterval,
-		Value:    "10",
-	}})
-	require.NoError(t, nErr)
+	// test that notifications are sent if enough time passes since the first message
+	queueNotification(t, th, 10060000, "post1")
+	queueNotification(t, th, 10090000, "post2")
 
-	var wasCalled atomic.Int32
-	job.checkPendingNotifications(time.Unix(10050, 0), func(string, []*batchedNotification) {
-		wasCalled.Store(int32(1))
+	var received []*batchedNotification
+	err = job.checkPendingNotifications(time.Unix(10130, 0), func(userID string, notifications []*batchedNotification) error {
+		if userID == th.BasicUser.Id {
+			received = append(received, notifications...)
+		}
+		return nil
 	})
+	require.NoError(t, err)
 
-	// A hack to check whether the handler was called.
-	// It's not straightforward to just wait for it using a channel because the test should
-	// NOT call the handler, and it will be called only if the test fails.
-	time.Sleep(1 * time.Second)
-	// We do a check outside the email handler, because otherwise, failing from
-	// inside the handler doesn't let the .Go() function exit cleanly, and it gets
-	// stuck during server shutdown, trying to wait for the goroutine to exit
-	require.Equal(t, int32(0), wasCalled.Load(), "email handler should not have been called")
+	require.Len(t, received, 2)
+	require.Equal(t, "post1", received[0].post.Message, "should've received post1 first")
+	require.Equal(t, "post2", received[1].post.Message, "should've received post2 second")
+	require.Equal(t, th.BasicTeam.Name, received[0].teamName)
 
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Empty(t, job.pendingNotifications[th.BasicUser.Id], "should've remove queued post since user acted")
+	pending, err = th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Empty(t, pending, "should've removed the sent notifications")
 
-	// test that notifications are sent if enough time passes since the first message
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10060000,
-				Message:   "post1",
-			},
-			teamName: th.BasicTeam.Name,
-		},
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10090000,
-				Message:   "post2",
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
-
-	received := make(chan *model.Post, 2)
-
-	job.checkPendingNotifications(time.Unix(10130, 0), func(s string, notifications []*batchedNotification) {
-		for _, notification := range notifications {
-			received <- notification.post
+	// test that the same notifications aren't sent twice
+	wasCalled = false
+	err = job.checkPendingNotifications(time.Unix(10200, 0), func(userID string, _ []*batchedNotification) error {
+		wasCalled = wasCalled || userID == th.BasicUser.Id
+		return nil
+	})
+	require.NoError(t, err)
+	require.False(t, wasCalled, "email handler should not have been called again")
+}
+
+func TestCheckPendingNotificationsDeletedPost(t *testing.T) {
+	th := Setup(t).InitBasic(t)
+
+	job := NewEmailBatchingJob(th.service)
+	setLastViewedAt(t, th, 9999000)
+
+	post := queueNotification(t, th, 20000000, "deleted")
+	queueNotification(t, th, 20001000, "kept")
+
+	err := th.store.Post().Delete(th.Context, post.Id, model.GetMillis(), th.BasicUser2.Id)
+	require.NoError(t, err)
+
+	var received []*batchedNotification
+	err = job.checkPendingNotifications(time.Unix(21000, 0), func(userID string, notifications []*batchedNotification) error {
+		if userID == th.BasicUser.Id {
+			received = append(received, notifications...)
 		}
+		return nil
 	})
+	require.NoError(t, err)
 
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id], "shouldn't have sent queued post")
-
-	select {
-	case post := <-received:
-		require.Equal(t, post.Message, "post1", "should've received post1 first")
-	case <-time.After(5 * time.Second):
-		require.Fail(t, "timed out waiting for first post notification")
-	}
-
-	select {
-	case post := <-received:
-		require.Equal(t, post.Message, "post2", "should've received post2 second")
-	case <-time.After(5 * time.Second):
-		require.Fail(t, "timed out waiting for second post notification")
-	}
+	require.Len(t, received, 1, "shouldn't have sent the deleted post")
+	require.Equal(t, "kept", received[0].post.Message)
+}
+
+func TestCheckPendingNotificationsFailedSend(t *testing.T) {
+	th := Setup(t).InitBasic(t)
+
+	job := NewEmailBatchingJob(th.service)
+	setLastViewedAt(t, th, 9999000)
+	queueNotification(t, th, 30000000, "retried")
+
+	err := job.checkPendingNotifications(time.Unix(31000, 0), func(string, []*batchedNotification) error {
+		return errors.New("smtp server unavailable")
+	})
+	require.NoError(t, err)
+
+	pending, err := th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "should've kept the notification that failed to be sent")
+	require.Equal(t, 1, pending[0].Attempts)
+
+	var received []*batchedNotification
+	err = job.checkPendingNotifications(time.Unix(31100, 0), func(userID string, notifications []*batchedNotification) error {
+		if userID == th.BasicUser.Id {
+			received = append(received, notifications...)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, received, 1)
+	require.Equal(t, "retried", received[0].post.Message)
+
+	pending, err = th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Empty(t, pending, "should've removed the sent notification")
+}
+
+func TestCheckPendingNotificationsDroppedSend(t *testing.T) {
+	t.Run("permanent error", func(t *testing.T) {
+		th := Setup(t).InitBasic(t)
+
+		job := NewEmailBatchingJob(th.service)
+		setLastViewedAt(t, th, 9999000)
+		queueNotification(t, th, 30000000, "rejected")
+
+		err := job.checkPendingNotifications(time.Unix(31000, 0), func(string, []*batchedNotification) error {
+			return &mail.PermanentError{Err: errors.New("550 mailbox unavailable")}
+		})
+		require.NoError(t, err)
+
+		pending, err := th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Empty(t, pending, "should've dropped the notification that can't be sent")
+	})
+
+	t.Run("out of attempts", func(t *testing.T) {
+		th := Setup(t).InitBasic(t)
+
+		job := NewEmailBatchingJob(th.service)
+		setLastViewedAt(t, th, 9999000)
+		queueNotification(t, th, 30000000, "failing")
+
+		for i := range maxBatchedEmailAttempts {
+			pending, err := th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+			require.NoError(t, err)
+			require.Len(t, pending, 1, "should've kept the notification after %d attempts", i)
+
+			err = job.checkPendingNotifications(time.Unix(31000, 0), func(string, []*batchedNotification) error {
+				return errors.New("smtp server unavailable")
+			})
+			require.NoError(t, err)
+		}
+
+		pending, err := th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Empty(t, pending, "should've dropped the notification after the last attempt")
+	})
 }
 
 /**
  * Ensures that email batch interval defaults to 15 minutes for users that haven't explicitly set this preference
  */
 func TestCheckPendingNotificationsDefaultInterval(t *testing.T) {
-	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic(t)
 
-	job := NewEmailBatchingJob(th.service, 128)
+	job := NewEmailBatchingJob(th.service)
 
 	// bypasses recent user activity check
-	require.NotNil(t, th.store)
-	require.NotNil(t, th.store.Channel())
+	setLastViewedAt(t, th, 9999000)
 
-	require.NotNil(t, th.BasicUser)
-	require.NotNil(t, th.BasicChannel)
-	channelMember, err := th.store.Channel().GetMember(th.Context, th.BasicChannel.Id, th.BasicUser.Id)
-	require.NoError(t, err)
-	channelMember.LastViewedAt = 9999000
-	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
-	require.NoError(t, err)
-
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
+	queueNotification(t, th, 10000000, "")
 
 	// notifications should not be sent 1s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	err := job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) error { return nil })
+	require.NoError(t, err)
+	pending, err := th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "shouldn't have sent queued post")
 
 	// notifications should be sent 901s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) {})
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Empty(t, job.pendingNotifications[th.BasicUser.Id], "should have sent queued post")
+	err = job.checkPendingNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) error { return nil })
+	require.NoError(t, err)
+	pending, err = th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Empty(t, pending, "should have sent queued post")
 }
 
 /**
  * Ensures that email batch interval defaults to 15 minutes if user preference is invalid
  */
 func TestCheckPendingNotificationsCantParseInterval(t *testing.T) {
-	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic(t)
 
-	job := NewEmailBatchingJob(th.service, 128)
+	job := NewEmailBatchingJob(th.service)
 
-	require.NotNil(t, th.store)
-	require.NotNil(t, th.store.Channel())
-	require.NotNil(t, th.BasicChannel)
-	require.NotNil(t, th.BasicUser)
 	// bypasses recent user activity check
-	channelMember, err := th.store.Channel().GetMember(th.Context, th.BasicChannel.Id, th.BasicUser.Id)
-	require.NoError(t, err)
-	channelMember.LastViewedAt = 9999000
-	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
-	require.NoError(t, err)
+	setLastViewedAt(t, th, 9999000)
 
 	// preference value is not an integer, so we'll fall back to the default 15min value
-	nErr := th.store.Preference().Save(model.Preferences{{
-		UserId:   th.BasicUser.Id,
-		Category: model.PreferenceCategoryNotifications,
-		Name:     model.PreferenceNameEmailInterval,
-		Value:    "notAnIntegerValue",
-	}})
-	require.NoError(t, nErr)
-
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
+	setEmailInterval(t, th, "notAnIntegerValue")
+
+	queueNotification(t, th, 10000000, "")
 
 	// notifications should not be sent 1s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	err := job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) error { return nil })
+	require.NoError(t, err)
+	pending, err := th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "shouldn't have sent queued post")
 
 	// notifications should be sent 901s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) {})
-
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id], "should have sent queued post")
+	err = job.checkPendingNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) error { return nil })
+	require.NoError(t, err)
+	pending, err = th.store.PendingEmailNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	require.Empty(t, pending, "should have sent queued post")
 }
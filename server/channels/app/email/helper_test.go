@@ -115,6 +115,8 @@ func setupTestHelper(s store.Store, tb testing.TB) *TestHelper {
 		templatesContainer: htmlTemplates,
 	}
 
+	service.EmailBatching = NewEmailBatchingJob(service)
+
 	err = service.setUpRateLimiters()
 	require.NoError(tb, err)
 
@@ -0,0 +1,178 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package email
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
+)
+
+const outgoingEmailQueueBatchSize = 100
+
+// NewMailTransport returns the transport emails are sent with according to the given
+// configuration, using the given SMTP settings when sending them to an SMTP server.
+func NewMailTransport(cfg *model.Config, smtpConfig *mail.SMTPConfig) mail.Transport {
+	return newMailTransport(cfg, smtpConfig, nil)
+}
+
+// newMailTransport is like NewMailTransport, but sends the emails of the HTTP mail
+// transport with the given client, if any.
+func newMailTransport(cfg *model.Config, smtpConfig *mail.SMTPConfig, client *http.Client) mail.Transport {
+	emailSettings := cfg.EmailSettings
+	if *emailSettings.MailTransport == model.MailTransportHTTP {
+		if client == nil {
+			client = &http.Client{Timeout: mailHTTPClientTimeout(cfg)}
+		}
+		return mail.NewHTTPTransport(client, *emailSettings.HTTPMailTransportURL, *emailSettings.HTTPMailTransportAPIKey, smtpConfig.Hostname)
+	}
+	return mail.NewSMTPTransport(smtpConfig)
+}
+
+func mailHTTPClientTimeout(cfg *model.Config) time.Duration {
+	return time.Duration(*cfg.EmailSettings.SMTPServerTimeout) * time.Second
+}
+
+// getMailHTTPClient returns the client the HTTP mail transport sends emails with, so that
+// its connections are reused across emails.
+func (es *Service) getMailHTTPClient(cfg *model.Config) *http.Client {
+	es.mailHTTPClientMut.Lock()
+	defer es.mailHTTPClientMut.Unlock()
+
+	timeout := mailHTTPClientTimeout(cfg)
+	if es.mailHTTPClient == nil || es.mailHTTPClient.Timeout != timeout {
+		es.mailHTTPClient = &http.Client{Timeout: timeout}
+	}
+	return es.mailHTTPClient
+}
+
+// sendOutgoingEmail makes a first attempt at sending an email. If it fails because of a
+// transient error, such as the mail server being unreachable, and the mail queue is
+// enabled, the email is queued to be retried later and no error is returned.
+func (es *Service) sendOutgoingEmail(email *model.OutgoingEmail, embeddedFiles map[string]io.Reader) error {
+	if len(embeddedFiles) > 0 {
+		// The files are read once so that they can be sent again if needed.
+		email.EmbeddedFiles = make(model.OutgoingEmailFiles, len(embeddedFiles))
+		for name, reader := range embeddedFiles {
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read embedded file %s", name)
+			}
+			email.EmbeddedFiles[name] = data
+		}
+	}
+
+	err := es.deliverOutgoingEmail(email)
+	if err == nil || mail.IsPermanentError(err) || !*es.config().EmailSettings.EnableMailQueue {
+		return err
+	}
+
+	es.recordFailedDelivery(email, err)
+	if _, saveErr := es.store.OutgoingEmail().Save(email); saveErr != nil {
+		mlog.Warn("Failed to queue email for another delivery attempt", mlog.Err(saveErr))
+		return err
+	}
+
+	if email.Status == model.OutgoingEmailStatusFailed {
+		return err
+	}
+
+	mlog.Warn("Failed to send email, it will be retried", mlog.String("outgoing_email_id", email.Id), mlog.Err(err))
+	return nil
+}
+
+// deliverOutgoingEmail sends an email with the configured transport. The sender and
+// default reply-to address are read from the configuration at the time of sending, but
+// the Message-ID is assigned once so that every attempt sends the same one.
+func (es *Service) deliverOutgoingEmail(email *model.OutgoingEmail) error {
+	mailConfig := es.mailServiceConfig(email.ReplyTo)
+	if email.MessageId == "" {
+		email.MessageId = mail.NewMessageID(mailConfig.Hostname)
+	}
+
+	var embeddedFiles map[string]io.Reader
+	if len(email.EmbeddedFiles) > 0 {
+		embeddedFiles = make(map[string]io.Reader, len(email.EmbeddedFiles))
+		for name, data := range email.EmbeddedFiles {
+			embeddedFiles[name] = bytes.NewReader(data)
+		}
+	}
+
+	msg := mail.NewMessage(mailConfig, email.To, email.Subject, email.HTMLBody, email.Category)
+	msg.Cc = email.Cc
+	msg.EmbeddedFiles = embeddedFiles
+	msg.MessageID = email.MessageId
+	msg.InReplyTo = email.InReplyTo
+	msg.References = email.References
+
+	cfg := es.config()
+	return newMailTransport(cfg, mailConfig, es.getMailHTTPClient(cfg)).Send(msg)
+}
+
+// recordFailedDelivery schedules the next delivery attempt of an email, or moves it to
+// the dead letters if it failed permanently or ran out of attempts.
+func (es *Service) recordFailedDelivery(email *model.OutgoingEmail, err error) {
+	email.Attempts++
+	email.LastError = err.Error()
+
+	if mail.IsPermanentError(err) || email.Attempts >= *es.config().EmailSettings.MailQueueMaxAttempts {
+		email.Status = model.OutgoingEmailStatusFailed
+		email.NextAttemptAt = 0
+		return
+	}
+
+	email.Status = model.OutgoingEmailStatusPending
+	email.NextAttemptAt = model.GetMillis() + model.OutgoingEmailBackoff(email.Attempts).Milliseconds()
+}
+
+// SendQueuedEmails makes another attempt at sending the queued emails whose retry is due,
+// and removes the emails that failed permanently once they are past their retention.
+func (es *Service) SendQueuedEmails() error {
+	emails, err := es.store.OutgoingEmail().GetDue(model.GetMillis(), outgoingEmailQueueBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to get queued emails")
+	}
+
+	for _, email := range emails {
+		err := es.deliverOutgoingEmail(email)
+		if err == nil {
+			if err := es.store.OutgoingEmail().Delete(email.Id); err != nil {
+				mlog.Warn("Failed to remove sent email from the queue", mlog.String("outgoing_email_id", email.Id), mlog.Err(err))
+			}
+			continue
+		}
+
+		es.recordFailedDelivery(email, err)
+		if email.Status == model.OutgoingEmailStatusFailed {
+			mlog.Warn("Giving up on sending queued email", mlog.String("outgoing_email_id", email.Id), mlog.Int("attempts", email.Attempts), mlog.Err(err))
+		}
+		if _, err := es.store.OutgoingEmail().Update(email); err != nil {
+			mlog.Warn("Failed to update queued email", mlog.String("outgoing_email_id", email.Id), mlog.Err(err))
+		}
+	}
+
+	// Sent emails are removed right away, but failed ones are kept for a while so that
+	// they can be retried. As they hold the full body of the email, they don't stay forever.
+	return es.deleteExpiredFailedEmails()
+}
+
+func (es *Service) deleteExpiredFailedEmails() error {
+	before := model.GetMillis() - model.OutgoingEmailRetention.Milliseconds()
+	for {
+		deleted, err := es.store.OutgoingEmail().PermanentDeleteFailedBefore(before, outgoingEmailQueueBatchSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete expired failed emails")
+		}
+		if deleted < outgoingEmailQueueBatchSize {
+			return nil
+		}
+	}
+}
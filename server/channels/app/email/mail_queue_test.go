@@ -0,0 +1,163 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package email
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+// setupMailQueue configures the HTTP mail transport to send emails to a server replying
+// with the given status code, and returns the mock store of queued emails.
+func setupMailQueue(t *testing.T, th *TestHelper, statusCode int) (*mocks.OutgoingEmailStore, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+
+	th.UpdateConfig(t, func(cfg *model.Config) {
+		*cfg.EmailSettings.MailTransport = model.MailTransportHTTP
+		*cfg.EmailSettings.HTTPMailTransportURL = server.URL
+		*cfg.EmailSettings.EnableMailQueue = true
+		*cfg.EmailSettings.MailQueueMaxAttempts = 3
+	})
+
+	outgoingEmailStore := &mocks.OutgoingEmailStore{}
+	th.service.store.(*mocks.Store).On("OutgoingEmail").Return(outgoingEmailStore)
+
+	return outgoingEmailStore, &requests
+}
+
+func TestSendOutgoingEmail(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	t.Run("sent", func(t *testing.T) {
+		th := SetupWithStoreMock(t)
+		outgoingEmailStore, requests := setupMailQueue(t, th, http.StatusOK)
+
+		err := th.service.sendOutgoingEmail(&model.OutgoingEmail{To: "user@example.com", Subject: "Subject"}, nil)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, requests.Load())
+		outgoingEmailStore.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("transient error queues the email", func(t *testing.T) {
+		th := SetupWithStoreMock(t)
+		outgoingEmailStore, _ := setupMailQueue(t, th, http.StatusServiceUnavailable)
+		outgoingEmailStore.On("Save", mock.AnythingOfType("*model.OutgoingEmail")).Return(func(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
+			return email, nil
+		})
+
+		embeddedFiles := map[string]io.Reader{"logo.png": strings.NewReader("logo")}
+		err := th.service.sendOutgoingEmail(&model.OutgoingEmail{To: "user@example.com", Subject: "Subject"}, embeddedFiles)
+		require.NoError(t, err)
+
+		outgoingEmailStore.AssertNumberOfCalls(t, "Save", 1)
+		queued := outgoingEmailStore.Calls[0].Arguments.Get(0).(*model.OutgoingEmail)
+		assert.Equal(t, model.OutgoingEmailStatusPending, queued.Status)
+		assert.Equal(t, 1, queued.Attempts)
+		assert.Greater(t, queued.NextAttemptAt, model.GetMillis())
+		assert.Equal(t, []byte("logo"), queued.EmbeddedFiles["logo.png"])
+		assert.NotEmpty(t, queued.MessageId, "should've kept the Message-ID for the next attempts")
+	})
+
+	t.Run("permanent error is returned", func(t *testing.T) {
+		th := SetupWithStoreMock(t)
+		outgoingEmailStore, _ := setupMailQueue(t, th, http.StatusBadRequest)
+
+		err := th.service.sendOutgoingEmail(&model.OutgoingEmail{To: "user@example.com", Subject: "Subject"}, nil)
+		require.Error(t, err)
+		outgoingEmailStore.AssertNotCalled(t, "Save", mock.Anything)
+	})
+
+	t.Run("transient error is returned when the mail queue is disabled", func(t *testing.T) {
+		th := SetupWithStoreMock(t)
+		outgoingEmailStore, _ := setupMailQueue(t, th, http.StatusServiceUnavailable)
+		th.UpdateConfig(t, func(cfg *model.Config) { *cfg.EmailSettings.EnableMailQueue = false })
+
+		err := th.service.sendOutgoingEmail(&model.OutgoingEmail{To: "user@example.com", Subject: "Subject"}, nil)
+		require.Error(t, err)
+		outgoingEmailStore.AssertNotCalled(t, "Save", mock.Anything)
+	})
+}
+
+func TestSendQueuedEmails(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	t.Run("sent emails are removed from the queue", func(t *testing.T) {
+		th := SetupWithStoreMock(t)
+		outgoingEmailStore, requests := setupMailQueue(t, th, http.StatusOK)
+
+		email := &model.OutgoingEmail{Id: model.NewId(), To: "user@example.com", Status: model.OutgoingEmailStatusPending, Attempts: 1}
+		outgoingEmailStore.On("GetDue", mock.AnythingOfType("int64"), outgoingEmailQueueBatchSize).Return([]*model.OutgoingEmail{email}, nil)
+		outgoingEmailStore.On("PermanentDeleteFailedBefore", mock.AnythingOfType("int64"), outgoingEmailQueueBatchSize).Return(int64(0), nil)
+		outgoingEmailStore.On("Delete", email.Id).Return(nil)
+
+		require.NoError(t, th.service.SendQueuedEmails())
+		assert.EqualValues(t, 1, requests.Load())
+		outgoingEmailStore.AssertCalled(t, "Delete", email.Id)
+		outgoingEmailStore.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("failed emails are retried later", func(t *testing.T) {
+		th := SetupWithStoreMock(t)
+		outgoingEmailStore, _ := setupMailQueue(t, th, http.StatusServiceUnavailable)
+
+		email := &model.OutgoingEmail{Id: model.NewId(), To: "user@example.com", Status: model.OutgoingEmailStatusPending, Attempts: 1, MessageId: "<abc@example.com>"}
+		outgoingEmailStore.On("GetDue", mock.AnythingOfType("int64"), outgoingEmailQueueBatchSize).Return([]*model.OutgoingEmail{email}, nil)
+		outgoingEmailStore.On("PermanentDeleteFailedBefore", mock.AnythingOfType("int64"), outgoingEmailQueueBatchSize).Return(int64(0), nil)
+		outgoingEmailStore.On("Update", email).Return(email, nil)
+
+		require.NoError(t, th.service.SendQueuedEmails())
+		assert.Equal(t, "<abc@example.com>", email.MessageId)
+		assert.Equal(t, model.OutgoingEmailStatusPending, email.Status)
+		assert.Equal(t, 2, email.Attempts)
+		assert.NotEmpty(t, email.LastError)
+		outgoingEmailStore.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("emails out of attempts are given up on", func(t *testing.T) {
+		th := SetupWithStoreMock(t)
+		outgoingEmailStore, _ := setupMailQueue(t, th, http.StatusServiceUnavailable)
+
+		email := &model.OutgoingEmail{Id: model.NewId(), To: "user@example.com", Status: model.OutgoingEmailStatusPending, Attempts: 2}
+		outgoingEmailStore.On("GetDue", mock.AnythingOfType("int64"), outgoingEmailQueueBatchSize).Return([]*model.OutgoingEmail{email}, nil)
+		outgoingEmailStore.On("PermanentDeleteFailedBefore", mock.AnythingOfType("int64"), outgoingEmailQueueBatchSize).Return(int64(0), nil)
+		outgoingEmailStore.On("Update", email).Return(email, nil)
+
+		require.NoError(t, th.service.SendQueuedEmails())
+		assert.Equal(t, model.OutgoingEmailStatusFailed, email.Status)
+		assert.Equal(t, 3, email.Attempts)
+		assert.Zero(t, email.NextAttemptAt)
+	})
+	t.Run("expired failed emails are deleted", func(t *testing.T) {
+		th := SetupWithStoreMock(t)
+		outgoingEmailStore, _ := setupMailQueue(t, th, http.StatusOK)
+
+		outgoingEmailStore.On("GetDue", mock.AnythingOfType("int64"), outgoingEmailQueueBatchSize).Return([]*model.OutgoingEmail{}, nil)
+		outgoingEmailStore.On("PermanentDeleteFailedBefore", mock.AnythingOfType("int64"), outgoingEmailQueueBatchSize).Return(int64(outgoingEmailQueueBatchSize), nil).Once()
+		outgoingEmailStore.On("PermanentDeleteFailedBefore", mock.AnythingOfType("int64"), outgoingEmailQueueBatchSize).Return(int64(10), nil).Once()
+
+		start := model.GetMillis()
+		require.NoError(t, th.service.SendQueuedEmails())
+		outgoingEmailStore.AssertNumberOfCalls(t, "PermanentDeleteFailedBefore", 2)
+
+		before := outgoingEmailStore.Calls[1].Arguments.Get(0).(int64)
+		assert.GreaterOrEqual(t, before, start-model.OutgoingEmailRetention.Milliseconds())
+		assert.LessOrEqual(t, before, model.GetMillis()-model.OutgoingEmailRetention.Milliseconds())
+	})
+}
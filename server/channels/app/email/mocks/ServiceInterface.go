@@ -116,11 +116,6 @@ func (_m *ServiceInterface) GetMessageForNotification(post *model.Post, teamName
 	return r0
 }
 
-// InitEmailBatching provides a mock function with no fields
-func (_m *ServiceInterface) InitEmailBatching() {
-	_m.Called()
-}
-
 // NewEmailTemplateData provides a mock function with given fields: locale
 func (_m *ServiceInterface) NewEmailTemplateData(locale string) templates.Data {
 	ret := _m.Called(locale)
@@ -139,6 +134,24 @@ func (_m *ServiceInterface) NewEmailTemplateData(locale string) templates.Data {
 	return r0
 }
 
+// SendBatchedEmailNotifications provides a mock function with no fields
+func (_m *ServiceInterface) SendBatchedEmailNotifications() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendBatchedEmailNotifications")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendChangeUsernameEmail provides a mock function with given fields: newUsername, _a1, locale, siteURL
 func (_m *ServiceInterface) SendChangeUsernameEmail(newUsername string, _a1 string, locale string, siteURL string) error {
 	ret := _m.Called(newUsername, _a1, locale, siteURL)
@@ -449,6 +462,24 @@ func (_m *ServiceInterface) SendPasswordResetEmail(_a0 string, token *model.Toke
 	return r0, r1
 }
 
+// SendQueuedEmails provides a mock function with no fields
+func (_m *ServiceInterface) SendQueuedEmails() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendQueuedEmails")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendRemoveExpiredLicenseEmail provides a mock function with given fields: _a0, locale
 func (_m *ServiceInterface) SendRemoveExpiredLicenseEmail(_a0 string, locale string) error {
 	ret := _m.Called(_a0, locale)
@@ -544,11 +575,6 @@ func (_m *ServiceInterface) SetStore(st store.Store) {
 	_m.Called(st)
 }
 
-// Stop provides a mock function with no fields
-func (_m *ServiceInterface) Stop() {
-	_m.Called()
-}
-
 // NewServiceInterface creates a new instance of ServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewServiceInterface(t interface {
@@ -5,9 +5,11 @@ package email
 
 import (
 	"io"
+	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/throttled/throttled/v2"
@@ -15,7 +17,6 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
-	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/app/users"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
@@ -57,6 +58,11 @@ type Service struct {
 	perMinuteEmailRateLimiter *throttled.GCRARateLimiterCtx
 	perHourEmailRateLimiter   *throttled.GCRARateLimiterCtx
 	EmailBatching             *EmailBatchingJob
+
+	// mailHTTPClient is shared by the emails sent with the HTTP mail transport, and is
+	// only replaced when the configured timeout changes.
+	mailHTTPClientMut sync.Mutex
+	mailHTTPClient    *http.Client
 }
 
 type ServiceConfig struct {
@@ -82,17 +88,10 @@ func NewService(config ServiceConfig) (*Service, error) {
 	if err := service.setUpRateLimiters(); err != nil {
 		return nil, err
 	}
-	service.InitEmailBatching()
+	service.EmailBatching = NewEmailBatchingJob(service)
 	return service, nil
 }
 
-func (es *Service) Stop() {
-	mlog.Info("Shutting down Email batching service...")
-	if es.EmailBatching != nil {
-		es.EmailBatching.Stop()
-	}
-}
-
 func (c *ServiceConfig) validate() error {
 	if c.ConfigFn == nil || c.Store == nil || c.LicenseFn == nil || c.TemplatesContainer == nil {
 		return errors.New("invalid service config")
@@ -155,12 +154,12 @@ type ServiceInterface interface {
 	AddNotificationEmailToBatch(user *model.User, post *model.Post, team *model.Team) *model.AppError
 	GetMessageForNotification(post *model.Post, teamName, siteUrl string, translateFunc i18n.TranslateFunc) string
 	GenerateHyperlinkForChannels(postMessage, teamName, teamURL string) (string, error)
-	InitEmailBatching()
+	SendBatchedEmailNotifications() error
+	SendQueuedEmails() error
 	SendChangeUsernameEmail(newUsername, email, locale, siteURL string) error
 	CreateVerifyEmailToken(userID string, newEmail string) (*model.Token, error)
 	SendIPFiltersChangedEmail(email string, userWhoChangedFilter *model.User, siteURL, portalURL, locale string, isWorkspaceOwner bool) error
 	SetStore(st store.Store)
-	Stop()
 }
 
 // getLicenseSkuName returns the license tier descriptor (e.g. "Professional",
@@ -110,6 +110,89 @@ var exportablePreferences = map[imports.ComparablePreference]string{
 	}: "EmailInterval",
 }
 
+// exportFilter restricts a bulk export to some teams and channels, and to the entities changed
+// since a given time.
+type exportFilter struct {
+	since int64
+
+	scoped     bool
+	teamIDs    map[string]any
+	channelIDs map[string]any
+	// channelTeamIDs holds the teams of the channels of the export, which are exported along
+	// with them.
+	channelTeamIDs map[string]any
+
+	// deletes holds the delete lines of the teams, channels and memberships, which are written
+	// at the end of the export along with the ones of the posts and emojis.
+	deletes []*imports.LineImportData
+}
+
+func (a *App) newExportFilter(rctx request.CTX, opts model.BulkExportOpts) (*exportFilter, *model.AppError) {
+	filter := &exportFilter{
+		since:          opts.Since,
+		scoped:         opts.IsScoped(),
+		teamIDs:        model.SliceToMapKey(opts.TeamIds...),
+		channelIDs:     model.SliceToMapKey(opts.ChannelIds...),
+		channelTeamIDs: make(map[string]any),
+	}
+
+	for _, channelID := range opts.ChannelIds {
+		channel, appErr := a.GetChannel(rctx, channelID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if channel.TeamId != "" {
+			filter.channelTeamIDs[channel.TeamId] = struct{}{}
+		}
+	}
+
+	return filter, nil
+}
+
+func (f *exportFilter) includesTeam(teamID string) bool {
+	if !f.scoped {
+		return true
+	}
+	_, inTeams := f.teamIDs[teamID]
+	_, inChannelTeams := f.channelTeamIDs[teamID]
+	return inTeams || inChannelTeams
+}
+
+// includesChannel returns true if the channel is part of the export. Direct and group channels,
+// which have no team, are only exported when they are given explicitly.
+func (f *exportFilter) includesChannel(teamID, channelID string) bool {
+	if !f.scoped {
+		return true
+	}
+	_, inTeams := f.teamIDs[teamID]
+	_, inChannels := f.channelIDs[channelID]
+	return (teamID != "" && inTeams) || inChannels
+}
+
+func (f *exportFilter) changedSince(updateAt int64) bool {
+	return f.since == 0 || updateAt > f.since
+}
+
+// deletedSince returns true if a delete line must be written for an entity. Full exports don't
+// need any as they only contain the entities that exist.
+func (f *exportFilter) deletedSince(deleteAt int64) bool {
+	return f.since > 0 && deleteAt > f.since
+}
+
+func (f *exportFilter) postOpts(includeArchivedChannels bool) model.PostExportOpts {
+	opts := model.PostExportOpts{
+		IncludeArchivedChannels: includeArchivedChannels,
+		Since:                   f.since,
+	}
+	for teamID := range f.teamIDs {
+		opts.TeamIds = append(opts.TeamIds, teamID)
+	}
+	for channelID := range f.channelIDs {
+		opts.ChannelIds = append(opts.ChannelIds, channelID)
+	}
+	return opts
+}
+
 func (a *App) BulkExport(rctx request.CTX, writer io.Writer, outPath string, job *model.Job, opts model.BulkExportOpts) *model.AppError {
 	var zipWr *zip.Writer
 	if opts.CreateArchive {
@@ -135,6 +218,11 @@ func (a *App) BulkExport(rctx request.CTX, writer io.Writer, outPath string, job
 		job.Data = make(model.StringMap)
 	}
 
+	filter, appErr := a.newExportFilter(rctx, opts)
+	if appErr != nil {
+		return appErr
+	}
+
 	rctx.Logger().Info("Bulk export: exporting version")
 	if err := a.exportVersion(writer); err != nil {
 		return err
@@ -147,51 +235,92 @@ func (a *App) BulkExport(rctx request.CTX, writer io.Writer, outPath string, job
 	}
 
 	rctx.Logger().Info("Bulk export: exporting teams")
-	teamNames, appErr := a.exportAllTeams(rctx, job, writer)
+	teamNames, appErr := a.exportAllTeams(rctx, job, writer, filter)
 	if appErr != nil {
 		return appErr
 	}
 
 	rctx.Logger().Info("Bulk export: exporting channels")
-	if appErr = a.exportAllChannels(rctx, job, writer, teamNames, opts.IncludeArchivedChannels); appErr != nil {
+	if appErr = a.exportAllChannels(rctx, job, writer, teamNames, opts.IncludeArchivedChannels, filter); appErr != nil {
 		return appErr
 	}
 
 	rctx.Logger().Info("Bulk export: exporting users")
-	profilePictures, appErr := a.exportAllUsers(rctx, job, writer, opts.IncludeArchivedChannels, opts.IncludeProfilePictures)
+	profilePictures, appErr := a.exportAllUsers(rctx, job, writer, opts.IncludeArchivedChannels, opts.IncludeProfilePictures, filter)
 	if appErr != nil {
 		return appErr
 	}
 
 	rctx.Logger().Info("Bulk export: exporting bots")
-	botPPs, appErr := a.exportAllBots(rctx, job, writer, opts.IncludeProfilePictures)
+	botPPs, appErr := a.exportAllBots(rctx, job, writer, opts.IncludeProfilePictures, filter)
 	if appErr != nil {
 		return appErr
 	}
 	profilePictures = append(profilePictures, botPPs...)
 
 	rctx.Logger().Info("Bulk export: exporting posts")
-	attachments, appErr := a.exportAllPosts(rctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
+	attachments, appErr := a.exportAllPosts(rctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, filter)
 	if appErr != nil {
 		return appErr
 	}
 
 	rctx.Logger().Info("Bulk export: exporting emoji")
-	emojiPaths, appErr := a.exportCustomEmoji(rctx, job, writer, outPath, "exported_emoji", !opts.CreateArchive)
+	emojiPaths, appErr := a.exportCustomEmoji(rctx, job, writer, outPath, "exported_emoji", !opts.CreateArchive, filter)
 	if appErr != nil {
 		return appErr
 	}
 
 	rctx.Logger().Info("Bulk export: exporting direct channels")
-	if appErr = a.exportAllDirectChannels(rctx, job, writer, opts.IncludeArchivedChannels); appErr != nil {
+	if appErr = a.exportAllDirectChannels(rctx, job, writer, opts.IncludeArchivedChannels, filter); appErr != nil {
 		return appErr
 	}
 
 	rctx.Logger().Info("Bulk export: exporting direct posts")
-	directAttachments, appErr := a.exportAllDirectPosts(rctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels)
+	directAttachments, appErr := a.exportAllDirectPosts(rctx, job, writer, opts.IncludeAttachments, opts.IncludeArchivedChannels, filter)
+	if appErr != nil {
+		return appErr
+	}
+
+	refs := newExportReferences(a.Srv().Store())
+
+	rctx.Logger().Info("Bulk export: exporting channel bookmarks")
+	bookmarkAttachments, appErr := a.exportAllChannelBookmarks(rctx, job, writer, opts.IncludeAttachments, filter, refs)
 	if appErr != nil {
 		return appErr
 	}
+	attachments = append(attachments, bookmarkAttachments...)
+
+	rctx.Logger().Info("Bulk export: exporting drafts")
+	if appErr = a.exportAllDrafts(rctx, job, writer, filter, refs); appErr != nil {
+		return appErr
+	}
+
+	rctx.Logger().Info("Bulk export: exporting scheduled posts")
+	if appErr = a.exportAllScheduledPosts(rctx, job, writer, filter, refs); appErr != nil {
+		return appErr
+	}
+
+	rctx.Logger().Info("Bulk export: exporting incoming webhooks")
+	if appErr = a.exportAllIncomingWebhooks(rctx, job, writer, filter, refs); appErr != nil {
+		return appErr
+	}
+
+	rctx.Logger().Info("Bulk export: exporting outgoing webhooks")
+	if appErr = a.exportAllOutgoingWebhooks(rctx, job, writer, filter, refs); appErr != nil {
+		return appErr
+	}
+
+	rctx.Logger().Info("Bulk export: exporting commands")
+	if appErr = a.exportAllCommands(rctx, job, writer, filter, refs); appErr != nil {
+		return appErr
+	}
+
+	if filter.since > 0 {
+		rctx.Logger().Info("Bulk export: exporting deletes")
+		if appErr = a.exportDeletes(rctx, job, writer, opts.IncludeArchivedChannels, filter); appErr != nil {
+			return appErr
+		}
+	}
 
 	if opts.IncludeAttachments {
 		rctx.Logger().Info("Bulk export: exporting file attachments")
@@ -399,7 +528,7 @@ func (a *App) exportSchemes(rctx request.CTX, job *model.Job, writer io.Writer,
 	}
 }
 
-func (a *App) exportAllTeams(rctx request.CTX, job *model.Job, writer io.Writer) (map[string]bool, *model.AppError) {
+func (a *App) exportAllTeams(rctx request.CTX, job *model.Job, writer io.Writer, filter *exportFilter) (map[string]bool, *model.AppError) {
 	afterId := strings.Repeat("0", 26)
 	teamNames := make(map[string]bool)
 	cnt := 0
@@ -418,12 +547,24 @@ func (a *App) exportAllTeams(rctx request.CTX, job *model.Job, writer io.Writer)
 		for _, team := range teams {
 			afterId = team.Id
 
+			if !filter.includesTeam(team.Id) {
+				continue
+			}
+
 			// Skip deleted.
 			if team.DeleteAt != 0 {
+				if filter.deletedSince(team.DeleteAt) {
+					filter.deletes = append(filter.deletes, importLineForDeletedTeam(team))
+				}
 				continue
 			}
+			// Unchanged teams are still needed to export their channels.
 			teamNames[team.Name] = true
 
+			if !filter.changedSince(team.UpdateAt) {
+				continue
+			}
+
 			teamLine := importLineFromTeam(team)
 			if err := a.exportWriteLine(writer, teamLine); err != nil {
 				return nil, err
@@ -434,7 +575,7 @@ func (a *App) exportAllTeams(rctx request.CTX, job *model.Job, writer io.Writer)
 	return teamNames, nil
 }
 
-func (a *App) exportAllChannels(rctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, withArchived bool) *model.AppError {
+func (a *App) exportAllChannels(rctx request.CTX, job *model.Job, writer io.Writer, teamNames map[string]bool, withArchived bool, filter *exportFilter) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	for {
@@ -452,14 +593,23 @@ func (a *App) exportAllChannels(rctx request.CTX, job *model.Job, writer io.Writ
 		for _, channel := range channels {
 			afterId = channel.Id
 
-			// Skip deleted.
-			if channel.DeleteAt != 0 && !withArchived {
+			if !filter.includesChannel(channel.TeamId, channel.Id) {
 				continue
 			}
 			// Skip channels on deleted teams.
 			if ok := teamNames[channel.TeamName]; !ok {
 				continue
 			}
+			// Skip deleted. Archived channels that are exported carry their deletion time.
+			if channel.DeleteAt != 0 && !withArchived {
+				if filter.deletedSince(channel.DeleteAt) {
+					filter.deletes = append(filter.deletes, importLineForDeletedChannel(channel))
+				}
+				continue
+			}
+			if !filter.changedSince(channel.UpdateAt) {
+				continue
+			}
 
 			channelLine := importLineFromChannel(channel)
 			if err := a.exportWriteLine(writer, channelLine); err != nil {
@@ -471,10 +621,16 @@ func (a *App) exportAllChannels(rctx request.CTX, job *model.Job, writer io.Writ
 	return nil
 }
 
-func (a *App) exportAllUsers(rctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels, includeProfilePictures bool) ([]string, *model.AppError) {
+func (a *App) exportAllUsers(rctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels, includeProfilePictures bool, filter *exportFilter) ([]string, *model.AppError) {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	profilePictures := []string{}
+
+	cpaGroupID, cpaFields, appErr := a.getCustomProfileAttributeFields(rctx)
+	if appErr != nil {
+		return profilePictures, appErr
+	}
+
 	for {
 		users, err := a.Srv().Store().User().GetAllAfter(1000, afterId)
 		if err != nil {
@@ -495,6 +651,30 @@ func (a *App) exportAllUsers(rctx request.CTX, job *model.Job, writer io.Writer,
 				continue
 			}
 
+			// Do the Team Memberships.
+			members, membersUpdateAt, err := a.buildUserTeamAndChannelMemberships(rctx, user.Id, includeArchivedChannels, filter)
+			if err != nil {
+				return profilePictures, err
+			}
+			if err := a.addUserMembershipDeletes(rctx, user, filter); err != nil {
+				return profilePictures, err
+			}
+
+			// Skip users outside of the teams and channels of the export.
+			if filter.scoped && len(*members) == 0 {
+				continue
+			}
+			attributes, attributesUpdateAt, err := a.buildUserCustomProfileAttributes(rctx, user.Id, cpaGroupID, cpaFields)
+			if err != nil {
+				return profilePictures, err
+			}
+
+			// The user line holds the memberships and attributes, so it's exported again when
+			// they change.
+			if !filter.changedSince(max(user.UpdateAt, membersUpdateAt, attributesUpdateAt)) {
+				continue
+			}
+
 			// Gathering here the exportable preferences to pass them on to importLineFromUser
 			exportedPrefs := make(map[string]*string)
 			allPrefs, err := a.GetPreferencesForUser(rctx, user.Id)
@@ -554,13 +734,8 @@ func (a *App) exportAllUsers(rctx request.CTX, job *model.Job, writer io.Writer,
 				userLine.User.CustomStatus = cs
 			}
 
-			// Do the Team Memberships.
-			members, err := a.buildUserTeamAndChannelMemberships(rctx, user.Id, includeArchivedChannels)
-			if err != nil {
-				return profilePictures, err
-			}
-
 			userLine.User.Teams = members
+			userLine.User.CustomProfileAttributes = attributes
 
 			if err := a.exportWriteLine(writer, userLine); err != nil {
 				return profilePictures, err
@@ -571,7 +746,7 @@ func (a *App) exportAllUsers(rctx request.CTX, job *model.Job, writer io.Writer,
 	return profilePictures, nil
 }
 
-func (a *App) exportAllBots(rctx request.CTX, job *model.Job, writer io.Writer, includeProfilePictures bool) ([]string, *model.AppError) {
+func (a *App) exportAllBots(rctx request.CTX, job *model.Job, writer io.Writer, includeProfilePictures bool, filter *exportFilter) ([]string, *model.AppError) {
 	afterId := ""
 	cnt := 0
 	profilePictures := []string{}
@@ -590,6 +765,10 @@ func (a *App) exportAllBots(rctx request.CTX, job *model.Job, writer io.Writer,
 		for _, bot := range bots {
 			afterId = bot.UserId
 
+			if !filter.changedSince(bot.UpdateAt) {
+				continue
+			}
+
 			var ownerUsername string
 			owner, err := a.Srv().Store().User().Get(rctx.Context(), bot.OwnerId)
 			if err != nil {
@@ -629,12 +808,15 @@ func (a *App) exportAllBots(rctx request.CTX, job *model.Job, writer io.Writer,
 	return profilePictures, nil
 }
 
-func (a *App) buildUserTeamAndChannelMemberships(rctx request.CTX, userID string, includeArchivedChannels bool) (*[]imports.UserTeamImportData, *model.AppError) {
+// buildUserTeamAndChannelMemberships returns the memberships of a user in the teams and channels
+// of the export, along with the time they were last updated.
+func (a *App) buildUserTeamAndChannelMemberships(rctx request.CTX, userID string, includeArchivedChannels bool, filter *exportFilter) (*[]imports.UserTeamImportData, int64, *model.AppError) {
 	var memberships []imports.UserTeamImportData
+	var lastUpdateAt int64
 
 	members, err := a.Srv().Store().Team().GetTeamMembersForExport(userID)
 	if err != nil {
-		return nil, model.NewAppError("buildUserTeamAndChannelMemberships", "app.team.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return nil, 0, model.NewAppError("buildUserTeamAndChannelMemberships", "app.team.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
 	for _, member := range members {
@@ -643,13 +825,22 @@ func (a *App) buildUserTeamAndChannelMemberships(rctx request.CTX, userID string
 			continue
 		}
 
+		if !filter.includesTeam(member.TeamId) {
+			continue
+		}
+
 		memberData := importUserTeamDataFromTeamMember(member)
 
 		// Do the Channel Memberships.
-		channelMembers, err := a.buildUserChannelMemberships(rctx, userID, member.TeamId, includeArchivedChannels)
+		channelMembers, channelsUpdateAt, err := a.buildUserChannelMemberships(rctx, userID, member.TeamId, includeArchivedChannels, filter)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
+		// Teams exported only for some of their channels only include the members of these.
+		if _, ok := filter.teamIDs[member.TeamId]; filter.scoped && !ok && len(*channelMembers) == 0 {
+			continue
+		}
+		lastUpdateAt = max(lastUpdateAt, member.CreateAt, channelsUpdateAt)
 
 		// Get the user theme
 		themePreference, nErr := a.Srv().Store().Preference().Get(member.UserId, model.PreferenceCategoryTheme, member.TeamId)
@@ -662,26 +853,129 @@ func (a *App) buildUserTeamAndChannelMemberships(rctx request.CTX, userID string
 		memberships = append(memberships, *memberData)
 	}
 
-	return &memberships, nil
+	return &memberships, lastUpdateAt, nil
 }
 
-func (a *App) buildUserChannelMemberships(rctx request.CTX, userID string, teamID string, includeArchivedChannels bool) (*[]imports.UserChannelImportData, *model.AppError) {
+// addUserMembershipDeletes adds the delete lines of the memberships of a user removed from the
+// teams and channels of the export since the previous one.
+func (a *App) addUserMembershipDeletes(rctx request.CTX, user *model.User, filter *exportFilter) *model.AppError {
+	if filter.since == 0 {
+		return nil
+	}
+
+	teamMembers, err := a.Srv().Store().Team().GetTeamMembersForExport(user.Id)
+	if err != nil {
+		return model.NewAppError("addUserMembershipDeletes", "app.team.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	leftTeams := make(map[string]bool)
+	for _, member := range teamMembers {
+		if member.DeleteAt != 0 {
+			leftTeams[member.TeamId] = true
+		}
+		if filter.deletedSince(member.DeleteAt) && filter.includesTeam(member.TeamId) {
+			filter.deletes = append(filter.deletes, importLineForDeletedTeamMember(member, user.Username))
+		}
+	}
+
+	leaves, err := a.Srv().Store().ChannelMemberHistory().GetChannelLeavesSince(user.Id, filter.since)
+	if err != nil {
+		return model.NewAppError("addUserMembershipDeletes", "app.channel_member_history.get_channel_leaves.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	for _, leave := range leaves {
+		channel, err := a.Srv().Store().Channel().Get(leave.ChannelId, true)
+		if err != nil {
+			var nfErr *store.ErrNotFound
+			if errors.As(err, &nfErr) {
+				continue
+			}
+			return model.NewAppError("addUserMembershipDeletes", "app.channel.get.find.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		// Users can't leave direct and group channels, and the memberships of deleted
+		// channels, or of channels in teams the user left, go away along with them.
+		if channel.IsGroupOrDirect() || channel.DeleteAt != 0 || leftTeams[channel.TeamId] || !filter.includesChannel(channel.TeamId, channel.Id) {
+			continue
+		}
+
+		team, err := a.Srv().Store().Team().Get(channel.TeamId)
+		if err != nil {
+			return model.NewAppError("addUserMembershipDeletes", "app.team.get.find.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		filter.deletes = append(filter.deletes, importLineForDeletedChannelMember(team.Name, channel.Name, user.Username, *leave.LeaveTime))
+	}
+
+	return nil
+}
+
+// buildUserChannelMemberships returns the memberships of a user in the channels of a team that
+// are part of the export, along with the time they were last updated.
+func (a *App) buildUserChannelMemberships(rctx request.CTX, userID string, teamID string, includeArchivedChannels bool, filter *exportFilter) (*[]imports.UserChannelImportData, int64, *model.AppError) {
 	members, nErr := a.Srv().Store().Channel().GetChannelMembersForExport(userID, teamID, includeArchivedChannels)
 	if nErr != nil {
-		return nil, model.NewAppError("buildUserChannelMemberships", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		return nil, 0, model.NewAppError("buildUserChannelMemberships", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 	}
 
 	category := model.PreferenceCategoryFavoriteChannel
 	preferences, err := a.GetPreferenceByCategoryForUser(rctx, userID, category)
 	if err != nil && err.StatusCode != http.StatusNotFound {
-		return nil, err
+		return nil, 0, err
 	}
 
-	memberships := make([]imports.UserChannelImportData, len(members))
-	for i, member := range members {
-		memberships[i] = *importUserChannelDataFromChannelMemberAndPreferences(member, &preferences)
+	memberships := make([]imports.UserChannelImportData, 0, len(members))
+	var lastUpdateAt int64
+	for _, member := range members {
+		if !filter.includesChannel(teamID, member.ChannelId) {
+			continue
+		}
+		memberships = append(memberships, *importUserChannelDataFromChannelMemberAndPreferences(member, &preferences))
+		lastUpdateAt = max(lastUpdateAt, member.LastUpdateAt)
 	}
-	return &memberships, nil
+	return &memberships, lastUpdateAt, nil
+}
+
+// buildUserCustomProfileAttributes returns the custom profile attributes of a user keyed by field
+// name, along with the last time they were updated.
+func (a *App) buildUserCustomProfileAttributes(rctx request.CTX, userID, groupID string, fields []*model.CPAField) (*map[string]json.RawMessage, int64, *model.AppError) {
+	if len(fields) == 0 {
+		return nil, 0, nil
+	}
+
+	values, err := a.Srv().Store().PropertyValue().SearchPropertyValues(model.PropertyValueSearchOpts{
+		GroupID:    groupID,
+		TargetType: model.PropertyValueTargetTypeUser,
+		TargetIDs:  []string{userID},
+		PerPage:    len(fields),
+	})
+	if err != nil {
+		return nil, 0, model.NewAppError("buildUserCustomProfileAttributes", "app.property_value.search.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	fieldsByID := make(map[string]*model.CPAField, len(fields))
+	for _, field := range fields {
+		fieldsByID[field.ID] = field
+	}
+
+	var lastUpdateAt int64
+	attributes := make(map[string]json.RawMessage, len(values))
+	for _, value := range values {
+		field, ok := fieldsByID[value.FieldID]
+		if !ok {
+			continue
+		}
+
+		exportedValue, err := customProfileAttributeValueForExport(field, value.Value)
+		if err != nil {
+			rctx.Logger().Warn("Skipping custom profile attribute value", mlog.String("user_id", userID), mlog.String("field_name", field.Name), mlog.Err(err))
+			continue
+		}
+		attributes[field.Name] = exportedValue
+		lastUpdateAt = max(lastUpdateAt, value.UpdateAt)
+	}
+
+	if len(attributes) == 0 {
+		return nil, 0, nil
+	}
+
+	return &attributes, lastUpdateAt, nil
 }
 
 func (a *App) buildUserNotifyProps(notifyProps model.StringMap) *imports.UserNotifyPropsImportData {
@@ -704,9 +998,10 @@ func (a *App) buildUserNotifyProps(notifyProps model.StringMap) *imports.UserNot
 	}
 }
 
-func (a *App) exportAllPosts(rctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+func (a *App) exportAllPosts(rctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, includeArchivedChannels bool, filter *exportFilter) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
 	afterId := strings.Repeat("0", 26)
+	postOpts := filter.postOpts(includeArchivedChannels)
 	var postProcessCount uint64
 	logCheckpoint := time.Now()
 
@@ -717,7 +1012,7 @@ func (a *App) exportAllPosts(rctx request.CTX, job *model.Job, writer io.Writer,
 			logCheckpoint = time.Now()
 		}
 
-		posts, nErr := a.Srv().Store().Post().GetParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		posts, nErr := a.Srv().Store().Post().GetParentsForExportAfter(1000, afterId, postOpts)
 		if nErr != nil {
 			return nil, model.NewAppError("exportAllPosts", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
 		}
@@ -873,7 +1168,7 @@ func (a *App) buildPostAttachments(postID string) ([]imports.AttachmentImportDat
 	return attachments, nil
 }
 
-func (a *App) exportCustomEmoji(rctx request.CTX, job *model.Job, writer io.Writer, outPath, exportDir string, exportFiles bool) ([]string, *model.AppError) {
+func (a *App) exportCustomEmoji(rctx request.CTX, job *model.Job, writer io.Writer, outPath, exportDir string, exportFiles bool, filter *exportFilter) ([]string, *model.AppError) {
 	var emojiPaths []string
 	pageNumber := 0
 	cnt := 0
@@ -902,6 +1197,10 @@ func (a *App) exportCustomEmoji(rctx request.CTX, job *model.Job, writer io.Writ
 			}
 
 			for _, emoji := range customEmojiList {
+				if !filter.changedSince(emoji.UpdateAt) {
+					continue
+				}
+
 				emojiImagePath := filepath.Join(emojiPath, emoji.Id, "image")
 				filePath := filepath.Join(exportDir, emoji.Id, "image")
 				if exportFiles {
@@ -965,7 +1264,7 @@ func (a *App) copyEmojiImages(rctx request.CTX, emojiId string, emojiImagePath s
 	return nil
 }
 
-func (a *App) exportAllDirectChannels(rctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels bool) *model.AppError {
+func (a *App) exportAllDirectChannels(rctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels bool, filter *exportFilter) *model.AppError {
 	afterId := strings.Repeat("0", 26)
 	cnt := 0
 	for {
@@ -993,6 +1292,19 @@ func (a *App) exportAllDirectChannels(rctx request.CTX, job *model.Job, writer i
 				continue
 			}
 
+			if !filter.includesChannel(channel.TeamId, channel.Id) {
+				continue
+			}
+
+			// The channel line holds the members, so it's exported again when they change.
+			lastUpdateAt := channel.UpdateAt
+			for _, member := range channel.Members {
+				lastUpdateAt = max(lastUpdateAt, member.LastUpdateAt)
+			}
+			if !filter.changedSince(lastUpdateAt) {
+				continue
+			}
+
 			// Skip if the channel member structure is not intact
 			switch channel.Type {
 			case model.ChannelTypeGroup:
@@ -1109,9 +1421,10 @@ func (a *App) buildShownByList(channel *model.DirectChannelForExport) ([]string,
 	return shownBy, nil
 }
 
-func (a *App) exportAllDirectPosts(rctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels bool) ([]imports.AttachmentImportData, *model.AppError) {
+func (a *App) exportAllDirectPosts(rctx request.CTX, job *model.Job, writer io.Writer, withAttachments, includeArchivedChannels bool, filter *exportFilter) ([]imports.AttachmentImportData, *model.AppError) {
 	var attachments []imports.AttachmentImportData
 	afterId := strings.Repeat("0", 26)
+	postOpts := filter.postOpts(includeArchivedChannels)
 	var postProcessCount uint64
 	logCheckpoint := time.Now()
 
@@ -1122,7 +1435,7 @@ func (a *App) exportAllDirectPosts(rctx request.CTX, job *model.Job, writer io.W
 			logCheckpoint = time.Now()
 		}
 
-		posts, err := a.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, afterId, includeArchivedChannels)
+		posts, err := a.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, afterId, postOpts)
 		if err != nil {
 			return nil, model.NewAppError("exportAllDirectPosts", "app.post.get_direct_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
@@ -1194,6 +1507,576 @@ func (a *App) exportAllDirectPosts(rctx request.CTX, job *model.Job, writer io.W
 	return attachments, nil
 }
 
+// exportChannelReference is how the lines of a bulk export reference a channel: by team and name,
+// or by members for direct and group channels.
+type exportChannelReference struct {
+	Team           *string
+	Channel        *string
+	ChannelMembers *[]string
+}
+
+// exportReferences caches the names by which the entities exported after the users reference
+// users, teams and channels.
+type exportReferences struct {
+	store     store.Store
+	usernames map[string]string
+	teams     map[string]*model.Team
+	channels  map[string]*model.Channel
+	refs      map[string]*exportChannelReference
+}
+
+func newExportReferences(s store.Store) *exportReferences {
+	return &exportReferences{
+		store:     s,
+		usernames: make(map[string]string),
+		teams:     make(map[string]*model.Team),
+		channels:  make(map[string]*model.Channel),
+		refs:      make(map[string]*exportChannelReference),
+	}
+}
+
+// username returns the username of a user, or an empty string if the user doesn't exist anymore.
+func (r *exportReferences) username(userID string) (string, *model.AppError) {
+	if username, ok := r.usernames[userID]; ok {
+		return username, nil
+	}
+
+	var username string
+	user, err := r.store.User().Get(context.Background(), userID)
+	var nfErr *store.ErrNotFound
+	if err != nil && !errors.As(err, &nfErr) {
+		return "", model.NewAppError("exportReferences.username", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	} else if err == nil {
+		username = user.Username
+	}
+
+	r.usernames[userID] = username
+	return username, nil
+}
+
+// team returns a team, or nil if it doesn't exist anymore or is deleted.
+func (r *exportReferences) team(teamID string) (*model.Team, *model.AppError) {
+	if team, ok := r.teams[teamID]; ok {
+		return team, nil
+	}
+
+	team, err := r.store.Team().Get(teamID)
+	var nfErr *store.ErrNotFound
+	if err != nil && !errors.As(err, &nfErr) {
+		return nil, model.NewAppError("exportReferences.team", "app.team.get.finding.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	} else if err != nil || team.DeleteAt != 0 {
+		team = nil
+	}
+
+	r.teams[teamID] = team
+	return team, nil
+}
+
+// channel returns a channel along with its reference, or nil if it doesn't exist anymore or is
+// deleted, either itself or its team.
+func (r *exportReferences) channel(channelID string) (*model.Channel, *exportChannelReference, *model.AppError) {
+	if channel, ok := r.channels[channelID]; ok {
+		return channel, r.refs[channelID], nil
+	}
+
+	channel, ref, appErr := r.loadChannel(channelID)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	r.channels[channelID] = channel
+	r.refs[channelID] = ref
+	return channel, ref, nil
+}
+
+func (r *exportReferences) loadChannel(channelID string) (*model.Channel, *exportChannelReference, *model.AppError) {
+	channel, err := r.store.Channel().Get(channelID, true)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, nil, nil
+		}
+		return nil, nil, model.NewAppError("exportReferences.channel", "app.channel.get.find.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if channel.DeleteAt != 0 {
+		return nil, nil, nil
+	}
+
+	if channel.TeamId != "" {
+		team, appErr := r.team(channel.TeamId)
+		if appErr != nil || team == nil {
+			return nil, nil, appErr
+		}
+		return channel, &exportChannelReference{Team: &team.Name, Channel: &channel.Name}, nil
+	}
+
+	members, err := r.store.Channel().GetMembersInfoByChannelIds([]string{channelID})
+	if err != nil {
+		return nil, nil, model.NewAppError("exportReferences.channel", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	usernames := make([]string, 0, len(members[channelID]))
+	for _, member := range members[channelID] {
+		usernames = append(usernames, member.Username)
+	}
+	if len(usernames) == 0 {
+		return nil, nil, nil
+	}
+	if len(usernames) == 1 {
+		usernames = []string{usernames[0], usernames[0]}
+	}
+
+	return channel, &exportChannelReference{ChannelMembers: &usernames}, nil
+}
+
+func (a *App) exportAllChannelBookmarks(rctx request.CTX, job *model.Job, writer io.Writer, withAttachments bool, filter *exportFilter, refs *exportReferences) ([]imports.AttachmentImportData, *model.AppError) {
+	var attachments []imports.AttachmentImportData
+	cnt := 0
+
+	exportChannel := func(channelID string) *model.AppError {
+		channel, channelRef, appErr := refs.channel(channelID)
+		if appErr != nil || channel == nil || !filter.includesChannel(channel.TeamId, channel.Id) {
+			return appErr
+		}
+
+		bookmarks, err := a.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+		if err != nil {
+			return model.NewAppError("exportAllChannelBookmarks", "app.channel.bookmark.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, bookmark := range bookmarks {
+			if !filter.changedSince(bookmark.UpdateAt) {
+				continue
+			}
+			// File bookmarks can't be imported without their file.
+			if bookmark.Type == model.ChannelBookmarkFile && (!withAttachments || bookmark.FileInfo == nil) {
+				continue
+			}
+
+			owner, appErr := refs.username(bookmark.OwnerId)
+			if appErr != nil {
+				return appErr
+			}
+			if owner == "" {
+				continue
+			}
+
+			bookmarkLine := importLineFromChannelBookmark(bookmark, channelRef, owner)
+			if bookmarkLine.ChannelBookmark.File != nil {
+				attachments = append(attachments, *bookmarkLine.ChannelBookmark.File)
+			}
+			if err := a.exportWriteLine(writer, bookmarkLine); err != nil {
+				return err
+			}
+			cnt++
+		}
+		updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "channel_bookmarks_exported", cnt)
+
+		return nil
+	}
+
+	afterId := strings.Repeat("0", 26)
+	for {
+		channels, err := a.Srv().Store().Channel().GetAllChannelsForExportAfter(1000, afterId)
+		if err != nil {
+			return nil, model.NewAppError("exportAllChannelBookmarks", "app.channel.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(channels) == 0 {
+			break
+		}
+
+		for _, channel := range channels {
+			afterId = channel.Id
+			if err := exportChannel(channel.Id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	afterId = strings.Repeat("0", 26)
+	for {
+		channels, err := a.Srv().Store().Channel().GetAllDirectChannelsForExportAfter(1000, afterId, false)
+		if err != nil {
+			return nil, model.NewAppError("exportAllChannelBookmarks", "app.channel.get_all_direct.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(channels) == 0 {
+			break
+		}
+
+		for _, channel := range channels {
+			afterId = channel.Id
+			if err := exportChannel(channel.Id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return attachments, nil
+}
+
+// exportThreadRootCreateAt returns the creation time of the root post by which drafts and
+// scheduled posts reference their thread, or false if the root post doesn't exist anymore.
+func (a *App) exportThreadRootCreateAt(rctx request.CTX, rootID string) (*int64, bool, *model.AppError) {
+	if rootID == "" {
+		return nil, true, nil
+	}
+
+	root, err := a.Srv().Store().Post().GetSingle(rctx, rootID, false)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, false, nil
+		}
+		return nil, false, model.NewAppError("exportThreadRootCreateAt", "app.post.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &root.CreateAt, true, nil
+}
+
+func (a *App) exportAllDrafts(rctx request.CTX, job *model.Job, writer io.Writer, filter *exportFilter, refs *exportReferences) *model.AppError {
+	afterId := strings.Repeat("0", 26)
+	cnt := 0
+	for {
+		users, err := a.Srv().Store().User().GetAllAfter(1000, afterId)
+		if err != nil {
+			return model.NewAppError("exportAllDrafts", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(users) == 0 {
+			break
+		}
+
+		for _, user := range users {
+			afterId = user.Id
+
+			drafts, err := a.Srv().Store().Draft().GetDraftsForUser(user.Id, "")
+			if err != nil {
+				return model.NewAppError("exportAllDrafts", "app.draft.get_drafts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+
+			for _, draft := range drafts {
+				if !filter.changedSince(draft.UpdateAt) {
+					continue
+				}
+
+				channel, channelRef, appErr := refs.channel(draft.ChannelId)
+				if appErr != nil {
+					return appErr
+				}
+				if channel == nil || !filter.includesChannel(channel.TeamId, channel.Id) {
+					continue
+				}
+
+				rootCreateAt, ok, appErr := a.exportThreadRootCreateAt(rctx, draft.RootId)
+				if appErr != nil {
+					return appErr
+				}
+				if !ok {
+					continue
+				}
+
+				if err := a.exportWriteLine(writer, importLineFromDraft(draft, channelRef, user.Username, rootCreateAt)); err != nil {
+					return err
+				}
+				cnt++
+			}
+		}
+		updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "drafts_exported", cnt)
+	}
+
+	return nil
+}
+
+func (a *App) exportAllScheduledPosts(rctx request.CTX, job *model.Job, writer io.Writer, filter *exportFilter, refs *exportReferences) *model.AppError {
+	afterId := strings.Repeat("0", 26)
+	cnt := 0
+	now := model.GetMillis()
+	for {
+		users, err := a.Srv().Store().User().GetAllAfter(1000, afterId)
+		if err != nil {
+			return model.NewAppError("exportAllScheduledPosts", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(users) == 0 {
+			break
+		}
+
+		for _, user := range users {
+			afterId = user.Id
+
+			teams, err := a.Srv().Store().Team().GetTeamsByUserId(user.Id)
+			if err != nil {
+				return model.NewAppError("exportAllScheduledPosts", "app.team.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+
+			// Scheduled posts of direct and group channels are the ones without a team.
+			teamIDs := []string{""}
+			for _, team := range teams {
+				teamIDs = append(teamIDs, team.Id)
+			}
+
+			for _, teamID := range teamIDs {
+				scheduledPosts, err := a.Srv().Store().ScheduledPost().GetScheduledPostsForUser(user.Id, teamID)
+				if err != nil {
+					return model.NewAppError("exportAllScheduledPosts", "app.get_user_team_scheduled_posts.error", map[string]any{"user_id": user.Id, "team_id": teamID}, "", http.StatusInternalServerError).Wrap(err)
+				}
+
+				for _, scheduledPost := range scheduledPosts {
+					// Only the posts still waiting to be sent are exported.
+					if scheduledPost.ErrorCode != "" || scheduledPost.ScheduledAt <= now {
+						continue
+					}
+					if !filter.changedSince(scheduledPost.UpdateAt) {
+						continue
+					}
+
+					channel, channelRef, appErr := refs.channel(scheduledPost.ChannelId)
+					if appErr != nil {
+						return appErr
+					}
+					if channel == nil || !filter.includesChannel(channel.TeamId, channel.Id) {
+						continue
+					}
+
+					rootCreateAt, ok, appErr := a.exportThreadRootCreateAt(rctx, scheduledPost.RootId)
+					if appErr != nil {
+						return appErr
+					}
+					if !ok {
+						continue
+					}
+
+					if err := a.exportWriteLine(writer, importLineFromScheduledPost(scheduledPost, channelRef, user.Username, rootCreateAt)); err != nil {
+						return err
+					}
+					cnt++
+				}
+			}
+		}
+		updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "scheduled_posts_exported", cnt)
+	}
+
+	return nil
+}
+
+func (a *App) exportAllIncomingWebhooks(rctx request.CTX, job *model.Job, writer io.Writer, filter *exportFilter, refs *exportReferences) *model.AppError {
+	offset := 0
+	cnt := 0
+	for {
+		hooks, err := a.Srv().Store().Webhook().GetIncomingList(offset, 1000)
+		if err != nil {
+			return model.NewAppError("exportAllIncomingWebhooks", "app.webhooks.get_incoming.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(hooks) == 0 {
+			break
+		}
+		offset += len(hooks)
+
+		for _, hook := range hooks {
+			if !filter.changedSince(hook.UpdateAt) {
+				continue
+			}
+
+			channel, channelRef, appErr := refs.channel(hook.ChannelId)
+			if appErr != nil {
+				return appErr
+			}
+			// Incoming webhooks only post to team channels.
+			if channel == nil || channelRef.Channel == nil || !filter.includesChannel(channel.TeamId, channel.Id) {
+				continue
+			}
+
+			username, appErr := refs.username(hook.UserId)
+			if appErr != nil {
+				return appErr
+			}
+			if username == "" {
+				continue
+			}
+
+			if err := a.exportWriteLine(writer, importLineFromIncomingWebhook(hook, channelRef, username)); err != nil {
+				return err
+			}
+			cnt++
+		}
+		updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "incoming_webhooks_exported", cnt)
+	}
+
+	return nil
+}
+
+func (a *App) exportAllOutgoingWebhooks(rctx request.CTX, job *model.Job, writer io.Writer, filter *exportFilter, refs *exportReferences) *model.AppError {
+	offset := 0
+	cnt := 0
+	for {
+		hooks, err := a.Srv().Store().Webhook().GetOutgoingList(offset, 1000)
+		if err != nil {
+			return model.NewAppError("exportAllOutgoingWebhooks", "app.webhooks.get_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(hooks) == 0 {
+			break
+		}
+		offset += len(hooks)
+
+		for _, hook := range hooks {
+			if !filter.changedSince(hook.UpdateAt) {
+				continue
+			}
+
+			team, appErr := refs.team(hook.TeamId)
+			if appErr != nil {
+				return appErr
+			}
+			if team == nil {
+				continue
+			}
+
+			var channelName *string
+			if hook.ChannelId != "" {
+				channel, channelRef, appErr := refs.channel(hook.ChannelId)
+				if appErr != nil {
+					return appErr
+				}
+				if channel == nil || !filter.includesChannel(channel.TeamId, channel.Id) {
+					continue
+				}
+				channelName = channelRef.Channel
+			} else if !filter.includesTeam(team.Id) {
+				continue
+			}
+
+			creator, appErr := refs.username(hook.CreatorId)
+			if appErr != nil {
+				return appErr
+			}
+			if creator == "" {
+				continue
+			}
+
+			if err := a.exportWriteLine(writer, importLineFromOutgoingWebhook(hook, team.Name, channelName, creator)); err != nil {
+				return err
+			}
+			cnt++
+		}
+		updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "outgoing_webhooks_exported", cnt)
+	}
+
+	return nil
+}
+
+func (a *App) exportAllCommands(rctx request.CTX, job *model.Job, writer io.Writer, filter *exportFilter, refs *exportReferences) *model.AppError {
+	afterId := strings.Repeat("0", 26)
+	cnt := 0
+	for {
+		teams, err := a.Srv().Store().Team().GetAllForExportAfter(1000, afterId)
+		if err != nil {
+			return model.NewAppError("exportAllCommands", "app.team.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(teams) == 0 {
+			break
+		}
+
+		for _, team := range teams {
+			afterId = team.Id
+
+			if team.DeleteAt != 0 || !filter.includesTeam(team.Id) {
+				continue
+			}
+
+			commands, err := a.Srv().Store().Command().GetByTeam(team.Id)
+			if err != nil {
+				return model.NewAppError("exportAllCommands", "app.command.listteamcommands.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+
+			for _, command := range commands {
+				// Commands registered by plugins are registered again by them.
+				if command.PluginId != "" || !filter.changedSince(command.UpdateAt) {
+					continue
+				}
+
+				creator, appErr := refs.username(command.CreatorId)
+				if appErr != nil {
+					return appErr
+				}
+				if creator == "" {
+					continue
+				}
+
+				if err := a.exportWriteLine(writer, importLineFromCommand(command, team.Name, creator)); err != nil {
+					return err
+				}
+				cnt++
+			}
+		}
+		updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "commands_exported", cnt)
+	}
+
+	return nil
+}
+
+// exportDeletes writes the delete lines of the entities deleted since the start of the export,
+// after all the others so that they apply to entities already imported.
+func (a *App) exportDeletes(rctx request.CTX, job *model.Job, writer io.Writer, includeArchivedChannels bool, filter *exportFilter) *model.AppError {
+	for _, line := range filter.deletes {
+		if err := a.exportWriteLine(writer, line); err != nil {
+			return err
+		}
+	}
+
+	afterId := strings.Repeat("0", 26)
+	postOpts := filter.postOpts(includeArchivedChannels)
+	cnt := len(filter.deletes)
+	updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "deletes_exported", cnt)
+	for {
+		posts, err := a.Srv().Store().Post().GetDeletedForExportAfter(1000, afterId, postOpts)
+		if err != nil {
+			return model.NewAppError("exportDeletes", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(posts) == 0 {
+			break
+		}
+		cnt += len(posts)
+		updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "deletes_exported", cnt)
+
+		for _, post := range posts {
+			afterId = post.Id
+
+			if err := a.exportWriteLine(writer, importLineForDeletedPost(post)); err != nil {
+				return err
+			}
+		}
+	}
+
+	afterId = ""
+	for {
+		emojis, err := a.Srv().Store().Emoji().GetDeletedForExportAfter(1000, afterId, filter.since)
+		if err != nil {
+			return model.NewAppError("exportDeletes", "app.emoji.get_list.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(emojis) == 0 {
+			break
+		}
+		cnt += len(emojis)
+		updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "deletes_exported", cnt)
+
+		for _, emoji := range emojis {
+			afterId = emoji.Id
+
+			if err := a.exportWriteLine(writer, importLineForDeletedEmoji(emoji)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func (a *App) exportFile(rctx request.CTX, outPath, filePath string, zipWr *zip.Writer) *model.AppError {
 	rd, appErr := a.FileReader(filePath)
 	if appErr != nil {
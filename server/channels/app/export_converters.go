@@ -4,6 +4,9 @@
 package app
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -298,6 +301,93 @@ func importLineForDirectPost(post *model.DirectPostForExport) *imports.LineImpor
 	}
 }
 
+func importLineForDeletedTeam(team *model.TeamForExport) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:     model.NewPointer(imports.DeleteTypeTeam),
+			Team:     &team.Name,
+			DeleteAt: &team.DeleteAt,
+		},
+	}
+}
+
+func importLineForDeletedChannel(channel *model.ChannelForExport) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:     model.NewPointer(imports.DeleteTypeChannel),
+			Team:     &channel.TeamName,
+			Channel:  &channel.Name,
+			DeleteAt: &channel.DeleteAt,
+		},
+	}
+}
+
+func importLineForDeletedTeamMember(member *model.TeamMemberForExport, username string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:     model.NewPointer(imports.DeleteTypeTeamMember),
+			Team:     &member.TeamName,
+			User:     &username,
+			DeleteAt: &member.DeleteAt,
+		},
+	}
+}
+
+func importLineForDeletedChannelMember(teamName, channelName, username string, leaveTime int64) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:     model.NewPointer(imports.DeleteTypeChannelMember),
+			Team:     &teamName,
+			Channel:  &channelName,
+			User:     &username,
+			DeleteAt: &leaveTime,
+		},
+	}
+}
+
+func importLineForDeletedEmoji(emoji *model.Emoji) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "delete",
+		Delete: &imports.DeleteImportData{
+			Type:     model.NewPointer(imports.DeleteTypeEmoji),
+			Emoji:    &emoji.Name,
+			DeleteAt: &emoji.DeleteAt,
+		},
+	}
+}
+
+func importLineForDeletedPost(post *model.DeletedPostForExport) *imports.LineImportData {
+	data := &imports.DeleteImportData{
+		Type:     model.NewPointer(imports.DeleteTypePost),
+		Team:     &post.TeamName,
+		Channel:  &post.ChannelName,
+		User:     &post.Username,
+		Message:  &post.Message,
+		CreateAt: &post.CreateAt,
+		DeleteAt: &post.DeleteAt,
+	}
+
+	if post.ChannelMembers != nil {
+		channelMembers := *post.ChannelMembers
+		if len(channelMembers) == 1 {
+			channelMembers = []string{channelMembers[0], channelMembers[0]}
+		}
+		data.Type = model.NewPointer(imports.DeleteTypeDirectPost)
+		data.Team = nil
+		data.Channel = nil
+		data.ChannelMembers = &channelMembers
+	}
+
+	return &imports.LineImportData{
+		Type:   "delete",
+		Delete: data,
+	}
+}
+
 func importReplyFromPost(post *model.ReplyForExport) *imports.ReplyImportData {
 	f := []string(post.FlaggedBy)
 	return &imports.ReplyImportData{
@@ -380,3 +470,172 @@ func importFollowerFromThreadMember(threadMember *model.ThreadMembershipForExpor
 		UnreadMentions: &threadMember.UnreadMentions,
 	}
 }
+
+func importLineFromChannelBookmark(bookmark *model.ChannelBookmarkWithFileInfo, channelRef *exportChannelReference, ownerUsername string) *imports.LineImportData {
+	data := &imports.ChannelBookmarkImportData{
+		Team:           channelRef.Team,
+		Channel:        channelRef.Channel,
+		ChannelMembers: channelRef.ChannelMembers,
+		Owner:          &ownerUsername,
+		DisplayName:    &bookmark.DisplayName,
+		Type:           &bookmark.Type,
+		SortOrder:      &bookmark.SortOrder,
+		CreateAt:       &bookmark.CreateAt,
+	}
+
+	if bookmark.LinkUrl != "" {
+		data.LinkUrl = &bookmark.LinkUrl
+	}
+	if bookmark.ImageUrl != "" {
+		data.ImageUrl = &bookmark.ImageUrl
+	}
+	if bookmark.Emoji != "" {
+		data.Emoji = &bookmark.Emoji
+	}
+	if bookmark.FileInfo != nil {
+		data.File = &imports.AttachmentImportData{Path: &bookmark.FileInfo.Path}
+	}
+
+	return &imports.LineImportData{
+		Type:            "channel_bookmark",
+		ChannelBookmark: data,
+	}
+}
+
+func importLineFromDraft(draft *model.Draft, channelRef *exportChannelReference, username string, rootCreateAt *int64) *imports.LineImportData {
+	props := draft.GetProps()
+	return &imports.LineImportData{
+		Type: "draft",
+		Draft: &imports.DraftImportData{
+			Team:           channelRef.Team,
+			Channel:        channelRef.Channel,
+			ChannelMembers: channelRef.ChannelMembers,
+			User:           &username,
+			RootCreateAt:   rootCreateAt,
+			Type:           &draft.Type,
+			Message:        &draft.Message,
+			Props:          &props,
+			CreateAt:       &draft.CreateAt,
+			UpdateAt:       &draft.UpdateAt,
+		},
+	}
+}
+
+func importLineFromScheduledPost(scheduledPost *model.ScheduledPost, channelRef *exportChannelReference, username string, rootCreateAt *int64) *imports.LineImportData {
+	props := scheduledPost.GetProps()
+	return &imports.LineImportData{
+		Type: "scheduled_post",
+		ScheduledPost: &imports.ScheduledPostImportData{
+			Team:           channelRef.Team,
+			Channel:        channelRef.Channel,
+			ChannelMembers: channelRef.ChannelMembers,
+			User:           &username,
+			RootCreateAt:   rootCreateAt,
+			Type:           &scheduledPost.Type,
+			Message:        &scheduledPost.Message,
+			Props:          &props,
+			CreateAt:       &scheduledPost.CreateAt,
+			ScheduledAt:    &scheduledPost.ScheduledAt,
+		},
+	}
+}
+
+func importLineFromIncomingWebhook(hook *model.IncomingWebhook, channelRef *exportChannelReference, username string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "incoming_webhook",
+		IncomingWebhook: &imports.IncomingWebhookImportData{
+			Id:            &hook.Id,
+			Team:          channelRef.Team,
+			Channel:       channelRef.Channel,
+			User:          &username,
+			DisplayName:   &hook.DisplayName,
+			Description:   &hook.Description,
+			Username:      &hook.Username,
+			IconURL:       &hook.IconURL,
+			ChannelLocked: &hook.ChannelLocked,
+		},
+	}
+}
+
+func importLineFromOutgoingWebhook(hook *model.OutgoingWebhook, teamName string, channelName *string, creatorUsername string) *imports.LineImportData {
+	triggerWords := []string(hook.TriggerWords)
+	callbackURLs := []string(hook.CallbackURLs)
+	return &imports.LineImportData{
+		Type: "outgoing_webhook",
+		OutgoingWebhook: &imports.OutgoingWebhookImportData{
+			Team:         &teamName,
+			Channel:      channelName,
+			Creator:      &creatorUsername,
+			DisplayName:  &hook.DisplayName,
+			Description:  &hook.Description,
+			Token:        &hook.Token,
+			TriggerWords: &triggerWords,
+			TriggerWhen:  &hook.TriggerWhen,
+			CallbackURLs: &callbackURLs,
+			ContentType:  &hook.ContentType,
+			Username:     &hook.Username,
+			IconURL:      &hook.IconURL,
+		},
+	}
+}
+
+func importLineFromCommand(command *model.Command, teamName, creatorUsername string) *imports.LineImportData {
+	return &imports.LineImportData{
+		Type: "command",
+		Command: &imports.CommandImportData{
+			Team:             &teamName,
+			Creator:          &creatorUsername,
+			Trigger:          &command.Trigger,
+			Method:           &command.Method,
+			URL:              &command.URL,
+			Token:            &command.Token,
+			Username:         &command.Username,
+			IconURL:          &command.IconURL,
+			AutoComplete:     &command.AutoComplete,
+			AutoCompleteDesc: &command.AutoCompleteDesc,
+			AutoCompleteHint: &command.AutoCompleteHint,
+			DisplayName:      &command.DisplayName,
+			Description:      &command.Description,
+		},
+	}
+}
+
+// customProfileAttributeValueForExport returns the exported form of the value of a custom profile
+// attribute, in which options are referenced by name as their ids differ between servers.
+func customProfileAttributeValueForExport(field *model.CPAField, value json.RawMessage) (json.RawMessage, error) {
+	optionNames := make(map[string]string, len(field.Attrs.Options))
+	for _, option := range field.Attrs.Options {
+		optionNames[option.ID] = option.Name
+	}
+
+	switch field.Type {
+	case model.PropertyFieldTypeSelect:
+		var id string
+		if err := json.Unmarshal(value, &id); err != nil {
+			return nil, err
+		}
+		name, ok := optionNames[id]
+		if !ok {
+			return nil, fmt.Errorf("unknown option %q", id)
+		}
+		return json.Marshal(name)
+	case model.PropertyFieldTypeMultiselect:
+		var ids []string
+		if err := json.Unmarshal(value, &ids); err != nil {
+			return nil, err
+		}
+		names := make([]string, len(ids))
+		for i, id := range ids {
+			name, ok := optionNames[id]
+			if !ok {
+				return nil, fmt.Errorf("unknown option %q", id)
+			}
+			names[i] = name
+		}
+		return json.Marshal(names)
+	case model.PropertyFieldTypeUser, model.PropertyFieldTypeMultiuser:
+		return nil, errors.New("user values are not supported")
+	default:
+		return value, nil
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -113,7 +114,7 @@ func TestExportUserChannels(t *testing.T) {
 
 	_, appErr = th.App.UpdateChannelMemberNotifyProps(th.Context, notifyProps, channel.Id, user.Id)
 	require.Nil(t, appErr)
-	exportData, appErr := th.App.buildUserChannelMemberships(th.Context, user.Id, team.Id, false)
+	exportData, _, appErr := th.App.buildUserChannelMemberships(th.Context, user.Id, team.Id, false, &exportFilter{})
 	require.Nil(t, appErr)
 	assert.Equal(t, len(*exportData), 3)
 	for _, data := range *exportData {
@@ -187,7 +188,7 @@ func TestExportCustomEmoji(t *testing.T) {
 	outPath, err := filepath.Abs(filePath)
 	require.NoError(t, err)
 
-	_, appErr := th.App.exportCustomEmoji(th.Context, nil, fileWriter, outPath, dirNameToExportEmoji, false)
+	_, appErr := th.App.exportCustomEmoji(th.Context, nil, fileWriter, outPath, dirNameToExportEmoji, false, &exportFilter{})
 	require.Nil(t, appErr, "should not have failed")
 }
 
@@ -569,7 +570,7 @@ func TestExportDMandGMPost(t *testing.T) {
 	_, _, appErr = th1.App.CreatePost(th1.Context, p4, gmChannel, model.CreatePostFlags{SetOnline: true})
 	require.Nil(t, appErr)
 
-	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", model.PostExportOpts{})
 	require.NoError(t, err)
 	assert.Equal(t, 4, len(posts))
 
@@ -579,7 +580,7 @@ func TestExportDMandGMPost(t *testing.T) {
 
 	th2 := Setup(t)
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", model.PostExportOpts{})
 	require.NoError(t, err)
 	assert.Equal(t, 0, len(posts))
 
@@ -588,7 +589,7 @@ func TestExportDMandGMPost(t *testing.T) {
 	assert.Nil(t, appErr)
 	assert.Equal(t, 0, i)
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", model.PostExportOpts{})
 	require.NoError(t, err)
 
 	// Adding some determinism so its possible to assert on slice index
@@ -642,7 +643,7 @@ func TestExportPostWithProps(t *testing.T) {
 	_, _, appErr = th1.App.CreatePost(th1.Context, p2, gmChannel, model.CreatePostFlags{SetOnline: true})
 	require.Nil(t, appErr)
 
-	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", model.PostExportOpts{})
 	require.NoError(t, err)
 	assert.Len(t, posts, 2)
 	require.NotEmpty(t, posts[0].Props)
@@ -654,7 +655,7 @@ func TestExportPostWithProps(t *testing.T) {
 
 	th2 := Setup(t)
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", model.PostExportOpts{})
 	require.NoError(t, err)
 	assert.Len(t, posts, 0)
 
@@ -663,7 +664,7 @@ func TestExportPostWithProps(t *testing.T) {
 	assert.Nil(t, appErr)
 	assert.Equal(t, 0, i)
 
-	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, err = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", model.PostExportOpts{})
 	require.NoError(t, err)
 
 	// Adding some determinism so its possible to assert on slice index
@@ -720,13 +721,13 @@ func TestExportDMPostWithSelf(t *testing.T) {
 	err := th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{})
 	require.Nil(t, err)
 
-	posts, nErr := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr := th1.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", model.PostExportOpts{})
 	require.NoError(t, nErr)
 	assert.Equal(t, 1, len(posts))
 
 	th2 := Setup(t)
 
-	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", model.PostExportOpts{})
 	require.NoError(t, nErr)
 	assert.Equal(t, 0, len(posts))
 
@@ -735,7 +736,7 @@ func TestExportDMPostWithSelf(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 0, i)
 
-	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", false)
+	posts, nErr = th2.App.Srv().Store().Post().GetDirectPostParentsForExportAfter(1000, "0000000", model.PostExportOpts{})
 	require.NoError(t, nErr)
 	assert.Equal(t, 1, len(posts))
 	assert.Equal(t, 1, len((*posts[0].ChannelMembers)))
@@ -1728,3 +1729,368 @@ func TestExportDeactivatedUserDMs(t *testing.T) {
 	require.True(t, foundThreadedReplyInImport,
 		"Threaded reply from deactivated user should be imported")
 }
+
+func TestExportChannelBookmarksDraftsWebhooksAndCommands(t *testing.T) {
+	mainHelper.Parallel(t)
+	th1 := Setup(t).InitBasic(t)
+
+	_, err := th1.App.Srv().Store().ChannelBookmark().Save(&model.ChannelBookmark{
+		ChannelId:   th1.BasicChannel.Id,
+		OwnerId:     th1.BasicUser.Id,
+		DisplayName: "docs",
+		LinkUrl:     "https://example.com/docs",
+		Type:        model.ChannelBookmarkLink,
+	}, true)
+	require.NoError(t, err)
+
+	_, err = th1.App.Srv().Store().Draft().Upsert(&model.Draft{
+		UserId:    th1.BasicUser.Id,
+		ChannelId: th1.BasicChannel.Id,
+		RootId:    th1.BasicPost.Id,
+		Message:   "thread draft",
+	})
+	require.NoError(t, err)
+
+	_, err = th1.App.Srv().Store().ScheduledPost().CreateScheduledPost(&model.ScheduledPost{
+		Draft: model.Draft{
+			UserId:    th1.BasicUser.Id,
+			ChannelId: th1.BasicChannel.Id,
+			Message:   "scheduled",
+		},
+		ScheduledAt: model.GetMillis() + 24*60*60*1000,
+	})
+	require.NoError(t, err)
+
+	incoming, err := th1.App.Srv().Store().Webhook().SaveIncoming(&model.IncomingWebhook{
+		UserId:      th1.BasicUser.Id,
+		ChannelId:   th1.BasicChannel.Id,
+		TeamId:      th1.BasicTeam.Id,
+		DisplayName: "incoming",
+	})
+	require.NoError(t, err)
+
+	outgoing, err := th1.App.Srv().Store().Webhook().SaveOutgoing(&model.OutgoingWebhook{
+		CreatorId:    th1.BasicUser.Id,
+		TeamId:       th1.BasicTeam.Id,
+		ChannelId:    th1.BasicChannel.Id,
+		DisplayName:  "outgoing",
+		TriggerWords: []string{"build"},
+		CallbackURLs: []string{"https://example.com/hook"},
+		ContentType:  "application/json",
+	})
+	require.NoError(t, err)
+
+	command, err := th1.App.Srv().Store().Command().Save(&model.Command{
+		CreatorId: th1.BasicUser.Id,
+		TeamId:    th1.BasicTeam.Id,
+		Trigger:   "deploy",
+		Method:    model.CommandMethodPost,
+		URL:       "https://example.com/command",
+	})
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	appErr := th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+
+	th2 := Setup(t)
+	i, appErr := th2.App.BulkImport(th2.Context, &b, nil, false, 5)
+	require.Nil(t, appErr)
+	assert.Equal(t, 0, i)
+
+	team, err := th2.App.Srv().Store().Team().GetByName(th1.BasicTeam.Name)
+	require.NoError(t, err)
+	channel, err := th2.App.Srv().Store().Channel().GetByName(team.Id, th1.BasicChannel.Name, false)
+	require.NoError(t, err)
+	user, err := th2.App.Srv().Store().User().GetByUsername(th1.BasicUser.Username)
+	require.NoError(t, err)
+
+	bookmarks, err := th2.App.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+	require.NoError(t, err)
+	require.Len(t, bookmarks, 1)
+	assert.Equal(t, "docs", bookmarks[0].DisplayName)
+	assert.Equal(t, "https://example.com/docs", bookmarks[0].LinkUrl)
+	assert.Equal(t, user.Id, bookmarks[0].OwnerId)
+
+	drafts, err := th2.App.Srv().Store().Draft().GetDraftsForUser(user.Id, "")
+	require.NoError(t, err)
+	require.Len(t, drafts, 1)
+	assert.Equal(t, "thread draft", drafts[0].Message)
+	root, err := th2.App.Srv().Store().Post().GetSingle(th2.Context, drafts[0].RootId, false)
+	require.NoError(t, err)
+	assert.Equal(t, th1.BasicPost.CreateAt, root.CreateAt)
+
+	scheduledPosts, err := th2.App.Srv().Store().ScheduledPost().GetScheduledPostsForUser(user.Id, team.Id)
+	require.NoError(t, err)
+	require.Len(t, scheduledPosts, 1)
+	assert.Equal(t, "scheduled", scheduledPosts[0].Message)
+
+	incomingHooks, err := th2.App.Srv().Store().Webhook().GetIncomingByChannel(channel.Id)
+	require.NoError(t, err)
+	require.Len(t, incomingHooks, 1)
+	assert.Equal(t, "incoming", incomingHooks[0].DisplayName)
+	// The id is kept so that the URL of the webhook keeps working.
+	assert.Equal(t, incoming.Id, incomingHooks[0].Id)
+
+	outgoingHooks, err := th2.App.Srv().Store().Webhook().GetOutgoingByTeam(team.Id, -1, -1)
+	require.NoError(t, err)
+	require.Len(t, outgoingHooks, 1)
+	assert.Equal(t, outgoing.Token, outgoingHooks[0].Token)
+	assert.Equal(t, channel.Id, outgoingHooks[0].ChannelId)
+	assert.Equal(t, []string{"build"}, []string(outgoingHooks[0].TriggerWords))
+
+	importedCommand, err := th2.App.Srv().Store().Command().GetByTrigger(team.Id, "deploy")
+	require.NoError(t, err)
+	assert.Equal(t, command.Token, importedCommand.Token)
+	assert.Equal(t, user.Id, importedCommand.CreatorId)
+
+	// Importing the same file again doesn't duplicate anything.
+	b.Reset()
+	appErr = th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+	_, appErr = th2.App.BulkImport(th2.Context, &b, nil, false, 5)
+	require.Nil(t, appErr)
+
+	bookmarks, err = th2.App.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+	require.NoError(t, err)
+	assert.Len(t, bookmarks, 1)
+	incomingHooks, err = th2.App.Srv().Store().Webhook().GetIncomingByChannel(channel.Id)
+	require.NoError(t, err)
+	require.Len(t, incomingHooks, 1)
+	assert.Equal(t, incoming.Id, incomingHooks[0].Id)
+	outgoingHooks, err = th2.App.Srv().Store().Webhook().GetOutgoingByTeam(team.Id, -1, -1)
+	require.NoError(t, err)
+	assert.Len(t, outgoingHooks, 1)
+}
+
+func TestBulkExportScopeAndSince(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	otherTeam := th.CreateTeam(t)
+	otherChannel := th.CreateChannel(t, otherTeam)
+	otherPost := th.CreatePost(t, otherChannel)
+	secondChannel := th.CreateChannel(t, th.BasicTeam)
+	secondPost := th.CreatePost(t, secondChannel)
+
+	// exportNames returns the names of the teams and channels and the messages of the posts and
+	// of the deleted posts of an export.
+	exportNames := func(t *testing.T, opts model.BulkExportOpts) (teams, channels, posts, deletes []string) {
+		var b bytes.Buffer
+		appErr := th.App.BulkExport(th.Context, &b, "somePath", nil, opts)
+		require.Nil(t, appErr)
+
+		scanner := bufio.NewScanner(&b)
+		for scanner.Scan() {
+			var line imports.LineImportData
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+
+			switch line.Type {
+			case "team":
+				teams = append(teams, *line.Team.Name)
+			case "channel":
+				channels = append(channels, *line.Channel.Name)
+			case "post":
+				posts = append(posts, *line.Post.Message)
+			case "delete":
+				if line.Delete.Message != nil {
+					deletes = append(deletes, *line.Delete.Message)
+				}
+			}
+		}
+		require.NoError(t, scanner.Err())
+		return teams, channels, posts, deletes
+	}
+
+	t.Run("teams", func(t *testing.T) {
+		teams, channels, posts, _ := exportNames(t, model.BulkExportOpts{TeamIds: []string{th.BasicTeam.Id}})
+		assert.Equal(t, []string{th.BasicTeam.Name}, teams)
+		assert.Contains(t, channels, th.BasicChannel.Name)
+		assert.Contains(t, channels, secondChannel.Name)
+		assert.NotContains(t, channels, otherChannel.Name)
+		assert.Contains(t, posts, th.BasicPost.Message)
+		assert.Contains(t, posts, secondPost.Message)
+		assert.NotContains(t, posts, otherPost.Message)
+	})
+
+	t.Run("channels", func(t *testing.T) {
+		// The team of the channel is exported along with it.
+		teams, channels, posts, _ := exportNames(t, model.BulkExportOpts{ChannelIds: []string{secondChannel.Id}})
+		assert.Equal(t, []string{th.BasicTeam.Name}, teams)
+		assert.Equal(t, []string{secondChannel.Name}, channels)
+		assert.Contains(t, posts, secondPost.Message)
+		assert.NotContains(t, posts, th.BasicPost.Message)
+		assert.NotContains(t, posts, otherPost.Message)
+	})
+
+	t.Run("since", func(t *testing.T) {
+		since := model.GetMillis()
+		newPost := th.CreatePost(t, th.BasicChannel, func(post *model.Post) {
+			post.CreateAt = since + 1
+		})
+		time.Sleep(2 * time.Millisecond)
+		_, appErr := th.App.DeletePost(th.Context, secondPost.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		teams, _, posts, deletes := exportNames(t, model.BulkExportOpts{Since: since})
+		assert.Empty(t, teams)
+		assert.Equal(t, []string{newPost.Message}, posts)
+		assert.Equal(t, []string{secondPost.Message}, deletes)
+
+		// Scoped incremental exports only contain the changes of their teams and channels.
+		_, _, posts, deletes = exportNames(t, model.BulkExportOpts{Since: since, ChannelIds: []string{otherChannel.Id}})
+		assert.Empty(t, posts)
+		assert.Empty(t, deletes)
+	})
+
+	t.Run("memberships and emojis", func(t *testing.T) {
+		emoji, err := th.App.Srv().Store().Emoji().Save(&model.Emoji{CreatorId: th.BasicUser.Id, Name: model.NewId()})
+		require.NoError(t, err)
+		th.LinkUserToTeam(t, th.BasicUser2, otherTeam)
+		th.AddUserToChannel(t, th.BasicUser2, secondChannel)
+
+		since := model.GetMillis()
+		time.Sleep(2 * time.Millisecond)
+		require.Nil(t, th.App.RemoveUserFromChannel(th.Context, th.BasicUser2.Id, th.BasicUser.Id, secondChannel))
+		require.Nil(t, th.App.RemoveUserFromTeam(th.Context, otherTeam.Id, th.BasicUser2.Id, th.BasicUser.Id))
+		require.Nil(t, th.App.DeleteEmoji(th.Context, emoji))
+
+		exportDeletes := func(t *testing.T, opts model.BulkExportOpts) []imports.DeleteImportData {
+			var b bytes.Buffer
+			appErr := th.App.BulkExport(th.Context, &b, "somePath", nil, opts)
+			require.Nil(t, appErr)
+
+			var deletes []imports.DeleteImportData
+			scanner := bufio.NewScanner(&b)
+			for scanner.Scan() {
+				var line imports.LineImportData
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+				if line.Type == "delete" && *line.Delete.Type != imports.DeleteTypePost {
+					deletes = append(deletes, *line.Delete)
+				}
+			}
+			require.NoError(t, scanner.Err())
+			return deletes
+		}
+
+		deletes := exportDeletes(t, model.BulkExportOpts{Since: since})
+		require.Len(t, deletes, 3)
+		assert.Equal(t, imports.DeleteTypeTeamMember, *deletes[0].Type)
+		assert.Equal(t, otherTeam.Name, *deletes[0].Team)
+		assert.Equal(t, th.BasicUser2.Username, *deletes[0].User)
+		assert.Equal(t, imports.DeleteTypeChannelMember, *deletes[1].Type)
+		assert.Equal(t, th.BasicTeam.Name, *deletes[1].Team)
+		assert.Equal(t, secondChannel.Name, *deletes[1].Channel)
+		assert.Equal(t, th.BasicUser2.Username, *deletes[1].User)
+		assert.Equal(t, imports.DeleteTypeEmoji, *deletes[2].Type)
+		assert.Equal(t, emoji.Name, *deletes[2].Emoji)
+
+		// Emojis aren't scoped to teams.
+		deletes = exportDeletes(t, model.BulkExportOpts{Since: since, TeamIds: []string{th.BasicTeam.Id}})
+		require.Len(t, deletes, 2)
+		assert.Equal(t, imports.DeleteTypeChannelMember, *deletes[0].Type)
+		assert.Equal(t, imports.DeleteTypeEmoji, *deletes[1].Type)
+	})
+}
+
+func TestBulkExportChainedImport(t *testing.T) {
+	mainHelper.Parallel(t)
+	th1 := Setup(t).InitBasic(t)
+
+	// Posts get explicit times so that they can't be created at the same time as the basic post.
+	createAt := th1.BasicPost.CreateAt
+	postToDelete := th1.CreatePost(t, th1.BasicChannel, func(post *model.Post) {
+		post.CreateAt = createAt + 1
+	})
+	unchangedPost := th1.CreatePost(t, th1.BasicChannel, func(post *model.Post) {
+		post.CreateAt = createAt + 2
+	})
+	channelToArchive := th1.CreateChannel(t, th1.BasicTeam)
+	channelToLeave := th1.CreateChannel(t, th1.BasicTeam)
+	th1.AddUserToChannel(t, th1.BasicUser2, channelToLeave)
+	teamToLeave := th1.CreateTeam(t)
+	th1.LinkUserToTeam(t, th1.BasicUser2, teamToLeave)
+
+	var b bytes.Buffer
+	appErr := th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+
+	th2 := Setup(t)
+	i, appErr := th2.App.BulkImport(th2.Context, &b, nil, false, 5)
+	require.Nil(t, appErr)
+	require.Equal(t, 0, i)
+
+	since := model.GetMillis()
+	time.Sleep(2 * time.Millisecond)
+
+	editedPost := th1.BasicPost.Clone()
+	editedPost.Message = "edited message"
+	_, _, appErr = th1.App.UpdatePost(th1.Context, editedPost, &model.UpdatePostOptions{SafeUpdate: false})
+	require.Nil(t, appErr)
+	newPost := th1.CreatePost(t, th1.BasicChannel, func(post *model.Post) {
+		post.CreateAt = model.GetMillis()
+	})
+	_, appErr = th1.App.DeletePost(th1.Context, postToDelete.Id, th1.BasicUser.Id)
+	require.Nil(t, appErr)
+	appErr = th1.App.DeleteChannel(th1.Context, channelToArchive, th1.SystemAdminUser.Id)
+	require.Nil(t, appErr)
+	appErr = th1.App.RemoveUserFromChannel(th1.Context, th1.BasicUser2.Id, th1.BasicUser2.Id, channelToLeave)
+	require.Nil(t, appErr)
+	appErr = th1.App.RemoveUserFromTeam(th1.Context, teamToLeave.Id, th1.BasicUser2.Id, th1.BasicUser2.Id)
+	require.Nil(t, appErr)
+
+	b.Reset()
+	appErr = th1.App.BulkExport(th1.Context, &b, "somePath", nil, model.BulkExportOpts{Since: since})
+	require.Nil(t, appErr)
+	export := b.String()
+
+	// The incremental export is imported on top of the full one, and importing it again
+	// doesn't change anything.
+	for range 2 {
+		i, appErr = th2.App.BulkImport(th2.Context, strings.NewReader(export), nil, false, 5)
+		require.Nil(t, appErr)
+		require.Equal(t, 0, i)
+
+		team, appErr := th2.App.GetTeamByName(th1.BasicTeam.Name)
+		require.Nil(t, appErr)
+		channel, err := th2.App.Srv().Store().Channel().GetByName(team.Id, th1.BasicChannel.Name, true)
+		require.NoError(t, err)
+
+		posts, err := th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, createAt)
+		require.NoError(t, err)
+		require.Len(t, posts, 1, "the edited post shouldn't be duplicated")
+		assert.Equal(t, "edited message", posts[0].Message)
+		assert.Zero(t, posts[0].DeleteAt)
+
+		posts, err = th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, postToDelete.CreateAt)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.NotZero(t, posts[0].DeleteAt)
+
+		posts, err = th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, unchangedPost.CreateAt)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, unchangedPost.Message, posts[0].Message)
+
+		posts, err = th2.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, newPost.CreateAt)
+		require.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, newPost.Message, posts[0].Message)
+
+		archived, err := th2.App.Srv().Store().Channel().GetByNameIncludeDeleted(team.Id, channelToArchive.Name, true)
+		require.NoError(t, err)
+		assert.NotZero(t, archived.DeleteAt)
+
+		user, err := th2.App.Srv().Store().User().GetByUsername(th1.BasicUser2.Username)
+		require.NoError(t, err)
+		left, err := th2.App.Srv().Store().Channel().GetByName(team.Id, channelToLeave.Name, true)
+		require.NoError(t, err)
+		_, err = th2.App.Srv().Store().Channel().GetMember(th2.Context, left.Id, user.Id)
+		require.Error(t, err)
+		leftTeam, appErr := th2.App.GetTeamByName(teamToLeave.Name)
+		require.Nil(t, appErr)
+		member, err := th2.App.Srv().Store().Team().GetMember(th2.Context, leftTeam.Id, user.Id)
+		require.NoError(t, err)
+		assert.NotZero(t, member.DeleteAt)
+	}
+}
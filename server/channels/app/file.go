@@ -9,6 +9,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"image"
 	"io"
@@ -374,6 +375,14 @@ func removeFile(backend filestore.FileBackend, path string) *model.AppError {
 }
 
 func (s *Server) removeFile(path string) *model.AppError {
+	// Blobs are shared by every file with the same content, so they are only removed once no
+	// file is stored in them anymore.
+	if hash, ok := model.FileBlobHash(path); ok {
+		if err := s.blobStore().Collect(hash); err != nil {
+			return model.NewAppError("RemoveFile", "api.file.remove_file.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return nil
+	}
 	return removeFile(s.FileBackend(), path)
 }
 
@@ -875,7 +884,8 @@ func (a *App) UploadFileX(rctx request.CTX, channelID, name string, input io.Rea
 		}
 	}
 
-	written, aerr := t.writeFile(io.MultiReader(t.buf, t.limitedInput), t.fileinfo.Path)
+	hash := sha256.New()
+	written, aerr := t.writeFile(io.TeeReader(io.MultiReader(t.buf, t.limitedInput), hash), t.fileinfo.Path)
 	if aerr != nil {
 		return nil, aerr
 	}
@@ -888,6 +898,7 @@ func (a *App) UploadFileX(rctx request.CTX, channelID, name string, input io.Rea
 	}
 
 	t.fileinfo.Size = written
+	t.fileinfo.ContentHash = hex.EncodeToString(hash.Sum(nil))
 
 	file, aerr := a.FileReader(t.fileinfo.Path)
 	if aerr != nil {
@@ -909,6 +920,10 @@ func (a *App) UploadFileX(rctx request.CTX, channelID, name string, input io.Rea
 		t.postprocessImage(file)
 	}
 
+	if a.IsFileScanEnabled() {
+		t.fileinfo.ScanStatus = model.FileScanStatusPending
+	}
+
 	if _, err := t.saveToDatabase(rctx, t.fileinfo); err != nil {
 		var appErr *model.AppError
 		switch {
@@ -919,7 +934,11 @@ func (a *App) UploadFileX(rctx request.CTX, channelID, name string, input io.Rea
 		}
 	}
 
-	if *a.Config().FileSettings.ExtractContent && t.ExtractContent {
+	a.deduplicateUploadedFile(rctx, t.fileinfo)
+
+	if a.IsFileScanEnabled() {
+		a.scanUploadedFile(rctx, t.fileinfo, t.ExtractContent, true)
+	} else if *a.Config().FileSettings.ExtractContent && t.ExtractContent {
 		infoCopy := *t.fileinfo
 		if !a.Srv().GoExtraction(func() {
 			err := a.ExtractContentFromFileInfo(rctx, &infoCopy)
@@ -1174,6 +1193,11 @@ func (a *App) DoUploadFileExpectModification(rctx request.CTX, now time.Time, ra
 	if _, err := a.WriteFile(bytes.NewReader(data), info.Path); err != nil {
 		return nil, data, err
 	}
+	info.ContentHash = fmt.Sprintf("%x", sha256.Sum256(data))
+
+	if a.IsFileScanEnabled() {
+		info.ScanStatus = model.FileScanStatusPending
+	}
 
 	if _, err := a.Srv().Store().FileInfo().Save(rctx, info); err != nil {
 		var appErr *model.AppError
@@ -1185,10 +1209,14 @@ func (a *App) DoUploadFileExpectModification(rctx request.CTX, now time.Time, ra
 		}
 	}
 
+	a.deduplicateUploadedFile(rctx, info)
+
 	// The extra boolean extractContent is used to turn off extraction
 	// during the import process. It is unnecessary overhead during the import,
 	// and something we can do without.
-	if *a.Config().FileSettings.ExtractContent && extractContent {
+	if a.IsFileScanEnabled() {
+		a.scanUploadedFile(rctx, info, extractContent, true)
+	} else if *a.Config().FileSettings.ExtractContent && extractContent {
 		infoCopy := *info
 		if !a.Srv().GoExtraction(func() {
 			err := a.ExtractContentFromFileInfo(rctx, &infoCopy)
@@ -1458,7 +1486,21 @@ func (a *App) CopyFileInfos(rctx request.CTX, userID string, fileIDs []string) (
 		fileInfo.PostId = ""
 		fileInfo.ChannelId = ""
 
-		if _, err := a.Srv().Store().FileInfo().Save(rctx, fileInfo); err != nil {
+		// The copy shares the blob of a deduplicated file, which is held until the copy is saved
+		// in case the original file is removed in the meantime.
+		if fileInfo.IsDeduplicated() {
+			if _, err := a.Srv().Store().FileInfo().AcquireBlob(fileInfo.ContentHash, fileInfo.Size); err != nil {
+				return nil, model.NewAppError("CopyFileInfos", "app.file_info.acquire_blob.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+
+		_, err = a.Srv().Store().FileInfo().Save(rctx, fileInfo)
+		if fileInfo.IsDeduplicated() {
+			if releaseErr := a.Srv().blobStore().Release(fileInfo.ContentHash); releaseErr != nil {
+				rctx.Logger().Warn("Failed to release the blob of the file", mlog.String("file_id", fileInfo.Id), mlog.Err(releaseErr))
+			}
+		}
+		if err != nil {
 			var appErr *model.AppError
 			switch {
 			case errors.As(err, &appErr):
@@ -1946,6 +1988,7 @@ func (a *App) PermanentDeleteFilesByPost(rctx request.CTX, postID string, report
 
 		return model.NewAppError("PermanentDeleteFilesByPost", i18n.TranslationId("app.file_info.permanent_delete_for_post.app_error"), nil, "", http.StatusInternalServerError).Wrap(err)
 	}
+	a.collectFileBlobs(rctx, fileInfos)
 
 	if report != nil {
 		report.AddStepWithParams(i18n.TranslationId("app.data_spillage.report.step.fileinfo_rows"), model.StepSuccess, i18n.TranslationId("app.data_spillage.report.detail.file_attachments_info_ids"), map[string]any{"FileInfoIDs": strings.Join(fileInfoIDs, ", ")}, nil)
@@ -1961,23 +2004,42 @@ func (a *App) RemoveFilesFromFileStore(rctx request.CTX, fileInfos []*model.File
 	errs := []*model.AppError{}
 
 	for _, info := range fileInfos {
-		appErr := a.RemoveFileFromFileStore(rctx, info.Path)
+		path, previewPath, thumbnailPath := info.Path, info.PreviewPath, info.ThumbnailPath
+		if info.IsQuarantined() {
+			path, previewPath, thumbnailPath = model.QuarantinePath(path), model.QuarantinePath(previewPath), model.QuarantinePath(thumbnailPath)
+		}
+
+		appErr := a.RemoveFileFromFileStore(rctx, path)
 		if appErr != nil && appErr.StatusCode != http.StatusNotFound {
 			newAppErr := model.NewAppError("RemoveFilesFromFileStore", "app.file_info.remove_file.app_error", map[string]any{"FileInfoID": info.Id}, "", http.StatusInternalServerError)
 			errs = append(errs, newAppErr)
 		}
 
-		if info.PreviewPath != "" {
-			_ = a.RemoveFileFromFileStore(rctx, info.PreviewPath)
+		if previewPath != "" {
+			_ = a.RemoveFileFromFileStore(rctx, previewPath)
 		}
-		if info.ThumbnailPath != "" {
-			_ = a.RemoveFileFromFileStore(rctx, info.ThumbnailPath)
+		if thumbnailPath != "" {
+			_ = a.RemoveFileFromFileStore(rctx, thumbnailPath)
 		}
 	}
 
 	return errs
 }
 
+// collectFileBlobs removes the blobs of the deduplicated files given once no other file is
+// stored in them. It is called after deleting the files, as the blobs of files removed from
+// the file store are kept until then.
+func (a *App) collectFileBlobs(rctx request.CTX, fileInfos []*model.FileInfo) {
+	for _, info := range fileInfos {
+		if !info.IsDeduplicated() {
+			continue
+		}
+		if err := a.Srv().blobStore().Collect(info.ContentHash); err != nil {
+			rctx.Logger().Warn("Failed to remove the blob of the file", mlog.String("file_id", info.Id), mlog.Err(err))
+		}
+	}
+}
+
 func (a *App) RemoveFileFromFileStore(rctx request.CTX, path string) *model.AppError {
 	res, appErr := a.FileExists(path)
 	if appErr != nil {
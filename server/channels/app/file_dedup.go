@@ -0,0 +1,150 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+func (a *App) IsFileDeduplicationEnabled() bool {
+	return *a.Config().FileSettings.DeduplicateFiles
+}
+
+const fileBlobCleanupBatchSize = 1000
+
+func (s *Server) blobStore() *filestore.BlobStore {
+	return filestore.NewBlobStore(s.FileBackend(), s.Store().FileInfo())
+}
+
+// removeUnreferencedFileBlobs removes the blobs no file is stored in anymore.
+func (s *Server) removeUnreferencedFileBlobs(rctx request.CTX) {
+	afterHash := ""
+	for {
+		hashes, err := s.Store().FileInfo().GetUnreferencedBlobs(afterHash, fileBlobCleanupBatchSize)
+		if err != nil {
+			rctx.Logger().Warn("Failed to get the unreferenced file blobs", mlog.Err(err))
+			return
+		}
+
+		for _, hash := range hashes {
+			if err := s.blobStore().Collect(hash); err != nil {
+				rctx.Logger().Warn("Failed to remove the file blob", mlog.String("hash", hash), mlog.Err(err))
+			}
+		}
+
+		if len(hashes) < fileBlobCleanupBatchSize {
+			return
+		}
+		afterHash = hashes[len(hashes)-1]
+	}
+}
+
+// DeduplicateFile moves the content of a file to the blob shared by every file with the same
+// content, computing the content hash of the file when it isn't known yet.
+func (a *App) DeduplicateFile(rctx request.CTX, info *model.FileInfo) *model.AppError {
+	if info.IsDeduplicated() || info.IsQuarantined() {
+		return nil
+	}
+
+	hash := info.ContentHash
+	if hash == "" {
+		file, appErr := a.FileReader(info.Path)
+		if appErr != nil {
+			return appErr
+		}
+		defer file.Close()
+
+		hasher := sha256.New()
+		if _, err := io.Copy(hasher, file); err != nil {
+			return model.NewAppError("DeduplicateFile", "app.file.deduplicate.hash.app_error", nil, "file_id="+info.Id, http.StatusInternalServerError).Wrap(err)
+		}
+		hash = hex.EncodeToString(hasher.Sum(nil))
+	}
+
+	// Storing the content holds the blob until the files are moved to it.
+	blobs := a.Srv().blobStore()
+	blobPath, err := blobs.Store(info.Path, hash, info.Size)
+	if err != nil {
+		return model.NewAppError("DeduplicateFile", "app.file.deduplicate.store.app_error", nil, "file_id="+info.Id, http.StatusInternalServerError).Wrap(err)
+	}
+	defer func() {
+		if err := blobs.Release(hash); err != nil {
+			rctx.Logger().Warn("Failed to release the blob of the file", mlog.String("file_id", info.Id), mlog.Err(err))
+		}
+	}()
+
+	// Copies of a file share its path, so they are all moved to the blob along with it.
+	fileIDs, err := a.Srv().Store().FileInfo().MoveFilesToBlob(rctx, info.Path, hash)
+	if err != nil {
+		return model.NewAppError("DeduplicateFile", "app.file_info.set_content_hash.app_error", nil, "file_id="+info.Id, http.StatusInternalServerError).Wrap(err)
+	}
+
+	originalPath := info.Path
+	info.ContentHash = hash
+	info.Path = blobPath
+
+	if appErr := a.RemoveFile(originalPath); appErr != nil {
+		rctx.Logger().Warn("Failed to remove the deduplicated file", mlog.String("file_id", info.Id), mlog.String("path", originalPath), mlog.Err(appErr))
+	}
+
+	infos, err := a.Srv().Store().FileInfo().GetByIds(fileIDs, true, false, true)
+	if err != nil {
+		rctx.Logger().Warn("Failed to get the deduplicated files", mlog.String("file_id", info.Id), mlog.Err(err))
+	}
+	for _, movedInfo := range infos {
+		if movedInfo.PostId != "" {
+			a.Srv().Store().FileInfo().InvalidateFileInfosForPostCache(movedInfo.PostId, false)
+			a.Srv().Store().FileInfo().InvalidateFileInfosForPostCache(movedInfo.PostId, true)
+		}
+	}
+
+	return nil
+}
+
+// deduplicateUploadedFile deduplicates a newly uploaded file when enabled. When file scanning
+// is enabled, files are only deduplicated once they are found clean.
+func (a *App) deduplicateUploadedFile(rctx request.CTX, info *model.FileInfo) {
+	if !a.IsFileDeduplicationEnabled() {
+		return
+	}
+	if a.IsFileScanEnabled() && info.ScanStatus != model.FileScanStatusClean {
+		return
+	}
+
+	if appErr := a.DeduplicateFile(rctx, info); appErr != nil {
+		rctx.Logger().Warn("Failed to deduplicate the uploaded file", mlog.String("file_id", info.Id), mlog.Err(appErr))
+	}
+}
+
+// detachFileBlob gives a deduplicated file its own copy of the content of its blob, so that
+// the file can be moved without affecting the other files sharing the blob.
+func (a *App) detachFileBlob(rctx request.CTX, info *model.FileInfo) *model.AppError {
+	path := time.UnixMilli(info.CreateAt).Format("20060102") + "/teams/noteam/channels/" + info.ChannelId + "/users/" + info.CreatorId + "/" + info.Id + "/" + info.Name
+	if err := a.FileBackend().CopyFile(info.Path, path); err != nil {
+		return model.NewAppError("detachFileBlob", "app.file.deduplicate.detach.app_error", nil, "file_id="+info.Id, http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().FileInfo().SetContentHash(rctx, info.Id, info.ContentHash, path); err != nil {
+		if appErr := a.RemoveFile(path); appErr != nil {
+			rctx.Logger().Warn("Failed to remove the copy of the blob of the file", mlog.String("file_id", info.Id), mlog.Err(appErr))
+		}
+		return model.NewAppError("detachFileBlob", "app.file_info.set_content_hash.app_error", nil, "file_id="+info.Id, http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().blobStore().Collect(info.ContentHash); err != nil {
+		rctx.Logger().Warn("Failed to remove the blob of the file", mlog.String("file_id", info.Id), mlog.Err(err))
+	}
+	info.Path = path
+
+	return nil
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestDeduplicateFile(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	upload := func(t *testing.T, data string) *model.FileInfo {
+		t.Helper()
+
+		info, appErr := th.App.UploadFileX(th.Context, th.BasicChannel.Id, "file.txt", bytes.NewReader([]byte(data)),
+			UploadFileSetTeamId(th.BasicTeam.Id),
+			UploadFileSetUserId(th.BasicUser.Id),
+			UploadFileSetTimestamp(time.Now()),
+		)
+		require.Nil(t, appErr)
+
+		info, err := th.App.Srv().Store().FileInfo().GetFromMaster(info.Id)
+		require.NoError(t, err)
+		return info
+	}
+
+	fileExists := func(t *testing.T, path string) bool {
+		t.Helper()
+
+		exists, appErr := th.App.FileExists(path)
+		require.Nil(t, appErr)
+		return exists
+	}
+
+	// deleteFile removes a file as permanently deleting its post does.
+	deleteFile := func(t *testing.T, info *model.FileInfo) {
+		t.Helper()
+
+		require.Empty(t, th.App.RemoveFilesFromFileStore(th.Context, []*model.FileInfo{info}))
+		require.NoError(t, th.App.Srv().Store().FileInfo().PermanentDelete(th.Context, info.Id))
+		th.App.collectFileBlobs(th.Context, []*model.FileInfo{info})
+	}
+
+	hashOf := func(data string) string {
+		return fmt.Sprintf("%x", sha256.Sum256([]byte(data)))
+	}
+
+	t.Run("uploads record the content hash", func(t *testing.T) {
+		info := upload(t, "not deduplicated")
+
+		assert.Equal(t, hashOf("not deduplicated"), info.ContentHash)
+		assert.False(t, info.IsDeduplicated())
+		assert.True(t, fileExists(t, info.Path))
+	})
+
+	t.Run("uploads with the same content share a blob", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.DeduplicateFiles = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.DeduplicateFiles = false })
+
+		data := "shared content " + model.NewId()
+		first := upload(t, data)
+		second := upload(t, data)
+
+		require.True(t, first.IsDeduplicated())
+		require.True(t, second.IsDeduplicated())
+		assert.Equal(t, model.FileBlobPath(hashOf(data)), first.Path)
+		assert.Equal(t, first.Path, second.Path)
+
+		read, appErr := th.App.ReadFile(second.Path)
+		require.Nil(t, appErr)
+		assert.Equal(t, []byte(data), read)
+
+		// The blob is only removed along with its last file, even when removing a file is retried.
+		require.Empty(t, th.App.RemoveFilesFromFileStore(th.Context, []*model.FileInfo{first}))
+		deleteFile(t, first)
+		assert.True(t, fileExists(t, second.Path))
+		deleteFile(t, second)
+		assert.False(t, fileExists(t, second.Path))
+	})
+
+	t.Run("copies reference the blob", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.DeduplicateFiles = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.DeduplicateFiles = false })
+
+		info := upload(t, "copied content "+model.NewId())
+		require.True(t, info.IsDeduplicated())
+
+		copyIDs, appErr := th.App.CopyFileInfos(th.Context, th.BasicUser.Id, []string{info.Id})
+		require.Nil(t, appErr)
+		copied, err := th.App.Srv().Store().FileInfo().GetFromMaster(copyIDs[0])
+		require.NoError(t, err)
+		assert.Equal(t, info.Path, copied.Path)
+
+		deleteFile(t, info)
+		assert.True(t, fileExists(t, copied.Path))
+		deleteFile(t, copied)
+		assert.False(t, fileExists(t, copied.Path))
+	})
+
+	t.Run("existing files are deduplicated", func(t *testing.T) {
+		data := "existing content " + model.NewId()
+		info := upload(t, data)
+		require.False(t, info.IsDeduplicated())
+		originalPath := info.Path
+
+		// Files uploaded before content hashes were recorded have their hash computed.
+		require.NoError(t, th.App.Srv().Store().FileInfo().SetContentHash(th.Context, info.Id, "", info.Path))
+		info.ContentHash = ""
+
+		appErr := th.App.DeduplicateFile(th.Context, info)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.FileBlobPath(hashOf(data)), info.Path)
+		assert.False(t, fileExists(t, originalPath))
+		assert.True(t, fileExists(t, info.Path))
+
+		saved, err := th.App.Srv().Store().FileInfo().GetFromMaster(info.Id)
+		require.NoError(t, err)
+		assert.Equal(t, hashOf(data), saved.ContentHash)
+		assert.True(t, saved.IsDeduplicated())
+
+		// Deduplicating a file again does nothing.
+		require.Nil(t, th.App.DeduplicateFile(th.Context, saved))
+
+		deleteFile(t, saved)
+		assert.False(t, fileExists(t, saved.Path))
+	})
+
+	t.Run("copies are deduplicated along with their file", func(t *testing.T) {
+		data := "copied before deduplication " + model.NewId()
+		info := upload(t, data)
+		require.False(t, info.IsDeduplicated())
+		originalPath := info.Path
+
+		copyIDs, appErr := th.App.CopyFileInfos(th.Context, th.BasicUser.Id, []string{info.Id})
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.DeduplicateFile(th.Context, info))
+		assert.False(t, fileExists(t, originalPath))
+
+		copied, err := th.App.Srv().Store().FileInfo().GetFromMaster(copyIDs[0])
+		require.NoError(t, err)
+		require.True(t, copied.IsDeduplicated())
+		assert.Equal(t, info.Path, copied.Path)
+
+		// The copy keeps its own reference to the blob.
+		deleteFile(t, info)
+		read, appErr := th.App.ReadFile(copied.Path)
+		require.Nil(t, appErr)
+		assert.Equal(t, []byte(data), read)
+
+		deleteFile(t, copied)
+		assert.False(t, fileExists(t, copied.Path))
+	})
+
+	t.Run("blobs left without files are removed", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.DeduplicateFiles = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.FileSettings.DeduplicateFiles = false })
+
+		data := "retained content " + model.NewId()
+		first := upload(t, data)
+		second := upload(t, data)
+		require.True(t, first.IsDeduplicated())
+
+		// Data retention deletes the files without touching the file store.
+		require.NoError(t, th.App.Srv().Store().FileInfo().PermanentDelete(th.Context, first.Id))
+		th.App.Srv().removeUnreferencedFileBlobs(th.Context)
+		assert.True(t, fileExists(t, second.Path))
+
+		require.NoError(t, th.App.Srv().Store().FileInfo().PermanentDelete(th.Context, second.Id))
+		th.App.Srv().removeUnreferencedFileBlobs(th.Context)
+		assert.False(t, fileExists(t, second.Path))
+	})
+
+	t.Run("infected files are detached from their blob", func(t *testing.T) {
+		var infected atomic.Bool
+		address := startFakeClamd(t, &infected)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.FileSettings.DeduplicateFiles = true
+			*cfg.FileScanSettings.Enable = true
+			*cfg.FileScanSettings.Type = model.FileScanTypeClamd
+			*cfg.FileScanSettings.Address = address
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.FileSettings.DeduplicateFiles = false
+			*cfg.FileScanSettings.Enable = false
+		})
+
+		data := "EICAR " + model.NewId()
+		var infos []*model.FileInfo
+		for range 2 {
+			info := upload(t, data)
+			require.Eventually(t, func() bool {
+				var err error
+				info, err = th.App.Srv().Store().FileInfo().GetFromMaster(info.Id)
+				require.NoError(t, err)
+				return info.IsDeduplicated()
+			}, 5*time.Second, 50*time.Millisecond)
+			infos = append(infos, info)
+		}
+
+		infected.Store(true)
+		require.Nil(t, th.App.ScanFile(th.Context, infos[0]))
+		assert.Equal(t, model.FileScanStatusInfected, infos[0].ScanStatus)
+		assert.False(t, infos[0].IsDeduplicated())
+		assert.True(t, fileExists(t, model.QuarantinePath(infos[0].Path)))
+
+		read, appErr := th.App.ReadFile(infos[1].Path)
+		require.Nil(t, appErr)
+		assert.Equal(t, []byte(data), read)
+	})
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/filescan"
+)
+
+const (
+	// pendingFileScansInterval is how often the files left pending, or whose scan failed, are
+	// scanned again.
+	pendingFileScansInterval  = 5 * time.Minute
+	pendingFileScansBatchSize = 100
+	// maxFailedFileScanBackoff is the longest a file whose scan keeps failing waits for its
+	// next scan.
+	maxFailedFileScanBackoff = 24 * time.Hour
+)
+
+func (a *App) IsFileScanEnabled() bool {
+	return *a.Config().FileScanSettings.Enable
+}
+
+// ScanFile scans a file with the scanning server of the config and records the verdict on the file.
+// Infected files are moved, with their thumbnail and preview, to the quarantine directory of the
+// file store. Quarantined files found clean when scanned again are restored.
+func (a *App) ScanFile(rctx request.CTX, info *model.FileInfo) *model.AppError {
+	settings := a.Config().FileScanSettings
+
+	scanner, err := filescan.New(settings)
+	if err != nil {
+		return model.NewAppError("ScanFile", "app.file.scan.scanner.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	path := info.Path
+	if info.IsQuarantined() {
+		path = model.QuarantinePath(info.Path)
+	}
+
+	file, appErr := a.FileReader(path)
+	if appErr != nil {
+		return appErr
+	}
+	defer file.Close()
+
+	// The scan outlives the request of uploads, as files are scanned in the background.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(rctx.Context()), time.Duration(*settings.TimeoutSeconds)*time.Second)
+	defer cancel()
+
+	result, err := scanner.Scan(ctx, info.Name, file)
+	if err != nil {
+		// A file already found infected stays in quarantine.
+		if !info.IsQuarantined() {
+			if appErr := a.setFileScanStatus(rctx, info, model.FileScanStatusFailed); appErr != nil {
+				rctx.Logger().Warn("Failed to record the scan status of the file", mlog.String("file_id", info.Id), mlog.Err(appErr))
+			}
+		}
+		return model.NewAppError("ScanFile", "app.file.scan.failed.app_error", nil, "file_id="+info.Id, http.StatusInternalServerError).Wrap(err)
+	}
+
+	status := model.FileScanStatusClean
+	if result.Infected {
+		status = model.FileScanStatusInfected
+		rctx.Logger().Warn("Infected file found", mlog.String("file_id", info.Id), mlog.String("threat", result.Threat))
+
+		if !info.IsQuarantined() {
+			// The blob of a deduplicated file is shared with other files.
+			if info.IsDeduplicated() {
+				if appErr := a.detachFileBlob(rctx, info); appErr != nil {
+					return appErr
+				}
+			}
+			if appErr := a.moveFileInfoFiles(info, false); appErr != nil {
+				return appErr
+			}
+		}
+	} else if info.IsQuarantined() {
+		rctx.Logger().Info("Restoring quarantined file found clean", mlog.String("file_id", info.Id))
+
+		if appErr := a.moveFileInfoFiles(info, true); appErr != nil {
+			return appErr
+		}
+	}
+
+	return a.setFileScanStatus(rctx, info, status)
+}
+
+// moveFileInfoFiles moves the files of a file info to the quarantine directory, or back from it.
+func (a *App) moveFileInfoFiles(info *model.FileInfo, restore bool) *model.AppError {
+	for _, path := range []string{info.Path, info.ThumbnailPath, info.PreviewPath} {
+		if path == "" {
+			continue
+		}
+
+		from, to := path, model.QuarantinePath(path)
+		if restore {
+			from, to = to, from
+		}
+
+		// Thumbnails and previews are missing when they could not be generated.
+		if exists, appErr := a.FileExists(from); appErr != nil {
+			return appErr
+		} else if !exists && path != info.Path {
+			continue
+		}
+
+		if appErr := a.MoveFile(from, to); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
+
+func (a *App) setFileScanStatus(rctx request.CTX, info *model.FileInfo, status string) *model.AppError {
+	scannedAt := model.GetMillis()
+	if err := a.Srv().Store().FileInfo().SetScanStatus(rctx, info.Id, status, scannedAt); err != nil {
+		return model.NewAppError("setFileScanStatus", "app.file_info.set_scan_status.app_error", nil, "file_id="+info.Id, http.StatusInternalServerError).Wrap(err)
+	}
+
+	info.ScanStatus = status
+	info.ScannedAt = scannedAt
+
+	if info.PostId != "" {
+		a.Srv().Store().FileInfo().InvalidateFileInfosForPostCache(info.PostId, false)
+		a.Srv().Store().FileInfo().InvalidateFileInfosForPostCache(info.PostId, true)
+	}
+
+	return nil
+}
+
+// scanUploadedFile scans a file in the background once it is uploaded. The content of the
+// file is only extracted, and the file deduplicated, once the file is found clean, so that
+// infected files are never parsed.
+func (a *App) scanUploadedFile(rctx request.CTX, info *model.FileInfo, extractContent, deduplicate bool) {
+	infoCopy := *info
+	a.Srv().Go(func() {
+		a.scanAndProcessFile(rctx, &infoCopy, extractContent, deduplicate)
+	})
+}
+
+func (a *App) scanAndProcessFile(rctx request.CTX, info *model.FileInfo, extractContent, deduplicate bool) {
+	if appErr := a.ScanFile(rctx, info); appErr != nil {
+		rctx.Logger().Error("Failed to scan file", mlog.String("file_id", info.Id), mlog.Err(appErr))
+		return
+	}
+
+	a.processScannedFile(rctx, info, extractContent, deduplicate)
+}
+
+// processScannedFile deduplicates a file and extracts its content once it is found clean.
+func (a *App) processScannedFile(rctx request.CTX, info *model.FileInfo, extractContent, deduplicate bool) {
+	if deduplicate {
+		a.deduplicateUploadedFile(rctx, info)
+	}
+
+	if info.ScanStatus != model.FileScanStatusClean || !extractContent || !*a.Config().FileSettings.ExtractContent {
+		return
+	}
+
+	if err := a.ExtractContentFromFileInfo(rctx, info); err != nil {
+		rctx.Logger().Error("Failed to extract file content", mlog.Err(err), mlog.String("fileInfoId", info.Id))
+	}
+}
+
+// RescanFile scans a file again. Files that weren't clean yet, and are found clean, are then
+// deduplicated and their content extracted, as their upload would have done.
+func (a *App) RescanFile(rctx request.CTX, info *model.FileInfo) *model.AppError {
+	// Files scanned clean, or uploaded while scanning was disabled, were processed already.
+	processed := info.ScanStatus == "" || info.ScanStatus == model.FileScanStatusClean
+
+	if appErr := a.ScanFile(rctx, info); appErr != nil {
+		return appErr
+	}
+
+	if !processed {
+		a.processScannedFile(rctx, info, true, true)
+	}
+
+	return nil
+}
+
+// RescanPendingFiles scans the files still waiting for their scan, whose background scan was
+// lost, for instance because the server was restarted while scanning them. Files uploaded
+// recently are left to the scan started by their upload. Files whose scan failed are scanned
+// again too, waiting twice as long after each failure.
+func (a *App) RescanPendingFiles(rctx request.CTX) {
+	if !a.IsFileScanEnabled() {
+		return
+	}
+
+	timeout := time.Duration(*a.Config().FileScanSettings.TimeoutSeconds) * time.Second
+	createdBefore := model.GetMillis() - (2 * timeout).Milliseconds()
+	a.rescanFiles(rctx, "pending", func(afterID string) ([]*model.FileInfo, error) {
+		return a.Srv().Store().FileInfo().GetPendingScans(createdBefore, afterID, pendingFileScansBatchSize)
+	})
+
+	now := model.GetMillis()
+	a.rescanFiles(rctx, "failed", func(afterID string) ([]*model.FileInfo, error) {
+		return a.Srv().Store().FileInfo().GetFailedScans(now, maxFailedFileScanBackoff.Milliseconds(), afterID, pendingFileScansBatchSize)
+	})
+}
+
+func (a *App) rescanFiles(rctx request.CTX, status string, getFiles func(afterID string) ([]*model.FileInfo, error)) {
+	var afterID string
+	for {
+		infos, err := getFiles(afterID)
+		if err != nil {
+			rctx.Logger().Error("Failed to get the files to scan again", mlog.String("scan_status", status), mlog.Err(err))
+			return
+		}
+
+		for _, info := range infos {
+			rctx.Logger().Info("Scanning file again", mlog.String("file_id", info.Id), mlog.String("scan_status", status))
+			a.scanAndProcessFile(rctx, info, true, true)
+		}
+
+		if len(infos) < pendingFileScansBatchSize {
+			return
+		}
+		afterID = infos[len(infos)-1].Id
+	}
+}
+
+// CheckFileScanStatus returns an error when the scan status of a file prevents downloading it.
+func (a *App) CheckFileScanStatus(info *model.FileInfo) *model.AppError {
+	switch info.ScanStatus {
+	case model.FileScanStatusPending:
+		return model.NewAppError("CheckFileScanStatus", "api.file.get_file.scan_pending.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+	case model.FileScanStatusInfected:
+		return model.NewAppError("CheckFileScanStatus", "api.file.get_file.infected.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+	case model.FileScanStatusFailed:
+		if !*a.Config().FileScanSettings.AllowDownloadOfFailedScans {
+			return model.NewAppError("CheckFileScanStatus", "api.file.get_file.scan_failed.app_error", nil, "file_id="+info.Id, http.StatusForbidden)
+		}
+	}
+
+	return nil
+}
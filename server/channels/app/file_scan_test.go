@@ -0,0 +1,261 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// startFakeClamd starts a clamd server finding the files containing "EICAR" infected,
+// unless infected is set to false. It replies with an error to files containing "error".
+func startFakeClamd(t *testing.T, infected *atomic.Bool) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				r := bufio.NewReader(conn)
+				if _, err := r.ReadString(0); err != nil {
+					return
+				}
+
+				var data []byte
+				for {
+					var size uint32
+					if err := binary.Read(r, binary.BigEndian, &size); err != nil || size == 0 {
+						break
+					}
+					chunk := make([]byte, size)
+					if _, err := io.ReadFull(r, chunk); err != nil {
+						return
+					}
+					data = append(data, chunk...)
+				}
+
+				switch {
+				case bytes.Contains(data, []byte("error")):
+					conn.Write([]byte("stream: Can't allocate memory ERROR\x00"))
+				case bytes.Contains(data, []byte("EICAR")) && infected.Load():
+					conn.Write([]byte("stream: Eicar-Signature FOUND\x00"))
+				default:
+					conn.Write([]byte("stream: OK\x00"))
+				}
+			}()
+		}
+	}()
+
+	return "tcp://" + listener.Addr().String()
+}
+
+func TestScanFile(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	var infected atomic.Bool
+	infected.Store(true)
+	address := startFakeClamd(t, &infected)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.FileScanSettings.Enable = true
+		*cfg.FileScanSettings.Type = model.FileScanTypeClamd
+		*cfg.FileScanSettings.Address = address
+	})
+
+	upload := func(t *testing.T, data string) *model.FileInfo {
+		t.Helper()
+
+		info, appErr := th.App.UploadFileX(th.Context, th.BasicChannel.Id, "file.txt", bytes.NewReader([]byte(data)),
+			UploadFileSetTeamId(th.BasicTeam.Id),
+			UploadFileSetUserId(th.BasicUser.Id),
+			UploadFileSetTimestamp(time.Now()),
+		)
+		require.Nil(t, appErr)
+		require.Equal(t, model.FileScanStatusPending, info.ScanStatus)
+
+		return info
+	}
+
+	waitForScan := func(t *testing.T, fileID string) *model.FileInfo {
+		t.Helper()
+
+		var info *model.FileInfo
+		require.Eventually(t, func() bool {
+			var err error
+			info, err = th.App.Srv().Store().FileInfo().GetFromMaster(fileID)
+			require.NoError(t, err)
+			return info.ScanStatus != model.FileScanStatusPending
+		}, 5*time.Second, 50*time.Millisecond)
+
+		return info
+	}
+
+	fileExists := func(t *testing.T, path string) bool {
+		t.Helper()
+
+		exists, appErr := th.App.FileExists(path)
+		require.Nil(t, appErr)
+		return exists
+	}
+
+	t.Run("pending files cannot be downloaded", func(t *testing.T) {
+		appErr := th.App.CheckFileScanStatus(&model.FileInfo{ScanStatus: model.FileScanStatusPending})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.file.get_file.scan_pending.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	t.Run("clean file", func(t *testing.T) {
+		info := waitForScan(t, upload(t, "clean file").Id)
+
+		assert.Equal(t, model.FileScanStatusClean, info.ScanStatus)
+		assert.NotZero(t, info.ScannedAt)
+		assert.True(t, fileExists(t, info.Path))
+		assert.Nil(t, th.App.CheckFileScanStatus(info))
+	})
+
+	t.Run("infected file is quarantined and restored when found clean", func(t *testing.T) {
+		info := waitForScan(t, upload(t, "EICAR test file").Id)
+
+		assert.Equal(t, model.FileScanStatusInfected, info.ScanStatus)
+		assert.False(t, fileExists(t, info.Path))
+		assert.True(t, fileExists(t, model.QuarantinePath(info.Path)))
+
+		appErr := th.App.CheckFileScanStatus(info)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.file.get_file.infected.app_error", appErr.Id)
+
+		// Scanning the file again once the scanning server no longer finds it infected.
+		infected.Store(false)
+		defer infected.Store(true)
+
+		appErr = th.App.ScanFile(th.Context, info)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.FileScanStatusClean, info.ScanStatus)
+		assert.True(t, fileExists(t, info.Path))
+		assert.False(t, fileExists(t, model.QuarantinePath(info.Path)))
+
+		info, err := th.App.Srv().Store().FileInfo().GetFromMaster(info.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.FileScanStatusClean, info.ScanStatus)
+	})
+
+	t.Run("failed scan", func(t *testing.T) {
+		info := waitForScan(t, upload(t, "error").Id)
+
+		assert.Equal(t, model.FileScanStatusFailed, info.ScanStatus)
+		assert.True(t, fileExists(t, info.Path))
+
+		appErr := th.App.CheckFileScanStatus(info)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.file.get_file.scan_failed.app_error", appErr.Id)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.FileScanSettings.AllowDownloadOfFailedScans = true
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.FileScanSettings.AllowDownloadOfFailedScans = false
+		})
+		assert.Nil(t, th.App.CheckFileScanStatus(info))
+	})
+
+	t.Run("files left pending are scanned again", func(t *testing.T) {
+		savePending := func(t *testing.T, createAt int64) *model.FileInfo {
+			t.Helper()
+
+			path := "data/" + model.NewId() + "/file.txt"
+			_, appErr := th.App.WriteFile(bytes.NewReader([]byte("clean file")), path)
+			require.Nil(t, appErr)
+
+			info, err := th.App.Srv().Store().FileInfo().Save(th.Context, &model.FileInfo{
+				CreatorId:  th.BasicUser.Id,
+				Path:       path,
+				Name:       "file.txt",
+				CreateAt:   createAt,
+				ScanStatus: model.FileScanStatusPending,
+			})
+			require.NoError(t, err)
+			return info
+		}
+
+		// the scan of the old file was lost, while the recent one may still be scanned.
+		old := savePending(t, model.GetMillis()-time.Hour.Milliseconds())
+		recent := savePending(t, model.GetMillis())
+
+		// Files whose scan failed are scanned again once they waited since their last scan as
+		// long as before it.
+		failed := savePending(t, model.GetMillis()-time.Hour.Milliseconds())
+		require.NoError(t, th.App.Srv().Store().FileInfo().SetScanStatus(th.Context, failed.Id, model.FileScanStatusFailed, model.GetMillis()-30*time.Minute.Milliseconds()))
+		recentlyFailed := savePending(t, model.GetMillis()-time.Hour.Milliseconds())
+		require.NoError(t, th.App.Srv().Store().FileInfo().SetScanStatus(th.Context, recentlyFailed.Id, model.FileScanStatusFailed, model.GetMillis()-time.Minute.Milliseconds()))
+
+		th.App.RescanPendingFiles(th.Context)
+
+		info, err := th.App.Srv().Store().FileInfo().GetFromMaster(old.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.FileScanStatusClean, info.ScanStatus)
+
+		info, err = th.App.Srv().Store().FileInfo().GetFromMaster(recent.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.FileScanStatusPending, info.ScanStatus)
+
+		info, err = th.App.Srv().Store().FileInfo().GetFromMaster(failed.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.FileScanStatusClean, info.ScanStatus)
+
+		info, err = th.App.Srv().Store().FileInfo().GetFromMaster(recentlyFailed.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.FileScanStatusFailed, info.ScanStatus)
+	})
+
+	t.Run("files found clean by a rescan are processed", func(t *testing.T) {
+		info := waitForScan(t, upload(t, "EICAR rescanned file").Id)
+		require.Equal(t, model.FileScanStatusInfected, info.ScanStatus)
+
+		infected.Store(false)
+		defer infected.Store(true)
+
+		require.Nil(t, th.App.RescanFile(th.Context, info))
+
+		info, err := th.App.Srv().Store().FileInfo().GetFromMaster(info.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.FileScanStatusClean, info.ScanStatus)
+		assert.Contains(t, info.Content, "rescanned")
+	})
+
+	t.Run("files uploaded while scanning is disabled are not scanned", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.FileScanSettings.Enable = false
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.FileScanSettings.Enable = true
+		})
+
+		info, appErr := th.App.DoUploadFile(th.Context, time.Now(), th.BasicTeam.Id, th.BasicChannel.Id, th.BasicUser.Id, "file.txt", []byte("EICAR test file"), false)
+		require.Nil(t, appErr)
+		assert.Empty(t, info.ScanStatus)
+		assert.Nil(t, th.App.CheckFileScanStatus(info))
+	})
+}
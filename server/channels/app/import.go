@@ -146,6 +146,14 @@ func processAttachments(rctx request.CTX, line *imports.LineImportData, basePath
 				}
 			}
 		}
+	case "channel_bookmark":
+		if line.ChannelBookmark != nil && line.ChannelBookmark.File != nil {
+			files := []imports.AttachmentImportData{*line.ChannelBookmark.File}
+			if err := processAttachmentPaths(rctx, &files, basePath, filesMap); err != nil {
+				return err
+			}
+			*line.ChannelBookmark.File = files[0]
+		}
 	}
 
 	return nil
@@ -394,6 +402,41 @@ func (a *App) importLine(rctx request.CTX, line imports.LineImportData, dryRun b
 			return model.NewAppError("BulkImport", "app.import.import_line.null_emoji.error", nil, "", http.StatusBadRequest)
 		}
 		return a.importEmoji(rctx, line.Emoji, dryRun)
+	case line.Type == "channel_bookmark":
+		if line.ChannelBookmark == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_channel_bookmark.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importChannelBookmark(rctx, line.ChannelBookmark, dryRun)
+	case line.Type == "draft":
+		if line.Draft == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_draft.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importDraft(rctx, line.Draft, dryRun)
+	case line.Type == "scheduled_post":
+		if line.ScheduledPost == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_scheduled_post.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importScheduledPost(rctx, line.ScheduledPost, dryRun)
+	case line.Type == "incoming_webhook":
+		if line.IncomingWebhook == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_incoming_webhook.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importIncomingWebhook(rctx, line.IncomingWebhook, dryRun)
+	case line.Type == "outgoing_webhook":
+		if line.OutgoingWebhook == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_outgoing_webhook.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importOutgoingWebhook(rctx, line.OutgoingWebhook, dryRun)
+	case line.Type == "command":
+		if line.Command == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_command.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importCommand(rctx, line.Command, dryRun)
+	case line.Type == "delete":
+		if line.Delete == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_delete.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importDelete(rctx, line.Delete, dryRun)
 	default:
 		return model.NewAppError("BulkImport", "app.import.import_line.unknown_line_type.error", map[string]any{"Type": line.Type}, "", http.StatusBadRequest)
 	}
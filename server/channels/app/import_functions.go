@@ -6,12 +6,14 @@ package app
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path"
+	"slices"
 	"strings"
 	"sync"
 
@@ -829,6 +831,12 @@ func (a *App) importUser(rctx request.CTX, data *imports.UserImportData, dryRun
 		}
 	}
 
+	if data.CustomProfileAttributes != nil {
+		if appErr := a.importCustomProfileAttributes(rctx, savedUser, *data.CustomProfileAttributes); appErr != nil {
+			return appErr
+		}
+	}
+
 	return a.importUserTeams(rctx, savedUser, data.Teams)
 }
 
@@ -1458,6 +1466,9 @@ func (a *App) importReplies(rctx request.CTX, data []imports.ReplyImportData, po
 			}
 		}
 
+		if reply == nil {
+			reply = findEditedPost(replies, user.Id, post.Id, replyData.EditAt)
+		}
 		if reply == nil {
 			reply = &model.Post{}
 		}
@@ -1818,6 +1829,23 @@ func (a *App) getChannelsForPosts(teams map[string]*model.Team, data []*imports.
 
 // getPostStrID returns a string ID composed of several post fields to
 // uniquely identify a post before it's imported, so it has no ID yet
+// findEditedPost returns the post an imported post edited at editAt refers to, among the posts of
+// its channel created at the same time. As its message may have changed since it was imported,
+// an edited post is identified by its user and thread instead.
+func findEditedPost(posts []*model.Post, userID, rootID string, editAt *int64) *model.Post {
+	if editAt == nil || *editAt == 0 {
+		return nil
+	}
+
+	for _, post := range posts {
+		if post.UserId == userID && post.RootId == rootID && post.OriginalId == "" && post.EditAt < *editAt {
+			return post
+		}
+	}
+
+	return nil
+}
+
 func getPostStrID(post *model.Post) string {
 	return fmt.Sprintf("%d%s%s", post.CreateAt, post.ChannelId, post.Message)
 }
@@ -1900,6 +1928,9 @@ func (a *App) importMultiplePostLines(rctx request.CTX, lines []imports.LineImpo
 			}
 		}
 
+		if post == nil {
+			post = findEditedPost(posts, user.Id, "", line.Post.EditAt)
+		}
 		if post == nil {
 			post = &model.Post{}
 		}
@@ -2454,6 +2485,9 @@ func (a *App) importMultipleDirectPostLines(rctx request.CTX, lines []imports.Li
 			}
 		}
 
+		if post == nil {
+			post = findEditedPost(posts, user.Id, "", line.DirectPost.EditAt)
+		}
 		if post == nil {
 			post = &model.Post{}
 		}
@@ -2687,6 +2721,878 @@ func (a *App) importEmoji(rctx request.CTX, data *imports.EmojiImportData, dryRu
 	return nil
 }
 
+// importDelete removes an entity imported previously. Entities that don't exist, such as the
+// ones created and deleted between two incremental exports, are skipped.
+func (a *App) importDelete(rctx request.CTX, data *imports.DeleteImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.Type != nil {
+		fields = append(fields, mlog.String("delete_type", *data.Type))
+	}
+	rctx.Logger().Info("Validating delete", fields...)
+
+	if err := imports.ValidateDeleteImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing delete", fields...)
+
+	var nfErr *store.ErrNotFound
+	if *data.Type == imports.DeleteTypeEmoji {
+		emoji, err := a.Srv().Store().Emoji().GetByName(rctx, *data.Emoji, false)
+		if errors.As(err, &nfErr) {
+			return nil
+		} else if err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return a.DeleteEmoji(rctx, emoji)
+	}
+
+	var user *model.User
+	if data.User != nil {
+		var err error
+		user, err = a.Srv().Store().User().GetByUsername(*data.User)
+		if errors.As(err, &nfErr) {
+			return nil
+		} else if err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	var channel *model.Channel
+	if *data.Type == imports.DeleteTypeDirectPost {
+		var appErr *model.AppError
+		if channel, appErr = a.getDirectChannelByMembers(*data.ChannelMembers); appErr != nil || channel == nil {
+			return appErr
+		}
+	} else {
+		team, err := a.Srv().Store().Team().GetByName(strings.ToLower(*data.Team))
+		if errors.As(err, &nfErr) {
+			return nil
+		} else if err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		switch *data.Type {
+		case imports.DeleteTypeTeam:
+			if team.DeleteAt != 0 {
+				return nil
+			}
+			return a.SoftDeleteTeam(team.Id)
+		case imports.DeleteTypeTeamMember:
+			return a.importDeleteTeamMember(rctx, team, user)
+		}
+
+		channel, err = a.Srv().Store().Channel().GetByNameIncludeDeleted(team.Id, strings.ToLower(*data.Channel), true)
+		if errors.As(err, &nfErr) {
+			return nil
+		} else if err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		switch *data.Type {
+		case imports.DeleteTypeChannel:
+			if channel.DeleteAt != 0 {
+				return nil
+			}
+			if err := a.Srv().Store().Channel().Delete(channel.Id, *data.DeleteAt); err != nil {
+				return model.NewAppError("BulkImport", "app.import.import_channel.deleting.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			return nil
+		case imports.DeleteTypeChannelMember:
+			return a.importDeleteChannelMember(rctx, channel, user)
+		}
+	}
+
+	posts, err := a.Srv().Store().Post().GetPostsCreatedAt(channel.Id, *data.CreateAt)
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	// As their message may have been edited since, posts are found by their author as
+	// findEditedPost does, and only told apart by their message.
+	var deleted []*model.Post
+	for _, post := range posts {
+		if post.DeleteAt == 0 && post.OriginalId == "" && post.UserId == user.Id {
+			deleted = append(deleted, post)
+		}
+	}
+	if len(deleted) > 1 {
+		deleted = slices.DeleteFunc(deleted, func(post *model.Post) bool {
+			return post.Message != *data.Message
+		})
+	}
+
+	for _, post := range deleted {
+		if err := a.Srv().Store().Post().Delete(rctx, post.Id, *data.DeleteAt, ""); err != nil {
+			return model.NewAppError("BulkImport", "app.import.import_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// importDeleteTeamMember removes a user from a team and its channels. No message is posted
+// about it, as the posts of the team are imported on their own.
+func (a *App) importDeleteTeamMember(rctx request.CTX, team *model.Team, user *model.User) *model.AppError {
+	member, err := a.Srv().Store().Team().GetMember(rctx, team.Id, user.Id)
+	var nfErr *store.ErrNotFound
+	if errors.As(err, &nfErr) {
+		return nil
+	} else if err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if member.DeleteAt != 0 {
+		return nil
+	}
+
+	return a.leaveTeam(rctx, team, user, "", false)
+}
+
+// importDeleteChannelMember removes a user from a channel without posting a message about it.
+func (a *App) importDeleteChannelMember(rctx request.CTX, channel *model.Channel, user *model.User) *model.AppError {
+	_, err := a.Srv().Store().Channel().GetMember(rctx, channel.Id, user.Id)
+	var nfErr *store.ErrNotFound
+	if errors.As(err, &nfErr) {
+		return nil
+	} else if err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return a.removeUserFromChannel(rctx, user.Id, user.Id, channel)
+}
+
+// getDirectChannelByMembers returns the direct or group channel of the given members, or nil if
+// it doesn't exist.
+func (a *App) getDirectChannelByMembers(members []string) (*model.Channel, *model.AppError) {
+	usernames := utils.RemoveDuplicatesFromStringArray(members)
+	users, err := a.Srv().Store().User().GetProfilesByUsernames(usernames, nil)
+	if err != nil {
+		return nil, model.NewAppError("BulkImport", "app.user.get_profiles.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if len(users) != len(usernames) {
+		return nil, nil
+	}
+
+	userIDs := make([]string, len(users))
+	for i, user := range users {
+		userIDs[i] = user.Id
+	}
+
+	var channelName string
+	if len(userIDs) <= 2 {
+		channelName = model.GetDMNameFromIds(userIDs[0], userIDs[len(userIDs)-1])
+	} else {
+		channelName = model.GetGroupNameFromUserIds(userIDs)
+	}
+
+	channel, err := a.Srv().Store().Channel().GetByNameIncludeDeleted("", channelName, true)
+	var nfErr *store.ErrNotFound
+	if errors.As(err, &nfErr) {
+		return nil, nil
+	} else if err != nil {
+		return nil, model.NewAppError("BulkImport", "app.channel.get_by_name.existing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return channel, nil
+}
+
+// getChannelByReference returns the channel given by its team and name or, for direct and group
+// channels, by its members.
+func (a *App) getChannelByReference(team, channel *string, channelMembers *[]string) (*model.Channel, *model.AppError) {
+	if channelMembers != nil {
+		directChannel, appErr := a.getDirectChannelByMembers(*channelMembers)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if directChannel == nil {
+			return nil, model.NewAppError("BulkImport", "app.import.get_channel_by_reference.channel_not_found.error", map[string]any{"ChannelName": strings.Join(*channelMembers, ", ")}, "", http.StatusBadRequest)
+		}
+		return directChannel, nil
+	}
+
+	var nfErr *store.ErrNotFound
+	foundTeam, err := a.Srv().Store().Team().GetByName(strings.ToLower(*team))
+	if errors.As(err, &nfErr) {
+		return nil, model.NewAppError("BulkImport", "app.import.import_channel.team_not_found.error", map[string]any{"TeamName": *team}, "", http.StatusBadRequest).Wrap(err)
+	} else if err != nil {
+		return nil, model.NewAppError("BulkImport", "app.team.get_by_name.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	foundChannel, err := a.Srv().Store().Channel().GetByNameIncludeDeleted(foundTeam.Id, strings.ToLower(*channel), true)
+	if errors.As(err, &nfErr) {
+		return nil, model.NewAppError("BulkImport", "app.import.get_channel_by_reference.channel_not_found.error", map[string]any{"ChannelName": *channel}, "", http.StatusBadRequest).Wrap(err)
+	} else if err != nil {
+		return nil, model.NewAppError("BulkImport", "app.channel.get_by_name.existing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return foundChannel, nil
+}
+
+func (a *App) getUserByUsernameForImport(username string) (*model.User, *model.AppError) {
+	user, err := a.Srv().Store().User().GetByUsername(username)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("BulkImport", "app.import.get_user_by_username.user_not_found.error", map[string]any{"Username": username}, "", http.StatusBadRequest).Wrap(err)
+		}
+		return nil, model.NewAppError("BulkImport", "app.user.get_by_username.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return user, nil
+}
+
+// getThreadRootForImport returns the root post of a channel created at the given time, which is
+// how imported drafts and scheduled posts reference their thread.
+func (a *App) getThreadRootForImport(channelID string, createAt int64) (*model.Post, *model.AppError) {
+	posts, err := a.Srv().Store().Post().GetPostsCreatedAt(channelID, createAt)
+	if err != nil {
+		return nil, model.NewAppError("BulkImport", "app.post.get_posts_created_at.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, post := range posts {
+		if post.RootId == "" && post.DeleteAt == 0 {
+			return post, nil
+		}
+	}
+
+	return nil, model.NewAppError("BulkImport", "app.import.get_thread_root.root_not_found.error", nil, "", http.StatusBadRequest)
+}
+
+// getCustomProfileAttributeFields returns the id of the custom profile attributes group and its
+// active user fields.
+func (a *App) getCustomProfileAttributeFields(rctx request.CTX) (string, []*model.CPAField, *model.AppError) {
+	group, appErr := a.GetPropertyGroup(rctx, model.AccessControlPropertyGroupName)
+	if appErr != nil {
+		return "", nil, appErr
+	}
+
+	propertyFields, appErr := a.GetPropertyFieldsForGroup(rctx, group.ID)
+	if appErr != nil {
+		return "", nil, appErr
+	}
+
+	fields := make([]*model.CPAField, 0, len(propertyFields))
+	for _, pf := range propertyFields {
+		if pf.ObjectType != model.PropertyFieldObjectTypeUser {
+			continue
+		}
+		field, err := model.NewCPAFieldFromPropertyField(pf)
+		if err != nil {
+			rctx.Logger().Warn("Failed to parse custom profile attribute field", mlog.String("field_name", pf.Name), mlog.Err(err))
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	return group.ID, fields, nil
+}
+
+// importCustomProfileAttributes sets the values of the custom profile attributes of a user. As
+// for the rest of the import, the access restrictions of the fields are bypassed.
+func (a *App) importCustomProfileAttributes(rctx request.CTX, user *model.User, attributes map[string]json.RawMessage) *model.AppError {
+	groupID, fields, appErr := a.getCustomProfileAttributeFields(rctx)
+	if appErr != nil {
+		return appErr
+	}
+
+	fieldsByName := make(map[string]*model.CPAField, len(fields))
+	for _, field := range fields {
+		fieldsByName[field.Name] = field
+	}
+
+	values := make([]*model.PropertyValue, 0, len(attributes))
+	for name, exportedValue := range attributes {
+		field, ok := fieldsByName[name]
+		if !ok {
+			rctx.Logger().Warn("Skipping unknown custom profile attribute", mlog.String("user_name", user.Username), mlog.String("field_name", name))
+			continue
+		}
+
+		value, err := customProfileAttributeValueFromImport(field, exportedValue)
+		if err != nil {
+			rctx.Logger().Warn("Skipping invalid custom profile attribute value", mlog.String("user_name", user.Username), mlog.String("field_name", name), mlog.Err(err))
+			continue
+		}
+
+		values = append(values, &model.PropertyValue{
+			GroupID:    groupID,
+			FieldID:    field.ID,
+			TargetType: model.PropertyValueTargetTypeUser,
+			TargetID:   user.Id,
+			Value:      value,
+		})
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	if _, err := a.Srv().Store().PropertyValue().Upsert(values); err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_custom_profile_attributes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// customProfileAttributeValueFromImport returns the value of a custom profile attribute from its
+// exported form, replacing the names of the options by their ids on this server.
+func customProfileAttributeValueFromImport(field *model.CPAField, value json.RawMessage) (json.RawMessage, error) {
+	optionIDs := make(map[string]string, len(field.Attrs.Options))
+	for _, option := range field.Attrs.Options {
+		optionIDs[option.Name] = option.ID
+	}
+
+	switch field.Type {
+	case model.PropertyFieldTypeSelect:
+		var name string
+		if err := json.Unmarshal(value, &name); err != nil {
+			return nil, err
+		}
+		id, ok := optionIDs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown option %q", name)
+		}
+		return json.Marshal(id)
+	case model.PropertyFieldTypeMultiselect:
+		var names []string
+		if err := json.Unmarshal(value, &names); err != nil {
+			return nil, err
+		}
+		ids := make([]string, len(names))
+		for i, name := range names {
+			id, ok := optionIDs[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown option %q", name)
+			}
+			ids[i] = id
+		}
+		return json.Marshal(ids)
+	case model.PropertyFieldTypeUser, model.PropertyFieldTypeMultiuser:
+		return nil, errors.New("user values are not supported")
+	default:
+		return value, nil
+	}
+}
+
+func (a *App) importChannelBookmark(rctx request.CTX, data *imports.ChannelBookmarkImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.DisplayName != nil {
+		fields = append(fields, mlog.String("bookmark_display_name", *data.DisplayName))
+	}
+	rctx.Logger().Info("Validating channel bookmark", fields...)
+
+	if err := imports.ValidateChannelBookmarkImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing channel bookmark", fields...)
+
+	channel, appErr := a.getChannelByReference(data.Team, data.Channel, data.ChannelMembers)
+	if appErr != nil {
+		return appErr
+	}
+
+	owner, appErr := a.getUserByUsernameForImport(*data.Owner)
+	if appErr != nil {
+		return appErr
+	}
+
+	existingBookmarks, err := a.Srv().Store().ChannelBookmark().GetBookmarksForChannelSince(channel.Id, 0)
+	if err != nil {
+		return model.NewAppError("importChannelBookmark", "app.channel.bookmark.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var bookmark *model.ChannelBookmark
+	for _, existing := range existingBookmarks {
+		if existing.DisplayName == *data.DisplayName && existing.Type == *data.Type {
+			bookmark = existing.ChannelBookmark
+			break
+		}
+	}
+
+	alreadyExists := bookmark != nil
+	if !alreadyExists {
+		bookmark = &model.ChannelBookmark{
+			ChannelId:   channel.Id,
+			DisplayName: *data.DisplayName,
+			Type:        *data.Type,
+		}
+		if data.CreateAt != nil {
+			bookmark.CreateAt = *data.CreateAt
+		}
+	}
+
+	bookmark.OwnerId = owner.Id
+	if data.LinkUrl != nil {
+		bookmark.LinkUrl = *data.LinkUrl
+	}
+	if data.ImageUrl != nil {
+		bookmark.ImageUrl = *data.ImageUrl
+	}
+	if data.Emoji != nil {
+		bookmark.Emoji = *data.Emoji
+	}
+	if data.SortOrder != nil {
+		bookmark.SortOrder = *data.SortOrder
+	}
+
+	if alreadyExists {
+		if err := a.Srv().Store().ChannelBookmark().Update(bookmark); err != nil {
+			return model.NewAppError("importChannelBookmark", "app.channel.bookmark.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return nil
+	}
+
+	if *data.Type == model.ChannelBookmarkFile {
+		// Bookmark files don't belong to any user, so they are uploaded on behalf of the
+		// bookmark file owner, as done by the API.
+		filePost := &model.Post{
+			ChannelId: channel.Id,
+			UserId:    model.BookmarkFileOwner,
+			CreateAt:  bookmark.CreateAt,
+		}
+		if filePost.CreateAt == 0 {
+			filePost.CreateAt = model.GetMillis()
+		}
+		fileInfo, appErr := a.importAttachment(rctx, data.File, filePost, channel.TeamId, false)
+		if appErr != nil {
+			return appErr
+		}
+		bookmark.FileId = fileInfo.Id
+	}
+
+	if _, err := a.Srv().Store().ChannelBookmark().Save(bookmark, data.SortOrder == nil); err != nil {
+		return model.NewAppError("importChannelBookmark", "app.channel.bookmark.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func (a *App) importDraft(rctx request.CTX, data *imports.DraftImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.User != nil {
+		fields = append(fields, mlog.String("user_name", *data.User))
+	}
+	rctx.Logger().Info("Validating draft", fields...)
+
+	if err := imports.ValidateDraftImportData(data, a.MaxPostSize()); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing draft", fields...)
+
+	user, appErr := a.getUserByUsernameForImport(*data.User)
+	if appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := a.getChannelByReference(data.Team, data.Channel, data.ChannelMembers)
+	if appErr != nil {
+		return appErr
+	}
+
+	draft := &model.Draft{
+		UserId:    user.Id,
+		ChannelId: channel.Id,
+		Message:   *data.Message,
+	}
+	if data.RootCreateAt != nil {
+		root, appErr := a.getThreadRootForImport(channel.Id, *data.RootCreateAt)
+		if appErr != nil {
+			return appErr
+		}
+		draft.RootId = root.Id
+	}
+	if data.Type != nil {
+		draft.Type = *data.Type
+	}
+	if data.Props != nil {
+		draft.SetProps(*data.Props)
+	}
+	if data.CreateAt != nil {
+		draft.CreateAt = *data.CreateAt
+	}
+
+	if _, err := a.Srv().Store().Draft().Upsert(draft); err != nil {
+		return model.NewAppError("importDraft", "app.draft.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func (a *App) importScheduledPost(rctx request.CTX, data *imports.ScheduledPostImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.User != nil {
+		fields = append(fields, mlog.String("user_name", *data.User))
+	}
+	rctx.Logger().Info("Validating scheduled post", fields...)
+
+	if err := imports.ValidateScheduledPostImportData(data, a.MaxPostSize()); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	// Posts scheduled for a time that has passed since the export are skipped rather than
+	// sent late.
+	if *data.ScheduledAt <= model.GetMillis() {
+		rctx.Logger().Warn("Skipping scheduled post whose time has passed", fields...)
+		return nil
+	}
+
+	rctx.Logger().Info("Importing scheduled post", fields...)
+
+	user, appErr := a.getUserByUsernameForImport(*data.User)
+	if appErr != nil {
+		return appErr
+	}
+
+	channel, appErr := a.getChannelByReference(data.Team, data.Channel, data.ChannelMembers)
+	if appErr != nil {
+		return appErr
+	}
+
+	var rootID string
+	if data.RootCreateAt != nil {
+		root, appErr := a.getThreadRootForImport(channel.Id, *data.RootCreateAt)
+		if appErr != nil {
+			return appErr
+		}
+		rootID = root.Id
+	}
+
+	existingPosts, err := a.Srv().Store().ScheduledPost().GetScheduledPostsForUser(user.Id, channel.TeamId)
+	if err != nil {
+		return model.NewAppError("importScheduledPost", "app.get_user_team_scheduled_posts.error", map[string]any{"user_id": user.Id, "team_id": channel.TeamId}, "", http.StatusInternalServerError).Wrap(err)
+	}
+	for _, existing := range existingPosts {
+		if existing.ChannelId == channel.Id && existing.RootId == rootID && existing.ScheduledAt == *data.ScheduledAt && existing.Message == *data.Message {
+			return nil
+		}
+	}
+
+	scheduledPost := &model.ScheduledPost{
+		Draft: model.Draft{
+			UserId:    user.Id,
+			ChannelId: channel.Id,
+			RootId:    rootID,
+			Message:   *data.Message,
+		},
+		ScheduledAt: *data.ScheduledAt,
+	}
+	if data.Type != nil {
+		scheduledPost.Type = *data.Type
+	}
+	if data.Props != nil {
+		scheduledPost.SetProps(*data.Props)
+	}
+	if data.CreateAt != nil {
+		scheduledPost.CreateAt = *data.CreateAt
+	}
+
+	if _, err := a.Srv().Store().ScheduledPost().CreateScheduledPost(scheduledPost); err != nil {
+		return model.NewAppError("importScheduledPost", "app.save_scheduled_post.save.app_error", map[string]any{"user_id": user.Id, "channel_id": channel.Id}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func (a *App) importIncomingWebhook(rctx request.CTX, data *imports.IncomingWebhookImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.DisplayName != nil {
+		fields = append(fields, mlog.String("webhook_display_name", *data.DisplayName))
+	}
+	rctx.Logger().Info("Validating incoming webhook", fields...)
+
+	if err := imports.ValidateIncomingWebhookImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing incoming webhook", fields...)
+
+	channel, appErr := a.getChannelByReference(data.Team, data.Channel, nil)
+	if appErr != nil {
+		return appErr
+	}
+
+	user, appErr := a.getUserByUsernameForImport(*data.User)
+	if appErr != nil {
+		return appErr
+	}
+
+	existingHooks, err := a.Srv().Store().Webhook().GetIncomingByChannel(channel.Id)
+	if err != nil {
+		return model.NewAppError("importIncomingWebhook", "app.webhooks.get_incoming_by_channel.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var hook *model.IncomingWebhook
+	for _, existing := range existingHooks {
+		if data.Id != nil && existing.Id == *data.Id {
+			hook = existing
+			break
+		}
+		if hook == nil && existing.UserId == user.Id && existing.DisplayName == model.SafeDereference(data.DisplayName) {
+			hook = existing
+		}
+	}
+
+	alreadyExists := hook != nil
+	if !alreadyExists {
+		hook = &model.IncomingWebhook{
+			UserId:    user.Id,
+			ChannelId: channel.Id,
+			TeamId:    channel.TeamId,
+		}
+	}
+
+	if data.DisplayName != nil {
+		hook.DisplayName = *data.DisplayName
+	}
+	if data.Description != nil {
+		hook.Description = *data.Description
+	}
+	if data.Username != nil {
+		hook.Username = *data.Username
+	}
+	if data.IconURL != nil {
+		hook.IconURL = *data.IconURL
+	}
+	if data.ChannelLocked != nil {
+		hook.ChannelLocked = *data.ChannelLocked
+	}
+
+	if alreadyExists {
+		if _, err := a.Srv().Store().Webhook().UpdateIncoming(hook); err != nil {
+			return model.NewAppError("importIncomingWebhook", "app.webhooks.update_incoming.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return nil
+	}
+
+	// The id is part of the URL of the webhook, so it is kept unless another webhook uses it.
+	if data.Id != nil {
+		hook.Id = *data.Id
+		_, err := a.Srv().Store().Webhook().ImportIncoming(hook)
+		if err == nil {
+			return nil
+		}
+		var cErr *store.ErrConflict
+		if !errors.As(err, &cErr) {
+			return model.NewAppError("importIncomingWebhook", "app.webhooks.save_incoming.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		rctx.Logger().Warn("The id of the incoming webhook is already used, a new one is generated", append(fields, mlog.String("webhook_id", hook.Id))...)
+		hook.Id = ""
+	}
+
+	if _, err := a.Srv().Store().Webhook().SaveIncoming(hook); err != nil {
+		return model.NewAppError("importIncomingWebhook", "app.webhooks.save_incoming.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func (a *App) importOutgoingWebhook(rctx request.CTX, data *imports.OutgoingWebhookImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.DisplayName != nil {
+		fields = append(fields, mlog.String("webhook_display_name", *data.DisplayName))
+	}
+	rctx.Logger().Info("Validating outgoing webhook", fields...)
+
+	if err := imports.ValidateOutgoingWebhookImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing outgoing webhook", fields...)
+
+	team, err := a.Srv().Store().Team().GetByName(strings.ToLower(*data.Team))
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_channel.team_not_found.error", map[string]any{"TeamName": *data.Team}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	var channelID string
+	if data.Channel != nil {
+		channel, appErr := a.getChannelByReference(data.Team, data.Channel, nil)
+		if appErr != nil {
+			return appErr
+		}
+		channelID = channel.Id
+	}
+
+	creator, appErr := a.getUserByUsernameForImport(*data.Creator)
+	if appErr != nil {
+		return appErr
+	}
+
+	existingHooks, err := a.Srv().Store().Webhook().GetOutgoingByTeamByUser(team.Id, creator.Id, -1, -1)
+	if err != nil {
+		return model.NewAppError("importOutgoingWebhook", "app.webhooks.get_outgoing_by_team.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var hook *model.OutgoingWebhook
+	for _, existing := range existingHooks {
+		if existing.DisplayName == *data.DisplayName {
+			hook = existing
+			break
+		}
+	}
+
+	alreadyExists := hook != nil
+	if !alreadyExists {
+		hook = &model.OutgoingWebhook{
+			CreatorId:   creator.Id,
+			TeamId:      team.Id,
+			DisplayName: *data.DisplayName,
+		}
+	}
+
+	hook.ChannelId = channelID
+	hook.CallbackURLs = *data.CallbackURLs
+	if data.Description != nil {
+		hook.Description = *data.Description
+	}
+	if data.Token != nil {
+		hook.Token = *data.Token
+	}
+	if data.TriggerWords != nil {
+		hook.TriggerWords = *data.TriggerWords
+	}
+	if data.TriggerWhen != nil {
+		hook.TriggerWhen = *data.TriggerWhen
+	}
+	if data.ContentType != nil {
+		hook.ContentType = *data.ContentType
+	}
+	if data.Username != nil {
+		hook.Username = *data.Username
+	}
+	if data.IconURL != nil {
+		hook.IconURL = *data.IconURL
+	}
+
+	if alreadyExists {
+		if _, err := a.Srv().Store().Webhook().UpdateOutgoing(hook); err != nil {
+			return model.NewAppError("importOutgoingWebhook", "app.webhooks.update_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return nil
+	}
+
+	if _, err := a.Srv().Store().Webhook().SaveOutgoing(hook); err != nil {
+		return model.NewAppError("importOutgoingWebhook", "app.webhooks.save_outgoing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func (a *App) importCommand(rctx request.CTX, data *imports.CommandImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.Trigger != nil {
+		fields = append(fields, mlog.String("command_trigger", *data.Trigger))
+	}
+	rctx.Logger().Info("Validating command", fields...)
+
+	if err := imports.ValidateCommandImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	rctx.Logger().Info("Importing command", fields...)
+
+	team, err := a.Srv().Store().Team().GetByName(strings.ToLower(*data.Team))
+	if err != nil {
+		return model.NewAppError("BulkImport", "app.import.import_channel.team_not_found.error", map[string]any{"TeamName": *data.Team}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	creator, appErr := a.getUserByUsernameForImport(*data.Creator)
+	if appErr != nil {
+		return appErr
+	}
+
+	command, err := a.Srv().Store().Command().GetByTrigger(team.Id, *data.Trigger)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return model.NewAppError("importCommand", "app.command.getcommand.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	alreadyExists := command != nil
+	if !alreadyExists {
+		command = &model.Command{
+			CreatorId: creator.Id,
+			TeamId:    team.Id,
+			Trigger:   *data.Trigger,
+		}
+	}
+
+	command.Method = *data.Method
+	command.URL = *data.URL
+	if data.Token != nil {
+		command.Token = *data.Token
+	}
+	if data.Username != nil {
+		command.Username = *data.Username
+	}
+	if data.IconURL != nil {
+		command.IconURL = *data.IconURL
+	}
+	if data.AutoComplete != nil {
+		command.AutoComplete = *data.AutoComplete
+	}
+	if data.AutoCompleteDesc != nil {
+		command.AutoCompleteDesc = *data.AutoCompleteDesc
+	}
+	if data.AutoCompleteHint != nil {
+		command.AutoCompleteHint = *data.AutoCompleteHint
+	}
+	if data.DisplayName != nil {
+		command.DisplayName = *data.DisplayName
+	}
+	if data.Description != nil {
+		command.Description = *data.Description
+	}
+
+	if alreadyExists {
+		if _, err := a.Srv().Store().Command().Update(command); err != nil {
+			return model.NewAppError("importCommand", "app.command.updatecommand.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return nil
+	}
+
+	if _, err := a.Srv().Store().Command().Save(command); err != nil {
+		return model.NewAppError("importCommand", "app.command.createcommand.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
 func (a *App) extractThreadMembers(line *imports.LineImportWorkerData, users map[string]*model.User, post *model.Post) ([]*model.ThreadMembership, int, *model.AppError) {
 	threadMemberships := []*model.ThreadMembership{}
 
@@ -2861,6 +2861,69 @@ func TestImportimportMultiplePostLines(t *testing.T) {
 		require.False(t, replyBool, "Post properties not as expected")
 	})
 
+	t.Run("import an edited post with replies", func(t *testing.T) {
+		// Times before createAt can't be taken by the posts of the other tests.
+		editedPostTime := createAt - 10
+		editedReplyTime := createAt - 9
+		editTime := createAt - 8
+
+		data := imports.LineImportWorkerData{
+			LineImportData: imports.LineImportData{
+				Post: &imports.PostImportData{
+					Team:     &teamName,
+					Channel:  &channelName,
+					User:     &username,
+					Message:  new("Message to edit"),
+					CreateAt: &editedPostTime,
+					Replies: &[]imports.ReplyImportData{{
+						User:     &user2.Username,
+						Message:  new("Reply to edit"),
+						CreateAt: &editedReplyTime,
+					}},
+				},
+			},
+			LineNumber: 1,
+		}
+		errLine, err2 := th.App.importMultiplePostLines(th.Context, []imports.LineImportWorkerData{data}, false, true)
+		require.Nil(t, err2)
+		require.Equal(t, 0, errLine)
+		assertionCount += 2
+		AssertAllPostsCount(t, th.App, initialPostCount, assertionCount, team.Id)
+
+		posts, nErr := th.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, editedPostTime)
+		require.NoError(t, nErr)
+		require.Len(t, posts, 1)
+		post := posts[0]
+		replies, nErr := th.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, editedReplyTime)
+		require.NoError(t, nErr)
+		require.Len(t, replies, 1)
+		reply := replies[0]
+
+		// An edited post is updated even though its message changed.
+		data.Post.Message = new("Edited message")
+		data.Post.EditAt = &editTime
+		(*data.Post.Replies)[0].Message = new("Edited reply")
+		(*data.Post.Replies)[0].EditAt = &editTime
+		errLine, err2 = th.App.importMultiplePostLines(th.Context, []imports.LineImportWorkerData{data}, false, true)
+		require.Nil(t, err2)
+		require.Equal(t, 0, errLine)
+		AssertAllPostsCount(t, th.App, initialPostCount, assertionCount, team.Id)
+
+		posts, nErr = th.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, editedPostTime)
+		require.NoError(t, nErr)
+		require.Len(t, posts, 1)
+		assert.Equal(t, post.Id, posts[0].Id)
+		assert.Equal(t, "Edited message", posts[0].Message)
+		assert.Equal(t, editTime, posts[0].EditAt)
+
+		replies, nErr = th.App.Srv().Store().Post().GetPostsCreatedAt(channel.Id, editedReplyTime)
+		require.NoError(t, nErr)
+		require.Len(t, replies, 1)
+		assert.Equal(t, reply.Id, replies[0].Id)
+		assert.Equal(t, post.Id, replies[0].RootId)
+		assert.Equal(t, "Edited reply", replies[0].Message)
+	})
+
 	t.Run("import post with pinned message", func(t *testing.T) {
 		// Create another Team.
 		teamName2 := model.NewRandomTeamName()
@@ -5636,6 +5699,227 @@ func TestImportImportEmoji(t *testing.T) {
 	require.ErrorIs(t, appErr.Unwrap(), utils.ErrSizeLimitExceeded)
 }
 
+func TestImportImportDelete(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	deleteAt := model.GetMillis()
+
+	t.Run("invalid delete", func(t *testing.T) {
+		appErr := th.App.importDelete(th.Context, &imports.DeleteImportData{Type: new(imports.DeleteTypePost)}, false)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("post", func(t *testing.T) {
+		post := th.CreatePost(t, th.BasicChannel)
+		data := &imports.DeleteImportData{
+			Type:     new(imports.DeleteTypePost),
+			Team:     &th.BasicTeam.Name,
+			Channel:  &th.BasicChannel.Name,
+			User:     &th.BasicUser2.Username,
+			Message:  &post.Message,
+			CreateAt: &post.CreateAt,
+			DeleteAt: &deleteAt,
+		}
+
+		// Posts of another user aren't deleted.
+		appErr := th.App.importDelete(th.Context, data, false)
+		require.Nil(t, appErr)
+		_, err := th.App.Srv().Store().Post().GetSingle(th.Context, post.Id, false)
+		require.NoError(t, err)
+
+		data.User = &th.BasicUser.Username
+		appErr = th.App.importDelete(th.Context, data, true)
+		require.Nil(t, appErr)
+		_, err = th.App.Srv().Store().Post().GetSingle(th.Context, post.Id, false)
+		require.NoError(t, err, "dry run shouldn't delete the post")
+
+		appErr = th.App.importDelete(th.Context, data, false)
+		require.Nil(t, appErr)
+		deleted, err := th.App.Srv().Store().Post().GetSingle(th.Context, post.Id, true)
+		require.NoError(t, err)
+		assert.Equal(t, deleteAt, deleted.DeleteAt)
+
+		// Deleting it again is a no-op.
+		appErr = th.App.importDelete(th.Context, data, false)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("edited post", func(t *testing.T) {
+		post := th.CreatePost(t, th.BasicChannel)
+		createAt := post.CreateAt
+		post.Message = "edited message"
+		_, err := th.App.Srv().Store().Post().Overwrite(th.Context, post)
+		require.NoError(t, err)
+
+		// The message of the delete line is the one of the post when it was deleted.
+		appErr := th.App.importDelete(th.Context, &imports.DeleteImportData{
+			Type:     new(imports.DeleteTypePost),
+			Team:     &th.BasicTeam.Name,
+			Channel:  &th.BasicChannel.Name,
+			User:     &th.BasicUser.Username,
+			Message:  new("message before the import"),
+			CreateAt: &createAt,
+			DeleteAt: &deleteAt,
+		}, false)
+		require.Nil(t, appErr)
+		deleted, err := th.App.Srv().Store().Post().GetSingle(th.Context, post.Id, true)
+		require.NoError(t, err)
+		assert.Equal(t, deleteAt, deleted.DeleteAt)
+	})
+
+	t.Run("direct post", func(t *testing.T) {
+		dm := th.CreateDmChannel(t, th.BasicUser2)
+		post := th.CreatePost(t, dm)
+
+		appErr := th.App.importDelete(th.Context, &imports.DeleteImportData{
+			Type:           new(imports.DeleteTypeDirectPost),
+			ChannelMembers: &[]string{th.BasicUser.Username, th.BasicUser2.Username},
+			User:           &th.BasicUser.Username,
+			Message:        &post.Message,
+			CreateAt:       &post.CreateAt,
+			DeleteAt:       &deleteAt,
+		}, false)
+		require.Nil(t, appErr)
+		deleted, err := th.App.Srv().Store().Post().GetSingle(th.Context, post.Id, true)
+		require.NoError(t, err)
+		assert.Equal(t, deleteAt, deleted.DeleteAt)
+	})
+
+	t.Run("channel", func(t *testing.T) {
+		channel := th.CreateChannel(t, th.BasicTeam)
+
+		appErr := th.App.importDelete(th.Context, &imports.DeleteImportData{
+			Type:     new(imports.DeleteTypeChannel),
+			Team:     &th.BasicTeam.Name,
+			Channel:  &channel.Name,
+			DeleteAt: &deleteAt,
+		}, false)
+		require.Nil(t, appErr)
+		deleted, err := th.App.Srv().Store().Channel().Get(channel.Id, true)
+		require.NoError(t, err)
+		assert.Equal(t, deleteAt, deleted.DeleteAt)
+	})
+
+	t.Run("team", func(t *testing.T) {
+		team := th.CreateTeam(t)
+
+		appErr := th.App.importDelete(th.Context, &imports.DeleteImportData{
+			Type:     new(imports.DeleteTypeTeam),
+			Team:     &team.Name,
+			DeleteAt: &deleteAt,
+		}, false)
+		require.Nil(t, appErr)
+		deleted, err := th.App.Srv().Store().Team().Get(team.Id)
+		require.NoError(t, err)
+		assert.NotZero(t, deleted.DeleteAt)
+	})
+
+	t.Run("team member", func(t *testing.T) {
+		team := th.CreateTeam(t)
+		th.LinkUserToTeam(t, th.BasicUser2, team)
+		channel := th.CreateChannel(t, team)
+		th.AddUserToChannel(t, th.BasicUser2, channel)
+
+		appErr := th.App.importDelete(th.Context, &imports.DeleteImportData{
+			Type:     new(imports.DeleteTypeTeamMember),
+			Team:     &team.Name,
+			User:     &th.BasicUser2.Username,
+			DeleteAt: &deleteAt,
+		}, false)
+		require.Nil(t, appErr)
+		member, err := th.App.Srv().Store().Team().GetMember(th.Context, team.Id, th.BasicUser2.Id)
+		require.NoError(t, err)
+		assert.NotZero(t, member.DeleteAt)
+		_, err = th.App.Srv().Store().Channel().GetMember(th.Context, channel.Id, th.BasicUser2.Id)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		// Removing the user again is a no-op.
+		appErr = th.App.importDelete(th.Context, &imports.DeleteImportData{
+			Type:     new(imports.DeleteTypeTeamMember),
+			Team:     &team.Name,
+			User:     &th.BasicUser2.Username,
+			DeleteAt: &deleteAt,
+		}, false)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("channel member", func(t *testing.T) {
+		channel := th.CreateChannel(t, th.BasicTeam)
+		th.AddUserToChannel(t, th.BasicUser2, channel)
+
+		data := &imports.DeleteImportData{
+			Type:     new(imports.DeleteTypeChannelMember),
+			Team:     &th.BasicTeam.Name,
+			Channel:  &channel.Name,
+			User:     &th.BasicUser2.Username,
+			DeleteAt: &deleteAt,
+		}
+		appErr := th.App.importDelete(th.Context, data, false)
+		require.Nil(t, appErr)
+		_, err := th.App.Srv().Store().Channel().GetMember(th.Context, channel.Id, th.BasicUser2.Id)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		appErr = th.App.importDelete(th.Context, data, false)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("emoji", func(t *testing.T) {
+		emoji, err := th.App.Srv().Store().Emoji().Save(&model.Emoji{
+			CreatorId: th.BasicUser.Id,
+			Name:      model.NewId(),
+		})
+		require.NoError(t, err)
+
+		data := &imports.DeleteImportData{
+			Type:     new(imports.DeleteTypeEmoji),
+			Emoji:    &emoji.Name,
+			DeleteAt: &deleteAt,
+		}
+		appErr := th.App.importDelete(th.Context, data, false)
+		require.Nil(t, appErr)
+		_, err = th.App.Srv().Store().Emoji().GetByName(th.Context, emoji.Name, false)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		appErr = th.App.importDelete(th.Context, data, false)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("missing entities are skipped", func(t *testing.T) {
+		appErr := th.App.importDelete(th.Context, &imports.DeleteImportData{
+			Type:     new(imports.DeleteTypeChannel),
+			Team:     new("missing-team"),
+			Channel:  new("missing-channel"),
+			DeleteAt: &deleteAt,
+		}, false)
+		require.Nil(t, appErr)
+
+		appErr = th.App.importDelete(th.Context, &imports.DeleteImportData{
+			Type:     new(imports.DeleteTypePost),
+			Team:     &th.BasicTeam.Name,
+			Channel:  new("missing-channel"),
+			User:     &th.BasicUser.Username,
+			Message:  new("message"),
+			CreateAt: new(model.GetMillis()),
+			DeleteAt: &deleteAt,
+		}, false)
+		require.Nil(t, appErr)
+
+		appErr = th.App.importDelete(th.Context, &imports.DeleteImportData{
+			Type:           new(imports.DeleteTypeDirectPost),
+			ChannelMembers: &[]string{th.BasicUser.Username, "missing-user"},
+			User:           &th.BasicUser.Username,
+			Message:        new("message"),
+			CreateAt:       new(model.GetMillis()),
+			DeleteAt:       &deleteAt,
+		}, false)
+		require.Nil(t, appErr)
+	})
+}
+
 func TestImportAttachment(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t)
@@ -13,19 +13,26 @@ import (
 // Import Data Models
 
 type LineImportData struct {
-	Type          string                   `json:"type"`
-	Role          *RoleImportData          `json:"role,omitempty"`
-	Scheme        *SchemeImportData        `json:"scheme,omitempty"`
-	Team          *TeamImportData          `json:"team,omitempty"`
-	Channel       *ChannelImportData       `json:"channel,omitempty"`
-	User          *UserImportData          `json:"user,omitempty"`
-	Bot           *BotImportData           `json:"bot,omitempty"`
-	Post          *PostImportData          `json:"post,omitempty"`
-	DirectChannel *DirectChannelImportData `json:"direct_channel,omitempty"`
-	DirectPost    *DirectPostImportData    `json:"direct_post,omitempty"`
-	Emoji         *EmojiImportData         `json:"emoji,omitempty"`
-	Version       *int                     `json:"version,omitempty"`
-	Info          *VersionInfoImportData   `json:"info,omitempty"`
+	Type            string                     `json:"type"`
+	Role            *RoleImportData            `json:"role,omitempty"`
+	Scheme          *SchemeImportData          `json:"scheme,omitempty"`
+	Team            *TeamImportData            `json:"team,omitempty"`
+	Channel         *ChannelImportData         `json:"channel,omitempty"`
+	User            *UserImportData            `json:"user,omitempty"`
+	Bot             *BotImportData             `json:"bot,omitempty"`
+	Post            *PostImportData            `json:"post,omitempty"`
+	DirectChannel   *DirectChannelImportData   `json:"direct_channel,omitempty"`
+	DirectPost      *DirectPostImportData      `json:"direct_post,omitempty"`
+	Emoji           *EmojiImportData           `json:"emoji,omitempty"`
+	Delete          *DeleteImportData          `json:"delete,omitempty"`
+	ChannelBookmark *ChannelBookmarkImportData `json:"channel_bookmark,omitempty"`
+	Draft           *DraftImportData           `json:"draft,omitempty"`
+	ScheduledPost   *ScheduledPostImportData   `json:"scheduled_post,omitempty"`
+	IncomingWebhook *IncomingWebhookImportData `json:"incoming_webhook,omitempty"`
+	OutgoingWebhook *OutgoingWebhookImportData `json:"outgoing_webhook,omitempty"`
+	Command         *CommandImportData         `json:"command,omitempty"`
+	Version         *int                       `json:"version,omitempty"`
+	Info            *VersionInfoImportData     `json:"info,omitempty"`
 }
 
 type VersionInfoImportData struct {
@@ -100,6 +107,10 @@ type UserImportData struct {
 
 	NotifyProps  *UserNotifyPropsImportData `json:"notify_props,omitempty"`
 	CustomStatus *model.CustomStatus        `json:"custom_status,omitempty"`
+
+	// CustomProfileAttributes holds the values of the custom profile attributes of the user by
+	// field name. The values of the fields with options hold the option names instead of their ids.
+	CustomProfileAttributes *map[string]json.RawMessage `json:"custom_profile_attributes,omitempty"`
 }
 
 type BotImportData struct {
@@ -243,6 +254,134 @@ type DirectPostImportData struct {
 	ThreadFollowers *[]ThreadFollowerImportData `json:"thread_followers,omitempty"`
 }
 
+// Types of the entities removed by a delete line.
+const (
+	DeleteTypeTeam          = "team"
+	DeleteTypeTeamMember    = "team_member"
+	DeleteTypeChannel       = "channel"
+	DeleteTypeChannelMember = "channel_member"
+	DeleteTypePost          = "post"
+	DeleteTypeDirectPost    = "direct_post"
+	DeleteTypeEmoji         = "emoji"
+)
+
+// DeleteImportData removes an entity imported by a previous import, identifying it the same way
+// as the line that created it. Posts are identified by their channel, author and creation time,
+// and memberships by their team or channel and user.
+type DeleteImportData struct {
+	Type *string `json:"type"`
+
+	Team           *string   `json:"team,omitempty"`
+	Channel        *string   `json:"channel,omitempty"`
+	ChannelMembers *[]string `json:"channel_members,omitempty"`
+	User           *string   `json:"user,omitempty"`
+	Message        *string   `json:"message,omitempty"`
+	CreateAt       *int64    `json:"create_at,omitempty"`
+	Emoji          *string   `json:"emoji,omitempty"`
+
+	DeleteAt *int64 `json:"delete_at"`
+}
+
+// ChannelBookmarkImportData is a bookmark of a channel, which is given by its team and name or,
+// for direct and group channels, by its members.
+type ChannelBookmarkImportData struct {
+	Team           *string   `json:"team,omitempty"`
+	Channel        *string   `json:"channel,omitempty"`
+	ChannelMembers *[]string `json:"channel_members,omitempty"`
+	Owner          *string   `json:"owner"`
+
+	DisplayName *string                    `json:"display_name"`
+	Type        *model.ChannelBookmarkType `json:"type"`
+	LinkUrl     *string                    `json:"link_url,omitempty"`
+	ImageUrl    *string                    `json:"image_url,omitempty"`
+	Emoji       *string                    `json:"emoji,omitempty"`
+	SortOrder   *int64                     `json:"sort_order,omitempty"`
+	File        *AttachmentImportData      `json:"file,omitempty"`
+	CreateAt    *int64                     `json:"create_at,omitempty"`
+}
+
+// DraftImportData is the draft of a user in a channel or, when RootCreateAt is set, in the thread
+// of the root post created at that time.
+type DraftImportData struct {
+	Team           *string   `json:"team,omitempty"`
+	Channel        *string   `json:"channel,omitempty"`
+	ChannelMembers *[]string `json:"channel_members,omitempty"`
+	User           *string   `json:"user"`
+
+	RootCreateAt *int64                 `json:"root_create_at,omitempty"`
+	Type         *string                `json:"type,omitempty"`
+	Message      *string                `json:"message"`
+	Props        *model.StringInterface `json:"props,omitempty"`
+	CreateAt     *int64                 `json:"create_at,omitempty"`
+	UpdateAt     *int64                 `json:"update_at,omitempty"`
+}
+
+type ScheduledPostImportData struct {
+	Team           *string   `json:"team,omitempty"`
+	Channel        *string   `json:"channel,omitempty"`
+	ChannelMembers *[]string `json:"channel_members,omitempty"`
+	User           *string   `json:"user"`
+
+	RootCreateAt *int64                 `json:"root_create_at,omitempty"`
+	Type         *string                `json:"type,omitempty"`
+	Message      *string                `json:"message"`
+	Props        *model.StringInterface `json:"props,omitempty"`
+	CreateAt     *int64                 `json:"create_at,omitempty"`
+	ScheduledAt  *int64                 `json:"scheduled_at"`
+}
+
+// IncomingWebhookImportData is an incoming webhook of a channel. The id is kept so that the
+// URL of the webhook keeps working after the import.
+type IncomingWebhookImportData struct {
+	Id      *string `json:"id,omitempty"`
+	Team    *string `json:"team"`
+	Channel *string `json:"channel"`
+	User    *string `json:"user"`
+
+	DisplayName   *string `json:"display_name,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	Username      *string `json:"username,omitempty"`
+	IconURL       *string `json:"icon_url,omitempty"`
+	ChannelLocked *bool   `json:"channel_locked,omitempty"`
+}
+
+// OutgoingWebhookImportData is an outgoing webhook of a team. The token is kept so that the
+// services receiving the webhook keep accepting it after the import.
+type OutgoingWebhookImportData struct {
+	Team    *string `json:"team"`
+	Channel *string `json:"channel,omitempty"`
+	Creator *string `json:"creator"`
+
+	DisplayName  *string   `json:"display_name"`
+	Description  *string   `json:"description,omitempty"`
+	Token        *string   `json:"token,omitempty"`
+	TriggerWords *[]string `json:"trigger_words,omitempty"`
+	TriggerWhen  *int      `json:"trigger_when,omitempty"`
+	CallbackURLs *[]string `json:"callback_urls"`
+	ContentType  *string   `json:"content_type,omitempty"`
+	Username     *string   `json:"username,omitempty"`
+	IconURL      *string   `json:"icon_url,omitempty"`
+}
+
+// CommandImportData is a custom slash command of a team. The token is kept so that the services
+// receiving the command keep accepting it after the import.
+type CommandImportData struct {
+	Team    *string `json:"team"`
+	Creator *string `json:"creator"`
+
+	Trigger          *string `json:"trigger"`
+	Method           *string `json:"method"`
+	URL              *string `json:"url"`
+	Token            *string `json:"token,omitempty"`
+	Username         *string `json:"username,omitempty"`
+	IconURL          *string `json:"icon_url,omitempty"`
+	AutoComplete     *bool   `json:"auto_complete,omitempty"`
+	AutoCompleteDesc *string `json:"auto_complete_desc,omitempty"`
+	AutoCompleteHint *string `json:"auto_complete_hint,omitempty"`
+	DisplayName      *string `json:"display_name,omitempty"`
+	Description      *string `json:"description,omitempty"`
+}
+
 type SchemeImportData struct {
 	Name                    *string         `json:"name"`
 	DisplayName             *string         `json:"display_name"`
@@ -380,7 +380,7 @@ func validateAuthService(authService *string) *model.AppError {
 	if authService == nil {
 		return nil
 	}
-	if slices.Contains(validAuthServices, *authService) {
+	if slices.Contains(validAuthServices, *authService) || model.IsOpenIdProviderService(*authService) {
 		return nil
 	}
 
@@ -738,6 +738,250 @@ func ValidateThreadFollowerImportData(data *ThreadFollowerImportData) *model.App
 	return nil
 }
 
+func ValidateDeleteImportData(data *DeleteImportData) *model.AppError {
+	if data == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.empty.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.DeleteAt == nil || *data.DeleteAt <= 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.delete_at_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Type == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.type_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	switch *data.Type {
+	case DeleteTypeTeam, DeleteTypeTeamMember:
+		if data.Team == nil || *data.Team == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case DeleteTypeChannel, DeleteTypeChannelMember, DeleteTypePost:
+		if data.Team == nil || *data.Team == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.Channel == nil || *data.Channel == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.channel_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case DeleteTypeDirectPost:
+		if data.ChannelMembers == nil || len(*data.ChannelMembers) == 0 {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.channel_members_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case DeleteTypeEmoji:
+		if data.Emoji == nil || *data.Emoji == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.emoji_missing.error", nil, "", http.StatusBadRequest)
+		}
+	default:
+		return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.type_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	switch *data.Type {
+	case DeleteTypeTeamMember, DeleteTypeChannelMember, DeleteTypePost, DeleteTypeDirectPost:
+		if data.User == nil || *data.User == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if *data.Type == DeleteTypePost || *data.Type == DeleteTypeDirectPost {
+		if data.CreateAt == nil || *data.CreateAt <= 0 {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.create_at_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if data.Message == nil {
+			return model.NewAppError("BulkImport", "app.import.validate_delete_import_data.message_missing.error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// validateChannelReference validates the channel of an entity, which is given either by its team
+// and name or, for direct and group channels, by its members.
+func validateChannelReference(team, channel *string, channelMembers *[]string) *model.AppError {
+	if channelMembers != nil {
+		if team != nil || channel != nil {
+			return model.NewAppError("BulkImport", "app.import.validate_channel_reference.ambiguous.error", nil, "", http.StatusBadRequest)
+		}
+		if len(*channelMembers) == 0 || len(*channelMembers) > model.ChannelGroupMaxUsers {
+			return model.NewAppError("BulkImport", "app.import.validate_channel_reference.channel_members_invalid.error", nil, "", http.StatusBadRequest)
+		}
+		return nil
+	}
+
+	if team == nil || *team == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_reference.team_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if channel == nil || *channel == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_reference.channel_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateChannelBookmarkImportData(data *ChannelBookmarkImportData) *model.AppError {
+	if data == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.empty.error", nil, "", http.StatusBadRequest)
+	}
+
+	if err := validateChannelReference(data.Team, data.Channel, data.ChannelMembers); err != nil {
+		return err
+	}
+
+	if data.Owner == nil || *data.Owner == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.owner_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.DisplayName == nil || *data.DisplayName == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.display_name_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Type == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.type_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	switch *data.Type {
+	case model.ChannelBookmarkLink:
+		if data.LinkUrl == nil || *data.LinkUrl == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.link_url_missing.error", nil, "", http.StatusBadRequest)
+		}
+	case model.ChannelBookmarkFile:
+		if data.File == nil || data.File.Path == nil || *data.File.Path == "" {
+			return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.file_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if err := ValidateAttachmentImportData(data.File); err != nil {
+			return err
+		}
+	default:
+		return model.NewAppError("BulkImport", "app.import.validate_channel_bookmark_import_data.type_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateDraftImportData(data *DraftImportData, maxPostSize int) *model.AppError {
+	if data == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_draft_import_data.empty.error", nil, "", http.StatusBadRequest)
+	}
+
+	if err := validateChannelReference(data.Team, data.Channel, data.ChannelMembers); err != nil {
+		return err
+	}
+
+	if data.User == nil || *data.User == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_draft_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Message == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_draft_import_data.message_missing.error", nil, "", http.StatusBadRequest)
+	} else if utf8.RuneCountInString(*data.Message) > maxPostSize {
+		return model.NewAppError("BulkImport", "app.import.validate_draft_import_data.message_length.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateScheduledPostImportData(data *ScheduledPostImportData, maxPostSize int) *model.AppError {
+	if data == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_scheduled_post_import_data.empty.error", nil, "", http.StatusBadRequest)
+	}
+
+	if err := validateChannelReference(data.Team, data.Channel, data.ChannelMembers); err != nil {
+		return err
+	}
+
+	if data.User == nil || *data.User == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_scheduled_post_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Message == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_scheduled_post_import_data.message_missing.error", nil, "", http.StatusBadRequest)
+	} else if utf8.RuneCountInString(*data.Message) > maxPostSize {
+		return model.NewAppError("BulkImport", "app.import.validate_scheduled_post_import_data.message_length.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.ScheduledAt == nil || *data.ScheduledAt <= 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_scheduled_post_import_data.scheduled_at_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateIncomingWebhookImportData(data *IncomingWebhookImportData) *model.AppError {
+	if data == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_incoming_webhook_import_data.empty.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Team == nil || *data.Team == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_incoming_webhook_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Channel == nil || *data.Channel == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_incoming_webhook_import_data.channel_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.User == nil || *data.User == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_incoming_webhook_import_data.user_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Id != nil && !model.IsValidId(*data.Id) {
+		return model.NewAppError("BulkImport", "app.import.validate_incoming_webhook_import_data.id_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateOutgoingWebhookImportData(data *OutgoingWebhookImportData) *model.AppError {
+	if data == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_outgoing_webhook_import_data.empty.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Team == nil || *data.Team == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_outgoing_webhook_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Creator == nil || *data.Creator == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_outgoing_webhook_import_data.creator_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.DisplayName == nil || *data.DisplayName == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_outgoing_webhook_import_data.display_name_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.CallbackURLs == nil || len(*data.CallbackURLs) == 0 {
+		return model.NewAppError("BulkImport", "app.import.validate_outgoing_webhook_import_data.callback_urls_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func ValidateCommandImportData(data *CommandImportData) *model.AppError {
+	if data == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.empty.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Team == nil || *data.Team == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.team_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Creator == nil || *data.Creator == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.creator_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Trigger == nil || *data.Trigger == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.trigger_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Method == nil || (*data.Method != model.CommandMethodPost && *data.Method != model.CommandMethodGet) {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.method_invalid.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.URL == nil || *data.URL == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_command_import_data.url_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func isValidTrueOrFalseString(value string) bool {
 	return value == "true" || value == "false"
 }
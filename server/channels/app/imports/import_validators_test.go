@@ -1613,6 +1613,367 @@ func checkNoError(t *testing.T, err *model.AppError) {
 	require.Nil(t, err, "Unexpected Error: %v", err)
 }
 
+func TestImportValidateDeleteImportData(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		input       *DeleteImportData
+		expectError string
+	}{
+		{"nil data", nil, "app.import.validate_delete_import_data.empty.error"},
+		{
+			"team",
+			&DeleteImportData{Type: new(DeleteTypeTeam), Team: new("team"), DeleteAt: new(int64(1000))},
+			"",
+		},
+		{
+			"missing delete_at",
+			&DeleteImportData{Type: new(DeleteTypeTeam), Team: new("team")},
+			"app.import.validate_delete_import_data.delete_at_missing.error",
+		},
+		{
+			"missing type",
+			&DeleteImportData{Team: new("team"), DeleteAt: new(int64(1000))},
+			"app.import.validate_delete_import_data.type_invalid.error",
+		},
+		{
+			"invalid type",
+			&DeleteImportData{Type: new("user"), Team: new("team"), DeleteAt: new(int64(1000))},
+			"app.import.validate_delete_import_data.type_invalid.error",
+		},
+		{
+			"team without name",
+			&DeleteImportData{Type: new(DeleteTypeTeam), DeleteAt: new(int64(1000))},
+			"app.import.validate_delete_import_data.team_missing.error",
+		},
+		{
+			"channel",
+			&DeleteImportData{Type: new(DeleteTypeChannel), Team: new("team"), Channel: new("channel"), DeleteAt: new(int64(1000))},
+			"",
+		},
+		{
+			"channel without name",
+			&DeleteImportData{Type: new(DeleteTypeChannel), Team: new("team"), DeleteAt: new(int64(1000))},
+			"app.import.validate_delete_import_data.channel_missing.error",
+		},
+		{
+			"post",
+			&DeleteImportData{Type: new(DeleteTypePost), Team: new("team"), Channel: new("channel"), User: new("user"), Message: new("message"), CreateAt: new(int64(500)), DeleteAt: new(int64(1000))},
+			"",
+		},
+		{
+			"post without create_at",
+			&DeleteImportData{Type: new(DeleteTypePost), Team: new("team"), Channel: new("channel"), User: new("user"), Message: new("message"), DeleteAt: new(int64(1000))},
+			"app.import.validate_delete_import_data.create_at_missing.error",
+		},
+		{
+			"post without message",
+			&DeleteImportData{Type: new(DeleteTypePost), Team: new("team"), Channel: new("channel"), User: new("user"), CreateAt: new(int64(500)), DeleteAt: new(int64(1000))},
+			"app.import.validate_delete_import_data.message_missing.error",
+		},
+		{
+			"post without user",
+			&DeleteImportData{Type: new(DeleteTypePost), Team: new("team"), Channel: new("channel"), Message: new("message"), CreateAt: new(int64(500)), DeleteAt: new(int64(1000))},
+			"app.import.validate_delete_import_data.user_missing.error",
+		},
+		{
+			"direct post",
+			&DeleteImportData{Type: new(DeleteTypeDirectPost), ChannelMembers: &[]string{"user1", "user2"}, User: new("user1"), Message: new("message"), CreateAt: new(int64(500)), DeleteAt: new(int64(1000))},
+			"",
+		},
+		{
+			"direct post without members",
+			&DeleteImportData{Type: new(DeleteTypeDirectPost), ChannelMembers: &[]string{}, User: new("user1"), Message: new("message"), CreateAt: new(int64(500)), DeleteAt: new(int64(1000))},
+			"app.import.validate_delete_import_data.channel_members_missing.error",
+		},
+		{
+			"team member",
+			&DeleteImportData{Type: new(DeleteTypeTeamMember), Team: new("team"), User: new("user"), DeleteAt: new(int64(1000))},
+			"",
+		},
+		{
+			"team member without user",
+			&DeleteImportData{Type: new(DeleteTypeTeamMember), Team: new("team"), DeleteAt: new(int64(1000))},
+			"app.import.validate_delete_import_data.user_missing.error",
+		},
+		{
+			"channel member",
+			&DeleteImportData{Type: new(DeleteTypeChannelMember), Team: new("team"), Channel: new("channel"), User: new("user"), DeleteAt: new(int64(1000))},
+			"",
+		},
+		{
+			"channel member without channel",
+			&DeleteImportData{Type: new(DeleteTypeChannelMember), Team: new("team"), User: new("user"), DeleteAt: new(int64(1000))},
+			"app.import.validate_delete_import_data.channel_missing.error",
+		},
+		{
+			"emoji",
+			&DeleteImportData{Type: new(DeleteTypeEmoji), Emoji: new("emoji"), DeleteAt: new(int64(1000))},
+			"",
+		},
+		{
+			"emoji without name",
+			&DeleteImportData{Type: new(DeleteTypeEmoji), DeleteAt: new(int64(1000))},
+			"app.import.validate_delete_import_data.emoji_missing.error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := ValidateDeleteImportData(tc.input)
+			if tc.expectError != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.expectError, err.Id)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestImportValidateChannelBookmarkImportData(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		input       *ChannelBookmarkImportData
+		expectError string
+	}{
+		{"nil data", nil, "app.import.validate_channel_bookmark_import_data.empty.error"},
+		{
+			"link",
+			&ChannelBookmarkImportData{Team: new("team"), Channel: new("channel"), Owner: new("user"), DisplayName: new("docs"), Type: new(model.ChannelBookmarkLink), LinkUrl: new("https://example.com")},
+			"",
+		},
+		{
+			"link in a direct channel",
+			&ChannelBookmarkImportData{ChannelMembers: &[]string{"user1", "user2"}, Owner: new("user1"), DisplayName: new("docs"), Type: new(model.ChannelBookmarkLink), LinkUrl: new("https://example.com")},
+			"",
+		},
+		{
+			"link without url",
+			&ChannelBookmarkImportData{Team: new("team"), Channel: new("channel"), Owner: new("user"), DisplayName: new("docs"), Type: new(model.ChannelBookmarkLink)},
+			"app.import.validate_channel_bookmark_import_data.link_url_missing.error",
+		},
+		{
+			"file",
+			&ChannelBookmarkImportData{Team: new("team"), Channel: new("channel"), Owner: new("user"), DisplayName: new("spec"), Type: new(model.ChannelBookmarkFile), File: &AttachmentImportData{Path: new("data/spec.pdf")}},
+			"",
+		},
+		{
+			"file without file",
+			&ChannelBookmarkImportData{Team: new("team"), Channel: new("channel"), Owner: new("user"), DisplayName: new("spec"), Type: new(model.ChannelBookmarkFile)},
+			"app.import.validate_channel_bookmark_import_data.file_missing.error",
+		},
+		{
+			"invalid type",
+			&ChannelBookmarkImportData{Team: new("team"), Channel: new("channel"), Owner: new("user"), DisplayName: new("docs"), Type: new(model.ChannelBookmarkType("page"))},
+			"app.import.validate_channel_bookmark_import_data.type_invalid.error",
+		},
+		{
+			"missing owner",
+			&ChannelBookmarkImportData{Team: new("team"), Channel: new("channel"), DisplayName: new("docs"), Type: new(model.ChannelBookmarkLink), LinkUrl: new("https://example.com")},
+			"app.import.validate_channel_bookmark_import_data.owner_missing.error",
+		},
+		{
+			"missing display name",
+			&ChannelBookmarkImportData{Team: new("team"), Channel: new("channel"), Owner: new("user"), Type: new(model.ChannelBookmarkLink), LinkUrl: new("https://example.com")},
+			"app.import.validate_channel_bookmark_import_data.display_name_missing.error",
+		},
+		{
+			"missing channel",
+			&ChannelBookmarkImportData{Team: new("team"), Owner: new("user"), DisplayName: new("docs"), Type: new(model.ChannelBookmarkLink), LinkUrl: new("https://example.com")},
+			"app.import.validate_channel_reference.channel_missing.error",
+		},
+		{
+			"both channel and members",
+			&ChannelBookmarkImportData{Team: new("team"), Channel: new("channel"), ChannelMembers: &[]string{"user1", "user2"}, Owner: new("user"), DisplayName: new("docs"), Type: new(model.ChannelBookmarkLink), LinkUrl: new("https://example.com")},
+			"app.import.validate_channel_reference.ambiguous.error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := ValidateChannelBookmarkImportData(tc.input)
+			if tc.expectError != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.expectError, err.Id)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestImportValidateDraftImportData(t *testing.T) {
+	maxPostSize := 10000
+
+	testCases := []struct {
+		testName    string
+		input       *DraftImportData
+		expectError string
+	}{
+		{"nil data", nil, "app.import.validate_draft_import_data.empty.error"},
+		{
+			"channel draft",
+			&DraftImportData{Team: new("team"), Channel: new("channel"), User: new("user"), Message: new("message")},
+			"",
+		},
+		{
+			"thread draft in a group channel",
+			&DraftImportData{ChannelMembers: &[]string{"user1", "user2", "user3"}, User: new("user1"), RootCreateAt: new(int64(1000)), Message: new("message")},
+			"",
+		},
+		{
+			"missing user",
+			&DraftImportData{Team: new("team"), Channel: new("channel"), Message: new("message")},
+			"app.import.validate_draft_import_data.user_missing.error",
+		},
+		{
+			"missing message",
+			&DraftImportData{Team: new("team"), Channel: new("channel"), User: new("user")},
+			"app.import.validate_draft_import_data.message_missing.error",
+		},
+		{
+			"message too long",
+			&DraftImportData{Team: new("team"), Channel: new("channel"), User: new("user"), Message: new(strings.Repeat("0", maxPostSize+1))},
+			"app.import.validate_draft_import_data.message_length.error",
+		},
+		{
+			"missing team",
+			&DraftImportData{Channel: new("channel"), User: new("user"), Message: new("message")},
+			"app.import.validate_channel_reference.team_missing.error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := ValidateDraftImportData(tc.input, maxPostSize)
+			if tc.expectError != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.expectError, err.Id)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestImportValidateScheduledPostImportData(t *testing.T) {
+	maxPostSize := 10000
+
+	testCases := []struct {
+		testName    string
+		input       *ScheduledPostImportData
+		expectError string
+	}{
+		{"nil data", nil, "app.import.validate_scheduled_post_import_data.empty.error"},
+		{
+			"scheduled post",
+			&ScheduledPostImportData{Team: new("team"), Channel: new("channel"), User: new("user"), Message: new("message"), ScheduledAt: new(int64(1000))},
+			"",
+		},
+		{
+			"missing scheduled_at",
+			&ScheduledPostImportData{Team: new("team"), Channel: new("channel"), User: new("user"), Message: new("message")},
+			"app.import.validate_scheduled_post_import_data.scheduled_at_missing.error",
+		},
+		{
+			"missing user",
+			&ScheduledPostImportData{Team: new("team"), Channel: new("channel"), Message: new("message"), ScheduledAt: new(int64(1000))},
+			"app.import.validate_scheduled_post_import_data.user_missing.error",
+		},
+		{
+			"direct channel with too few members",
+			&ScheduledPostImportData{ChannelMembers: &[]string{}, User: new("user"), Message: new("message"), ScheduledAt: new(int64(1000))},
+			"app.import.validate_channel_reference.channel_members_invalid.error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := ValidateScheduledPostImportData(tc.input, maxPostSize)
+			if tc.expectError != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.expectError, err.Id)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestImportValidateWebhookImportData(t *testing.T) {
+	t.Run("incoming", func(t *testing.T) {
+		assert.Equal(t, "app.import.validate_incoming_webhook_import_data.empty.error", ValidateIncomingWebhookImportData(nil).Id)
+
+		data := &IncomingWebhookImportData{Team: new("team"), Channel: new("channel"), User: new("user")}
+		assert.Nil(t, ValidateIncomingWebhookImportData(data))
+
+		data.Channel = nil
+		appErr := ValidateIncomingWebhookImportData(data)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.validate_incoming_webhook_import_data.channel_missing.error", appErr.Id)
+
+		data.Channel = new("channel")
+		data.Id = new("invalid")
+		appErr = ValidateIncomingWebhookImportData(data)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.validate_incoming_webhook_import_data.id_invalid.error", appErr.Id)
+	})
+
+	t.Run("outgoing", func(t *testing.T) {
+		assert.Equal(t, "app.import.validate_outgoing_webhook_import_data.empty.error", ValidateOutgoingWebhookImportData(nil).Id)
+
+		data := &OutgoingWebhookImportData{Team: new("team"), Creator: new("user"), DisplayName: new("hook"), CallbackURLs: &[]string{"https://example.com"}}
+		assert.Nil(t, ValidateOutgoingWebhookImportData(data))
+
+		data.CallbackURLs = &[]string{}
+		appErr := ValidateOutgoingWebhookImportData(data)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.validate_outgoing_webhook_import_data.callback_urls_missing.error", appErr.Id)
+	})
+}
+
+func TestImportValidateCommandImportData(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		input       *CommandImportData
+		expectError string
+	}{
+		{"nil data", nil, "app.import.validate_command_import_data.empty.error"},
+		{
+			"command",
+			&CommandImportData{Team: new("team"), Creator: new("user"), Trigger: new("deploy"), Method: new(model.CommandMethodPost), URL: new("https://example.com")},
+			"",
+		},
+		{
+			"missing trigger",
+			&CommandImportData{Team: new("team"), Creator: new("user"), Method: new(model.CommandMethodPost), URL: new("https://example.com")},
+			"app.import.validate_command_import_data.trigger_missing.error",
+		},
+		{
+			"invalid method",
+			&CommandImportData{Team: new("team"), Creator: new("user"), Trigger: new("deploy"), Method: new("D"), URL: new("https://example.com")},
+			"app.import.validate_command_import_data.method_invalid.error",
+		},
+		{
+			"missing url",
+			&CommandImportData{Team: new("team"), Creator: new("user"), Trigger: new("deploy"), Method: new(model.CommandMethodGet)},
+			"app.import.validate_command_import_data.url_missing.error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			err := ValidateCommandImportData(tc.input)
+			if tc.expectError != "" {
+				require.NotNil(t, err)
+				assert.Equal(t, tc.expectError, err.Id)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateGuestRoles(t *testing.T) {
 	testCases := []struct {
 		name        string
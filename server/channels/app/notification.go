@@ -527,7 +527,7 @@ func (a *App) SendNotifications(rctx request.CTX, post *model.Post, team *model.
 		}
 	}
 
-	if a.canSendPushNotifications() {
+	if a.canSendPushNotifications() || a.canSendWebPushNotifications() {
 		rctx.Logger().LogM(mlog.MlvlNotificationTrace, "Begin sending push notifications",
 			mlog.String("type", model.NotificationTypePush),
 			mlog.String("sender_id", sender.Id),
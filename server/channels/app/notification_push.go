@@ -87,10 +87,18 @@ func (a *App) sendPushNotificationSync(rctx request.CTX, post *model.Post, user
 		return appErr
 	}
 
-	return a.sendPushNotificationToAllSessions(rctx, msg, user.Id, "")
+	// Post notifications are also queued when only web push is enabled.
+	return a.sendPushNotificationToSessions(rctx, msg, user.Id, "", a.canSendPushNotifications())
 }
 
 func (a *App) sendPushNotificationToAllSessions(rctx request.CTX, msg *model.PushNotification, userID string, skipSessionId string) *model.AppError {
+	return a.sendPushNotificationToSessions(rctx, msg, userID, skipSessionId, true)
+}
+
+// sendPushNotificationToSessions sends the notification to the browsers the user has
+// subscribed from when web push is enabled, and to the mobile app sessions of the user
+// through the push proxy when includeMobile is set.
+func (a *App) sendPushNotificationToSessions(rctx request.CTX, msg *model.PushNotification, userID string, skipSessionId string, includeMobile bool) *model.AppError {
 	rejectionReason := ""
 
 	if msg == nil {
@@ -146,6 +154,14 @@ func (a *App) sendPushNotificationToAllSessions(rctx request.CTX, msg *model.Pus
 		return nil
 	}
 
+	if a.canSendWebPushNotifications() {
+		a.sendWebPushNotificationToAllSessions(rctx, msg, userID, skipSessionId)
+	}
+
+	if !includeMobile {
+		return nil
+	}
+
 	sessions, appErr := a.getMobileAppSessions(userID)
 	if appErr != nil {
 		a.CountNotificationReason(model.NotificationStatusError, model.NotificationTypePush, model.NotificationReasonFetchError, model.NotificationNoPlatform)
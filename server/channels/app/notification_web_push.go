@@ -0,0 +1,256 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/webpush"
+)
+
+const webPushRequestTimeout = 10 * time.Second
+
+// canSendWebPushNotifications reports whether notifications can be pushed to browsers.
+// Unlike the mobile push notifications, this doesn't depend on the push proxy: only the
+// VAPID key is needed, and the users have to subscribe from their browser sessions.
+func (a *App) canSendWebPushNotifications() bool {
+	if !*a.Config().EmailSettings.SendWebPushNotifications {
+		return false
+	}
+
+	if a.Srv().platform.WebPushVAPIDKey() == nil {
+		a.Log().LogM(mlog.MlvlNotificationWarn, "Web push notifications are disabled - no VAPID key available",
+			mlog.String("status", model.NotificationStatusNotSent),
+			mlog.String("reason", "web_push_no_vapid_key"),
+		)
+		return false
+	}
+
+	return true
+}
+
+// sendWebPushNotificationToAllSessions delivers the notification straight to the push
+// services of the browsers the user has subscribed from, without going through the
+// push proxy. The requests are sent in the background so that a slow push service
+// doesn't hold up the notifications of other users.
+func (a *App) sendWebPushNotificationToAllSessions(rctx request.CTX, msg *model.PushNotification, userID string, skipSessionId string) {
+	// Browsers require the service worker to display something for every push message
+	// it receives, so only the notifications meant to be shown to the user are sent.
+	if msg.Type != model.PushTypeMessage {
+		return
+	}
+
+	key := a.Srv().platform.WebPushVAPIDKey()
+	if key == nil {
+		return
+	}
+
+	sessions, err := a.Srv().Store().Session().GetSessionsWithWebPushSubscriptions(userID)
+	if err != nil {
+		a.CountNotificationReason(model.NotificationStatusError, model.NotificationTypePush, model.NotificationReasonFetchError, model.PushNotifyWebPush)
+		rctx.Logger().LogM(mlog.MlvlNotificationError, "Failed to get web push sessions",
+			mlog.String("type", model.NotificationTypePush),
+			mlog.String("status", model.NotificationStatusError),
+			mlog.String("reason", model.NotificationReasonFetchError),
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	sender := webpush.NewSender(a.Srv().webPushClient, key, a.webPushSubject())
+
+	// A browser that was logged in more than once may have attached the same
+	// subscription to several sessions, but should only be notified once.
+	sent := make(map[string]bool, len(sessions))
+	for _, session := range sessions {
+		if session.IsExpired() || (skipSessionId != "" && skipSessionId == session.Id) {
+			continue
+		}
+
+		subscription := session.GetWebPushSubscription()
+		if subscription == nil || sent[subscription.Endpoint] {
+			continue
+		}
+		sent[subscription.Endpoint] = true
+
+		tmpMessage := msg.DeepCopy()
+		tmpMessage.Platform = model.PushNotifyWebPush
+		tmpMessage.DeviceId = ""
+		tmpMessage.AckId = model.NewId()
+
+		payload, err := marshalWebPushPayload(tmpMessage)
+		if err != nil {
+			a.CountNotificationReason(model.NotificationStatusError, model.NotificationTypePush, model.NotificationReasonParseError, model.PushNotifyWebPush)
+			rctx.Logger().LogM(mlog.MlvlNotificationError, "Failed to encode web push notification",
+				mlog.String("type", model.NotificationTypePush),
+				mlog.String("status", model.NotificationStatusError),
+				mlog.String("reason", model.NotificationReasonParseError),
+				mlog.String("user_id", session.UserId),
+				mlog.String("session_id", session.Id),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		a.Srv().GoBuffered(func() {
+			a.sendWebPushNotification(rctx, sender, session, subscription, tmpMessage, payload)
+		})
+	}
+}
+
+// sendWebPushNotification sends the encoded notification to the push service of a
+// browser subscription, removing the subscription if it has expired.
+func (a *App) sendWebPushNotification(rctx request.CTX, sender *webpush.Sender, session *model.Session, subscription *model.WebPushSubscription, msg *model.PushNotification, payload []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), webPushRequestTimeout)
+	defer cancel()
+
+	err := sender.Send(ctx, subscription, &webpush.Message{
+		Payload: payload,
+		TTL:     webpush.DefaultTTL,
+		Urgency: webpush.UrgencyHigh,
+	})
+	if err != nil {
+		reason := model.NotificationReasonPushProxySendError
+		if errors.Is(err, webpush.ErrSubscriptionGone) {
+			reason = model.NotificationReasonPushProxyRemoveDevice
+			a.removeWebPushSubscription(rctx, session)
+		}
+		a.CountNotificationReason(model.NotificationStatusError, model.NotificationTypePush, reason, model.PushNotifyWebPush)
+		rctx.Logger().LogM(mlog.MlvlNotificationError, "Failed to send web push notification",
+			mlog.String("type", model.NotificationTypePush),
+			mlog.String("status", model.NotificationStatusNotSent),
+			mlog.String("reason", reason),
+			mlog.String("ack_id", msg.AckId),
+			mlog.String("push_type", msg.Type),
+			mlog.String("user_id", session.UserId),
+			mlog.String("session_id", session.Id),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	rctx.Logger().LogM(mlog.MlvlNotificationTrace, "Notification sent to web push service",
+		mlog.String("type", model.NotificationTypePush),
+		mlog.String("ack_id", msg.AckId),
+		mlog.String("push_type", msg.Type),
+		mlog.String("user_id", session.UserId),
+		mlog.String("session_id", session.Id),
+		mlog.String("status", model.PushSendSuccess),
+	)
+
+	if a.Metrics() != nil {
+		a.Metrics().IncrementPostSentPush()
+	}
+
+	a.CountNotification(model.NotificationTypePush, model.PushNotifyWebPush)
+}
+
+// AttachWebPushSubscription attaches the subscription of the browser the session was
+// created from, so that notifications for the user are pushed to it.
+func (a *App) AttachWebPushSubscription(session *model.Session, subscription *model.WebPushSubscription) *model.AppError {
+	if !*a.Config().EmailSettings.SendWebPushNotifications {
+		return model.NewAppError("AttachWebPushSubscription", "app.session.web_push.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if appErr := subscription.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	data, err := json.Marshal(subscription)
+	if err != nil {
+		return model.NewAppError("AttachWebPushSubscription", "app.session.set_extra_session_prop.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if appErr := a.SetExtraSessionProps(session, map[string]string{model.SessionPropWebPushSubscription: string(data)}); appErr != nil {
+		return appErr
+	}
+
+	a.ClearSessionCacheForUser(session.UserId)
+	return nil
+}
+
+// DetachWebPushSubscription stops pushing notifications to the browser the session
+// was created from.
+func (a *App) DetachWebPushSubscription(session *model.Session) *model.AppError {
+	if appErr := a.SetExtraSessionProps(session, map[string]string{model.SessionPropWebPushSubscription: ""}); appErr != nil {
+		return appErr
+	}
+
+	a.ClearSessionCacheForUser(session.UserId)
+	return nil
+}
+
+// removeWebPushSubscription detaches a subscription the push service no longer accepts
+// from the session, so that no more notifications are sent to it.
+func (a *App) removeWebPushSubscription(rctx request.CTX, session *model.Session) {
+	if appErr := a.DetachWebPushSubscription(session); appErr != nil {
+		rctx.Logger().Warn("Failed to remove web push subscription", mlog.String("session_id", session.Id), mlog.Err(appErr))
+	}
+}
+
+// webPushSubject returns the contact URL sent to push services in the VAPID token,
+// which must be either an https: or a mailto: URL.
+func (a *App) webPushSubject() string {
+	if siteURL := *a.Config().ServiceSettings.SiteURL; strings.HasPrefix(siteURL, "https://") {
+		return siteURL
+	}
+	return "mailto:" + *a.Config().EmailSettings.FeedbackEmail
+}
+
+// marshalWebPushPayload encodes the notification, shortening the message if needed to
+// fit within the maximum size of a push message.
+func marshalWebPushPayload(msg *model.PushNotification) ([]byte, error) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) <= webpush.MaxPayloadSize {
+		return payload, nil
+	}
+
+	message := msg.Message
+	msg.Message = "..."
+	payload, err = json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep as much of the message as fits, measuring each character once encoded
+	// since JSON escaping can make it several times longer.
+	budget := webpush.MaxPayloadSize - len(payload)
+	keep := 0
+	for keep < len(message) {
+		_, size := utf8.DecodeRuneInString(message[keep:])
+		encoded, err := json.Marshal(message[keep : keep+size])
+		if err != nil {
+			return nil, err
+		}
+		if budget -= len(encoded) - 2; budget < 0 {
+			break
+		}
+		keep += size
+	}
+
+	msg.Message = message[:keep] + "..."
+	payload, err = json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) > webpush.MaxPayloadSize {
+		return nil, webpush.ErrPayloadTooLarge
+	}
+
+	return payload, nil
+}
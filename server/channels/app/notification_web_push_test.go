@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/services/webpush"
+)
+
+func TestMarshalWebPushPayload(t *testing.T) {
+	newMessage := func(message string) *model.PushNotification {
+		return &model.PushNotification{
+			Platform:  model.PushNotifyWebPush,
+			ServerId:  model.NewId(),
+			PostId:    model.NewId(),
+			ChannelId: model.NewId(),
+			Type:      model.PushTypeMessage,
+			Message:   message,
+		}
+	}
+
+	t.Run("short message is unchanged", func(t *testing.T) {
+		payload, err := marshalWebPushPayload(newMessage("hello"))
+		require.NoError(t, err)
+
+		var decoded model.PushNotification
+		require.NoError(t, json.Unmarshal(payload, &decoded))
+		assert.Equal(t, "hello", decoded.Message)
+	})
+
+	for name, message := range map[string]string{
+		"long message":         strings.Repeat("a", 2*webpush.MaxPayloadSize),
+		"multibyte characters": strings.Repeat("é", webpush.MaxPayloadSize),
+		"escaped characters":   strings.Repeat("<", webpush.MaxPayloadSize),
+	} {
+		t.Run(name, func(t *testing.T) {
+			payload, err := marshalWebPushPayload(newMessage(message))
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(payload), webpush.MaxPayloadSize)
+
+			var decoded model.PushNotification
+			require.NoError(t, json.Unmarshal(payload, &decoded))
+			assert.True(t, strings.HasSuffix(decoded.Message, "..."))
+			assert.True(t, utf8.ValidString(decoded.Message))
+			assert.True(t, strings.HasPrefix(message, strings.TrimSuffix(decoded.Message, "...")))
+		})
+	}
+}
+
+func TestSendWebPushNotificationsWithoutPushProxy(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	var proxyRequests, webPushRequests atomic.Int32
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyRequests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(proxyServer.Close)
+	webPushServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webPushRequests.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(webPushServer.Close)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.SendPushNotifications = false
+		*cfg.EmailSettings.PushNotificationServer = proxyServer.URL
+		*cfg.EmailSettings.SendWebPushNotifications = true
+	})
+	require.NoError(t, th.App.Srv().platform.EnsureWebPushVAPIDKey())
+	th.App.Srv().webPushClient = webPushServer.Client()
+
+	require.False(t, th.App.canSendPushNotifications())
+	require.True(t, th.App.canSendWebPushNotifications())
+
+	_, appErr := th.App.CreateSession(th.Context, &model.Session{
+		UserId:    th.BasicUser.Id,
+		DeviceId:  "mobile",
+		ExpiresAt: model.GetMillis() + 100000,
+	})
+	require.Nil(t, appErr)
+
+	uaPrivateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, err = rand.Read(authSecret)
+	require.NoError(t, err)
+	for range 2 {
+		browserSession, appErr := th.App.CreateSession(th.Context, &model.Session{
+			UserId:    th.BasicUser.Id,
+			ExpiresAt: model.GetMillis() + 100000,
+		})
+		require.Nil(t, appErr)
+		appErr = th.App.AttachWebPushSubscription(browserSession, &model.WebPushSubscription{
+			Endpoint: webPushServer.URL + "/push/" + model.NewId(),
+			Keys: model.WebPushSubscriptionKeys{
+				P256dh: base64.RawURLEncoding.EncodeToString(uaPrivateKey.PublicKey().Bytes()),
+				Auth:   base64.RawURLEncoding.EncodeToString(authSecret),
+			},
+		})
+		require.Nil(t, appErr)
+	}
+
+	post := th.CreatePost(t, th.BasicChannel)
+	appErr = th.App.sendPushNotificationSync(th.Context, post, th.BasicUser, th.BasicChannel, th.BasicChannel.DisplayName, th.BasicUser2.Username, true, false, "")
+	require.Nil(t, appErr)
+
+	require.Eventually(t, func() bool {
+		return webPushRequests.Load() == 2
+	}, 5*time.Second, 50*time.Millisecond, "every browser subscription should be notified")
+	assert.Zero(t, proxyRequests.Load(), "the push proxy should not be used when push notifications are disabled")
+}
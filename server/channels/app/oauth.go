@@ -22,6 +22,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	oauthopenid "github.com/mattermost/mattermost/server/v8/channels/app/oauthproviders/openid"
 	"github.com/mattermost/mattermost/server/v8/channels/app/platform"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
@@ -31,6 +32,7 @@ import (
 const (
 	OAuthCookieMaxAgeSeconds = 30 * 60 // 30 minutes
 	CookieOAuth              = "MMOAUTH"
+	CookieOAuthPKCE          = "MMOAUTHPKCE"
 	OpenIDScope              = "openid"
 )
 
@@ -719,6 +721,29 @@ func (a *App) RevokeAccessToken(rctx request.CTX, token string) *model.AppError
 func (a *App) CompleteOAuth(rctx request.CTX, service string, body io.ReadCloser, props map[string]string, tokenUser *model.User) (*model.User, *model.AppError) {
 	defer body.Close()
 
+	if !model.IsOpenIdProviderService(service) {
+		return a.completeOAuthAction(rctx, service, body, props, tokenUser)
+	}
+
+	// The claims of the users of OpenID Connect providers are read again once they are signed in,
+	// to update their groups and custom profile attributes.
+	claims, err := io.ReadAll(body)
+	if err != nil {
+		return nil, model.NewAppError("CompleteOAuth", "api.user.login_by_oauth.parse.app_error",
+			map[string]any{"Service": service}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	user, appErr := a.completeOAuthAction(rctx, service, bytes.NewReader(claims), props, tokenUser)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	a.syncOpenIdProviderClaims(rctx, service, user, claims)
+
+	return user, nil
+}
+
+func (a *App) completeOAuthAction(rctx request.CTX, service string, body io.Reader, props map[string]string, tokenUser *model.User) (*model.User, *model.AppError) {
 	action := props["action"]
 
 	// Extract invite token or ID from props so we can add the user to the team if needed
@@ -740,6 +765,14 @@ func (a *App) CompleteOAuth(rctx request.CTX, service string, body io.ReadCloser
 }
 
 func (a *App) getSSOProvider(service string) (einterfaces.OAuthProvider, *model.AppError) {
+	if model.IsOpenIdProviderService(service) {
+		provider, appErr := a.getOpenIdProvider(service)
+		if appErr != nil {
+			return nil, appErr
+		}
+		return provider, nil
+	}
+
 	sso := a.Config().GetSSOService(service)
 	if sso == nil || !*sso.Enable {
 		return nil, model.NewAppError("getSSOProvider", "api.user.authorize_oauth_user.unsupported.app_error", nil, "service="+service, http.StatusNotImplemented)
@@ -1019,6 +1052,22 @@ func (a *App) GetAuthorizationCode(rctx request.CTX, w http.ResponseWriter, r *h
 		authURL += "&login_hint=" + utils.URLEncode(loginHint)
 	}
 
+	if openIdProvider, ok := provider.(*oauthopenid.Provider); ok && openIdProvider.UsePKCE() {
+		// The code verifier is kept in a cookie of its own, as the state token is sent to the provider.
+		codeVerifier := model.NewRandomString(model.PKCECodeVerifierMaxLength / 2)
+		http.SetCookie(w, &http.Cookie{
+			Name:     CookieOAuthPKCE,
+			Value:    codeVerifier,
+			Path:     subpath,
+			MaxAge:   OAuthCookieMaxAgeSeconds,
+			Expires:  expiresAt,
+			HttpOnly: true,
+			Secure:   secure,
+		})
+
+		authURL += "&code_challenge=" + oauthopenid.CodeChallenge(codeVerifier) + "&code_challenge_method=" + model.PKCECodeChallengeMethodS256
+	}
+
 	return authURL, nil
 }
 
@@ -1091,6 +1140,23 @@ func (a *App) AuthorizeOAuthUser(rctx request.CTX, w http.ResponseWriter, r *htt
 	p.Set("grant_type", model.AccessTokenGrantType)
 	p.Set("redirect_uri", redirectURI)
 
+	openIdProvider, isOpenIdProvider := provider.(*oauthopenid.Provider)
+	if isOpenIdProvider && openIdProvider.UsePKCE() {
+		pkceCookie, cookieErr := r.Cookie(CookieOAuthPKCE)
+		if cookieErr != nil {
+			return nil, stateProps, nil, model.NewAppError("AuthorizeOAuthUser", "api.user.authorize_oauth_user.invalid_state.app_error", nil, "", http.StatusBadRequest).Wrap(cookieErr)
+		}
+		p.Set("code_verifier", pkceCookie.Value)
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     CookieOAuthPKCE,
+			Value:    "",
+			Path:     subpath,
+			MaxAge:   -1,
+			HttpOnly: true,
+		})
+	}
+
 	req, requestErr := http.NewRequest("POST", *sso.TokenEndpoint, strings.NewReader(p.Encode()))
 	if requestErr != nil {
 		return nil, stateProps, nil, model.NewAppError("AuthorizeOAuthUser", "api.user.authorize_oauth_user.token_failed.app_error", nil, "", http.StatusInternalServerError).Wrap(requestErr)
@@ -1121,6 +1187,14 @@ func (a *App) AuthorizeOAuthUser(rctx request.CTX, w http.ResponseWriter, r *htt
 		return nil, stateProps, nil, model.NewAppError("AuthorizeOAuthUser", "api.user.authorize_oauth_user.missing.app_error", nil, "response_body="+buf.String(), http.StatusInternalServerError)
 	}
 
+	if isOpenIdProvider {
+		claims, err := openIdProvider.UserClaims(rctx, ar.IdToken, ar.AccessToken)
+		if err != nil {
+			return nil, stateProps, nil, model.NewAppError("AuthorizeOAuthUser", "api.user.authorize_oauth_user.service.app_error", map[string]any{"Service": service}, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return io.NopCloser(bytes.NewReader(claims)), stateProps, nil, nil
+	}
+
 	p = url.Values{}
 	p.Set("access_token", ar.AccessToken)
 
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	oauthopenid "github.com/mattermost/mattermost/server/v8/channels/app/oauthproviders/openid"
+)
+
+// getOpenIdProvider returns the provider of one of the OpenID Connect providers of the config.
+func (a *App) getOpenIdProvider(service string) (*oauthopenid.Provider, *model.AppError) {
+	settings := a.Config().GetOpenIdProvider(service)
+	if settings == nil || !*settings.Enable {
+		return nil, model.NewAppError("getOpenIdProvider", "api.user.authorize_oauth_user.unsupported.app_error", nil, "service="+service, http.StatusNotImplemented)
+	}
+
+	return oauthopenid.NewProvider(settings, a.HTTPService().MakeClient(true)), nil
+}
+
+// syncOpenIdProviderClaims updates the groups and custom profile attributes of a user
+// signed in with an OpenID Connect provider from the claims of the provider.
+// Failures are logged rather than preventing the user from signing in.
+func (a *App) syncOpenIdProviderClaims(rctx request.CTX, service string, user *model.User, data []byte) {
+	provider, appErr := a.getOpenIdProvider(service)
+	if appErr != nil {
+		return
+	}
+	settings := a.Config().GetOpenIdProvider(service)
+
+	claims, err := oauthopenid.ClaimsFromJSON(bytes.NewReader(data))
+	if err != nil {
+		rctx.Logger().Warn("Failed to read the claims of the OpenID Connect provider", mlog.String("service", service), mlog.Err(err))
+		return
+	}
+
+	if *settings.GroupsClaim != "" {
+		if appErr := a.syncOpenIdProviderGroups(rctx, settings, user, provider.Groups(claims)); appErr != nil {
+			rctx.Logger().Error("Failed to synchronize the groups of the user", mlog.String("service", service), mlog.String("user_id", user.Id), mlog.Err(appErr))
+		}
+	}
+
+	if attributes := provider.Attributes(claims); len(attributes) > 0 {
+		if appErr := a.importCustomProfileAttributes(rctx, user, attributes); appErr != nil {
+			rctx.Logger().Error("Failed to update the custom profile attributes of the user", mlog.String("service", service), mlog.String("user_id", user.Id), mlog.Err(appErr))
+		}
+	}
+}
+
+// syncOpenIdProviderGroups makes the user a member of exactly the groups of the provider
+// named in the groups claim. Groups are created the first time one of their members signs in.
+func (a *App) syncOpenIdProviderGroups(rctx request.CTX, settings *model.OpenIdProviderSettings, user *model.User, names []string) *model.AppError {
+	source := settings.GroupSource()
+
+	userGroups, appErr := a.GetGroupsByUserId(user.Id, model.GroupSearchOpts{})
+	if appErr != nil {
+		return appErr
+	}
+
+	isMember := make(map[string]bool, len(userGroups))
+	for _, group := range userGroups {
+		if group.Source == source {
+			isMember[group.Id] = true
+		}
+	}
+
+	memberOf := make(map[string]bool, len(names))
+	for _, name := range names {
+		if len(name) > model.GroupRemoteIDMaxLength {
+			rctx.Logger().Warn("Skipping group with a name that is too long", mlog.String("group_source", string(source)), mlog.String("group_name", name))
+			continue
+		}
+
+		group, appErr := a.GetGroupByRemoteID(name, source)
+		if appErr != nil {
+			if appErr.StatusCode != http.StatusNotFound {
+				return appErr
+			}
+
+			group, appErr = a.CreateGroup(&model.Group{
+				DisplayName: name,
+				Source:      source,
+				RemoteId:    new(name),
+			})
+			if appErr != nil {
+				return appErr
+			}
+		}
+
+		if group.DeleteAt != 0 {
+			continue
+		}
+
+		memberOf[group.Id] = true
+		if isMember[group.Id] {
+			continue
+		}
+
+		if _, appErr := a.UpsertGroupMember(group.Id, user.Id); appErr != nil {
+			return appErr
+		}
+	}
+
+	for groupID := range isMember {
+		if memberOf[groupID] {
+			continue
+		}
+
+		if _, appErr := a.DeleteGroupMember(groupID, user.Id); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
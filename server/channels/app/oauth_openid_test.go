@@ -0,0 +1,255 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	oauthopenid "github.com/mattermost/mattermost/server/v8/channels/app/oauthproviders/openid"
+)
+
+// fakeOpenIdProvider is an in-process OpenID Connect provider that
+// issues an authorization code for a single user.
+type fakeOpenIdProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mut           sync.Mutex
+	codeChallenge string
+	claims        jwt.MapClaims
+}
+
+func newFakeOpenIdProvider(t *testing.T) *fakeOpenIdProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	fp := &fakeOpenIdProvider{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 fp.server.URL,
+			"authorization_endpoint": fp.server.URL + "/authorize",
+			"token_endpoint":         fp.server.URL + "/token",
+			"jwks_uri":               fp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": "key",
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		fp.mut.Lock()
+		defer fp.mut.Unlock()
+
+		if r.FormValue("code") != "code" || oauthopenid.CodeChallenge(r.FormValue("code_verifier")) != fp.codeChallenge {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, fp.claims)
+		token.Header["kid"] = "key"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": model.NewId(),
+			"token_type":   "bearer",
+			"id_token":     idToken,
+		})
+	})
+	fp.server = httptest.NewServer(mux)
+	t.Cleanup(fp.server.Close)
+
+	return fp
+}
+
+// signIn goes through the authorization code flow of the provider for a user with the given groups.
+func (fp *fakeOpenIdProvider) signIn(t *testing.T, th *TestHelper, service, action string, groups []string) (*model.User, *model.AppError) {
+	recorder := httptest.NewRecorder()
+	authURL, appErr := th.App.GetAuthorizationCode(th.Context, recorder, httptest.NewRequest(http.MethodGet, "/", nil), service, map[string]string{"action": action}, "")
+	require.Nil(t, appErr)
+
+	parsedURL, err := url.Parse(authURL)
+	require.NoError(t, err)
+	query := parsedURL.Query()
+	require.Equal(t, fp.server.URL+"/authorize", parsedURL.Scheme+"://"+parsedURL.Host+parsedURL.Path)
+	require.Equal(t, model.PKCECodeChallengeMethodS256, query.Get("code_challenge_method"))
+
+	fp.mut.Lock()
+	fp.codeChallenge = query.Get("code_challenge")
+	fp.claims = jwt.MapClaims{
+		"iss":                fp.server.URL,
+		"aud":                "client-id",
+		"sub":                "user1",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"email":              "jane.doe@example.com",
+		"preferred_username": "jane.doe",
+		"given_name":         "Jane",
+		"family_name":        "Doe",
+		"title":              "Engineer",
+		"groups":             groups,
+	}
+	fp.mut.Unlock()
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, cookie := range recorder.Result().Cookies() {
+		r.AddCookie(cookie)
+	}
+
+	body, props, _, appErr := th.App.AuthorizeOAuthUser(th.Context, httptest.NewRecorder(), r, service, "code", query.Get("state"), th.App.GetSiteURL()+"/signup/"+service+"/complete")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return th.App.CompleteOAuth(th.Context, service, body, props, nil)
+}
+
+func TestOpenIdProviders(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+
+	fp := newFakeOpenIdProvider(t)
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.TeamSettings.EnableUserCreation = true
+		cfg.OpenIdProvidersSettings.Providers = []*model.OpenIdProviderSettings{
+			{
+				Name:              new("staff"),
+				Enable:            new(true),
+				Id:                new("client-id"),
+				Secret:            new("client-secret"),
+				DiscoveryEndpoint: new(fp.server.URL + "/.well-known/openid-configuration"),
+				PositionClaim:     new("title"),
+				GroupsClaim:       new("groups"),
+			},
+			{
+				Name:   new("contractors"),
+				Enable: new(false),
+			},
+		}
+	})
+
+	t.Run("disabled provider", func(t *testing.T) {
+		_, appErr := th.App.GetAuthorizationCode(th.Context, httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), "oidccontractors", map[string]string{}, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.authorize_oauth_user.unsupported.app_error", appErr.Id)
+	})
+
+	t.Run("missing code verifier", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		authURL, appErr := th.App.GetAuthorizationCode(th.Context, recorder, httptest.NewRequest(http.MethodGet, "/", nil), "oidcstaff", map[string]string{"action": model.OAuthActionLogin}, "")
+		require.Nil(t, appErr)
+		parsedURL, err := url.Parse(authURL)
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		for _, cookie := range recorder.Result().Cookies() {
+			if cookie.Name != CookieOAuthPKCE {
+				r.AddCookie(cookie)
+			}
+		}
+
+		_, _, _, appErr = th.App.AuthorizeOAuthUser(th.Context, httptest.NewRecorder(), r, "oidcstaff", "code", parsedURL.Query().Get("state"), "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.authorize_oauth_user.invalid_state.app_error", appErr.Id)
+	})
+
+	groupMembers := func(t *testing.T, user *model.User) []string {
+		groups, appErr := th.App.GetGroupsByUserId(user.Id, model.GroupSearchOpts{})
+		require.Nil(t, appErr)
+
+		var names []string
+		for _, group := range groups {
+			if group.Source == model.GroupSourceOpenIdPrefix+"staff" {
+				names = append(names, group.GetRemoteId())
+			}
+		}
+		return names
+	}
+
+	t.Run("sign up", func(t *testing.T) {
+		user, appErr := fp.signIn(t, th, "oidcstaff", model.OAuthActionSignup, []string{"developers", "admins"})
+		require.Nil(t, appErr)
+
+		assert.Equal(t, "oidcstaff", user.AuthService)
+		assert.Equal(t, "user1", *user.AuthData)
+		assert.Equal(t, "jane.doe", user.Username)
+		assert.Equal(t, "Engineer", user.Position)
+		assert.ElementsMatch(t, []string{"developers", "admins"}, groupMembers(t, user))
+	})
+
+	t.Run("login updates the groups of the user", func(t *testing.T) {
+		user, appErr := fp.signIn(t, th, "oidcstaff", model.OAuthActionLogin, []string{"developers", "support"})
+		require.Nil(t, appErr)
+
+		assert.Equal(t, "user1", *user.AuthData)
+		assert.ElementsMatch(t, []string{"developers", "support"}, groupMembers(t, user))
+
+		group, appErr := th.App.GetGroupByRemoteID("admins", model.GroupSourceOpenIdPrefix+"staff")
+		require.Nil(t, appErr)
+		assert.Equal(t, "admins", group.DisplayName)
+	})
+}
+
+func TestSyncOpenIdProviderClaims(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.OpenIdProvidersSettings.Providers = []*model.OpenIdProviderSettings{{
+			Name:              new("staff"),
+			Enable:            new(true),
+			DiscoveryEndpoint: new("https://idp.example.com/.well-known/openid-configuration"),
+		}}
+	})
+
+	t.Run("invalid claims are ignored", func(t *testing.T) {
+		th.App.syncOpenIdProviderClaims(th.Context, "oidcstaff", th.BasicUser, []byte("not json"))
+	})
+
+	t.Run("groups are not synchronized without a groups claim", func(t *testing.T) {
+		th.App.syncOpenIdProviderClaims(th.Context, "oidcstaff", th.BasicUser, []byte(`{"groups":["developers"]}`))
+
+		_, appErr := th.App.GetGroupByRemoteID("developers", model.GroupSourceOpenIdPrefix+"staff")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("groups with names that are too long are skipped", func(t *testing.T) {
+		settings := th.App.Config().GetOpenIdProvider("oidcstaff")
+		longName := model.NewId() + model.NewId()
+
+		appErr := th.App.syncOpenIdProviderGroups(th.Context, settings, th.BasicUser, []string{longName, "support"})
+		require.Nil(t, appErr)
+
+		groups, appErr := th.App.GetGroupsByUserId(th.BasicUser.Id, model.GroupSearchOpts{})
+		require.Nil(t, appErr)
+		require.Len(t, groups, 1)
+		assert.Equal(t, "support", groups[0].GetRemoteId())
+	})
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package oauthopenid
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// discoveryCacheTTL is how long the discovery document and the keys of an issuer are cached.
+	discoveryCacheTTL = time.Hour
+	// keysRefreshInterval is the minimum time between two fetches of the keys of an issuer
+	// when an ID token is signed with an unknown key.
+	keysRefreshInterval = time.Minute
+	maxResponseSize     = 1024 * 1024
+)
+
+type discoveryDocument struct {
+	Issuer                        string   `json:"issuer"`
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	UserInfoEndpoint              string   `json:"userinfo_endpoint"`
+	JWKSURI                       string   `json:"jwks_uri"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type issuer struct {
+	discovery     *discoveryDocument
+	discoveredAt  time.Time
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+// issuers caches the discovery document and the keys of the providers by discovery endpoint.
+var (
+	issuersMut sync.Mutex
+	issuers    = map[string]*issuer{}
+)
+
+func (p *Provider) discover(ctx context.Context) (*discoveryDocument, error) {
+	endpoint := *p.settings.DiscoveryEndpoint
+
+	issuersMut.Lock()
+	cached, ok := issuers[endpoint]
+	issuersMut.Unlock()
+	if ok && time.Since(cached.discoveredAt) < discoveryCacheTTL {
+		return cached.discovery, nil
+	}
+
+	var doc discoveryDocument
+	if err := p.getJSON(ctx, endpoint, &doc); err != nil {
+		return nil, errors.Wrap(err, "failed to get discovery document")
+	}
+
+	if doc.Issuer == "" || doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("discovery document is missing required fields")
+	}
+
+	issuersMut.Lock()
+	issuers[endpoint] = &issuer{
+		discovery:    &doc,
+		discoveredAt: time.Now(),
+	}
+	issuersMut.Unlock()
+
+	return &doc, nil
+}
+
+// key returns the public key an ID token is signed with. The keys of the issuer are fetched
+// again when the key is unknown, as the issuer may have rotated its keys.
+func (p *Provider) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := *p.settings.DiscoveryEndpoint
+
+	issuersMut.Lock()
+	cached := issuers[endpoint]
+	keys := cached.keys
+	refresh := time.Since(cached.keysFetchedAt) >= keysRefreshInterval
+	issuersMut.Unlock()
+
+	if key := findKey(keys, kid); key != nil {
+		return key, nil
+	}
+
+	if !refresh {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	keys, err = p.fetchKeys(ctx, doc.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+
+	issuersMut.Lock()
+	cached.keys = keys
+	cached.keysFetchedAt = time.Now()
+	issuersMut.Unlock()
+
+	if key := findKey(keys, kid); key != nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// findKey returns the key of the given id. Tokens without a key id can only
+// be verified when the issuer has a single key.
+func findKey(keys map[string]crypto.PublicKey, kid string) crypto.PublicKey {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key
+		}
+	}
+
+	return keys[kid]
+}
+
+func (p *Provider) fetchKeys(ctx context.Context, jwksURI string) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, jwksURI, &set); err != nil {
+		return nil, errors.Wrap(err, "failed to get signing keys")
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Keys of unsupported types are skipped rather than failing the sign in.
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (jwk *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("invalid EC point")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v any) error {
+	return p.doJSON(ctx, url, "", v)
+}
+
+func (p *Provider) doJSON(ctx context.Context, url, accessToken string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+
+	decoder := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
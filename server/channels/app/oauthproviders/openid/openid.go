@@ -0,0 +1,310 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package oauthopenid
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// idTokenLeeway is the clock skew tolerated when checking the times of an ID token.
+const idTokenLeeway = time.Minute
+
+var idTokenSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// Provider signs users in with one of the OpenID Connect providers of the config.
+// Unlike the other OAuth providers, it is not registered but created for the
+// provider settings of the auth service users sign in with.
+type Provider struct {
+	settings *model.OpenIdProviderSettings
+	client   *http.Client
+}
+
+// Claims are the claims of the ID token of a user, completed by the user info of the provider.
+type Claims map[string]any
+
+func NewProvider(settings *model.OpenIdProviderSettings, client *http.Client) *Provider {
+	return &Provider{
+		settings: settings,
+		client:   client,
+	}
+}
+
+// UsePKCE returns whether the authorization code of the provider is protected by PKCE.
+func (p *Provider) UsePKCE() bool {
+	return model.SafeDereference(p.settings.UsePKCE)
+}
+
+// CodeChallenge returns the S256 PKCE code challenge of a code verifier.
+func CodeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func ClaimsFromJSON(data io.Reader) (Claims, error) {
+	decoder := json.NewDecoder(data)
+	decoder.UseNumber()
+	var claims Claims
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// UserClaims verifies the ID token of a user and returns its claims as JSON, completed
+// by the user info of the provider when it has a user info endpoint.
+func (p *Provider) UserClaims(rctx request.CTX, idToken, accessToken string) ([]byte, error) {
+	if idToken == "" {
+		return nil, errors.New("the token response has no ID token")
+	}
+
+	claims, err := p.verifyIdToken(rctx.Context(), idToken)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := p.discover(rctx.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.UserInfoEndpoint != "" {
+		var userInfo Claims
+		if err := p.doJSON(rctx.Context(), doc.UserInfoEndpoint, accessToken, &userInfo); err != nil {
+			return nil, errors.Wrap(err, "failed to get user info")
+		}
+
+		// The user info must be about the user the ID token was issued for.
+		if claimString(userInfo, "sub") != claimString(claims, "sub") {
+			return nil, errors.New("the user info and the ID token have different subjects")
+		}
+
+		maps.Copy(claims, userInfo)
+	}
+
+	return json.Marshal(claims)
+}
+
+func (p *Provider) verifyIdToken(ctx context.Context, idToken string) (Claims, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.key(ctx, kid)
+	},
+		jwt.WithValidMethods(idTokenSigningMethods),
+		jwt.WithIssuer(doc.Issuer),
+		jwt.WithAudience(*p.settings.Id),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(idTokenLeeway),
+		jwt.WithJSONNumber(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid ID token")
+	}
+
+	return Claims(claims), nil
+}
+
+func (p *Provider) userFromClaims(logger mlog.LoggerIFace, claims Claims) (*model.User, error) {
+	authData := claimString(claims, *p.settings.IdClaim)
+	if authData == "" {
+		return nil, fmt.Errorf("missing %s claim", *p.settings.IdClaim)
+	}
+
+	email := strings.ToLower(claimString(claims, *p.settings.EmailClaim))
+	if email == "" {
+		return nil, fmt.Errorf("missing %s claim", *p.settings.EmailClaim)
+	}
+
+	// Users are matched by email when switching to SSO, so an email the provider
+	// did not verify cannot be trusted.
+	if verified, ok := lookupClaim(claims, "email_verified"); ok && claimValueString(verified) == "false" {
+		return nil, errors.New("the email of the user is not verified")
+	}
+
+	// to maintain consistency with the other providers, usernames are split by @ (if present)
+	// and the first part is kept
+	username, _, _ := strings.Cut(claimString(claims, *p.settings.UsernameClaim), "@")
+	if username == "" {
+		username, _, _ = strings.Cut(email, "@")
+	}
+
+	user := &model.User{
+		Username:    model.CleanUsername(logger, username),
+		Email:       email,
+		FirstName:   claimString(claims, *p.settings.FirstNameClaim),
+		LastName:    claimString(claims, *p.settings.LastNameClaim),
+		Nickname:    claimString(claims, *p.settings.NicknameClaim),
+		Position:    claimString(claims, *p.settings.PositionClaim),
+		AuthData:    &authData,
+		AuthService: p.settings.Service(),
+	}
+
+	return user, nil
+}
+
+// Groups returns the names of the groups of the user, read from the groups claim.
+func (p *Provider) Groups(claims Claims) []string {
+	value, ok := lookupClaim(claims, *p.settings.GroupsClaim)
+	if !ok {
+		return nil
+	}
+
+	var groups []string
+	switch value := value.(type) {
+	case []any:
+		for _, item := range value {
+			if group := claimValueString(item); group != "" {
+				groups = append(groups, group)
+			}
+		}
+	default:
+		if group := claimValueString(value); group != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// Attributes returns the values of the custom profile attributes of the user by attribute name.
+// Claims that are not strings or lists of strings are converted to strings.
+func (p *Provider) Attributes(claims Claims) map[string]json.RawMessage {
+	attributes := make(map[string]json.RawMessage, len(p.settings.AttributeClaims))
+	for name, claim := range p.settings.AttributeClaims {
+		value, ok := lookupClaim(claims, claim)
+		if !ok || value == nil {
+			continue
+		}
+
+		var attribute any
+		switch value := value.(type) {
+		case []any:
+			values := make([]string, 0, len(value))
+			for _, item := range value {
+				values = append(values, claimValueString(item))
+			}
+			attribute = values
+		default:
+			attribute = claimValueString(value)
+		}
+
+		b, err := json.Marshal(attribute)
+		if err != nil {
+			continue
+		}
+		attributes[name] = b
+	}
+
+	return attributes
+}
+
+// lookupClaim returns the claim of the given name. Names that are not claims themselves are
+// read as dotted paths to claims nested in objects, such as "address.country".
+func lookupClaim(claims Claims, name string) (any, bool) {
+	if name == "" {
+		return nil, false
+	}
+
+	if value, ok := claims[name]; ok {
+		return value, true
+	}
+
+	var value any = map[string]any(claims)
+	for part := range strings.SplitSeq(name, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[part]; !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+func claimString(claims Claims, name string) string {
+	value, ok := lookupClaim(claims, name)
+	if !ok {
+		return ""
+	}
+	return claimValueString(value)
+}
+
+func claimValueString(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return fmt.Sprint(value)
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func (p *Provider) GetUserFromJSON(rctx request.CTX, data io.Reader, tokenUser *model.User, settings *model.SSOSettings) (*model.User, error) {
+	claims, err := ClaimsFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.userFromClaims(rctx.Logger(), claims)
+}
+
+// GetSSOSettings returns the settings of the provider, with the endpoints read from its discovery document.
+func (p *Provider) GetSSOSettings(rctx request.CTX, _ *model.Config, _ string) (*model.SSOSettings, error) {
+	doc, err := p.discover(rctx.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	settings := p.settings.SSOSettings()
+	settings.AuthEndpoint = &doc.AuthorizationEndpoint
+	settings.TokenEndpoint = &doc.TokenEndpoint
+	settings.UserAPIEndpoint = &doc.UserInfoEndpoint
+
+	return settings, nil
+}
+
+func (p *Provider) GetUserFromIdToken(rctx request.CTX, idToken string) (*model.User, error) {
+	claims, err := p.verifyIdToken(rctx.Context(), idToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.userFromClaims(rctx.Logger(), claims)
+}
+
+func (p *Provider) IsSameUser(_ request.CTX, dbUser, oauthUser *model.User) bool {
+	return model.SafeDereference(dbUser.AuthData) == model.SafeDereference(oauthUser.AuthData)
+}
@@ -0,0 +1,331 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package oauthopenid
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const testClientId = "mattermost"
+
+// fakeIssuer is an in-process OpenID Connect provider.
+type fakeIssuer struct {
+	server      *httptest.Server
+	rsaKey      *rsa.PrivateKey
+	ecKey       *ecdsa.PrivateKey
+	userInfo    map[string]any
+	accessToken string
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	issuer := &fakeIssuer{
+		rsaKey:      rsaKey,
+		ecKey:       ecKey,
+		accessToken: model.NewId(),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                           issuer.server.URL,
+			"authorization_endpoint":           issuer.server.URL + "/authorize",
+			"token_endpoint":                   issuer.server.URL + "/token",
+			"userinfo_endpoint":                issuer.server.URL + "/userinfo",
+			"jwks_uri":                         issuer.server.URL + "/jwks",
+			"code_challenge_methods_supported": []string{"S256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{
+				{"kid": "rsa", "kty": "RSA", "use": "sig", "n": encode(rsaKey.N.Bytes()), "e": encode(big.NewInt(int64(rsaKey.E)).Bytes())},
+				{"kid": "ec", "kty": "EC", "crv": "P-256", "x": encode(ecKey.X.Bytes()), "y": encode(ecKey.Y.Bytes())},
+				{"kid": "enc", "kty": "RSA", "use": "enc", "n": encode(rsaKey.N.Bytes()), "e": "AQAB"},
+			},
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+issuer.accessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(issuer.userInfo)
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+func (fi *fakeIssuer) provider() *Provider {
+	settings := &model.OpenIdProviderSettings{
+		Name:              new("staff"),
+		Enable:            new(true),
+		Id:                new(testClientId),
+		DiscoveryEndpoint: new(fi.server.URL + "/.well-known/openid-configuration"),
+		PositionClaim:     new("job.title"),
+		GroupsClaim:       new("groups"),
+		AttributeClaims: map[string]string{
+			"Department": "department",
+			"Skills":     "skills",
+			"Level":      "level",
+		},
+	}
+	settings.SetDefaults()
+
+	return NewProvider(settings, fi.server.Client())
+}
+
+func (fi *fakeIssuer) idToken(t *testing.T, method jwt.SigningMethod, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+
+	var key any = fi.rsaKey
+	token.Header["kid"] = "rsa"
+	if method == jwt.SigningMethodES256 {
+		key = fi.ecKey
+		token.Header["kid"] = "ec"
+	}
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func (fi *fakeIssuer) claims(sub string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":   fi.server.URL,
+		"aud":   testClientId,
+		"sub":   sub,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iat":   time.Now().Unix(),
+		"email": "Jane.Doe@example.com",
+	}
+}
+
+func TestUserClaims(t *testing.T) {
+	rctx := request.TestContext(t)
+	issuer := newFakeIssuer(t)
+	provider := issuer.provider()
+
+	issuer.userInfo = map[string]any{
+		"sub":                "user1",
+		"preferred_username": "jane.doe@example.com",
+		"given_name":         "Jane",
+		"family_name":        "Doe",
+		"job":                map[string]any{"title": "Engineer"},
+		"groups":             []string{"developers", "admins"},
+		"department":         "Research",
+		"skills":             []string{"go", "sql"},
+		"level":              3,
+	}
+
+	for _, method := range []jwt.SigningMethod{jwt.SigningMethodRS256, jwt.SigningMethodES256} {
+		t.Run("merges the user info into the claims of the ID token signed with "+method.Alg(), func(t *testing.T) {
+			data, err := provider.UserClaims(rctx, issuer.idToken(t, method, issuer.claims("user1")), issuer.accessToken)
+			require.NoError(t, err)
+
+			user, err := provider.GetUserFromJSON(rctx, strings.NewReader(string(data)), nil, nil)
+			require.NoError(t, err)
+			assert.Equal(t, "user1", *user.AuthData)
+			assert.Equal(t, "oidcstaff", user.AuthService)
+			assert.Equal(t, "jane.doe", user.Username)
+			assert.Equal(t, "jane.doe@example.com", user.Email)
+			assert.Equal(t, "Jane", user.FirstName)
+			assert.Equal(t, "Doe", user.LastName)
+			assert.Equal(t, "Engineer", user.Position)
+
+			claims, err := ClaimsFromJSON(strings.NewReader(string(data)))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"developers", "admins"}, provider.Groups(claims))
+			assert.Equal(t, map[string]json.RawMessage{
+				"Department": json.RawMessage(`"Research"`),
+				"Skills":     json.RawMessage(`["go","sql"]`),
+				"Level":      json.RawMessage(`"3"`),
+			}, provider.Attributes(claims))
+		})
+	}
+
+	t.Run("user info of another user", func(t *testing.T) {
+		_, err := provider.UserClaims(rctx, issuer.idToken(t, jwt.SigningMethodRS256, issuer.claims("user2")), issuer.accessToken)
+		require.ErrorContains(t, err, "different subjects")
+	})
+
+	t.Run("missing ID token", func(t *testing.T) {
+		_, err := provider.UserClaims(rctx, "", issuer.accessToken)
+		require.Error(t, err)
+	})
+
+	for name, update := range map[string]func(jwt.MapClaims){
+		"wrong audience": func(c jwt.MapClaims) { c["aud"] = "other-client" },
+		"wrong issuer":   func(c jwt.MapClaims) { c["iss"] = "https://idp.example.com" },
+		"expired":        func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"no expiration":  func(c jwt.MapClaims) { delete(c, "exp") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			claims := issuer.claims("user1")
+			update(claims)
+			_, err := provider.UserClaims(rctx, issuer.idToken(t, jwt.SigningMethodRS256, claims), issuer.accessToken)
+			require.ErrorContains(t, err, "invalid ID token")
+		})
+	}
+
+	t.Run("unknown signing key", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, issuer.claims("user1"))
+		token.Header["kid"] = "rsa"
+		signed, err := token.SignedString(otherKey)
+		require.NoError(t, err)
+
+		_, err = provider.UserClaims(rctx, signed, issuer.accessToken)
+		require.ErrorContains(t, err, "invalid ID token")
+	})
+
+	t.Run("unsigned token", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, issuer.claims("user1"))
+		signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = provider.UserClaims(rctx, signed, issuer.accessToken)
+		require.ErrorContains(t, err, "invalid ID token")
+	})
+
+	t.Run("encryption keys are not used to verify tokens", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, issuer.claims("user1"))
+		token.Header["kid"] = "enc"
+		signed, err := token.SignedString(issuer.rsaKey)
+		require.NoError(t, err)
+
+		_, err = provider.UserClaims(rctx, signed, issuer.accessToken)
+		require.ErrorContains(t, err, "invalid ID token")
+	})
+}
+
+func TestGetUserFromIdToken(t *testing.T) {
+	rctx := request.TestContext(t)
+	issuer := newFakeIssuer(t)
+	provider := issuer.provider()
+
+	user, err := provider.GetUserFromIdToken(rctx, issuer.idToken(t, jwt.SigningMethodRS256, issuer.claims("user1")))
+	require.NoError(t, err)
+	assert.Equal(t, "user1", *user.AuthData)
+	assert.Equal(t, "jane.doe", user.Username)
+}
+
+func TestGetSSOSettings(t *testing.T) {
+	rctx := request.TestContext(t)
+	issuer := newFakeIssuer(t)
+
+	settings, err := issuer.provider().GetSSOSettings(rctx, nil, "oidcstaff")
+	require.NoError(t, err)
+	assert.Equal(t, testClientId, *settings.Id)
+	assert.Equal(t, model.OpenIdProviderSettingsDefaultScope, *settings.Scope)
+	assert.Equal(t, issuer.server.URL+"/authorize", *settings.AuthEndpoint)
+	assert.Equal(t, issuer.server.URL+"/token", *settings.TokenEndpoint)
+	assert.Equal(t, issuer.server.URL+"/userinfo", *settings.UserAPIEndpoint)
+
+	t.Run("unreachable discovery endpoint", func(t *testing.T) {
+		provider := issuer.provider()
+		*provider.settings.DiscoveryEndpoint = issuer.server.URL + "/missing"
+
+		_, err := provider.GetSSOSettings(rctx, nil, "oidcstaff")
+		require.Error(t, err)
+	})
+}
+
+func TestUserFromClaims(t *testing.T) {
+	rctx := request.TestContext(t)
+	issuer := newFakeIssuer(t)
+	provider := issuer.provider()
+
+	t.Run("custom claims", func(t *testing.T) {
+		provider := issuer.provider()
+		*provider.settings.IdClaim = "oid"
+		*provider.settings.EmailClaim = "upn"
+		*provider.settings.UsernameClaim = "login"
+		*provider.settings.NicknameClaim = "https://example.com/nickname"
+
+		user, err := provider.userFromClaims(rctx.Logger(), Claims{
+			"oid":                          json.Number("1234"),
+			"upn":                          "jdoe@example.com",
+			"login":                        "JDoe",
+			"https://example.com/nickname": "JD",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1234", *user.AuthData)
+		assert.Equal(t, "jdoe@example.com", user.Email)
+		assert.Equal(t, "jdoe", user.Username)
+		assert.Equal(t, "JD", user.Nickname)
+	})
+
+	t.Run("username from email", func(t *testing.T) {
+		user, err := provider.userFromClaims(rctx.Logger(), Claims{"sub": "user1", "email": "jdoe@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe", user.Username)
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		_, err := provider.userFromClaims(rctx.Logger(), Claims{"email": "jdoe@example.com"})
+		require.ErrorContains(t, err, "missing sub claim")
+	})
+
+	t.Run("missing email", func(t *testing.T) {
+		_, err := provider.userFromClaims(rctx.Logger(), Claims{"sub": "user1"})
+		require.ErrorContains(t, err, "missing email claim")
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		for _, verified := range []any{false, "false"} {
+			_, err := provider.userFromClaims(rctx.Logger(), Claims{"sub": "user1", "email": "jdoe@example.com", "email_verified": verified})
+			require.ErrorContains(t, err, "not verified")
+		}
+	})
+}
+
+func TestGroups(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	provider := issuer.provider()
+
+	assert.Equal(t, []string{"developers"}, provider.Groups(Claims{"groups": "developers"}))
+	assert.Equal(t, []string{"developers", "42"}, provider.Groups(Claims{"groups": []any{"developers", "", json.Number("42")}}))
+	assert.Empty(t, provider.Groups(Claims{}))
+
+	*provider.settings.GroupsClaim = ""
+	assert.Empty(t, provider.Groups(Claims{"groups": "developers"}))
+}
+
+func TestCodeChallenge(t *testing.T) {
+	codeVerifier := model.NewRandomString(64)
+	authData := &model.AuthData{
+		CodeChallenge:       CodeChallenge(codeVerifier),
+		CodeChallengeMethod: model.PKCECodeChallengeMethodS256,
+	}
+
+	assert.True(t, authData.VerifyPKCE(codeVerifier))
+	assert.False(t, authData.VerifyPKCE(model.NewRandomString(64)))
+}
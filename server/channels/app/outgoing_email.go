@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// GetFailedOutgoingEmails returns a page of the dead letters of the mail queue: the
+// emails that won't be retried unless an administrator asks to, most recent first.
+func (a *App) GetFailedOutgoingEmails(page, perPage int) ([]*model.OutgoingEmail, *model.AppError) {
+	emails, err := a.Srv().Store().OutgoingEmail().GetFailed(page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetFailedOutgoingEmails", "app.outgoing_email.get_failed.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return emails, nil
+}
+
+// RetryOutgoingEmail moves a dead letter back to the mail queue, to be sent with the
+// next run of the outgoing email queue job.
+func (a *App) RetryOutgoingEmail(id string) (*model.OutgoingEmail, *model.AppError) {
+	if !*a.Config().EmailSettings.EnableMailQueue {
+		return nil, model.NewAppError("RetryOutgoingEmail", "app.outgoing_email.queue_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	email, appErr := a.getOutgoingEmail(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if email.Status != model.OutgoingEmailStatusFailed {
+		return nil, model.NewAppError("RetryOutgoingEmail", "app.outgoing_email.retry.not_failed.app_error", nil, "id="+id, http.StatusBadRequest)
+	}
+
+	email.Status = model.OutgoingEmailStatusPending
+	email.Attempts = 0
+	email.NextAttemptAt = model.GetMillis()
+	if _, err := a.Srv().Store().OutgoingEmail().Update(email); err != nil {
+		return nil, model.NewAppError("RetryOutgoingEmail", "app.outgoing_email.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return email, nil
+}
+
+// DeleteOutgoingEmail removes an email from the mail queue or from its dead letters.
+func (a *App) DeleteOutgoingEmail(id string) *model.AppError {
+	if err := a.Srv().Store().OutgoingEmail().Delete(id); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteOutgoingEmail", "app.outgoing_email.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return model.NewAppError("DeleteOutgoingEmail", "app.outgoing_email.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+func (a *App) getOutgoingEmail(id string) (*model.OutgoingEmail, *model.AppError) {
+	email, err := a.Srv().Store().OutgoingEmail().Get(id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("getOutgoingEmail", "app.outgoing_email.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("getOutgoingEmail", "app.outgoing_email.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return email, nil
+}
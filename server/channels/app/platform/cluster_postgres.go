@@ -0,0 +1,900 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+const (
+	// postgresClusterMaxNotifyPayload is the size above which a message is saved in the
+	// ClusterMessages table and only its id is notified, since Postgres limits the payload
+	// of a notification to 8000 bytes.
+	postgresClusterMaxNotifyPayload = 7900
+
+	postgresClusterSendQueueSize        = 10000
+	postgresClusterRequestTimeout       = 15 * time.Second
+	postgresClusterSupportPacketTimeout = 5 * time.Minute
+	postgresClusterLeaderInterval       = 5 * time.Second
+	postgresClusterMaintenanceInterval  = time.Minute
+
+	// postgresClusterMessageRetention is how long the messages saved in the ClusterMessages
+	// table are kept for the other nodes to read them.
+	postgresClusterMessageRetention = 5 * time.Minute
+
+	// postgresClusterNodeTimeout is how long after its last discovery ping a node is no longer
+	// expected to answer requests.
+	postgresClusterNodeTimeout = 3 * DiscoveryServiceWritePing
+
+	// postgresClusterDefaultName is the cluster name used when ClusterSettings.ClusterName is empty.
+	postgresClusterDefaultName = "mattermost"
+)
+
+const (
+	clusterEventRequestClusterInfo  model.ClusterEvent = "pg_request_cluster_info"
+	clusterEventResponseClusterInfo model.ClusterEvent = "pg_response_cluster_info"
+)
+
+var errPostgresClusterTimeout = errors.New("timed out waiting for the other cluster nodes")
+
+// postgresClusterResponseEvents maps the events of the requests a node answers to the
+// events of their responses.
+var postgresClusterResponseEvents = map[model.ClusterEvent]model.ClusterEvent{
+	model.ClusterGossipEventRequestGetClusterStats:       model.ClusterGossipEventResponseGetClusterStats,
+	model.ClusterGossipEventRequestGetLogs:               model.ClusterGossipEventResponseGetLogs,
+	model.ClusterGossipEventRequestGenerateSupportPacket: model.ClusterGossipEventResponseGenerateSupportPacket,
+	model.ClusterGossipEventRequestGetPluginStatuses:     model.ClusterGossipEventResponseGetPluginStatuses,
+	model.ClusterGossipEventRequestWebConnCount:          model.ClusterGossipEventResponseWebConnCount,
+	model.ClusterGossipEventRequestWSQueues:              model.ClusterGossipEventResponseWSQueues,
+	clusterEventRequestClusterInfo:                       clusterEventResponseClusterInfo,
+}
+
+// postgresClusterEnvelope is the payload of the notifications exchanged by the nodes.
+type postgresClusterEnvelope struct {
+	From    string                `json:"from"`
+	To      string                `json:"to,omitempty"`
+	Message *model.ClusterMessage `json:"message,omitempty"`
+
+	// PayloadId is the id of the envelope saved in the ClusterMessages table when it is too
+	// large for a notification.
+	PayloadId string `json:"payload_id,omitempty"`
+
+	// RequestId is set on requests expecting a reply from each node, and on their replies.
+	RequestId string `json:"request_id,omitempty"`
+	Reply     bool   `json:"reply,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type postgresClusterLogsRequest struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+type postgresClusterLogs struct {
+	Hostname string   `json:"hostname"`
+	Lines    []string `json:"lines"`
+}
+
+type postgresClusterWSQueuesRequest struct {
+	UserId       string `json:"user_id"`
+	ConnectionId string `json:"connection_id"`
+	SeqNum       int64  `json:"seq_num"`
+}
+
+// postgresCluster implements the cluster interface on top of the database shared by all the
+// nodes, when no other implementation is registered. Messages are sent with NOTIFY on a
+// channel specific to the cluster name, large messages are saved in the ClusterMessages
+// table, and the leader is the node holding an advisory lock. Nodes find each other through
+// the ClusterDiscovery table.
+//
+// Notifications sent while a node is disconnected from the database are lost, so a node
+// invalidates all its caches after reconnecting.
+type postgresCluster struct {
+	ps       *PlatformService
+	id       string
+	hostname string
+
+	leaderInterval      time.Duration
+	maintenanceInterval time.Duration
+
+	handlersMut sync.RWMutex
+	handlers    map[model.ClusterEvent]einterfaces.ClusterMessageHandler
+
+	requestsMut sync.Mutex
+	requests    map[string]chan *postgresClusterEnvelope
+
+	leader    atomic.Bool
+	connected atomic.Bool
+
+	mut         sync.Mutex
+	running     bool
+	clusterName string
+	channel     string
+	leaderKey   int64
+	listener    *pq.Listener
+	discovery   *ClusterDiscoveryService
+	queue       chan *postgresClusterEnvelope
+	stop        chan struct{}
+	wg          sync.WaitGroup
+}
+
+func newPostgresCluster(ps *PlatformService) *postgresCluster {
+	c := &postgresCluster{
+		ps:                  ps,
+		id:                  model.NewId(),
+		hostname:            postgresClusterHostname(ps.Config().ClusterSettings),
+		leaderInterval:      postgresClusterLeaderInterval,
+		maintenanceInterval: postgresClusterMaintenanceInterval,
+		handlers:            make(map[model.ClusterEvent]einterfaces.ClusterMessageHandler),
+		requests:            make(map[string]chan *postgresClusterEnvelope),
+	}
+	c.handlers[model.ClusterGossipEventRequestSaveConfig] = c.clusterConfigChangedHandler
+	return c
+}
+
+func postgresClusterHostname(settings model.ClusterSettings) string {
+	if *settings.OverrideHostname != "" {
+		return *settings.OverrideHostname
+	}
+	if *settings.UseIPAddress {
+		return model.GetServerIPAddress(*settings.NetworkInterface)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return model.GetServerIPAddress(*settings.NetworkInterface)
+	}
+	return hostname
+}
+
+// postgresClusterChannel returns the notification channel of a cluster. Channel names are
+// identifiers, so the cluster name is hashed to keep them short and valid.
+func postgresClusterChannel(clusterName string) string {
+	sum := sha256.Sum256([]byte(clusterName))
+	return "mattermost_cluster_" + hex.EncodeToString(sum[:8])
+}
+
+// postgresClusterLeaderKey returns the key of the advisory lock held by the leader of a cluster.
+func postgresClusterLeaderKey(clusterName string) int64 {
+	sum := sha256.Sum256([]byte("mattermost_cluster_leader:" + clusterName))
+	return int64(binary.BigEndian.Uint64(sum[:8]))
+}
+
+func (c *postgresCluster) StartInterNodeCommunication() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.running {
+		return
+	}
+
+	cfg := c.ps.Config()
+	c.clusterName = *cfg.ClusterSettings.ClusterName
+	if c.clusterName == "" {
+		c.clusterName = postgresClusterDefaultName
+	}
+	c.channel = postgresClusterChannel(c.clusterName)
+	c.leaderKey = postgresClusterLeaderKey(c.clusterName)
+	c.queue = make(chan *postgresClusterEnvelope, postgresClusterSendQueueSize)
+	c.stop = make(chan struct{})
+
+	c.listener = pq.NewListener(*cfg.SqlSettings.DataSource, time.Second, time.Minute, c.onListenerEvent)
+	if err := c.listener.Listen(c.channel); err != nil {
+		c.ps.Log().Error("Failed to listen to cluster messages", mlog.String("channel", c.channel), mlog.Err(err))
+	} else {
+		c.connected.Store(true)
+	}
+
+	c.discovery = c.ps.NewClusterDiscoveryService()
+	c.discovery.Id = c.id
+	c.discovery.Type = model.CDSTypeApp
+	c.discovery.ClusterName = c.clusterName
+	c.discovery.Hostname = c.hostname
+	c.discovery.Start()
+
+	c.running = true
+	c.wg.Add(4)
+	go c.receiveLoop(c.listener, c.stop)
+	go c.sendLoop(c.queue, c.stop)
+	go c.leaderLoop(c.stop)
+	go c.maintenanceLoop(c.listener, c.stop)
+
+	c.ps.Log().Info("Started cluster communication through the database",
+		mlog.String("cluster_id", c.id),
+		mlog.String("cluster_name", c.clusterName),
+		mlog.String("hostname", c.hostname),
+	)
+}
+
+func (c *postgresCluster) StopInterNodeCommunication() {
+	c.mut.Lock()
+	if !c.running {
+		c.mut.Unlock()
+		return
+	}
+	c.running = false
+	close(c.stop)
+	c.mut.Unlock()
+
+	c.wg.Wait()
+
+	if err := c.listener.Close(); err != nil {
+		c.ps.Log().Warn("Failed to close the cluster listener", mlog.Err(err))
+	}
+	c.connected.Store(false)
+	c.discovery.Stop()
+}
+
+func (c *postgresCluster) RegisterClusterMessageHandler(event model.ClusterEvent, crm einterfaces.ClusterMessageHandler) {
+	c.handlersMut.Lock()
+	defer c.handlersMut.Unlock()
+	c.handlers[event] = crm
+}
+
+func (c *postgresCluster) GetClusterId() string {
+	return c.id
+}
+
+func (c *postgresCluster) IsLeader() bool {
+	return c.leader.Load()
+}
+
+func (c *postgresCluster) HealthScore() int {
+	if c.connected.Load() {
+		return 0
+	}
+	return 1
+}
+
+func (c *postgresCluster) GetMyClusterInfo() *model.ClusterInfo {
+	info := &model.ClusterInfo{
+		Id:         c.id,
+		Version:    model.CurrentVersion,
+		ConfigHash: c.ps.ClientConfigHash(),
+		IPAddress:  model.GetServerIPAddress(*c.ps.Config().ClusterSettings.NetworkInterface),
+		Hostname:   c.hostname,
+	}
+	if version, err := c.ps.Store.GetDBSchemaVersion(); err == nil {
+		info.SchemaVersion = strconv.Itoa(version)
+	}
+	return info
+}
+
+func (c *postgresCluster) GetClusterInfos() ([]*model.ClusterInfo, error) {
+	infos, err := postgresClusterRequest[*model.ClusterInfo](c, clusterEventRequestClusterInfo, nil, postgresClusterRequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*model.ClusterInfo{c.GetMyClusterInfo()}
+	for _, info := range infos {
+		result = append(result, info)
+	}
+	slices.SortFunc(result[1:], func(a, b *model.ClusterInfo) int {
+		return cmp.Compare(a.Hostname, b.Hostname)
+	})
+	return result, nil
+}
+
+func (c *postgresCluster) SendClusterMessage(msg *model.ClusterMessage) {
+	env := &postgresClusterEnvelope{Message: msg}
+	if msg.WaitForAllToSend {
+		if err := c.send(env); err != nil {
+			c.ps.Log().Warn("Failed to send cluster message", append(msg.LogFields(), mlog.Err(err))...)
+		}
+		return
+	}
+
+	c.mut.Lock()
+	running, queue, stop := c.running, c.queue, c.stop
+	c.mut.Unlock()
+	if !running {
+		return
+	}
+
+	if msg.SendType == model.ClusterSendReliable {
+		select {
+		case queue <- env:
+		case <-stop:
+		}
+		return
+	}
+
+	select {
+	case queue <- env:
+	default:
+		c.ps.Log().Warn("Cluster send queue is full, dropping best effort message", msg.LogFields()...)
+	}
+}
+
+func (c *postgresCluster) SendClusterMessageToNode(nodeID string, msg *model.ClusterMessage) error {
+	return c.send(&postgresClusterEnvelope{To: nodeID, Message: msg})
+}
+
+// NotifyMsg handles the payload of a notification received from the database.
+func (c *postgresCluster) NotifyMsg(buf []byte) {
+	var env postgresClusterEnvelope
+	if err := json.Unmarshal(buf, &env); err != nil {
+		c.ps.Log().Warn("Failed to decode cluster message", mlog.Err(err))
+		return
+	}
+	if env.From == c.id || (env.To != "" && env.To != c.id) {
+		return
+	}
+
+	if env.PayloadId != "" {
+		data, err := c.ps.Store.ClusterMessage().Get(env.PayloadId)
+		if err != nil {
+			c.ps.Log().Warn("Failed to get cluster message", mlog.String("payload_id", env.PayloadId), mlog.Err(err))
+			return
+		}
+		env = postgresClusterEnvelope{}
+		if err := json.Unmarshal(data, &env); err != nil {
+			c.ps.Log().Warn("Failed to decode cluster message", mlog.Err(err))
+			return
+		}
+	}
+
+	c.dispatch(&env)
+}
+
+func (c *postgresCluster) GetClusterStats(rctx request.CTX) ([]*model.ClusterStats, *model.AppError) {
+	stats, err := postgresClusterRequest[*model.ClusterStats](c, model.ClusterGossipEventRequestGetClusterStats, nil, postgresClusterRequestTimeout)
+	if err != nil {
+		return nil, postgresClusterAppError("GetClusterStats", err)
+	}
+
+	result := make([]*model.ClusterStats, 0, len(stats))
+	for _, stat := range stats {
+		result = append(result, stat)
+	}
+	return result, nil
+}
+
+func (c *postgresCluster) GetLogs(rctx request.CTX, page, perPage int) ([]string, *model.AppError) {
+	logs, err := c.getLogs(page, perPage)
+	if err != nil {
+		return nil, postgresClusterAppError("GetLogs", err)
+	}
+
+	lines := []string{}
+	for _, nodeLogs := range logs {
+		lines = append(lines,
+			"-----------------------------------------------------------------------------------------------------------",
+			"-----------------------------------------------------------------------------------------------------------",
+			nodeLogs.Hostname,
+			"-----------------------------------------------------------------------------------------------------------",
+			"-----------------------------------------------------------------------------------------------------------",
+		)
+		lines = append(lines, nodeLogs.Lines...)
+	}
+	return lines, nil
+}
+
+func (c *postgresCluster) QueryLogs(rctx request.CTX, page, perPage int) (map[string][]string, *model.AppError) {
+	logs, err := c.getLogs(page, perPage)
+	if err != nil {
+		return nil, postgresClusterAppError("QueryLogs", err)
+	}
+
+	result := make(map[string][]string, len(logs))
+	for _, nodeLogs := range logs {
+		result[nodeLogs.Hostname] = nodeLogs.Lines
+	}
+	return result, nil
+}
+
+// getLogs returns the logs of the other nodes, sorted by hostname.
+func (c *postgresCluster) getLogs(page, perPage int) ([]*postgresClusterLogs, error) {
+	logs, err := postgresClusterRequest[*postgresClusterLogs](c, model.ClusterGossipEventRequestGetLogs, &postgresClusterLogsRequest{Page: page, PerPage: perPage}, postgresClusterRequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*postgresClusterLogs, 0, len(logs))
+	for _, nodeLogs := range logs {
+		result = append(result, nodeLogs)
+	}
+	slices.SortFunc(result, func(a, b *postgresClusterLogs) int {
+		return cmp.Compare(a.Hostname, b.Hostname)
+	})
+	return result, nil
+}
+
+func (c *postgresCluster) GenerateSupportPacket(rctx request.CTX, options *model.SupportPacketOptions) (map[string][]model.FileData, error) {
+	return postgresClusterRequest[[]model.FileData](c, model.ClusterGossipEventRequestGenerateSupportPacket, options, postgresClusterSupportPacketTimeout)
+}
+
+func (c *postgresCluster) GetPluginStatuses() (model.PluginStatuses, *model.AppError) {
+	statuses, err := postgresClusterRequest[model.PluginStatuses](c, model.ClusterGossipEventRequestGetPluginStatuses, nil, postgresClusterRequestTimeout)
+	if err != nil {
+		return nil, postgresClusterAppError("GetPluginStatuses", err)
+	}
+
+	result := model.PluginStatuses{}
+	for _, nodeStatuses := range statuses {
+		result = append(result, nodeStatuses...)
+	}
+	return result, nil
+}
+
+func (c *postgresCluster) ConfigChanged(previousConfig *model.Config, newConfig *model.Config, sendToOtherServer bool) *model.AppError {
+	if !sendToOtherServer {
+		return nil
+	}
+
+	// The configuration is shared through the database, so the other nodes only need to reload it.
+	c.SendClusterMessage(&model.ClusterMessage{
+		Event:            model.ClusterGossipEventRequestSaveConfig,
+		SendType:         model.ClusterSendReliable,
+		WaitForAllToSend: true,
+	})
+	return nil
+}
+
+func (c *postgresCluster) WebConnCountForUser(userID string) (int, *model.AppError) {
+	counts, err := postgresClusterRequest[int](c, model.ClusterGossipEventRequestWebConnCount, userID, postgresClusterRequestTimeout)
+	if err != nil {
+		return 0, postgresClusterAppError("WebConnCountForUser", err)
+	}
+
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	return total, nil
+}
+
+func (c *postgresCluster) GetWSQueues(userID, connectionID string, seqNum int64) (map[string]*model.WSQueues, error) {
+	return postgresClusterRequest[*model.WSQueues](c, model.ClusterGossipEventRequestWSQueues, &postgresClusterWSQueuesRequest{
+		UserId:       userID,
+		ConnectionId: connectionID,
+		SeqNum:       seqNum,
+	}, postgresClusterRequestTimeout)
+}
+
+func (c *postgresCluster) clusterConfigChangedHandler(msg *model.ClusterMessage) {
+	if err := c.ps.ReloadConfig(); err != nil {
+		c.ps.Log().Error("Failed to reload the configuration changed by another cluster node", mlog.Err(err))
+	}
+}
+
+func (c *postgresCluster) dispatch(env *postgresClusterEnvelope) {
+	if env.Message == nil {
+		return
+	}
+
+	switch {
+	case env.Reply:
+		c.requestsMut.Lock()
+		replies, ok := c.requests[env.RequestId]
+		c.requestsMut.Unlock()
+		if !ok {
+			return
+		}
+		select {
+		case replies <- env:
+		default:
+		}
+	case env.RequestId != "":
+		// answering may take a while, so it must not hold back the following messages.
+		c.ps.Go(func() {
+			c.answer(env)
+		})
+	default:
+		c.handlersMut.RLock()
+		handler, ok := c.handlers[env.Message.Event]
+		c.handlersMut.RUnlock()
+		if ok {
+			handler(env.Message)
+		}
+	}
+}
+
+// answer replies to a request sent by another node.
+func (c *postgresCluster) answer(req *postgresClusterEnvelope) {
+	reply := &postgresClusterEnvelope{
+		To:        req.From,
+		RequestId: req.RequestId,
+		Reply:     true,
+		Message:   &model.ClusterMessage{Event: postgresClusterResponseEvents[req.Message.Event]},
+	}
+
+	value, err := c.answerRequest(req.Message)
+	if err == nil {
+		reply.Message.Data, err = json.Marshal(value)
+	}
+	if err != nil {
+		reply.Error = err.Error()
+	}
+
+	if err := c.send(reply); err != nil {
+		c.ps.Log().Warn("Failed to reply to cluster request", append(req.Message.LogFields(), mlog.Err(err))...)
+	}
+}
+
+func (c *postgresCluster) answerRequest(msg *model.ClusterMessage) (any, error) {
+	rctx := request.EmptyContext(c.ps.Log())
+
+	switch msg.Event {
+	case clusterEventRequestClusterInfo:
+		return c.GetMyClusterInfo(), nil
+
+	case model.ClusterGossipEventRequestGetClusterStats:
+		return &model.ClusterStats{
+			Id:                        c.id,
+			TotalWebsocketConnections: c.ps.TotalWebsocketConnections(),
+			TotalReadDbConnections:    c.ps.Store.TotalReadDbConnections(),
+			TotalMasterDbConnections:  c.ps.Store.TotalMasterDbConnections(),
+		}, nil
+
+	case model.ClusterGossipEventRequestGetLogs:
+		var params postgresClusterLogsRequest
+		if err := json.Unmarshal(msg.Data, &params); err != nil {
+			return nil, err
+		}
+		lines, appErr := c.ps.GetLogsSkipSend(rctx, params.Page, params.PerPage, &model.LogFilter{})
+		if appErr != nil {
+			return nil, appErr
+		}
+		return &postgresClusterLogs{Hostname: c.hostname, Lines: lines}, nil
+
+	case model.ClusterGossipEventRequestGenerateSupportPacket:
+		var options model.SupportPacketOptions
+		if err := json.Unmarshal(msg.Data, &options); err != nil {
+			return nil, err
+		}
+		files, err := c.ps.GenerateSupportPacket(rctx, &options)
+		if err != nil {
+			return nil, err
+		}
+		// the files of each node go in a directory named after it, like the app does for the local files.
+		for i := range files {
+			files[i].Filename = filepath.Join(c.hostname, files[i].Filename)
+		}
+		return files, nil
+
+	case model.ClusterGossipEventRequestGetPluginStatuses:
+		statuses, appErr := c.ps.GetPluginStatuses()
+		if appErr != nil {
+			return nil, appErr
+		}
+		return statuses, nil
+
+	case model.ClusterGossipEventRequestWebConnCount:
+		var userID string
+		if err := json.Unmarshal(msg.Data, &userID); err != nil {
+			return nil, err
+		}
+		return c.ps.WebConnCountForUser(userID), nil
+
+	case model.ClusterGossipEventRequestWSQueues:
+		var params postgresClusterWSQueuesRequest
+		if err := json.Unmarshal(msg.Data, &params); err != nil {
+			return nil, err
+		}
+		return c.ps.GetWSQueues(params.UserId, params.ConnectionId, params.SeqNum)
+	}
+
+	return nil, fmt.Errorf("unknown cluster request %s", msg.Event)
+}
+
+// postgresClusterRequest sends a request to the other nodes and waits for all of them to
+// reply, returning the decoded replies by node id.
+func postgresClusterRequest[T any](c *postgresCluster, event model.ClusterEvent, params any, timeout time.Duration) (map[string]T, error) {
+	nodes, err := c.otherNodes()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]T, len(nodes))
+	if len(nodes) == 0 {
+		return values, nil
+	}
+
+	msg := &model.ClusterMessage{Event: event}
+	if params != nil {
+		if msg.Data, err = json.Marshal(params); err != nil {
+			return nil, fmt.Errorf("failed to encode cluster request: %w", err)
+		}
+	}
+
+	requestID := model.NewId()
+	replies := make(chan *postgresClusterEnvelope, len(nodes))
+	c.requestsMut.Lock()
+	c.requests[requestID] = replies
+	c.requestsMut.Unlock()
+	defer func() {
+		c.requestsMut.Lock()
+		delete(c.requests, requestID)
+		c.requestsMut.Unlock()
+	}()
+
+	if err := c.send(&postgresClusterEnvelope{RequestId: requestID, Message: msg}); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	answered := make(map[string]bool, len(nodes))
+	for len(answered) < len(nodes) {
+		select {
+		case reply := <-replies:
+			if !slices.Contains(nodes, reply.From) || answered[reply.From] {
+				continue
+			}
+			answered[reply.From] = true
+			if reply.Error != "" {
+				return nil, fmt.Errorf("cluster node %s failed to answer %s: %s", reply.From, event, reply.Error)
+			}
+			var value T
+			if err := json.Unmarshal(reply.Message.Data, &value); err != nil {
+				return nil, fmt.Errorf("failed to decode the reply of cluster node %s: %w", reply.From, err)
+			}
+			values[reply.From] = value
+		case <-timer.C:
+			return nil, fmt.Errorf("%d of %d nodes did not answer %s: %w", len(nodes)-len(answered), len(nodes), event, errPostgresClusterTimeout)
+		}
+	}
+	return values, nil
+}
+
+func postgresClusterAppError(where string, err error) *model.AppError {
+	if errors.Is(err, errPostgresClusterTimeout) {
+		return model.NewAppError(where, "ent.cluster.timeout.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return model.NewAppError(where, "app.cluster.request.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+}
+
+// otherNodes returns the ids of the other nodes of the cluster that recently pinged the
+// discovery table.
+func (c *postgresCluster) otherNodes() ([]string, error) {
+	c.mut.Lock()
+	running, clusterName := c.running, c.clusterName
+	c.mut.Unlock()
+	if !running {
+		return nil, nil
+	}
+
+	discoveries, err := c.ps.Store.ClusterDiscovery().GetAll(model.CDSTypeApp, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the cluster nodes: %w", err)
+	}
+
+	since := model.GetMillis() - postgresClusterNodeTimeout.Milliseconds()
+	nodes := []string{}
+	for _, discovery := range discoveries {
+		if discovery.Id != c.id && discovery.LastPingAt >= since {
+			nodes = append(nodes, discovery.Id)
+		}
+	}
+	return nodes, nil
+}
+
+// send notifies the other nodes of a message, saving it in the ClusterMessages table first
+// when it is too large for a notification.
+func (c *postgresCluster) send(env *postgresClusterEnvelope) error {
+	c.mut.Lock()
+	running, channel := c.running, c.channel
+	c.mut.Unlock()
+	if !running {
+		return nil
+	}
+
+	env.From = c.id
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to encode cluster message: %w", err)
+	}
+
+	if len(payload) > postgresClusterMaxNotifyPayload {
+		payloadID := model.NewId()
+		if err := c.ps.Store.ClusterMessage().Save(payloadID, payload); err != nil {
+			return err
+		}
+		payload, err = json.Marshal(&postgresClusterEnvelope{From: env.From, To: env.To, PayloadId: payloadID})
+		if err != nil {
+			return fmt.Errorf("failed to encode cluster message: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.queryTimeout())
+	defer cancel()
+	if _, err := c.ps.Store.GetInternalMasterDB().ExecContext(ctx, "SELECT pg_notify($1, $2)", channel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify cluster message: %w", err)
+	}
+	return nil
+}
+
+func (c *postgresCluster) queryTimeout() time.Duration {
+	return time.Duration(*c.ps.Config().SqlSettings.QueryTimeout) * time.Second
+}
+
+func (c *postgresCluster) onListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventConnected, pq.ListenerEventReconnected:
+		c.connected.Store(true)
+	case pq.ListenerEventDisconnected, pq.ListenerEventConnectionAttemptFailed:
+		c.connected.Store(false)
+		c.ps.Log().Warn("Cluster listener lost its database connection", mlog.String("event", event.String()), mlog.Err(err))
+	}
+}
+
+func (c *postgresCluster) receiveLoop(listener *pq.Listener, stop <-chan struct{}) {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case notification := <-listener.Notify:
+			if notification == nil {
+				// the listener reconnected, so messages sent in the meantime may have been missed.
+				c.ps.Log().Warn("Cluster listener reconnected, invalidating all caches")
+				c.handlersMut.RLock()
+				handler, ok := c.handlers[model.ClusterEventInvalidateAllCaches]
+				c.handlersMut.RUnlock()
+				if ok {
+					handler(&model.ClusterMessage{Event: model.ClusterEventInvalidateAllCaches})
+				}
+				continue
+			}
+			c.NotifyMsg([]byte(notification.Extra))
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (c *postgresCluster) sendLoop(queue chan *postgresClusterEnvelope, stop <-chan struct{}) {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case env := <-queue:
+			if err := c.send(env); err != nil {
+				c.ps.Log().Warn("Failed to send cluster message", append(env.Message.LogFields(), mlog.Err(err))...)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// leaderLoop elects the leader of the cluster: the node holding the advisory lock of the
+// cluster on one of its connections.
+func (c *postgresCluster) leaderLoop(stop <-chan struct{}) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.leaderInterval)
+	defer ticker.Stop()
+
+	var conn *sql.Conn
+	for {
+		conn = c.checkLeadership(conn)
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			if conn != nil {
+				c.releaseLeadership(conn)
+			}
+			return
+		}
+	}
+}
+
+// checkLeadership makes sure the connection holding the leader lock is still alive, or tries
+// to take the lock when this node isn't the leader. It returns the connection holding the
+// lock, if any.
+func (c *postgresCluster) checkLeadership(conn *sql.Conn) *sql.Conn {
+	ctx, cancel := context.WithTimeout(context.Background(), c.queryTimeout())
+	defer cancel()
+
+	if conn != nil {
+		err := conn.PingContext(ctx)
+		if err == nil {
+			return conn
+		}
+		c.ps.Log().Warn("Lost the connection holding the cluster leader lock", mlog.Err(err))
+		discardConn(conn)
+		c.setLeader(false)
+	}
+
+	conn, err := c.ps.Store.GetInternalMasterDB().Conn(ctx)
+	if err != nil {
+		c.ps.Log().Warn("Failed to get a connection to take the cluster leader lock", mlog.Err(err))
+		return nil
+	}
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", c.leaderKey).Scan(&locked); err != nil {
+		c.ps.Log().Warn("Failed to take the cluster leader lock", mlog.Err(err))
+		discardConn(conn)
+		return nil
+	}
+	if !locked {
+		conn.Close()
+		return nil
+	}
+
+	c.setLeader(true)
+	return conn
+}
+
+func (c *postgresCluster) releaseLeadership(conn *sql.Conn) {
+	c.setLeader(false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.queryTimeout())
+	defer cancel()
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", c.leaderKey); err != nil {
+		c.ps.Log().Warn("Failed to release the cluster leader lock", mlog.Err(err))
+		discardConn(conn)
+		return
+	}
+	conn.Close()
+}
+
+// discardConn closes the underlying connection rather than returning it to the pool, so that
+// the session and the locks it may hold end.
+func discardConn(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+	conn.Close()
+}
+
+func (c *postgresCluster) setLeader(leader bool) {
+	if c.leader.Swap(leader) != leader {
+		c.ps.Log().Info("Cluster leadership changed", mlog.String("cluster_id", c.id), mlog.Bool("leader", leader))
+		c.ps.InvokeClusterLeaderChangedListeners()
+	}
+}
+
+// maintenanceLoop checks the connection of the listener, and has the leader delete the
+// messages the other nodes had time to read.
+func (c *postgresCluster) maintenanceLoop(listener *pq.Listener, stop <-chan struct{}) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.maintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := listener.Ping(); err != nil {
+				c.ps.Log().Warn("Failed to ping the cluster listener", mlog.Err(err))
+			}
+
+			if c.IsLeader() {
+				before := model.GetMillis() - postgresClusterMessageRetention.Milliseconds()
+				if _, err := c.ps.Store.ClusterMessage().DeleteBefore(before); err != nil {
+					c.ps.Log().Warn("Failed to delete old cluster messages", mlog.Err(err))
+				}
+			}
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// setupPostgresClusterNodes starts count nodes sharing the database of the test helper, as
+// separate servers of the same cluster would.
+func setupPostgresClusterNodes(t *testing.T, th *TestHelper, count int) []*postgresCluster {
+	t.Helper()
+
+	th.Service.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ClusterSettings.ClusterName = "cluster_" + model.NewId()
+	})
+
+	nodes := make([]*postgresCluster, count)
+	for i := range nodes {
+		node := newPostgresCluster(th.Service)
+		// the discovery table identifies the nodes by hostname.
+		node.hostname = "node-" + node.id
+		node.leaderInterval = 100 * time.Millisecond
+		node.StartInterNodeCommunication()
+		t.Cleanup(node.StopInterNodeCommunication)
+		nodes[i] = node
+	}
+	return nodes
+}
+
+func receiveClusterMessages(node *postgresCluster, event model.ClusterEvent) chan *model.ClusterMessage {
+	received := make(chan *model.ClusterMessage, 10)
+	node.RegisterClusterMessageHandler(event, func(msg *model.ClusterMessage) {
+		received <- msg
+	})
+	return received
+}
+
+func requireClusterMessage(t *testing.T, received chan *model.ClusterMessage) *model.ClusterMessage {
+	t.Helper()
+
+	select {
+	case msg := <-received:
+		return msg
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "cluster message not received")
+	}
+	return nil
+}
+
+func requireNoClusterMessage(t *testing.T, received chan *model.ClusterMessage) {
+	t.Helper()
+
+	select {
+	case msg := <-received:
+		require.FailNow(t, "unexpected cluster message", "event %s", msg.Event)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func TestPostgresClusterMessages(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	nodes := setupPostgresClusterNodes(t, th, 3)
+
+	received := make([]chan *model.ClusterMessage, len(nodes))
+	for i, node := range nodes {
+		received[i] = receiveClusterMessages(node, model.ClusterEventPublish)
+	}
+
+	t.Run("broadcast to the other nodes", func(t *testing.T) {
+		nodes[0].SendClusterMessage(&model.ClusterMessage{
+			Event:    model.ClusterEventPublish,
+			SendType: model.ClusterSendReliable,
+			Data:     []byte("hello"),
+			Props:    map[string]string{"key": "value"},
+		})
+
+		for _, r := range received[1:] {
+			msg := requireClusterMessage(t, r)
+			assert.Equal(t, []byte("hello"), msg.Data)
+			assert.Equal(t, map[string]string{"key": "value"}, msg.Props)
+		}
+		requireNoClusterMessage(t, received[0])
+	})
+
+	t.Run("large messages go through the table", func(t *testing.T) {
+		data := []byte(strings.Repeat("x", 3*postgresClusterMaxNotifyPayload))
+		nodes[1].SendClusterMessage(&model.ClusterMessage{
+			Event:            model.ClusterEventPublish,
+			WaitForAllToSend: true,
+			Data:             data,
+		})
+
+		for _, r := range []chan *model.ClusterMessage{received[0], received[2]} {
+			msg := requireClusterMessage(t, r)
+			assert.Equal(t, data, msg.Data)
+		}
+		requireNoClusterMessage(t, received[1])
+	})
+
+	t.Run("send to a single node", func(t *testing.T) {
+		err := nodes[0].SendClusterMessageToNode(nodes[2].GetClusterId(), &model.ClusterMessage{
+			Event: model.ClusterEventPublish,
+			Data:  []byte("only for node 2"),
+		})
+		require.NoError(t, err)
+
+		msg := requireClusterMessage(t, received[2])
+		assert.Equal(t, []byte("only for node 2"), msg.Data)
+		requireNoClusterMessage(t, received[1])
+	})
+
+	t.Run("messages are kept in order", func(t *testing.T) {
+		for i := range 5 {
+			nodes[0].SendClusterMessage(&model.ClusterMessage{
+				Event:    model.ClusterEventPublish,
+				SendType: model.ClusterSendReliable,
+				Data:     []byte{byte(i)},
+			})
+		}
+
+		for i := range 5 {
+			msg := requireClusterMessage(t, received[1])
+			assert.Equal(t, []byte{byte(i)}, msg.Data)
+		}
+	})
+
+	t.Run("stopped node no longer receives messages", func(t *testing.T) {
+		nodes[2].StopInterNodeCommunication()
+
+		nodes[0].SendClusterMessage(&model.ClusterMessage{
+			Event:    model.ClusterEventPublish,
+			SendType: model.ClusterSendReliable,
+		})
+		requireClusterMessage(t, received[1])
+		requireNoClusterMessage(t, received[2])
+	})
+}
+
+func TestPostgresClusterLeader(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	nodes := setupPostgresClusterNodes(t, th, 3)
+
+	leaders := func() []*postgresCluster {
+		var result []*postgresCluster
+		for _, node := range nodes {
+			if node.IsLeader() {
+				result = append(result, node)
+			}
+		}
+		return result
+	}
+
+	require.Eventually(t, func() bool { return len(leaders()) == 1 }, 5*time.Second, 50*time.Millisecond)
+	leader := leaders()[0]
+
+	// another node takes over when the leader stops.
+	leader.StopInterNodeCommunication()
+	assert.False(t, leader.IsLeader())
+	require.Eventually(t, func() bool {
+		current := leaders()
+		return len(current) == 1 && current[0] != leader
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestPostgresClusterRequests(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	nodes := setupPostgresClusterNodes(t, th, 3)
+
+	t.Run("cluster infos", func(t *testing.T) {
+		infos, err := nodes[0].GetClusterInfos()
+		require.NoError(t, err)
+		require.Len(t, infos, 3)
+		assert.Equal(t, nodes[0].GetClusterId(), infos[0].Id)
+
+		ids := []string{}
+		for _, info := range infos {
+			ids = append(ids, info.Id)
+			assert.Equal(t, "node-"+info.Id, info.Hostname)
+			assert.Equal(t, model.CurrentVersion, info.Version)
+		}
+		assert.ElementsMatch(t, []string{nodes[0].id, nodes[1].id, nodes[2].id}, ids)
+	})
+
+	t.Run("cluster stats", func(t *testing.T) {
+		stats, appErr := nodes[1].GetClusterStats(th.Context)
+		require.Nil(t, appErr)
+		require.Len(t, stats, 2)
+
+		ids := []string{stats[0].Id, stats[1].Id}
+		assert.ElementsMatch(t, []string{nodes[0].id, nodes[2].id}, ids)
+	})
+
+	t.Run("web connection count", func(t *testing.T) {
+		count, appErr := nodes[0].WebConnCountForUser(model.NewId())
+		require.Nil(t, appErr)
+		assert.Zero(t, count)
+	})
+
+	t.Run("websocket queues", func(t *testing.T) {
+		queues, err := nodes[0].GetWSQueues(model.NewId(), model.NewId(), 1)
+		require.NoError(t, err)
+		assert.Len(t, queues, 2)
+		for _, q := range queues {
+			assert.Nil(t, q)
+		}
+	})
+
+	t.Run("stopped node is not expected to answer", func(t *testing.T) {
+		nodes[2].StopInterNodeCommunication()
+		// the discovery service removes the node asynchronously.
+		require.Eventually(t, func() bool {
+			others, err := nodes[0].otherNodes()
+			return err == nil && len(others) == 1
+		}, 5*time.Second, 50*time.Millisecond)
+
+		stats, appErr := nodes[0].GetClusterStats(th.Context)
+		require.Nil(t, appErr)
+		require.Len(t, stats, 1)
+		assert.Equal(t, nodes[1].id, stats[0].Id)
+	})
+}
@@ -237,6 +237,13 @@ func (ps *PlatformService) regenerateClientConfig() {
 		limitedClientConfig["AsymmetricSigningPublicKey"] = base64.StdEncoding.EncodeToString(der)
 	}
 
+	// Browsers expect the uncompressed public key, base64url encoded, when subscribing.
+	if key := ps.WebPushVAPIDKey(); key != nil && *ps.Config().EmailSettings.SendWebPushNotifications {
+		if publicKey, err := key.PublicKey.ECDH(); err == nil {
+			clientConfig["WebPushVAPIDPublicKey"] = base64.RawURLEncoding.EncodeToString(publicKey.Bytes())
+		}
+	}
+
 	clientConfigJSON, _ := json.Marshal(clientConfig)
 	ps.clientConfig.Store(clientConfig)
 	ps.limitedClientConfig.Store(limitedClientConfig)
@@ -255,12 +262,49 @@ func (ps *PlatformService) EnsureAsymmetricSigningKey() error {
 		return nil
 	}
 
+	key, err := ps.ensureECDSAKey(model.SystemAsymmetricSigningKeyKey)
+	if err != nil {
+		return err
+	}
+
+	ps.asymmetricSigningKey.Store(key)
+	ps.regenerateClientConfig()
+	return nil
+}
+
+// WebPushVAPIDKey returns the key identifying the server to Web Push services, as
+// described in RFC 8292.
+func (ps *PlatformService) WebPushVAPIDKey() *ecdsa.PrivateKey {
+	return ps.webPushVAPIDKey.Load()
+}
+
+// EnsureWebPushVAPIDKey ensures that a VAPID key exists and future calls to WebPushVAPIDKey
+// will always return a valid key. The key is shared by all the servers of a cluster, since
+// browsers only accept notifications signed with the key they subscribed with.
+func (ps *PlatformService) EnsureWebPushVAPIDKey() error {
+	if ps.WebPushVAPIDKey() != nil {
+		return nil
+	}
+
+	key, err := ps.ensureECDSAKey(model.SystemWebPushVAPIDKeyKey)
+	if err != nil {
+		return err
+	}
+
+	ps.webPushVAPIDKey.Store(key)
+	ps.regenerateClientConfig()
+	return nil
+}
+
+// ensureECDSAKey returns the P-256 key stored in the Systems table under the given name,
+// generating and saving one if it doesn't exist yet.
+func (ps *PlatformService) ensureECDSAKey(name string) (*ecdsa.PrivateKey, error) {
 	var key *model.SystemAsymmetricSigningKey
 
-	value, err := ps.Store.System().GetByName(model.SystemAsymmetricSigningKeyKey)
+	value, err := ps.Store.System().GetByName(name)
 	if err == nil {
 		if err := json.Unmarshal([]byte(value.Value), &key); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -268,7 +312,7 @@ func (ps *PlatformService) EnsureAsymmetricSigningKey() error {
 	if key == nil {
 		newECDSAKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		newKey := &model.SystemAsymmetricSigningKey{
 			ECDSAKey: &model.SystemECDSAKey{
@@ -279,16 +323,16 @@ func (ps *PlatformService) EnsureAsymmetricSigningKey() error {
 			},
 		}
 		system := &model.System{
-			Name: model.SystemAsymmetricSigningKeyKey,
+			Name: name,
 		}
 		v, err := json.Marshal(newKey)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		system.Value = string(v)
 		// If we were able to save the key, use it, otherwise log the error.
 		if err = ps.Store.System().Save(system); err != nil {
-			mlog.Warn("Failed to save AsymmetricSigningKey", mlog.Err(err))
+			mlog.Warn("Failed to save key", mlog.String("name", name), mlog.Err(err))
 		} else {
 			key = newKey
 		}
@@ -297,13 +341,13 @@ func (ps *PlatformService) EnsureAsymmetricSigningKey() error {
 	// If we weren't able to save a new key above, another server must have beat us to it. Get the
 	// key from the database, and if that fails, error out.
 	if key == nil {
-		value, err := ps.Store.System().GetByName(model.SystemAsymmetricSigningKeyKey)
+		value, err := ps.Store.System().GetByName(name)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if err := json.Unmarshal([]byte(value.Value), &key); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -312,18 +356,16 @@ func (ps *PlatformService) EnsureAsymmetricSigningKey() error {
 	case "P-256":
 		curve = elliptic.P256()
 	default:
-		return fmt.Errorf("unknown curve: %s", key.ECDSAKey.Curve)
+		return nil, fmt.Errorf("unknown curve: %s", key.ECDSAKey.Curve)
 	}
-	ps.asymmetricSigningKey.Store(&ecdsa.PrivateKey{
+	return &ecdsa.PrivateKey{
 		PublicKey: ecdsa.PublicKey{
 			Curve: curve,
 			X:     key.ECDSAKey.X,
 			Y:     key.ECDSAKey.Y,
 		},
 		D: key.ECDSAKey.D,
-	})
-	ps.regenerateClientConfig()
-	return nil
+	}, nil
 }
 
 // LimitedClientConfigWithComputed gets the configuration in a format suitable for sending to the client.
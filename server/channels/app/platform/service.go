@@ -59,6 +59,7 @@ type PlatformService struct {
 	sessionCache  cache.Cache
 
 	asymmetricSigningKey atomic.Pointer[ecdsa.PrivateKey]
+	webPushVAPIDKey      atomic.Pointer[ecdsa.PrivateKey]
 	clientConfig         atomic.Value
 	clientConfigHash     atomic.Value
 	limitedClientConfig  atomic.Value
@@ -422,6 +423,10 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 		return nil, fmt.Errorf("unable to ensure asymmetric signing key: %w", err)
 	}
 
+	if err = ps.EnsureWebPushVAPIDKey(); err != nil {
+		return nil, fmt.Errorf("unable to ensure web push VAPID key: %w", err)
+	}
+
 	ps.Busy = NewBusy(ps.clusterIFace)
 
 	// Enable developer settings and mmctl local mode if this is a "dev" build
@@ -538,6 +543,9 @@ func (ps *PlatformService) SetLogger(logger *mlog.Logger) {
 func (ps *PlatformService) initEnterprise() {
 	if clusterInterface != nil && ps.clusterIFace == nil {
 		ps.clusterIFace = clusterInterface(ps)
+	} else if ps.clusterIFace == nil && *ps.Config().ClusterSettings.Enable && *ps.Config().SqlSettings.DriverName == model.DatabaseDriverPostgres {
+		// Without an enterprise implementation, the nodes communicate through the database they share.
+		ps.clusterIFace = newPostgresCluster(ps)
 	}
 
 	if elasticsearchInterface != nil {
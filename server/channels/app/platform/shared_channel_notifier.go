@@ -23,6 +23,12 @@ var sharedChannelEventsForSync = []model.WebsocketEventType{
 	model.WebsocketEventReactionRemoved,
 	model.WebsocketEventAcknowledgementAdded,
 	model.WebsocketEventAcknowledgementRemoved,
+	model.WebsocketEventChannelUpdated,
+	model.WebsocketEventChannelBookmarkCreated,
+	model.WebsocketEventChannelBookmarkUpdated,
+	model.WebsocketEventChannelBookmarkDeleted,
+	model.WebsocketEventChannelBookmarkSorted,
+	model.WebsocketEventPropertyValuesUpdated,
 }
 
 var sharedChannelEventsForInvitation = []model.WebsocketEventType{
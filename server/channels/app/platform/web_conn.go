@@ -63,6 +63,7 @@ type pluginWSPostedHook struct {
 
 type WebConnConfig struct {
 	WebSocket         *websocket.Conn
+	EventStream       *EventStream
 	Session           model.Session
 	TFunc             i18n.TranslateFunc
 	Locale            string
@@ -98,6 +99,15 @@ type WebConn struct {
 	PostedAck         bool
 	DisconnectErrCode string
 
+	// eventStream is set instead of WebSocket for connections that
+	// receive events over Server-Sent Events. Requests from those clients
+	// arrive over HTTP and are handed to the connection through requests.
+	eventStream *EventStream
+	requests    chan *model.WebSocketRequest
+	// deflateConn is set when per-message compression was negotiated
+	// with the client, and counts the bytes written to the websocket.
+	deflateConn *deflateConn
+
 	allChannelMembers         map[string]string
 	lastAllChannelMembersTime int64
 	lastUserActivityAt        int64
@@ -207,15 +217,31 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 		})
 	}
 
+	var deflate *deflateConn
+	if cfg.WebSocket != nil {
+		deflate, _ = cfg.WebSocket.NetConn().(*deflateConn)
+		if deflate != nil {
+			if err := cfg.WebSocket.SetCompressionLevel(*ps.Config().ServiceSettings.WebSocketCompressionLevel); err != nil {
+				ps.logger.Warn("Error in setting websocket compression level", mlog.Err(err))
+			}
+		}
+	}
+
 	// Disable TCP_NO_DELAY for higher throughput
 	var tcpConn *net.TCPConn
-	switch conn := cfg.WebSocket.UnderlyingConn().(type) {
-	case *net.TCPConn:
-		tcpConn = conn
-	case *tls.Conn:
-		newConn, ok := conn.NetConn().(*net.TCPConn)
-		if ok {
-			tcpConn = newConn
+	if cfg.WebSocket != nil {
+		netConn := cfg.WebSocket.NetConn()
+		if deflate != nil {
+			netConn = deflate.Conn
+		}
+		switch conn := netConn.(type) {
+		case *net.TCPConn:
+			tcpConn = conn
+		case *tls.Conn:
+			newConn, ok := conn.NetConn().(*net.TCPConn)
+			if ok {
+				tcpConn = newConn
+			}
 		}
 	}
 
@@ -243,6 +269,8 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 		deadQueuePointer:   cfg.deadQueuePointer,
 		Sequence:           cfg.sequence,
 		WebSocket:          cfg.WebSocket,
+		eventStream:        cfg.EventStream,
+		deflateConn:        deflate,
 		lastUserActivityAt: model.GetMillis(),
 		UserId:             cfg.Session.UserId,
 		T:                  cfg.TFunc,
@@ -261,6 +289,10 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 	}
 	wc.Active.Store(cfg.Active)
 
+	if wc.eventStream != nil {
+		wc.requests = make(chan *model.WebSocketRequest, eventStreamRequestQueueSize)
+	}
+
 	wc.SetSession(&cfg.Session)
 	wc.SetSessionToken(cfg.Session.Token)
 	wc.SetSessionExpiresAt(cfg.Session.ExpiresAt)
@@ -295,10 +327,26 @@ func (wc *WebConn) pluginPostedConsumer(wg *sync.WaitGroup) {
 
 // Close closes the WebConn.
 func (wc *WebConn) Close() {
-	wc.WebSocket.Close()
+	wc.closeConnection()
 	<-wc.pumpFinished
 }
 
+// closeConnection closes the underlying websocket or event stream,
+// which in turn stops the pumps of the WebConn.
+func (wc *WebConn) closeConnection() {
+	if wc.eventStream != nil {
+		wc.eventStream.Close()
+		return
+	}
+	wc.WebSocket.Close()
+}
+
+// IsEventStream returns whether the connection delivers events over
+// Server-Sent Events rather than a websocket.
+func (wc *WebConn) IsEventStream() bool {
+	return wc.eventStream != nil
+}
+
 // GetSessionExpiresAt returns the time at which the session expires.
 func (wc *WebConn) GetSessionExpiresAt() int64 {
 	return atomic.LoadInt64(&wc.sessionExpiresAt)
@@ -406,15 +454,20 @@ func (wc *WebConn) SetSession(v *model.Session) {
 // Pump starts the WebConn instance. After this, the websocket
 // is ready to send/receive messages.
 func (wc *WebConn) Pump() {
+	readPump, writePump := wc.readPump, wc.writePump
+	if wc.eventStream != nil {
+		readPump, writePump = wc.eventStreamReadPump, wc.eventStreamWritePump
+	}
+
 	var wg sync.WaitGroup
 	wg.Go(func() {
-		wc.writePump()
+		writePump()
 	})
 
 	wg.Add(1)
 	go wc.pluginPostedConsumer(&wg)
 
-	wc.readPump()
+	readPump()
 	close(wc.endWritePump)
 	close(wc.pluginPosted)
 	wg.Wait()
@@ -487,30 +540,36 @@ func (wc *WebConn) readPump() {
 			return
 		}
 
-		// Messages which actions are prefixed with the plugin prefix
-		// should only be dispatched to the plugins
-		if !strings.HasPrefix(req.Action, websocketMessagePluginPrefix) {
-			wc.Platform.WebSocketRouter.ServeWebSocket(wc, &req)
-		}
+		wc.serveRequest(&req)
+	}
+}
 
-		clonedReq, err := req.Clone()
-		if err != nil {
-			wc.logSocketErr("websocket.cloneRequest", err)
-			continue
-		}
+// serveRequest dispatches a request received from the client to the
+// websocket router and the plugins.
+func (wc *WebConn) serveRequest(req *model.WebSocketRequest) {
+	// Messages which actions are prefixed with the plugin prefix
+	// should only be dispatched to the plugins
+	if !strings.HasPrefix(req.Action, websocketMessagePluginPrefix) {
+		wc.Platform.WebSocketRouter.ServeWebSocket(wc, req)
+	}
 
-		if session := wc.GetSession(); session != nil {
-			clonedReq.Session.Id = session.Id
-		}
+	clonedReq, err := req.Clone()
+	if err != nil {
+		wc.logSocketErr("websocket.cloneRequest", err)
+		return
+	}
 
-		if clonedReq.Data == nil {
-			clonedReq.Data = map[string]any{}
-		}
-		clonedReq.Data[model.WebSocketRemoteAddr] = wc.remoteAddress
-		clonedReq.Data[model.WebSocketXForwardedFor] = wc.xForwardedFor
+	if session := wc.GetSession(); session != nil {
+		clonedReq.Session.Id = session.Id
+	}
 
-		wc.pluginPosted <- pluginWSPostedHook{wc.GetConnectionID(), wc.UserId, clonedReq}
+	if clonedReq.Data == nil {
+		clonedReq.Data = map[string]any{}
 	}
+	clonedReq.Data[model.WebSocketRemoteAddr] = wc.remoteAddress
+	clonedReq.Data[model.WebSocketXForwardedFor] = wc.xForwardedFor
+
+	wc.pluginPosted <- pluginWSPostedHook{wc.GetConnectionID(), wc.UserId, clonedReq}
 }
 
 func (wc *WebConn) writePump() {
@@ -523,38 +582,8 @@ func (wc *WebConn) writePump() {
 		wc.WebSocket.Close()
 	}()
 
-	if wc.Sequence != 0 {
-		if ok, index := wc.isInDeadQueue(wc.Sequence); ok {
-			if err := wc.drainDeadQueue(index); err != nil {
-				wc.logSocketErr("websocket.drainDeadQueue", err)
-				return
-			}
-			if m := wc.Platform.metricsIFace; m != nil {
-				m.IncrementWebsocketReconnectEventWithDisconnectErrCode(reconnectFound, wc.DisconnectErrCode)
-			}
-		} else if wc.hasMsgLoss() {
-			// If the seq number is not in dead queue, but it was supposed to be,
-			// then generate a different connection ID,
-			// and set sequence to 0, and clear dead queue.
-			wc.clearDeadQueue()
-			wc.SetConnectionID(model.NewId())
-			wc.Sequence = 0
-
-			// Send hello message
-			msg := wc.createHelloMessage()
-			wc.addToDeadQueue(msg)
-			if err := wc.writeMessage(msg); err != nil {
-				wc.logSocketErr("websocket.sendHello", err)
-				return
-			}
-			if m := wc.Platform.metricsIFace; m != nil {
-				m.IncrementWebsocketReconnectEventWithDisconnectErrCode(reconnectNotFound, wc.DisconnectErrCode)
-			}
-		} else {
-			if m := wc.Platform.metricsIFace; m != nil {
-				m.IncrementWebsocketReconnectEventWithDisconnectErrCode(reconnectLossless, wc.DisconnectErrCode)
-			}
-		}
+	if !wc.resume() {
+		return
 	}
 
 	var buf bytes.Buffer
@@ -572,45 +601,10 @@ func (wc *WebConn) writePump() {
 				return
 			}
 
-			evt, evtOk := msg.(*model.WebSocketEvent)
-
-			if evtOk && evt.IsRejected() {
+			if _, ok := wc.encodeMessage(msg, enc, &buf); !ok {
 				continue
 			}
 
-			buf.Reset()
-			var err error
-			if evtOk {
-				evt = evt.SetSequence(wc.Sequence)
-				err = evt.Encode(enc, &buf)
-				wc.Sequence++
-			} else {
-				err = enc.Encode(msg)
-			}
-			if err != nil {
-				wc.Platform.logger.Warn("Error in encoding websocket message", mlog.Err(err))
-				continue
-			}
-
-			if wc.Active.Load() && len(wc.send) >= sendFullWarn && time.Since(wc.lastLogTimeFull) > websocketSuppressWarnThreshold {
-				logData := []mlog.Field{
-					mlog.String("user_id", wc.UserId),
-					mlog.String("conn_id", wc.GetConnectionID()),
-					mlog.String("type", msg.EventType()),
-					mlog.Int("size", buf.Len()),
-				}
-				if evtOk {
-					logData = append(logData, mlog.String("channel_id", evt.GetBroadcast().ChannelId))
-				}
-
-				wc.Platform.logger.Warn("websocket.full", logData...)
-				wc.lastLogTimeFull = time.Now()
-			}
-
-			if evtOk {
-				wc.addToDeadQueue(evt)
-			}
-
 			if err := wc.writeMessageBuf(websocket.TextMessage, buf.Bytes()); err != nil {
 				wc.logSocketErr("websocket.send", err)
 				return
@@ -638,13 +632,121 @@ func (wc *WebConn) writePump() {
 	}
 }
 
+// resume replays the messages the client missed when it reconnects with
+// a sequence number, or starts over with a new connection ID if those were lost.
+// It returns false if the connection should be closed.
+func (wc *WebConn) resume() bool {
+	if wc.Sequence == 0 {
+		return true
+	}
+
+	if ok, index := wc.isInDeadQueue(wc.Sequence); ok {
+		if err := wc.drainDeadQueue(index); err != nil {
+			wc.logSocketErr("websocket.drainDeadQueue", err)
+			return false
+		}
+		if m := wc.Platform.metricsIFace; m != nil {
+			m.IncrementWebsocketReconnectEventWithDisconnectErrCode(reconnectFound, wc.DisconnectErrCode)
+		}
+	} else if wc.hasMsgLoss() {
+		// If the seq number is not in dead queue, but it was supposed to be,
+		// then generate a different connection ID,
+		// and set sequence to 0, and clear dead queue.
+		wc.clearDeadQueue()
+		wc.SetConnectionID(model.NewId())
+		wc.Sequence = 0
+
+		// Send hello message
+		msg := wc.createHelloMessage()
+		wc.addToDeadQueue(msg)
+		if err := wc.writeMessage(msg); err != nil {
+			wc.logSocketErr("websocket.sendHello", err)
+			return false
+		}
+		if m := wc.Platform.metricsIFace; m != nil {
+			m.IncrementWebsocketReconnectEventWithDisconnectErrCode(reconnectNotFound, wc.DisconnectErrCode)
+		}
+	} else {
+		if m := wc.Platform.metricsIFace; m != nil {
+			m.IncrementWebsocketReconnectEventWithDisconnectErrCode(reconnectLossless, wc.DisconnectErrCode)
+		}
+	}
+	return true
+}
+
+// encodeMessage encodes a message from the send queue into buf, assigning
+// the next sequence number to events and recording them in the dead queue.
+// It returns the encoded event, if the message is one, and false if
+// the message should not be sent.
+func (wc *WebConn) encodeMessage(msg model.WebSocketMessage, enc *json.Encoder, buf *bytes.Buffer) (*model.WebSocketEvent, bool) {
+	evt, evtOk := msg.(*model.WebSocketEvent)
+
+	if evtOk && evt.IsRejected() {
+		return nil, false
+	}
+
+	buf.Reset()
+	var err error
+	if evtOk {
+		evt = evt.SetSequence(wc.Sequence)
+		err = evt.Encode(enc, buf)
+		wc.Sequence++
+	} else {
+		err = enc.Encode(msg)
+	}
+	if err != nil {
+		wc.Platform.logger.Warn("Error in encoding websocket message", mlog.Err(err))
+		return nil, false
+	}
+
+	if wc.Active.Load() && len(wc.send) >= sendFullWarn && time.Since(wc.lastLogTimeFull) > websocketSuppressWarnThreshold {
+		logData := []mlog.Field{
+			mlog.String("user_id", wc.UserId),
+			mlog.String("conn_id", wc.GetConnectionID()),
+			mlog.String("type", msg.EventType()),
+			mlog.Int("size", buf.Len()),
+		}
+		if evtOk {
+			logData = append(logData, mlog.String("channel_id", evt.GetBroadcast().ChannelId))
+		}
+
+		wc.Platform.logger.Warn("websocket.full", logData...)
+		wc.lastLogTimeFull = time.Now()
+	}
+
+	if !evtOk {
+		return nil, true
+	}
+
+	wc.addToDeadQueue(evt)
+	return evt, true
+}
+
 // writeMessageBuf is a helper utility that wraps the write to the socket
 // along with setting the write deadline.
 func (wc *WebConn) writeMessageBuf(msgType int, data []byte) error {
 	if err := wc.WebSocket.SetWriteDeadline(time.Now().Add(writeWaitTime)); err != nil {
 		return err
 	}
-	return wc.WebSocket.WriteMessage(msgType, data)
+	if wc.deflateConn == nil || msgType != websocket.TextMessage {
+		return wc.WebSocket.WriteMessage(msgType, data)
+	}
+
+	// Small messages aren't worth the CPU, and might even grow when compressed.
+	compress := len(data) >= *wc.Platform.Config().ServiceSettings.WebSocketCompressionThresholdBytes
+	wc.WebSocket.EnableWriteCompression(compress)
+	if !compress {
+		return wc.WebSocket.WriteMessage(msgType, data)
+	}
+
+	written := wc.deflateConn.written.Load()
+	if err := wc.WebSocket.WriteMessage(msgType, data); err != nil {
+		return err
+	}
+	if m := wc.Platform.metricsIFace; m != nil {
+		m.ObserveWebSocketCompression(wc.originClient, len(data), int(wc.deflateConn.written.Load()-written))
+	}
+	return nil
 }
 
 func (wc *WebConn) writeMessage(msg *model.WebSocketEvent) error {
@@ -658,6 +760,9 @@ func (wc *WebConn) writeMessage(msg *model.WebSocketEvent) error {
 	}
 	wc.Sequence++
 
+	if wc.eventStream != nil {
+		return wc.eventStream.writeEvent(eventStreamID(wc.GetConnectionID(), msg.GetSequence()), buf.Bytes())
+	}
 	return wc.writeMessageBuf(websocket.TextMessage, buf.Bytes())
 }
 
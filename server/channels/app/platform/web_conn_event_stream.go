@@ -0,0 +1,225 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	eventStreamRequestQueueSize = 10
+	eventStreamIDSeparator      = ":"
+)
+
+// ErrEventStreamClosed is returned when a request is submitted to an
+// event stream connection that has already been closed.
+var ErrEventStreamClosed = errors.New("event stream is closed")
+
+// EventStream is the Server-Sent Events response of a WebConn, used in place of
+// a websocket by clients that can't upgrade their connection, e.g. when they
+// sit behind proxies which don't support websockets.
+//
+// Every event is sent with an ID made of the connection ID and its sequence number,
+// so that a client reconnecting with the Last-Event-ID header resumes the
+// connection the same way a reliable websocket does.
+type EventStream struct {
+	ctx       context.Context
+	w         http.ResponseWriter
+	rc        *http.ResponseController
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewEventStream starts a Server-Sent Events response over w. The stream ends
+// when the request is done or the stream is closed.
+func NewEventStream(w http.ResponseWriter, r *http.Request) (*EventStream, error) {
+	es := &EventStream{
+		ctx:  r.Context(),
+		w:    w,
+		rc:   http.NewResponseController(w),
+		done: make(chan struct{}),
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Prevents proxies such as nginx from buffering the events.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := es.flush(); err != nil {
+		return nil, fmt.Errorf("failed to start event stream: %w", err)
+	}
+	return es, nil
+}
+
+// Close ends the stream. It is safe to call it multiple times.
+func (es *EventStream) Close() {
+	es.closeOnce.Do(func() {
+		close(es.done)
+	})
+}
+
+// writeEvent writes a single event with the given ID, which is omitted
+// when empty, and flushes it to the client.
+func (es *EventStream) writeEvent(id string, data []byte) error {
+	var buf bytes.Buffer
+	if id != "" {
+		buf.WriteString("id: " + id + "\n")
+	}
+	for line := range bytes.SplitSeq(bytes.TrimRight(data, "\n"), []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+
+	return es.write(buf.Bytes())
+}
+
+// writeKeepAlive writes a comment which is ignored by clients, but keeps
+// idle connections from being dropped by proxies.
+func (es *EventStream) writeKeepAlive() error {
+	return es.write([]byte(": ping\n\n"))
+}
+
+func (es *EventStream) write(data []byte) error {
+	if err := es.rc.SetWriteDeadline(time.Now().Add(writeWaitTime)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if _, err := es.w.Write(data); err != nil {
+		return err
+	}
+	return es.flush()
+}
+
+func (es *EventStream) flush() error {
+	return es.rc.Flush()
+}
+
+// eventStreamID returns the ID of the event with the given sequence number.
+func eventStreamID(connectionID string, seq int64) string {
+	return connectionID + eventStreamIDSeparator + strconv.FormatInt(seq, 10)
+}
+
+// ParseEventStreamID returns the connection ID and the sequence number of the
+// event following the one with the given ID. It is used to resume a connection
+// from the Last-Event-ID header.
+func ParseEventStreamID(id string) (string, string, error) {
+	connectionID, seqVal, ok := strings.Cut(id, eventStreamIDSeparator)
+	if !ok {
+		return "", "", fmt.Errorf("invalid event id: %s", id)
+	}
+
+	seq, err := strconv.ParseInt(seqVal, 10, 64)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid sequence number in event id %s: %w", id, err)
+	}
+	return connectionID, strconv.FormatInt(seq+1, 10), nil
+}
+
+// SubmitRequest hands a request received over HTTP to an event stream connection,
+// to be served the same way as a request received over a websocket.
+func (wc *WebConn) SubmitRequest(req *model.WebSocketRequest) error {
+	if wc.eventStream == nil {
+		return errors.New("connection does not accept submitted requests")
+	}
+
+	select {
+	case wc.requests <- req:
+		return nil
+	case <-wc.eventStream.done:
+		return ErrEventStreamClosed
+	case <-wc.eventStream.ctx.Done():
+		return ErrEventStreamClosed
+	}
+}
+
+func (wc *WebConn) eventStreamReadPump() {
+	defer func() {
+		if metrics := wc.Platform.metricsIFace; metrics != nil {
+			metrics.DecrementHTTPWebSockets(wc.originClient)
+		}
+		wc.eventStream.Close()
+	}()
+	if metrics := wc.Platform.metricsIFace; metrics != nil {
+		metrics.IncrementHTTPWebSockets(wc.originClient)
+	}
+
+	for {
+		select {
+		case req := <-wc.requests:
+			wc.serveRequest(req)
+		case <-wc.eventStream.done:
+			return
+		case <-wc.eventStream.ctx.Done():
+			wc.logSocketErr("eventstream.read", context.Cause(wc.eventStream.ctx))
+			return
+		}
+	}
+}
+
+func (wc *WebConn) eventStreamWritePump() {
+	ticker := time.NewTicker(pingInterval)
+
+	defer func() {
+		ticker.Stop()
+		wc.eventStream.Close()
+	}()
+
+	if !wc.resume() {
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(1024 * 2)
+	enc := json.NewEncoder(&buf)
+
+	for {
+		select {
+		case msg, ok := <-wc.send:
+			if !ok {
+				return
+			}
+
+			evt, ok := wc.encodeMessage(msg, enc, &buf)
+			if !ok {
+				continue
+			}
+
+			// Only events carry an ID, so that responses to client requests
+			// don't move the position a reconnecting client resumes from.
+			var id string
+			if evt != nil {
+				id = eventStreamID(wc.GetConnectionID(), evt.GetSequence())
+			}
+			if err := wc.eventStream.writeEvent(id, buf.Bytes()); err != nil {
+				wc.logSocketErr("eventstream.send", err)
+				return
+			}
+
+			if m := wc.Platform.metricsIFace; m != nil {
+				m.IncrementWebSocketBroadcast(msg.EventType())
+			}
+		case <-ticker.C:
+			if err := wc.eventStream.writeKeepAlive(); err != nil {
+				wc.logSocketErr("eventstream.ticker", err)
+				return
+			}
+
+		case <-wc.endWritePump:
+			return
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestParseEventStreamID(t *testing.T) {
+	connID := model.NewId()
+
+	t.Run("valid id", func(t *testing.T) {
+		gotConnID, seqVal, err := ParseEventStreamID(eventStreamID(connID, 41))
+		require.NoError(t, err)
+		assert.Equal(t, connID, gotConnID)
+		assert.Equal(t, "42", seqVal)
+	})
+
+	t.Run("missing separator", func(t *testing.T) {
+		_, _, err := ParseEventStreamID(connID)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid sequence number", func(t *testing.T) {
+		_, _, err := ParseEventStreamID(connID + ":abc")
+		require.Error(t, err)
+	})
+}
+
+func TestEventStreamWriteEvent(t *testing.T) {
+	rec := httptest.NewRecorder()
+	es, err := NewEventStream(rec, httptest.NewRequest(http.MethodGet, "/api/v4/websocket/events", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+
+	require.NoError(t, es.writeEvent("conn:1", []byte("{\"event\":\"hello\"}\n")))
+	require.NoError(t, es.writeEvent("", []byte("{\"status\":\"OK\"}\n")))
+	require.NoError(t, es.writeKeepAlive())
+
+	assert.Equal(t, "id: conn:1\ndata: {\"event\":\"hello\"}\n\ndata: {\"status\":\"OK\"}\n\n: ping\n\n", rec.Body.String())
+	assert.True(t, rec.Flushed)
+}
+
+func TestEventStreamResume(t *testing.T) {
+	th := Setup(t)
+
+	rec := httptest.NewRecorder()
+	es, err := NewEventStream(rec, httptest.NewRequest(http.MethodGet, "/api/v4/websocket/events", nil))
+	require.NoError(t, err)
+
+	connID := model.NewId()
+	wc := th.Service.NewWebConn(&WebConnConfig{
+		EventStream:  es,
+		ConnectionID: connID,
+	}, th.Suite, &hookRunner{})
+	require.True(t, wc.IsEventStream())
+
+	for i := range 10 {
+		msg := model.NewWebSocketEvent("", "", "", "", map[string]bool{}, "")
+		msg = msg.SetSequence(int64(i))
+		wc.addToDeadQueue(msg)
+	}
+	wc.Sequence = 4
+
+	require.True(t, wc.resume())
+
+	var ids []string
+	for line := range strings.SplitSeq(rec.Body.String(), "\n") {
+		if id, ok := strings.CutPrefix(line, "id: "); ok {
+			ids = append(ids, id)
+		}
+	}
+	require.Len(t, ids, 6)
+	for i, id := range ids {
+		assert.Equal(t, fmt.Sprintf("%s:%d", connID, i+4), id)
+	}
+	assert.Equal(t, int64(10), wc.Sequence)
+}
+
+func TestEventStreamSubmitRequest(t *testing.T) {
+	th := Setup(t)
+
+	es, err := NewEventStream(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v4/websocket/events", nil))
+	require.NoError(t, err)
+
+	wc := th.Service.NewWebConn(&WebConnConfig{
+		EventStream: es,
+	}, th.Suite, &hookRunner{})
+
+	req := &model.WebSocketRequest{Seq: 1, Action: "user_typing"}
+	require.NoError(t, wc.SubmitRequest(req))
+	assert.Same(t, req, <-wc.requests)
+
+	es.Close()
+	for range eventStreamRequestQueueSize + 1 {
+		if err = wc.SubmitRequest(req); err != nil {
+			break
+		}
+	}
+	require.ErrorIs(t, err, ErrEventStreamClosed)
+}
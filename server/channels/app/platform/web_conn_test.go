@@ -9,15 +9,18 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
 )
 
 type hookRunner struct {
@@ -283,3 +286,112 @@ func TestWebConnRejectBinaryFrameUnauthenticated(t *testing.T) {
 		require.Fail(t, "readPump did not exit after receiving binary frame")
 	}
 }
+
+func TestWebConnCompression(t *testing.T) {
+	th := Setup(t)
+	th.Service.UpdateConfig(func(cfg *model.Config) {
+		cfg.ServiceSettings.EnableWebSocketCompression = new(true)
+		cfg.ServiceSettings.WebSocketCompressionThresholdBytes = new(1024)
+	})
+
+	// connect returns the server side WebConn and the client side of a websocket.
+	connect := func(t *testing.T, dialer *websocket.Dialer) (*WebConn, *websocket.Conn) {
+		t.Helper()
+		wcCh := make(chan *WebConn, 1)
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := th.Service.UpgradeWebSocket(w, r, nil)
+			if !assert.NoError(t, err) {
+				close(wcCh)
+				return
+			}
+			wcCh <- th.Service.NewWebConn(&WebConnConfig{WebSocket: conn}, th.Suite, &hookRunner{})
+		}))
+		t.Cleanup(s.Close)
+
+		clientConn, _, err := dialer.Dial("ws://"+s.Listener.Addr().String()+"/ws", nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { clientConn.Close() })
+
+		wc := <-wcCh
+		require.NotNil(t, wc)
+		t.Cleanup(func() { wc.WebSocket.Close() })
+		return wc, clientConn
+	}
+
+	large := []byte(`{"event":"posted","data":{"message":"` + strings.Repeat("hello world ", 400) + `"}}`)
+	small := []byte(`{"event":"typing"}`)
+
+	t.Run("compresses messages above the threshold", func(t *testing.T) {
+		metricsMock := &mocks.MetricsInterface{}
+		metricsMock.On("ObserveWebSocketCompression", "", len(large), mock.MatchedBy(func(size int) bool {
+			return size > 0 && size < len(large)/10
+		})).Return().Once()
+		metricsMock.On("IncrementWebSocketBroadcastBufferSize", mock.AnythingOfType("string"), mock.AnythingOfType("float64")).Return().Maybe()
+		metricsMock.On("DecrementWebSocketBroadcastBufferSize", mock.AnythingOfType("string"), mock.AnythingOfType("float64")).Return().Maybe()
+		th.Service.metricsIFace = metricsMock
+		defer func() { th.Service.metricsIFace = nil }()
+
+		wc, clientConn := connect(t, &websocket.Dialer{EnableCompression: true})
+		require.NotNil(t, wc.deflateConn)
+
+		require.NoError(t, wc.writeMessageBuf(websocket.TextMessage, large))
+		require.NoError(t, wc.writeMessageBuf(websocket.TextMessage, small))
+
+		for _, expected := range [][]byte{large, small} {
+			_, data, err := clientConn.ReadMessage()
+			require.NoError(t, err)
+			assert.Equal(t, expected, data)
+		}
+
+		// The small message is sent uncompressed, and isn't reported.
+		metricsMock.AssertExpectations(t)
+	})
+
+	t.Run("client without compression support", func(t *testing.T) {
+		wc, clientConn := connect(t, &websocket.Dialer{})
+		require.Nil(t, wc.deflateConn)
+
+		require.NoError(t, wc.writeMessageBuf(websocket.TextMessage, large))
+		_, data, err := clientConn.ReadMessage()
+		require.NoError(t, err)
+		assert.Equal(t, large, data)
+	})
+
+	t.Run("compression disabled", func(t *testing.T) {
+		th.Service.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.EnableWebSocketCompression = new(false)
+		})
+		defer th.Service.UpdateConfig(func(cfg *model.Config) {
+			cfg.ServiceSettings.EnableWebSocketCompression = new(true)
+		})
+
+		wc, clientConn := connect(t, &websocket.Dialer{EnableCompression: true})
+		require.Nil(t, wc.deflateConn)
+
+		require.NoError(t, wc.writeMessageBuf(websocket.TextMessage, large))
+		_, data, err := clientConn.ReadMessage()
+		require.NoError(t, err)
+		assert.Equal(t, large, data)
+	})
+}
+
+func TestOffersPermessageDeflate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		headers  []string
+		expected bool
+	}{
+		"no extensions":          {nil, false},
+		"permessage-deflate":     {[]string{"permessage-deflate; client_max_window_bits"}, true},
+		"among other extensions": {[]string{"x-webkit-deflate-frame, permessage-deflate"}, true},
+		"in a second header":     {[]string{"x-webkit-deflate-frame", "permessage-deflate"}, true},
+		"other extension only":   {[]string{"x-webkit-deflate-frame"}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+			for _, h := range tc.headers {
+				r.Header.Add("Sec-WebSocket-Extensions", h)
+			}
+			assert.Equal(t, tc.expected, offersPermessageDeflate(r))
+		})
+	}
+}
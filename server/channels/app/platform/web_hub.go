@@ -65,6 +65,12 @@ type webConnCheckMessage struct {
 	result       chan *CheckConnResult
 }
 
+type webConnGetMessage struct {
+	userID       string
+	connectionID string
+	result       chan *WebConn
+}
+
 type webConnCountMessage struct {
 	userID string
 	result chan int
@@ -93,6 +99,7 @@ type Hub struct {
 	explicitStop    bool
 	checkRegistered chan *webConnSessionMessage
 	checkConn       chan *webConnCheckMessage
+	getConn         chan *webConnGetMessage
 	connCount       chan *webConnCountMessage
 	broadcastHooks  map[string]BroadcastHook
 
@@ -115,6 +122,7 @@ func newWebHub(ps *PlatformService) *Hub {
 		directMsg:       make(chan *webConnDirectMessage),
 		checkRegistered: make(chan *webConnSessionMessage),
 		checkConn:       make(chan *webConnCheckMessage),
+		getConn:         make(chan *webConnGetMessage),
 		connCount:       make(chan *webConnCountMessage),
 		hubSemaphore:    make(chan struct{}, hubSemaphoreCount),
 	}
@@ -364,6 +372,16 @@ func (ps *PlatformService) CheckWebConn(userID, connectionID string, seqNum int6
 	return nil
 }
 
+// GetWebConn returns the active connection of a user with the given connection ID,
+// or nil if there is none on this node.
+func (ps *PlatformService) GetWebConn(userID, connectionID string) *WebConn {
+	hub := ps.GetHubForUserId(userID)
+	if hub != nil {
+		return hub.GetConn(userID, connectionID)
+	}
+	return nil
+}
+
 // WebConnCountForUser returns the number of active websocket connections
 // for a given userID.
 func (ps *PlatformService) WebConnCountForUser(userID string) int {
@@ -425,6 +443,20 @@ func (h *Hub) CheckConn(userID, connectionID string) *CheckConnResult {
 	return nil
 }
 
+func (h *Hub) GetConn(userID, connectionID string) *WebConn {
+	req := &webConnGetMessage{
+		userID:       userID,
+		connectionID: connectionID,
+		result:       make(chan *WebConn),
+	}
+	select {
+	case h.getConn <- req:
+		return <-req.result
+	case <-h.stop:
+	}
+	return nil
+}
+
 func (h *Hub) WebConnCountForUser(userID string) int {
 	req := &webConnCountMessage{
 		userID: userID,
@@ -579,6 +611,17 @@ func (h *Hub) Start() {
 					}
 				}
 				req.result <- res
+			case req := <-h.getConn:
+				var res *WebConn
+				// The connection ID changes when a connection can't be resumed,
+				// so we look at the current ID of each connection of the user.
+				for conn := range connIndex.ForUser(req.userID) {
+					if conn.Active.Load() && conn.GetConnectionID() == req.connectionID {
+						res = conn
+						break
+					}
+				}
+				req.result <- res
 			case req := <-h.connCount:
 				req.result <- connIndex.ForUserActiveCount(req.userID)
 			case <-ticker.C:
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const permessageDeflateExtension = "permessage-deflate"
+
+// UpgradeWebSocket upgrades the connection of the request to a websocket.
+// When enabled in the config, per-message compression (RFC 7692) is negotiated
+// with the clients that support it.
+func (ps *PlatformService) UpgradeWebSocket(w http.ResponseWriter, r *http.Request, checkOrigin func(*http.Request) bool) (*websocket.Conn, error) {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:    model.SocketMaxMessageSizeKb,
+		WriteBufferSize:   model.SocketMaxMessageSizeKb,
+		CheckOrigin:       checkOrigin,
+		EnableCompression: *ps.Config().ServiceSettings.EnableWebSocketCompression,
+	}
+
+	if upgrader.EnableCompression && offersPermessageDeflate(r) {
+		// The connection is wrapped to count the bytes that actually go on the wire,
+		// which is the only way to know how well messages are compressed.
+		w = &deflateResponseWriter{ResponseWriter: w}
+	}
+
+	return upgrader.Upgrade(w, r, nil)
+}
+
+// offersPermessageDeflate returns whether the client offers per-message compression,
+// in which case the upgrader accepts it.
+func offersPermessageDeflate(r *http.Request) bool {
+	for _, header := range r.Header.Values("Sec-WebSocket-Extensions") {
+		for ext := range strings.SplitSeq(header, ",") {
+			name, _, _ := strings.Cut(ext, ";")
+			if strings.TrimSpace(name) == permessageDeflateExtension {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deflateResponseWriter hands a deflateConn to the upgrader when it hijacks the connection.
+type deflateResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *deflateResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &deflateConn{Conn: conn}, rw, nil
+}
+
+// deflateConn is the network connection of a websocket with per-message compression,
+// which counts the bytes written to it.
+type deflateConn struct {
+	net.Conn
+	written atomic.Int64
+}
+
+func (c *deflateConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.written.Add(int64(n))
+	return n, err
+}
@@ -1276,7 +1276,7 @@ func (a *App) GetPostsPage(rctx request.CTX, options model.GetPostsOptions) (*mo
 }
 
 // GetPostsForView returns posts for a specific view. Currently returns all channel posts.
-// TODO: In the future, this will filter posts based on the view's configuration (e.g., property values, sort order).
+// Table and calendar views are served by GetPostsForTableView and GetPostsForCalendarView.
 func (a *App) GetPostsForView(rctx request.CTX, options model.GetPostsOptions) (*model.PostList, *model.AppError) {
 	postList, err := a.Srv().Store().Post().GetPosts(rctx, options, false, a.Config().GetSanitizeOptions())
 	if err != nil {
@@ -1289,13 +1289,77 @@ func (a *App) GetPostsForView(rctx request.CTX, options model.GetPostsOptions) (
 		}
 	}
 
-	var appErr *model.AppError
-	postList, appErr = a.revealBurnOnReadPostsForUser(rctx, postList, options.UserId)
+	return a.prepareViewPostList(rctx, postList, options.UserId, filterPostOptions{assumeSortedCreatedAt: true})
+}
+
+// GetPostsForTableView returns a page of the posts of a table view, filtered and sorted
+// by their property values according to the view's configuration.
+func (a *App) GetPostsForTableView(rctx request.CTX, view *model.View, opts model.ViewPostsQueryOpts, userID string) (*model.PostList, *model.AppError) {
+	props, err := model.TablePropsFromProps(view.Props)
+	if err != nil {
+		return nil, model.NewAppError("GetPostsForTableView", "model.view.is_valid.props.table_invalid.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	posts, err := a.Srv().Store().View().GetPostsForTable(view.ChannelId, props, opts)
+	if err != nil {
+		var invErr *store.ErrInvalidInput
+		switch {
+		case errors.As(err, &invErr):
+			return nil, model.NewAppError("GetPostsForTableView", "app.post.get_posts.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetPostsForTableView", "app.post.get_posts_for_view.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return a.prepareViewPostList(rctx, newViewPostList(posts), userID, filterPostOptions{})
+}
+
+// GetPostsForCalendarView returns the posts of a calendar view whose date falls between
+// opts.Start and opts.End, sorted by date.
+func (a *App) GetPostsForCalendarView(rctx request.CTX, view *model.View, opts model.ViewPostsQueryOpts, userID string) (*model.PostList, *model.AppError) {
+	if !opts.IsValidCalendarRange() {
+		return nil, model.NewAppError("GetPostsForCalendarView", "app.view.get_posts.calendar_range.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	props, err := model.CalendarPropsFromProps(view.Props)
+	if err != nil {
+		return nil, model.NewAppError("GetPostsForCalendarView", "model.view.is_valid.props.calendar_invalid.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	posts, err := a.Srv().Store().View().GetPostsForCalendar(view.ChannelId, props, opts)
+	if err != nil {
+		var invErr *store.ErrInvalidInput
+		switch {
+		case errors.As(err, &invErr):
+			return nil, model.NewAppError("GetPostsForCalendarView", "app.post.get_posts.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetPostsForCalendarView", "app.post.get_posts_for_view.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return a.prepareViewPostList(rctx, newViewPostList(posts), userID, filterPostOptions{})
+}
+
+// newViewPostList builds a post list keeping the order in which the posts were returned.
+func newViewPostList(posts []*model.Post) *model.PostList {
+	postList := model.NewPostList()
+	for _, p := range posts {
+		if p.Type == model.PostTypeBurnOnRead {
+			postList.BurnOnReadPosts[p.Id] = p
+		}
+		postList.AddPost(p)
+		postList.AddOrder(p.Id)
+	}
+	return postList
+}
+
+func (a *App) prepareViewPostList(rctx request.CTX, postList *model.PostList, userID string, options filterPostOptions) (*model.PostList, *model.AppError) {
+	postList, appErr := a.revealBurnOnReadPostsForUser(rctx, postList, userID)
 	if appErr != nil {
 		return nil, appErr
 	}
 
-	if appErr = a.filterInaccessiblePosts(postList, filterPostOptions{assumeSortedCreatedAt: true}); appErr != nil {
+	if appErr = a.filterInaccessiblePosts(postList, options); appErr != nil {
 		return nil, appErr
 	}
 
@@ -2194,9 +2258,12 @@ func (a *App) SearchPostsForUser(rctx request.CTX, terms string, userID string,
 	postSearchResults, err := a.Srv().Store().Post().SearchPostsForUser(rctx, finalParamsList, userID, teamID, page, perPage)
 	if err != nil {
 		var appErr *model.AppError
+		var nieErr *store.ErrNotImplemented
 		switch {
 		case errors.As(err, &appErr):
 			return nil, false, appErr
+		case errors.As(err, &nieErr):
+			return nil, false, model.NewAppError("SearchPostsForUser", "app.post.search.unsupported.app_error", nil, "", http.StatusBadRequest).Wrap(err)
 		default:
 			return nil, false, model.NewAppError("SearchPostsForUser", "app.post.search.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
@@ -35,11 +35,12 @@ type PermissionChecker func(userID string, permission *model.Permission) bool
 //   - trims whitespace on string attrs
 //   - applies the visibility default when unset
 //   - clears attrs that don't apply to the field type (options on non-select,
-//     ldap/saml on non-text fields)
+//     ldap/saml on non-text fields, min/max/precision on non-number fields)
 //   - auto-assigns IDs to options that lack one and validates option shape
 //   - validates visibility, value_type, managed, display_name, and sort_order
 //   - validates property values for text fields against value_type
-//     constraints (email, url, phone)
+//     constraints (email, url, phone), and for number, url and checkbox
+//     fields against their type
 //   - enforces that managed="admin" can only be set by callers with
 //     PermissionManageSystem, and keeps PermissionValues in sync with the
 //     managed attribute
@@ -106,6 +107,11 @@ func (h *AccessControlAttributeValidationHook) sanitizeAndValidateFieldAttrs(fie
 		delete(field.Attrs, model.PropertyFieldAttrLDAP)
 		delete(field.Attrs, model.PropertyFieldAttrSAML)
 	}
+	if field.Type != model.PropertyFieldTypeNumber {
+		delete(field.Attrs, model.PropertyFieldAttrMin)
+		delete(field.Attrs, model.PropertyFieldAttrMax)
+		delete(field.Attrs, model.PropertyFieldAttrPrecision)
+	}
 
 	if err := model.ValidatePropertyFieldVisibility(field); err != nil {
 		return fmt.Errorf("%s: %w", err.Error(), ErrInvalidFieldAttrs)
@@ -126,6 +132,11 @@ func (h *AccessControlAttributeValidationHook) sanitizeAndValidateFieldAttrs(fie
 			return err
 		}
 	}
+	if field.Type == model.PropertyFieldTypeNumber {
+		if err := model.ValidatePropertyFieldNumberAttrs(field); err != nil {
+			return fmt.Errorf("%s: %w", err.Error(), ErrInvalidFieldAttrs)
+		}
+	}
 	if err := model.ValidatePropertyFieldSortOrder(field); err != nil {
 		return fmt.Errorf("%s: %w", err.Error(), ErrInvalidFieldAttrs)
 	}
@@ -389,6 +400,7 @@ func extractOptionIDs(field *model.PropertyField) (map[string]struct{}, error) {
 //   - multiselect: all option IDs must exist
 //   - user: value must be a valid Mattermost ID
 //   - multiuser: all values must be valid Mattermost IDs
+//   - number, url, checkbox: see model.ValidatePropertyValueForFieldType
 func (h *AccessControlAttributeValidationHook) validateValueAgainstField(field *model.PropertyField, value *model.PropertyValue) error {
 	switch field.Type {
 	case model.PropertyFieldTypeText:
@@ -456,6 +468,9 @@ func (h *AccessControlAttributeValidationHook) validateValueAgainstField(field *
 				return fmt.Errorf("invalid user id: %s", v)
 			}
 		}
+
+	case model.PropertyFieldTypeNumber, model.PropertyFieldTypeURL, model.PropertyFieldTypeCheckbox:
+		return model.ValidatePropertyValueForFieldType(field, value.Value)
 	}
 
 	return nil
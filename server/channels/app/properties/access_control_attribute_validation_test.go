@@ -936,6 +936,116 @@ func TestAccessControlAttributeValidationHook(t *testing.T) {
 		assert.Contains(t, upsertErr.Error(), "maximum length")
 	})
 
+	t.Run("number — rejects min greater than max on create", func(t *testing.T) {
+		field := &model.PropertyField{
+			GroupID:    group.ID,
+			Name:       "field_" + model.NewId(),
+			Type:       model.PropertyFieldTypeNumber,
+			TargetType: "system",
+			ObjectType: "user",
+			Attrs:      model.StringInterface{model.PropertyFieldAttrMin: 10, model.PropertyFieldAttrMax: 1},
+		}
+		_, createErr := th.service.CreatePropertyField(th.Context, field)
+		require.Error(t, createErr)
+		assert.ErrorIs(t, createErr, ErrInvalidFieldAttrs)
+	})
+
+	t.Run("number attrs are stripped from non-number fields", func(t *testing.T) {
+		field := &model.PropertyField{
+			GroupID:    group.ID,
+			Name:       "field_" + model.NewId(),
+			Type:       model.PropertyFieldTypeText,
+			TargetType: "system",
+			ObjectType: "user",
+			Attrs:      model.StringInterface{model.PropertyFieldAttrMin: 1, model.PropertyFieldAttrPrecision: 2},
+		}
+		created, createErr := th.service.CreatePropertyField(th.Context, field)
+		require.NoError(t, createErr)
+		assert.NotContains(t, created.Attrs, model.PropertyFieldAttrMin)
+		assert.NotContains(t, created.Attrs, model.PropertyFieldAttrPrecision)
+	})
+
+	t.Run("number — validates values against min, max and precision", func(t *testing.T) {
+		field := th.CreatePropertyFieldDirect(t, &model.PropertyField{
+			GroupID:    group.ID,
+			Name:       "number_field_" + model.NewId(),
+			Type:       model.PropertyFieldTypeNumber,
+			TargetType: "system",
+			ObjectType: "user",
+			Attrs: model.StringInterface{
+				model.PropertyFieldAttrMin:       0,
+				model.PropertyFieldAttrMax:       10,
+				model.PropertyFieldAttrPrecision: 1,
+			},
+		})
+
+		for raw, valid := range map[string]bool{
+			`5.5`:  true,
+			`10`:   true,
+			`11`:   false,
+			`-1`:   false,
+			`1.25`: false,
+			`"5"`:  false,
+		} {
+			value := &model.PropertyValue{
+				GroupID:    group.ID,
+				FieldID:    field.ID,
+				TargetID:   model.NewId(),
+				TargetType: "user",
+				Value:      json.RawMessage(raw),
+			}
+			_, upsertErr := th.service.UpsertPropertyValue(th.Context, value)
+			if valid {
+				assert.NoError(t, upsertErr, raw)
+			} else {
+				assert.ErrorIs(t, upsertErr, ErrInvalidValue, raw)
+			}
+		}
+	})
+
+	t.Run("url and checkbox — validate value shape", func(t *testing.T) {
+		urlField := th.CreatePropertyFieldDirect(t, &model.PropertyField{
+			GroupID:    group.ID,
+			Name:       "url_field_" + model.NewId(),
+			Type:       model.PropertyFieldTypeURL,
+			TargetType: "system",
+			ObjectType: "user",
+		})
+		checkboxField := th.CreatePropertyFieldDirect(t, &model.PropertyField{
+			GroupID:    group.ID,
+			Name:       "checkbox_field_" + model.NewId(),
+			Type:       model.PropertyFieldTypeCheckbox,
+			TargetType: "system",
+			ObjectType: "user",
+		})
+
+		for _, tc := range []struct {
+			fieldID string
+			raw     string
+			valid   bool
+		}{
+			{urlField.ID, `"https://example.com"`, true},
+			{urlField.ID, `""`, true},
+			{urlField.ID, `"not a url"`, false},
+			{checkboxField.ID, `true`, true},
+			{checkboxField.ID, `"yes"`, false},
+		} {
+			value := &model.PropertyValue{
+				GroupID:    group.ID,
+				FieldID:    tc.fieldID,
+				TargetID:   model.NewId(),
+				TargetType: "user",
+				Value:      json.RawMessage(tc.raw),
+			}
+			_, upsertErr := th.service.UpsertPropertyValue(th.Context, value)
+			if tc.valid {
+				assert.NoError(t, upsertErr, tc.raw)
+			} else {
+				assert.ErrorIs(t, upsertErr, ErrInvalidValue, tc.raw)
+			}
+		}
+	})
+
 	t.Run("sanitizeAndValidateOptions writes back canonical []any of map[string]any", func(t *testing.T) {
 		// Downstream readers (asOptionSlice, EnsureOptionIDs, store-layer
 		// serialization) expect the canonical loose-typed shape. Writing back
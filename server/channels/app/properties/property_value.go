@@ -13,11 +13,12 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 )
 
-// rejectTemplateValues checks that none of the given values target a template
-// field. Template fields are definition-only and must never hold values.
-// This is enforced at the service layer to cover all entry points (API,
-// CPA endpoints, plugin API).
-func (ps *PropertyService) rejectTemplateValues(values []*model.PropertyValue) error {
+// validateValuesAgainstFields checks that none of the given values target a
+// template field, and that the values of number, url and checkbox fields are
+// valid for their field. Template fields are definition-only and must never
+// hold values. This is enforced at the service layer to cover all entry
+// points (API, CPA endpoints, plugin API).
+func (ps *PropertyService) validateValuesAgainstFields(values []*model.PropertyValue) error {
 	// Collect unique field IDs
 	seen := make(map[string]struct{}, len(values))
 	for _, v := range values {
@@ -38,9 +39,10 @@ func (ps *PropertyService) rejectTemplateValues(values []*model.PropertyValue) e
 	// Batch lookup from master to avoid replication lag
 	fields, err := ps.fieldStore.GetMany(store.WithMaster(context.Background()), "", fieldIDs)
 	if err != nil {
-		return fmt.Errorf("failed to look up fields for template check: %w", err)
+		return fmt.Errorf("failed to look up fields for value validation: %w", err)
 	}
 
+	fieldMap := make(map[string]*model.PropertyField, len(fields))
 	for _, field := range fields {
 		if field.ObjectType == model.PropertyFieldObjectTypeTemplate {
 			return model.NewAppError(
@@ -51,6 +53,20 @@ func (ps *PropertyService) rejectTemplateValues(values []*model.PropertyValue) e
 				http.StatusBadRequest,
 			)
 		}
+		fieldMap[field.ID] = field
+	}
+
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		field, ok := fieldMap[value.FieldID]
+		if !ok {
+			continue
+		}
+		if err := model.ValidatePropertyValueForFieldType(field, value.Value); err != nil {
+			return fmt.Errorf("field %s: %s: %w", value.FieldID, err.Error(), ErrInvalidValue)
+		}
 	}
 	return nil
 }
@@ -58,14 +74,14 @@ func (ps *PropertyService) rejectTemplateValues(values []*model.PropertyValue) e
 // Private implementation methods (database access)
 
 func (ps *PropertyService) createPropertyValue(value *model.PropertyValue) (*model.PropertyValue, error) {
-	if err := ps.rejectTemplateValues([]*model.PropertyValue{value}); err != nil {
+	if err := ps.validateValuesAgainstFields([]*model.PropertyValue{value}); err != nil {
 		return nil, err
 	}
 	return ps.valueStore.Create(value)
 }
 
 func (ps *PropertyService) createPropertyValues(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
-	if err := ps.rejectTemplateValues(values); err != nil {
+	if err := ps.validateValuesAgainstFields(values); err != nil {
 		return nil, err
 	}
 	return ps.valueStore.CreateMany(values)
@@ -96,7 +112,7 @@ func (ps *PropertyService) updatePropertyValue(groupID string, value *model.Prop
 }
 
 func (ps *PropertyService) updatePropertyValues(groupID string, values []*model.PropertyValue) ([]*model.PropertyValue, error) {
-	if err := ps.rejectTemplateValues(values); err != nil {
+	if err := ps.validateValuesAgainstFields(values); err != nil {
 		return nil, err
 	}
 	return ps.valueStore.Update(groupID, values)
@@ -112,7 +128,7 @@ func (ps *PropertyService) upsertPropertyValue(value *model.PropertyValue) (*mod
 }
 
 func (ps *PropertyService) upsertPropertyValues(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
-	if err := ps.rejectTemplateValues(values); err != nil {
+	if err := ps.validateValuesAgainstFields(values); err != nil {
 		return nil, err
 	}
 	return ps.valueStore.Upsert(values)
@@ -4,6 +4,8 @@
 package properties
 
 import (
+	"slices"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
@@ -15,6 +17,9 @@ import (
 // matching select field), so leaving values behind across a type change leaves
 // the field functionally broken until callers manually reset the values.
 //
+// The only exceptions are the type changes listed in
+// valuePreservingTypeChanges, which every stored value survives as is.
+//
 // The hook runs in PostUpdatePropertyFields. Earlier hooks
 // (linked-property checks at the store layer) already reject the type-change
 // cases that would corrupt linked state, so by the time this hook runs the
@@ -29,6 +34,13 @@ type TypeChangeValueCleanupHook struct {
 
 var _ PropertyHook = (*TypeChangeValueCleanupHook)(nil)
 
+// valuePreservingTypeChanges maps a field type to the types it can be changed
+// to without clearing its values: url values are plain JSON strings, which
+// text fields accept unchanged.
+var valuePreservingTypeChanges = map[model.PropertyFieldType][]model.PropertyFieldType{
+	model.PropertyFieldTypeURL: {model.PropertyFieldTypeText},
+}
+
 // NewTypeChangeValueCleanupHook constructs the hook. The PropertyService
 // reference is used to delete dependent values via the unexported
 // deletePropertyValuesForField path so the hook does not re-enter the public
@@ -47,7 +59,7 @@ func (h *TypeChangeValueCleanupHook) PostUpdatePropertyFields(rctx request.CTX,
 		if i >= len(prev) || prev[i] == nil || u == nil {
 			continue
 		}
-		if prev[i].Type == u.Type {
+		if prev[i].Type == u.Type || slices.Contains(valuePreservingTypeChanges[prev[i].Type], u.Type) {
 			continue
 		}
 		if err := h.propertyService.deletePropertyValuesForField(groupID, u.ID); err != nil {
@@ -165,6 +165,74 @@ func TestTypeChangeValueCleanupHook(t *testing.T) {
 		assert.Len(t, values, 1, "value must survive a rename")
 	})
 
+	t.Run("url to text change keeps values", func(t *testing.T) {
+		field := &model.PropertyField{
+			GroupID:    th.CPAGroupID,
+			Name:       "url-field-" + model.NewId(),
+			Type:       model.PropertyFieldTypeURL,
+			ObjectType: model.PropertyFieldObjectTypeUser,
+			TargetType: string(model.PropertyFieldTargetLevelSystem),
+		}
+		created, err := th.service.CreatePropertyField(th.Context, field)
+		require.NoError(t, err)
+
+		raw, err := json.Marshal("https://example.com")
+		require.NoError(t, err)
+		_, err = th.service.UpsertPropertyValue(th.Context, &model.PropertyValue{
+			GroupID:    th.CPAGroupID,
+			FieldID:    created.ID,
+			TargetID:   model.NewId(),
+			TargetType: model.PropertyValueTargetTypeUser,
+			Value:      raw,
+		})
+		require.NoError(t, err)
+
+		created.Type = model.PropertyFieldTypeText
+		_, clearedIDs, err := th.service.UpdatePropertyField(th.Context, th.CPAGroupID, created)
+		require.NoError(t, err)
+		assert.Empty(t, clearedIDs, "url values are valid text values and must be kept")
+
+		values, err := th.service.SearchPropertyValues(th.Context, th.CPAGroupID, model.PropertyValueSearchOpts{
+			FieldID: created.ID,
+			PerPage: 10,
+		})
+		require.NoError(t, err)
+		assert.Len(t, values, 1, "value must survive the type change")
+	})
+
+	t.Run("number to text change deletes values", func(t *testing.T) {
+		field := &model.PropertyField{
+			GroupID:    th.CPAGroupID,
+			Name:       "number-field-" + model.NewId(),
+			Type:       model.PropertyFieldTypeNumber,
+			ObjectType: model.PropertyFieldObjectTypeUser,
+			TargetType: string(model.PropertyFieldTargetLevelSystem),
+		}
+		created, err := th.service.CreatePropertyField(th.Context, field)
+		require.NoError(t, err)
+
+		_, err = th.service.UpsertPropertyValue(th.Context, &model.PropertyValue{
+			GroupID:    th.CPAGroupID,
+			FieldID:    created.ID,
+			TargetID:   model.NewId(),
+			TargetType: model.PropertyValueTargetTypeUser,
+			Value:      json.RawMessage(`42`),
+		})
+		require.NoError(t, err)
+
+		created.Type = model.PropertyFieldTypeText
+		_, clearedIDs, err := th.service.UpdatePropertyField(th.Context, th.CPAGroupID, created)
+		require.NoError(t, err)
+		assert.Equal(t, []string{created.ID}, clearedIDs)
+
+		values, err := th.service.SearchPropertyValues(th.Context, th.CPAGroupID, model.PropertyValueSearchOpts{
+			FieldID: created.ID,
+			PerPage: 10,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, values, "expected dependent values to be cleared")
+	})
+
 	t.Run("plural batch reports cleared ids per affected field", func(t *testing.T) {
 		// Field 1: select with a value, will be patched to text → cleanup expected.
 		optID := model.NewId()
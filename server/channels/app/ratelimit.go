@@ -18,22 +18,56 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
 )
 
+// RateLimitClass identifies a group of routes sharing a dedicated quota, which is
+// enforced on top of the global quota configured in RateLimitSettings.
+type RateLimitClass string
+
+const (
+	RateLimitClassLogin      RateLimitClass = "login"
+	RateLimitClassPostCreate RateLimitClass = "post_create"
+	RateLimitClassFileUpload RateLimitClass = "file_upload"
+	RateLimitClassSearch     RateLimitClass = "search"
+)
+
+// rateLimitClassQuotas returns the quota of each route class as configured in the settings.
+func rateLimitClassQuotas(settings *model.RateLimitSettings) map[RateLimitClass]throttled.RateQuota {
+	quota := func(q *model.RateLimitQuota) throttled.RateQuota {
+		return throttled.RateQuota{MaxRate: throttled.PerSec(*q.PerSec), MaxBurst: *q.MaxBurst}
+	}
+
+	return map[RateLimitClass]throttled.RateQuota{
+		RateLimitClassLogin:      quota(settings.LoginQuota),
+		RateLimitClassPostCreate: quota(settings.PostCreateQuota),
+		RateLimitClassFileUpload: quota(settings.FileUploadQuota),
+		RateLimitClassSearch:     quota(settings.SearchQuota),
+	}
+}
+
 type RateLimiter struct {
 	throttledRateLimiter *throttled.GCRARateLimiterCtx
+	classRateLimiters    map[RateLimitClass]*throttled.GCRARateLimiterCtx
 	useAuth              bool
 	useIP                bool
 	header               string
 	trustedProxyIPHeader []string
 }
 
+// NewRateLimiter creates a rate limiter keeping its state in the memory of the current node.
 func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []string) (*RateLimiter, error) {
 	store, err := memstore.NewCtx(*settings.MemoryStoreSize)
 	if err != nil {
 		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_memory_store"))
 	}
 
+	return NewRateLimiterWithStore(store, settings, trustedProxyIPHeader)
+}
+
+// NewRateLimiterWithStore creates a rate limiter keeping its state in the given store. When the
+// store is shared between nodes, e.g. backed by Redis, quotas are enforced cluster-wide.
+func NewRateLimiterWithStore(store throttled.GCRAStoreCtx, settings *model.RateLimitSettings, trustedProxyIPHeader []string) (*RateLimiter, error) {
 	quota := throttled.RateQuota{
 		MaxRate:  throttled.PerSec(*settings.PerSec),
 		MaxBurst: *settings.MaxBurst,
@@ -44,8 +78,19 @@ func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []st
 		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
 	}
 
+	classQuotas := rateLimitClassQuotas(settings)
+	classRateLimiters := make(map[RateLimitClass]*throttled.GCRARateLimiterCtx, len(classQuotas))
+	for class, classQuota := range classQuotas {
+		classRateLimiter, err := throttled.NewGCRARateLimiterCtx(store, classQuota)
+		if err != nil {
+			return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
+		}
+		classRateLimiters[class] = classRateLimiter
+	}
+
 	return &RateLimiter{
 		throttledRateLimiter: throttledRateLimiter,
+		classRateLimiters:    classRateLimiters,
 		useAuth:              *settings.VaryByUser,
 		useIP:                *settings.VaryByRemoteAddr,
 		header:               settings.VaryByHeader,
@@ -53,6 +98,29 @@ func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []st
 	}, nil
 }
 
+// newRateLimiter creates the server rate limiter, sharing its state through the cache
+// provider when configured to use the redis store.
+func (s *Server) newRateLimiter() (*RateLimiter, error) {
+	settings := &s.platform.Config().RateLimitSettings
+	trustedProxyIPHeader := s.platform.Config().ServiceSettings.TrustedProxyIPHeader
+
+	if *settings.StoreType != model.RateLimitStoreTypeRedis {
+		return NewRateLimiter(settings, trustedProxyIPHeader)
+	}
+
+	provider, ok := s.platform.CacheProvider().(cache.RateLimitStoreProvider)
+	if !ok {
+		return nil, errors.New(i18n.T("api.server.start_server.rate_limiting_redis_store"))
+	}
+
+	store, err := provider.NewRateLimitStore("ratelimit")
+	if err != nil {
+		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_redis_store"))
+	}
+
+	return NewRateLimiterWithStore(store, settings, trustedProxyIPHeader)
+}
+
 func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 	key := ""
 
@@ -77,7 +145,29 @@ func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 }
 
 func (rl *RateLimiter) RateLimitWriter(ctx context.Context, key string, w http.ResponseWriter) bool {
-	limited, context, err := rl.throttledRateLimiter.RateLimitCtx(ctx, key, 1)
+	return rateLimitWriter(ctx, rl.throttledRateLimiter, key, w)
+}
+
+// ClassRateLimit applies the quota of the given route class to the request. Requests are keyed by
+// user when authenticated and by IP address otherwise, regardless of the VaryBy settings, so that
+// every user gets their own bucket for the class.
+func (rl *RateLimiter) ClassRateLimit(ctx context.Context, class RateLimitClass, userID string, w http.ResponseWriter, r *http.Request) bool {
+	classRateLimiter, ok := rl.classRateLimiters[class]
+	if !ok {
+		mlog.Warn("Unknown rate limit class, skipping", mlog.String("class", string(class)))
+		return false
+	}
+
+	key := userID
+	if key == "" {
+		key = utils.GetIPAddress(r, rl.trustedProxyIPHeader)
+	}
+
+	return rateLimitWriter(ctx, classRateLimiter, string(class)+":"+key, w)
+}
+
+func rateLimitWriter(ctx context.Context, rateLimiter *throttled.GCRARateLimiterCtx, key string, w http.ResponseWriter) bool {
+	limited, context, err := rateLimiter.RateLimitCtx(ctx, key, 1)
 	if err != nil {
 		mlog.Error("Internal server error when rate limiting. Rate Limiting broken.", mlog.Err(err))
 		return false
@@ -110,23 +200,24 @@ func (rl *RateLimiter) RateLimitHandler(wrappedHandler http.Handler) http.Handle
 	})
 }
 
-// Copied from https://github.com/throttled/throttled http.go
+// Copied from https://github.com/throttled/throttled http.go, setting rather than adding the
+// headers so that a route class quota overrides the values of the global quota.
 func setRateLimitHeaders(w http.ResponseWriter, context throttled.RateLimitResult) {
 	if v := context.Limit; v >= 0 {
-		w.Header().Add("X-RateLimit-Limit", strconv.Itoa(v))
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(v))
 	}
 
 	if v := context.Remaining; v >= 0 {
-		w.Header().Add("X-RateLimit-Remaining", strconv.Itoa(v))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(v))
 	}
 
 	if v := context.ResetAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
-		w.Header().Add("X-RateLimit-Reset", strconv.Itoa(vi))
+		w.Header().Set("X-RateLimit-Reset", strconv.Itoa(vi))
 	}
 
 	if v := context.RetryAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
-		w.Header().Add("Retry-After", strconv.Itoa(vi))
+		w.Header().Set("Retry-After", strconv.Itoa(vi))
 	}
 }
@@ -13,12 +13,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled/v2/store/memstore"
 
 	"github.com/mattermost/mattermost/server/public/model"
 )
 
 func genRateLimitSettings(useAuth, useIP bool, header string) *model.RateLimitSettings {
-	return &model.RateLimitSettings{
+	settings := &model.RateLimitSettings{
 		Enable:           new(true),
 		PerSec:           new(10),
 		MaxBurst:         new(100),
@@ -27,6 +28,8 @@ func genRateLimitSettings(useAuth, useIP bool, header string) *model.RateLimitSe
 		VaryByUser:       new(useAuth),
 		VaryByHeader:     header,
 	}
+	settings.SetDefaults()
+	return settings
 }
 
 func TestNewRateLimiterSuccess(t *testing.T) {
@@ -112,7 +115,7 @@ func TestGenerateKey_TrustedHeader(t *testing.T) {
 }
 
 func genRateLimitSettingsWithBurst(useAuth, useIP bool, header string, perSec, maxBurst int) *model.RateLimitSettings {
-	return &model.RateLimitSettings{
+	settings := &model.RateLimitSettings{
 		Enable:           new(true),
 		PerSec:           new(perSec),
 		MaxBurst:         new(maxBurst),
@@ -121,6 +124,8 @@ func genRateLimitSettingsWithBurst(useAuth, useIP bool, header string, perSec, m
 		VaryByUser:       new(useAuth),
 		VaryByHeader:     header,
 	}
+	settings.SetDefaults()
+	return settings
 }
 
 func TestRateLimitWriter(t *testing.T) {
@@ -259,3 +264,98 @@ func TestRateLimitHandler(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 }
+
+func TestClassRateLimit(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	settings := genRateLimitSettingsWithBurst(false, true, "", 100, 100)
+	settings.SearchQuota = &model.RateLimitQuota{PerSec: new(1), MaxBurst: new(3)}
+	rl, err := NewRateLimiter(settings, nil)
+	require.NoError(t, err)
+
+	// The search class allows MaxBurst + 1 requests before limiting.
+	limit := *settings.SearchQuota.MaxBurst + 1
+
+	t.Run("authenticated requests are limited per user", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", nil)
+		for i := range limit {
+			w := httptest.NewRecorder()
+			limited := rl.ClassRateLimit(context.Background(), RateLimitClassSearch, "user-A", w, req)
+			require.False(t, limited, "request %d should not be rate limited", i)
+			assert.Equal(t, strconv.Itoa(limit), w.Header().Get("X-RateLimit-Limit"))
+		}
+
+		w := httptest.NewRecorder()
+		limited := rl.ClassRateLimit(context.Background(), RateLimitClassSearch, "user-A", w, req)
+		require.True(t, limited)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+		w = httptest.NewRecorder()
+		limited = rl.ClassRateLimit(context.Background(), RateLimitClassSearch, "user-B", w, req)
+		require.False(t, limited)
+	})
+
+	t.Run("classes use independent buckets", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", nil)
+		w := httptest.NewRecorder()
+		limited := rl.ClassRateLimit(context.Background(), RateLimitClassPostCreate, "user-A", w, req)
+		require.False(t, limited)
+	})
+
+	t.Run("anonymous requests are limited per IP", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", nil)
+		req.RemoteAddr = "192.168.1.1:1234"
+		for i := range limit {
+			w := httptest.NewRecorder()
+			limited := rl.ClassRateLimit(context.Background(), RateLimitClassSearch, "", w, req)
+			require.False(t, limited, "request %d should not be rate limited", i)
+		}
+
+		w := httptest.NewRecorder()
+		limited := rl.ClassRateLimit(context.Background(), RateLimitClassSearch, "", w, req)
+		require.True(t, limited)
+
+		req.RemoteAddr = "10.0.0.1:1234"
+		w = httptest.NewRecorder()
+		limited = rl.ClassRateLimit(context.Background(), RateLimitClassSearch, "", w, req)
+		require.False(t, limited)
+	})
+
+	t.Run("unknown class is never limited", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", nil)
+		w := httptest.NewRecorder()
+		limited := rl.ClassRateLimit(context.Background(), RateLimitClass("unknown"), "user-A", w, req)
+		require.False(t, limited)
+	})
+}
+
+func TestNewRateLimiterInvalidClassQuota(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	settings := genRateLimitSettings(false, false, "")
+	settings.LoginQuota.MaxBurst = new(-1)
+	rateLimiter, err := NewRateLimiter(settings, nil)
+	require.Nil(t, rateLimiter)
+	require.Error(t, err)
+}
+
+func TestNewRateLimiterWithSharedStore(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	// Two rate limiters sharing a store behave like two nodes of a cluster
+	// backed by the same Redis instance: the quota is enforced across both.
+	store, err := memstore.NewCtx(100)
+	require.NoError(t, err)
+
+	// PerSec=1, MaxBurst=1 → effective limit of 2
+	settings := genRateLimitSettingsWithBurst(false, false, "", 1, 1)
+	node1, err := NewRateLimiterWithStore(store, settings, nil)
+	require.NoError(t, err)
+	node2, err := NewRateLimiterWithStore(store, settings, nil)
+	require.NoError(t, err)
+
+	require.False(t, node1.RateLimitWriter(context.Background(), "shared-key", httptest.NewRecorder()))
+	require.False(t, node2.RateLimitWriter(context.Background(), "shared-key", httptest.NewRecorder()))
+	require.True(t, node1.RateLimitWriter(context.Background(), "shared-key", httptest.NewRecorder()))
+	require.True(t, node2.RateLimitWriter(context.Background(), "shared-key", httptest.NewRecorder()))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/email"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
 )
 
@@ -125,9 +126,9 @@ func (s *Server) DoSecurityUpdateCheck() {
 
 					for _, user := range users {
 						mlog.Info("Sending security bulletin", mlog.String("bulletin_id", bulletin.Id), mlog.String("user_email", user.Email))
-						license := s.License()
 						mailConfig := s.MailServiceConfig()
-						err = mail.SendMailUsingConfig(user.Email, i18n.T("mattermost.bulletin.subject"), string(body), mailConfig, license != nil && *license.Features.Compliance, "", "", "", "", "SecurityUpdateCheck")
+						msg := mail.NewMessage(mailConfig, user.Email, i18n.T("mattermost.bulletin.subject"), string(body), "SecurityUpdateCheck")
+						err = email.NewMailTransport(s.platform.Config(), mailConfig).Send(msg)
 						if err != nil {
 							s.Log().Error("Failed to send security bulletin email", mlog.String("user_email", user.Email), mlog.Err(err))
 						}
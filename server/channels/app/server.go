@@ -46,11 +46,14 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_empty_drafts_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_expired_posts"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_orphan_drafts_migration"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/email_batching"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/expirynotify"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_delete"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_process"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_users_to_csv"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/extract_content"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/file_deduplication"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/file_scan"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/hosted_purchase_screening"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/import_delete"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/import_process"
@@ -59,6 +62,8 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/migrations"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/mobile_session_metadata"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/notify_admin"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/outgoing_email_queue"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/outgoing_webhook_retry"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugins"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/post_persistent_notifications"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/product_notices"
@@ -114,6 +119,7 @@ type Server struct {
 	PushNotificationsHub   PushNotificationsHub
 	pushNotificationClient *http.Client // TODO: move this to it's own package
 	outgoingWebhookClient  *http.Client
+	webPushClient          *http.Client
 
 	runEssentialJobs bool
 	Jobs             *jobs.JobServer
@@ -403,6 +409,8 @@ func NewServer(options ...Option) (*Server, error) {
 
 	s.pushNotificationClient = s.httpService.MakeClient(true)
 	s.outgoingWebhookClient = s.httpService.MakeClient(false)
+	// Web Push endpoints are provided by clients, so they must not reach internal addresses.
+	s.webPushClient = s.httpService.MakeClient(false)
 
 	if err2 := utils.TranslationsPreInit(); err2 != nil {
 		return nil, errors.Wrapf(err2, "unable to load Mattermost translation files")
@@ -492,11 +500,6 @@ func NewServer(options ...Option) (*Server, error) {
 		}
 	}
 
-	// Start email batching because it's not like the other jobs
-	s.platform.AddConfigListener(func(_, _ *model.Config) {
-		s.EmailService.InitEmailBatching()
-	})
-
 	pwd, _ := os.Getwd()
 	mlog.Info("Printing current working", mlog.String("directory", pwd))
 	mlog.Info("Loaded config", mlog.String("source", s.platform.DescribeConfig()))
@@ -598,6 +601,7 @@ func (s *Server) runJobs() {
 		runDNDStatusExpireJob(appInstance)
 		runPostReminderJob(appInstance)
 		runScheduledPostJob(appInstance)
+		runPendingFileScansJob(appInstance)
 	})
 	s.Go(func() {
 		runSecurityJob(s)
@@ -617,6 +621,9 @@ func (s *Server) runJobs() {
 	s.Go(func() {
 		runConfigCleanupJob(s)
 	})
+	s.Go(func() {
+		runFileBlobCleanupJob(s)
+	})
 	s.Go(func() {
 		runCloudUserCountReportJob(s)
 	})
@@ -795,10 +802,6 @@ func (s *Server) Shutdown() {
 		s.Log().Warn("Failed to stop metrics server", mlog.Err(err))
 	}
 
-	// Stopping email service after HTTP server has stopped to prevent
-	// any stray notifications from being queued.
-	s.EmailService.Stop()
-
 	// This must be done after the cluster is stopped.
 	if s.Jobs != nil {
 		// For simplicity we don't check if workers and schedulers are active
@@ -950,7 +953,7 @@ func (s *Server) Start() error {
 		mlog.Error("Error to reset the server status.", mlog.Err(err))
 	}
 
-	if s.MailServiceConfig().SendEmailNotifications {
+	if s.MailServiceConfig().SendEmailNotifications && *s.platform.Config().EmailSettings.MailTransport == model.MailTransportSMTP {
 		if err := mail.TestConnection(s.MailServiceConfig()); err != nil {
 			mlog.Error("Mail server connection test failed", mlog.Err(err))
 		}
@@ -1036,7 +1039,7 @@ func (s *Server) Start() error {
 	if *s.platform.Config().RateLimitSettings.Enable {
 		mlog.Info("RateLimiter is enabled")
 
-		rateLimiter, err2 := NewRateLimiter(&s.platform.Config().RateLimitSettings, s.platform.Config().ServiceSettings.TrustedProxyIPHeader)
+		rateLimiter, err2 := s.newRateLimiter()
 		if err2 != nil {
 			return err2
 		}
@@ -1326,6 +1329,13 @@ func runConfigCleanupJob(s *Server) {
 	}, time.Hour*24)
 }
 
+func runFileBlobCleanupJob(s *Server) {
+	doFileBlobCleanup(s)
+	model.CreateRecurringTask("File Blob Cleanup", func() {
+		doFileBlobCleanup(s)
+	}, time.Hour*1)
+}
+
 func (s *Server) runLicenseExpirationCheckJob() {
 	s.doLicenseExpirationCheck()
 	model.CreateRecurringTask("License Expiration Check", func() {
@@ -1376,6 +1386,13 @@ func doTokenCleanup(s *Server) {
 	s.Store().Token().Cleanup(expiry)
 }
 
+// doFileBlobCleanup removes the blobs left without files, such as those of the files deleted
+// by the data retention policies.
+func doFileBlobCleanup(s *Server) {
+	mlog.Debug("Cleaning up file blobs.")
+	s.removeUnreferencedFileBlobs(request.EmptyContext(s.Log()))
+}
+
 func doCommandWebhookCleanup(s *Server) {
 	s.Store().CommandWebhook().Cleanup()
 }
@@ -1692,6 +1709,18 @@ func (s *Server) initJobs() {
 		nil,
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeFileScan,
+		file_scan.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())), s.Store()),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeFileDeduplication,
+		file_deduplication.MakeWorker(s.Jobs, New(ServerConnector(s.Channels())), s.Store()),
+		nil,
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeLastAccessiblePost,
 		last_accessible_post.MakeWorker(s.Jobs, s.License(), New(ServerConnector(s.Channels()))),
@@ -1775,6 +1804,24 @@ func (s *Server) initJobs() {
 		delete_expired_posts.MakeScheduler(s.Jobs),
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeOutgoingWebhookRetry,
+		outgoing_webhook_retry.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		outgoing_webhook_retry.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeEmailBatching,
+		email_batching.MakeWorker(s.Jobs, s.Store(), s.EmailService),
+		email_batching.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeOutgoingEmailQueue,
+		outgoing_email_queue.MakeWorker(s.Jobs, s.EmailService),
+		outgoing_email_queue.MakeScheduler(s.Jobs),
+	)
+
 	s.platform.Jobs = s.Jobs
 }
 
@@ -1994,6 +2041,37 @@ func doRunScheduledPostJob(a *App) {
 	})
 }
 
+func runPendingFileScansJob(a *App) {
+	if a.IsLeader() {
+		doRunPendingFileScansJob(a)
+	} else {
+		mlog.Debug("Skipping pending file scans job startup since this is not the leader node")
+	}
+
+	a.ch.srv.AddClusterLeaderChangedListener(func() {
+		mlog.Info("Cluster leader changed. Determining if pending file scans task should be running", mlog.Bool("isLeader", a.IsLeader()))
+		if a.IsLeader() {
+			doRunPendingFileScansJob(a)
+		} else {
+			mlog.Debug("This is no longer leader node. Cancelling the pending file scans task", mlog.Bool("isLeader", a.IsLeader()))
+			cancelTask(&a.ch.pendingFileScansMut, &a.ch.pendingFileScansTask)
+		}
+	})
+}
+
+// doRunPendingFileScansJob starts the task scanning the files left pending, for instance by a
+// restart. It runs on the leader node so that each file is scanned once.
+func doRunPendingFileScansJob(a *App) {
+	rctx := request.EmptyContext(a.Log())
+	withMut(&a.ch.pendingFileScansMut, func() {
+		if a.ch.pendingFileScansTask != nil {
+			a.ch.pendingFileScansTask.Cancel()
+		}
+		fn := func() { a.RescanPendingFiles(rctx) }
+		a.ch.pendingFileScansTask = model.CreateRecurringTaskFromNextIntervalTime("Scan Pending Files", fn, pendingFileScansInterval)
+	})
+}
+
 func (a *App) GetAppliedSchemaMigrations() ([]model.AppliedMigration, *model.AppError) {
 	table, err := a.Srv().Store().GetAppliedMigrations()
 	if err != nil {
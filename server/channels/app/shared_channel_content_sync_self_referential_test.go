@@ -0,0 +1,256 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/services/sharedchannel"
+)
+
+// TestSharedChannelContentSyncSelfReferential tests that the channel header/purpose, bookmarks
+// and board property values of a shared channel are sent to a remote, using a server that
+// syncs with itself over real HTTP.
+func TestSharedChannelContentSyncSelfReferential(t *testing.T) {
+	th := setupSharedChannels(t).InitBasic(t)
+
+	ss := th.App.Srv().Store()
+
+	// Get the shared channel service and cast to concrete type
+	scsInterface := th.App.Srv().GetSharedChannelSyncService()
+	service, ok := scsInterface.(*sharedchannel.Service)
+	require.True(t, ok, "Expected sharedchannel.Service concrete type")
+
+	err := service.Start()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return service.Active()
+	}, 5*time.Second, 100*time.Millisecond, "SharedChannelService should be active")
+
+	rcService := th.App.Srv().GetRemoteClusterService()
+	if rcService != nil {
+		_ = rcService.Start()
+		require.Eventually(t, func() bool {
+			return rcService.Active()
+		}, 5*time.Second, 100*time.Millisecond, "RemoteClusterService should be active")
+	}
+
+	// contentSync collects the content sync messages received by the self-referential remote.
+	type contentSync struct {
+		mu       sync.Mutex
+		messages []*model.SyncMsg
+	}
+
+	// setupSharedChannel shares a new channel with a self-referential remote cluster and returns
+	// the channel, the shared channel remote and the collected content sync messages.
+	setupSharedChannel := func(t *testing.T) (*model.Channel, *model.SharedChannelRemote, *contentSync) {
+		t.Helper()
+
+		received := &contentSync{}
+		var syncHandler *SelfReferentialSyncHandler
+		testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if syncHandler != nil {
+				syncHandler.HandleRequest(w, r)
+			} else {
+				writeOKResponse(w)
+			}
+		}))
+		t.Cleanup(testServer.Close)
+
+		channel := th.CreateChannel(t, th.BasicTeam)
+		_, shareErr := th.App.ShareChannel(th.Context, &model.SharedChannel{
+			ChannelId: channel.Id,
+			TeamId:    th.BasicTeam.Id,
+			Home:      true,
+			ShareName: "content-sync-test",
+			CreatorId: th.BasicUser.Id,
+		})
+		require.NoError(t, shareErr)
+
+		selfCluster, err := ss.RemoteCluster().Save(&model.RemoteCluster{
+			RemoteId:    model.NewId(),
+			Name:        "self-cluster",
+			SiteURL:     testServer.URL,
+			CreateAt:    model.GetMillis(),
+			LastPingAt:  model.GetMillis(),
+			Token:       model.NewId(),
+			CreatorId:   th.BasicUser.Id,
+			RemoteToken: model.NewId(),
+		})
+		require.NoError(t, err)
+
+		syncHandler = NewSelfReferentialSyncHandler(t, service, selfCluster)
+		syncHandler.OnContentSync = func(syncMsg *model.SyncMsg, _ int32) {
+			received.mu.Lock()
+			defer received.mu.Unlock()
+			received.messages = append(received.messages, syncMsg)
+		}
+
+		// Start the post cursors now so that only the channel content is of interest.
+		now := model.GetMillis()
+		scr, err := ss.SharedChannel().SaveRemote(&model.SharedChannelRemote{
+			Id:                model.NewId(),
+			ChannelId:         channel.Id,
+			CreatorId:         th.BasicUser.Id,
+			RemoteId:          selfCluster.RemoteId,
+			IsInviteAccepted:  true,
+			IsInviteConfirmed: true,
+			LastPostCreateAt:  now,
+			LastPostUpdateAt:  now,
+		})
+		require.NoError(t, err)
+
+		return channel, scr, received
+	}
+
+	waitForSync := func(t *testing.T) {
+		t.Helper()
+		require.Eventually(t, func() bool {
+			return !service.HasPendingTasksForTesting()
+		}, 15*time.Second, 200*time.Millisecond, "All async sync tasks should be completed")
+	}
+
+	t.Run("Test 1: Channel header and purpose changes are synced", func(t *testing.T) {
+		EnsureCleanState(t, th, ss)
+		channel, scr, received := setupSharedChannel(t)
+
+		patched, appErr := th.App.PatchChannel(th.Context, channel, &model.ChannelPatch{
+			Header:  new("synced header"),
+			Purpose: new("synced purpose"),
+		}, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		require.Eventually(t, func() bool {
+			received.mu.Lock()
+			defer received.mu.Unlock()
+			for _, msg := range received.messages {
+				if msg.ChannelInfo != nil && msg.ChannelInfo.Header == "synced header" && msg.ChannelInfo.Purpose == "synced purpose" {
+					return true
+				}
+			}
+			return false
+		}, 15*time.Second, 200*time.Millisecond, "Should have received the channel header and purpose")
+		waitForSync(t)
+
+		updated, err := ss.SharedChannel().GetRemote(scr.Id)
+		require.NoError(t, err)
+		assert.Equal(t, patched.UpdateAt, updated.LastChannelUpdateAt)
+	})
+
+	t.Run("Test 2: Link bookmarks are synced", func(t *testing.T) {
+		EnsureCleanState(t, th, ss)
+		channel, scr, received := setupSharedChannel(t)
+
+		bookmark, appErr := th.App.CreateChannelBookmark(th.Context, &model.ChannelBookmark{
+			ChannelId:   channel.Id,
+			OwnerId:     th.BasicUser.Id,
+			DisplayName: "docs",
+			LinkUrl:     "https://example.com/docs",
+			Type:        model.ChannelBookmarkLink,
+		}, "")
+		require.Nil(t, appErr)
+
+		require.Eventually(t, func() bool {
+			received.mu.Lock()
+			defer received.mu.Unlock()
+			for _, msg := range received.messages {
+				for _, b := range msg.Bookmarks {
+					if b.Id == bookmark.Id && b.LinkUrl == "https://example.com/docs" {
+						return true
+					}
+				}
+			}
+			return false
+		}, 15*time.Second, 200*time.Millisecond, "Should have received the bookmark")
+		waitForSync(t)
+
+		updated, err := ss.SharedChannel().GetRemote(scr.Id)
+		require.NoError(t, err)
+		assert.Equal(t, bookmark.UpdateAt, updated.LastBookmarkUpdateAt)
+	})
+
+	t.Run("Test 3: Property values changed at the same time are all synced across batches", func(t *testing.T) {
+		EnsureCleanState(t, th, ss)
+		channel, scr, received := setupSharedChannel(t)
+
+		group, appErr := th.App.RegisterPropertyGroup(th.Context, &model.PropertyGroup{Name: model.BoardsPropertyGroupName, Version: model.PropertyGroupVersionV2})
+		require.Nil(t, appErr)
+		field, err := ss.PropertyField().Create(&model.PropertyField{
+			GroupID:    group.ID,
+			Name:       "Estimate",
+			Type:       model.PropertyFieldTypeText,
+			TargetType: "channel",
+			TargetID:   channel.Id,
+		})
+		require.NoError(t, err)
+
+		// More values than fit in one batch, all changed at the same time.
+		changeAt := model.GetMillis()
+		count := sharedchannel.MaxPropertyValuesPerSync + 5
+		values := make([]*model.PropertyValue, 0, count)
+		postIDs := make([]string, 0, count)
+		for i := range count {
+			post, postErr := ss.Post().Save(th.Context, &model.Post{
+				ChannelId: channel.Id,
+				UserId:    th.BasicUser.Id,
+				Message:   "card",
+				CreateAt:  scr.LastPostCreateAt - int64(i) - 1,
+			})
+			require.NoError(t, postErr)
+			postIDs = append(postIDs, post.Id)
+			values = append(values, &model.PropertyValue{
+				TargetID:   post.Id,
+				TargetType: model.PropertyValueTargetTypePost,
+				GroupID:    group.ID,
+				FieldID:    field.ID,
+				Value:      json.RawMessage(`"3 days"`),
+				CreateAt:   changeAt,
+			})
+		}
+		values, err = ss.PropertyValue().CreateMany(values)
+		require.NoError(t, err)
+
+		service.NotifyChannelChanged(channel.Id)
+
+		syncedPostIDs := func() []string {
+			received.mu.Lock()
+			defer received.mu.Unlock()
+			var ids []string
+			for _, msg := range received.messages {
+				for _, value := range msg.PropertyValues {
+					ids = append(ids, value.PostId)
+				}
+			}
+			return ids
+		}
+		require.Eventually(t, func() bool {
+			return len(syncedPostIDs()) >= count
+		}, 30*time.Second, 200*time.Millisecond, "Should have received every property value")
+		waitForSync(t)
+
+		synced := syncedPostIDs()
+		slices.Sort(synced)
+		slices.Sort(postIDs)
+		assert.Equal(t, postIDs, slices.Compact(synced), "Every value should be synced")
+
+		lastID := values[0].ID
+		for _, value := range values {
+			lastID = max(lastID, value.ID)
+		}
+		updated, err := ss.SharedChannel().GetRemote(scr.Id)
+		require.NoError(t, err)
+		assert.Equal(t, changeAt, updated.LastPropertyValueUpdateAt)
+		assert.Equal(t, lastID, updated.LastPropertyValueId)
+	})
+}
@@ -48,6 +48,7 @@ type SelfReferentialSyncHandler struct {
 	OnIndividualSync func(userId string, messageNumber int32)
 	OnBatchSync      func(userIds []string, messageNumber int32)
 	OnGlobalUserSync func(userIds []string, messageNumber int32)
+	OnContentSync    func(syncMsg *model.SyncMsg, messageNumber int32)
 }
 
 // NewSelfReferentialSyncHandler creates a new handler for processing sync messages in tests
@@ -136,6 +137,13 @@ func (h *SelfReferentialSyncHandler) HandleRequest(w http.ResponseWriter, r *htt
 						}
 					}
 
+					// Handle channel header/purpose, bookmark and property value sync
+					if syncMsg.ChannelInfo != nil || len(syncMsg.Bookmarks) > 0 || len(syncMsg.PropertyValues) > 0 {
+						if h.OnContentSync != nil {
+							h.OnContentSync(&syncMsg, currentCall)
+						}
+					}
+
 					_ = response.SetPayload(syncResp)
 				}
 			}
@@ -223,7 +231,11 @@ func EnsureCleanState(t *testing.T, th *TestHelper, ss store.Store) {
 		LastPostCreateId = '', 
 		LastPostUpdateAt = 0, 
 		LastPostId = '', 
-		LastMembersSyncAt = 0 
+		LastMembersSyncAt = 0, 
+		LastChannelUpdateAt = 0, 
+		LastBookmarkUpdateAt = 0, 
+		LastPropertyValueUpdateAt = 0, 
+		LastPropertyValueId = '' 
 		WHERE 1=1`)
 
 	// Remove all channel members from test channels (except the basic team/channel setup)
@@ -18,7 +18,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/platform/services/slackimport"
 )
 
-func (a *App) SlackImport(rctx request.CTX, fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *bytes.Buffer) {
+func (a *App) SlackImport(rctx request.CTX, fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *slackimport.Report) {
 	actions := slackimport.Actions{
 		UpdateActive: func(user *model.User, active bool) (*model.User, *model.AppError) {
 			return a.UpdateActive(rctx, user, active)
@@ -56,6 +56,12 @@ func (a *App) SlackImport(rctx request.CTX, fileData multipart.File, fileSize in
 		},
 	}
 
+	// User groups are imported as custom groups, provided they are available.
+	if license := a.Srv().License(); license != nil && model.MinimumProfessionalLicense(license) && *a.Config().ServiceSettings.EnableCustomGroups {
+		actions.CreateGroupWithUserIds = a.CreateGroupWithUserIds
+		actions.UpsertGroupMembers = a.UpsertGroupMembers
+	}
+
 	// Determine if this is an Admin import:
 	// mattermost cmd imports (no session) are treated as admin imports since only server admins can run them
 	// Web imports (include mmctl calls) check the actual user's role
@@ -1361,6 +1361,12 @@ func (a *App) postProcessTeamMemberLeave(rctx request.CTX, teamMember *model.Tea
 }
 
 func (a *App) LeaveTeam(rctx request.CTX, team *model.Team, user *model.User, requestorId string) *model.AppError {
+	return a.leaveTeam(rctx, team, user, requestorId, *a.Config().ServiceSettings.ExperimentalEnableDefaultChannelLeaveJoinMessages)
+}
+
+// leaveTeam removes a user from a team and its channels, posting a message about it in the
+// default channel of the team when postMessage is true.
+func (a *App) leaveTeam(rctx request.CTX, team *model.Team, user *model.User, requestorId string, postMessage bool) *model.AppError {
 	teamMember, err := a.GetTeamMember(rctx, team.Id, user.Id)
 	if err != nil {
 		return model.NewAppError("LeaveTeam", "api.team.remove_user_from_team.missing.app_error", nil, "", http.StatusBadRequest).Wrap(err)
@@ -1389,7 +1395,7 @@ func (a *App) LeaveTeam(rctx request.CTX, team *model.Team, user *model.User, re
 		}
 	}
 
-	if *a.Config().ServiceSettings.ExperimentalEnableDefaultChannelLeaveJoinMessages {
+	if postMessage {
 		channel, cErr := a.Srv().Store().Channel().GetByName(team.Id, model.DefaultChannelName, false)
 		if cErr != nil {
 			var nfErr *store.ErrNotFound
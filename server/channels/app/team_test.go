@@ -2055,7 +2055,6 @@ func TestInviteNewUsersToTeamGracefully(t *testing.T) {
 			false,
 			false,
 		).Once().Return(nil)
-		emailServiceMock.On("Stop").Once().Return()
 		th.App.Srv().EmailService = &emailServiceMock
 
 		res, err := th.App.InviteNewUsersToTeamGracefully(th.Context, memberInvite, th.BasicTeam.Id, th.BasicUser.Id, "")
@@ -2081,7 +2080,6 @@ func TestInviteNewUsersToTeamGracefully(t *testing.T) {
 			false,
 			false,
 		).Once().Return(email.SendMailError)
-		emailServiceMock.On("Stop").Once().Return()
 		th.App.Srv().EmailService = &emailServiceMock
 
 		res, err := th.App.InviteNewUsersToTeamGracefully(th.Context, memberInvite, th.BasicTeam.Id, th.BasicUser.Id, "")
@@ -2111,7 +2109,6 @@ func TestInviteNewUsersToTeamGracefully(t *testing.T) {
 			false,
 			false,
 		).Once().Return([]*model.EmailInviteWithError{}, nil)
-		emailServiceMock.On("Stop").Once().Return()
 		th.App.Srv().EmailService = &emailServiceMock
 
 		res, err := th.App.InviteNewUsersToTeamGracefully(th.Context, memberInvite, th.BasicTeam.Id, th.BasicUser.Id, "")
@@ -2137,7 +2134,6 @@ func TestInviteNewUsersToTeamGracefully(t *testing.T) {
 			false,
 			false,
 		).Once().Return(nil)
-		emailServiceMock.On("Stop").Once().Return()
 		th.App.Srv().EmailService = &emailServiceMock
 
 		res, err := th.App.InviteNewUsersToTeamGracefully(th.Context, memberInvite, th.BasicTeam.Id, th.BasicUser.Id, "")
@@ -2172,7 +2168,6 @@ func TestInviteGuestsToChannelsGracefully(t *testing.T) {
 			false,
 			false,
 		).Once().Return(nil)
-		emailServiceMock.On("Stop").Once().Return()
 		th.App.Srv().EmailService = &emailServiceMock
 
 		res, err := th.App.InviteGuestsToChannelsGracefully(th.Context, th.BasicTeam.Id, &model.GuestsInvite{
@@ -2201,7 +2196,6 @@ func TestInviteGuestsToChannelsGracefully(t *testing.T) {
 			false,
 			false,
 		).Once().Return(email.SendMailError)
-		emailServiceMock.On("Stop").Once().Return()
 		th.App.Srv().EmailService = &emailServiceMock
 
 		res, err := th.App.InviteGuestsToChannelsGracefully(th.Context, th.BasicTeam.Id, &model.GuestsInvite{
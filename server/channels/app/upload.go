@@ -4,6 +4,8 @@
 package app
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"io"
 	"mime"
@@ -94,7 +96,8 @@ func (a *App) runPluginsHook(rctx request.CTX, info *model.FileInfo, file io.Rea
 	}
 
 	tmpPath := filePath + ".tmp"
-	written, err := a.WriteFile(r, tmpPath)
+	hash := sha256.New()
+	written, err := a.WriteFile(io.TeeReader(r, hash), tmpPath)
 	if err != nil {
 		if fileErr := a.RemoveFile(tmpPath); fileErr != nil {
 			rctx.Logger().Warn("Failed to remove file", mlog.Err(fileErr))
@@ -115,6 +118,7 @@ func (a *App) runPluginsHook(rctx request.CTX, info *model.FileInfo, file io.Rea
 
 	if written > 0 {
 		info.Size = written
+		info.ContentHash = hex.EncodeToString(hash.Sum(nil))
 		if fileErr := a.MoveFile(tmpPath, info.Path); fileErr != nil {
 			return model.NewAppError("runPluginsHook", "app.upload.run_plugins_hook.move_fail",
 				nil, "", http.StatusInternalServerError).Wrap(fileErr)
@@ -336,6 +340,10 @@ func (a *App) UploadData(rctx request.CTX, us *model.UploadSession, rd io.Reader
 		}
 	}
 
+	if a.IsFileScanEnabled() {
+		info.ScanStatus = model.FileScanStatusPending
+	}
+
 	var storeErr error
 	if info, storeErr = a.Srv().Store().FileInfo().Save(rctx, info); storeErr != nil {
 		var appErr *model.AppError
@@ -347,7 +355,15 @@ func (a *App) UploadData(rctx request.CTX, us *model.UploadSession, rd io.Reader
 		}
 	}
 
-	if *a.Config().FileSettings.ExtractContent {
+	// Imports are read from their upload path by the import job.
+	deduplicate := us.Type == model.UploadTypeAttachment
+	if deduplicate {
+		a.deduplicateUploadedFile(rctx, info)
+	}
+
+	if a.IsFileScanEnabled() {
+		a.scanUploadedFile(rctx, info, true, deduplicate)
+	} else if *a.Config().FileSettings.ExtractContent {
 		infoCopy := *info
 		if !a.Srv().GoExtraction(func() {
 			err := a.ExtractContentFromFileInfo(rctx, &infoCopy)
@@ -966,6 +966,15 @@ func (a *App) DeactivateMfa(userID string) *model.AppError {
 		return model.NewAppError("DeactivateMfa", "mfa.deactivate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	// Security keys and recovery codes are only second factors: they go away with MFA.
+	if err := a.Srv().Store().WebAuthnCredential().PermanentDeleteByUser(userID); err != nil {
+		return model.NewAppError("DeactivateMfa", "mfa.deactivate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().MfaRecoveryCode().PermanentDeleteByUser(userID); err != nil {
+		return model.NewAppError("DeactivateMfa", "mfa.deactivate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	// Make sure old MFA status is not cached locally or in cluster nodes.
 	a.InvalidateCacheForUser(userID)
 
@@ -1651,6 +1660,12 @@ func (a *App) UpdateMfa(rctx request.CTX, activate bool, userID, token string) *
 		}
 	}
 
+	a.sendMfaChangeEmail(rctx, userID, activate)
+
+	return nil
+}
+
+func (a *App) sendMfaChangeEmail(rctx request.CTX, userID string, activate bool) {
 	a.Srv().Go(func() {
 		user, err := a.GetUser(userID)
 		if err != nil {
@@ -1662,8 +1677,6 @@ func (a *App) UpdateMfa(rctx request.CTX, activate bool, userID, token string) *
 			rctx.Logger().Error("Failed to send mfa change email", mlog.Err(err))
 		}
 	})
-
-	return nil
 }
 
 func (a *App) UpdatePasswordByUserIdSendEmail(rctx request.CTX, userID, newPassword, method string) *model.AppError {
@@ -2113,6 +2126,18 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 		return model.NewAppError("PermanentDeleteUser", "app.drafts.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().PendingEmailNotification().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.pending_email_notification.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().WebAuthnCredential().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.webauthn_credential.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().MfaRecoveryCode().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.mfa_recovery_code.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	if err := a.Srv().Store().Bot().PermanentDelete(user.Id); err != nil {
 		var invErr *store.ErrInvalidInput
 		switch {
@@ -2162,6 +2187,7 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 	if _, err := a.Srv().Store().FileInfo().PermanentDeleteByUser(rctx, user.Id); err != nil {
 		return model.NewAppError("PermanentDeleteUser", "app.file_info.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
+	a.collectFileBlobs(rctx, infos)
 
 	if err := a.Srv().Store().User().PermanentDelete(rctx, user.Id); err != nil {
 		return model.NewAppError("PermanentDeleteUser", "app.user.permanent_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -2533,6 +2559,18 @@ func (a *App) UpdateOAuthUserAttrs(rctx request.CTX, userData io.Reader, user *m
 		}
 	}
 
+	// Only some providers know the nickname and position of users, which
+	// are otherwise left as set by the users themselves.
+	if oauthUser.Nickname != "" && oauthUser.Nickname != user.Nickname {
+		user.Nickname = oauthUser.Nickname
+		userAttrsChanged = true
+	}
+
+	if oauthUser.Position != "" && oauthUser.Position != user.Position {
+		user.Position = oauthUser.Position
+		userAttrsChanged = true
+	}
+
 	if user.DeleteAt > 0 {
 		// Make sure they are not disabled
 		user.DeleteAt = 0
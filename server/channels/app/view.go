@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -27,6 +28,10 @@ func (a *App) CreateView(rctx request.CTX, view *model.View, connectionID string
 		return nil, model.NewAppError("CreateView", "app.view.create.limit.app_error", nil, "channel has reached the maximum number of views", http.StatusBadRequest)
 	}
 
+	if appErr := a.validateViewFields(rctx, view); appErr != nil {
+		return nil, appErr
+	}
+
 	saved, err := a.Srv().Store().View().Save(view)
 	if err != nil {
 		var appErr *model.AppError
@@ -87,6 +92,11 @@ func (a *App) UpdateView(rctx request.CTX, view *model.View, patch *model.ViewPa
 	}
 	view = view.Clone()
 	view.Patch(patch)
+
+	if appErr := a.validateViewFields(rctx, view); appErr != nil {
+		return nil, appErr
+	}
+
 	updated, err := a.Srv().Store().View().Update(view)
 	if err != nil {
 		var appErr *model.AppError
@@ -155,6 +165,59 @@ func (a *App) UpdateViewSortOrder(rctx request.CTX, viewID, channelID string, ne
 	return views, nil
 }
 
+// validateViewFields checks that the property fields referenced by a table or calendar
+// view exist, and that a calendar view is keyed on a date field. The structure of the
+// props is left for View.IsValid to check.
+func (a *App) validateViewFields(rctx request.CTX, view *model.View) *model.AppError {
+	var fieldIDs []string
+	var dateFieldID string
+	switch view.Type {
+	case model.ViewTypeTable:
+		props, err := model.TablePropsFromProps(view.Props)
+		if err != nil {
+			return nil
+		}
+		fieldIDs = props.FieldIDs()
+	case model.ViewTypeCalendar:
+		props, err := model.CalendarPropsFromProps(view.Props)
+		if err != nil {
+			return nil
+		}
+		fieldIDs = props.FieldIDs()
+		dateFieldID = props.DateFieldID
+	default:
+		return nil
+	}
+
+	for _, id := range fieldIDs {
+		if !model.IsValidId(id) {
+			return nil
+		}
+	}
+	slices.Sort(fieldIDs)
+	fieldIDs = slices.Compact(fieldIDs)
+
+	fields, err := a.Srv().Store().PropertyField().GetMany(rctx.Context(), "", fieldIDs)
+	if err != nil {
+		var rmErr *store.ErrResultsMismatch
+		if errors.As(err, &rmErr) {
+			return model.NewAppError("validateViewFields", "app.view.fields.not_found.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+		return model.NewAppError("validateViewFields", "app.view.fields.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, field := range fields {
+		if field.DeleteAt != 0 {
+			return model.NewAppError("validateViewFields", "app.view.fields.not_found.app_error", nil, "field_id="+field.ID, http.StatusBadRequest)
+		}
+		if field.ID == dateFieldID && field.Type != model.PropertyFieldTypeDate {
+			return model.NewAppError("validateViewFields", "app.view.fields.calendar_date_type.app_error", nil, "field_id="+field.ID, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
 func (a *App) publishViewEvent(rctx request.CTX, eventType model.WebsocketEventType, view *model.View, connectionID string) {
 	if view == nil {
 		return
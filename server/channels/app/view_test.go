@@ -59,6 +59,55 @@ func TestAppCreateView(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
 		assert.Equal(t, "app.view.create.limit.app_error", appErr.Id)
 	})
+
+	t.Run("calendar view", func(t *testing.T) {
+		createField := func(fieldType model.PropertyFieldType) *model.PropertyField {
+			field, err := th.Store.PropertyField().Create(&model.PropertyField{
+				GroupID:    model.NewId(),
+				Name:       "field " + model.NewId(),
+				Type:       fieldType,
+				ObjectType: model.PropertyFieldObjectTypePost,
+				TargetType: string(model.PropertyFieldTargetLevelChannel),
+				TargetID:   th.BasicChannel.Id,
+			})
+			require.NoError(t, err)
+			return field
+		}
+
+		makeCalendarView := func(dateFieldID string) *model.View {
+			props, err := (&model.CalendarProps{DateFieldID: dateFieldID}).ToProps()
+			require.NoError(t, err)
+			return &model.View{
+				ChannelId: th.BasicChannel.Id,
+				Type:      model.ViewTypeCalendar,
+				CreatorId: th.BasicUser.Id,
+				Title:     "Calendar",
+				Props:     props,
+			}
+		}
+
+		t.Run("creates a view keyed on a date field", func(t *testing.T) {
+			field := createField(model.PropertyFieldTypeDate)
+			saved, appErr := th.App.CreateView(th.Context, makeCalendarView(field.ID), "")
+			require.Nil(t, appErr)
+			assert.Equal(t, model.ViewTypeCalendar, saved.Type)
+		})
+
+		t.Run("non-date field returns 400", func(t *testing.T) {
+			field := createField(model.PropertyFieldTypeText)
+			_, appErr := th.App.CreateView(th.Context, makeCalendarView(field.ID), "")
+			require.NotNil(t, appErr)
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+			assert.Equal(t, "app.view.fields.calendar_date_type.app_error", appErr.Id)
+		})
+
+		t.Run("unknown field returns 400", func(t *testing.T) {
+			_, appErr := th.App.CreateView(th.Context, makeCalendarView(model.NewId()), "")
+			require.NotNil(t, appErr)
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+			assert.Equal(t, "app.view.fields.not_found.app_error", appErr.Id)
+		})
+	})
 }
 
 func TestAppGetView(t *testing.T) {
@@ -0,0 +1,336 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa"
+)
+
+// webAuthn returns the relying party for the security keys of this server, which is
+// bound to the host of the site URL.
+func (a *App) webAuthn() (*mfa.WebAuthn, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return nil, model.NewAppError("webAuthn", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	rpName := *a.Config().TeamSettings.SiteName
+	if rpName == "" {
+		rpName = model.TeamSettingsDefaultSiteName
+	}
+
+	webAuthn, err := mfa.NewWebAuthn(*a.Config().ServiceSettings.SiteURL, rpName)
+	if err != nil {
+		return nil, model.NewAppError("webAuthn", "app.webauthn.site_url.app_error", nil, "", http.StatusNotImplemented).Wrap(err)
+	}
+
+	return webAuthn, nil
+}
+
+// createWebAuthnChallenge stores a single-use challenge of a WebAuthn ceremony of the
+// given user. The challenge is the token itself.
+func (a *App) createWebAuthnChallenge(tokenType, userID string) (*model.Token, *model.AppError) {
+	token := model.NewToken(tokenType, userID)
+	if err := a.Srv().Store().Token().Save(token); err != nil {
+		return nil, model.NewAppError("createWebAuthnChallenge", "app.webauthn.save_challenge.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return token, nil
+}
+
+// consumeWebAuthnChallenge returns the challenge signed by the client if it was issued
+// to the given user and hasn't expired, or nil otherwise. A challenge can only be
+// consumed once.
+func (a *App) consumeWebAuthnChallenge(tokenType, clientDataJSON, userID string) []byte {
+	challenge, err := mfa.WebAuthnChallenge(clientDataJSON)
+	if err != nil {
+		return nil
+	}
+
+	token, err := a.Srv().Store().Token().ConsumeOnce(tokenType, string(challenge))
+	if err != nil || token.IsExpired() || token.Extra != userID {
+		return nil
+	}
+
+	return challenge
+}
+
+// BeginWebAuthnRegistration starts the registration of a new security key for a user,
+// returning the options to pass to navigator.credentials.create().
+func (a *App) BeginWebAuthnRegistration(userID string) (*model.WebAuthnCreationOptions, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if user.AuthService != "" && user.AuthService != model.UserAuthServiceLdap {
+		return nil, model.NewAppError("BeginWebAuthnRegistration", "api.user.activate_mfa.email_and_ldap_only.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	webAuthn, appErr := a.webAuthn()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(credentials) >= model.WebAuthnMaxCredentialsPerUser {
+		return nil, model.NewAppError("BeginWebAuthnRegistration", "app.webauthn.too_many_credentials.app_error", map[string]any{"Max": model.WebAuthnMaxCredentialsPerUser}, "", http.StatusBadRequest)
+	}
+
+	token, appErr := a.createWebAuthnChallenge(model.TokenTypeWebAuthnRegistration, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return webAuthn.CreationOptions(user, []byte(token.Token), credentials), nil
+}
+
+// FinishWebAuthnRegistration verifies the response of the authenticator to a
+// registration started with BeginWebAuthnRegistration and saves the new security key.
+// Registering a first security key activates MFA for the user.
+func (a *App) FinishWebAuthnRegistration(rctx request.CTX, userID string, registration *model.WebAuthnRegistration) (*model.WebAuthnCredential, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	webAuthn, appErr := a.webAuthn()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	challenge := a.consumeWebAuthnChallenge(model.TokenTypeWebAuthnRegistration, registration.Credential.Response.ClientDataJSON, userID)
+	if challenge == nil {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.invalid_response.app_error", nil, "invalid challenge", http.StatusBadRequest)
+	}
+
+	credential, err := webAuthn.VerifyRegistration(registration.Credential, challenge)
+	if err != nil {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	credential.UserId = userID
+	credential.Name = registration.Name
+
+	credentials, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(credentials) >= model.WebAuthnMaxCredentialsPerUser {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.too_many_credentials.app_error", map[string]any{"Max": model.WebAuthnMaxCredentialsPerUser}, "", http.StatusBadRequest)
+	}
+
+	credential, err = a.Srv().Store().WebAuthnCredential().Save(credential)
+	if err != nil {
+		var appErr *model.AppError
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &cErr):
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.credential_exists.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if !user.MfaActive {
+		if err := a.Srv().Store().User().UpdateMfaActive(userID, true); err != nil {
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "mfa.activate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		// Make sure old MFA status is not cached locally or in cluster nodes.
+		a.InvalidateCacheForUser(userID)
+		a.sendMfaChangeEmail(rctx, userID, true)
+	}
+
+	return credential, nil
+}
+
+// GetWebAuthnCredentials returns the security keys registered by a user.
+func (a *App) GetWebAuthnCredentials(userID string) ([]*model.WebAuthnCredential, *model.AppError) {
+	credentials, err := a.Srv().Store().WebAuthnCredential().GetForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetWebAuthnCredentials", "app.webauthn.get_for_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return credentials, nil
+}
+
+// DeleteWebAuthnCredential removes a security key of a user. Removing the last second
+// factor of a user deactivates MFA.
+func (a *App) DeleteWebAuthnCredential(rctx request.CTX, userID, credentialID string) *model.AppError {
+	credential, err := a.Srv().Store().WebAuthnCredential().Get(credentialID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.get.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if credential.UserId != userID {
+		return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.get.app_error", nil, "", http.StatusNotFound)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if err := a.Srv().Store().WebAuthnCredential().Delete(credentialID); err != nil {
+		return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if user.MfaActive && user.MfaSecret == "" && len(credentials) == 0 {
+		if appErr := a.DeactivateMfa(userID); appErr != nil {
+			return appErr
+		}
+		a.sendMfaChangeEmail(rctx, userID, false)
+	}
+
+	return nil
+}
+
+// BeginWebAuthnLogin starts the authentication of a user with one of their security
+// keys, returning the options to pass to navigator.credentials.get(). The response of
+// the authenticator is then sent as the MFA token of the login request.
+//
+// To not disclose which users exist or have registered security keys, the options of
+// unknown users are returned without any allowed credentials.
+func (a *App) BeginWebAuthnLogin(rctx request.CTX, loginID string) (*model.WebAuthnRequestOptions, *model.AppError) {
+	webAuthn, appErr := a.webAuthn()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var userID string
+	credentials := []*model.WebAuthnCredential{}
+	if user, appErr := a.GetUserForLogin(rctx, "", loginID); appErr == nil && user.MfaActive {
+		userID = user.Id
+		if credentials, appErr = a.GetWebAuthnCredentials(user.Id); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	token, appErr := a.createWebAuthnChallenge(model.TokenTypeWebAuthnLogin, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return webAuthn.RequestOptions([]byte(token.Token), credentials), nil
+}
+
+// checkWebAuthnAssertion verifies a security key assertion sent as the MFA token of a
+// login request.
+func (a *App) checkWebAuthnAssertion(rctx request.CTX, user *model.User, token string) *model.AppError {
+	badCode := model.NewAppError("checkUserMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+
+	var assertion model.WebAuthnAssertion
+	if err := json.Unmarshal([]byte(token), &assertion); err != nil {
+		return badCode.Wrap(err)
+	}
+
+	webAuthn, appErr := a.webAuthn()
+	if appErr != nil {
+		return appErr
+	}
+
+	challenge := a.consumeWebAuthnChallenge(model.TokenTypeWebAuthnLogin, assertion.Response.ClientDataJSON, user.Id)
+	if challenge == nil {
+		return badCode
+	}
+
+	rawID, err := mfa.DecodeWebAuthnBinary(assertion.RawId)
+	if err != nil {
+		return badCode.Wrap(err)
+	}
+
+	credential, err := a.Srv().Store().WebAuthnCredential().GetByCredentialId(mfa.EncodeWebAuthnBinary(rawID))
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return badCode.Wrap(err)
+		}
+		return model.NewAppError("checkUserMfa", "app.webauthn.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if credential.UserId != user.Id {
+		return badCode
+	}
+
+	signCount, err := webAuthn.VerifyAssertion(&assertion, challenge, credential)
+	if err != nil {
+		rctx.Logger().Debug("Invalid security key assertion", mlog.String("user_id", user.Id), mlog.Err(err))
+		return badCode.Wrap(err)
+	}
+
+	if err := a.Srv().Store().WebAuthnCredential().UpdateLastUsed(credential.Id, signCount, model.GetMillis()); err != nil {
+		return model.NewAppError("checkUserMfa", "app.webauthn.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// GenerateMfaRecoveryCodes replaces the recovery codes of a user with new ones. The
+// codes are only ever returned here, as just their hashes are stored.
+func (a *App) GenerateMfaRecoveryCodes(userID string) (*model.MfaRecoveryCodes, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if !user.MfaActive {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "app.mfa_recovery_code.mfa_inactive.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	codes := mfa.GenerateRecoveryCodes(model.MfaRecoveryCodeCount)
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = mfa.HashRecoveryCode(code)
+	}
+
+	if err := a.Srv().Store().MfaRecoveryCode().SaveForUser(userID, hashes); err != nil {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "app.mfa_recovery_code.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &model.MfaRecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// useMfaRecoveryCode checks a recovery code sent as the MFA token of a login request,
+// consuming it.
+func (a *App) useMfaRecoveryCode(user *model.User, code string) *model.AppError {
+	used, err := a.Srv().Store().MfaRecoveryCode().Use(user.Id, mfa.HashRecoveryCode(code))
+	if err != nil {
+		return model.NewAppError("checkUserMfa", "app.mfa_recovery_code.use.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if !used {
+		return model.NewAppError("checkUserMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	return nil
+}
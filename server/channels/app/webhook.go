@@ -4,14 +4,17 @@
 package app
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"maps"
 	"net/http"
+	"net/url"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -30,6 +33,8 @@ const (
 
 	MaxIntegrationResponseSize = 1024 * 1024 // Posts can be <100KB at most, so this is likely more than enough
 	MaxDialogResponseSize      = 1024 * 1024 // 1MB limit for dialog responses to prevent OOM attacks
+
+	outgoingWebhookRetryBatchSize = 100
 )
 
 var linkWithTextRegex = regexp.MustCompile(`<([^\n<\|>]+)\|([^\|\n>]+)>`)
@@ -99,34 +104,34 @@ func (a *App) handleWebhookEvents(rctx request.CTX, post *model.Post, team *mode
 func (a *App) TriggerWebhook(rctx request.CTX, payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel) {
 	logger := rctx.Logger().With(mlog.String("outgoing_webhook_id", hook.Id), mlog.String("post_id", post.Id), mlog.String("channel_id", channel.Id), mlog.String("content_type", hook.ContentType))
 
-	var jsonBytes []byte
-	var err error
+	// The token is left out of the persisted payload and only added back when sending it,
+	// so that it isn't stored in plain text along with every delivery.
+	persistedPayload := *payload
+	persistedPayload.Token = ""
+
+	var body []byte
 	contentType := "application/x-www-form-urlencoded"
 	if hook.ContentType == "application/json" {
+		var err error
 		contentType = "application/json"
-		jsonBytes, err = json.Marshal(payload)
+		body, err = json.Marshal(persistedPayload)
 		if err != nil {
 			logger.Warn("Failed to encode to JSON", mlog.Err(err))
 			return
 		}
+	} else {
+		body = []byte(persistedPayload.ToFormValues())
 	}
 
-	var wg sync.WaitGroup
+	// A delivery that isn't completed by the time the request times out is picked up
+	// by the retry job, so that deliveries in flight aren't lost if the server stops.
+	firstRetryAt := model.GetMillis() +
+		(time.Duration(*a.Config().ServiceSettings.OutgoingIntegrationRequestsTimeout)*time.Second + model.OutgoingWebhookDeliveryInitialBackoff).Milliseconds()
 
-	for i := range hook.CallbackURLs {
-		var body io.Reader
-		if hook.ContentType == "application/json" {
-			body = bytes.NewReader(jsonBytes)
-		} else {
-			body = strings.NewReader(payload.ToFormValues())
-		}
-		wg.Add(1)
-
-		// Get the callback URL by index to properly capture it for the go func
-		url := hook.CallbackURLs[i]
+	var wg sync.WaitGroup
 
-		go func() {
-			defer wg.Done()
+	for _, url := range hook.CallbackURLs {
+		wg.Go(func() {
 			defer func() {
 				if r := recover(); r != nil {
 					logger.Error("Recovered from panic in outgoing webhook goroutine",
@@ -136,102 +141,300 @@ func (a *App) TriggerWebhook(rctx request.CTX, payload *model.OutgoingWebhookPay
 				}
 			}()
 
-			var accessToken *model.OutgoingOAuthConnectionToken
+			delivery := &model.OutgoingWebhookDelivery{
+				HookId:      hook.Id,
+				PostId:      post.Id,
+				CallbackURL: url,
+				ContentType: contentType,
+				Payload:     string(body),
+				NextRetryAt: firstRetryAt,
+			}
 
-			// Retrieve an access token from a connection if one exists to use for the webhook request
-			if a.Config().ServiceSettings.EnableOutgoingOAuthConnections != nil && *a.Config().ServiceSettings.EnableOutgoingOAuthConnections && a.OutgoingOAuthConnections() != nil {
-				connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(rctx, url)
-				if err != nil {
-					logger.Error("Failed to find an outgoing oauth connection for the webhook", mlog.Err(err))
-					return
-				}
+			saved := true
+			if _, err := a.Srv().Store().Webhook().SaveOutgoingDelivery(delivery); err != nil {
+				logger.Warn("Failed to save outgoing webhook delivery", mlog.String("url", url), mlog.Err(err))
+				saved = false
+			}
+
+			webhookResp := a.deliverOutgoingWebhook(rctx, hook, delivery)
 
-				if connection != nil {
-					accessToken, err = a.OutgoingOAuthConnections().RetrieveTokenForConnection(rctx, connection)
-					if err != nil {
-						logger.Error("Failed to retrieve token for outgoing oauth connection", mlog.Err(err))
-						return
-					}
+			if saved {
+				if _, err := a.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery); err != nil {
+					logger.Warn("Failed to update outgoing webhook delivery", mlog.String("delivery_id", delivery.Id), mlog.Err(err))
 				}
 			}
 
-			webhookResp, err := a.doOutgoingWebhookRequest(url, body, contentType, accessToken)
+			a.handleOutgoingWebhookResponse(rctx, webhookResp, hook, post, channel)
+		})
+	}
+	wg.Wait()
+}
+
+// deliverOutgoingWebhook makes a single attempt at delivering the payload of the given
+// delivery, and records the outcome of the attempt on it. Failures caused by the
+// remote end being unavailable are scheduled to be retried with exponential backoff
+// until ServiceSettings.OutgoingWebhookMaxAttempts is reached.
+func (a *App) deliverOutgoingWebhook(rctx request.CTX, hook *model.OutgoingWebhook, delivery *model.OutgoingWebhookDelivery) *model.OutgoingWebhookResponse {
+	logger := rctx.Logger().With(mlog.String("outgoing_webhook_id", hook.Id), mlog.String("delivery_id", delivery.Id), mlog.String("url", delivery.CallbackURL))
+
+	delivery.Attempts++
+	delivery.StatusCode = 0
+	delivery.LatencyMs = 0
+
+	webhookResp, err := a.attemptOutgoingWebhookDelivery(rctx, hook, delivery)
+	if err == nil && delivery.StatusCode < http.StatusBadRequest {
+		delivery.Status = model.OutgoingWebhookDeliveryStatusSuccess
+		delivery.LastError = ""
+		delivery.NextRetryAt = 0
+		return webhookResp
+	}
+
+	if err != nil {
+		delivery.LastError = err.Error()
+		if errors.Is(err, context.DeadlineExceeded) {
+			logger.Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
+		} else {
+			logger.Error("Outgoing Webhook POST failed", mlog.Err(err))
+		}
+	} else {
+		delivery.LastError = http.StatusText(delivery.StatusCode)
+	}
+
+	retryable := delivery.StatusCode == 0 || delivery.StatusCode >= http.StatusInternalServerError
+	if retryable && delivery.Attempts < *a.Config().ServiceSettings.OutgoingWebhookMaxAttempts {
+		delivery.Status = model.OutgoingWebhookDeliveryStatusRetrying
+		delivery.NextRetryAt = model.GetMillis() + model.OutgoingWebhookDeliveryBackoff(delivery.Attempts).Milliseconds()
+		return nil
+	}
+
+	delivery.Status = model.OutgoingWebhookDeliveryStatusFailed
+	delivery.NextRetryAt = 0
+
+	// Responses to requests rejected by the remote end are still honoured, as they always have been.
+	return webhookResp
+}
+
+func (a *App) attemptOutgoingWebhookDelivery(rctx request.CTX, hook *model.OutgoingWebhook, delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookResponse, error) {
+	var accessToken *model.OutgoingOAuthConnectionToken
+
+	// Retrieve an access token from a connection if one exists to use for the webhook request
+	if a.Config().ServiceSettings.EnableOutgoingOAuthConnections != nil && *a.Config().ServiceSettings.EnableOutgoingOAuthConnections && a.OutgoingOAuthConnections() != nil {
+		connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(rctx, delivery.CallbackURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find an outgoing oauth connection for the webhook: %w", err)
+		}
+
+		if connection != nil {
+			accessToken, err = a.OutgoingOAuthConnections().RetrieveTokenForConnection(rctx, connection)
 			if err != nil {
-				if errors.Is(err, context.DeadlineExceeded) {
-					logger.Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
-				} else {
-					logger.Error("Outgoing Webhook POST failed", mlog.Err(err))
-				}
-				return
+				return nil, fmt.Errorf("failed to retrieve token for outgoing oauth connection: %w", err)
 			}
+		}
+	}
 
-			if webhookResp != nil && (webhookResp.Text != nil || len(webhookResp.Attachments) > 0) {
-				postRootId := ""
-				if webhookResp.ResponseType == model.OutgoingHookResponseTypeComment {
-					postRootId = post.Id
-				}
-				if len(webhookResp.Props) == 0 {
-					webhookResp.Props = make(model.StringInterface)
-				}
-				webhookResp.Props[model.PostPropsWebhookDisplayName] = hook.DisplayName
+	body, err := outgoingWebhookRequestBody(delivery, hook.Token)
+	if err != nil {
+		return nil, err
+	}
 
-				text := ""
-				if webhookResp.Text != nil {
-					text = a.ProcessSlackText(rctx, *webhookResp.Text)
-				}
-				webhookResp.Attachments = a.ProcessMessageAttachments(rctx, webhookResp.Attachments)
-				// attachments is in here for slack compatibility
-				if len(webhookResp.Attachments) > 0 {
-					webhookResp.Props[model.PostPropsAttachments] = webhookResp.Attachments
-				}
-				if *a.Config().ServiceSettings.EnablePostUsernameOverride && hook.Username != "" && webhookResp.Username == "" {
-					webhookResp.Username = hook.Username
-				}
+	timestamp := time.Now().Unix()
+	headers := map[string]string{
+		model.HeaderOutgoingWebhookSignature:  model.SignOutgoingWebhookPayload(hook.Token, timestamp, []byte(body)),
+		model.HeaderOutgoingWebhookTimestamp:  strconv.FormatInt(timestamp, 10),
+		model.HeaderOutgoingWebhookDeliveryId: delivery.Id,
+	}
 
-				if *a.Config().ServiceSettings.EnablePostIconOverride && hook.IconURL != "" && webhookResp.IconURL == "" {
-					webhookResp.IconURL = hook.IconURL
-				}
-				if _, err := a.CreateWebhookPost(rctx, hook.CreatorId, channel, text, webhookResp.Username, webhookResp.IconURL, "", webhookResp.Props, webhookResp.Type, postRootId, webhookResp.Priority); err != nil {
-					logger.Error("Failed to create response post.", mlog.Err(err))
-				}
-			}
-		}()
+	start := time.Now()
+	webhookResp, statusCode, err := a.doSignedOutgoingWebhookRequest(delivery.CallbackURL, strings.NewReader(body), delivery.ContentType, accessToken, headers)
+	delivery.LatencyMs = time.Since(start).Milliseconds()
+	delivery.StatusCode = statusCode
+
+	return webhookResp, err
+}
+
+// outgoingWebhookRequestBody returns the body a delivery is sent with, which is its
+// persisted payload with the token of the hook added back.
+func outgoingWebhookRequestBody(delivery *model.OutgoingWebhookDelivery, token string) (string, error) {
+	if delivery.ContentType == "application/json" {
+		var payload model.OutgoingWebhookPayload
+		if err := json.Unmarshal([]byte(delivery.Payload), &payload); err != nil {
+			return "", fmt.Errorf("failed to decode the outgoing webhook payload: %w", err)
+		}
+		payload.Token = token
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode the outgoing webhook payload: %w", err)
+		}
+		return string(body), nil
 	}
-	wg.Wait()
+
+	values, err := url.ParseQuery(delivery.Payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode the outgoing webhook payload: %w", err)
+	}
+	values.Set("token", token)
+	return values.Encode(), nil
+}
+
+func (a *App) handleOutgoingWebhookResponse(rctx request.CTX, webhookResp *model.OutgoingWebhookResponse, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel) {
+	if webhookResp == nil || (webhookResp.Text == nil && len(webhookResp.Attachments) == 0) {
+		return
+	}
+
+	postRootId := ""
+	if webhookResp.ResponseType == model.OutgoingHookResponseTypeComment {
+		postRootId = post.Id
+	}
+	if len(webhookResp.Props) == 0 {
+		webhookResp.Props = make(model.StringInterface)
+	}
+	webhookResp.Props[model.PostPropsWebhookDisplayName] = hook.DisplayName
+
+	text := ""
+	if webhookResp.Text != nil {
+		text = a.ProcessSlackText(rctx, *webhookResp.Text)
+	}
+	webhookResp.Attachments = a.ProcessMessageAttachments(rctx, webhookResp.Attachments)
+	// attachments is in here for slack compatibility
+	if len(webhookResp.Attachments) > 0 {
+		webhookResp.Props[model.PostPropsAttachments] = webhookResp.Attachments
+	}
+	if *a.Config().ServiceSettings.EnablePostUsernameOverride && hook.Username != "" && webhookResp.Username == "" {
+		webhookResp.Username = hook.Username
+	}
+
+	if *a.Config().ServiceSettings.EnablePostIconOverride && hook.IconURL != "" && webhookResp.IconURL == "" {
+		webhookResp.IconURL = hook.IconURL
+	}
+	if _, err := a.CreateWebhookPost(rctx, hook.CreatorId, channel, text, webhookResp.Username, webhookResp.IconURL, "", webhookResp.Props, webhookResp.Type, postRootId, webhookResp.Priority); err != nil {
+		rctx.Logger().Error("Failed to create response post.", mlog.String("outgoing_webhook_id", hook.Id), mlog.String("post_id", post.Id), mlog.Err(err))
+	}
+}
+
+// RetryOutgoingWebhookDeliveries makes another attempt at delivering the outgoing webhook
+// payloads whose retry is due, and removes the delivery log entries past their retention.
+func (a *App) RetryOutgoingWebhookDeliveries(rctx request.CTX) error {
+	now := model.GetMillis()
+
+	deliveries, err := a.Srv().Store().Webhook().GetOutgoingDeliveriesForRetry(now, outgoingWebhookRetryBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get outgoing webhook deliveries to retry: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		a.retryOutgoingWebhookDelivery(rctx, delivery)
+	}
+
+	for {
+		deleted, err := a.Srv().Store().Webhook().PermanentDeleteOutgoingDeliveriesBefore(now-model.OutgoingWebhookDeliveryRetention.Milliseconds(), outgoingWebhookRetryBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to delete old outgoing webhook deliveries: %w", err)
+		}
+		if deleted < outgoingWebhookRetryBatchSize {
+			return nil
+		}
+	}
+}
+
+func (a *App) retryOutgoingWebhookDelivery(rctx request.CTX, delivery *model.OutgoingWebhookDelivery) {
+	logger := rctx.Logger().With(mlog.String("outgoing_webhook_id", delivery.HookId), mlog.String("delivery_id", delivery.Id))
+
+	hook, post, channel, err := a.getOutgoingWebhookDeliveryContext(rctx, delivery)
+	if err != nil {
+		logger.Info("Abandoning outgoing webhook delivery", mlog.Err(err))
+		delivery.Status = model.OutgoingWebhookDeliveryStatusFailed
+		delivery.LastError = err.Error()
+		delivery.NextRetryAt = 0
+		if _, err := a.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery); err != nil {
+			logger.Warn("Failed to update outgoing webhook delivery", mlog.Err(err))
+		}
+		return
+	}
+
+	webhookResp := a.deliverOutgoingWebhook(rctx, hook, delivery)
+	if _, err := a.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery); err != nil {
+		logger.Warn("Failed to update outgoing webhook delivery", mlog.Err(err))
+	}
+
+	a.handleOutgoingWebhookResponse(rctx, webhookResp, hook, post, channel)
+}
+
+func (a *App) getOutgoingWebhookDeliveryContext(rctx request.CTX, delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhook, *model.Post, *model.Channel, error) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, nil, nil, errors.New("outgoing webhooks are disabled")
+	}
+
+	hook, err := a.Srv().Store().Webhook().GetOutgoing(delivery.HookId)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get outgoing webhook: %w", err)
+	}
+
+	if !slices.Contains(hook.CallbackURLs, delivery.CallbackURL) {
+		return nil, nil, nil, errors.New("callback URL was removed from the outgoing webhook")
+	}
+
+	post, err := a.Srv().Store().Post().GetSingle(rctx, delivery.PostId, false)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	channel, err := a.Srv().Store().Channel().Get(post.ChannelId, true)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	return hook, post, channel, nil
 }
 
 func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType string, accessToken *model.OutgoingOAuthConnectionToken) (*model.OutgoingWebhookResponse, error) {
+	hookResp, _, err := a.doSignedOutgoingWebhookRequest(url, body, contentType, accessToken, nil)
+	return hookResp, err
+}
+
+// doSignedOutgoingWebhookRequest sends an outgoing webhook request with the given extra
+// headers, returning the status code of the response. Responses with a 5xx status code
+// are reported as errors so that the delivery can be retried.
+func (a *App) doSignedOutgoingWebhookRequest(url string, body io.Reader, contentType string, accessToken *model.OutgoingOAuthConnectionToken, headers map[string]string) (*model.OutgoingWebhookResponse, int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*a.Config().ServiceSettings.OutgoingIntegrationRequestsTimeout)*time.Second)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
 	if accessToken != nil {
 		req.Header.Add("Authorization", accessToken.AsHeaderValue())
 	}
 
 	resp, err := a.Srv().outgoingWebhookClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, resp.StatusCode, fmt.Errorf("outgoing webhook request failed with status code %d", resp.StatusCode)
+	}
+
 	var hookResp model.OutgoingWebhookResponse
 	if jsonErr := json.NewDecoder(io.LimitReader(resp.Body, MaxIntegrationResponseSize)).Decode(&hookResp); jsonErr != nil {
 		if jsonErr == io.EOF {
-			return nil, nil
+			return nil, resp.StatusCode, nil
 		}
-		return nil, model.NewAppError("doOutgoingWebhookRequest", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
+		return nil, resp.StatusCode, model.NewAppError("doOutgoingWebhookRequest", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(jsonErr)
 	}
 
-	return &hookResp, nil
+	return &hookResp, resp.StatusCode, nil
 }
 
 func splitWebhookPost(post *model.Post, maxPostSize int) ([]*model.Post, *model.AppError) {
@@ -692,6 +895,19 @@ func (a *App) GetOutgoingWebhook(hookID string) (*model.OutgoingWebhook, *model.
 	return webhook, nil
 }
 
+func (a *App) GetOutgoingWebhookDeliveries(hookID string, page, perPage int) ([]*model.OutgoingWebhookDelivery, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return nil, model.NewAppError("GetOutgoingWebhookDeliveries", "api.outgoing_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	deliveries, err := a.Srv().Store().Webhook().GetOutgoingDeliveriesByHook(hookID, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetOutgoingWebhookDeliveries", "app.webhooks.get_outgoing_deliveries.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return deliveries, nil
+}
+
 func (a *App) GetOutgoingWebhooksPage(page, perPage int) ([]*model.OutgoingWebhook, *model.AppError) {
 	return a.GetOutgoingWebhooksPageByUser("", page, perPage)
 }
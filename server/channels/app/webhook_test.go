@@ -11,7 +11,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -1132,6 +1134,164 @@ func TestTriggerOutGoingWebhookWithMultipleURLs(t *testing.T) {
 	}
 }
 
+func TestTriggerOutgoingWebhookDelivery(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.OutgoingWebhookMaxAttempts = 2
+	})
+
+	createHook := func(t *testing.T, callbackURL string) *model.OutgoingWebhook {
+		t.Helper()
+		hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+			ChannelId:    th.BasicChannel.Id,
+			TeamId:       th.BasicTeam.Id,
+			CallbackURLs: []string{callbackURL},
+			CreatorId:    th.BasicUser.Id,
+			TriggerWords: []string{model.NewId()},
+			ContentType:  "application/json",
+		})
+		require.Nil(t, appErr)
+		return hook
+	}
+
+	getPayload := func(hook *model.OutgoingWebhook) *model.OutgoingWebhookPayload {
+		return &model.OutgoingWebhookPayload{
+			Token:     hook.Token,
+			TeamId:    hook.TeamId,
+			ChannelId: th.BasicChannel.Id,
+			PostId:    th.BasicPost.Id,
+			Text:      th.BasicPost.Message,
+		}
+	}
+
+	t.Run("should sign the payload and record the delivery", func(t *testing.T) {
+		var validSignature atomic.Bool
+		var deliveryID, token atomic.Value
+		var hook *model.OutgoingWebhook
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			timestamp, err := strconv.ParseInt(r.Header.Get(model.HeaderOutgoingWebhookTimestamp), 10, 64)
+			require.NoError(t, err)
+			validSignature.Store(model.VerifyOutgoingWebhookSignature(hook.Token, timestamp, body, r.Header.Get(model.HeaderOutgoingWebhookSignature)))
+			deliveryID.Store(r.Header.Get(model.HeaderOutgoingWebhookDeliveryId))
+
+			var payload model.OutgoingWebhookPayload
+			require.NoError(t, json.Unmarshal(body, &payload))
+			token.Store(payload.Token)
+		}))
+		defer ts.Close()
+
+		hook = createHook(t, ts.URL)
+		th.App.TriggerWebhook(th.Context, getPayload(hook), hook, th.BasicPost, th.BasicChannel)
+
+		assert.True(t, validSignature.Load())
+		assert.Equal(t, hook.Token, token.Load())
+
+		deliveries, appErr := th.App.GetOutgoingWebhookDeliveries(hook.Id, 0, 10)
+		require.Nil(t, appErr)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, deliveryID.Load(), deliveries[0].Id)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusSuccess, deliveries[0].Status)
+		assert.Equal(t, http.StatusOK, deliveries[0].StatusCode)
+		assert.Equal(t, 1, deliveries[0].Attempts)
+		assert.NotContains(t, deliveries[0].Payload, hook.Token, "should not persist the token")
+	})
+
+	t.Run("should retry deliveries failing with a server error", func(t *testing.T) {
+		var requests atomic.Int32
+		var hook *model.OutgoingWebhook
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+
+			var payload model.OutgoingWebhookPayload
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, hook.Token, payload.Token)
+
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+
+		hook = createHook(t, ts.URL)
+		th.App.TriggerWebhook(th.Context, getPayload(hook), hook, th.BasicPost, th.BasicChannel)
+
+		deliveries, appErr := th.App.GetOutgoingWebhookDeliveries(hook.Id, 0, 10)
+		require.Nil(t, appErr)
+		require.Len(t, deliveries, 1)
+		delivery := deliveries[0]
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusRetrying, delivery.Status)
+		assert.Equal(t, http.StatusServiceUnavailable, delivery.StatusCode)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Greater(t, delivery.NextRetryAt, model.GetMillis())
+
+		// Make the retry due right away.
+		delivery.NextRetryAt = model.GetMillis() - 1
+		_, err := th.App.Srv().Store().Webhook().UpdateOutgoingDelivery(delivery)
+		require.NoError(t, err)
+
+		require.NoError(t, th.App.RetryOutgoingWebhookDeliveries(th.Context))
+
+		deliveries, appErr = th.App.GetOutgoingWebhookDeliveries(hook.Id, 0, 10)
+		require.Nil(t, appErr)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, deliveries[0].Status, "should give up once the maximum number of attempts is reached")
+		assert.Equal(t, 2, deliveries[0].Attempts)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("should not retry deliveries rejected by the remote end", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer ts.Close()
+
+		hook := createHook(t, ts.URL)
+		th.App.TriggerWebhook(th.Context, getPayload(hook), hook, th.BasicPost, th.BasicChannel)
+
+		deliveries, appErr := th.App.GetOutgoingWebhookDeliveries(hook.Id, 0, 10)
+		require.Nil(t, appErr)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, model.OutgoingWebhookDeliveryStatusFailed, deliveries[0].Status)
+		assert.Equal(t, http.StatusBadRequest, deliveries[0].StatusCode)
+	})
+}
+
+func TestOutgoingWebhookRequestBody(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	payload := &model.OutgoingWebhookPayload{Token: "token", TeamId: model.NewId(), Text: "text & more"}
+	persisted := *payload
+	persisted.Token = ""
+
+	t.Run("json", func(t *testing.T) {
+		persistedBody, err := json.Marshal(persisted)
+		require.NoError(t, err)
+		expected, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		body, err := outgoingWebhookRequestBody(&model.OutgoingWebhookDelivery{ContentType: "application/json", Payload: string(persistedBody)}, "token")
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), body)
+	})
+
+	t.Run("form values", func(t *testing.T) {
+		delivery := &model.OutgoingWebhookDelivery{ContentType: "application/x-www-form-urlencoded", Payload: persisted.ToFormValues()}
+
+		body, err := outgoingWebhookRequestBody(delivery, "token")
+		require.NoError(t, err)
+		assert.Equal(t, payload.ToFormValues(), body)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := outgoingWebhookRequestBody(&model.OutgoingWebhookDelivery{ContentType: "application/json", Payload: "{"}, "token")
+		require.Error(t, err)
+	})
+}
+
 type InfiniteReader struct {
 	Prefix string
 }
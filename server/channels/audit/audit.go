@@ -21,9 +21,12 @@ type Audit struct {
 
 	// OnError is called when an error occurs while writing an audit record.
 	OnError func(err error)
+
+	chain *hashChain
 }
 
 func (a *Audit) Init(maxQueueSize int) {
+	a.chain = &hashChain{}
 	a.logger, _ = mlog.NewLogger(
 		mlog.MaxQueueSize(maxQueueSize),
 		mlog.OnLoggerError(a.onLoggerError),
@@ -34,7 +37,27 @@ func (a *Audit) Init(maxQueueSize int) {
 
 // LogRecord emits an audit record with complete info.
 func (a *Audit) LogRecord(level mlog.Level, rec model.AuditRecord) {
-	flds := []mlog.Field{
+	if a.chain == nil {
+		a.logger.Log(level, "", recordFields(rec)...)
+		return
+	}
+
+	a.chain.mux.Lock()
+	defer a.chain.mux.Unlock()
+
+	if !a.chain.enabled {
+		a.logger.Log(level, "", recordFields(rec)...)
+		return
+	}
+
+	a.logChained(level, rec)
+	if link := a.chain.link(level); link.sinceCheckpoint >= a.chain.opts.CheckpointInterval {
+		a.logCheckpoint(link)
+	}
+}
+
+func recordFields(rec model.AuditRecord) []mlog.Field {
+	return []mlog.Field{
 		mlog.String(model.AuditKeyEventName, rec.EventName),
 		mlog.String(model.AuditKeyStatus, rec.Status),
 		mlog.Any(model.AuditKeyActor, rec.Actor),
@@ -42,8 +65,6 @@ func (a *Audit) LogRecord(level mlog.Level, rec model.AuditRecord) {
 		mlog.Any(model.AuditKeyMeta, rec.Meta),
 		mlog.Any(model.AuditKeyError, rec.Error),
 	}
-
-	a.logger.Log(level, "", flds...)
 }
 
 // Configure sets zero or more target to output audit logs to.
@@ -61,7 +82,10 @@ func (a *Audit) Flush() error {
 }
 
 // Shutdown cleanly stops the audit engine after making best efforts to flush all targets.
+// A checkpoint of each hash chain is logged first so that the chains end on a signed state.
 func (a *Audit) Shutdown() error {
+	a.DisableHashChain()
+
 	err := a.logger.Shutdown()
 	if err != nil {
 		a.onLoggerError(err)
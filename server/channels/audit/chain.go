@@ -0,0 +1,282 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	// CheckpointEventName is the event name of the records periodically added to a hash chain
+	// to sign its current state.
+	CheckpointEventName = "auditChainCheckpoint"
+
+	DefCheckpointInterval = 1000
+
+	KeyChainID  = "chain_id"
+	KeySeq      = "seq"
+	KeyPrevHash = "prev_hash"
+	KeyHash     = "hash"
+	// KeyCreateAt holds the time a chained record was logged, in milliseconds. Unlike the
+	// timestamp added by the logger, it is covered by the hash of the record.
+	KeyCreateAt = "create_at"
+
+	MetaKeyCheckpointSeq  = "checkpoint_seq"
+	MetaKeyCheckpointHash = "checkpoint_hash"
+	MetaKeySignature      = "signature"
+	MetaKeyKeyID          = "key_id"
+)
+
+// hashedKeys are the keys of a logged record covered by its hash. Keys added by the logger,
+// such as its timestamp and the level, are left out.
+var hashedKeys = []string{
+	model.AuditKeyEventName,
+	model.AuditKeyStatus,
+	model.AuditKeyActor,
+	model.AuditKeyEvent,
+	model.AuditKeyMeta,
+	model.AuditKeyError,
+	KeyChainID,
+	KeySeq,
+	KeyPrevHash,
+	KeyCreateAt,
+}
+
+// HashChainOptions configures the hash chain of the audit records.
+type HashChainOptions struct {
+	// CheckpointInterval is the number of records between two checkpoints of a chain.
+	CheckpointInterval int
+
+	// SigningKey signs the checkpoints. Checkpoints are not signed when nil.
+	SigningKey ed25519.PrivateKey
+}
+
+// hashChain links each audit record to the previous record logged at the same level, so that
+// deleting or editing a record breaks the chain. Each level has its own chain since targets
+// may only receive some of the levels.
+type hashChain struct {
+	mux     sync.Mutex
+	enabled bool
+	id      string
+	opts    HashChainOptions
+	links   map[string]*chainLink
+}
+
+type chainLink struct {
+	level           mlog.Level
+	seq             int64
+	hash            string
+	sinceCheckpoint int
+}
+
+func (c *hashChain) link(level mlog.Level) *chainLink {
+	link, ok := c.links[level.Name]
+	if !ok {
+		link = &chainLink{level: level}
+		c.links[level.Name] = link
+	}
+	return link
+}
+
+// checkpoint returns a record holding the sequence number and hash of the last record of a
+// chain, signed when a signing key is configured.
+func (c *hashChain) checkpoint(link *chainLink) model.AuditRecord {
+	rec := model.AuditRecord{
+		EventName: CheckpointEventName,
+		Status:    model.AuditStatusSuccess,
+		Meta: map[string]any{
+			MetaKeyCheckpointSeq:  link.seq,
+			MetaKeyCheckpointHash: link.hash,
+		},
+	}
+
+	if c.opts.SigningKey != nil {
+		signature := ed25519.Sign(c.opts.SigningKey, checkpointMessage(c.id, link.level.Name, link.seq, link.hash))
+		rec.Meta[MetaKeySignature] = base64.StdEncoding.EncodeToString(signature)
+		rec.Meta[MetaKeyKeyID] = KeyID(c.opts.SigningKey.Public().(ed25519.PublicKey))
+	}
+	return rec
+}
+
+// EnableHashChain links each record logged from now on to the previous record of the same
+// level through its hash, and periodically logs checkpoints of the chains. Calling it again
+// only updates the options of the current chains. Init must be called first.
+func (a *Audit) EnableHashChain(opts HashChainOptions) {
+	if opts.CheckpointInterval <= 0 {
+		opts.CheckpointInterval = DefCheckpointInterval
+	}
+
+	a.chain.mux.Lock()
+	defer a.chain.mux.Unlock()
+
+	if !a.chain.enabled {
+		a.chain.enabled = true
+		a.chain.id = model.NewId()
+		a.chain.links = make(map[string]*chainLink)
+	}
+	a.chain.opts = opts
+}
+
+// DisableHashChain stops linking records, after logging a checkpoint of each chain.
+func (a *Audit) DisableHashChain() {
+	if a.chain == nil {
+		return
+	}
+
+	a.chain.mux.Lock()
+	defer a.chain.mux.Unlock()
+
+	a.checkpointAll()
+	a.chain.enabled = false
+}
+
+// checkpointAll logs a checkpoint for each chain with records since its last checkpoint.
+// The caller must hold the chain lock.
+func (a *Audit) checkpointAll() {
+	if !a.chain.enabled {
+		return
+	}
+	for _, link := range a.chain.links {
+		if link.sinceCheckpoint > 0 {
+			a.logCheckpoint(link)
+		}
+	}
+}
+
+// logChained logs a record with its position in the chain of its level. The caller must
+// hold the chain lock.
+func (a *Audit) logChained(level mlog.Level, rec model.AuditRecord) {
+	link := a.chain.link(level)
+	seq := link.seq + 1
+	createAt := model.GetMillis()
+
+	hash, err := hashRecord(map[string]any{
+		model.AuditKeyEventName: rec.EventName,
+		model.AuditKeyStatus:    rec.Status,
+		model.AuditKeyActor:     rec.Actor,
+		model.AuditKeyEvent:     rec.EventData,
+		model.AuditKeyMeta:      rec.Meta,
+		model.AuditKeyError:     rec.Error,
+		KeyChainID:              a.chain.id,
+		KeySeq:                  seq,
+		KeyPrevHash:             link.hash,
+		KeyCreateAt:             createAt,
+	})
+	if err != nil {
+		// the record can't be encoded, so it is logged without taking a place in the chain.
+		a.onLoggerError(fmt.Errorf("cannot hash audit record %s: %w", rec.EventName, err))
+		a.logger.Log(level, "", recordFields(rec)...)
+		return
+	}
+
+	flds := append(recordFields(rec),
+		mlog.String(KeyChainID, a.chain.id),
+		mlog.Int(KeySeq, seq),
+		mlog.String(KeyPrevHash, link.hash),
+		mlog.Int(KeyCreateAt, createAt),
+		mlog.String(KeyHash, hash),
+	)
+	a.logger.Log(level, "", flds...)
+
+	link.seq = seq
+	link.hash = hash
+	link.sinceCheckpoint++
+}
+
+// logCheckpoint logs a checkpoint of a chain. The caller must hold the chain lock.
+func (a *Audit) logCheckpoint(link *chainLink) {
+	a.logChained(link.level, a.chain.checkpoint(link))
+	link.sinceCheckpoint = 0
+}
+
+// hashRecord returns the hex encoded SHA-256 of the canonical JSON encoding of a record.
+func hashRecord(fields any) (string, error) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	canonical, err := canonicalJSON(data)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON re-encodes JSON with sorted object keys and consistent escaping, so that
+// the same record gives the same bytes no matter which encoder wrote it.
+func canonicalJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+func checkpointMessage(chainID, level string, seq int64, hash string) []byte {
+	return []byte("mattermost-audit-checkpoint\n" + chainID + "\n" + level + "\n" + strconv.FormatInt(seq, 10) + "\n" + hash)
+}
+
+// KeyID returns a short fingerprint of a checkpoint signing key.
+func KeyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// ParseSigningKey parses a PEM encoded PKCS #8 Ed25519 private key.
+func ParseSigningKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse private key: %w", err)
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T, expected an Ed25519 key", key)
+	}
+	return edKey, nil
+}
+
+// ParseVerifyKey parses a PEM encoded PKIX Ed25519 public key.
+func ParseVerifyKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse public key: %w", err)
+	}
+
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T, expected an Ed25519 key", key)
+	}
+	return edKey, nil
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// writeChainedLog logs count records per level with the hash chain enabled and returns the
+// lines of the resulting log file.
+func writeChainedLog(t *testing.T, opts HashChainOptions, levels []mlog.Level, count int) []string {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), "audit.log")
+	cfg := mlog.TargetCfg{
+		Type:    "file",
+		Format:  "json",
+		Levels:  levels,
+		Options: json.RawMessage(fmt.Sprintf(`{"filename": "%s"}`, filePath)),
+	}
+
+	audit := &Audit{}
+	audit.Init(DefMaxQueueSize)
+	require.NoError(t, audit.Configure(map[string]mlog.TargetCfg{"file": cfg}))
+	audit.EnableHashChain(opts)
+
+	for i := range count {
+		for _, level := range levels {
+			rec := model.AuditRecord{
+				EventName: "updateUser",
+				Status:    model.AuditStatusSuccess,
+				Actor:     model.AuditEventActor{UserId: model.NewId(), IpAddress: "127.0.0.1"},
+				EventData: model.AuditEventData{
+					Parameters: map[string]any{"index": i, "ratio": 0.5, "name": "<b>&amp; é"},
+				},
+				Meta: map[string]any{model.AuditKeyClusterID: "cluster"},
+			}
+			audit.LogRecord(level, rec)
+		}
+	}
+	require.NoError(t, audit.Shutdown())
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func verifyLines(t *testing.T, opts VerifyOptions, lines []string) *VerifyResult {
+	t.Helper()
+
+	v := NewVerifier(opts)
+	require.NoError(t, v.Verify("audit.log", strings.NewReader(strings.Join(lines, "\n"))))
+	return v.Result()
+}
+
+func generateKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return public, private
+}
+
+func TestHashChain(t *testing.T) {
+	levels := []mlog.Level{mlog.LvlAuditAPI, mlog.LvlAuditCLI}
+
+	t.Run("intact log verifies", func(t *testing.T) {
+		public, private := generateKey(t)
+		lines := writeChainedLog(t, HashChainOptions{CheckpointInterval: 4, SigningKey: private}, levels, 10)
+
+		// 10 records and 3 checkpoints per level.
+		require.Len(t, lines, 26)
+
+		result := verifyLines(t, VerifyOptions{PublicKey: public}, lines)
+		assert.Empty(t, result.Problems)
+		assert.Equal(t, 26, result.Records)
+		assert.Zero(t, result.UnchainedRecords)
+		require.Len(t, result.Chains, 2)
+		for _, chain := range result.Chains {
+			assert.Equal(t, int64(1), chain.FirstSeq)
+			assert.Equal(t, int64(13), chain.LastSeq)
+			assert.Equal(t, 3, chain.Checkpoints)
+			assert.Equal(t, 3, chain.SignedCheckpoints)
+			assert.True(t, chain.EndsWithCheckpoint)
+		}
+	})
+
+	t.Run("detects modified record", func(t *testing.T) {
+		lines := writeChainedLog(t, HashChainOptions{CheckpointInterval: 100}, levels[:1], 5)
+		lines[2] = strings.Replace(lines[2], `"status":"success"`, `"status":"fail"`, 1)
+
+		result := verifyLines(t, VerifyOptions{}, lines)
+		require.Len(t, result.Problems, 1)
+		assert.Equal(t, 3, result.Problems[0].Line)
+		assert.Contains(t, result.Problems[0].Message, "modified")
+	})
+
+	t.Run("detects deleted record", func(t *testing.T) {
+		lines := writeChainedLog(t, HashChainOptions{CheckpointInterval: 100}, levels[:1], 5)
+		lines = append(lines[:2], lines[3:]...)
+
+		result := verifyLines(t, VerifyOptions{}, lines)
+		require.Len(t, result.Problems, 1)
+		assert.Contains(t, result.Problems[0].Message, "records 3 to 3 are missing")
+	})
+
+	t.Run("detects truncated log", func(t *testing.T) {
+		lines := writeChainedLog(t, HashChainOptions{CheckpointInterval: 100}, levels[:1], 5)
+		lines = lines[:len(lines)-2]
+
+		result := verifyLines(t, VerifyOptions{}, lines)
+		assert.Empty(t, result.Problems)
+		require.Len(t, result.Chains, 1)
+		assert.False(t, result.Chains[0].EndsWithCheckpoint)
+	})
+
+	t.Run("detects truncated head", func(t *testing.T) {
+		lines := writeChainedLog(t, HashChainOptions{CheckpointInterval: 100}, levels[:1], 5)
+		lines = lines[2:]
+
+		result := verifyLines(t, VerifyOptions{}, lines)
+		require.Len(t, result.Problems, 1)
+		assert.Equal(t, 1, result.Problems[0].Line)
+		assert.Equal(t, int64(3), result.Problems[0].Seq)
+		assert.Contains(t, result.Problems[0].Message, "records 1 to 2 are missing")
+		assert.Empty(t, result.Warnings)
+		require.Len(t, result.Chains, 1)
+		assert.Equal(t, int64(3), result.Chains[0].FirstSeq)
+	})
+
+	t.Run("detects checkpoint signed with another key", func(t *testing.T) {
+		_, private := generateKey(t)
+		otherPublic, _ := generateKey(t)
+		lines := writeChainedLog(t, HashChainOptions{CheckpointInterval: 2, SigningKey: private}, levels[:1], 2)
+
+		result := verifyLines(t, VerifyOptions{PublicKey: otherPublic}, lines)
+		require.Len(t, result.Problems, 2)
+		assert.Contains(t, result.Problems[0].Message, "another key")
+		assert.Contains(t, result.Problems[1].Message, "chain has no signed checkpoint")
+	})
+
+	t.Run("detects modified timestamp", func(t *testing.T) {
+		lines := writeChainedLog(t, HashChainOptions{CheckpointInterval: 100}, levels[:1], 3)
+
+		var fields map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &fields))
+		fields[KeyCreateAt] = fields[KeyCreateAt].(float64) - 3600000
+		data, err := json.Marshal(fields)
+		require.NoError(t, err)
+		lines[1] = string(data)
+
+		result := verifyLines(t, VerifyOptions{}, lines)
+		require.Len(t, result.Problems, 1)
+		assert.Equal(t, 2, result.Problems[0].Line)
+		assert.Contains(t, result.Problems[0].Message, "modified")
+	})
+
+	t.Run("detects unsigned checkpoints", func(t *testing.T) {
+		public, private := generateKey(t)
+
+		// a log rewritten as a new chain, with valid hashes but no signatures.
+		lines := writeChainedLog(t, HashChainOptions{CheckpointInterval: 2}, levels[:1], 4)
+
+		result := verifyLines(t, VerifyOptions{}, lines)
+		assert.Empty(t, result.Problems)
+
+		result = verifyLines(t, VerifyOptions{PublicKey: public}, lines)
+		require.Len(t, result.Problems, 3)
+		assert.Contains(t, result.Problems[0].Message, "checkpoint is not signed")
+		assert.Contains(t, result.Problems[1].Message, "checkpoint is not signed")
+		assert.Equal(t, 1, result.Problems[2].Line)
+		assert.Contains(t, result.Problems[2].Message, "chain has no signed checkpoint")
+
+		// a chain with signed checkpoints is accepted.
+		lines = writeChainedLog(t, HashChainOptions{CheckpointInterval: 2, SigningKey: private}, levels[:1], 4)
+		result = verifyLines(t, VerifyOptions{PublicKey: public}, lines)
+		assert.Empty(t, result.Problems)
+	})
+
+	t.Run("verifies rotated logs in order", func(t *testing.T) {
+		lines := writeChainedLog(t, HashChainOptions{CheckpointInterval: 100}, levels[:1], 6)
+
+		v := NewVerifier(VerifyOptions{})
+		require.NoError(t, v.Verify("audit.1.log", strings.NewReader(strings.Join(lines[:3], "\n"))))
+		require.NoError(t, v.Verify("audit.log", strings.NewReader(strings.Join(lines[3:], "\n"))))
+		assert.Empty(t, v.Result().Problems)
+
+		// the second file alone starts in the middle of the chain.
+		result := verifyLines(t, VerifyOptions{AllowPartialChains: true}, lines[3:])
+		assert.Empty(t, result.Problems)
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0].Message, "starts at sequence number 4")
+		require.Len(t, result.Chains, 1)
+		assert.Equal(t, int64(4), result.Chains[0].FirstSeq)
+	})
+
+	t.Run("records are not chained when disabled", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "audit.log")
+		audit := &Audit{}
+		audit.Init(DefMaxQueueSize)
+		require.NoError(t, audit.Configure(map[string]mlog.TargetCfg{"file": {
+			Type:    "file",
+			Format:  "json",
+			Levels:  levels,
+			Options: json.RawMessage(fmt.Sprintf(`{"filename": "%s"}`, filePath)),
+		}}))
+		audit.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: "login"})
+		require.NoError(t, audit.Shutdown())
+
+		data, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), KeyChainID)
+
+		v := NewVerifier(VerifyOptions{})
+		require.NoError(t, v.Verify("audit.log", bytes.NewReader(data)))
+		assert.Equal(t, 1, v.Result().UnchainedRecords)
+	})
+}
+
+func TestParseKeys(t *testing.T) {
+	public, private := generateKey(t)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	parsedPrivate, err := ParseSigningKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	require.NoError(t, err)
+	assert.Equal(t, private, parsedPrivate)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+	parsedPublic, err := ParseVerifyKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	require.NoError(t, err)
+	assert.Equal(t, public, parsedPublic)
+
+	_, err = ParseSigningKey([]byte("not a key"))
+	require.Error(t, err)
+}
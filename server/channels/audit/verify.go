@@ -0,0 +1,276 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// VerifyOptions configures the verification of audit logs.
+type VerifyOptions struct {
+	// PublicKey verifies the checkpoint signatures. When set, every checkpoint must be signed
+	// with it and every chain must have at least one signed checkpoint, so that a log
+	// rewritten as a new chain without signatures is reported. Signatures are not checked
+	// when nil.
+	PublicKey ed25519.PublicKey
+	// AllowPartialChains reports chains that don't start at the first record as warnings
+	// instead of problems, for when older rotated logs are no longer available.
+	AllowPartialChains bool
+}
+
+// ChainSummary describes a hash chain found in the verified audit logs.
+type ChainSummary struct {
+	ChainID            string `json:"chain_id"`
+	Level              string `json:"level"`
+	FirstSeq           int64  `json:"first_seq"`
+	LastSeq            int64  `json:"last_seq"`
+	Records            int    `json:"records"`
+	Checkpoints        int    `json:"checkpoints"`
+	SignedCheckpoints  int    `json:"signed_checkpoints"`
+	EndsWithCheckpoint bool   `json:"ends_with_checkpoint"`
+}
+
+// VerifyProblem describes a record that shows the audit logs were altered, or that could not
+// be fully verified when reported as a warning.
+type VerifyProblem struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	ChainID string `json:"chain_id,omitempty"`
+	Level   string `json:"level,omitempty"`
+	Seq     int64  `json:"seq,omitempty"`
+	Message string `json:"message"`
+}
+
+// VerifyResult is the outcome of the verification of audit logs.
+type VerifyResult struct {
+	Records          int             `json:"records"`
+	UnchainedRecords int             `json:"unchained_records"`
+	Chains           []*ChainSummary `json:"chains"`
+	Problems         []VerifyProblem `json:"problems"`
+	Warnings         []VerifyProblem `json:"warnings"`
+}
+
+// Verifier checks the hash chains of audit logs written with the hash chain enabled. Logs
+// split over several files, for instance by rotation, must be verified in the order they
+// were written.
+type Verifier struct {
+	opts   VerifyOptions
+	result VerifyResult
+	chains map[string]*verifiedChain
+}
+
+type verifiedChain struct {
+	summary *ChainSummary
+	hash    string
+	// first locates the first verified record of the chain.
+	first VerifyProblem
+}
+
+func NewVerifier(opts VerifyOptions) *Verifier {
+	return &Verifier{
+		opts: opts,
+		result: VerifyResult{
+			Chains:   []*ChainSummary{},
+			Problems: []VerifyProblem{},
+			Warnings: []VerifyProblem{},
+		},
+		chains: make(map[string]*verifiedChain),
+	}
+}
+
+// Verify checks the records of an audit log in JSON format. name identifies the log in the
+// reported problems.
+func (v *Verifier) Verify(name string, r io.Reader) error {
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(data)) != 0 {
+			v.verifyRecord(name, line, data)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("cannot read %s: %w", name, err)
+		}
+	}
+}
+
+// Result returns the outcome of the verification of all the logs verified so far.
+func (v *Verifier) Result() *VerifyResult {
+	result := v.result
+	if v.opts.PublicKey == nil {
+		return &result
+	}
+
+	// a chain without any signed checkpoint can't be told apart from a forged one.
+	result.Problems = slices.Clone(v.result.Problems)
+	for _, chain := range v.result.Chains {
+		if chain.SignedCheckpoints == 0 {
+			p := v.chains[chain.ChainID+"/"+chain.Level].first
+			p.Message = "chain has no signed checkpoint"
+			result.Problems = append(result.Problems, p)
+		}
+	}
+	return &result
+}
+
+func (v *Verifier) verifyRecord(name string, line int, data []byte) {
+	problem := VerifyProblem{File: name, Line: line}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		problem.Message = "not a JSON audit record"
+		v.result.Problems = append(v.result.Problems, problem)
+		return
+	}
+	v.result.Records++
+
+	if _, ok := fields[KeyChainID]; !ok {
+		v.result.UnchainedRecords++
+		return
+	}
+
+	var chainID, level, prevHash, hash, eventName string
+	var seq int64
+	if err := unmarshalFields(fields, map[string]any{
+		KeyChainID:              &chainID,
+		"level":                 &level,
+		KeySeq:                  &seq,
+		KeyPrevHash:             &prevHash,
+		KeyHash:                 &hash,
+		model.AuditKeyEventName: &eventName,
+	}); err != nil {
+		problem.Message = "malformed hash chain fields: " + err.Error()
+		v.result.Problems = append(v.result.Problems, problem)
+		return
+	}
+	problem.ChainID = chainID
+	problem.Level = level
+	problem.Seq = seq
+
+	report := func(msg string, args ...any) {
+		p := problem
+		p.Message = fmt.Sprintf(msg, args...)
+		v.result.Problems = append(v.result.Problems, p)
+	}
+
+	key := chainID + "/" + level
+	chain, ok := v.chains[key]
+	if !ok {
+		chain = &verifiedChain{summary: &ChainSummary{ChainID: chainID, Level: level, FirstSeq: seq}, first: problem}
+		v.chains[key] = chain
+		v.result.Chains = append(v.result.Chains, chain.summary)
+
+		// the head of the chain is missing when its first records were deleted, or when the
+		// log was rotated and the older files are not verified.
+		if seq != 1 {
+			if v.opts.AllowPartialChains {
+				p := problem
+				p.Message = fmt.Sprintf("chain starts at sequence number %d, the records before it were not verified", seq)
+				v.result.Warnings = append(v.result.Warnings, p)
+			} else {
+				report("records 1 to %d are missing at the start of the chain", seq-1)
+			}
+		}
+	} else {
+		switch {
+		case seq == chain.summary.LastSeq+1:
+			if prevHash != chain.hash {
+				report("record does not link to the previous record")
+			}
+		case seq > chain.summary.LastSeq+1:
+			report("records %d to %d are missing", chain.summary.LastSeq+1, seq-1)
+		default:
+			report("record is out of order or duplicated, expected sequence number %d", chain.summary.LastSeq+1)
+		}
+	}
+
+	hashed := make(map[string]json.RawMessage, len(hashedKeys))
+	for _, k := range hashedKeys {
+		if value, ok := fields[k]; ok {
+			hashed[k] = value
+		}
+	}
+	if computed, err := hashRecord(hashed); err != nil || computed != hash {
+		report("record was modified, its hash does not match its content")
+	}
+
+	if eventName == CheckpointEventName {
+		v.verifyCheckpoint(chain, fields, report)
+	} else {
+		chain.summary.EndsWithCheckpoint = false
+	}
+
+	chain.summary.Records++
+	chain.summary.LastSeq = seq
+	chain.hash = hash
+}
+
+func (v *Verifier) verifyCheckpoint(chain *verifiedChain, fields map[string]json.RawMessage, report func(string, ...any)) {
+	chain.summary.Checkpoints++
+	chain.summary.EndsWithCheckpoint = true
+
+	var meta struct {
+		Seq       json.Number `json:"checkpoint_seq"`
+		Hash      string      `json:"checkpoint_hash"`
+		Signature string      `json:"signature"`
+		KeyID     string      `json:"key_id"`
+	}
+	if err := json.Unmarshal(fields[model.AuditKeyMeta], &meta); err != nil {
+		report("malformed checkpoint: %s", err.Error())
+		return
+	}
+	seq, err := meta.Seq.Int64()
+	if err != nil {
+		report("malformed checkpoint sequence number")
+		return
+	}
+
+	// the checkpoint covers the record before it, which is only known if it was verified.
+	if chain.summary.Records > 0 && (seq != chain.summary.LastSeq || meta.Hash != chain.hash) {
+		report("checkpoint does not match the previous record")
+	}
+
+	if v.opts.PublicKey == nil {
+		return
+	}
+	if meta.Signature == "" {
+		report("checkpoint is not signed")
+		return
+	}
+	if meta.KeyID != KeyID(v.opts.PublicKey) {
+		report("checkpoint was signed with another key (%s)", meta.KeyID)
+		return
+	}
+	signature, err := base64.StdEncoding.DecodeString(meta.Signature)
+	if err != nil || !ed25519.Verify(v.opts.PublicKey, checkpointMessage(chain.summary.ChainID, chain.summary.Level, seq, meta.Hash), signature) {
+		report("checkpoint signature is invalid")
+		return
+	}
+	chain.summary.SignedCheckpoints++
+}
+
+func unmarshalFields(fields map[string]json.RawMessage, dest map[string]any) error {
+	for key, value := range dest {
+		raw, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("missing %s", key)
+		}
+		if err := json.Unmarshal(raw, value); err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS OutgoingWebhookDeliveries (
+    Id          VARCHAR(26)   PRIMARY KEY,
+    HookId      VARCHAR(26)   NOT NULL,
+    PostId      VARCHAR(26)   NOT NULL,
+    CallbackURL VARCHAR(1024) NOT NULL,
+    ContentType VARCHAR(128)  NOT NULL DEFAULT '',
+    Payload     TEXT          NOT NULL DEFAULT '',
+    Status      VARCHAR(16)   NOT NULL,
+    StatusCode  INTEGER       NOT NULL DEFAULT 0,
+    LatencyMs   BIGINT        NOT NULL DEFAULT 0,
+    Attempts    INTEGER       NOT NULL DEFAULT 0,
+    LastError   VARCHAR(1024) NOT NULL DEFAULT '',
+    NextRetryAt BIGINT        NOT NULL DEFAULT 0,
+    CreateAt    BIGINT        NOT NULL,
+    UpdateAt    BIGINT        NOT NULL
+);
@@ -0,0 +1,3 @@
+-- morph:nontransactional
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_outgoingwebhookdeliveries_hookid_createat
+    ON OutgoingWebhookDeliveries (HookId, CreateAt);
@@ -0,0 +1,4 @@
+-- morph:nontransactional
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_outgoingwebhookdeliveries_nextretryat
+    ON OutgoingWebhookDeliveries (NextRetryAt)
+    WHERE Status IN ('pending', 'retrying');
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS PendingEmailNotifications (
+    Id       VARCHAR(26) PRIMARY KEY,
+    UserId   VARCHAR(26) NOT NULL,
+    PostId   VARCHAR(26) NOT NULL,
+    TeamId   VARCHAR(26) NOT NULL,
+    CreateAt BIGINT      NOT NULL
+);
@@ -0,0 +1,3 @@
+-- morph:nontransactional
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_pendingemailnotifications_userid_createat
+    ON PendingEmailNotifications (UserId, CreateAt);
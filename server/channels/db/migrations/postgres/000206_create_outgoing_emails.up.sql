@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS OutgoingEmails (
+    Id               VARCHAR(26)   PRIMARY KEY,
+    ToAddress        TEXT          NOT NULL,
+    CcAddress        TEXT          NOT NULL DEFAULT '',
+    ReplyTo          VARCHAR(320)  NOT NULL DEFAULT '',
+    Subject          TEXT          NOT NULL DEFAULT '',
+    HTMLBody         TEXT          NOT NULL DEFAULT '',
+    EmbeddedFiles    JSONB         NOT NULL DEFAULT '{}',
+    MessageId        VARCHAR(998)  NOT NULL DEFAULT '',
+    InReplyTo        VARCHAR(998)  NOT NULL DEFAULT '',
+    ReferencesHeader TEXT          NOT NULL DEFAULT '',
+    Category         VARCHAR(64)   NOT NULL DEFAULT '',
+    Status           VARCHAR(16)   NOT NULL,
+    Attempts         INTEGER       NOT NULL DEFAULT 0,
+    LastError        VARCHAR(1024) NOT NULL DEFAULT '',
+    NextAttemptAt    BIGINT        NOT NULL DEFAULT 0,
+    CreateAt         BIGINT        NOT NULL,
+    UpdateAt         BIGINT        NOT NULL
+);
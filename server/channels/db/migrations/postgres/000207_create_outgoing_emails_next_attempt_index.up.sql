@@ -0,0 +1,4 @@
+-- morph:nontransactional
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_outgoingemails_nextattemptat
+    ON OutgoingEmails (NextAttemptAt)
+    WHERE Status = 'pending';
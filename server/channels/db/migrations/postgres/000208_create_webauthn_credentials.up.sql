@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS WebAuthnCredentials (
+    Id           VARCHAR(26)   PRIMARY KEY,
+    UserId       VARCHAR(26)   NOT NULL,
+    Name         VARCHAR(64)   NOT NULL,
+    CredentialId VARCHAR(1400) NOT NULL,
+    PublicKey    BYTEA         NOT NULL,
+    SignCount    BIGINT        NOT NULL DEFAULT 0,
+    CreateAt     BIGINT        NOT NULL,
+    LastUsedAt   BIGINT        NOT NULL DEFAULT 0
+);
@@ -0,0 +1,3 @@
+-- morph:nontransactional
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_webauthncredentials_userid
+    ON WebAuthnCredentials (UserId);
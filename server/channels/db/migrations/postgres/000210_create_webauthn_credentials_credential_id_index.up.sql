@@ -0,0 +1,3 @@
+-- morph:nontransactional
+CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS idx_webauthncredentials_credentialid
+    ON WebAuthnCredentials (CredentialId);
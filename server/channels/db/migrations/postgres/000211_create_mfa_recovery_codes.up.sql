@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS MfaRecoveryCodes (
+    UserId   VARCHAR(26) NOT NULL,
+    CodeHash VARCHAR(64) NOT NULL,
+    CreateAt BIGINT      NOT NULL,
+    PRIMARY KEY (UserId, CodeHash)
+);
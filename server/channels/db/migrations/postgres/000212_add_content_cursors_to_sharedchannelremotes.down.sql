@@ -0,0 +1,3 @@
+ALTER TABLE sharedchannelremotes DROP COLUMN IF EXISTS lastpropertyvalueupdateat;
+ALTER TABLE sharedchannelremotes DROP COLUMN IF EXISTS lastbookmarkupdateat;
+ALTER TABLE sharedchannelremotes DROP COLUMN IF EXISTS lastchannelupdateat;
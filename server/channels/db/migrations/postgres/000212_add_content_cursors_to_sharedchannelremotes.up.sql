@@ -0,0 +1,3 @@
+ALTER TABLE sharedchannelremotes ADD COLUMN IF NOT EXISTS lastchannelupdateat bigint DEFAULT 0;
+ALTER TABLE sharedchannelremotes ADD COLUMN IF NOT EXISTS lastbookmarkupdateat bigint DEFAULT 0;
+ALTER TABLE sharedchannelremotes ADD COLUMN IF NOT EXISTS lastpropertyvalueupdateat bigint DEFAULT 0;
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS ClusterMessages (
+    Id       VARCHAR(26) PRIMARY KEY,
+    Data     BYTEA       NOT NULL,
+    CreateAt BIGINT      NOT NULL
+);
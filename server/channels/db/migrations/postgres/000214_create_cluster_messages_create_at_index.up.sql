@@ -0,0 +1,3 @@
+-- morph:nontransactional
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_clustermessages_createat
+    ON ClusterMessages (CreateAt);
@@ -0,0 +1,2 @@
+ALTER TABLE fileinfo DROP COLUMN IF EXISTS scannedat;
+ALTER TABLE fileinfo DROP COLUMN IF EXISTS scanstatus;
@@ -0,0 +1,2 @@
+ALTER TABLE fileinfo ADD COLUMN IF NOT EXISTS scanstatus varchar(16) DEFAULT '';
+ALTER TABLE fileinfo ADD COLUMN IF NOT EXISTS scannedat bigint DEFAULT 0;
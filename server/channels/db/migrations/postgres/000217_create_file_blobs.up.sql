@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS FileBlobs (
+    Hash     VARCHAR(64) PRIMARY KEY,
+    Size     BIGINT      NOT NULL,
+    RefCount BIGINT      NOT NULL,
+    CreateAt BIGINT      NOT NULL
+);
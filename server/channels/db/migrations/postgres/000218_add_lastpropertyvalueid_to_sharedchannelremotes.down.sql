@@ -0,0 +1 @@
+ALTER TABLE sharedchannelremotes DROP COLUMN IF EXISTS lastpropertyvalueid;
@@ -0,0 +1 @@
+ALTER TABLE sharedchannelremotes ADD COLUMN IF NOT EXISTS lastpropertyvalueid varchar(26) DEFAULT '';
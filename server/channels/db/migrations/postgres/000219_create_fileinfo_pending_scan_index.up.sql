@@ -0,0 +1,4 @@
+-- morph:nontransactional
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_fileinfo_pending_scan
+    ON FileInfo (Id)
+    WHERE ScanStatus = 'pending';
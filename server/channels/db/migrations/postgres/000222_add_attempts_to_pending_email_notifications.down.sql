@@ -0,0 +1 @@
+ALTER TABLE PendingEmailNotifications DROP COLUMN IF EXISTS Attempts;
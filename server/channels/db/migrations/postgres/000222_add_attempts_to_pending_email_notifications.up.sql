@@ -0,0 +1 @@
+ALTER TABLE PendingEmailNotifications ADD COLUMN IF NOT EXISTS Attempts INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,55 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package email_batching
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+// schedFreq is how often a new job is created to take over from the running one, so that
+// a job lost along with its node is eventually replaced.
+const schedFreq = 15 * time.Minute
+
+type Scheduler struct {
+	*jobs.PeriodicScheduler
+	jobServer *jobs.JobServer
+}
+
+func (scheduler *Scheduler) NextScheduleTime(cfg *model.Config, now time.Time, pendingJobs bool, lastSuccessfulJob *model.Job) *time.Time {
+	// Without a running job, the batched emails aren't sent until one is created.
+	if !pendingJobs {
+		count, err := scheduler.jobServer.Store.Job().GetCountByStatusAndType(model.JobStatusInProgress, model.JobTypeEmailBatching)
+		if err != nil {
+			mlog.Warn("Failed to count the running email batching jobs", mlog.Err(err))
+		} else if count == 0 {
+			return &now
+		}
+	}
+
+	return scheduler.PeriodicScheduler.NextScheduleTime(cfg, now, pendingJobs, lastSuccessfulJob)
+}
+
+func (scheduler *Scheduler) ScheduleJob(rctx request.CTX, cfg *model.Config, pendingJobs bool, lastSuccessfulJob *model.Job) (*model.Job, *model.AppError) {
+	// The pending job takes over once it is claimed, so another one isn't needed.
+	if pendingJobs {
+		return nil, nil
+	}
+
+	return scheduler.PeriodicScheduler.ScheduleJob(rctx, cfg, pendingJobs, lastSuccessfulJob)
+}
+
+func MakeScheduler(jobServer *jobs.JobServer) *Scheduler {
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.EmailSettings.EnableEmailBatching
+	}
+	return &Scheduler{
+		PeriodicScheduler: jobs.NewPeriodicScheduler(jobServer, model.JobTypeEmailBatching, schedFreq, isEnabled),
+		jobServer:         jobServer,
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package email_batching
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// timeBetweenChecks is how often a job checks whether it was replaced, and whether the
+// batching interval has passed since it last sent the batched emails.
+const timeBetweenChecks = 10 * time.Second
+
+type EmailServiceIface interface {
+	SendBatchedEmailNotifications() error
+}
+
+// MakeWorker creates a worker that sends the batched email notifications whose batching
+// interval has passed. Running it from the jobs server makes sure that only one node in a
+// cluster sends them. Each job keeps sending them every EmailBatchingInterval until a newer
+// job is scheduled, so that the Jobs table doesn't get a row every interval.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, emailService EmailServiceIface) *jobs.BatchWorker {
+	var lastSentAt time.Time
+
+	doBatch := func(rctx request.CTX, job *model.Job) bool {
+		newest, err := store.Job().GetNewestJobByStatusesAndType([]string{model.JobStatusPending, model.JobStatusInProgress}, model.JobTypeEmailBatching)
+		if err != nil {
+			rctx.Logger().Warn("Worker: Failed to get the newest email batching job", mlog.Err(err))
+		} else if newest.Id != job.Id && newest.CreateAt >= job.CreateAt {
+			rctx.Logger().Debug("Worker: Handing over to a newer job", mlog.String("newer_job_id", newest.Id))
+			return finish(rctx, jobServer, job)
+		}
+
+		cfg := jobServer.Config()
+		if !*cfg.EmailSettings.EnableEmailBatching {
+			return finish(rctx, jobServer, job)
+		}
+
+		if time.Since(lastSentAt) < time.Duration(*cfg.EmailSettings.EmailBatchingInterval)*time.Second {
+			return false
+		}
+		lastSentAt = time.Now()
+
+		if err := emailService.SendBatchedEmailNotifications(); err != nil {
+			rctx.Logger().Error("Worker: Failed to send batched email notifications", mlog.Err(err))
+		}
+		return false
+	}
+
+	return jobs.MakeBatchWorker(jobServer, store, timeBetweenChecks, doBatch)
+}
+
+func finish(rctx request.CTX, jobServer *jobs.JobServer, job *model.Job) bool {
+	if err := jobServer.SetJobSuccess(job); err != nil {
+		rctx.Logger().Error("Worker: Failed to set success for job", mlog.Err(err))
+	}
+	return true
+}
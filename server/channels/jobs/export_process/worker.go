@@ -5,8 +5,11 @@ package export_process
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/configservice"
@@ -53,6 +56,21 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			opts.IncludeRolesAndSchemes = true
 		}
 
+		if teamIDs := job.Data["team_ids"]; teamIDs != "" {
+			opts.TeamIds = strings.Split(teamIDs, ",")
+		}
+
+		if channelIDs := job.Data["channel_ids"]; channelIDs != "" {
+			opts.ChannelIds = strings.Split(channelIDs, ",")
+		}
+
+		if since := job.Data["since"]; since != "" {
+			var err error
+			if opts.Since, err = strconv.ParseInt(since, 10, 64); err != nil {
+				return fmt.Errorf("invalid since timestamp %q: %w", since, err)
+			}
+		}
+
 		outPath := *app.Config().ExportSettings.Directory
 		exportFilename := job.Id + "_export.zip"
 
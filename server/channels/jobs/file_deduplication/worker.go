@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_deduplication
+
+import (
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const batchSize = 1000
+
+type AppIface interface {
+	DeduplicateFile(rctx request.CTX, info *model.FileInfo) *model.AppError
+}
+
+// MakeWorker returns the worker of the jobs moving the files uploaded before file deduplication
+// was enabled to the blobs shared by every file with the same content. Files that aren't known
+// to be clean when file scanning is used are left in place.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface, store store.Store) *jobs.SimpleWorker {
+	const workerName = "FileDeduplication"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.FileSettings.DeduplicateFiles
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		var nFiles, nDeduplicated, nErrs int
+		var startTime int64
+		var startFileID string
+		for {
+			fileInfos, err := store.FileInfo().GetFilesBatchForIndexing(startTime, startFileID, true, batchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, fileInfo := range fileInfos {
+				nFiles++
+
+				switch fileInfo.ScanStatus {
+				case model.FileScanStatusPending, model.FileScanStatusInfected, model.FileScanStatusFailed:
+					continue
+				}
+				if fileInfo.IsDeduplicated() {
+					continue
+				}
+
+				if appErr := app.DeduplicateFile(request.EmptyContext(logger), &fileInfo.FileInfo); appErr != nil {
+					logger.Warn("Failed to deduplicate file", mlog.Err(appErr), mlog.String("file_info_id", fileInfo.Id))
+					nErrs++
+					continue
+				}
+				nDeduplicated++
+			}
+
+			job.Data["processed"] = strconv.Itoa(nFiles)
+			job.Data["deduplicated"] = strconv.Itoa(nDeduplicated)
+			job.Data["errors"] = strconv.Itoa(nErrs)
+			if err := jobServer.UpdateInProgressJobData(job); err != nil {
+				logger.Error("Worker: Failed to update job data", mlog.Err(err))
+			}
+
+			if len(fileInfos) < batchSize {
+				break
+			}
+			lastFileInfo := fileInfos[len(fileInfos)-1]
+			startTime, startFileID = lastFileInfo.CreateAt, lastFileInfo.Id
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+	return worker
+}
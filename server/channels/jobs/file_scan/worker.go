@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_scan
+
+import (
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const batchSize = 1000
+
+type AppIface interface {
+	RescanFile(rctx request.CTX, info *model.FileInfo) *model.AppError
+}
+
+// MakeWorker returns the worker of the jobs scanning again the files uploaded between the
+// optional "from" and "to" timestamps of the job, expressed in seconds since the unix epoch.
+// Files can be scanned again after the signatures of the scanning server are updated, or to
+// scan the files that were uploaded before file scanning was enabled. Files found clean that
+// weren't before are deduplicated and their content extracted, as on upload.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface, store store.Store) *jobs.SimpleWorker {
+	const workerName = "FileScan"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.FileScanSettings.Enable
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		var err error
+		var fromTS int64
+		var toTS int64 = model.GetMillis()
+		if fromStr, ok := job.Data["from"]; ok {
+			if fromTS, err = strconv.ParseInt(fromStr, 10, 64); err != nil {
+				return err
+			}
+			fromTS *= 1000
+		}
+		if toStr, ok := job.Data["to"]; ok {
+			if toTS, err = strconv.ParseInt(toStr, 10, 64); err != nil {
+				return err
+			}
+			toTS *= 1000
+		}
+
+		var nFiles, nInfected, nErrs int
+		for page := 0; ; page++ {
+			opts := model.GetFileInfosOptions{
+				Since:          fromTS,
+				SortBy:         model.FileinfoSortByCreated,
+				IncludeDeleted: false,
+			}
+			fileInfos, err := store.FileInfo().GetWithOptions(page, batchSize, &opts)
+			if err != nil {
+				return err
+			}
+
+			done := len(fileInfos) < batchSize
+			for _, fileInfo := range fileInfos {
+				if fileInfo.CreateAt > toTS {
+					done = true
+					break
+				}
+
+				if appErr := app.RescanFile(request.EmptyContext(logger), fileInfo); appErr != nil {
+					logger.Warn("Failed to scan file", mlog.Err(appErr), mlog.String("file_info_id", fileInfo.Id))
+					nErrs++
+				} else if fileInfo.ScanStatus == model.FileScanStatusInfected {
+					nInfected++
+				}
+				nFiles++
+			}
+
+			job.Data["processed"] = strconv.Itoa(nFiles)
+			job.Data["infected"] = strconv.Itoa(nInfected)
+			job.Data["errors"] = strconv.Itoa(nErrs)
+			if err := jobServer.UpdateInProgressJobData(job); err != nil {
+				logger.Error("Worker: Failed to update job data", mlog.Err(err))
+			}
+
+			if done {
+				break
+			}
+		}
+
+		return nil
+	}
+	worker := jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+	return worker
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package outgoing_email_queue
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+type EmailServiceIface interface {
+	SendQueuedEmails() error
+}
+
+// MakeWorker creates a worker that makes another attempt at sending the queued
+// emails whose previous attempt failed and whose retry is due.
+func MakeWorker(jobServer *jobs.JobServer, emailService EmailServiceIface) *jobs.SimpleWorker {
+	const workerName = "OutgoingEmailQueue"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.EmailSettings.EnableMailQueue
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		return emailService.SendQueuedEmails()
+	}
+	return jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package outgoing_webhook_retry
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+type AppIface interface {
+	RetryOutgoingWebhookDeliveries(rctx request.CTX) error
+}
+
+// MakeWorker creates a worker that makes another attempt at delivering the
+// outgoing webhook payloads whose previous attempt failed and whose retry is due.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
+	const workerName = "OutgoingWebhookRetry"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return *cfg.ServiceSettings.EnableOutgoingWebhooks
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		return app.RetryOutgoingWebhookDeliveries(request.EmptyContext(logger))
+	}
+	return jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+}
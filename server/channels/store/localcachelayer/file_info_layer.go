@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 )
 
@@ -90,6 +91,36 @@ func (s LocalCacheFileInfoStore) GetByIds(ids []string, includeDeleted, allowFro
 	return fileInfos, nil
 }
 
+func (s LocalCacheFileInfoStore) SetScanStatus(rctx request.CTX, fileID, status string, scannedAt int64) error {
+	if err := s.FileInfoStore.SetScanStatus(rctx, fileID, status, scannedAt); err != nil {
+		return err
+	}
+
+	for _, includeDeleted := range []bool{false, true} {
+		s.rootStore.doInvalidateCacheCluster(s.rootStore.fileInfoCache, fmt.Sprintf("%s_%t", fileID, includeDeleted), nil)
+	}
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.fileInfoCache.Name())
+	}
+
+	return nil
+}
+
+func (s LocalCacheFileInfoStore) SetContentHash(rctx request.CTX, fileID, contentHash, path string) error {
+	if err := s.FileInfoStore.SetContentHash(rctx, fileID, contentHash, path); err != nil {
+		return err
+	}
+
+	for _, includeDeleted := range []bool{false, true} {
+		s.rootStore.doInvalidateCacheCluster(s.rootStore.fileInfoCache, fmt.Sprintf("%s_%t", fileID, includeDeleted), nil)
+	}
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.fileInfoCache.Name())
+	}
+
+	return nil
+}
+
 func (s LocalCacheFileInfoStore) ClearCaches() {
 	s.rootStore.fileInfoCache.Purge()
 	if s.rootStore.metrics != nil {
@@ -137,3 +168,21 @@ func (s LocalCacheFileInfoStore) GetStorageUsage(allowFromCache, includeDeleted
 	s.rootStore.doStandardAddToCache(s.rootStore.fileInfoCache, storageUsageKey, usage)
 	return usage, nil
 }
+
+func (s LocalCacheFileInfoStore) MoveFilesToBlob(rctx request.CTX, path, contentHash string) ([]string, error) {
+	fileIDs, err := s.FileInfoStore.MoveFilesToBlob(rctx, path, contentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fileID := range fileIDs {
+		for _, includeDeleted := range []bool{false, true} {
+			s.rootStore.doInvalidateCacheCluster(s.rootStore.fileInfoCache, fmt.Sprintf("%s_%t", fileID, includeDeleted), nil)
+		}
+	}
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.fileInfoCache.Name())
+	}
+
+	return fileIDs, nil
+}
@@ -31,6 +31,7 @@ type RetryLayer struct {
 	ChannelJoinRequestStore         store.ChannelJoinRequestStore
 	ChannelMemberHistoryStore       store.ChannelMemberHistoryStore
 	ClusterDiscoveryStore           store.ClusterDiscoveryStore
+	ClusterMessageStore             store.ClusterMessageStore
 	CommandStore                    store.CommandStore
 	CommandWebhookStore             store.CommandWebhookStore
 	ComplianceStore                 store.ComplianceStore
@@ -43,9 +44,12 @@ type RetryLayer struct {
 	JobStore                        store.JobStore
 	LicenseStore                    store.LicenseStore
 	LinkMetadataStore               store.LinkMetadataStore
+	MfaRecoveryCodeStore            store.MfaRecoveryCodeStore
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
+	OutgoingEmailStore              store.OutgoingEmailStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
+	PendingEmailNotificationStore   store.PendingEmailNotificationStore
 	PluginStore                     store.PluginStore
 	PostStore                       store.PostStore
 	PostAcknowledgementStore        store.PostAcknowledgementStore
@@ -79,6 +83,7 @@ type RetryLayer struct {
 	UserAccessTokenStore            store.UserAccessTokenStore
 	UserTermsOfServiceStore         store.UserTermsOfServiceStore
 	ViewStore                       store.ViewStore
+	WebAuthnCredentialStore         store.WebAuthnCredentialStore
 	WebhookStore                    store.WebhookStore
 }
 
@@ -126,6 +131,10 @@ func (s *RetryLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return s.ClusterDiscoveryStore
 }
 
+func (s *RetryLayer) ClusterMessage() store.ClusterMessageStore {
+	return s.ClusterMessageStore
+}
+
 func (s *RetryLayer) Command() store.CommandStore {
 	return s.CommandStore
 }
@@ -174,6 +183,10 @@ func (s *RetryLayer) LinkMetadata() store.LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *RetryLayer) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return s.MfaRecoveryCodeStore
+}
+
 func (s *RetryLayer) NotifyAdmin() store.NotifyAdminStore {
 	return s.NotifyAdminStore
 }
@@ -182,10 +195,18 @@ func (s *RetryLayer) OAuth() store.OAuthStore {
 	return s.OAuthStore
 }
 
+func (s *RetryLayer) OutgoingEmail() store.OutgoingEmailStore {
+	return s.OutgoingEmailStore
+}
+
 func (s *RetryLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return s.OutgoingOAuthConnectionStore
 }
 
+func (s *RetryLayer) PendingEmailNotification() store.PendingEmailNotificationStore {
+	return s.PendingEmailNotificationStore
+}
+
 func (s *RetryLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
@@ -318,6 +339,10 @@ func (s *RetryLayer) View() store.ViewStore {
 	return s.ViewStore
 }
 
+func (s *RetryLayer) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return s.WebAuthnCredentialStore
+}
+
 func (s *RetryLayer) Webhook() store.WebhookStore {
 	return s.WebhookStore
 }
@@ -377,6 +402,11 @@ type RetryLayerClusterDiscoveryStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerClusterMessageStore struct {
+	store.ClusterMessageStore
+	Root *RetryLayer
+}
+
 type RetryLayerCommandStore struct {
 	store.CommandStore
 	Root *RetryLayer
@@ -437,6 +467,11 @@ type RetryLayerLinkMetadataStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerMfaRecoveryCodeStore struct {
+	store.MfaRecoveryCodeStore
+	Root *RetryLayer
+}
+
 type RetryLayerNotifyAdminStore struct {
 	store.NotifyAdminStore
 	Root *RetryLayer
@@ -447,11 +482,21 @@ type RetryLayerOAuthStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerOutgoingEmailStore struct {
+	store.OutgoingEmailStore
+	Root *RetryLayer
+}
+
 type RetryLayerOutgoingOAuthConnectionStore struct {
 	store.OutgoingOAuthConnectionStore
 	Root *RetryLayer
 }
 
+type RetryLayerPendingEmailNotificationStore struct {
+	store.PendingEmailNotificationStore
+	Root *RetryLayer
+}
+
 type RetryLayerPluginStore struct {
 	store.PluginStore
 	Root *RetryLayer
@@ -617,6 +662,11 @@ type RetryLayerViewStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerWebAuthnCredentialStore struct {
+	store.WebAuthnCredentialStore
+	Root *RetryLayer
+}
+
 type RetryLayerWebhookStore struct {
 	store.WebhookStore
 	Root *RetryLayer
@@ -4245,6 +4295,27 @@ func (s *RetryLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int
 
 }
 
+func (s *RetryLayerChannelMemberHistoryStore) GetChannelLeavesSince(userID string, since int64) ([]*model.ChannelMemberHistory, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelMemberHistoryStore.GetChannelLeavesSince(userID, since)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
 
 	tries := 0
@@ -4560,6 +4631,69 @@ func (s *RetryLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.Cluster
 
 }
 
+func (s *RetryLayerClusterMessageStore) DeleteBefore(createAt int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.ClusterMessageStore.DeleteBefore(createAt)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerClusterMessageStore) Get(id string) ([]byte, error) {
+
+	tries := 0
+	for {
+		result, err := s.ClusterMessageStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerClusterMessageStore) Save(id string, data []byte) error {
+
+	tries := 0
+	for {
+		err := s.ClusterMessageStore.Save(id, data)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerCommandStore) AnalyticsCommandCount(teamID string) (int64, error) {
 
 	tries := 0
@@ -5349,6 +5483,27 @@ func (s *RetryLayerEmojiStore) GetByName(rctx request.CTX, name string, allowFro
 
 }
 
+func (s *RetryLayerEmojiStore) GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.Emoji, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmojiStore.GetDeletedForExportAfter(limit, afterID, since)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
 
 	tries := 0
@@ -5433,6 +5588,27 @@ func (s *RetryLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 
 }
 
+func (s *RetryLayerFileInfoStore) AcquireBlob(contentHash string, size int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.FileInfoStore.AcquireBlob(contentHash, size)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerFileInfoStore) AttachToPost(rctx request.CTX, fileID string, postID string, channelID string, creatorID string) error {
 
 	tries := 0
@@ -5586,11 +5762,11 @@ func (s *RetryLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error
 
 }
 
-func (s *RetryLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
+func (s *RetryLayerFileInfoStore) GetFailedScans(now int64, maxBackoff int64, afterID string, limit int) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetFilesBatchForIndexing(startTime, startFileID, includeDeleted, limit)
+		result, err := s.FileInfoStore.GetFailedScans(now, maxBackoff, afterID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -5607,11 +5783,11 @@ func (s *RetryLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, star
 
 }
 
-func (s *RetryLayerFileInfoStore) GetForPost(postID string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+func (s *RetryLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetForPost(postID, readFromMaster, includeDeleted, allowFromCache)
+		result, err := s.FileInfoStore.GetFilesBatchForIndexing(startTime, startFileID, includeDeleted, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -5628,11 +5804,11 @@ func (s *RetryLayerFileInfoStore) GetForPost(postID string, readFromMaster bool,
 
 }
 
-func (s *RetryLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo, error) {
+func (s *RetryLayerFileInfoStore) GetForPost(postID string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetForUser(userID)
+		result, err := s.FileInfoStore.GetForPost(postID, readFromMaster, includeDeleted, allowFromCache)
 		if err == nil {
 			return result, nil
 		}
@@ -5649,11 +5825,11 @@ func (s *RetryLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo,
 
 }
 
-func (s *RetryLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, error) {
+func (s *RetryLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetFromMaster(id)
+		result, err := s.FileInfoStore.GetForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -5670,11 +5846,11 @@ func (s *RetryLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, err
 
 }
 
-func (s *RetryLayerFileInfoStore) GetStorageUsage(allowFromCache bool, includeDeleted bool) (int64, error) {
+func (s *RetryLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetStorageUsage(allowFromCache, includeDeleted)
+		result, err := s.FileInfoStore.GetFromMaster(id)
 		if err == nil {
 			return result, nil
 		}
@@ -5691,11 +5867,11 @@ func (s *RetryLayerFileInfoStore) GetStorageUsage(allowFromCache bool, includeDe
 
 }
 
-func (s *RetryLayerFileInfoStore) GetUptoNSizeFileTime(n int64) (int64, error) {
+func (s *RetryLayerFileInfoStore) GetPendingScans(createdBefore int64, afterID string, limit int) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetUptoNSizeFileTime(n)
+		result, err := s.FileInfoStore.GetPendingScans(createdBefore, afterID, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -5712,11 +5888,11 @@ func (s *RetryLayerFileInfoStore) GetUptoNSizeFileTime(n int64) (int64, error) {
 
 }
 
-func (s *RetryLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+func (s *RetryLayerFileInfoStore) GetStorageUsage(allowFromCache bool, includeDeleted bool) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
+		result, err := s.FileInfoStore.GetStorageUsage(allowFromCache, includeDeleted)
 		if err == nil {
 			return result, nil
 		}
@@ -5733,38 +5909,32 @@ func (s *RetryLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *mod
 
 }
 
-func (s *RetryLayerFileInfoStore) InvalidateFileInfosForPostCache(postID string, deleted bool) {
-
-	s.FileInfoStore.InvalidateFileInfosForPostCache(postID, deleted)
-
-}
-
-func (s *RetryLayerFileInfoStore) PermanentDelete(rctx request.CTX, fileID string) error {
+func (s *RetryLayerFileInfoStore) GetUnreferencedBlobs(afterHash string, limit int) ([]string, error) {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.PermanentDelete(rctx, fileID)
+		result, err := s.FileInfoStore.GetUnreferencedBlobs(afterHash, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime int64, limit int64) (int64, error) {
+func (s *RetryLayerFileInfoStore) GetUptoNSizeFileTime(n int64) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.PermanentDeleteBatch(rctx, endTime, limit)
+		result, err := s.FileInfoStore.GetUptoNSizeFileTime(n)
 		if err == nil {
 			return result, nil
 		}
@@ -5781,11 +5951,11 @@ func (s *RetryLayerFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime
 
 }
 
-func (s *RetryLayerFileInfoStore) PermanentDeleteByUser(rctx request.CTX, userID string) (int64, error) {
+func (s *RetryLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.PermanentDeleteByUser(rctx, userID)
+		result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
 		if err == nil {
 			return result, nil
 		}
@@ -5802,32 +5972,38 @@ func (s *RetryLayerFileInfoStore) PermanentDeleteByUser(rctx request.CTX, userID
 
 }
 
-func (s *RetryLayerFileInfoStore) PermanentDeleteForPost(rctx request.CTX, postID string) error {
+func (s *RetryLayerFileInfoStore) InvalidateFileInfosForPostCache(postID string, deleted bool) {
+
+	s.FileInfoStore.InvalidateFileInfosForPostCache(postID, deleted)
+
+}
+
+func (s *RetryLayerFileInfoStore) MoveFilesToBlob(rctx request.CTX, path string, contentHash string) ([]string, error) {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.PermanentDeleteForPost(rctx, postID)
+		result, err := s.FileInfoStore.MoveFilesToBlob(rctx, path, contentHash)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) RefreshFileStats() error {
+func (s *RetryLayerFileInfoStore) PermanentDelete(rctx request.CTX, fileID string) error {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.RefreshFileStats()
+		err := s.FileInfoStore.PermanentDelete(rctx, fileID)
 		if err == nil {
 			return nil
 		}
@@ -5844,32 +6020,32 @@ func (s *RetryLayerFileInfoStore) RefreshFileStats() error {
 
 }
 
-func (s *RetryLayerFileInfoStore) RestoreForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
+func (s *RetryLayerFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.RestoreForPostByIds(rctx, postId, fileIDs)
+		result, err := s.FileInfoStore.PermanentDeleteBatch(rctx, endTime, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) Save(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
+func (s *RetryLayerFileInfoStore) PermanentDeleteByUser(rctx request.CTX, userID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.Save(rctx, info)
+		result, err := s.FileInfoStore.PermanentDeleteByUser(rctx, userID)
 		if err == nil {
 			return result, nil
 		}
@@ -5886,32 +6062,32 @@ func (s *RetryLayerFileInfoStore) Save(rctx request.CTX, info *model.FileInfo) (
 
 }
 
-func (s *RetryLayerFileInfoStore) Search(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.FileInfoList, error) {
+func (s *RetryLayerFileInfoStore) PermanentDeleteForPost(rctx request.CTX, postID string) error {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.Search(rctx, paramsList, userID, teamID, page, perPage)
+		err := s.FileInfoStore.PermanentDeleteForPost(rctx, postID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerFileInfoStore) SetContent(rctx request.CTX, fileID string, content string) error {
+func (s *RetryLayerFileInfoStore) RefreshFileStats() error {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.SetContent(rctx, fileID, content)
+		err := s.FileInfoStore.RefreshFileStats()
 		if err == nil {
 			return nil
 		}
@@ -5928,11 +6104,11 @@ func (s *RetryLayerFileInfoStore) SetContent(rctx request.CTX, fileID string, co
 
 }
 
-func (s *RetryLayerFileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
+func (s *RetryLayerFileInfoStore) ReleaseBlob(contentHash string, removeBlob func() error) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.FileInfoStore.Upsert(rctx, info)
+		result, err := s.FileInfoStore.ReleaseBlob(contentHash, removeBlob)
 		if err == nil {
 			return result, nil
 		}
@@ -5949,11 +6125,11 @@ func (s *RetryLayerFileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo)
 
 }
 
-func (s *RetryLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+func (s *RetryLayerFileInfoStore) RemoveBlobIfUnreferenced(contentHash string, removeBlob func() error) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
+		result, err := s.FileInfoStore.RemoveBlobIfUnreferenced(contentHash, removeBlob)
 		if err == nil {
 			return result, nil
 		}
@@ -5970,32 +6146,32 @@ func (s *RetryLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, s
 
 }
 
-func (s *RetryLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+func (s *RetryLayerFileInfoStore) RestoreForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+		err := s.FileInfoStore.RestoreForPostByIds(rctx, postId, fileIDs)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string, reAddRemovedMembers bool) ([]*model.UserChannelIDPair, error) {
+func (s *RetryLayerFileInfoStore) Save(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.ChannelMembersToAdd(since, channelID, reAddRemovedMembers)
+		result, err := s.FileInfoStore.Save(rctx, info)
 		if err == nil {
 			return result, nil
 		}
@@ -6012,11 +6188,11 @@ func (s *RetryLayerGroupStore) ChannelMembersToAdd(since int64, channelID *strin
 
 }
 
-func (s *RetryLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, error) {
+func (s *RetryLayerFileInfoStore) Search(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.FileInfoList, error) {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.ChannelMembersToRemove(channelID)
+		result, err := s.FileInfoStore.Search(rctx, paramsList, userID, teamID, page, perPage)
 		if err == nil {
 			return result, nil
 		}
@@ -6033,11 +6209,179 @@ func (s *RetryLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*mod
 
 }
 
-func (s *RetryLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, error) {
+func (s *RetryLayerFileInfoStore) SetContent(rctx request.CTX, fileID string, content string) error {
 
 	tries := 0
 	for {
-		result, err := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+		err := s.FileInfoStore.SetContent(rctx, fileID, content)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerFileInfoStore) SetContentHash(rctx request.CTX, fileID string, contentHash string, path string) error {
+
+	tries := 0
+	for {
+		err := s.FileInfoStore.SetContentHash(rctx, fileID, contentHash, path)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerFileInfoStore) SetScanStatus(rctx request.CTX, fileID string, status string, scannedAt int64) error {
+
+	tries := 0
+	for {
+		err := s.FileInfoStore.SetScanStatus(rctx, fileID, status, scannedAt)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerFileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
+
+	tries := 0
+	for {
+		result, err := s.FileInfoStore.Upsert(rctx, info)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+
+	tries := 0
+	for {
+		result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+
+	tries := 0
+	for {
+		result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string, reAddRemovedMembers bool) ([]*model.UserChannelIDPair, error) {
+
+	tries := 0
+	for {
+		result, err := s.GroupStore.ChannelMembersToAdd(since, channelID, reAddRemovedMembers)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, error) {
+
+	tries := 0
+	for {
+		result, err := s.GroupStore.ChannelMembersToRemove(channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
 		if err == nil {
 			return result, nil
 		}
@@ -7587,6 +7931,69 @@ func (s *RetryLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 
 }
 
+func (s *RetryLayerMfaRecoveryCodeStore) PermanentDeleteByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.MfaRecoveryCodeStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerMfaRecoveryCodeStore) SaveForUser(userID string, codeHashes []string) error {
+
+	tries := 0
+	for {
+		err := s.MfaRecoveryCodeStore.SaveForUser(userID, codeHashes)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerMfaRecoveryCodeStore) Use(userID string, codeHash string) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.MfaRecoveryCodeStore.Use(userID, codeHash)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
 
 	tries := 0
@@ -8133,11 +8540,11 @@ func (s *RetryLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 
 }
 
-func (s *RetryLayerOutgoingOAuthConnectionStore) DeleteConnection(rctx request.CTX, id string) error {
+func (s *RetryLayerOutgoingEmailStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		err := s.OutgoingOAuthConnectionStore.DeleteConnection(rctx, id)
+		err := s.OutgoingEmailStore.Delete(id)
 		if err == nil {
 			return nil
 		}
@@ -8154,11 +8561,11 @@ func (s *RetryLayerOutgoingOAuthConnectionStore) DeleteConnection(rctx request.C
 
 }
 
-func (s *RetryLayerOutgoingOAuthConnectionStore) GetConnection(rctx request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
+func (s *RetryLayerOutgoingEmailStore) Get(id string) (*model.OutgoingEmail, error) {
 
 	tries := 0
 	for {
-		result, err := s.OutgoingOAuthConnectionStore.GetConnection(rctx, id)
+		result, err := s.OutgoingEmailStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -8175,11 +8582,11 @@ func (s *RetryLayerOutgoingOAuthConnectionStore) GetConnection(rctx request.CTX,
 
 }
 
-func (s *RetryLayerOutgoingOAuthConnectionStore) GetConnections(rctx request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
+func (s *RetryLayerOutgoingEmailStore) GetDue(before int64, limit int) ([]*model.OutgoingEmail, error) {
 
 	tries := 0
 	for {
-		result, err := s.OutgoingOAuthConnectionStore.GetConnections(rctx, filters)
+		result, err := s.OutgoingEmailStore.GetDue(before, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8196,11 +8603,11 @@ func (s *RetryLayerOutgoingOAuthConnectionStore) GetConnections(rctx request.CTX
 
 }
 
-func (s *RetryLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+func (s *RetryLayerOutgoingEmailStore) GetFailed(offset int, limit int) ([]*model.OutgoingEmail, error) {
 
 	tries := 0
 	for {
-		result, err := s.OutgoingOAuthConnectionStore.SaveConnection(rctx, conn)
+		result, err := s.OutgoingEmailStore.GetFailed(offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8217,11 +8624,11 @@ func (s *RetryLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX
 
 }
 
-func (s *RetryLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+func (s *RetryLayerOutgoingEmailStore) PermanentDeleteFailedBefore(before int64, limit int) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(rctx, conn)
+		result, err := s.OutgoingEmailStore.PermanentDeleteFailedBefore(before, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -8238,11 +8645,11 @@ func (s *RetryLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.C
 
 }
 
-func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *RetryLayerOutgoingEmailStore) Save(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+		result, err := s.OutgoingEmailStore.Save(email)
 		if err == nil {
 			return result, nil
 		}
@@ -8259,11 +8666,11 @@ func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, o
 
 }
 
-func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+func (s *RetryLayerOutgoingEmailStore) Update(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
 
 	tries := 0
 	for {
-		result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+		result, err := s.OutgoingEmailStore.Update(email)
 		if err == nil {
 			return result, nil
 		}
@@ -8280,11 +8687,11 @@ func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldV
 
 }
 
-func (s *RetryLayerPluginStore) Delete(pluginID string, key string) error {
+func (s *RetryLayerOutgoingOAuthConnectionStore) DeleteConnection(rctx request.CTX, id string) error {
 
 	tries := 0
 	for {
-		err := s.PluginStore.Delete(pluginID, key)
+		err := s.OutgoingOAuthConnectionStore.DeleteConnection(rctx, id)
 		if err == nil {
 			return nil
 		}
@@ -8301,34 +8708,307 @@ func (s *RetryLayerPluginStore) Delete(pluginID string, key string) error {
 
 }
 
-func (s *RetryLayerPluginStore) DeleteAllExpired() error {
+func (s *RetryLayerOutgoingOAuthConnectionStore) GetConnection(rctx request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
 
 	tries := 0
 	for {
-		err := s.PluginStore.DeleteAllExpired()
+		result, err := s.OutgoingOAuthConnectionStore.GetConnection(rctx, id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
+func (s *RetryLayerOutgoingOAuthConnectionStore) GetConnections(rctx request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
 
 	tries := 0
 	for {
-		err := s.PluginStore.DeleteAllForPlugin(PluginID)
+		result, err := s.OutgoingOAuthConnectionStore.GetConnections(rctx, filters)
 		if err == nil {
-			return nil
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+
+	tries := 0
+	for {
+		result, err := s.OutgoingOAuthConnectionStore.SaveConnection(rctx, conn)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+
+	tries := 0
+	for {
+		result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(rctx, conn)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPendingEmailNotificationStore) Delete(ids []string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PendingEmailNotificationStore.Delete(ids)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPendingEmailNotificationStore) GetBatchStartTimes() (map[string]int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.PendingEmailNotificationStore.GetBatchStartTimes()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPendingEmailNotificationStore) GetForUser(userID string) ([]*model.PendingEmailNotification, error) {
+
+	tries := 0
+	for {
+		result, err := s.PendingEmailNotificationStore.GetForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPendingEmailNotificationStore) IncrementAttempts(ids []string) error {
+
+	tries := 0
+	for {
+		err := s.PendingEmailNotificationStore.IncrementAttempts(ids)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPendingEmailNotificationStore) PermanentDeleteByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.PendingEmailNotificationStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPendingEmailNotificationStore) Save(notification *model.PendingEmailNotification) (*model.PendingEmailNotification, error) {
+
+	tries := 0
+	for {
+		result, err := s.PendingEmailNotificationStore.Save(notification)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPluginStore) Delete(pluginID string, key string) error {
+
+	tries := 0
+	for {
+		err := s.PluginStore.Delete(pluginID, key)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPluginStore) DeleteAllExpired() error {
+
+	tries := 0
+	for {
+		err := s.PluginStore.DeleteAllExpired()
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
+
+	tries := 0
+	for {
+		err := s.PluginStore.DeleteAllForPlugin(PluginID)
+		if err == nil {
+			return nil
 		}
 		if !isRepeatableError(err) {
 			return err
@@ -8542,28 +9222,49 @@ func (s *RetryLayerPostStore) DeleteAllPostRemindersForPost(postId string) error
 
 	tries := 0
 	for {
-		err := s.PostStore.DeleteAllPostRemindersForPost(postId)
+		err := s.PostStore.DeleteAllPostRemindersForPost(postId)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPostStore) Get(rctx request.CTX, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.Get(rctx, id, opts, userID, sanitizeOptions)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerPostStore) Get(rctx request.CTX, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
+func (s *RetryLayerPostStore) GetDeletedForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.DeletedPostForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.Get(rctx, id, opts, userID, sanitizeOptions)
+		result, err := s.PostStore.GetDeletedForExportAfter(limit, afterID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -8580,11 +9281,11 @@ func (s *RetryLayerPostStore) Get(rctx request.CTX, id string, opts model.GetPos
 
 }
 
-func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *RetryLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.DirectPostForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels)
+		result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -8760,11 +9461,11 @@ func (s *RetryLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 
 }
 
-func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
+func (s *RetryLayerPostStore) GetParentsForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.PostForExport, error) {
 
 	tries := 0
 	for {
-		result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels)
+		result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -10803,6 +11504,27 @@ func (s *RetryLayerPropertyValueStore) Get(groupID string, id string) (*model.Pr
 
 }
 
+func (s *RetryLayerPropertyValueStore) GetForChannelPostsSince(groupID string, channelID string, since int64, afterID string, limit int) ([]*model.PropertyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PropertyValueStore.GetForChannelPostsSince(groupID, channelID, since, afterID, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPropertyValueStore) GetMany(groupID string, ids []string) ([]*model.PropertyValue, error) {
 
 	tries := 0
@@ -12936,6 +13658,27 @@ func (s *RetryLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) (
 
 }
 
+func (s *RetryLayerSessionStore) GetSessionsWithWebPushSubscriptions(userID string) ([]*model.Session, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.GetSessionsWithWebPushSubscriptions(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
 
 	tries := 0
@@ -13713,6 +14456,27 @@ func (s *RetryLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelR
 
 }
 
+func (s *RetryLayerSharedChannelStore) UpdateRemoteContentCursor(id string, cursor model.SharedChannelContentCursor) error {
+
+	tries := 0
+	for {
+		err := s.SharedChannelStore.UpdateRemoteContentCursor(id, cursor)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
 
 	tries := 0
@@ -17941,7 +18705,196 @@ func (s *RetryLayerUserStore) UpdateUpdateAt(userID string) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.UpdateUpdateAt(userID)
+		result, err := s.UserStore.UpdateUpdateAt(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserStore) VerifyEmail(userID string, email string) (string, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.VerifyEmail(userID, email)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) Delete(tokenID string) error {
+
+	tries := 0
+	for {
+		err := s.UserAccessTokenStore.Delete(tokenID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) DeleteAllForUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.UserAccessTokenStore.DeleteAllForUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) DeleteByIds(tokenIDs []string) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserAccessTokenStore.DeleteByIds(tokenIDs)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) Get(tokenID string) (*model.UserAccessToken, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserAccessTokenStore.Get(tokenID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserAccessTokenStore.GetAll(offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserAccessTokenStore.GetByToken(tokenString)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) GetByUser(userID string, page int, perPage int) ([]*model.UserAccessToken, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserAccessTokenStore.GetByUser(userID, page, perPage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) GetExpiredBefore(cutoff int64, limit int) ([]*model.UserAccessToken, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserAccessTokenStore.GetExpiredBefore(cutoff, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -17958,11 +18911,11 @@ func (s *RetryLayerUserStore) UpdateUpdateAt(userID string) (int64, error) {
 
 }
 
-func (s *RetryLayerUserStore) VerifyEmail(userID string, email string) (string, error) {
+func (s *RetryLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserStore.VerifyEmail(userID, email)
+		result, err := s.UserAccessTokenStore.Save(token)
 		if err == nil {
 			return result, nil
 		}
@@ -17979,32 +18932,32 @@ func (s *RetryLayerUserStore) VerifyEmail(userID string, email string) (string,
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) Delete(tokenID string) error {
+func (s *RetryLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, error) {
 
 	tries := 0
 	for {
-		err := s.UserAccessTokenStore.Delete(tokenID)
+		result, err := s.UserAccessTokenStore.Search(term)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) DeleteAllForUser(userID string) error {
+func (s *RetryLayerUserAccessTokenStore) UpdateTokenDisable(tokenID string) error {
 
 	tries := 0
 	for {
-		err := s.UserAccessTokenStore.DeleteAllForUser(userID)
+		err := s.UserAccessTokenStore.UpdateTokenDisable(tokenID)
 		if err == nil {
 			return nil
 		}
@@ -18021,53 +18974,53 @@ func (s *RetryLayerUserAccessTokenStore) DeleteAllForUser(userID string) error {
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) DeleteByIds(tokenIDs []string) (int64, error) {
+func (s *RetryLayerUserAccessTokenStore) UpdateTokenEnable(tokenID string) error {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.DeleteByIds(tokenIDs)
+		err := s.UserAccessTokenStore.UpdateTokenEnable(tokenID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) Get(tokenID string) (*model.UserAccessToken, error) {
+func (s *RetryLayerUserTermsOfServiceStore) Delete(userID string, termsOfServiceID string) error {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.Get(tokenID)
+		err := s.UserTermsOfServiceStore.Delete(userID, termsOfServiceID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, error) {
+func (s *RetryLayerUserTermsOfServiceStore) GetByUser(userID string) (*model.UserTermsOfService, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.GetAll(offset, limit)
+		result, err := s.UserTermsOfServiceStore.GetByUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -18084,11 +19037,11 @@ func (s *RetryLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, error) {
+func (s *RetryLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.GetByToken(tokenString)
+		result, err := s.UserTermsOfServiceStore.Save(userTermsOfService)
 		if err == nil {
 			return result, nil
 		}
@@ -18105,11 +19058,11 @@ func (s *RetryLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) GetByUser(userID string, page int, perPage int) ([]*model.UserAccessToken, error) {
+func (s *RetryLayerViewStore) CountForChannel(channelID string, opts model.ViewQueryOpts) (int64, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.GetByUser(userID, page, perPage)
+		result, err := s.ViewStore.CountForChannel(channelID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -18126,32 +19079,32 @@ func (s *RetryLayerUserAccessTokenStore) GetByUser(userID string, page int, perP
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) GetExpiredBefore(cutoff int64, limit int) ([]*model.UserAccessToken, error) {
+func (s *RetryLayerViewStore) Delete(viewID string, deleteAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.GetExpiredBefore(cutoff, limit)
+		err := s.ViewStore.Delete(viewID, deleteAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
+func (s *RetryLayerViewStore) Get(id string) (*model.View, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.Save(token)
+		result, err := s.ViewStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -18168,11 +19121,11 @@ func (s *RetryLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*mo
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, error) {
+func (s *RetryLayerViewStore) GetForChannel(channelID string, opts model.ViewQueryOpts) ([]*model.View, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserAccessTokenStore.Search(term)
+		result, err := s.ViewStore.GetForChannel(channelID, opts)
 		if err == nil {
 			return result, nil
 		}
@@ -18189,74 +19142,74 @@ func (s *RetryLayerUserAccessTokenStore) Search(term string) ([]*model.UserAcces
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) UpdateTokenDisable(tokenID string) error {
+func (s *RetryLayerViewStore) GetPostsForCalendar(channelID string, props *model.CalendarProps, opts model.ViewPostsQueryOpts) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		err := s.UserAccessTokenStore.UpdateTokenDisable(tokenID)
+		result, err := s.ViewStore.GetPostsForCalendar(channelID, props, opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserAccessTokenStore) UpdateTokenEnable(tokenID string) error {
+func (s *RetryLayerViewStore) GetPostsForTable(channelID string, props *model.TableProps, opts model.ViewPostsQueryOpts) ([]*model.Post, error) {
 
 	tries := 0
 	for {
-		err := s.UserAccessTokenStore.UpdateTokenEnable(tokenID)
+		result, err := s.ViewStore.GetPostsForTable(channelID, props, opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserTermsOfServiceStore) Delete(userID string, termsOfServiceID string) error {
+func (s *RetryLayerViewStore) Save(view *model.View) (*model.View, error) {
 
 	tries := 0
 	for {
-		err := s.UserTermsOfServiceStore.Delete(userID, termsOfServiceID)
+		result, err := s.ViewStore.Save(view)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerUserTermsOfServiceStore) GetByUser(userID string) (*model.UserTermsOfService, error) {
+func (s *RetryLayerViewStore) Update(view *model.View) (*model.View, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserTermsOfServiceStore.GetByUser(userID)
+		result, err := s.ViewStore.Update(view)
 		if err == nil {
 			return result, nil
 		}
@@ -18273,11 +19226,11 @@ func (s *RetryLayerUserTermsOfServiceStore) GetByUser(userID string) (*model.Use
 
 }
 
-func (s *RetryLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
+func (s *RetryLayerViewStore) UpdateSortOrder(viewID string, channelID string, newIndex int64) ([]*model.View, error) {
 
 	tries := 0
 	for {
-		result, err := s.UserTermsOfServiceStore.Save(userTermsOfService)
+		result, err := s.ViewStore.UpdateSortOrder(viewID, channelID, newIndex)
 		if err == nil {
 			return result, nil
 		}
@@ -18294,53 +19247,53 @@ func (s *RetryLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserT
 
 }
 
-func (s *RetryLayerViewStore) CountForChannel(channelID string, opts model.ViewQueryOpts) (int64, error) {
+func (s *RetryLayerWebAuthnCredentialStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		result, err := s.ViewStore.CountForChannel(channelID, opts)
+		err := s.WebAuthnCredentialStore.Delete(id)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerViewStore) Delete(viewID string, deleteAt int64) error {
+func (s *RetryLayerWebAuthnCredentialStore) Get(id string) (*model.WebAuthnCredential, error) {
 
 	tries := 0
 	for {
-		err := s.ViewStore.Delete(viewID, deleteAt)
+		result, err := s.WebAuthnCredentialStore.Get(id)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerViewStore) Get(id string) (*model.View, error) {
+func (s *RetryLayerWebAuthnCredentialStore) GetByCredentialId(credentialID string) (*model.WebAuthnCredential, error) {
 
 	tries := 0
 	for {
-		result, err := s.ViewStore.Get(id)
+		result, err := s.WebAuthnCredentialStore.GetByCredentialId(credentialID)
 		if err == nil {
 			return result, nil
 		}
@@ -18357,11 +19310,11 @@ func (s *RetryLayerViewStore) Get(id string) (*model.View, error) {
 
 }
 
-func (s *RetryLayerViewStore) GetForChannel(channelID string, opts model.ViewQueryOpts) ([]*model.View, error) {
+func (s *RetryLayerWebAuthnCredentialStore) GetForUser(userID string) ([]*model.WebAuthnCredential, error) {
 
 	tries := 0
 	for {
-		result, err := s.ViewStore.GetForChannel(channelID, opts)
+		result, err := s.WebAuthnCredentialStore.GetForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -18378,32 +19331,32 @@ func (s *RetryLayerViewStore) GetForChannel(channelID string, opts model.ViewQue
 
 }
 
-func (s *RetryLayerViewStore) Save(view *model.View) (*model.View, error) {
+func (s *RetryLayerWebAuthnCredentialStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.ViewStore.Save(view)
+		err := s.WebAuthnCredentialStore.PermanentDeleteByUser(userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerViewStore) Update(view *model.View) (*model.View, error) {
+func (s *RetryLayerWebAuthnCredentialStore) Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error) {
 
 	tries := 0
 	for {
-		result, err := s.ViewStore.Update(view)
+		result, err := s.WebAuthnCredentialStore.Save(credential)
 		if err == nil {
 			return result, nil
 		}
@@ -18420,21 +19373,21 @@ func (s *RetryLayerViewStore) Update(view *model.View) (*model.View, error) {
 
 }
 
-func (s *RetryLayerViewStore) UpdateSortOrder(viewID string, channelID string, newIndex int64) ([]*model.View, error) {
+func (s *RetryLayerWebAuthnCredentialStore) UpdateLastUsed(id string, signCount int64, lastUsedAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.ViewStore.UpdateSortOrder(viewID, channelID, newIndex)
+		err := s.WebAuthnCredentialStore.UpdateLastUsed(id, signCount, lastUsedAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
@@ -18762,6 +19715,48 @@ func (s *RetryLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, userID s
 
 }
 
+func (s *RetryLayerWebhookStore) GetOutgoingDeliveriesByHook(hookID string, offset int, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.GetOutgoingDeliveriesByHook(hookID, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerWebhookStore) GetOutgoingDeliveriesForRetry(before int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.GetOutgoingDeliveriesForRetry(before, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
 
 	tries := 0
@@ -18804,6 +19799,27 @@ func (s *RetryLayerWebhookStore) GetOutgoingListByUser(userID string, offset int
 
 }
 
+func (s *RetryLayerWebhookStore) ImportIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.ImportIncoming(webhook)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerWebhookStore) InvalidateWebhookCache(webhook string) {
 
 	s.WebhookStore.InvalidateWebhookCache(webhook)
@@ -18894,6 +19910,27 @@ func (s *RetryLayerWebhookStore) PermanentDeleteOutgoingByUser(userID string) er
 
 }
 
+func (s *RetryLayerWebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64, limit int64) (int64, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.PermanentDeleteOutgoingDeliveriesBefore(before, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 
 	tries := 0
@@ -18936,6 +19973,27 @@ func (s *RetryLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*
 
 }
 
+func (s *RetryLayerWebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.SaveOutgoingDelivery(delivery)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 
 	tries := 0
@@ -18999,6 +20057,27 @@ func (s *RetryLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*m
 
 }
 
+func (s *RetryLayerWebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+
+	tries := 0
+	for {
+		result, err := s.WebhookStore.UpdateOutgoingDelivery(delivery)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayer) Close() {
 	s.Store.Close()
 }
@@ -19047,6 +20126,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.ChannelJoinRequestStore = &RetryLayerChannelJoinRequestStore{ChannelJoinRequestStore: childStore.ChannelJoinRequest(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &RetryLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &RetryLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
+	newStore.ClusterMessageStore = &RetryLayerClusterMessageStore{ClusterMessageStore: childStore.ClusterMessage(), Root: &newStore}
 	newStore.CommandStore = &RetryLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
 	newStore.CommandWebhookStore = &RetryLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
 	newStore.ComplianceStore = &RetryLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
@@ -19059,9 +20139,12 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.JobStore = &RetryLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &RetryLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &RetryLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.MfaRecoveryCodeStore = &RetryLayerMfaRecoveryCodeStore{MfaRecoveryCodeStore: childStore.MfaRecoveryCode(), Root: &newStore}
 	newStore.NotifyAdminStore = &RetryLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &RetryLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OutgoingEmailStore = &RetryLayerOutgoingEmailStore{OutgoingEmailStore: childStore.OutgoingEmail(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &RetryLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PendingEmailNotificationStore = &RetryLayerPendingEmailNotificationStore{PendingEmailNotificationStore: childStore.PendingEmailNotification(), Root: &newStore}
 	newStore.PluginStore = &RetryLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &RetryLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PostAcknowledgementStore = &RetryLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
@@ -19095,6 +20178,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.UserAccessTokenStore = &RetryLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &RetryLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
 	newStore.ViewStore = &RetryLayerViewStore{ViewStore: childStore.View(), Root: &newStore}
+	newStore.WebAuthnCredentialStore = &RetryLayerWebAuthnCredentialStore{WebAuthnCredentialStore: childStore.WebAuthnCredential(), Root: &newStore}
 	newStore.WebhookStore = &RetryLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
 	return &newStore
 }
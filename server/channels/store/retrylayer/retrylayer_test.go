@@ -77,6 +77,11 @@ func genStore() *mocks.Store {
 	mock.On("TemporaryPost").Return(&mocks.TemporaryPostStore{})
 	mock.On("View").Return(&mocks.ViewStore{})
 	mock.On("ChannelJoinRequest").Return(&mocks.ChannelJoinRequestStore{})
+	mock.On("PendingEmailNotification").Return(&mocks.PendingEmailNotificationStore{})
+	mock.On("OutgoingEmail").Return(&mocks.OutgoingEmailStore{})
+	mock.On("WebAuthnCredential").Return(&mocks.WebAuthnCredentialStore{})
+	mock.On("MfaRecoveryCode").Return(&mocks.MfaRecoveryCodeStore{})
+	mock.On("ClusterMessage").Return(&mocks.ClusterMessageStore{})
 	return mock
 }
 
@@ -10,6 +10,8 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/store/searchlayer"
 	"github.com/mattermost/mattermost/server/v8/channels/store/sqlstore"
 	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
@@ -28,14 +30,14 @@ func TestUpdateConfigRace(t *testing.T) {
 		driverName = model.DatabaseDriverPostgres
 	}
 	settings := storetest.MakeSqlSettings(driverName)
-	store, err := sqlstore.New(*settings, logger, nil, sqlstore.DisableMorphLogging())
+	sqlStore, err := sqlstore.New(*settings, logger, nil, sqlstore.DisableMorphLogging())
 	require.NoError(t, err)
 
 	cfg := &model.Config{}
 	cfg.SetDefaults()
 	cfg.ClusterSettings.GossipPort = new(9999)
 	searchEngine := searchengine.NewBroker(cfg)
-	layer := searchlayer.NewSearchLayer(&testlib.TestStore{Store: store}, searchEngine, cfg)
+	layer := searchlayer.NewSearchLayer(&testlib.TestStore{Store: sqlStore}, searchEngine, cfg)
 	var wg sync.WaitGroup
 
 	wg.Add(5)
@@ -48,3 +50,37 @@ func TestUpdateConfigRace(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestSearchUnindexedAttributesWithoutDatabaseSearch(t *testing.T) {
+	logger := mlog.CreateTestLogger(t)
+
+	driverName := os.Getenv("MM_SQLSETTINGS_DRIVERNAME")
+	if driverName == "" {
+		driverName = model.DatabaseDriverPostgres
+	}
+	settings := storetest.MakeSqlSettings(driverName)
+	sqlStore, err := sqlstore.New(*settings, logger, nil, sqlstore.DisableMorphLogging())
+	require.NoError(t, err)
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.SqlSettings.DisableDatabaseSearch = new(true)
+	searchEngine := searchengine.NewBroker(cfg)
+	layer := searchlayer.NewSearchLayer(&testlib.TestStore{Store: sqlStore}, searchEngine, cfg)
+
+	// The search engines don't index flagged posts nor reactions, so these searches are refused
+	// rather than answered without their filters.
+	for _, params := range []*model.SearchParams{
+		{Terms: "test", IsFlagged: new(true)},
+		{Terms: "test", HasReaction: new(true)},
+		{Terms: "test", Reactions: []string{"smile"}},
+	} {
+		_, err = layer.Post().SearchPostsForUser(request.TestContext(t), []*model.SearchParams{params}, model.NewId(), model.NewId(), 0, 20)
+		var nieErr *store.ErrNotImplemented
+		require.ErrorAs(t, err, &nieErr)
+	}
+
+	results, err := layer.Post().SearchPostsForUser(request.TestContext(t), []*model.SearchParams{{Terms: "test", IsPinned: new(true)}}, model.NewId(), model.NewId(), 0, 20)
+	require.NoError(t, err)
+	require.Empty(t, results.Posts)
+}
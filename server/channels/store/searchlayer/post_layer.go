@@ -191,9 +191,28 @@ func (s SearchPostStore) searchPostsForUserByEngine(engine searchengine.SearchEn
 	return model.MakePostSearchResults(postList, matches), nil
 }
 
+// searchesUnindexedAttributes returns true if the search filters on flagged posts
+// or reactions, which the search engines don't index.
+func searchesUnindexedAttributes(paramsList []*model.SearchParams) bool {
+	for _, params := range paramsList {
+		if params.IsFlagged != nil || params.HasReaction != nil ||
+			len(params.Reactions) != 0 || len(params.ExcludedReactions) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (s SearchPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.PostSearchResults, error) {
+	// Searches the engines can't answer are run against the database, and refused
+	// when database search is disabled rather than answered without their filters.
+	useEngines := !searchesUnindexedAttributes(paramsList)
+	if !useEngines && *s.rootStore.getConfig().SqlSettings.DisableDatabaseSearch {
+		return nil, store.NewErrNotImplemented("searching flagged posts or reactions is unsupported with the current search engine")
+	}
+
 	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
-		if engine.IsSearchEnabled() {
+		if useEngines && engine.IsSearchEnabled() {
 			results, err := s.searchPostsForUserByEngine(engine, paramsList, userId, teamId, page, perPage)
 			if err != nil {
 				rctx.Logger().Warn("Encountered error on SearchPostsInTeamForUser.", mlog.String("search_engine", engine.GetName()), mlog.Err(err))
@@ -298,6 +298,16 @@ var searchPostStoreTests = []searchTest{
 		Fn:   testSearchURLsPostgres,
 		Tags: []string{EnginePostgres},
 	},
+	{
+		Name: "Should be able to filter posts by attributes",
+		Fn:   testSearchPostsByAttributes,
+		Tags: []string{EngineAll},
+	},
+	{
+		Name: "Should be able to filter flagged posts and posts with reactions",
+		Fn:   testSearchFlaggedPostsAndReactions,
+		Tags: []string{EnginePostgres},
+	},
 }
 
 func TestSearchPostStore(t *testing.T, s store.Store, testEngine *SearchTestEngine) {
@@ -2468,3 +2478,152 @@ func testSearchURLsPostgres(t *testing.T, th *SearchTestHelper) {
 		})
 	}
 }
+
+func testSearchPostsByAttributes(t *testing.T, th *SearchTestHelper) {
+	plain, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "attribute test plain", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	pinned, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "attribute test pinned", "", model.PostTypeDefault, 0, true)
+	require.NoError(t, err)
+	link, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "attribute test https://example.com/page", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	withFileModel := th.createPostModel(th.User.Id, th.ChannelBasic.Id, "attribute test file", "", model.PostTypeDefault, 1000000, false)
+	withFileModel.FileIds = model.StringArray{model.NewId()}
+	withFile, err := th.Store.Post().Save(th.Context, withFileModel)
+	require.NoError(t, err)
+	reply, err := th.createReply(th.User.Id, "attribute test reply", "", plain, 1000001, false)
+	require.NoError(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	testCases := []struct {
+		name        string
+		params      *model.SearchParams
+		expectedIDs []string
+	}{
+		{
+			name:        "has:file",
+			params:      &model.SearchParams{Terms: "attribute", HasFile: model.NewPointer(true)},
+			expectedIDs: []string{withFile.Id},
+		},
+		{
+			name:        "-has:file",
+			params:      &model.SearchParams{Terms: "attribute", HasFile: model.NewPointer(false)},
+			expectedIDs: []string{plain.Id, pinned.Id, link.Id, reply.Id},
+		},
+		{
+			name:        "has:link",
+			params:      &model.SearchParams{Terms: "attribute", HasLink: model.NewPointer(true)},
+			expectedIDs: []string{link.Id},
+		},
+		{
+			name:        "is:pinned",
+			params:      &model.SearchParams{Terms: "attribute", IsPinned: model.NewPointer(true)},
+			expectedIDs: []string{pinned.Id},
+		},
+		{
+			name:        "is:reply",
+			params:      &model.SearchParams{Terms: "attribute", IsReply: model.NewPointer(true)},
+			expectedIDs: []string{reply.Id},
+		},
+		{
+			name:        "is:root",
+			params:      &model.SearchParams{Terms: "attribute", IsReply: model.NewPointer(false)},
+			expectedIDs: []string{plain.Id, pinned.Id, link.Id, withFile.Id},
+		},
+		{
+			name:        "is:pinned without terms",
+			params:      &model.SearchParams{IsPinned: model.NewPointer(true)},
+			expectedIDs: []string{pinned.Id},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := th.Store.Post().SearchPostsForUser(th.Context, []*model.SearchParams{tc.params}, th.User.Id, th.Team.Id, 0, 20)
+			require.NoError(t, err)
+
+			require.Len(t, results.Posts, len(tc.expectedIDs))
+			for _, id := range tc.expectedIDs {
+				th.checkPostInSearchResults(t, id, results.Posts)
+			}
+		})
+	}
+}
+
+func testSearchFlaggedPostsAndReactions(t *testing.T, th *SearchTestHelper) {
+	p1, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "reaction test one", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	p2, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "reaction test two", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	p3, err := th.createPost(th.User.Id, th.ChannelBasic.Id, "reaction test three", "", model.PostTypeDefault, 0, false)
+	require.NoError(t, err)
+	defer th.deleteUserPosts(th.User.Id)
+
+	err = th.Store.Preference().Save(model.Preferences{
+		{UserId: th.User.Id, Category: model.PreferenceCategoryFlaggedPost, Name: p1.Id, Value: "true"},
+		{UserId: th.User2.Id, Category: model.PreferenceCategoryFlaggedPost, Name: p2.Id, Value: "true"},
+	})
+	require.NoError(t, err)
+	defer th.Store.Preference().PermanentDeleteByUser(th.User.Id)
+	defer th.Store.Preference().PermanentDeleteByUser(th.User2.Id)
+
+	_, err = th.Store.Reaction().Save(&model.Reaction{UserId: th.User.Id, PostId: p1.Id, EmojiName: "smile"})
+	require.NoError(t, err)
+	_, err = th.Store.Reaction().Save(&model.Reaction{UserId: th.User2.Id, PostId: p2.Id, EmojiName: "thumbsup"})
+	require.NoError(t, err)
+	defer th.Store.Reaction().PermanentDeleteByUser(th.User.Id)
+	defer th.Store.Reaction().PermanentDeleteByUser(th.User2.Id)
+
+	testCases := []struct {
+		name        string
+		params      *model.SearchParams
+		expectedIDs []string
+	}{
+		{
+			name:        "is:flagged only matches the posts flagged by the user",
+			params:      &model.SearchParams{Terms: "reaction", IsFlagged: model.NewPointer(true)},
+			expectedIDs: []string{p1.Id},
+		},
+		{
+			name:        "-is:flagged",
+			params:      &model.SearchParams{Terms: "reaction", IsFlagged: model.NewPointer(false)},
+			expectedIDs: []string{p2.Id, p3.Id},
+		},
+		{
+			name:        "has:reaction",
+			params:      &model.SearchParams{Terms: "reaction", HasReaction: model.NewPointer(true)},
+			expectedIDs: []string{p1.Id, p2.Id},
+		},
+		{
+			name:        "-has:reaction",
+			params:      &model.SearchParams{Terms: "reaction", HasReaction: model.NewPointer(false)},
+			expectedIDs: []string{p3.Id},
+		},
+		{
+			name:        "has:reaction with an emoji",
+			params:      &model.SearchParams{Terms: "reaction", Reactions: []string{"thumbsup"}},
+			expectedIDs: []string{p2.Id},
+		},
+		{
+			name:        "-has:reaction with an emoji",
+			params:      &model.SearchParams{Terms: "reaction", ExcludedReactions: []string{"thumbsup"}},
+			expectedIDs: []string{p1.Id, p3.Id},
+		},
+		{
+			name:        "is:flagged without terms",
+			params:      &model.SearchParams{IsFlagged: model.NewPointer(true)},
+			expectedIDs: []string{p1.Id},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := th.Store.Post().SearchPostsForUser(th.Context, []*model.SearchParams{tc.params}, th.User.Id, th.Team.Id, 0, 20)
+			require.NoError(t, err)
+
+			require.Len(t, results.Posts, len(tc.expectedIDs))
+			for _, id := range tc.expectedIDs {
+				th.checkPostInSearchResults(t, id, results.Posts)
+			}
+		})
+	}
+}
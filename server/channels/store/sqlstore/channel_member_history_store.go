@@ -377,3 +377,19 @@ func (s SqlChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since
 
 	return channelIds, nil
 }
+
+func (s SqlChannelMemberHistoryStore) GetChannelLeavesSince(userID string, since int64) ([]*model.ChannelMemberHistory, error) {
+	query := s.getQueryBuilder().
+		Select("ChannelId", "UserId", "MAX(JoinTime) AS JoinTime", "MAX(LeaveTime) AS LeaveTime").
+		From("ChannelMemberHistory").
+		Where(sq.Eq{"UserId": userID}).
+		GroupBy("ChannelId", "UserId").
+		Having("MAX(LeaveTime) > MAX(JoinTime) AND MAX(LeaveTime) > ?", since)
+
+	histories := []*model.ChannelMemberHistory{}
+	if err := s.GetReplica().SelectBuilder(&histories, query); err != nil {
+		return nil, errors.Wrapf(err, "GetChannelLeavesSince userId=%s since=%d", userID, since)
+	}
+
+	return histories, nil
+}
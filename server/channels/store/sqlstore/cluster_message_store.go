@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+)
+
+const clusterMessagesTable = "ClusterMessages"
+
+type SqlClusterMessageStore struct {
+	*SqlStore
+}
+
+func newSqlClusterMessageStore(sqlStore *SqlStore) store.ClusterMessageStore {
+	return &SqlClusterMessageStore{SqlStore: sqlStore}
+}
+
+func (s *SqlClusterMessageStore) Save(id string, data []byte) error {
+	query := s.getQueryBuilder().
+		Insert(clusterMessagesTable).
+		Columns("Id", "Data", "CreateAt").
+		Values(id, data, model.GetMillis())
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to save ClusterMessage with id=%s", id)
+	}
+
+	return nil
+}
+
+func (s *SqlClusterMessageStore) Get(id string) ([]byte, error) {
+	query := s.getQueryBuilder().
+		Select("Data").
+		From(clusterMessagesTable).
+		Where(sq.Eq{"Id": id})
+
+	var data []byte
+	if err := s.GetMaster().GetBuilder(&data, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ClusterMessage", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get ClusterMessage with id=%s", id)
+	}
+
+	return data, nil
+}
+
+// DeleteBefore deletes the messages saved before the given time, returning how many were deleted.
+func (s *SqlClusterMessageStore) DeleteBefore(createAt int64) (int64, error) {
+	query := s.getQueryBuilder().
+		Delete(clusterMessagesTable).
+		Where(sq.Lt{"CreateAt": createAt})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete ClusterMessages")
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return deleted, nil
+}
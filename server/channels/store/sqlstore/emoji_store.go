@@ -127,6 +127,25 @@ func (es SqlEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*mode
 	return emojis, nil
 }
 
+func (es SqlEmojiStore) GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.Emoji, error) {
+	query := es.getQueryBuilder().
+		Select("Id", "CreateAt", "UpdateAt", "DeleteAt", "CreatorId", "Name").
+		From("Emoji").
+		Where(sq.And{
+			sq.Gt{"Id": afterID},
+			sq.Gt{"DeleteAt": since},
+			sq.Expr("NOT EXISTS (SELECT 1 FROM Emoji Active WHERE Active.Name = Emoji.Name AND Active.DeleteAt = 0)"),
+		}).
+		OrderBy("Id").
+		Limit(uint64(limit))
+
+	emojis := []*model.Emoji{}
+	if err := es.GetReplica().SelectBuilder(&emojis, query); err != nil {
+		return nil, errors.Wrap(err, "could not get deleted emojis")
+	}
+	return emojis, nil
+}
+
 // getBy returns one active (not deleted) emoji, found by any one column (what/key).
 func (es SqlEmojiStore) getBy(rctx request.CTX, what, key string) (*model.Emoji, error) {
 	var emoji model.Emoji
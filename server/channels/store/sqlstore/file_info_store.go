@@ -42,6 +42,9 @@ type fileInfoWithChannelID struct {
 	Content         string
 	RemoteId        *string
 	Archived        bool
+	ScanStatus      string
+	ScannedAt       int64
+	ContentHash     string
 }
 
 func (fi fileInfoWithChannelID) ToModel() *model.FileInfo {
@@ -66,6 +69,9 @@ func (fi fileInfoWithChannelID) ToModel() *model.FileInfo {
 		MiniPreview:     fi.MiniPreview,
 		Content:         fi.Content,
 		RemoteId:        fi.RemoteId,
+		ScanStatus:      fi.ScanStatus,
+		ScannedAt:       fi.ScannedAt,
+		ContentHash:     fi.ContentHash,
 	}
 }
 
@@ -106,6 +112,9 @@ func newSqlFileInfoStore(sqlStore *SqlStore, metrics einterfaces.MetricsInterfac
 		"Coalesce(FileInfo.Content, '') AS Content",
 		"Coalesce(FileInfo.RemoteId, '') AS RemoteId",
 		"FileInfo.Archived",
+		"COALESCE(FileInfo.ScanStatus, '') AS ScanStatus",
+		"COALESCE(FileInfo.ScannedAt, 0) AS ScannedAt",
+		"COALESCE(FileInfo.ContentHash, '') AS ContentHash",
 	}
 
 	return s
@@ -120,10 +129,10 @@ func (fs SqlFileInfoStore) Save(rctx request.CTX, info *model.FileInfo) (*model.
 	query := `
 		INSERT INTO FileInfo
 		(Id, CreatorId, PostId, ChannelId, CreateAt, UpdateAt, DeleteAt, Path, ThumbnailPath, PreviewPath,
-			Name, Extension, Size, MimeType, Width, Height, HasPreviewImage, MiniPreview, Content, RemoteId)
+			Name, Extension, Size, MimeType, Width, Height, HasPreviewImage, MiniPreview, Content, RemoteId, ScanStatus, ScannedAt, ContentHash)
 		VALUES
 		(:Id, :CreatorId, :PostId, :ChannelId, :CreateAt, :UpdateAt, :DeleteAt, :Path, :ThumbnailPath, :PreviewPath,
-			:Name, :Extension, :Size, :MimeType, :Width, :Height, :HasPreviewImage, :MiniPreview, :Content, :RemoteId)
+			:Name, :Extension, :Size, :MimeType, :Width, :Height, :HasPreviewImage, :MiniPreview, :Content, :RemoteId, :ScanStatus, :ScannedAt, :ContentHash)
 	`
 
 	if _, err := fs.GetMaster().NamedExec(query, info); err != nil {
@@ -195,6 +204,9 @@ func (fs SqlFileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*mode
 			"MiniPreview":     info.MiniPreview,
 			"Content":         info.Content,
 			"RemoteId":        info.RemoteId,
+			"ScanStatus":      info.ScanStatus,
+			"ScannedAt":       info.ScannedAt,
+			"ContentHash":     info.ContentHash,
 		}).
 		Where(sq.Eq{"Id": info.Id}).
 		ToSql()
@@ -450,6 +462,241 @@ func (fs SqlFileInfoStore) SetContent(rctx request.CTX, fileId, content string)
 	return nil
 }
 
+func (fs SqlFileInfoStore) SetScanStatus(rctx request.CTX, fileId, status string, scannedAt int64) error {
+	query := fs.getQueryBuilder().
+		Update("FileInfo").
+		Set("ScanStatus", status).
+		Set("ScannedAt", scannedAt).
+		Where(sq.Eq{"Id": fileId})
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "file_info_tosql")
+	}
+
+	result, err := fs.GetMaster().Exec(queryString, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update FileInfo scan status with id=%s", fileId)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to retrieve rows affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("FileInfo", fileId)
+	}
+
+	return nil
+}
+
+func (fs SqlFileInfoStore) GetPendingScans(createdBefore int64, afterID string, limit int) ([]*model.FileInfo, error) {
+	query := fs.getQueryBuilder().
+		Select(fs.queryFields...).
+		From("FileInfo").
+		Where(sq.Eq{
+			"FileInfo.ScanStatus": model.FileScanStatusPending,
+			"FileInfo.DeleteAt":   0,
+		}).
+		Where(sq.Lt{"FileInfo.CreateAt": createdBefore}).
+		Where(sq.Gt{"FileInfo.Id": afterID}).
+		OrderBy("FileInfo.Id ASC").
+		Limit(uint64(limit))
+
+	items := []fileInfoWithChannelID{}
+	if err := fs.GetReplica().SelectBuilder(&items, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find FileInfos pending a scan")
+	}
+
+	infos := make([]*model.FileInfo, 0, len(items))
+	for _, item := range items {
+		infos = append(infos, item.ToModel())
+	}
+	return infos, nil
+}
+
+func (fs SqlFileInfoStore) GetFailedScans(now, maxBackoff int64, afterID string, limit int) ([]*model.FileInfo, error) {
+	query := fs.getQueryBuilder().
+		Select(fs.queryFields...).
+		From("FileInfo").
+		Where(sq.Eq{
+			"FileInfo.ScanStatus": model.FileScanStatusFailed,
+			"FileInfo.DeleteAt":   0,
+		}).
+		Where("FileInfo.ScannedAt <= ? - LEAST(FileInfo.ScannedAt - FileInfo.CreateAt, ?)", now, maxBackoff).
+		Where(sq.Gt{"FileInfo.Id": afterID}).
+		OrderBy("FileInfo.Id ASC").
+		Limit(uint64(limit))
+
+	items := []fileInfoWithChannelID{}
+	if err := fs.GetReplica().SelectBuilder(&items, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find FileInfos whose scan failed")
+	}
+
+	infos := make([]*model.FileInfo, 0, len(items))
+	for _, item := range items {
+		infos = append(infos, item.ToModel())
+	}
+	return infos, nil
+}
+
+func (fs SqlFileInfoStore) SetContentHash(rctx request.CTX, fileId, contentHash, path string) error {
+	query := fs.getQueryBuilder().
+		Update("FileInfo").
+		Set("ContentHash", contentHash).
+		Set("Path", path).
+		Where(sq.Eq{"Id": fileId})
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "file_info_tosql")
+	}
+
+	result, err := fs.GetMaster().Exec(queryString, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update FileInfo content hash with id=%s", fileId)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to retrieve rows affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("FileInfo", fileId)
+	}
+
+	return nil
+}
+
+func (fs SqlFileInfoStore) MoveFilesToBlob(rctx request.CTX, path, contentHash string) ([]string, error) {
+	query := fs.getQueryBuilder().
+		Update("FileInfo").
+		Set("ContentHash", contentHash).
+		Set("Path", model.FileBlobPath(contentHash)).
+		Where(sq.Eq{"Path": path}).
+		Suffix("RETURNING Id")
+
+	fileIds := []string{}
+	if err := fs.GetMaster().SelectBuilder(&fileIds, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to move FileInfos with path=%s to a blob", path)
+	}
+	if len(fileIds) == 0 {
+		return nil, store.NewErrNotFound("FileInfo", path)
+	}
+	return fileIds, nil
+}
+
+func (fs SqlFileInfoStore) AcquireBlob(contentHash string, size int64) (int64, error) {
+	var refCount int64
+	query := `
+		INSERT INTO FileBlobs (Hash, Size, RefCount, CreateAt)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT (Hash) DO UPDATE SET RefCount = FileBlobs.RefCount + 1
+		RETURNING RefCount
+	`
+	if err := fs.GetMaster().Get(&refCount, query, contentHash, size, model.GetMillis()); err != nil {
+		return 0, errors.Wrapf(err, "failed to acquire FileBlob with hash=%s", contentHash)
+	}
+	return refCount, nil
+}
+
+func (fs SqlFileInfoStore) ReleaseBlob(contentHash string, removeBlob func() error) (_ int64, err error) {
+	tx, err := fs.GetMaster().Begin()
+	if err != nil {
+		return 0, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(tx, &err)
+
+	// The update locks the row of the blob until the transaction ends, so the blob can't be
+	// acquired again while it is being removed.
+	var refCount int64
+	if err = tx.Get(&refCount, `UPDATE FileBlobs SET RefCount = RefCount - 1 WHERE Hash = ? RETURNING RefCount`, contentHash); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, store.NewErrNotFound("FileBlob", contentHash)
+		}
+		return 0, errors.Wrapf(err, "failed to release FileBlob with hash=%s", contentHash)
+	}
+
+	if refCount <= 0 {
+		if _, err = fs.removeBlobIfUnreferenced(tx, contentHash, removeBlob); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, "commit_transaction")
+	}
+	return max(refCount, 0), nil
+}
+
+func (fs SqlFileInfoStore) RemoveBlobIfUnreferenced(contentHash string, removeBlob func() error) (_ bool, err error) {
+	tx, err := fs.GetMaster().Begin()
+	if err != nil {
+		return false, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(tx, &err)
+
+	var refCount int64
+	if err = tx.Get(&refCount, `SELECT RefCount FROM FileBlobs WHERE Hash = ? FOR UPDATE`, contentHash); err != nil {
+		// The blob was already removed.
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to lock FileBlob with hash=%s", contentHash)
+	}
+
+	removed := false
+	if refCount <= 0 {
+		if removed, err = fs.removeBlobIfUnreferenced(tx, contentHash, removeBlob); err != nil {
+			return false, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, errors.Wrap(err, "commit_transaction")
+	}
+	return removed, nil
+}
+
+// removeBlobIfUnreferenced removes a blob locked by the transaction unless a file is stored in it.
+func (fs SqlFileInfoStore) removeBlobIfUnreferenced(tx *sqlxTxWrapper, contentHash string, removeBlob func() error) (bool, error) {
+	var referenced bool
+	if err := tx.Get(&referenced, `SELECT EXISTS (SELECT 1 FROM FileInfo WHERE Path = ?)`, model.FileBlobPath(contentHash)); err != nil {
+		return false, errors.Wrapf(err, "failed to check the files of FileBlob with hash=%s", contentHash)
+	}
+	if referenced {
+		return false, nil
+	}
+
+	if err := removeBlob(); err != nil {
+		return false, errors.Wrapf(err, "failed to remove FileBlob with hash=%s", contentHash)
+	}
+	if _, err := tx.Exec(`DELETE FROM FileBlobs WHERE Hash = ?`, contentHash); err != nil {
+		return false, errors.Wrapf(err, "failed to delete FileBlob with hash=%s", contentHash)
+	}
+	return true, nil
+}
+
+func (fs SqlFileInfoStore) GetUnreferencedBlobs(afterHash string, limit int) ([]string, error) {
+	// The path of the blob is built as model.FileBlobPath does, to use the index on FileInfo.Path.
+	query := `
+		SELECT Hash FROM FileBlobs
+		WHERE Hash > ?
+			AND RefCount <= 0
+			AND NOT EXISTS (
+				SELECT 1 FROM FileInfo
+				WHERE Path = 'blobs/' || substr(FileBlobs.Hash, 1, 2) || '/' || substr(FileBlobs.Hash, 3, 2) || '/' || FileBlobs.Hash
+			)
+		ORDER BY Hash
+		LIMIT ?
+	`
+	hashes := []string{}
+	if err := fs.GetReplica().Select(&hashes, query, afterHash, limit); err != nil {
+		return nil, errors.Wrap(err, "failed to get unreferenced FileBlobs")
+	}
+	return hashes, nil
+}
+
 func (fs SqlFileInfoStore) DeleteForPost(rctx request.CTX, postId string) (string, error) {
 	if _, err := fs.GetMaster().Exec(
 		`UPDATE
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+)
+
+const mfaRecoveryCodesTable = "MfaRecoveryCodes"
+
+type SqlMfaRecoveryCodeStore struct {
+	*SqlStore
+}
+
+func newSqlMfaRecoveryCodeStore(sqlStore *SqlStore) store.MfaRecoveryCodeStore {
+	return &SqlMfaRecoveryCodeStore{SqlStore: sqlStore}
+}
+
+// SaveForUser replaces the recovery codes of a user with the given hashed codes.
+func (s *SqlMfaRecoveryCodeStore) SaveForUser(userID string, codeHashes []string) (err error) {
+	tx, err := s.GetMaster().Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to start transaction")
+	}
+	defer finalizeTransactionX(tx, &err)
+
+	deleteQuery := s.getQueryBuilder().
+		Delete(mfaRecoveryCodesTable).
+		Where(sq.Eq{"UserId": userID})
+	if _, err = tx.ExecBuilder(deleteQuery); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaRecoveryCodes for user_id=%s", userID)
+	}
+
+	if len(codeHashes) > 0 {
+		createAt := model.GetMillis()
+		insertQuery := s.getQueryBuilder().
+			Insert(mfaRecoveryCodesTable).
+			Columns("UserId", "CodeHash", "CreateAt")
+		for _, codeHash := range codeHashes {
+			insertQuery = insertQuery.Values(userID, codeHash, createAt)
+		}
+		if _, err = tx.ExecBuilder(insertQuery); err != nil {
+			return errors.Wrapf(err, "failed to save MfaRecoveryCodes for user_id=%s", userID)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+// Use deletes the given recovery code of a user, reporting whether it existed. Each
+// code can only be used once.
+func (s *SqlMfaRecoveryCodeStore) Use(userID, codeHash string) (bool, error) {
+	query := s.getQueryBuilder().
+		Delete(mfaRecoveryCodesTable).
+		Where(sq.Eq{"UserId": userID, "CodeHash": codeHash})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to use MfaRecoveryCode for user_id=%s", userID)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return deleted > 0, nil
+}
+
+func (s *SqlMfaRecoveryCodeStore) PermanentDeleteByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete(mfaRecoveryCodesTable).
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaRecoveryCodes for user_id=%s", userID)
+	}
+
+	return nil
+}
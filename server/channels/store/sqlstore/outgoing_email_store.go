@@ -0,0 +1,208 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+)
+
+const outgoingEmailsTable = "OutgoingEmails"
+
+type SqlOutgoingEmailStore struct {
+	*SqlStore
+
+	selectQuery sq.SelectBuilder
+}
+
+func newSqlOutgoingEmailStore(sqlStore *SqlStore) store.OutgoingEmailStore {
+	s := &SqlOutgoingEmailStore{SqlStore: sqlStore}
+
+	// To, Cc and References are reserved words, so the columns are named differently.
+	s.selectQuery = s.getQueryBuilder().
+		Select(
+			"Id",
+			`ToAddress AS "to"`,
+			"CcAddress AS cc",
+			"ReplyTo",
+			"Subject",
+			"HTMLBody",
+			"EmbeddedFiles",
+			"MessageId",
+			"InReplyTo",
+			`ReferencesHeader AS "references"`,
+			"Category",
+			"Status",
+			"Attempts",
+			"LastError",
+			"NextAttemptAt",
+			"CreateAt",
+			"UpdateAt",
+		).
+		From(outgoingEmailsTable)
+
+	return s
+}
+
+func (s *SqlOutgoingEmailStore) Save(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
+	if email.Id != "" {
+		return nil, store.NewErrInvalidInput("OutgoingEmail", "id", email.Id)
+	}
+
+	email.PreSave()
+	if err := email.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert(outgoingEmailsTable).
+		SetMap(map[string]any{
+			"Id":               email.Id,
+			"ToAddress":        email.To,
+			"CcAddress":        email.Cc,
+			"ReplyTo":          email.ReplyTo,
+			"Subject":          email.Subject,
+			"HTMLBody":         email.HTMLBody,
+			"EmbeddedFiles":    email.EmbeddedFiles,
+			"MessageId":        email.MessageId,
+			"InReplyTo":        email.InReplyTo,
+			"ReferencesHeader": email.References,
+			"Category":         email.Category,
+			"Status":           email.Status,
+			"Attempts":         email.Attempts,
+			"LastError":        email.LastError,
+			"NextAttemptAt":    email.NextAttemptAt,
+			"CreateAt":         email.CreateAt,
+			"UpdateAt":         email.UpdateAt,
+		})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to save OutgoingEmail with id=%s", email.Id)
+	}
+
+	return email, nil
+}
+
+func (s *SqlOutgoingEmailStore) Get(id string) (*model.OutgoingEmail, error) {
+	var email model.OutgoingEmail
+	query := s.selectQuery.Where(sq.Eq{"Id": id})
+
+	if err := s.GetMaster().GetBuilder(&email, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("OutgoingEmail", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get OutgoingEmail with id=%s", id)
+	}
+
+	return &email, nil
+}
+
+func (s *SqlOutgoingEmailStore) Update(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
+	email.PreUpdate()
+	if err := email.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Update(outgoingEmailsTable).
+		SetMap(map[string]any{
+			"Status":        email.Status,
+			"Attempts":      email.Attempts,
+			"LastError":     email.LastError,
+			"NextAttemptAt": email.NextAttemptAt,
+			"UpdateAt":      email.UpdateAt,
+		}).
+		Where(sq.Eq{"Id": email.Id})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update OutgoingEmail with id=%s", email.Id)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get rows affected")
+	}
+	if updated == 0 {
+		return nil, store.NewErrNotFound("OutgoingEmail", email.Id)
+	}
+
+	return email, nil
+}
+
+func (s *SqlOutgoingEmailStore) GetDue(before int64, limit int) ([]*model.OutgoingEmail, error) {
+	query := s.selectQuery.
+		Where(sq.And{
+			sq.Eq{"Status": model.OutgoingEmailStatusPending},
+			sq.LtOrEq{"NextAttemptAt": before},
+		}).
+		OrderBy("NextAttemptAt ASC").
+		Limit(uint64(limit))
+
+	emails := []*model.OutgoingEmail{}
+	if err := s.GetMaster().SelectBuilder(&emails, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get OutgoingEmails due for delivery")
+	}
+
+	return emails, nil
+}
+
+func (s *SqlOutgoingEmailStore) GetFailed(offset, limit int) ([]*model.OutgoingEmail, error) {
+	query := s.selectQuery.
+		Where(sq.Eq{"Status": model.OutgoingEmailStatusFailed}).
+		OrderBy("UpdateAt DESC", "Id DESC")
+
+	if limit >= 0 && offset >= 0 {
+		query = query.Limit(uint64(limit)).Offset(uint64(offset))
+	}
+
+	emails := []*model.OutgoingEmail{}
+	if err := s.GetReplica().SelectBuilder(&emails, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get failed OutgoingEmails")
+	}
+
+	return emails, nil
+}
+
+func (s *SqlOutgoingEmailStore) Delete(id string) error {
+	query := s.getQueryBuilder().
+		Delete(outgoingEmailsTable).
+		Where(sq.Eq{"Id": id})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete OutgoingEmail with id=%s", id)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if deleted == 0 {
+		return store.NewErrNotFound("OutgoingEmail", id)
+	}
+
+	return nil
+}
+
+func (s *SqlOutgoingEmailStore) PermanentDeleteFailedBefore(before int64, limit int) (int64, error) {
+	query := `DELETE FROM OutgoingEmails WHERE Id = any (array (
+		SELECT Id FROM OutgoingEmails WHERE Status = ? AND UpdateAt < ? LIMIT ?))`
+
+	result, err := s.GetMaster().Exec(query, model.OutgoingEmailStatusFailed, before, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete failed OutgoingEmails")
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+)
+
+const pendingEmailNotificationsTable = "PendingEmailNotifications"
+
+var pendingEmailNotificationColumns = []string{
+	"Id",
+	"UserId",
+	"PostId",
+	"TeamId",
+	"CreateAt",
+	"Attempts",
+}
+
+type SqlPendingEmailNotificationStore struct {
+	*SqlStore
+
+	selectQuery sq.SelectBuilder
+}
+
+func newSqlPendingEmailNotificationStore(sqlStore *SqlStore) store.PendingEmailNotificationStore {
+	s := &SqlPendingEmailNotificationStore{SqlStore: sqlStore}
+	s.selectQuery = s.getQueryBuilder().
+		Select(pendingEmailNotificationColumns...).
+		From(pendingEmailNotificationsTable)
+	return s
+}
+
+func (s *SqlPendingEmailNotificationStore) Save(notification *model.PendingEmailNotification) (*model.PendingEmailNotification, error) {
+	notification.PreSave()
+
+	if err := notification.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert(pendingEmailNotificationsTable).
+		Columns(pendingEmailNotificationColumns...).
+		Values(notification.Id, notification.UserId, notification.PostId, notification.TeamId, notification.CreateAt, notification.Attempts)
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return nil, errors.Wrap(err, "failed to save PendingEmailNotification")
+	}
+
+	return notification, nil
+}
+
+func (s *SqlPendingEmailNotificationStore) GetBatchStartTimes() (map[string]int64, error) {
+	query := s.getQueryBuilder().
+		Select("UserId", "MIN(CreateAt) AS CreateAt").
+		From(pendingEmailNotificationsTable).
+		GroupBy("UserId")
+
+	// Read from the master so that notifications queued moments ago on another
+	// node aren't missed because of replica lag.
+	var rows []struct {
+		UserId   string
+		CreateAt int64
+	}
+	if err := s.GetMaster().SelectBuilder(&rows, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get batch start times of PendingEmailNotifications")
+	}
+
+	batchStartTimes := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		batchStartTimes[row.UserId] = row.CreateAt
+	}
+
+	return batchStartTimes, nil
+}
+
+func (s *SqlPendingEmailNotificationStore) GetForUser(userID string) ([]*model.PendingEmailNotification, error) {
+	query := s.selectQuery.
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("CreateAt ASC", "Id ASC")
+
+	notifications := []*model.PendingEmailNotification{}
+	if err := s.GetMaster().SelectBuilder(&notifications, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PendingEmailNotifications with userId=%s", userID)
+	}
+
+	return notifications, nil
+}
+
+func (s *SqlPendingEmailNotificationStore) Delete(ids []string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	query := s.getQueryBuilder().
+		Delete(pendingEmailNotificationsTable).
+		Where(sq.Eq{"Id": ids})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete PendingEmailNotifications")
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to get rows affected")
+	}
+
+	return deleted, nil
+}
+
+func (s *SqlPendingEmailNotificationStore) IncrementAttempts(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := s.getQueryBuilder().
+		Update(pendingEmailNotificationsTable).
+		Set("Attempts", sq.Expr("Attempts + 1")).
+		Where(sq.Eq{"Id": ids})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrap(err, "failed to increment the attempts of PendingEmailNotifications")
+	}
+
+	return nil
+}
+
+func (s *SqlPendingEmailNotificationStore) PermanentDeleteByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete(pendingEmailNotificationsTable).
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete PendingEmailNotifications with userId=%s", userID)
+	}
+
+	return nil
+}
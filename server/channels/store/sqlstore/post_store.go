@@ -2011,6 +2011,65 @@ func (s *SqlPostStore) buildCreateDateFilterClause(params *model.SearchParams, b
 	return builder
 }
 
+func (s *SqlPostStore) buildSearchPostAttributeFilterClause(userId string, params *model.SearchParams, builder sq.SelectBuilder) sq.SelectBuilder {
+	// handle has: and is: filters
+	if params.HasFile != nil {
+		if *params.HasFile {
+			builder = builder.Where(sq.Or{sq.NotEq{"q2.FileIds": "[]"}, sq.NotEq{"q2.Filenames": "[]"}})
+		} else {
+			builder = builder.Where(sq.And{sq.Eq{"q2.FileIds": "[]"}, sq.Eq{"q2.Filenames": "[]"}})
+		}
+	}
+
+	if params.HasLink != nil {
+		if *params.HasLink {
+			builder = builder.Where(sq.Or{sq.ILike{"q2.Message": "%http://%"}, sq.ILike{"q2.Message": "%https://%"}})
+		} else {
+			builder = builder.Where(sq.And{sq.NotILike{"q2.Message": "%http://%"}, sq.NotILike{"q2.Message": "%https://%"}})
+		}
+	}
+
+	if params.IsPinned != nil {
+		builder = builder.Where(sq.Eq{"q2.IsPinned": *params.IsPinned})
+	}
+
+	if params.IsReply != nil {
+		if *params.IsReply {
+			builder = builder.Where(sq.NotEq{"q2.RootId": ""})
+		} else {
+			builder = builder.Where(sq.Eq{"q2.RootId": ""})
+		}
+	}
+
+	if params.IsFlagged != nil {
+		flagged := "EXISTS (SELECT 1 FROM Preferences WHERE Preferences.UserId = ? AND Preferences.Category = ? AND Preferences.Name = q2.Id)"
+		if !*params.IsFlagged {
+			flagged = "NOT " + flagged
+		}
+		builder = builder.Where(flagged, userId, model.PreferenceCategoryFlaggedPost)
+	}
+
+	reactions := "EXISTS (SELECT 1 FROM Reactions WHERE Reactions.PostId = q2.Id AND Reactions.DeleteAt = 0)"
+	if params.HasReaction != nil {
+		if *params.HasReaction {
+			builder = builder.Where(reactions)
+		} else {
+			builder = builder.Where("NOT " + reactions)
+		}
+	}
+
+	// every emoji has to be on the post, but none of the excluded ones
+	for _, emojiName := range params.Reactions {
+		builder = builder.Where("EXISTS (SELECT 1 FROM Reactions WHERE Reactions.PostId = q2.Id AND Reactions.DeleteAt = 0 AND Reactions.EmojiName = ?)", emojiName)
+	}
+
+	if len(params.ExcludedReactions) != 0 {
+		builder = builder.Where(sq.Expr("NOT EXISTS (SELECT 1 FROM Reactions WHERE Reactions.PostId = q2.Id AND Reactions.DeleteAt = 0 AND ?)", sq.Eq{"Reactions.EmojiName": params.ExcludedReactions}))
+	}
+
+	return builder
+}
+
 func (s *SqlPostStore) buildSearchTeamFilterClause(teamId string, builder sq.SelectBuilder) sq.SelectBuilder {
 	if teamId == "" {
 		return builder
@@ -2162,7 +2221,8 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 	if params.Terms == "" && params.ExcludedTerms == "" &&
 		len(params.InChannels) == 0 && len(params.ExcludedChannels) == 0 &&
 		len(params.FromUsers) == 0 && len(params.ExcludedUsers) == 0 &&
-		params.OnDate == "" && params.AfterDate == "" && params.BeforeDate == "" {
+		params.OnDate == "" && params.AfterDate == "" && params.BeforeDate == "" &&
+		!params.HasPostAttributeFilters() {
 		return list, nil
 	}
 
@@ -2184,6 +2244,7 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 		return nil, errors.Wrap(err, "failed to build search post filter clause")
 	}
 	baseQuery = s.buildCreateDateFilterClause(params, baseQuery)
+	baseQuery = s.buildSearchPostAttributeFilterClause(userId, params, baseQuery)
 
 	termMap := map[string]bool{}
 	terms := params.Terms
@@ -2205,7 +2266,7 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 	}
 
 	if terms == "" && excludedTerms == "" {
-		// we've already confirmed that we have a channel or user to search for
+		// we've already confirmed that we have a channel, user or post attribute to search for
 	} else if s.getFeatureFlags().CJKSearch && (model.ContainsCJK(terms) || model.ContainsCJK(excludedTerms)) {
 		// CJK characters are not supported by PostgreSQL's to_tsvector/to_tsquery
 		// with the default English text search config. Fall back to LIKE matching.
@@ -2671,22 +2732,42 @@ func (s *SqlPostStore) GetMaxPostSize() int {
 	return s.maxPostSizeCached
 }
 
-func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, includeArchivedChannel bool) ([]*model.PostForExport, error) {
+// exportScopeCondition returns the condition restricting the posts of an export to the teams
+// and channels of opts, or nil if the export isn't restricted.
+func exportScopeCondition(column string, opts model.PostExportOpts) sq.Sqlizer {
+	if len(opts.TeamIds) == 0 && len(opts.ChannelIds) == 0 {
+		return nil
+	}
+
+	channelsQuery := sq.Select("Id").From("Channels").Where(sq.Or{
+		sq.Eq{"TeamId": opts.TeamIds},
+		sq.Eq{"Id": opts.ChannelIds},
+	})
+	return sq.Expr(column+" IN (?)", channelsQuery)
+}
+
+func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, opts model.PostExportOpts) ([]*model.PostForExport, error) {
 	for {
+		rootIdsQuery := s.getQueryBuilder().
+			Select("Id").
+			From("Posts").
+			Where(sq.And{
+				sq.Gt{"Posts.Id": afterId},
+				sq.Eq{"Posts.RootId": ""},
+				sq.Eq{"Posts.DeleteAt": 0},
+			}).
+			OrderBy("Posts.Id").
+			Limit(uint64(limit))
+		if opts.Since > 0 {
+			// the root post is updated along with its replies and reactions.
+			rootIdsQuery = rootIdsQuery.Where(sq.Gt{"Posts.UpdateAt": opts.Since})
+		}
+		if scope := exportScopeCondition("Posts.ChannelId", opts); scope != nil {
+			rootIdsQuery = rootIdsQuery.Where(scope)
+		}
+
 		rootIds := []string{}
-		err := s.GetReplica().Select(&rootIds,
-			`SELECT
-				Id
-			FROM
-				Posts
-			WHERE
-				Posts.Id > ?
-				AND Posts.RootId = ''
-				AND Posts.DeleteAt = 0
-			ORDER BY Posts.Id
-			LIMIT ?`,
-			afterId, limit)
-		if err != nil {
+		if err := s.GetReplica().SelectBuilder(&rootIds, rootIdsQuery); err != nil {
 			return nil, errors.Wrap(err, "failed to find Posts")
 		}
 
@@ -2698,7 +2779,7 @@ func (s *SqlPostStore) GetParentsForExportAfter(limit int, afterId string, inclu
 		excludeDeletedCond := sq.And{
 			sq.Eq{"Teams.DeleteAt": 0},
 		}
-		if !includeArchivedChannel {
+		if !opts.IncludeArchivedChannels {
 			excludeDeletedCond = append(excludeDeletedCond, sq.Eq{"Channels.DeleteAt": 0})
 		}
 
@@ -2753,7 +2834,7 @@ func (s *SqlPostStore) GetRepliesForExport(rootId string) ([]*model.ReplyForExpo
 	return result, nil
 }
 
-func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId string, opts model.PostExportOpts) ([]*model.DirectPostForExport, error) {
 	aggFn := "COALESCE(json_agg(u1.username) FILTER (WHERE u1.username IS NOT NULL), '[]')"
 	result := []*model.DirectPostForExport{}
 
@@ -2776,11 +2857,17 @@ func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId str
 		OrderBy("p.Id").
 		Limit(uint64(limit))
 
-	if !includeArchivedChannels {
+	if !opts.IncludeArchivedChannels {
 		query = query.Where(
 			sq.Eq{"Channels.DeleteAt": 0},
 		)
 	}
+	if opts.Since > 0 {
+		query = query.Where(sq.Gt{"p.UpdateAt": opts.Since})
+	}
+	if scope := exportScopeCondition("p.ChannelId", opts); scope != nil {
+		query = query.Where(scope)
+	}
 
 	if err := s.GetReplica().SelectBuilder(&result, query); err != nil {
 		return nil, errors.Wrap(err, "failed to find Posts")
@@ -2825,6 +2912,78 @@ func (s *SqlPostStore) GetDirectPostParentsForExportAfter(limit int, afterId str
 	return result, nil
 }
 
+// GetDeletedForExportAfter returns the posts and replies deleted after opts.Since. The previous
+// versions of edited posts are left out, as the importer updates edited posts in place.
+func (s *SqlPostStore) GetDeletedForExportAfter(limit int, afterId string, opts model.PostExportOpts) ([]*model.DeletedPostForExport, error) {
+	query := s.getQueryBuilder().
+		Select(postSliceColumnsWithName("p")...).
+		Columns(
+			"COALESCE(Teams.Name, '') as TeamName",
+			"Channels.Name as ChannelName",
+			"Channels.Type as ChannelType",
+			"Users.Username as Username",
+		).
+		From("Posts p").
+		InnerJoin("Channels ON p.ChannelId = Channels.Id").
+		LeftJoin("Teams ON Channels.TeamId = Teams.Id").
+		InnerJoin("Users ON p.UserId = Users.Id").
+		Where(sq.And{
+			sq.Gt{"p.Id": afterId},
+			sq.Gt{"p.DeleteAt": opts.Since},
+			sq.Eq{"p.OriginalId": ""},
+		}).
+		OrderBy("p.Id").
+		Limit(uint64(limit))
+
+	if !opts.IncludeArchivedChannels {
+		query = query.Where(sq.Eq{"Channels.DeleteAt": 0})
+	}
+	if scope := exportScopeCondition("p.ChannelId", opts); scope != nil {
+		query = query.Where(scope)
+	}
+
+	result := []*model.DeletedPostForExport{}
+	if err := s.GetReplica().SelectBuilder(&result, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find deleted Posts")
+	}
+
+	var directChannelIds []string
+	for _, post := range result {
+		if post.ChannelType == model.ChannelTypeDirect || post.ChannelType == model.ChannelTypeGroup {
+			directChannelIds = append(directChannelIds, post.ChannelId)
+		}
+	}
+	if len(directChannelIds) == 0 {
+		return result, nil
+	}
+
+	membersQuery := s.getQueryBuilder().
+		Select("cm.ChannelId", "u.Username").
+		From("ChannelMembers cm").
+		Join("Users u ON ( u.Id = cm.UserId )").
+		Where(sq.Eq{"cm.ChannelId": directChannelIds})
+
+	members := []struct {
+		ChannelId string
+		Username  string
+	}{}
+	if err := s.GetReplica().SelectBuilder(&members, membersQuery); err != nil {
+		return nil, errors.Wrap(err, "failed to find ChannelMembers")
+	}
+
+	channelMembersMap := make(map[string][]string)
+	for _, member := range members {
+		channelMembersMap[member.ChannelId] = append(channelMembersMap[member.ChannelId], member.Username)
+	}
+	for _, post := range result {
+		if usernames, ok := channelMembersMap[post.ChannelId]; ok {
+			post.ChannelMembers = &usernames
+		}
+	}
+
+	return result, nil
+}
+
 //nolint:unparam
 func (s *SqlPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.PostSearchResults, error) {
 	// Since we don't support paging for DB search, we just return nothing for later pages
@@ -185,6 +185,18 @@ func (s *SqlPropertyValueStore) SearchPropertyValues(opts model.PropertyValueSea
 		builder = builder.Where(sq.Eq{"Value": string(opts.Value)})
 	}
 
+	if opts.ValueMin != nil || opts.ValueMax != nil {
+		// Non-numeric values evaluate to NULL, which never matches the range,
+		// rather than making the cast fail.
+		number := "CASE WHEN jsonb_typeof(Value) = 'number' THEN (Value)::numeric END"
+		if opts.ValueMin != nil {
+			builder = builder.Where(sq.GtOrEq{number: *opts.ValueMin})
+		}
+		if opts.ValueMax != nil {
+			builder = builder.Where(sq.LtOrEq{number: *opts.ValueMax})
+		}
+	}
+
 	var values []*model.PropertyValue
 	if err := s.GetReplica().SelectBuilder(&values, builder); err != nil {
 		return nil, errors.Wrap(err, "property_value_search_query")
@@ -387,3 +399,40 @@ func (s *SqlPropertyValueStore) DeleteForTarget(groupID string, targetType strin
 
 	return nil
 }
+
+// GetForChannelPostsSince returns the values of the group that target posts of the given
+// channel, ordered by the time of their last change and their id. Only values that come after
+// the (since, afterID) pair in that order are returned, so that values changed at the same time
+// can be fetched across several pages.
+func (s *SqlPropertyValueStore) GetForChannelPostsSince(groupID, channelID string, since int64, afterID string, limit int) ([]*model.PropertyValue, error) {
+	if limit < 1 {
+		return nil, errors.New("limit must be positive integer greater than zero")
+	}
+
+	builder := s.getQueryBuilder().
+		Select("pv.ID", "pv.TargetID", "pv.TargetType", "pv.GroupID", "pv.FieldID", "pv.Value", "pv.CreateAt", "pv.UpdateAt", "pv.DeleteAt", "COALESCE(pv.CreatedBy, '') as CreatedBy", "COALESCE(pv.UpdatedBy, '') as UpdatedBy").
+		From("PropertyValues pv").
+		Join("Posts p ON p.Id = pv.TargetID").
+		Where(sq.Eq{
+			"pv.GroupID":    groupID,
+			"pv.TargetType": model.PropertyValueTargetTypePost,
+			"p.ChannelId":   channelID,
+		}).
+		Where(sq.Or{
+			sq.Gt{"pv.UpdateAt": since},
+			sq.Gt{"pv.DeleteAt": since},
+			sq.And{
+				sq.Expr("GREATEST(pv.UpdateAt, pv.DeleteAt) = ?", since),
+				sq.Gt{"pv.ID": afterID},
+			},
+		}).
+		OrderBy("GREATEST(pv.UpdateAt, pv.DeleteAt) ASC", "pv.ID ASC").
+		Limit(uint64(limit))
+
+	var values []*model.PropertyValue
+	if err := s.GetReplica().SelectBuilder(&values, builder); err != nil {
+		return nil, errors.Wrap(err, "property_value_get_for_channel_posts_since_query")
+	}
+
+	return values, nil
+}
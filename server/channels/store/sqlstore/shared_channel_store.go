@@ -417,6 +417,10 @@ func sharedChannelRemoteFields(prefix string) []string {
 		prefix + "LastPostUpdateAt",
 		"COALESCE(" + prefix + "LastPostId,'') AS LastPostUpdateID",
 		prefix + "LastMembersSyncAt",
+		prefix + "LastChannelUpdateAt",
+		prefix + "LastBookmarkUpdateAt",
+		prefix + "LastPropertyValueUpdateAt",
+		"COALESCE(" + prefix + "LastPropertyValueId,'') AS LastPropertyValueId",
 	}
 }
 
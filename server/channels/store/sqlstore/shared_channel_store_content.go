@@ -0,0 +1,55 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"fmt"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// UpdateRemoteContentCursor updates the channel, bookmark and property value cursors for the
+// specified SharedChannelRemote. Each cursor only moves forward; zero values are ignored. The
+// property value cursor is compared and updated as an (UpdateAt, Id) pair.
+func (s SqlSharedChannelStore) UpdateRemoteContentCursor(id string, cursor model.SharedChannelContentCursor) error {
+	if cursor.IsEmpty() {
+		return fmt.Errorf("cursor empty")
+	}
+
+	query := s.getQueryBuilder().
+		Update("SharedChannelRemotes").
+		Set("LastChannelUpdateAt", sq.Expr("GREATEST(LastChannelUpdateAt, ?)", cursor.LastChannelUpdateAt)).
+		Set("LastBookmarkUpdateAt", sq.Expr("GREATEST(LastBookmarkUpdateAt, ?)", cursor.LastBookmarkUpdateAt)).
+		Where(sq.Eq{"Id": id})
+
+	if cursor.LastPropertyValueUpdateAt > 0 || cursor.LastPropertyValueId != "" {
+		// Both columns are computed from the row before the update, so they are set from
+		// the same comparison of the stored and the new pair.
+		newer := "(LastPropertyValueUpdateAt, COALESCE(LastPropertyValueId, '')) < (?, ?)"
+		query = query.
+			Set("LastPropertyValueUpdateAt", sq.Expr("CASE WHEN "+newer+" THEN ? ELSE LastPropertyValueUpdateAt END",
+				cursor.LastPropertyValueUpdateAt, cursor.LastPropertyValueId, cursor.LastPropertyValueUpdateAt)).
+			Set("LastPropertyValueId", sq.Expr("CASE WHEN "+newer+" THEN ? ELSE COALESCE(LastPropertyValueId, '') END",
+				cursor.LastPropertyValueUpdateAt, cursor.LastPropertyValueId, cursor.LastPropertyValueId))
+	}
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrap(err, "failed to update content cursor for SharedChannelRemote")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine rows affected")
+	}
+
+	if count == 0 {
+		return fmt.Errorf("id not found: %s", id)
+	}
+
+	return nil
+}
@@ -120,6 +120,11 @@ type SqlStoreStores struct {
 	readReceipt                store.ReadReceiptStore
 	temporaryPost              store.TemporaryPostStore
 	channelJoinRequest         store.ChannelJoinRequestStore
+	pendingEmailNotification   store.PendingEmailNotificationStore
+	outgoingEmail              store.OutgoingEmailStore
+	webAuthnCredential         store.WebAuthnCredentialStore
+	mfaRecoveryCode            store.MfaRecoveryCodeStore
+	clusterMessage             store.ClusterMessageStore
 }
 
 type SqlStore struct {
@@ -314,6 +319,11 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.readReceipt = newSqlReadReceiptStore(store, metrics)
 	store.stores.temporaryPost = newSqlTemporaryPostStore(store, metrics)
 	store.stores.channelJoinRequest = newSqlChannelJoinRequestStore(store)
+	store.stores.pendingEmailNotification = newSqlPendingEmailNotificationStore(store)
+	store.stores.outgoingEmail = newSqlOutgoingEmailStore(store)
+	store.stores.webAuthnCredential = newSqlWebAuthnCredentialStore(store)
+	store.stores.mfaRecoveryCode = newSqlMfaRecoveryCodeStore(store)
+	store.stores.clusterMessage = newSqlClusterMessageStore(store)
 
 	store.stores.preference.(*SqlPreferenceStore).deleteUnusedFeatures()
 
@@ -974,6 +984,26 @@ func (ss *SqlStore) ChannelJoinRequest() store.ChannelJoinRequestStore {
 	return ss.stores.channelJoinRequest
 }
 
+func (ss *SqlStore) PendingEmailNotification() store.PendingEmailNotificationStore {
+	return ss.stores.pendingEmailNotification
+}
+
+func (ss *SqlStore) OutgoingEmail() store.OutgoingEmailStore {
+	return ss.stores.outgoingEmail
+}
+
+func (ss *SqlStore) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return ss.stores.webAuthnCredential
+}
+
+func (ss *SqlStore) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return ss.stores.mfaRecoveryCode
+}
+
+func (ss *SqlStore) ClusterMessage() store.ClusterMessageStore {
+	return ss.stores.clusterMessage
+}
+
 func (ss *SqlStore) DropAllTables() {
 	ss.masterX.Exec(`DO
 		$func$
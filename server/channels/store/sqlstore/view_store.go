@@ -5,6 +5,7 @@ package sqlstore
 
 import (
 	"database/sql"
+	"fmt"
 	"slices"
 	"strconv"
 
@@ -274,3 +275,122 @@ func (s *SqlViewStore) UpdateSortOrder(viewID, channelID string, newIndex int64)
 	}
 	return views, nil
 }
+
+// GetPostsForTable returns the root posts of the channel matching the filters of a
+// table view, sorted by their value for the sort field if any, newest first otherwise.
+func (s *SqlViewStore) GetPostsForTable(channelID string, props *model.TableProps, opts model.ViewPostsQueryOpts) ([]*model.Post, error) {
+	if channelID == "" {
+		return nil, store.NewErrInvalidInput("View", "channelID", channelID)
+	}
+	if props == nil {
+		return nil, store.NewErrInvalidInput("View", "props", nil)
+	}
+
+	opts = normalizeViewPostsQueryOpts(opts)
+	builder := s.viewPostsQuery(channelID, props.Filters)
+
+	if props.Sort != nil {
+		direction := "ASC"
+		if props.Sort.Direction == model.ViewSortDirectionDesc {
+			direction = "DESC"
+		}
+		// Posts without a value for the field always come last, whatever the direction.
+		builder = builder.
+			LeftJoin("PropertyValues sv ON sv.TargetID = p.Id AND sv.TargetType = ? AND sv.FieldID = ? AND sv.DeleteAt = 0", model.PropertyValueTargetTypePost, props.Sort.FieldID).
+			OrderBy("sv.Value " + direction + " NULLS LAST")
+	}
+
+	builder = builder.
+		OrderBy("p.CreateAt DESC", "p.Id ASC").
+		Limit(uint64(opts.PerPage)).
+		Offset(uint64(opts.Page * opts.PerPage))
+
+	posts := []*model.Post{}
+	if err := s.GetReplica().SelectBuilder(&posts, builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to get posts for table view in channel %s", channelID)
+	}
+
+	return posts, nil
+}
+
+// GetPostsForCalendar returns the root posts of the channel matching the filters of a
+// calendar view whose date falls between opts.Start and opts.End, sorted by date.
+func (s *SqlViewStore) GetPostsForCalendar(channelID string, props *model.CalendarProps, opts model.ViewPostsQueryOpts) ([]*model.Post, error) {
+	if channelID == "" {
+		return nil, store.NewErrInvalidInput("View", "channelID", channelID)
+	}
+	if props == nil {
+		return nil, store.NewErrInvalidInput("View", "props", nil)
+	}
+	if !opts.IsValidCalendarRange() {
+		return nil, store.NewErrInvalidInput("View", "range", fmt.Sprintf("start=%d, end=%d", opts.Start, opts.End))
+	}
+
+	opts = normalizeViewPostsQueryOpts(opts)
+
+	// Date values are stored as JSON numbers holding a timestamp in milliseconds. The
+	// cast is guarded so that values of any other type can't make the query fail, as
+	// the order in which conditions are evaluated isn't guaranteed.
+	date := "CASE WHEN jsonb_typeof(dv.Value) = 'number' THEN (dv.Value)::numeric END"
+	builder := s.viewPostsQuery(channelID, props.Filters).
+		Join("PropertyValues dv ON dv.TargetID = p.Id AND dv.TargetType = ? AND dv.FieldID = ? AND dv.DeleteAt = 0", model.PropertyValueTargetTypePost, props.DateFieldID).
+		Where(sq.GtOrEq{date: opts.Start}).
+		Where(sq.Lt{date: opts.End}).
+		OrderBy(date+" ASC", "p.CreateAt ASC", "p.Id ASC").
+		Limit(uint64(opts.PerPage)).
+		Offset(uint64(opts.Page * opts.PerPage))
+
+	posts := []*model.Post{}
+	if err := s.GetReplica().SelectBuilder(&posts, builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to get posts for calendar view in channel %s", channelID)
+	}
+
+	return posts, nil
+}
+
+// viewPostsQuery selects the root posts of the channel matching all the filters,
+// leaving out system messages.
+func (s *SqlViewStore) viewPostsQuery(channelID string, filters []model.ViewFilter) sq.SelectBuilder {
+	builder := s.getQueryBuilder().
+		Select(postSliceColumnsWithName("p")...).
+		From("Posts p").
+		Where(sq.Eq{"p.ChannelId": channelID, "p.RootId": "", "p.DeleteAt": 0}).
+		Where(sq.NotLike{"p.Type": model.PostSystemMessagePrefix + "%"})
+
+	for _, filter := range filters {
+		builder = builder.Where(viewFilterExpr(filter))
+	}
+
+	return builder
+}
+
+// viewFilterExpr matches the posts whose value for the field of the filter is one of its
+// values once converted to text. Array values, such as those of multiselect fields, are
+// expanded so that matching any of their elements is enough.
+func viewFilterExpr(filter model.ViewFilter) sq.Sqlizer {
+	args := make([]any, 0, len(filter.Values)+2)
+	args = append(args, model.PropertyValueTargetTypePost, filter.FieldID)
+	for _, value := range filter.Values {
+		args = append(args, value)
+	}
+
+	return sq.Expr(fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM PropertyValues fv,
+			jsonb_array_elements_text(CASE WHEN jsonb_typeof(fv.Value) = 'array' THEN fv.Value ELSE jsonb_build_array(fv.Value) END) AS fe(Value)
+		WHERE fv.TargetID = p.Id AND fv.TargetType = ? AND fv.FieldID = ? AND fv.DeleteAt = 0 AND fe.Value IN (%s)
+	)`, sq.Placeholders(len(filter.Values))), args...)
+}
+
+func normalizeViewPostsQueryOpts(opts model.ViewPostsQueryOpts) model.ViewPostsQueryOpts {
+	if opts.PerPage <= 0 {
+		opts.PerPage = model.ViewPostsQueryDefaultPerPage
+	} else if opts.PerPage > model.ViewPostsQueryMaxPerPage {
+		opts.PerPage = model.ViewPostsQueryMaxPerPage
+	}
+
+	if opts.Page < 0 {
+		opts.Page = 0
+	}
+
+	return opts
+}
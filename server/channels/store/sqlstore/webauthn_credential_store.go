@@ -0,0 +1,172 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+)
+
+const webAuthnCredentialsTable = "WebAuthnCredentials"
+
+type SqlWebAuthnCredentialStore struct {
+	*SqlStore
+
+	selectQuery sq.SelectBuilder
+}
+
+func newSqlWebAuthnCredentialStore(sqlStore *SqlStore) store.WebAuthnCredentialStore {
+	s := &SqlWebAuthnCredentialStore{SqlStore: sqlStore}
+
+	s.selectQuery = s.getQueryBuilder().
+		Select(
+			"Id",
+			"UserId",
+			"Name",
+			"CredentialId",
+			"PublicKey",
+			"SignCount",
+			"CreateAt",
+			"LastUsedAt",
+		).
+		From(webAuthnCredentialsTable)
+
+	return s
+}
+
+func (s *SqlWebAuthnCredentialStore) Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error) {
+	if credential.Id != "" {
+		return nil, store.NewErrInvalidInput("WebAuthnCredential", "id", credential.Id)
+	}
+
+	credential.PreSave()
+	if err := credential.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder().
+		Insert(webAuthnCredentialsTable).
+		SetMap(map[string]any{
+			"Id":           credential.Id,
+			"UserId":       credential.UserId,
+			"Name":         credential.Name,
+			"CredentialId": credential.CredentialId,
+			"PublicKey":    credential.PublicKey,
+			"SignCount":    credential.SignCount,
+			"CreateAt":     credential.CreateAt,
+			"LastUsedAt":   credential.LastUsedAt,
+		})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		if IsUniqueConstraintError(err, []string{"CredentialId", "idx_webauthncredentials_credentialid"}) {
+			return nil, store.NewErrConflict("WebAuthnCredential", err, "credential_id="+credential.CredentialId)
+		}
+		return nil, errors.Wrapf(err, "failed to save WebAuthnCredential with id=%s", credential.Id)
+	}
+
+	return credential, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) Get(id string) (*model.WebAuthnCredential, error) {
+	var credential model.WebAuthnCredential
+	query := s.selectQuery.Where(sq.Eq{"Id": id})
+
+	if err := s.GetReplica().GetBuilder(&credential, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("WebAuthnCredential", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get WebAuthnCredential with id=%s", id)
+	}
+
+	return &credential, nil
+}
+
+// GetByCredentialId reads from the master, as the signature counter of the credential
+// must be up to date to detect cloned authenticators.
+func (s *SqlWebAuthnCredentialStore) GetByCredentialId(credentialID string) (*model.WebAuthnCredential, error) {
+	var credential model.WebAuthnCredential
+	query := s.selectQuery.Where(sq.Eq{"CredentialId": credentialID})
+
+	if err := s.GetMaster().GetBuilder(&credential, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("WebAuthnCredential", credentialID)
+		}
+		return nil, errors.Wrap(err, "failed to get WebAuthnCredential by credential id")
+	}
+
+	return &credential, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) GetForUser(userID string) ([]*model.WebAuthnCredential, error) {
+	query := s.selectQuery.
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("CreateAt ASC", "Id ASC")
+
+	credentials := []*model.WebAuthnCredential{}
+	if err := s.GetMaster().SelectBuilder(&credentials, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get WebAuthnCredentials for user_id=%s", userID)
+	}
+
+	return credentials, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) UpdateLastUsed(id string, signCount int64, lastUsedAt int64) error {
+	query := s.getQueryBuilder().
+		Update(webAuthnCredentialsTable).
+		Set("SignCount", signCount).
+		Set("LastUsedAt", lastUsedAt).
+		Where(sq.Eq{"Id": id})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update WebAuthnCredential with id=%s", id)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if updated == 0 {
+		return store.NewErrNotFound("WebAuthnCredential", id)
+	}
+
+	return nil
+}
+
+func (s *SqlWebAuthnCredentialStore) Delete(id string) error {
+	query := s.getQueryBuilder().
+		Delete(webAuthnCredentialsTable).
+		Where(sq.Eq{"Id": id})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete WebAuthnCredential with id=%s", id)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "unable to get rows affected")
+	}
+	if deleted == 0 {
+		return store.NewErrNotFound("WebAuthnCredential", id)
+	}
+
+	return nil
+}
+
+func (s *SqlWebAuthnCredentialStore) PermanentDeleteByUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete(webAuthnCredentialsTable).
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete WebAuthnCredentials for user_id=%s", userID)
+	}
+
+	return nil
+}
@@ -18,8 +18,9 @@ type SqlWebhookStore struct {
 	*SqlStore
 	metrics einterfaces.MetricsInterface
 
-	incomingWebhookSelectQuery sq.SelectBuilder
-	outgoingWebhookSelectQuery sq.SelectBuilder
+	incomingWebhookSelectQuery  sq.SelectBuilder
+	outgoingWebhookSelectQuery  sq.SelectBuilder
+	outgoingDeliverySelectQuery sq.SelectBuilder
 }
 
 func (s SqlWebhookStore) ClearCaches() {
@@ -72,6 +73,25 @@ func newSqlWebhookStore(sqlStore *SqlStore, metrics einterfaces.MetricsInterface
 		).
 		From("OutgoingWebhooks")
 
+	s.outgoingDeliverySelectQuery = s.getQueryBuilder().
+		Select(
+			"Id",
+			"HookId",
+			"PostId",
+			"CallbackURL",
+			"ContentType",
+			"Payload",
+			"Status",
+			"StatusCode",
+			"LatencyMs",
+			"Attempts",
+			"LastError",
+			"NextRetryAt",
+			"CreateAt",
+			"UpdateAt",
+		).
+		From("OutgoingWebhookDeliveries")
+
 	return s
 }
 
@@ -98,6 +118,37 @@ func (s SqlWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.In
 	return webhook, nil
 }
 
+func (s SqlWebhookStore) ImportIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	if !model.IsValidId(webhook.Id) {
+		return nil, store.NewErrInvalidInput("IncomingWebhook", "id", webhook.Id)
+	}
+
+	webhook.PreSave()
+	if err := webhook.IsValid(); err != nil {
+		return nil, err
+	}
+
+	// Deleted webhooks keep their id, so the id may be taken even when no webhook uses it.
+	result, err := s.GetMaster().NamedExec(`INSERT INTO IncomingWebhooks
+		(Id, CreateAt, UpdateAt, DeleteAt, UserId, ChannelId, TeamId, DisplayName, Description, Username, IconURL, ChannelLocked, LastUsed)
+		VALUES
+		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :UserId, :ChannelId, :TeamId, :DisplayName, :Description, :Username, :IconURL, :ChannelLocked, :LastUsed)
+		ON CONFLICT (Id) DO NOTHING`, webhook)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to import IncomingWebhook with id=%s", webhook.Id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve rows affected")
+	}
+	if count == 0 {
+		return nil, store.NewErrConflict("IncomingWebhook", nil, "id="+webhook.Id)
+	}
+
+	return webhook, nil
+}
+
 func (s SqlWebhookStore) UpdateIncoming(hook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	hook.UpdateAt = model.GetMillis()
 
@@ -406,6 +457,99 @@ func (s SqlWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.Out
 	return hook, nil
 }
 
+func (s SqlWebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	if delivery.Id != "" {
+		return nil, store.NewErrInvalidInput("OutgoingWebhookDelivery", "id", delivery.Id)
+	}
+
+	delivery.PreSave()
+	if err := delivery.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().NamedExec(`INSERT INTO OutgoingWebhookDeliveries
+		(Id, HookId, PostId, CallbackURL, ContentType, Payload, Status, StatusCode, LatencyMs, Attempts, LastError, NextRetryAt, CreateAt, UpdateAt)
+		VALUES
+		(:Id, :HookId, :PostId, :CallbackURL, :ContentType, :Payload, :Status, :StatusCode, :LatencyMs, :Attempts, :LastError, :NextRetryAt, :CreateAt, :UpdateAt)`, delivery); err != nil {
+		return nil, errors.Wrapf(err, "failed to save OutgoingWebhookDelivery with id=%s", delivery.Id)
+	}
+
+	return delivery, nil
+}
+
+func (s SqlWebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	delivery.PreUpdate()
+	if err := delivery.IsValid(); err != nil {
+		return nil, err
+	}
+
+	// The payload is only kept while the delivery can still be retried.
+	if delivery.Status == model.OutgoingWebhookDeliveryStatusSuccess || delivery.Status == model.OutgoingWebhookDeliveryStatusFailed {
+		delivery.Payload = ""
+	}
+
+	if _, err := s.GetMaster().NamedExec(`UPDATE OutgoingWebhookDeliveries SET
+			Payload = :Payload, Status = :Status, StatusCode = :StatusCode, LatencyMs = :LatencyMs,
+			Attempts = :Attempts, LastError = :LastError, NextRetryAt = :NextRetryAt, UpdateAt = :UpdateAt
+			WHERE Id = :Id`, delivery); err != nil {
+		return nil, errors.Wrapf(err, "failed to update OutgoingWebhookDelivery with id=%s", delivery.Id)
+	}
+
+	return delivery, nil
+}
+
+func (s SqlWebhookStore) GetOutgoingDeliveriesByHook(hookID string, offset, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	deliveries := []*model.OutgoingWebhookDelivery{}
+
+	query := s.outgoingDeliverySelectQuery.
+		Where(sq.Eq{"HookId": hookID}).
+		OrderBy("CreateAt DESC", "Id DESC")
+
+	if limit >= 0 && offset >= 0 {
+		query = query.Limit(uint64(limit)).Offset(uint64(offset))
+	}
+
+	if err := s.GetReplica().SelectBuilder(&deliveries, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to find OutgoingWebhookDeliveries with hookId=%s", hookID)
+	}
+
+	return deliveries, nil
+}
+
+func (s SqlWebhookStore) GetOutgoingDeliveriesForRetry(before int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	deliveries := []*model.OutgoingWebhookDelivery{}
+
+	query := s.outgoingDeliverySelectQuery.
+		Where(sq.And{
+			sq.Eq{"Status": []string{model.OutgoingWebhookDeliveryStatusPending, model.OutgoingWebhookDeliveryStatusRetrying}},
+			sq.LtOrEq{"NextRetryAt": before},
+		}).
+		OrderBy("NextRetryAt ASC").
+		Limit(uint64(limit))
+
+	if err := s.GetMaster().SelectBuilder(&deliveries, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find OutgoingWebhookDeliveries to retry")
+	}
+
+	return deliveries, nil
+}
+
+func (s SqlWebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64, limit int64) (int64, error) {
+	query := `DELETE FROM OutgoingWebhookDeliveries WHERE Id = any (array (
+		SELECT Id FROM OutgoingWebhookDeliveries WHERE CreateAt < ? AND Status IN (?, ?) LIMIT ?))`
+
+	sqlResult, err := s.GetMaster().Exec(query, before, model.OutgoingWebhookDeliveryStatusSuccess, model.OutgoingWebhookDeliveryStatusFailed, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete OutgoingWebhookDeliveries")
+	}
+
+	rowsAffected, err := sqlResult.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete OutgoingWebhookDeliveries")
+	}
+	return rowsAffected, nil
+}
+
 func (s SqlWebhookStore) AnalyticsIncomingCount(teamID string, userID string) (int64, error) {
 	queryBuilder :=
 		s.getQueryBuilder().
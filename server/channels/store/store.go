@@ -106,6 +106,11 @@ type Store interface {
 	ReadReceipt() ReadReceiptStore
 	TemporaryPost() TemporaryPostStore
 	ChannelJoinRequest() ChannelJoinRequestStore
+	PendingEmailNotification() PendingEmailNotificationStore
+	OutgoingEmail() OutgoingEmailStore
+	WebAuthnCredential() WebAuthnCredentialStore
+	MfaRecoveryCode() MfaRecoveryCodeStore
+	ClusterMessage() ClusterMessageStore
 }
 
 type RetentionPolicyStore interface {
@@ -345,6 +350,9 @@ type ChannelMemberHistoryStore interface {
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	GetChannelsLeftSince(userID string, since int64) ([]string, error)
 	GetMembershipChanges(channelID string, since int64, limit int) ([]*model.ChannelMemberHistory, error)
+	// GetChannelLeavesSince returns the channels a user left after since without joining them
+	// again, along with the last time they left them.
+	GetChannelLeavesSince(userID string, since int64) ([]*model.ChannelMemberHistory, error)
 }
 type ThreadStore interface {
 	GetThreadFollowers(threadID string, fetchOnlyActive bool) ([]string, error)
@@ -420,9 +428,10 @@ type PostStore interface {
 	PermanentDeleteBatch(endTime int64, limit int64) (int64, error)
 	GetOldest() (*model.Post, error)
 	GetMaxPostSize() int
-	GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error)
+	GetParentsForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.PostForExport, error)
 	GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error)
-	GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error)
+	GetDirectPostParentsForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.DirectPostForExport, error)
+	GetDeletedForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.DeletedPostForExport, error)
 	SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID, teamID string, page, perPage int) (*model.PostSearchResults, error)
 	GetOldestEntityCreationTime() (int64, error)
 	HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userID string) (bool, error)
@@ -550,6 +559,7 @@ type SessionStore interface {
 	GetLRUSessions(rctx request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, error)
 	GetMobileSessionMetadata() ([]*model.MobileSessionMetadata, error)
 	GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error)
+	GetSessionsWithWebPushSubscriptions(userID string) ([]*model.Session, error)
 	GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error)
 	UpdateExpiredNotify(sessionid string, notified bool) error
 	Remove(sessionIDOrToken string) error
@@ -570,6 +580,50 @@ type AuditStore interface {
 	PermanentDeleteByUser(userID string) error
 }
 
+// OutgoingEmailStore persists the outbound mail queue: the emails waiting for
+// another delivery attempt, and the dead letters that won't be retried unless
+// an administrator asks to.
+type OutgoingEmailStore interface {
+	Save(email *model.OutgoingEmail) (*model.OutgoingEmail, error)
+	Get(id string) (*model.OutgoingEmail, error)
+	Update(email *model.OutgoingEmail) (*model.OutgoingEmail, error)
+	// GetDue returns the pending emails whose next delivery attempt is due.
+	GetDue(before int64, limit int) ([]*model.OutgoingEmail, error)
+	GetFailed(offset, limit int) ([]*model.OutgoingEmail, error)
+	Delete(id string) error
+	// PermanentDeleteFailedBefore removes up to limit of the emails that failed permanently
+	// and were last updated before the given time, returning how many were removed.
+	PermanentDeleteFailedBefore(before int64, limit int) (int64, error)
+}
+
+// WebAuthnCredentialStore persists the WebAuthn authenticators users registered as
+// a second factor.
+type WebAuthnCredentialStore interface {
+	Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error)
+	Get(id string) (*model.WebAuthnCredential, error)
+	GetByCredentialId(credentialID string) (*model.WebAuthnCredential, error)
+	GetForUser(userID string) ([]*model.WebAuthnCredential, error)
+	UpdateLastUsed(id string, signCount int64, lastUsedAt int64) error
+	Delete(id string) error
+	PermanentDeleteByUser(userID string) error
+}
+
+// MfaRecoveryCodeStore persists the hashes of the single-use recovery codes users
+// can sign in with in place of their second factor.
+type MfaRecoveryCodeStore interface {
+	SaveForUser(userID string, codeHashes []string) error
+	Use(userID, codeHash string) (bool, error)
+	PermanentDeleteByUser(userID string) error
+}
+
+// ClusterMessageStore holds the cluster messages too large to be sent as a database
+// notification, until the other nodes had the chance to read them.
+type ClusterMessageStore interface {
+	Save(id string, data []byte) error
+	Get(id string) ([]byte, error)
+	DeleteBefore(createAt int64) (int64, error)
+}
+
 type ClusterDiscoveryStore interface {
 	Save(discovery *model.ClusterDiscovery) error
 	Delete(discovery *model.ClusterDiscovery) (bool, error)
@@ -652,6 +706,9 @@ type SystemStore interface {
 
 type WebhookStore interface {
 	SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error)
+	// ImportIncoming saves an incoming webhook keeping its id, so that its URL keeps working
+	// after an import. It returns an ErrConflict when a webhook with the same id exists.
+	ImportIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error)
 	GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, error)
 	GetIncomingList(offset, limit int) ([]*model.IncomingWebhook, error)
 	GetIncomingListByUser(userID string, offset, limit int) ([]*model.IncomingWebhook, error)
@@ -677,6 +734,12 @@ type WebhookStore interface {
 	PermanentDeleteOutgoingByUser(userID string) error
 	UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error)
 
+	SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)
+	UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)
+	GetOutgoingDeliveriesByHook(hookID string, offset, limit int) ([]*model.OutgoingWebhookDelivery, error)
+	GetOutgoingDeliveriesForRetry(before int64, limit int) ([]*model.OutgoingWebhookDelivery, error)
+	PermanentDeleteOutgoingDeliveriesBefore(before int64, limit int64) (int64, error)
+
 	AnalyticsIncomingCount(teamID string, userID string) (int64, error)
 	AnalyticsOutgoingCount(teamID string) (int64, error)
 	InvalidateWebhookCache(webhook string)
@@ -750,6 +813,9 @@ type EmojiStore interface {
 	GetList(offset, limit int, sort string) ([]*model.Emoji, error)
 	Delete(emoji *model.Emoji, timestamp int64) error
 	Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error)
+	// GetDeletedForExportAfter returns the emojis deleted after since whose name isn't used by
+	// another emoji, ordered by id and starting after afterID.
+	GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.Emoji, error)
 }
 
 type StatusStore interface {
@@ -783,6 +849,35 @@ type FileInfoStore interface {
 	PermanentDeleteBatch(rctx request.CTX, endTime int64, limit int64) (int64, error)
 	PermanentDeleteByUser(rctx request.CTX, userID string) (int64, error)
 	SetContent(rctx request.CTX, fileID, content string) error
+	// SetScanStatus records the verdict of the file scanner for a file.
+	SetScanStatus(rctx request.CTX, fileID, status string, scannedAt int64) error
+	// GetPendingScans returns the files created before createdBefore that are still waiting
+	// for their scan, ordered by id and starting after afterID.
+	GetPendingScans(createdBefore int64, afterID string, limit int) ([]*model.FileInfo, error)
+	// GetFailedScans returns the files whose last scan failed and that are due for another
+	// one at now, ordered by id and starting after afterID. A file waits as long since its last
+	// scan as it waited between its creation and that scan, and at most maxBackoff, so that the
+	// wait doubles with each failure.
+	GetFailedScans(now, maxBackoff int64, afterID string, limit int) ([]*model.FileInfo, error)
+	// SetContentHash records the content hash of a file and the path its content is stored at.
+	SetContentHash(rctx request.CTX, fileID, contentHash, path string) error
+	// AcquireBlob holds the blob of a content hash while it is in use, creating the blob when it
+	// doesn't exist, and returns the number of holds on the blob. Files stored in a blob reference
+	// it on their own, so holds only keep a blob from being removed until files are stored in it.
+	AcquireBlob(contentHash string, size int64) (int64, error)
+	// MoveFilesToBlob points every file stored at path to the blob of a content hash and returns
+	// the ids of the files moved.
+	MoveFilesToBlob(rctx request.CTX, path, contentHash string) ([]string, error)
+	// ReleaseBlob releases a hold on the blob of a content hash and returns the number of holds
+	// left, removing the blob as RemoveBlobIfUnreferenced does.
+	ReleaseBlob(contentHash string, removeBlob func() error) (int64, error)
+	// RemoveBlobIfUnreferenced removes the blob of a content hash when it isn't held and no file
+	// is stored in it. removeBlob is called while the blob is locked against new holds, and the
+	// blob is only deleted once removeBlob succeeds.
+	RemoveBlobIfUnreferenced(contentHash string, removeBlob func() error) (bool, error)
+	// GetUnreferencedBlobs returns the content hashes of the blobs that aren't held and have no
+	// file stored in them, ordered by hash and starting after afterHash.
+	GetUnreferencedBlobs(afterHash string, limit int) ([]string, error)
 	Search(rctx request.CTX, paramsList []*model.SearchParams, userID, teamID string, page, perPage int) (*model.FileInfoList, error)
 	CountAll() (int64, error)
 	GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error)
@@ -1061,6 +1156,7 @@ type SharedChannelStore interface {
 	GetRemotes(offset, limit int, opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error)
 	UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error
 	UpdateRemoteMembershipCursor(id string, syncTime int64) error
+	UpdateRemoteContentCursor(id string, cursor model.SharedChannelContentCursor) error
 	DeleteRemote(remoteID string) (bool, error)
 	GetRemotesStatus(channelID string) ([]*model.SharedChannelRemoteStatus, error)
 
@@ -1151,6 +1247,8 @@ type ViewStore interface {
 	Update(view *model.View) (*model.View, error)
 	Delete(viewID string, deleteAt int64) error
 	UpdateSortOrder(viewID, channelID string, newIndex int64) ([]*model.View, error)
+	GetPostsForTable(channelID string, props *model.TableProps, opts model.ViewPostsQueryOpts) ([]*model.Post, error)
+	GetPostsForCalendar(channelID string, props *model.CalendarProps, opts model.ViewPostsQueryOpts) ([]*model.Post, error)
 }
 
 type ScheduledPostStore interface {
@@ -1199,6 +1297,7 @@ type PropertyValueStore interface {
 	Delete(groupID string, id string) error
 	DeleteForField(groupID, fieldID string) error
 	DeleteForTarget(groupID string, targetType string, targetID string) error
+	GetForChannelPostsSince(groupID, channelID string, since int64, afterID string, limit int) ([]*model.PropertyValue, error)
 }
 
 type AccessControlPolicyStore interface {
@@ -1397,6 +1496,22 @@ type ChannelJoinRequestStore interface {
 	CountPending(channelId string) (int64, error)
 }
 
+// PendingEmailNotificationStore persists the notifications waiting to be sent
+// as batched emails, so that they survive restarts and can be sent by any node.
+type PendingEmailNotificationStore interface {
+	Save(notification *model.PendingEmailNotification) (*model.PendingEmailNotification, error)
+	// GetBatchStartTimes returns the time the oldest pending notification of
+	// each user was queued, keyed by user id.
+	GetBatchStartTimes() (map[string]int64, error)
+	GetForUser(userID string) ([]*model.PendingEmailNotification, error)
+	// Delete removes the given notifications, returning how many were removed
+	// so that callers racing to send the same batch can tell which one won.
+	Delete(ids []string) (int64, error)
+	// IncrementAttempts records a failed attempt at sending the given notifications.
+	IncrementAttempts(ids []string) error
+	PermanentDeleteByUser(userID string) error
+}
+
 type RecapStore interface {
 	SaveRecap(recap *model.Recap) (*model.Recap, error)
 	UpdateRecap(recap *model.Recap) (*model.Recap, error)
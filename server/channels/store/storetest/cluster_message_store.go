@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestClusterMessageStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testClusterMessageSaveAndGet(t, rctx, ss) })
+	t.Run("DeleteBefore", func(t *testing.T) { testClusterMessageDeleteBefore(t, rctx, ss) })
+}
+
+func testClusterMessageSaveAndGet(t *testing.T, _ request.CTX, ss store.Store) {
+	id := model.NewId()
+	data := []byte{0, 1, 2, 255}
+	require.NoError(t, ss.ClusterMessage().Save(id, data))
+
+	got, err := ss.ClusterMessage().Get(id)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	err = ss.ClusterMessage().Save(id, data)
+	require.Error(t, err, "ids must be unique")
+
+	_, err = ss.ClusterMessage().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testClusterMessageDeleteBefore(t *testing.T, _ request.CTX, ss store.Store) {
+	before := model.GetMillis()
+	_, err := ss.ClusterMessage().DeleteBefore(model.GetMillis() + 1)
+	require.NoError(t, err)
+
+	id1 := model.NewId()
+	id2 := model.NewId()
+	require.NoError(t, ss.ClusterMessage().Save(id1, []byte("first")))
+	require.NoError(t, ss.ClusterMessage().Save(id2, []byte("second")))
+
+	deleted, err := ss.ClusterMessage().DeleteBefore(before)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	deleted, err = ss.ClusterMessage().DeleteBefore(model.GetMillis() + 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	_, err = ss.ClusterMessage().Get(id1)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
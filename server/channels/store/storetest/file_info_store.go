@@ -4,7 +4,11 @@
 package storetest
 
 import (
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -32,6 +36,13 @@ func TestFileInfoStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStor
 	t.Run("FileInfoPermanentDeleteBatch", func(t *testing.T) { testFileInfoPermanentDeleteBatch(t, rctx, ss) })
 	t.Run("FileInfoPermanentDeleteByUser", func(t *testing.T) { testFileInfoPermanentDeleteByUser(t, rctx, ss) })
 	t.Run("FileInfoUpdateMinipreview", func(t *testing.T) { testFileInfoUpdateMinipreview(t, rctx, ss) })
+	t.Run("FileInfoSetScanStatus", func(t *testing.T) { testFileInfoSetScanStatus(t, rctx, ss) })
+	t.Run("FileInfoGetPendingScans", func(t *testing.T) { testFileInfoGetPendingScans(t, rctx, ss) })
+	t.Run("FileInfoGetFailedScans", func(t *testing.T) { testFileInfoGetFailedScans(t, rctx, ss) })
+	t.Run("FileInfoSetContentHash", func(t *testing.T) { testFileInfoSetContentHash(t, rctx, ss) })
+	t.Run("FileInfoAcquireReleaseBlob", func(t *testing.T) { testFileInfoAcquireReleaseBlob(t, rctx, ss) })
+	t.Run("FileInfoMoveFilesToBlob", func(t *testing.T) { testFileInfoMoveFilesToBlob(t, rctx, ss) })
+	t.Run("FileInfoRemoveUnreferencedBlobs", func(t *testing.T) { testFileInfoRemoveUnreferencedBlobs(t, rctx, ss) })
 	t.Run("GetFilesBatchForIndexing", func(t *testing.T) { testFileInfoStoreGetFilesBatchForIndexing(t, rctx, ss) })
 	t.Run("CountAll", func(t *testing.T) { testFileInfoStoreCountAll(t, rctx, ss) })
 	t.Run("GetStorageUsage", func(t *testing.T) { testFileInfoGetStorageUsage(t, rctx, ss) })
@@ -683,6 +694,345 @@ func testFileInfoUpdateMinipreview(t *testing.T, rctx request.CTX, ss store.Stor
 	require.Equal(t, *tinfo.MiniPreview, miniPreview)
 }
 
+func testFileInfoSetScanStatus(t *testing.T, rctx request.CTX, ss store.Store) {
+	info, err := ss.FileInfo().Save(rctx, &model.FileInfo{
+		CreatorId:  model.NewId(),
+		Path:       "file.txt",
+		ScanStatus: model.FileScanStatusPending,
+	})
+	require.NoError(t, err)
+	defer func() {
+		ss.FileInfo().PermanentDelete(rctx, info.Id)
+	}()
+
+	rinfo, err := ss.FileInfo().Get(info.Id)
+	require.NoError(t, err)
+	require.Equal(t, model.FileScanStatusPending, rinfo.ScanStatus)
+	require.Zero(t, rinfo.ScannedAt)
+
+	scannedAt := model.GetMillis()
+	err = ss.FileInfo().SetScanStatus(rctx, info.Id, model.FileScanStatusInfected, scannedAt)
+	require.NoError(t, err)
+
+	rinfos, err := ss.FileInfo().GetByIds([]string{info.Id}, false, false, true)
+	require.NoError(t, err)
+	require.Len(t, rinfos, 1)
+	require.Equal(t, model.FileScanStatusInfected, rinfos[0].ScanStatus)
+	require.Equal(t, scannedAt, rinfos[0].ScannedAt)
+
+	err = ss.FileInfo().SetScanStatus(rctx, model.NewId(), model.FileScanStatusClean, scannedAt)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testFileInfoGetPendingScans(t *testing.T, rctx request.CTX, ss store.Store) {
+	save := func(createAt int64, status string, deleteAt int64) *model.FileInfo {
+		info, err := ss.FileInfo().Save(rctx, &model.FileInfo{
+			CreatorId:  model.NewId(),
+			Path:       "file.txt",
+			CreateAt:   createAt,
+			DeleteAt:   deleteAt,
+			ScanStatus: status,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			ss.FileInfo().PermanentDelete(rctx, info.Id)
+		})
+		return info
+	}
+
+	pending1 := save(1000, model.FileScanStatusPending, 0)
+	pending2 := save(1100, model.FileScanStatusPending, 0)
+	save(1200, model.FileScanStatusClean, 0)
+	save(1300, model.FileScanStatusPending, 1400)
+	recent := save(5000, model.FileScanStatusPending, 0)
+
+	pendingIDs := []string{pending1.Id, pending2.Id}
+	slices.Sort(pendingIDs)
+
+	getIDs := func(createdBefore int64, afterID string, limit int) []string {
+		infos, err := ss.FileInfo().GetPendingScans(createdBefore, afterID, limit)
+		require.NoError(t, err)
+		var ids []string
+		for _, info := range infos {
+			require.Equal(t, model.FileScanStatusPending, info.ScanStatus)
+			ids = append(ids, info.Id)
+		}
+		return ids
+	}
+
+	t.Run("returns the pending files created before the given time", func(t *testing.T) {
+		require.Equal(t, pendingIDs, getIDs(2000, "", 10))
+	})
+
+	t.Run("pages by id", func(t *testing.T) {
+		require.Equal(t, pendingIDs[:1], getIDs(2000, "", 1))
+		require.Equal(t, pendingIDs[1:], getIDs(2000, pendingIDs[0], 1))
+		require.Empty(t, getIDs(2000, pendingIDs[1], 1))
+	})
+
+	t.Run("includes recent files when asked", func(t *testing.T) {
+		require.Contains(t, getIDs(6000, "", 10), recent.Id)
+	})
+}
+
+func testFileInfoGetFailedScans(t *testing.T, rctx request.CTX, ss store.Store) {
+	save := func(createAt, scannedAt int64, status string, deleteAt int64) *model.FileInfo {
+		info, err := ss.FileInfo().Save(rctx, &model.FileInfo{
+			CreatorId:  model.NewId(),
+			Path:       "file.txt",
+			CreateAt:   createAt,
+			DeleteAt:   deleteAt,
+			ScanStatus: status,
+			ScannedAt:  scannedAt,
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			ss.FileInfo().PermanentDelete(rctx, info.Id)
+		})
+		return info
+	}
+
+	// At 10000, files are due when they waited since their last scan at least as long as
+	// between their creation and that scan.
+	failed1 := save(1000, 5000, model.FileScanStatusFailed, 0)
+	failed2 := save(8000, 9000, model.FileScanStatusFailed, 0)
+	recentFailure := save(1000, 9500, model.FileScanStatusFailed, 0)
+	save(5000, 9000, model.FileScanStatusFailed, 0)
+	save(1000, 5000, model.FileScanStatusClean, 0)
+	save(1000, 5000, model.FileScanStatusFailed, 6000)
+
+	failedIDs := []string{failed1.Id, failed2.Id}
+	slices.Sort(failedIDs)
+
+	getIDs := func(now, maxBackoff int64, afterID string, limit int) []string {
+		infos, err := ss.FileInfo().GetFailedScans(now, maxBackoff, afterID, limit)
+		require.NoError(t, err)
+		var ids []string
+		for _, info := range infos {
+			require.Equal(t, model.FileScanStatusFailed, info.ScanStatus)
+			ids = append(ids, info.Id)
+		}
+		return ids
+	}
+
+	t.Run("returns the failed files due for a scan", func(t *testing.T) {
+		require.Equal(t, failedIDs, getIDs(10000, 100000, "", 10))
+	})
+
+	t.Run("pages by id", func(t *testing.T) {
+		require.Equal(t, failedIDs[:1], getIDs(10000, 100000, "", 1))
+		require.Equal(t, failedIDs[1:], getIDs(10000, 100000, failedIDs[0], 1))
+		require.Empty(t, getIDs(10000, 100000, failedIDs[1], 1))
+	})
+
+	t.Run("caps the wait", func(t *testing.T) {
+		require.Contains(t, getIDs(10000, 500, "", 10), recentFailure.Id)
+	})
+}
+
+func testFileInfoSetContentHash(t *testing.T, rctx request.CTX, ss store.Store) {
+	hash := strings.Repeat("ab", 32)
+	info, err := ss.FileInfo().Save(rctx, &model.FileInfo{
+		CreatorId:   model.NewId(),
+		Path:        "file.txt",
+		ContentHash: hash,
+	})
+	require.NoError(t, err)
+	defer func() {
+		ss.FileInfo().PermanentDelete(rctx, info.Id)
+	}()
+
+	rinfo, err := ss.FileInfo().Get(info.Id)
+	require.NoError(t, err)
+	require.Equal(t, hash, rinfo.ContentHash)
+	require.False(t, rinfo.IsDeduplicated())
+
+	err = ss.FileInfo().SetContentHash(rctx, info.Id, hash, model.FileBlobPath(hash))
+	require.NoError(t, err)
+
+	rinfos, err := ss.FileInfo().GetByIds([]string{info.Id}, false, false, true)
+	require.NoError(t, err)
+	require.Len(t, rinfos, 1)
+	require.Equal(t, model.FileBlobPath(hash), rinfos[0].Path)
+	require.True(t, rinfos[0].IsDeduplicated())
+
+	err = ss.FileInfo().SetContentHash(rctx, model.NewId(), hash, model.FileBlobPath(hash))
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testFileInfoAcquireReleaseBlob(t *testing.T, rctx request.CTX, ss store.Store) {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(model.NewId())))
+	removed := 0
+	removeBlob := func() error {
+		removed++
+		return nil
+	}
+
+	refCount, err := ss.FileInfo().AcquireBlob(hash, 1024)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, refCount)
+
+	refCount, err = ss.FileInfo().AcquireBlob(hash, 1024)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, refCount)
+
+	refCount, err = ss.FileInfo().ReleaseBlob(hash, removeBlob)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, refCount)
+	require.Zero(t, removed)
+
+	// The blob is kept when it can't be removed.
+	refCount, err = ss.FileInfo().ReleaseBlob(hash, func() error { return errors.New("remove failed") })
+	require.Error(t, err)
+	require.Zero(t, refCount)
+
+	refCount, err = ss.FileInfo().ReleaseBlob(hash, removeBlob)
+	require.NoError(t, err)
+	require.Zero(t, refCount)
+	require.Equal(t, 1, removed)
+
+	// The blob is deleted once its last reference is released.
+	_, err = ss.FileInfo().ReleaseBlob(hash, removeBlob)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	t.Run("acquiring waits for the removal of the blob", func(t *testing.T) {
+		refCount, err := ss.FileInfo().AcquireBlob(hash, 1024)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, refCount)
+
+		acquired := make(chan int64, 1)
+		_, err = ss.FileInfo().ReleaseBlob(hash, func() error {
+			go func() {
+				refCount, err := ss.FileInfo().AcquireBlob(hash, 1024)
+				assert.NoError(t, err)
+				acquired <- refCount
+			}()
+
+			select {
+			case <-acquired:
+				return errors.New("blob acquired while being removed")
+			case <-time.After(200 * time.Millisecond):
+				return nil
+			}
+		})
+		require.NoError(t, err)
+
+		select {
+		case refCount = <-acquired:
+			require.EqualValues(t, 1, refCount)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "blob not acquired after its removal")
+		}
+
+		_, err = ss.FileInfo().ReleaseBlob(hash, removeBlob)
+		require.NoError(t, err)
+	})
+}
+
+func testFileInfoMoveFilesToBlob(t *testing.T, rctx request.CTX, ss store.Store) {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(model.NewId())))
+	path := "moved/" + model.NewId() + "/file.txt"
+
+	var fileIDs []string
+	for range 2 {
+		info, err := ss.FileInfo().Save(rctx, &model.FileInfo{
+			CreatorId: model.NewId(),
+			Path:      path,
+		})
+		require.NoError(t, err)
+		fileIDs = append(fileIDs, info.Id)
+	}
+	defer func() {
+		for _, fileID := range fileIDs {
+			ss.FileInfo().PermanentDelete(rctx, fileID)
+		}
+	}()
+
+	movedIDs, err := ss.FileInfo().MoveFilesToBlob(rctx, path, hash)
+	require.NoError(t, err)
+	require.ElementsMatch(t, fileIDs, movedIDs)
+
+	rinfos, err := ss.FileInfo().GetByIds(fileIDs, false, false, true)
+	require.NoError(t, err)
+	require.Len(t, rinfos, 2)
+	for _, rinfo := range rinfos {
+		require.Equal(t, hash, rinfo.ContentHash)
+		require.True(t, rinfo.IsDeduplicated())
+	}
+
+	_, err = ss.FileInfo().MoveFilesToBlob(rctx, path, hash)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testFileInfoRemoveUnreferencedBlobs(t *testing.T, rctx request.CTX, ss store.Store) {
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(model.NewId())))
+	removed := 0
+	removeBlob := func() error {
+		removed++
+		return nil
+	}
+	isUnreferenced := func() bool {
+		hashes, err := ss.FileInfo().GetUnreferencedBlobs("", 1000)
+		require.NoError(t, err)
+		return slices.Contains(hashes, hash)
+	}
+
+	// Removing a missing blob does nothing.
+	ok, err := ss.FileInfo().RemoveBlobIfUnreferenced(hash, removeBlob)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = ss.FileInfo().AcquireBlob(hash, 1024)
+	require.NoError(t, err)
+	info, err := ss.FileInfo().Save(rctx, &model.FileInfo{
+		CreatorId: model.NewId(),
+		Path:      "unreferenced/" + model.NewId() + "/file.txt",
+	})
+	require.NoError(t, err)
+	defer ss.FileInfo().PermanentDelete(rctx, info.Id)
+	_, err = ss.FileInfo().MoveFilesToBlob(rctx, info.Path, hash)
+	require.NoError(t, err)
+
+	// A held blob isn't removed.
+	require.False(t, isUnreferenced())
+	ok, err = ss.FileInfo().RemoveBlobIfUnreferenced(hash, removeBlob)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// The files stored in a blob keep it once it is released.
+	refCount, err := ss.FileInfo().ReleaseBlob(hash, removeBlob)
+	require.NoError(t, err)
+	require.Zero(t, refCount)
+	require.Zero(t, removed)
+	require.False(t, isUnreferenced())
+	ok, err = ss.FileInfo().RemoveBlobIfUnreferenced(hash, removeBlob)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Deleting the last file of the blob leaves it unreferenced.
+	require.NoError(t, ss.FileInfo().PermanentDelete(rctx, info.Id))
+	require.True(t, isUnreferenced())
+
+	hashes, err := ss.FileInfo().GetUnreferencedBlobs(hash, 1000)
+	require.NoError(t, err)
+	require.NotContains(t, hashes, hash)
+
+	ok, err = ss.FileInfo().RemoveBlobIfUnreferenced(hash, func() error { return errors.New("remove failed") })
+	require.Error(t, err)
+	require.False(t, ok)
+	require.True(t, isUnreferenced())
+
+	ok, err = ss.FileInfo().RemoveBlobIfUnreferenced(hash, removeBlob)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, removed)
+	require.False(t, isUnreferenced())
+}
+
 func testFileInfoStoreGetFilesBatchForIndexing(t *testing.T, rctx request.CTX, ss store.Store) {
 	c1 := &model.Channel{}
 	c1.TeamId = model.NewId()
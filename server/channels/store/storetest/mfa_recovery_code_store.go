@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestMfaRecoveryCodeStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveForUserAndUse", func(t *testing.T) { testMfaRecoveryCodeSaveForUserAndUse(t, rctx, ss) })
+	t.Run("SaveForUserReplaces", func(t *testing.T) { testMfaRecoveryCodeSaveForUserReplaces(t, rctx, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testMfaRecoveryCodePermanentDeleteByUser(t, rctx, ss) })
+}
+
+func testMfaRecoveryCodeSaveForUserAndUse(t *testing.T, _ request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, []string{"hash1", "hash2"}))
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(otherUserID, []string{"hash3"}))
+
+	used, err := ss.MfaRecoveryCode().Use(userID, "hash1")
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	used, err = ss.MfaRecoveryCode().Use(userID, "hash1")
+	require.NoError(t, err)
+	assert.False(t, used, "codes can only be used once")
+
+	used, err = ss.MfaRecoveryCode().Use(userID, "hash3")
+	require.NoError(t, err)
+	assert.False(t, used, "codes of other users can't be used")
+
+	used, err = ss.MfaRecoveryCode().Use(userID, "hash2")
+	require.NoError(t, err)
+	assert.True(t, used)
+}
+
+func testMfaRecoveryCodeSaveForUserReplaces(t *testing.T, _ request.CTX, ss store.Store) {
+	userID := model.NewId()
+
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, []string{"hash1", "hash2"}))
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, []string{"hash3"}))
+
+	used, err := ss.MfaRecoveryCode().Use(userID, "hash1")
+	require.NoError(t, err)
+	assert.False(t, used)
+
+	used, err = ss.MfaRecoveryCode().Use(userID, "hash3")
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, nil))
+}
+
+func testMfaRecoveryCodePermanentDeleteByUser(t *testing.T, _ request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, []string{"hash1"}))
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(otherUserID, []string{"hash1"}))
+
+	require.NoError(t, ss.MfaRecoveryCode().PermanentDeleteByUser(userID))
+
+	used, err := ss.MfaRecoveryCode().Use(userID, "hash1")
+	require.NoError(t, err)
+	assert.False(t, used)
+
+	used, err = ss.MfaRecoveryCode().Use(otherUserID, "hash1")
+	require.NoError(t, err)
+	assert.True(t, used)
+}
@@ -42,6 +42,36 @@ func (_m *ChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int64, error
 	return r0, r1
 }
 
+// GetChannelLeavesSince provides a mock function with given fields: userID, since
+func (_m *ChannelMemberHistoryStore) GetChannelLeavesSince(userID string, since int64) ([]*model.ChannelMemberHistory, error) {
+	ret := _m.Called(userID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChannelLeavesSince")
+	}
+
+	var r0 []*model.ChannelMemberHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) ([]*model.ChannelMemberHistory, error)); ok {
+		return rf(userID, since)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) []*model.ChannelMemberHistory); ok {
+		r0 = rf(userID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ChannelMemberHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChannelsLeftSince provides a mock function with given fields: userID, since
 func (_m *ChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
 	ret := _m.Called(userID, since)
@@ -93,6 +93,36 @@ func (_m *EmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bo
 	return r0, r1
 }
 
+// GetDeletedForExportAfter provides a mock function with given fields: limit, afterID, since
+func (_m *EmojiStore) GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.Emoji, error) {
+	ret := _m.Called(limit, afterID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedForExportAfter")
+	}
+
+	var r0 []*model.Emoji
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, int64) ([]*model.Emoji, error)); ok {
+		return rf(limit, afterID, since)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, int64) []*model.Emoji); ok {
+		r0 = rf(limit, afterID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Emoji)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, int64) error); ok {
+		r1 = rf(limit, afterID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetList provides a mock function with given fields: offset, limit, sort
 func (_m *EmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
 	ret := _m.Called(offset, limit, sort)
@@ -15,6 +15,34 @@ type FileInfoStore struct {
 	mock.Mock
 }
 
+// AcquireBlob provides a mock function with given fields: contentHash, size
+func (_m *FileInfoStore) AcquireBlob(contentHash string, size int64) (int64, error) {
+	ret := _m.Called(contentHash, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireBlob")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64) (int64, error)); ok {
+		return rf(contentHash, size)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64) int64); ok {
+		r0 = rf(contentHash, size)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(contentHash, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AttachToPost provides a mock function with given fields: rctx, fileID, postID, channelID, creatorID
 func (_m *FileInfoStore) AttachToPost(rctx request.CTX, fileID string, postID string, channelID string, creatorID string) error {
 	ret := _m.Called(rctx, fileID, postID, channelID, creatorID)
@@ -202,6 +230,36 @@ func (_m *FileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
 	return r0, r1
 }
 
+// GetFailedScans provides a mock function with given fields: now, maxBackoff, afterID, limit
+func (_m *FileInfoStore) GetFailedScans(now int64, maxBackoff int64, afterID string, limit int) ([]*model.FileInfo, error) {
+	ret := _m.Called(now, maxBackoff, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFailedScans")
+	}
+
+	var r0 []*model.FileInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, string, int) ([]*model.FileInfo, error)); ok {
+		return rf(now, maxBackoff, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, string, int) []*model.FileInfo); ok {
+		r0 = rf(now, maxBackoff, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, string, int) error); ok {
+		r1 = rf(now, maxBackoff, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFilesBatchForIndexing provides a mock function with given fields: startTime, startFileID, includeDeleted, limit
 func (_m *FileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
 	ret := _m.Called(startTime, startFileID, includeDeleted, limit)
@@ -322,6 +380,36 @@ func (_m *FileInfoStore) GetFromMaster(id string) (*model.FileInfo, error) {
 	return r0, r1
 }
 
+// GetPendingScans provides a mock function with given fields: createdBefore, afterID, limit
+func (_m *FileInfoStore) GetPendingScans(createdBefore int64, afterID string, limit int) ([]*model.FileInfo, error) {
+	ret := _m.Called(createdBefore, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingScans")
+	}
+
+	var r0 []*model.FileInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string, int) ([]*model.FileInfo, error)); ok {
+		return rf(createdBefore, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string, int) []*model.FileInfo); ok {
+		r0 = rf(createdBefore, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FileInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string, int) error); ok {
+		r1 = rf(createdBefore, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageUsage provides a mock function with given fields: allowFromCache, includeDeleted
 func (_m *FileInfoStore) GetStorageUsage(allowFromCache bool, includeDeleted bool) (int64, error) {
 	ret := _m.Called(allowFromCache, includeDeleted)
@@ -350,6 +438,36 @@ func (_m *FileInfoStore) GetStorageUsage(allowFromCache bool, includeDeleted boo
 	return r0, r1
 }
 
+// GetUnreferencedBlobs provides a mock function with given fields: afterHash, limit
+func (_m *FileInfoStore) GetUnreferencedBlobs(afterHash string, limit int) ([]string, error) {
+	ret := _m.Called(afterHash, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnreferencedBlobs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int) ([]string, error)); ok {
+		return rf(afterHash, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int) []string); ok {
+		r0 = rf(afterHash, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(afterHash, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUptoNSizeFileTime provides a mock function with given fields: n
 func (_m *FileInfoStore) GetUptoNSizeFileTime(n int64) (int64, error) {
 	ret := _m.Called(n)
@@ -413,6 +531,36 @@ func (_m *FileInfoStore) InvalidateFileInfosForPostCache(postID string, deleted
 	_m.Called(postID, deleted)
 }
 
+// MoveFilesToBlob provides a mock function with given fields: rctx, path, contentHash
+func (_m *FileInfoStore) MoveFilesToBlob(rctx request.CTX, path string, contentHash string) ([]string, error) {
+	ret := _m.Called(rctx, path, contentHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveFilesToBlob")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string) ([]string, error)); ok {
+		return rf(rctx, path, contentHash)
+	}
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string) []string); ok {
+		r0 = rf(rctx, path, contentHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(request.CTX, string, string) error); ok {
+		r1 = rf(rctx, path, contentHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermanentDelete provides a mock function with given fields: rctx, fileID
 func (_m *FileInfoStore) PermanentDelete(rctx request.CTX, fileID string) error {
 	ret := _m.Called(rctx, fileID)
@@ -523,6 +671,62 @@ func (_m *FileInfoStore) RefreshFileStats() error {
 	return r0
 }
 
+// ReleaseBlob provides a mock function with given fields: contentHash, removeBlob
+func (_m *FileInfoStore) ReleaseBlob(contentHash string, removeBlob func() error) (int64, error) {
+	ret := _m.Called(contentHash, removeBlob)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseBlob")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, func() error) (int64, error)); ok {
+		return rf(contentHash, removeBlob)
+	}
+	if rf, ok := ret.Get(0).(func(string, func() error) int64); ok {
+		r0 = rf(contentHash, removeBlob)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, func() error) error); ok {
+		r1 = rf(contentHash, removeBlob)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveBlobIfUnreferenced provides a mock function with given fields: contentHash, removeBlob
+func (_m *FileInfoStore) RemoveBlobIfUnreferenced(contentHash string, removeBlob func() error) (bool, error) {
+	ret := _m.Called(contentHash, removeBlob)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBlobIfUnreferenced")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, func() error) (bool, error)); ok {
+		return rf(contentHash, removeBlob)
+	}
+	if rf, ok := ret.Get(0).(func(string, func() error) bool); ok {
+		r0 = rf(contentHash, removeBlob)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, func() error) error); ok {
+		r1 = rf(contentHash, removeBlob)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RestoreForPostByIds provides a mock function with given fields: rctx, postId, fileIDs
 func (_m *FileInfoStore) RestoreForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
 	ret := _m.Called(rctx, postId, fileIDs)
@@ -619,6 +823,42 @@ func (_m *FileInfoStore) SetContent(rctx request.CTX, fileID string, content str
 	return r0
 }
 
+// SetContentHash provides a mock function with given fields: rctx, fileID, contentHash, path
+func (_m *FileInfoStore) SetContentHash(rctx request.CTX, fileID string, contentHash string, path string) error {
+	ret := _m.Called(rctx, fileID, contentHash, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetContentHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, string) error); ok {
+		r0 = rf(rctx, fileID, contentHash, path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetScanStatus provides a mock function with given fields: rctx, fileID, status, scannedAt
+func (_m *FileInfoStore) SetScanStatus(rctx request.CTX, fileID, status string, scannedAt int64) error {
+	ret := _m.Called(rctx, fileID, status, scannedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetScanStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(request.CTX, string, string, int64) error); ok {
+		r0 = rf(rctx, fileID, status, scannedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Upsert provides a mock function with given fields: rctx, info
 func (_m *FileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
 	ret := _m.Called(rctx, info)
@@ -203,9 +203,39 @@ func (_m *PostStore) Get(rctx request.CTX, id string, opts model.GetPostsOptions
 	return r0, r1
 }
 
-// GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels
-func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
-	ret := _m.Called(limit, afterID, includeArchivedChannels)
+// GetDeletedForExportAfter provides a mock function with given fields: limit, afterID, opts
+func (_m *PostStore) GetDeletedForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.DeletedPostForExport, error) {
+	ret := _m.Called(limit, afterID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedForExportAfter")
+	}
+
+	var r0 []*model.DeletedPostForExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string, model.PostExportOpts) ([]*model.DeletedPostForExport, error)); ok {
+		return rf(limit, afterID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(int, string, model.PostExportOpts) []*model.DeletedPostForExport); ok {
+		r0 = rf(limit, afterID, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.DeletedPostForExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string, model.PostExportOpts) error); ok {
+		r1 = rf(limit, afterID, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDirectPostParentsForExportAfter provides a mock function with given fields: limit, afterID, opts
+func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.DirectPostForExport, error) {
+	ret := _m.Called(limit, afterID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDirectPostParentsForExportAfter")
@@ -213,19 +243,19 @@ func (_m *PostStore) GetDirectPostParentsForExportAfter(limit int, afterID strin
 
 	var r0 []*model.DirectPostForExport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, string, bool) ([]*model.DirectPostForExport, error)); ok {
-		return rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, model.PostExportOpts) ([]*model.DirectPostForExport, error)); ok {
+		return rf(limit, afterID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(int, string, bool) []*model.DirectPostForExport); ok {
-		r0 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, model.PostExportOpts) []*model.DirectPostForExport); ok {
+		r0 = rf(limit, afterID, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.DirectPostForExport)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, string, bool) error); ok {
-		r1 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(1).(func(int, string, model.PostExportOpts) error); ok {
+		r1 = rf(limit, afterID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -475,9 +505,9 @@ func (_m *PostStore) GetOldestEntityCreationTime() (int64, error) {
 	return r0, r1
 }
 
-// GetParentsForExportAfter provides a mock function with given fields: limit, afterID, includeArchivedChannels
-func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
-	ret := _m.Called(limit, afterID, includeArchivedChannels)
+// GetParentsForExportAfter provides a mock function with given fields: limit, afterID, opts
+func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.PostForExport, error) {
+	ret := _m.Called(limit, afterID, opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetParentsForExportAfter")
@@ -485,19 +515,19 @@ func (_m *PostStore) GetParentsForExportAfter(limit int, afterID string, include
 
 	var r0 []*model.PostForExport
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, string, bool) ([]*model.PostForExport, error)); ok {
-		return rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, model.PostExportOpts) ([]*model.PostForExport, error)); ok {
+		return rf(limit, afterID, opts)
 	}
-	if rf, ok := ret.Get(0).(func(int, string, bool) []*model.PostForExport); ok {
-		r0 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(0).(func(int, string, model.PostExportOpts) []*model.PostForExport); ok {
+		r0 = rf(limit, afterID, opts)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.PostForExport)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, string, bool) error); ok {
-		r1 = rf(limit, afterID, includeArchivedChannels)
+	if rf, ok := ret.Get(1).(func(int, string, model.PostExportOpts) error); ok {
+		r1 = rf(limit, afterID, opts)
 	} else {
 		r1 = ret.Error(1)
 	}
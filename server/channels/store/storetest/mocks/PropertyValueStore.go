@@ -158,6 +158,36 @@ func (_m *PropertyValueStore) Get(groupID string, id string) (*model.PropertyVal
 	return r0, r1
 }
 
+// GetForChannelPostsSince provides a mock function with given fields: groupID, channelID, since, afterID, limit
+func (_m *PropertyValueStore) GetForChannelPostsSince(groupID, channelID string, since int64, afterID string, limit int) ([]*model.PropertyValue, error) {
+	ret := _m.Called(groupID, channelID, since, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForChannelPostsSince")
+	}
+
+	var r0 []*model.PropertyValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int64, string, int) ([]*model.PropertyValue, error)); ok {
+		return rf(groupID, channelID, since, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int64, string, int) []*model.PropertyValue); ok {
+		r0 = rf(groupID, channelID, since, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PropertyValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int64, string, int) error); ok {
+		r1 = rf(groupID, channelID, since, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMany provides a mock function with given fields: groupID, ids
 func (_m *PropertyValueStore) GetMany(groupID string, ids []string) ([]*model.PropertyValue, error) {
 	ret := _m.Called(groupID, ids)
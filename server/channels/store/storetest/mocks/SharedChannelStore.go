@@ -682,6 +682,24 @@ func (_m *SharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (*
 	return r0, r1
 }
 
+// UpdateRemoteContentCursor provides a mock function with given fields: id, cursor
+func (_m *SharedChannelStore) UpdateRemoteContentCursor(id string, cursor model.SharedChannelContentCursor) error {
+	ret := _m.Called(id, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRemoteContentCursor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, model.SharedChannelContentCursor) error); ok {
+		r0 = rf(id, cursor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRemoteCursor provides a mock function with given fields: id, cursor
 func (_m *SharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
 	ret := _m.Called(id, cursor)
@@ -265,6 +265,26 @@ func (_m *Store) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return r0
 }
 
+// ClusterMessage provides a mock function with no fields
+func (_m *Store) ClusterMessage() store.ClusterMessageStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClusterMessage")
+	}
+
+	var r0 store.ClusterMessageStore
+	if rf, ok := ret.Get(0).(func() store.ClusterMessageStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ClusterMessageStore)
+		}
+	}
+
+	return r0
+}
+
 // Command provides a mock function with no fields
 func (_m *Store) Command() store.CommandStore {
 	ret := _m.Called()
@@ -754,6 +774,26 @@ func (_m *Store) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// MfaRecoveryCode provides a mock function with no fields
+func (_m *Store) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MfaRecoveryCode")
+	}
+
+	var r0 store.MfaRecoveryCodeStore
+	if rf, ok := ret.Get(0).(func() store.MfaRecoveryCodeStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MfaRecoveryCodeStore)
+		}
+	}
+
+	return r0
+}
+
 // NotifyAdmin provides a mock function with no fields
 func (_m *Store) NotifyAdmin() store.NotifyAdminStore {
 	ret := _m.Called()
@@ -794,6 +834,26 @@ func (_m *Store) OAuth() store.OAuthStore {
 	return r0
 }
 
+// OutgoingEmail provides a mock function with no fields
+func (_m *Store) OutgoingEmail() store.OutgoingEmailStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutgoingEmail")
+	}
+
+	var r0 store.OutgoingEmailStore
+	if rf, ok := ret.Get(0).(func() store.OutgoingEmailStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.OutgoingEmailStore)
+		}
+	}
+
+	return r0
+}
+
 // OutgoingOAuthConnection provides a mock function with no fields
 func (_m *Store) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	ret := _m.Called()
@@ -814,6 +874,26 @@ func (_m *Store) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return r0
 }
 
+// PendingEmailNotification provides a mock function with no fields
+func (_m *Store) PendingEmailNotification() store.PendingEmailNotificationStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PendingEmailNotification")
+	}
+
+	var r0 store.PendingEmailNotificationStore
+	if rf, ok := ret.Get(0).(func() store.PendingEmailNotificationStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PendingEmailNotificationStore)
+		}
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with no fields
 func (_m *Store) Plugin() store.PluginStore {
 	ret := _m.Called()
@@ -1574,6 +1654,26 @@ func (_m *Store) View() store.ViewStore {
 	return r0
 }
 
+// WebAuthnCredential provides a mock function with no fields
+func (_m *Store) WebAuthnCredential() store.WebAuthnCredentialStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebAuthnCredential")
+	}
+
+	var r0 store.WebAuthnCredentialStore
+	if rf, ok := ret.Get(0).(func() store.WebAuthnCredentialStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.WebAuthnCredentialStore)
+		}
+	}
+
+	return r0
+}
+
 // Webhook provides a mock function with no fields
 func (_m *Store) Webhook() store.WebhookStore {
 	ret := _m.Called()
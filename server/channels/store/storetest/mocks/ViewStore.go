@@ -120,6 +120,66 @@ func (_m *ViewStore) GetForChannel(channelID string, opts model.ViewQueryOpts) (
 	return r0, r1
 }
 
+// GetPostsForCalendar provides a mock function with given fields: channelID, props, opts
+func (_m *ViewStore) GetPostsForCalendar(channelID string, props *model.CalendarProps, opts model.ViewPostsQueryOpts) ([]*model.Post, error) {
+	ret := _m.Called(channelID, props, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostsForCalendar")
+	}
+
+	var r0 []*model.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *model.CalendarProps, model.ViewPostsQueryOpts) ([]*model.Post, error)); ok {
+		return rf(channelID, props, opts)
+	}
+	if rf, ok := ret.Get(0).(func(string, *model.CalendarProps, model.ViewPostsQueryOpts) []*model.Post); ok {
+		r0 = rf(channelID, props, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *model.CalendarProps, model.ViewPostsQueryOpts) error); ok {
+		r1 = rf(channelID, props, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostsForTable provides a mock function with given fields: channelID, props, opts
+func (_m *ViewStore) GetPostsForTable(channelID string, props *model.TableProps, opts model.ViewPostsQueryOpts) ([]*model.Post, error) {
+	ret := _m.Called(channelID, props, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostsForTable")
+	}
+
+	var r0 []*model.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *model.TableProps, model.ViewPostsQueryOpts) ([]*model.Post, error)); ok {
+		return rf(channelID, props, opts)
+	}
+	if rf, ok := ret.Get(0).(func(string, *model.TableProps, model.ViewPostsQueryOpts) []*model.Post); ok {
+		r0 = rf(channelID, props, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *model.TableProps, model.ViewPostsQueryOpts) error); ok {
+		r1 = rf(channelID, props, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: view
 func (_m *ViewStore) Save(view *model.View) (*model.View, error) {
 	ret := _m.Called(view)
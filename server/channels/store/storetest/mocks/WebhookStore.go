@@ -441,6 +441,66 @@ func (_m *WebhookStore) GetOutgoingByTeamByUser(teamID string, userID string, of
 	return r0, r1
 }
 
+// GetOutgoingDeliveriesByHook provides a mock function with given fields: hookID, offset, limit
+func (_m *WebhookStore) GetOutgoingDeliveriesByHook(hookID string, offset int, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	ret := _m.Called(hookID, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutgoingDeliveriesByHook")
+	}
+
+	var r0 []*model.OutgoingWebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]*model.OutgoingWebhookDelivery, error)); ok {
+		return rf(hookID, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.OutgoingWebhookDelivery); ok {
+		r0 = rf(hookID, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.OutgoingWebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(hookID, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOutgoingDeliveriesForRetry provides a mock function with given fields: before, limit
+func (_m *WebhookStore) GetOutgoingDeliveriesForRetry(before int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	ret := _m.Called(before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutgoingDeliveriesForRetry")
+	}
+
+	var r0 []*model.OutgoingWebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int) ([]*model.OutgoingWebhookDelivery, error)); ok {
+		return rf(before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int) []*model.OutgoingWebhookDelivery); ok {
+		r0 = rf(before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.OutgoingWebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOutgoingList provides a mock function with given fields: offset, limit
 func (_m *WebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
 	ret := _m.Called(offset, limit)
@@ -501,6 +561,36 @@ func (_m *WebhookStore) GetOutgoingListByUser(userID string, offset int, limit i
 	return r0, r1
 }
 
+// ImportIncoming provides a mock function with given fields: webhook
+func (_m *WebhookStore) ImportIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	ret := _m.Called(webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportIncoming")
+	}
+
+	var r0 *model.IncomingWebhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.IncomingWebhook) (*model.IncomingWebhook, error)); ok {
+		return rf(webhook)
+	}
+	if rf, ok := ret.Get(0).(func(*model.IncomingWebhook) *model.IncomingWebhook); ok {
+		r0 = rf(webhook)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IncomingWebhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.IncomingWebhook) error); ok {
+		r1 = rf(webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InvalidateWebhookCache provides a mock function with given fields: webhook
 func (_m *WebhookStore) InvalidateWebhookCache(webhook string) {
 	_m.Called(webhook)
@@ -578,6 +668,34 @@ func (_m *WebhookStore) PermanentDeleteOutgoingByUser(userID string) error {
 	return r0
 }
 
+// PermanentDeleteOutgoingDeliveriesBefore provides a mock function with given fields: before, limit
+func (_m *WebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64, limit int64) (int64, error) {
+	ret := _m.Called(before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PermanentDeleteOutgoingDeliveriesBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64) (int64, error)); ok {
+		return rf(before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(before, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveIncoming provides a mock function with given fields: webhook
 func (_m *WebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	ret := _m.Called(webhook)
@@ -638,6 +756,36 @@ func (_m *WebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.Out
 	return r0, r1
 }
 
+// SaveOutgoingDelivery provides a mock function with given fields: delivery
+func (_m *WebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	ret := _m.Called(delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveOutgoingDelivery")
+	}
+
+	var r0 *model.OutgoingWebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)); ok {
+		return rf(delivery)
+	}
+	if rf, ok := ret.Get(0).(func(*model.OutgoingWebhookDelivery) *model.OutgoingWebhookDelivery); ok {
+		r0 = rf(delivery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OutgoingWebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.OutgoingWebhookDelivery) error); ok {
+		r1 = rf(delivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateIncoming provides a mock function with given fields: webhook
 func (_m *WebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	ret := _m.Called(webhook)
@@ -716,6 +864,36 @@ func (_m *WebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.Outg
 	return r0, r1
 }
 
+// UpdateOutgoingDelivery provides a mock function with given fields: delivery
+func (_m *WebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	ret := _m.Called(delivery)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOutgoingDelivery")
+	}
+
+	var r0 *model.OutgoingWebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error)); ok {
+		return rf(delivery)
+	}
+	if rf, ok := ret.Get(0).(func(*model.OutgoingWebhookDelivery) *model.OutgoingWebhookDelivery); ok {
+		r0 = rf(delivery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.OutgoingWebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.OutgoingWebhookDelivery) error); ok {
+		r1 = rf(delivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewWebhookStore creates a new instance of WebhookStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewWebhookStore(t interface {
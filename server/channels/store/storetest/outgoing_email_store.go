@@ -0,0 +1,188 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestOutgoingEmailStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testOutgoingEmailSaveAndGet(t, rctx, ss) })
+	t.Run("Update", func(t *testing.T) { testOutgoingEmailUpdate(t, rctx, ss) })
+	t.Run("GetDue", func(t *testing.T) { testOutgoingEmailGetDue(t, rctx, ss) })
+	t.Run("GetFailed", func(t *testing.T) { testOutgoingEmailGetFailed(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testOutgoingEmailDelete(t, rctx, ss) })
+	t.Run("PermanentDeleteFailedBefore", func(t *testing.T) { testOutgoingEmailPermanentDeleteFailedBefore(t, rctx, ss) })
+}
+
+func saveOutgoingEmail(t *testing.T, ss store.Store, status string, nextAttemptAt int64) *model.OutgoingEmail {
+	t.Helper()
+
+	email, err := ss.OutgoingEmail().Save(&model.OutgoingEmail{
+		To:            model.NewId() + "@example.com",
+		Subject:       "Subject",
+		HTMLBody:      "<p>Body</p>",
+		Status:        status,
+		NextAttemptAt: nextAttemptAt,
+	})
+	require.NoError(t, err)
+	return email
+}
+
+func outgoingEmailIds(emails []*model.OutgoingEmail) []string {
+	ids := make([]string, len(emails))
+	for i, email := range emails {
+		ids[i] = email.Id
+	}
+	return ids
+}
+
+func testOutgoingEmailSaveAndGet(t *testing.T, _ request.CTX, ss store.Store) {
+	t.Run("valid", func(t *testing.T) {
+		email, err := ss.OutgoingEmail().Save(&model.OutgoingEmail{
+			To:            "user1@example.com",
+			Cc:            "user2@example.com",
+			ReplyTo:       "reply@example.com",
+			Subject:       "Subject",
+			HTMLBody:      "<p>Body</p>",
+			EmbeddedFiles: model.OutgoingEmailFiles{"logo.png": []byte{0, 1, 2}},
+			MessageId:     "<abc@example.com>",
+			InReplyTo:     "<def@example.com>",
+			References:    "<ghi@example.com>",
+			Category:      "NotificationEmail",
+			Attempts:      1,
+			LastError:     "connection refused",
+			NextAttemptAt: 1000,
+		})
+		require.NoError(t, err)
+		assert.True(t, model.IsValidId(email.Id))
+		assert.Equal(t, model.OutgoingEmailStatusPending, email.Status)
+
+		saved, err := ss.OutgoingEmail().Get(email.Id)
+		require.NoError(t, err)
+		assert.Equal(t, email, saved)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ss.OutgoingEmail().Save(&model.OutgoingEmail{Subject: "No recipient"})
+		require.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ss.OutgoingEmail().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testOutgoingEmailUpdate(t *testing.T, _ request.CTX, ss store.Store) {
+	email := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusPending, 0)
+
+	email.Status = model.OutgoingEmailStatusFailed
+	email.Attempts = 3
+	email.LastError = "mailbox unavailable"
+	email.NextAttemptAt = 0
+	_, err := ss.OutgoingEmail().Update(email)
+	require.NoError(t, err)
+
+	updated, err := ss.OutgoingEmail().Get(email.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.OutgoingEmailStatusFailed, updated.Status)
+	assert.Equal(t, 3, updated.Attempts)
+	assert.Equal(t, "mailbox unavailable", updated.LastError)
+	assert.Equal(t, "<p>Body</p>", updated.HTMLBody)
+
+	t.Run("not found", func(t *testing.T) {
+		missing := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusPending, 0)
+		require.NoError(t, ss.OutgoingEmail().Delete(missing.Id))
+
+		_, err := ss.OutgoingEmail().Update(missing)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testOutgoingEmailGetDue(t *testing.T, _ request.CTX, ss store.Store) {
+	now := model.GetMillis()
+	due1 := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusPending, now-2000)
+	due2 := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusPending, now-1000)
+	notDue := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusPending, now+60000)
+	failed := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusFailed, now-3000)
+
+	emails, err := ss.OutgoingEmail().GetDue(now, 1000)
+	require.NoError(t, err)
+
+	ids := outgoingEmailIds(emails)
+	assert.Contains(t, ids, due1.Id)
+	assert.Contains(t, ids, due2.Id)
+	assert.NotContains(t, ids, notDue.Id)
+	assert.NotContains(t, ids, failed.Id)
+
+	for i := 1; i < len(emails); i++ {
+		assert.LessOrEqual(t, emails[i-1].NextAttemptAt, emails[i].NextAttemptAt)
+	}
+
+	emails, err = ss.OutgoingEmail().GetDue(now, 1)
+	require.NoError(t, err)
+	assert.Len(t, emails, 1)
+}
+
+func testOutgoingEmailGetFailed(t *testing.T, _ request.CTX, ss store.Store) {
+	pending := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusPending, 0)
+	failed1 := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusFailed, 0)
+	failed2 := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusFailed, 0)
+
+	emails, err := ss.OutgoingEmail().GetFailed(0, 1000)
+	require.NoError(t, err)
+
+	ids := outgoingEmailIds(emails)
+	assert.Contains(t, ids, failed1.Id)
+	assert.Contains(t, ids, failed2.Id)
+	assert.NotContains(t, ids, pending.Id)
+
+	emails, err = ss.OutgoingEmail().GetFailed(0, 1)
+	require.NoError(t, err)
+	assert.Len(t, emails, 1)
+}
+
+func testOutgoingEmailDelete(t *testing.T, _ request.CTX, ss store.Store) {
+	email := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusFailed, 0)
+
+	require.NoError(t, ss.OutgoingEmail().Delete(email.Id))
+
+	_, err := ss.OutgoingEmail().Get(email.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	err = ss.OutgoingEmail().Delete(email.Id)
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testOutgoingEmailPermanentDeleteFailedBefore(t *testing.T, _ request.CTX, ss store.Store) {
+	pending := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusPending, 0)
+	failed := saveOutgoingEmail(t, ss, model.OutgoingEmailStatusFailed, 0)
+
+	_, err := ss.OutgoingEmail().PermanentDeleteFailedBefore(failed.UpdateAt, 1000)
+	require.NoError(t, err)
+	_, err = ss.OutgoingEmail().Get(failed.Id)
+	require.NoError(t, err, "should've kept the email updated at the cutoff")
+
+	deleted, err := ss.OutgoingEmail().PermanentDeleteFailedBefore(max(failed.UpdateAt, pending.UpdateAt)+1, 1000)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+
+	_, err = ss.OutgoingEmail().Get(failed.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = ss.OutgoingEmail().Get(pending.Id)
+	require.NoError(t, err, "should've kept the pending email")
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestPendingEmailNotificationStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("Save", func(t *testing.T) { testPendingEmailNotificationSave(t, rctx, ss) })
+	t.Run("GetForUser", func(t *testing.T) { testPendingEmailNotificationGetForUser(t, rctx, ss) })
+	t.Run("GetBatchStartTimes", func(t *testing.T) { testPendingEmailNotificationGetBatchStartTimes(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testPendingEmailNotificationDelete(t, rctx, ss) })
+	t.Run("IncrementAttempts", func(t *testing.T) { testPendingEmailNotificationIncrementAttempts(t, rctx, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testPendingEmailNotificationPermanentDeleteByUser(t, rctx, ss) })
+}
+
+func savePendingEmailNotification(t *testing.T, ss store.Store, userID string, createAt int64) *model.PendingEmailNotification {
+	t.Helper()
+
+	notification, err := ss.PendingEmailNotification().Save(&model.PendingEmailNotification{
+		UserId:   userID,
+		PostId:   model.NewId(),
+		TeamId:   model.NewId(),
+		CreateAt: createAt,
+	})
+	require.NoError(t, err)
+	return notification
+}
+
+func testPendingEmailNotificationSave(t *testing.T, _ request.CTX, ss store.Store) {
+	t.Run("valid", func(t *testing.T) {
+		notification, err := ss.PendingEmailNotification().Save(&model.PendingEmailNotification{
+			UserId: model.NewId(),
+			PostId: model.NewId(),
+			TeamId: model.NewId(),
+		})
+		require.NoError(t, err)
+		assert.True(t, model.IsValidId(notification.Id))
+		assert.NotZero(t, notification.CreateAt)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ss.PendingEmailNotification().Save(&model.PendingEmailNotification{
+			UserId: model.NewId(),
+			TeamId: model.NewId(),
+		})
+		require.Error(t, err)
+	})
+}
+
+func testPendingEmailNotificationGetForUser(t *testing.T, _ request.CTX, ss store.Store) {
+	userID := model.NewId()
+	n2 := savePendingEmailNotification(t, ss, userID, 2000)
+	n1 := savePendingEmailNotification(t, ss, userID, 1000)
+	savePendingEmailNotification(t, ss, model.NewId(), 1500)
+
+	notifications, err := ss.PendingEmailNotification().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, n1, notifications[0])
+	assert.Equal(t, n2, notifications[1])
+
+	notifications, err = ss.PendingEmailNotification().GetForUser(model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+}
+
+func testPendingEmailNotificationGetBatchStartTimes(t *testing.T, _ request.CTX, ss store.Store) {
+	userID1 := model.NewId()
+	userID2 := model.NewId()
+	savePendingEmailNotification(t, ss, userID1, 3000)
+	savePendingEmailNotification(t, ss, userID1, 1000)
+	savePendingEmailNotification(t, ss, userID2, 2000)
+
+	batchStartTimes, err := ss.PendingEmailNotification().GetBatchStartTimes()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), batchStartTimes[userID1])
+	assert.Equal(t, int64(2000), batchStartTimes[userID2])
+}
+
+func testPendingEmailNotificationDelete(t *testing.T, _ request.CTX, ss store.Store) {
+	userID := model.NewId()
+	n1 := savePendingEmailNotification(t, ss, userID, 1000)
+	n2 := savePendingEmailNotification(t, ss, userID, 2000)
+	n3 := savePendingEmailNotification(t, ss, userID, 3000)
+
+	deleted, err := ss.PendingEmailNotification().Delete([]string{n1.Id, n2.Id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	// deleting again removes nothing, so a concurrent sender can tell it lost
+	deleted, err = ss.PendingEmailNotification().Delete([]string{n1.Id, n2.Id})
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	deleted, err = ss.PendingEmailNotification().Delete(nil)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	notifications, err := ss.PendingEmailNotification().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, n3.Id, notifications[0].Id)
+}
+
+func testPendingEmailNotificationIncrementAttempts(t *testing.T, _ request.CTX, ss store.Store) {
+	userID := model.NewId()
+	n1 := savePendingEmailNotification(t, ss, userID, 1000)
+	n2 := savePendingEmailNotification(t, ss, userID, 2000)
+
+	require.NoError(t, ss.PendingEmailNotification().IncrementAttempts([]string{n1.Id}))
+	require.NoError(t, ss.PendingEmailNotification().IncrementAttempts([]string{n1.Id, n2.Id}))
+	require.NoError(t, ss.PendingEmailNotification().IncrementAttempts(nil))
+
+	notifications, err := ss.PendingEmailNotification().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, 2, notifications[0].Attempts)
+	assert.Equal(t, 1, notifications[1].Attempts)
+}
+
+func testPendingEmailNotificationPermanentDeleteByUser(t *testing.T, _ request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	savePendingEmailNotification(t, ss, userID, 1000)
+	savePendingEmailNotification(t, ss, userID, 2000)
+	savePendingEmailNotification(t, ss, otherUserID, 1000)
+
+	err := ss.PendingEmailNotification().PermanentDeleteByUser(userID)
+	require.NoError(t, err)
+
+	notifications, err := ss.PendingEmailNotification().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+
+	notifications, err = ss.PendingEmailNotification().GetForUser(otherUserID)
+	require.NoError(t, err)
+	assert.Len(t, notifications, 1)
+}
@@ -54,6 +54,7 @@ func TestPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("GetOldest", func(t *testing.T) { testPostStoreGetOldest(t, rctx, ss) })
 	t.Run("TestGetMaxPostSize", func(t *testing.T) { testGetMaxPostSize(t, rctx, ss) })
 	t.Run("GetParentsForExportAfter", func(t *testing.T) { testPostStoreGetParentsForExportAfter(t, rctx, ss) })
+	t.Run("GetDeletedForExportAfter", func(t *testing.T) { testPostStoreGetDeletedForExportAfter(t, rctx, ss) })
 	t.Run("GetRepliesForExport", func(t *testing.T) { testPostStoreGetRepliesForExport(t, rctx, ss) })
 	t.Run("GetDirectPostParentsForExportAfter", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfter(t, rctx, ss, s) })
 	t.Run("GetDirectPostParentsForExportAfterDeleted", func(t *testing.T) { testPostStoreGetDirectPostParentsForExportAfterDeleted(t, rctx, ss, s) })
@@ -4727,7 +4728,7 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 	require.NoError(t, nErr)
 
 	t.Run("without archived channels", func(t *testing.T) {
-		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), model.PostExportOpts{})
 		assert.NoError(t, err)
 
 		found := false
@@ -4750,7 +4751,7 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 	})
 
 	t.Run("with archived channels", func(t *testing.T) {
-		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), true)
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), model.PostExportOpts{IncludeArchivedChannels: true})
 		assert.NoError(t, err)
 
 		found := false
@@ -4778,7 +4779,7 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 		}))
 		require.NoError(t, err)
 
-		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), model.PostExportOpts{})
 		assert.NoError(t, err)
 
 		for _, p := range posts {
@@ -4788,6 +4789,102 @@ func testPostStoreGetParentsForExportAfter(t *testing.T, rctx request.CTX, ss st
 			}
 		}
 	})
+
+	t.Run("scoped to a channel", func(t *testing.T) {
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), model.PostExportOpts{
+			IncludeArchivedChannels: true,
+			ChannelIds:              []string{c2.Id},
+		})
+		assert.NoError(t, err)
+
+		require.Len(t, posts, 1)
+		assert.Equal(t, p2.Id, posts[0].Id)
+	})
+
+	t.Run("since a given time", func(t *testing.T) {
+		posts, err := ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), model.PostExportOpts{
+			TeamIds: []string{t1.Id},
+			Since:   p1.UpdateAt,
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, posts)
+
+		posts, err = ss.Post().GetParentsForExportAfter(10000, strings.Repeat("0", 26), model.PostExportOpts{
+			TeamIds: []string{t1.Id},
+			Since:   p1.UpdateAt - 1,
+		})
+		assert.NoError(t, err)
+		require.Len(t, posts, 1)
+		assert.Equal(t, p1.Id, posts[0].Id)
+	})
+}
+
+func testPostStoreGetDeletedForExportAfter(t *testing.T, rctx request.CTX, ss store.Store) {
+	t1 := model.Team{}
+	t1.DisplayName = "Name"
+	t1.Name = NewTestID()
+	t1.Email = MakeEmail()
+	t1.Type = model.TeamOpen
+	_, err := ss.Team().Save(&t1)
+	require.NoError(t, err)
+
+	c1 := model.Channel{}
+	c1.TeamId = t1.Id
+	c1.DisplayName = "Channel1"
+	c1.Name = NewTestID()
+	c1.Type = model.ChannelTypeOpen
+	_, nErr := ss.Channel().Save(rctx, &c1, -1)
+	require.NoError(t, nErr)
+
+	u1 := model.User{}
+	u1.Username = model.NewUsername()
+	u1.Email = MakeEmail()
+	u1.Nickname = model.NewId()
+	_, err = ss.User().Save(rctx, &u1)
+	require.NoError(t, err)
+
+	p1 := &model.Post{}
+	p1.ChannelId = c1.Id
+	p1.UserId = u1.Id
+	p1.Message = NewTestID()
+	p1.CreateAt = 1000
+	p1, nErr = ss.Post().Save(rctx, p1)
+	require.NoError(t, nErr)
+
+	p2 := &model.Post{}
+	p2.ChannelId = c1.Id
+	p2.UserId = u1.Id
+	p2.Message = NewTestID()
+	p2.CreateAt = 1001
+	p2, nErr = ss.Post().Save(rctx, p2)
+	require.NoError(t, nErr)
+
+	nErr = ss.Post().Delete(rctx, p2.Id, 2000, u1.Id)
+	require.NoError(t, nErr)
+
+	// The previous version of an edited post isn't exported.
+	oldVersion := p1.Clone()
+	oldVersion.Id = ""
+	oldVersion.OriginalId = p1.Id
+	oldVersion.DeleteAt = 1800
+	_, nErr = ss.Post().Save(rctx, oldVersion)
+	require.NoError(t, nErr)
+
+	opts := model.PostExportOpts{TeamIds: []string{t1.Id}, Since: 1500}
+
+	posts, err := ss.Post().GetDeletedForExportAfter(10000, strings.Repeat("0", 26), opts)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, p2.Id, posts[0].Id)
+	assert.Equal(t, p2.Message, posts[0].Message)
+	assert.Equal(t, t1.Name, posts[0].TeamName)
+	assert.Equal(t, c1.Name, posts[0].ChannelName)
+	assert.Equal(t, u1.Username, posts[0].Username)
+
+	opts.Since = 2000
+	posts, err = ss.Post().GetDeletedForExportAfter(10000, strings.Repeat("0", 26), opts)
+	require.NoError(t, err)
+	assert.Empty(t, posts)
 }
 
 func testPostStoreGetRepliesForExport(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -4901,7 +4998,7 @@ func testPostStoreGetDirectPostParentsForExportAfter(t *testing.T, rctx request.
 	p1, nErr = ss.Post().Save(rctx, p1)
 	require.NoError(t, nErr)
 
-	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), model.PostExportOpts{})
 	assert.NoError(t, nErr)
 
 	assert.Equal(t, p1.Message, r1[0].Message)
@@ -4961,11 +5058,11 @@ func testPostStoreGetDirectPostParentsForExportAfterDeleted(t *testing.T, rctx r
 	_, nErr = ss.Post().Save(rctx, p1)
 	require.NoError(t, nErr)
 
-	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, nErr := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), model.PostExportOpts{})
 	assert.NoError(t, nErr)
 	assert.Equal(t, 0, len(r1))
 
-	r1, nErr = ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), true)
+	r1, nErr = ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), model.PostExportOpts{IncludeArchivedChannels: true})
 	assert.NoError(t, nErr)
 	assert.Equal(t, 1, len(r1))
 
@@ -5024,7 +5121,7 @@ func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, rctx r
 	slices.Sort(postIds)
 
 	// Get all posts
-	r1, err := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), false)
+	r1, err := ss.Post().GetDirectPostParentsForExportAfter(10000, strings.Repeat("0", 26), model.PostExportOpts{})
 	assert.NoError(t, err)
 	assert.Equal(t, len(postIds), len(r1))
 	var exportedPostIds []string
@@ -5035,7 +5132,7 @@ func testPostStoreGetDirectPostParentsForExportAfterBatched(t *testing.T, rctx r
 	assert.ElementsMatch(t, postIds, exportedPostIds)
 
 	// Get 100
-	r1, err = ss.Post().GetDirectPostParentsForExportAfter(100, strings.Repeat("0", 26), false)
+	r1, err = ss.Post().GetDirectPostParentsForExportAfter(100, strings.Repeat("0", 26), model.PostExportOpts{})
 	assert.NoError(t, err)
 	assert.Equal(t, 100, len(r1))
 	exportedPostIds = []string{}
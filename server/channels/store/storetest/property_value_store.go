@@ -6,6 +6,7 @@ package storetest
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -30,6 +31,7 @@ func TestPropertyValueStore(t *testing.T, rctx request.CTX, ss store.Store, s Sq
 	t.Run("SearchPropertyValuesSince", func(t *testing.T) { testSearchPropertyValuesSince(t, rctx, ss) })
 	t.Run("DeleteForField", func(t *testing.T) { testDeleteForField(t, rctx, ss) })
 	t.Run("DeleteForTarget", func(t *testing.T) { testDeleteForTarget(t, rctx, ss) })
+	t.Run("GetForChannelPostsSince", func(t *testing.T) { testGetForChannelPostsSince(t, rctx, ss) })
 }
 
 func testCreatePropertyValue(t *testing.T, _ request.CTX, ss store.Store) {
@@ -1440,6 +1442,44 @@ func testSearchPropertyValues(t *testing.T, _ request.CTX, ss store.Store, s Sql
 		require.Empty(t, results[0].CreatedBy)
 		require.Empty(t, results[0].UpdatedBy)
 	})
+
+	t.Run("filter by numeric range", func(t *testing.T) {
+		numberGroupID := model.NewId()
+		numberFieldID := model.NewId()
+
+		ids := map[string]string{}
+		for _, raw := range []string{`-5`, `1.5`, `10`, `42`, `"7"`, `true`} {
+			value, err := ss.PropertyValue().Create(&model.PropertyValue{
+				GroupID:    numberGroupID,
+				TargetID:   model.NewId(),
+				TargetType: "test_type",
+				FieldID:    numberFieldID,
+				Value:      json.RawMessage(raw),
+			})
+			require.NoError(t, err)
+			ids[raw] = value.ID
+		}
+
+		search := func(minValue, maxValue *float64) []string {
+			results, err := ss.PropertyValue().SearchPropertyValues(model.PropertyValueSearchOpts{
+				GroupID:  numberGroupID,
+				ValueMin: minValue,
+				ValueMax: maxValue,
+				PerPage:  10,
+			})
+			require.NoError(t, err)
+			found := make([]string, len(results))
+			for i, value := range results {
+				found[i] = value.ID
+			}
+			return found
+		}
+
+		require.ElementsMatch(t, []string{ids[`1.5`], ids[`10`]}, search(model.NewPointer(1.5), model.NewPointer(10.0)))
+		require.ElementsMatch(t, []string{ids[`10`], ids[`42`]}, search(model.NewPointer(2.0), nil))
+		require.ElementsMatch(t, []string{ids[`-5`]}, search(nil, model.NewPointer(0.0)))
+		require.Empty(t, search(model.NewPointer(100.0), nil))
+	})
 }
 
 func testSearchPropertyValuesSince(t *testing.T, _ request.CTX, ss store.Store) {
@@ -1936,3 +1976,111 @@ func testDeleteForTarget(t *testing.T, _ request.CTX, ss store.Store) {
 		require.NotNil(t, nonDeletedTypeValue)
 	})
 }
+
+func testGetForChannelPostsSince(t *testing.T, rctx request.CTX, ss store.Store) {
+	groupID := model.NewId()
+	channelID := model.NewId()
+
+	savePost := func(channelID string) *model.Post {
+		post, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channelID,
+			UserId:    model.NewId(),
+			Message:   "card",
+		})
+		require.NoError(t, err)
+		return post
+	}
+
+	saveValue := func(groupID, postID string) *model.PropertyValue {
+		value, err := ss.PropertyValue().Create(&model.PropertyValue{
+			TargetID:   postID,
+			TargetType: model.PropertyValueTargetTypePost,
+			GroupID:    groupID,
+			FieldID:    model.NewId(),
+			Value:      json.RawMessage(`"value"`),
+		})
+		require.NoError(t, err)
+		return value
+	}
+
+	post1 := savePost(channelID)
+	post2 := savePost(channelID)
+	otherChannelPost := savePost(model.NewId())
+
+	value1 := saveValue(groupID, post1.Id)
+	time.Sleep(2 * time.Millisecond)
+	value2 := saveValue(groupID, post2.Id)
+	saveValue(groupID, otherChannelPost.Id)
+	saveValue(model.NewId(), post1.Id)
+
+	t.Run("should return the channel's values of the group", func(t *testing.T) {
+		values, err := ss.PropertyValue().GetForChannelPostsSince(groupID, channelID, 0, "", 10)
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+		require.Equal(t, value1.ID, values[0].ID)
+		require.Equal(t, value2.ID, values[1].ID)
+	})
+
+	t.Run("should honor since and limit", func(t *testing.T) {
+		values, err := ss.PropertyValue().GetForChannelPostsSince(groupID, channelID, 0, "", 1)
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		require.Equal(t, value1.ID, values[0].ID)
+
+		values, err = ss.PropertyValue().GetForChannelPostsSince(groupID, channelID, value1.UpdateAt, value1.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		require.Equal(t, value2.ID, values[0].ID)
+	})
+
+	t.Run("should include deleted values", func(t *testing.T) {
+		time.Sleep(2 * time.Millisecond)
+		require.NoError(t, ss.PropertyValue().Delete(groupID, value1.ID))
+
+		values, err := ss.PropertyValue().GetForChannelPostsSince(groupID, channelID, value2.UpdateAt, value2.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		require.Equal(t, value1.ID, values[0].ID)
+		require.NotZero(t, values[0].DeleteAt)
+	})
+
+	t.Run("should page through values changed at the same time", func(t *testing.T) {
+		sameTimeChannelID := model.NewId()
+		post := savePost(sameTimeChannelID)
+
+		var ids []string
+		for range 3 {
+			value, err := ss.PropertyValue().Create(&model.PropertyValue{
+				TargetID:   post.Id,
+				TargetType: model.PropertyValueTargetTypePost,
+				GroupID:    groupID,
+				FieldID:    model.NewId(),
+				Value:      json.RawMessage(`"value"`),
+				CreateAt:   1000,
+			})
+			require.NoError(t, err)
+			ids = append(ids, value.ID)
+		}
+		slices.Sort(ids)
+
+		values, err := ss.PropertyValue().GetForChannelPostsSince(groupID, sameTimeChannelID, 0, "", 2)
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+		require.Equal(t, ids[0], values[0].ID)
+		require.Equal(t, ids[1], values[1].ID)
+
+		values, err = ss.PropertyValue().GetForChannelPostsSince(groupID, sameTimeChannelID, 1000, values[1].ID, 2)
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		require.Equal(t, ids[2], values[0].ID)
+
+		values, err = ss.PropertyValue().GetForChannelPostsSince(groupID, sameTimeChannelID, 1000, ids[2], 2)
+		require.NoError(t, err)
+		require.Empty(t, values)
+	})
+
+	t.Run("should fail with an invalid limit", func(t *testing.T) {
+		_, err := ss.PropertyValue().GetForChannelPostsSince(groupID, channelID, 0, "", 0)
+		require.Error(t, err)
+	})
+}
@@ -40,6 +40,7 @@ func TestSessionStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("UpdateExpiredNotify", func(t *testing.T) { testUpdateExpiredNotify(t, rctx, ss) })
 	t.Run("GetLRUSessions", func(t *testing.T) { testGetLRUSessions(t, rctx, ss) })
 	t.Run("GetSessionsWithActiveDeviceIds", func(t *testing.T) { testGetSessionsWithActiveDeviceIds(t, rctx, ss) })
+	t.Run("GetSessionsWithWebPushSubscriptions", func(t *testing.T) { testGetSessionsWithWebPushSubscriptions(t, rctx, ss) })
 	t.Run("GetMobileSessionMetadata", func(t *testing.T) { testGetMobileSessionMetadata(t, rctx, ss) })
 }
 
@@ -533,6 +534,54 @@ func testGetSessionsWithActiveDeviceIds(t *testing.T, rctx request.CTX, ss store
 	require.False(t, sessionIds[s8.Id])
 }
 
+func testGetSessionsWithWebPushSubscriptions(t *testing.T, rctx request.CTX, ss store.Store) {
+	userId := model.NewId()
+
+	// Create session 1 with a web push subscription
+	s1 := &model.Session{}
+	s1.UserId = userId
+	s1.ExpiresAt = model.GetMillis() + 100000
+	s1.AddProp(model.SessionPropWebPushSubscription, `{"endpoint":"https://push.example.com/1"}`)
+	s1, err := ss.Session().Save(rctx, s1)
+	require.NoError(t, err)
+
+	// Create session 2 without a subscription - this should be filtered out
+	s2 := &model.Session{}
+	s2.UserId = userId
+	s2.ExpiresAt = model.GetMillis() + 100000
+	_, err = ss.Session().Save(rctx, s2)
+	require.NoError(t, err)
+
+	// Create session 3 with a cleared subscription - this should be filtered out
+	s3 := &model.Session{}
+	s3.UserId = userId
+	s3.ExpiresAt = model.GetMillis() + 100000
+	s3.AddProp(model.SessionPropWebPushSubscription, "")
+	_, err = ss.Session().Save(rctx, s3)
+	require.NoError(t, err)
+
+	// Create session 4 with a subscription but expired - this should be filtered out
+	s4 := &model.Session{}
+	s4.UserId = userId
+	s4.ExpiresAt = model.GetMillis() - 100000
+	s4.AddProp(model.SessionPropWebPushSubscription, `{"endpoint":"https://push.example.com/4"}`)
+	_, err = ss.Session().Save(rctx, s4)
+	require.NoError(t, err)
+
+	// Create session 5 with a subscription for another user - this should be filtered out
+	s5 := &model.Session{}
+	s5.UserId = model.NewId()
+	s5.ExpiresAt = model.GetMillis() + 100000
+	s5.AddProp(model.SessionPropWebPushSubscription, `{"endpoint":"https://push.example.com/5"}`)
+	_, err = ss.Session().Save(rctx, s5)
+	require.NoError(t, err)
+
+	sessions, err := ss.Session().GetSessionsWithWebPushSubscriptions(userId)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, s1.Id, sessions[0].Id)
+}
+
 func testUpdateExpiredNotify(t *testing.T, rctx request.CTX, ss store.Store) {
 	s1 := &model.Session{}
 	s1.UserId = model.NewId()
@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"slices"
 	"strconv"
 	"testing"
 	"time"
@@ -33,6 +34,7 @@ func TestSharedChannelStore(t *testing.T, rctx request.CTX, ss store.Store, s Sq
 	t.Run("HasRemote", func(t *testing.T) { testHasRemote(t, rctx, ss) })
 	t.Run("GetRemoteForUser", func(t *testing.T) { testGetRemoteForUser(t, rctx, ss) })
 	t.Run("UpdateSharedChannelRemoteNextSyncAt", func(t *testing.T) { testUpdateSharedChannelRemoteCursor(t, rctx, ss) })
+	t.Run("UpdateSharedChannelRemoteContentCursor", func(t *testing.T) { testUpdateSharedChannelRemoteContentCursor(t, rctx, ss) })
 	t.Run("UpdateGlobalUserSyncCursor", func(t *testing.T) { testUpdateGlobalUserSyncCursor(t, rctx, ss) })
 	t.Run("DeleteSharedChannelRemote", func(t *testing.T) { testDeleteSharedChannelRemote(t, rctx, ss) })
 
@@ -1086,6 +1088,91 @@ func testUpdateSharedChannelRemoteCursor(t *testing.T, rctx request.CTX, ss stor
 	})
 }
 
+func testUpdateSharedChannelRemoteContentCursor(t *testing.T, rctx request.CTX, ss store.Store) {
+	channel, err := createTestChannel(ss, rctx, "test_remote_update_content_cursor")
+	require.NoError(t, err)
+
+	remoteSaved, err := ss.SharedChannel().SaveRemote(&model.SharedChannelRemote{
+		ChannelId: channel.Id,
+		CreatorId: model.NewId(),
+		RemoteId:  model.NewId(),
+	})
+	require.NoError(t, err, "couldn't save remote", err)
+
+	t.Run("Update content cursor for remote", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteContentCursor(remoteSaved.Id, model.SharedChannelContentCursor{
+			LastChannelUpdateAt:       100,
+			LastBookmarkUpdateAt:      200,
+			LastPropertyValueUpdateAt: 300,
+		})
+		require.NoError(t, err)
+
+		r, err := ss.SharedChannel().GetRemote(remoteSaved.Id)
+		require.NoError(t, err)
+		require.Equal(t, int64(100), r.LastChannelUpdateAt)
+		require.Equal(t, int64(200), r.LastBookmarkUpdateAt)
+		require.Equal(t, int64(300), r.LastPropertyValueUpdateAt)
+	})
+
+	t.Run("Cursors only move forward", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteContentCursor(remoteSaved.Id, model.SharedChannelContentCursor{
+			LastChannelUpdateAt:  50,
+			LastBookmarkUpdateAt: 250,
+		})
+		require.NoError(t, err)
+
+		r, err := ss.SharedChannel().GetRemote(remoteSaved.Id)
+		require.NoError(t, err)
+		require.Equal(t, int64(100), r.LastChannelUpdateAt)
+		require.Equal(t, int64(250), r.LastBookmarkUpdateAt)
+		require.Equal(t, int64(300), r.LastPropertyValueUpdateAt)
+	})
+
+	t.Run("Property value cursor moves forward by time and id", func(t *testing.T) {
+		ids := []string{model.NewId(), model.NewId(), model.NewId()}
+		slices.Sort(ids)
+
+		update := func(updateAt int64, id string) {
+			err := ss.SharedChannel().UpdateRemoteContentCursor(remoteSaved.Id, model.SharedChannelContentCursor{
+				LastPropertyValueUpdateAt: updateAt,
+				LastPropertyValueId:       id,
+			})
+			require.NoError(t, err)
+		}
+		requireCursor := func(updateAt int64, id string) {
+			r, err := ss.SharedChannel().GetRemote(remoteSaved.Id)
+			require.NoError(t, err)
+			require.Equal(t, updateAt, r.LastPropertyValueUpdateAt)
+			require.Equal(t, id, r.LastPropertyValueId)
+		}
+
+		update(300, ids[1])
+		requireCursor(300, ids[1])
+
+		update(300, ids[0])
+		requireCursor(300, ids[1])
+
+		update(300, ids[2])
+		requireCursor(300, ids[2])
+
+		update(250, ids[2])
+		requireCursor(300, ids[2])
+
+		update(400, ids[0])
+		requireCursor(400, ids[0])
+	})
+
+	t.Run("Update content cursor for non-existent shared channel remote", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteContentCursor(model.NewId(), model.SharedChannelContentCursor{LastChannelUpdateAt: 100})
+		require.Error(t, err)
+	})
+
+	t.Run("Update with empty content cursor", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteContentCursor(remoteSaved.Id, model.SharedChannelContentCursor{})
+		require.Error(t, err)
+	})
+}
+
 func testUpdateGlobalUserSyncCursor(t *testing.T, rctx request.CTX, ss store.Store) {
 	// Create a remote cluster first
 	rc := &model.RemoteCluster{
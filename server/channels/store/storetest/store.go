@@ -79,6 +79,11 @@ type Store struct {
 	TemporaryPostStore              mocks.TemporaryPostStore
 	ViewStore                       mocks.ViewStore
 	ChannelJoinRequestStore         mocks.ChannelJoinRequestStore
+	PendingEmailNotificationStore   mocks.PendingEmailNotificationStore
+	OutgoingEmailStore              mocks.OutgoingEmailStore
+	WebAuthnCredentialStore         mocks.WebAuthnCredentialStore
+	MfaRecoveryCodeStore            mocks.MfaRecoveryCodeStore
+	ClusterMessageStore             mocks.ClusterMessageStore
 }
 
 func (s *Store) Logger() mlog.LoggerIFace                      { return s.logger }
@@ -194,6 +199,21 @@ func (s *Store) ChannelJoinRequest() store.ChannelJoinRequestStore {
 func (s *Store) View() store.ViewStore {
 	return &s.ViewStore
 }
+func (s *Store) PendingEmailNotification() store.PendingEmailNotificationStore {
+	return &s.PendingEmailNotificationStore
+}
+func (s *Store) OutgoingEmail() store.OutgoingEmailStore {
+	return &s.OutgoingEmailStore
+}
+func (s *Store) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return &s.WebAuthnCredentialStore
+}
+func (s *Store) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return &s.MfaRecoveryCodeStore
+}
+func (s *Store) ClusterMessage() store.ClusterMessageStore {
+	return &s.ClusterMessageStore
+}
 func (s *Store) GetSchemaDefinition() (*model.SupportPacketDatabaseSchema, error) {
 	return &model.SupportPacketDatabaseSchema{
 		Tables: []model.DatabaseTable{},
@@ -257,5 +277,10 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.TemporaryPostStore,
 		&s.ViewStore,
 		&s.ChannelJoinRequestStore,
+		&s.PendingEmailNotificationStore,
+		&s.OutgoingEmailStore,
+		&s.WebAuthnCredentialStore,
+		&s.MfaRecoveryCodeStore,
+		&s.ClusterMessageStore,
 	)
 }
@@ -4,7 +4,9 @@
 package storetest
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
@@ -23,6 +25,8 @@ func TestViewStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("UpdateView", func(t *testing.T) { testUpdateView(t, ss) })
 	t.Run("DeleteView", func(t *testing.T) { testDeleteView(t, ss) })
 	t.Run("UpdateSortOrder", func(t *testing.T) { testUpdateViewSortOrder(t, ss) })
+	t.Run("GetPostsForTable", func(t *testing.T) { testGetPostsForTable(t, rctx, ss) })
+	t.Run("GetPostsForCalendar", func(t *testing.T) { testGetPostsForCalendar(t, rctx, ss) })
 }
 
 func makeView(channelID, creatorID string) *model.View {
@@ -515,3 +519,183 @@ func testUpdateViewSortOrder(t *testing.T, ss store.Store) {
 		}
 	})
 }
+
+func makeViewPost(t *testing.T, rctx request.CTX, ss store.Store, channelID string, createAt int64) *model.Post {
+	t.Helper()
+
+	post, err := ss.Post().Save(rctx, &model.Post{
+		ChannelId: channelID,
+		UserId:    model.NewId(),
+		Message:   "card " + model.NewId(),
+		Type:      model.PostTypeCard,
+		CreateAt:  createAt,
+	})
+	require.NoError(t, err)
+	return post
+}
+
+func setViewPostValue(t *testing.T, ss store.Store, postID, fieldID, value string) {
+	t.Helper()
+
+	_, err := ss.PropertyValue().Create(&model.PropertyValue{
+		TargetID:   postID,
+		TargetType: model.PropertyValueTargetTypePost,
+		GroupID:    model.NewId(),
+		FieldID:    fieldID,
+		Value:      json.RawMessage(value),
+	})
+	require.NoError(t, err)
+}
+
+func postIDs(posts []*model.Post) []string {
+	ids := make([]string, 0, len(posts))
+	for _, p := range posts {
+		ids = append(ids, p.Id)
+	}
+	return ids
+}
+
+func testGetPostsForTable(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	statusFieldID := model.NewId()
+	tagsFieldID := model.NewId()
+	priorityFieldID := model.NewId()
+	todo, done := model.NewId(), model.NewId()
+	tagA, tagB := model.NewId(), model.NewId()
+
+	now := model.GetMillis()
+	p1 := makeViewPost(t, rctx, ss, channelID, now-3000)
+	p2 := makeViewPost(t, rctx, ss, channelID, now-2000)
+	p3 := makeViewPost(t, rctx, ss, channelID, now-1000)
+
+	setViewPostValue(t, ss, p1.Id, statusFieldID, `"`+todo+`"`)
+	setViewPostValue(t, ss, p2.Id, statusFieldID, `"`+done+`"`)
+	setViewPostValue(t, ss, p3.Id, statusFieldID, `"`+todo+`"`)
+	setViewPostValue(t, ss, p1.Id, tagsFieldID, `["`+tagA+`","`+tagB+`"]`)
+	setViewPostValue(t, ss, p2.Id, tagsFieldID, `["`+tagB+`"]`)
+	setViewPostValue(t, ss, p1.Id, priorityFieldID, "2")
+	setViewPostValue(t, ss, p2.Id, priorityFieldID, "1")
+
+	// Replies, system messages and deleted posts are never part of a view.
+	_, err := ss.Post().Save(rctx, &model.Post{ChannelId: channelID, UserId: model.NewId(), RootId: p1.Id, Message: "reply"})
+	require.NoError(t, err)
+	_, err = ss.Post().Save(rctx, &model.Post{ChannelId: channelID, UserId: model.NewId(), Type: model.PostTypeJoinChannel, Message: "joined"})
+	require.NoError(t, err)
+	deleted := makeViewPost(t, rctx, ss, channelID, now)
+	require.NoError(t, ss.Post().Delete(rctx, deleted.Id, model.GetMillis(), deleted.UserId))
+
+	// A post in another channel must not leak into the results.
+	other := makeViewPost(t, rctx, ss, model.NewId(), now)
+	setViewPostValue(t, ss, other.Id, statusFieldID, `"`+todo+`"`)
+
+	t.Run("returns root posts newest first without sort", func(t *testing.T) {
+		posts, err := ss.View().GetPostsForTable(channelID, &model.TableProps{}, model.ViewPostsQueryOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{p3.Id, p2.Id, p1.Id}, postIDs(posts))
+	})
+
+	t.Run("filters by single value", func(t *testing.T) {
+		props := &model.TableProps{Filters: []model.ViewFilter{{FieldID: statusFieldID, Values: []string{todo}}}}
+		posts, err := ss.View().GetPostsForTable(channelID, props, model.ViewPostsQueryOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{p3.Id, p1.Id}, postIDs(posts))
+	})
+
+	t.Run("filters by any element of array values", func(t *testing.T) {
+		props := &model.TableProps{Filters: []model.ViewFilter{{FieldID: tagsFieldID, Values: []string{tagA}}}}
+		posts, err := ss.View().GetPostsForTable(channelID, props, model.ViewPostsQueryOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{p1.Id}, postIDs(posts))
+
+		props.Filters[0].Values = []string{tagA, tagB}
+		posts, err = ss.View().GetPostsForTable(channelID, props, model.ViewPostsQueryOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{p2.Id, p1.Id}, postIDs(posts))
+	})
+
+	t.Run("combines filters", func(t *testing.T) {
+		props := &model.TableProps{Filters: []model.ViewFilter{
+			{FieldID: statusFieldID, Values: []string{todo}},
+			{FieldID: tagsFieldID, Values: []string{tagB}},
+		}}
+		posts, err := ss.View().GetPostsForTable(channelID, props, model.ViewPostsQueryOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{p1.Id}, postIDs(posts))
+	})
+
+	t.Run("sorts by value with missing values last", func(t *testing.T) {
+		props := &model.TableProps{Sort: &model.ViewSort{FieldID: priorityFieldID, Direction: model.ViewSortDirectionAsc}}
+		posts, err := ss.View().GetPostsForTable(channelID, props, model.ViewPostsQueryOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{p2.Id, p1.Id, p3.Id}, postIDs(posts))
+
+		props.Sort.Direction = model.ViewSortDirectionDesc
+		posts, err = ss.View().GetPostsForTable(channelID, props, model.ViewPostsQueryOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{p1.Id, p2.Id, p3.Id}, postIDs(posts))
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		posts, err := ss.View().GetPostsForTable(channelID, &model.TableProps{}, model.ViewPostsQueryOpts{Page: 1, PerPage: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []string{p1.Id}, postIDs(posts))
+	})
+
+	t.Run("empty channel id returns error", func(t *testing.T) {
+		_, err := ss.View().GetPostsForTable("", &model.TableProps{}, model.ViewPostsQueryOpts{})
+		var iiErr *store.ErrInvalidInput
+		assert.ErrorAs(t, err, &iiErr)
+	})
+}
+
+func testGetPostsForCalendar(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	dateFieldID := model.NewId()
+	statusFieldID := model.NewId()
+	todo := model.NewId()
+
+	day := int64(24 * time.Hour / time.Millisecond)
+	start := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	end := start + 31*day
+
+	now := model.GetMillis()
+	p1 := makeViewPost(t, rctx, ss, channelID, now-3000)
+	p2 := makeViewPost(t, rctx, ss, channelID, now-2000)
+	p3 := makeViewPost(t, rctx, ss, channelID, now-1000)
+	outside := makeViewPost(t, rctx, ss, channelID, now)
+	makeViewPost(t, rctx, ss, channelID, now)
+
+	setViewPostValue(t, ss, p1.Id, dateFieldID, strconv.FormatInt(start+10*day, 10))
+	setViewPostValue(t, ss, p2.Id, dateFieldID, strconv.FormatInt(start, 10))
+	setViewPostValue(t, ss, p3.Id, dateFieldID, strconv.FormatInt(start+20*day, 10))
+	setViewPostValue(t, ss, outside.Id, dateFieldID, strconv.FormatInt(end, 10))
+	setViewPostValue(t, ss, p3.Id, statusFieldID, `"`+todo+`"`)
+
+	t.Run("returns posts dated within the range sorted by date", func(t *testing.T) {
+		posts, err := ss.View().GetPostsForCalendar(channelID, &model.CalendarProps{DateFieldID: dateFieldID}, model.ViewPostsQueryOpts{Start: start, End: end})
+		require.NoError(t, err)
+		assert.Equal(t, []string{p2.Id, p1.Id, p3.Id}, postIDs(posts))
+	})
+
+	t.Run("applies filters", func(t *testing.T) {
+		props := &model.CalendarProps{
+			DateFieldID: dateFieldID,
+			Filters:     []model.ViewFilter{{FieldID: statusFieldID, Values: []string{todo}}},
+		}
+		posts, err := ss.View().GetPostsForCalendar(channelID, props, model.ViewPostsQueryOpts{Start: start, End: end})
+		require.NoError(t, err)
+		assert.Equal(t, []string{p3.Id}, postIDs(posts))
+	})
+
+	t.Run("invalid range returns error", func(t *testing.T) {
+		props := &model.CalendarProps{DateFieldID: dateFieldID}
+		for _, opts := range []model.ViewPostsQueryOpts{
+			{Start: end, End: start},
+			{Start: start, End: start + model.ViewCalendarMaxRange + 1},
+		} {
+			_, err := ss.View().GetPostsForCalendar(channelID, props, opts)
+			var iiErr *store.ErrInvalidInput
+			assert.ErrorAs(t, err, &iiErr)
+		}
+	})
+}
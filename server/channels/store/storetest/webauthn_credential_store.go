@@ -0,0 +1,153 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestWebAuthnCredentialStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testWebAuthnCredentialSaveAndGet(t, rctx, ss) })
+	t.Run("GetForUser", func(t *testing.T) { testWebAuthnCredentialGetForUser(t, rctx, ss) })
+	t.Run("UpdateLastUsed", func(t *testing.T) { testWebAuthnCredentialUpdateLastUsed(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testWebAuthnCredentialDelete(t, rctx, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testWebAuthnCredentialPermanentDeleteByUser(t, rctx, ss) })
+}
+
+func saveWebAuthnCredential(t *testing.T, ss store.Store, userID string) *model.WebAuthnCredential {
+	t.Helper()
+
+	credential, err := ss.WebAuthnCredential().Save(&model.WebAuthnCredential{
+		UserId:       userID,
+		Name:         "Security key",
+		CredentialId: model.NewId(),
+		PublicKey:    []byte{1, 2, 3},
+	})
+	require.NoError(t, err)
+	return credential
+}
+
+func testWebAuthnCredentialSaveAndGet(t *testing.T, _ request.CTX, ss store.Store) {
+	t.Run("valid", func(t *testing.T) {
+		credential, err := ss.WebAuthnCredential().Save(&model.WebAuthnCredential{
+			UserId:       model.NewId(),
+			Name:         "Security key",
+			CredentialId: model.NewId(),
+			PublicKey:    []byte{1, 2, 3},
+			SignCount:    5,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, credential.Id)
+		require.NotZero(t, credential.CreateAt)
+
+		received, err := ss.WebAuthnCredential().Get(credential.Id)
+		require.NoError(t, err)
+		assert.Equal(t, credential, received)
+
+		received, err = ss.WebAuthnCredential().GetByCredentialId(credential.CredentialId)
+		require.NoError(t, err)
+		assert.Equal(t, credential, received)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ss.WebAuthnCredential().Save(&model.WebAuthnCredential{
+			UserId:       model.NewId(),
+			CredentialId: model.NewId(),
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("duplicate credential id", func(t *testing.T) {
+		credential := saveWebAuthnCredential(t, ss, model.NewId())
+
+		_, err := ss.WebAuthnCredential().Save(&model.WebAuthnCredential{
+			UserId:       model.NewId(),
+			Name:         "Security key",
+			CredentialId: credential.CredentialId,
+			PublicKey:    []byte{1, 2, 3},
+		})
+		require.Error(t, err)
+		var cErr *store.ErrConflict
+		assert.ErrorAs(t, err, &cErr)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ss.WebAuthnCredential().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+
+		_, err = ss.WebAuthnCredential().GetByCredentialId(model.NewId())
+		assert.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testWebAuthnCredentialGetForUser(t *testing.T, _ request.CTX, ss store.Store) {
+	userID := model.NewId()
+	credential1 := saveWebAuthnCredential(t, ss, userID)
+	credential2 := saveWebAuthnCredential(t, ss, userID)
+	saveWebAuthnCredential(t, ss, model.NewId())
+
+	credentials, err := ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, credentials, 2)
+	assert.ElementsMatch(t, []string{credential1.Id, credential2.Id}, []string{credentials[0].Id, credentials[1].Id})
+
+	credentials, err = ss.WebAuthnCredential().GetForUser(model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+}
+
+func testWebAuthnCredentialUpdateLastUsed(t *testing.T, _ request.CTX, ss store.Store) {
+	credential := saveWebAuthnCredential(t, ss, model.NewId())
+
+	err := ss.WebAuthnCredential().UpdateLastUsed(credential.Id, 10, 1234)
+	require.NoError(t, err)
+
+	received, err := ss.WebAuthnCredential().GetByCredentialId(credential.CredentialId)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), received.SignCount)
+	assert.Equal(t, int64(1234), received.LastUsedAt)
+
+	err = ss.WebAuthnCredential().UpdateLastUsed(model.NewId(), 10, 1234)
+	var nfErr *store.ErrNotFound
+	assert.ErrorAs(t, err, &nfErr)
+}
+
+func testWebAuthnCredentialDelete(t *testing.T, _ request.CTX, ss store.Store) {
+	credential := saveWebAuthnCredential(t, ss, model.NewId())
+
+	err := ss.WebAuthnCredential().Delete(credential.Id)
+	require.NoError(t, err)
+
+	_, err = ss.WebAuthnCredential().Get(credential.Id)
+	var nfErr *store.ErrNotFound
+	assert.ErrorAs(t, err, &nfErr)
+
+	err = ss.WebAuthnCredential().Delete(credential.Id)
+	assert.ErrorAs(t, err, &nfErr)
+}
+
+func testWebAuthnCredentialPermanentDeleteByUser(t *testing.T, _ request.CTX, ss store.Store) {
+	userID := model.NewId()
+	saveWebAuthnCredential(t, ss, userID)
+	saveWebAuthnCredential(t, ss, userID)
+	other := saveWebAuthnCredential(t, ss, model.NewId())
+
+	err := ss.WebAuthnCredential().PermanentDeleteByUser(userID)
+	require.NoError(t, err)
+
+	credentials, err := ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+
+	_, err = ss.WebAuthnCredential().Get(other.Id)
+	require.NoError(t, err)
+}
@@ -17,6 +17,7 @@ import (
 
 func TestWebhookStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("SaveIncoming", func(t *testing.T) { testWebhookStoreSaveIncoming(t, rctx, ss) })
+	t.Run("ImportIncoming", func(t *testing.T) { testWebhookStoreImportIncoming(t, rctx, ss) })
 	t.Run("UpdateIncoming", func(t *testing.T) { testWebhookStoreUpdateIncoming(t, rctx, ss) })
 	t.Run("UpdateIncomingPreservesLastUsed", func(t *testing.T) { testWebhookStoreUpdateIncomingPreservesLastUsed(t, rctx, ss) })
 	t.Run("UpdateIncomingLastUsed", func(t *testing.T) { testWebhookStoreUpdateIncomingLastUsed(t, rctx, ss) })
@@ -43,6 +44,11 @@ func TestWebhookStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("UpdateOutgoing", func(t *testing.T) { testWebhookStoreUpdateOutgoing(t, rctx, ss) })
 	t.Run("CountIncoming", func(t *testing.T) { testWebhookStoreCountIncoming(t, rctx, ss) })
 	t.Run("CountOutgoing", func(t *testing.T) { testWebhookStoreCountOutgoing(t, rctx, ss) })
+	t.Run("SaveOutgoingDelivery", func(t *testing.T) { testWebhookStoreSaveOutgoingDelivery(t, rctx, ss) })
+	t.Run("UpdateOutgoingDelivery", func(t *testing.T) { testWebhookStoreUpdateOutgoingDelivery(t, rctx, ss) })
+	t.Run("GetOutgoingDeliveriesByHook", func(t *testing.T) { testWebhookStoreGetOutgoingDeliveriesByHook(t, rctx, ss) })
+	t.Run("GetOutgoingDeliveriesForRetry", func(t *testing.T) { testWebhookStoreGetOutgoingDeliveriesForRetry(t, rctx, ss) })
+	t.Run("PermanentDeleteOutgoingDeliveriesBefore", func(t *testing.T) { testWebhookStorePermanentDeleteOutgoingDeliveriesBefore(t, rctx, ss) })
 }
 
 func testWebhookStoreSaveIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -55,6 +61,31 @@ func testWebhookStoreSaveIncoming(t *testing.T, rctx request.CTX, ss store.Store
 	require.Error(t, err, "shouldn't be able to update from save")
 }
 
+func testWebhookStoreImportIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
+	o1 := buildIncomingWebhook()
+	o1.Id = model.NewId()
+
+	_, err := ss.Webhook().ImportIncoming(o1)
+	require.NoError(t, err)
+
+	saved, err := ss.Webhook().GetIncoming(o1.Id, false)
+	require.NoError(t, err)
+	require.Equal(t, o1.DisplayName, saved.DisplayName)
+
+	// The id of a deleted webhook can't be reused.
+	require.NoError(t, ss.Webhook().DeleteIncoming(o1.Id, model.GetMillis()))
+	o2 := buildIncomingWebhook()
+	o2.Id = o1.Id
+	_, err = ss.Webhook().ImportIncoming(o2)
+	var cErr *store.ErrConflict
+	require.ErrorAs(t, err, &cErr)
+
+	o3 := buildIncomingWebhook()
+	_, err = ss.Webhook().ImportIncoming(o3)
+	var iErr *store.ErrInvalidInput
+	require.ErrorAs(t, err, &iErr)
+}
+
 func testWebhookStoreUpdateIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
 	var err error
 
@@ -759,3 +790,153 @@ func testWebhookStoreCountOutgoing(t *testing.T, rctx request.CTX, ss store.Stor
 	require.NoError(t, err)
 	require.NotEqual(t, 0, r, "should have at least 1 outgoing hook")
 }
+
+func buildOutgoingWebhookDelivery(hookID string) *model.OutgoingWebhookDelivery {
+	return &model.OutgoingWebhookDelivery{
+		HookId:      hookID,
+		PostId:      model.NewId(),
+		CallbackURL: "http://nowhere.com/",
+		ContentType: "application/json",
+		Payload:     `{"text":"hello"}`,
+	}
+}
+
+func testWebhookStoreSaveOutgoingDelivery(t *testing.T, rctx request.CTX, ss store.Store) {
+	delivery := buildOutgoingWebhookDelivery(model.NewId())
+
+	saved, err := ss.Webhook().SaveOutgoingDelivery(delivery)
+	require.NoError(t, err)
+	require.NotEmpty(t, saved.Id)
+	require.Equal(t, model.OutgoingWebhookDeliveryStatusPending, saved.Status)
+
+	_, err = ss.Webhook().SaveOutgoingDelivery(delivery)
+	require.Error(t, err, "shouldn't be able to update from save")
+
+	invalid := buildOutgoingWebhookDelivery("invalid")
+	_, err = ss.Webhook().SaveOutgoingDelivery(invalid)
+	require.Error(t, err)
+}
+
+func testWebhookStoreUpdateOutgoingDelivery(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookID := model.NewId()
+	delivery, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookID))
+	require.NoError(t, err)
+
+	delivery.Status = model.OutgoingWebhookDeliveryStatusRetrying
+	delivery.StatusCode = 503
+	delivery.Attempts = 1
+	delivery.LatencyMs = 42
+	delivery.LastError = "service unavailable"
+	delivery.NextRetryAt = model.GetMillis()
+	_, err = ss.Webhook().UpdateOutgoingDelivery(delivery)
+	require.NoError(t, err)
+
+	deliveries, err := ss.Webhook().GetOutgoingDeliveriesByHook(hookID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, model.OutgoingWebhookDeliveryStatusRetrying, deliveries[0].Status)
+	require.Equal(t, 503, deliveries[0].StatusCode)
+	require.Equal(t, 1, deliveries[0].Attempts)
+	require.Equal(t, int64(42), deliveries[0].LatencyMs)
+	require.Equal(t, "service unavailable", deliveries[0].LastError)
+	require.Equal(t, `{"text":"hello"}`, deliveries[0].Payload)
+
+	t.Run("payload is dropped once the delivery is final", func(t *testing.T) {
+		delivery.Status = model.OutgoingWebhookDeliveryStatusSuccess
+		delivery.StatusCode = 200
+		delivery.Attempts = 2
+		_, err = ss.Webhook().UpdateOutgoingDelivery(delivery)
+		require.NoError(t, err)
+
+		deliveries, err = ss.Webhook().GetOutgoingDeliveriesByHook(hookID, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		require.Equal(t, model.OutgoingWebhookDeliveryStatusSuccess, deliveries[0].Status)
+		require.Empty(t, deliveries[0].Payload)
+	})
+}
+
+func testWebhookStoreGetOutgoingDeliveriesByHook(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookID := model.NewId()
+	var ids []string
+	for range 3 {
+		delivery, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookID))
+		require.NoError(t, err)
+		ids = append(ids, delivery.Id)
+		time.Sleep(time.Millisecond)
+	}
+	_, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(model.NewId()))
+	require.NoError(t, err)
+
+	deliveries, err := ss.Webhook().GetOutgoingDeliveriesByHook(hookID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 3)
+	require.Equal(t, ids[2], deliveries[0].Id, "most recent delivery should come first")
+	require.Equal(t, ids[0], deliveries[2].Id)
+
+	deliveries, err = ss.Webhook().GetOutgoingDeliveriesByHook(hookID, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, ids[1], deliveries[0].Id)
+}
+
+func testWebhookStoreGetOutgoingDeliveriesForRetry(t *testing.T, rctx request.CTX, ss store.Store) {
+	now := model.GetMillis()
+	hookID := model.NewId()
+
+	due, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookID))
+	require.NoError(t, err)
+	due.Status = model.OutgoingWebhookDeliveryStatusRetrying
+	due.NextRetryAt = now - 1000
+	_, err = ss.Webhook().UpdateOutgoingDelivery(due)
+	require.NoError(t, err)
+
+	notDue, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookID))
+	require.NoError(t, err)
+	notDue.Status = model.OutgoingWebhookDeliveryStatusRetrying
+	notDue.NextRetryAt = now + 60000
+	_, err = ss.Webhook().UpdateOutgoingDelivery(notDue)
+	require.NoError(t, err)
+
+	done, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookID))
+	require.NoError(t, err)
+	done.Status = model.OutgoingWebhookDeliveryStatusFailed
+	done.NextRetryAt = now - 1000
+	_, err = ss.Webhook().UpdateOutgoingDelivery(done)
+	require.NoError(t, err)
+
+	deliveries, err := ss.Webhook().GetOutgoingDeliveriesForRetry(now, 1000)
+	require.NoError(t, err)
+
+	var found []string
+	for _, delivery := range deliveries {
+		if delivery.HookId == hookID {
+			found = append(found, delivery.Id)
+		}
+	}
+	require.Equal(t, []string{due.Id}, found)
+}
+
+func testWebhookStorePermanentDeleteOutgoingDeliveriesBefore(t *testing.T, rctx request.CTX, ss store.Store) {
+	hookID := model.NewId()
+
+	finished, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookID))
+	require.NoError(t, err)
+	finished.Status = model.OutgoingWebhookDeliveryStatusSuccess
+	_, err = ss.Webhook().UpdateOutgoingDelivery(finished)
+	require.NoError(t, err)
+
+	retrying, err := ss.Webhook().SaveOutgoingDelivery(buildOutgoingWebhookDelivery(hookID))
+	require.NoError(t, err)
+	retrying.Status = model.OutgoingWebhookDeliveryStatusRetrying
+	_, err = ss.Webhook().UpdateOutgoingDelivery(retrying)
+	require.NoError(t, err)
+
+	_, err = ss.Webhook().PermanentDeleteOutgoingDeliveriesBefore(model.GetMillis()+1000, 1000)
+	require.NoError(t, err)
+
+	deliveries, err := ss.Webhook().GetOutgoingDeliveriesByHook(hookID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1, "deliveries still being retried should be kept")
+	require.Equal(t, retrying.Id, deliveries[0].Id)
+}
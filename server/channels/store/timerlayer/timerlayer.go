@@ -30,6 +30,7 @@ type TimerLayer struct {
 	ChannelJoinRequestStore         store.ChannelJoinRequestStore
 	ChannelMemberHistoryStore       store.ChannelMemberHistoryStore
 	ClusterDiscoveryStore           store.ClusterDiscoveryStore
+	ClusterMessageStore             store.ClusterMessageStore
 	CommandStore                    store.CommandStore
 	CommandWebhookStore             store.CommandWebhookStore
 	ComplianceStore                 store.ComplianceStore
@@ -42,9 +43,12 @@ type TimerLayer struct {
 	JobStore                        store.JobStore
 	LicenseStore                    store.LicenseStore
 	LinkMetadataStore               store.LinkMetadataStore
+	MfaRecoveryCodeStore            store.MfaRecoveryCodeStore
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
+	OutgoingEmailStore              store.OutgoingEmailStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
+	PendingEmailNotificationStore   store.PendingEmailNotificationStore
 	PluginStore                     store.PluginStore
 	PostStore                       store.PostStore
 	PostAcknowledgementStore        store.PostAcknowledgementStore
@@ -78,6 +82,7 @@ type TimerLayer struct {
 	UserAccessTokenStore            store.UserAccessTokenStore
 	UserTermsOfServiceStore         store.UserTermsOfServiceStore
 	ViewStore                       store.ViewStore
+	WebAuthnCredentialStore         store.WebAuthnCredentialStore
 	WebhookStore                    store.WebhookStore
 }
 
@@ -125,6 +130,10 @@ func (s *TimerLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
 	return s.ClusterDiscoveryStore
 }
 
+func (s *TimerLayer) ClusterMessage() store.ClusterMessageStore {
+	return s.ClusterMessageStore
+}
+
 func (s *TimerLayer) Command() store.CommandStore {
 	return s.CommandStore
 }
@@ -173,6 +182,10 @@ func (s *TimerLayer) LinkMetadata() store.LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *TimerLayer) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return s.MfaRecoveryCodeStore
+}
+
 func (s *TimerLayer) NotifyAdmin() store.NotifyAdminStore {
 	return s.NotifyAdminStore
 }
@@ -181,10 +194,18 @@ func (s *TimerLayer) OAuth() store.OAuthStore {
 	return s.OAuthStore
 }
 
+func (s *TimerLayer) OutgoingEmail() store.OutgoingEmailStore {
+	return s.OutgoingEmailStore
+}
+
 func (s *TimerLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return s.OutgoingOAuthConnectionStore
 }
 
+func (s *TimerLayer) PendingEmailNotification() store.PendingEmailNotificationStore {
+	return s.PendingEmailNotificationStore
+}
+
 func (s *TimerLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
@@ -317,6 +338,10 @@ func (s *TimerLayer) View() store.ViewStore {
 	return s.ViewStore
 }
 
+func (s *TimerLayer) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return s.WebAuthnCredentialStore
+}
+
 func (s *TimerLayer) Webhook() store.WebhookStore {
 	return s.WebhookStore
 }
@@ -376,6 +401,11 @@ type TimerLayerClusterDiscoveryStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerClusterMessageStore struct {
+	store.ClusterMessageStore
+	Root *TimerLayer
+}
+
 type TimerLayerCommandStore struct {
 	store.CommandStore
 	Root *TimerLayer
@@ -436,6 +466,11 @@ type TimerLayerLinkMetadataStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerMfaRecoveryCodeStore struct {
+	store.MfaRecoveryCodeStore
+	Root *TimerLayer
+}
+
 type TimerLayerNotifyAdminStore struct {
 	store.NotifyAdminStore
 	Root *TimerLayer
@@ -446,11 +481,21 @@ type TimerLayerOAuthStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerOutgoingEmailStore struct {
+	store.OutgoingEmailStore
+	Root *TimerLayer
+}
+
 type TimerLayerOutgoingOAuthConnectionStore struct {
 	store.OutgoingOAuthConnectionStore
 	Root *TimerLayer
 }
 
+type TimerLayerPendingEmailNotificationStore struct {
+	store.PendingEmailNotificationStore
+	Root *TimerLayer
+}
+
 type TimerLayerPluginStore struct {
 	store.PluginStore
 	Root *TimerLayer
@@ -616,6 +661,11 @@ type TimerLayerViewStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerWebAuthnCredentialStore struct {
+	store.WebAuthnCredentialStore
+	Root *TimerLayer
+}
+
 type TimerLayerWebhookStore struct {
 	store.WebhookStore
 	Root *TimerLayer
@@ -3520,6 +3570,22 @@ func (s *TimerLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int
 	return result, err
 }
 
+func (s *TimerLayerChannelMemberHistoryStore) GetChannelLeavesSince(userID string, since int64) ([]*model.ChannelMemberHistory, error) {
+	start := time.Now()
+
+	result, err := s.ChannelMemberHistoryStore.GetChannelLeavesSince(userID, since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelMemberHistoryStore.GetChannelLeavesSince", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
 	start := time.Now()
 
@@ -3760,6 +3826,54 @@ func (s *TimerLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.Cluster
 	return err
 }
 
+func (s *TimerLayerClusterMessageStore) DeleteBefore(createAt int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.ClusterMessageStore.DeleteBefore(createAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterMessageStore.DeleteBefore", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerClusterMessageStore) Get(id string) ([]byte, error) {
+	start := time.Now()
+
+	result, err := s.ClusterMessageStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterMessageStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerClusterMessageStore) Save(id string, data []byte) error {
+	start := time.Now()
+
+	err := s.ClusterMessageStore.Save(id, data)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterMessageStore.Save", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerCommandStore) AnalyticsCommandCount(teamID string) (int64, error) {
 	start := time.Now()
 
@@ -4382,6 +4496,22 @@ func (s *TimerLayerEmojiStore) GetByName(rctx request.CTX, name string, allowFro
 	return result, err
 }
 
+func (s *TimerLayerEmojiStore) GetDeletedForExportAfter(limit int, afterID string, since int64) ([]*model.Emoji, error) {
+	start := time.Now()
+
+	result, err := s.EmojiStore.GetDeletedForExportAfter(limit, afterID, since)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetDeletedForExportAfter", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
 	start := time.Now()
 
@@ -4446,6 +4576,22 @@ func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 	return result, err
 }
 
+func (s *TimerLayerFileInfoStore) AcquireBlob(contentHash string, size int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.FileInfoStore.AcquireBlob(contentHash, size)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.AcquireBlob", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerFileInfoStore) AttachToPost(rctx request.CTX, fileID string, postID string, channelID string, creatorID string) error {
 	start := time.Now()
 
@@ -4573,6 +4719,22 @@ func (s *TimerLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error
 	return result, err
 }
 
+func (s *TimerLayerFileInfoStore) GetFailedScans(now int64, maxBackoff int64, afterID string, limit int) ([]*model.FileInfo, error) {
+	start := time.Now()
+
+	result, err := s.FileInfoStore.GetFailedScans(now, maxBackoff, afterID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetFailedScans", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
 	start := time.Now()
 
@@ -4637,6 +4799,22 @@ func (s *TimerLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, err
 	return result, err
 }
 
+func (s *TimerLayerFileInfoStore) GetPendingScans(createdBefore int64, afterID string, limit int) ([]*model.FileInfo, error) {
+	start := time.Now()
+
+	result, err := s.FileInfoStore.GetPendingScans(createdBefore, afterID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetPendingScans", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerFileInfoStore) GetStorageUsage(allowFromCache bool, includeDeleted bool) (int64, error) {
 	start := time.Now()
 
@@ -4653,6 +4831,22 @@ func (s *TimerLayerFileInfoStore) GetStorageUsage(allowFromCache bool, includeDe
 	return result, err
 }
 
+func (s *TimerLayerFileInfoStore) GetUnreferencedBlobs(afterHash string, limit int) ([]string, error) {
+	start := time.Now()
+
+	result, err := s.FileInfoStore.GetUnreferencedBlobs(afterHash, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.GetUnreferencedBlobs", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerFileInfoStore) GetUptoNSizeFileTime(n int64) (int64, error) {
 	start := time.Now()
 
@@ -4700,6 +4894,22 @@ func (s *TimerLayerFileInfoStore) InvalidateFileInfosForPostCache(postID string,
 	}
 }
 
+func (s *TimerLayerFileInfoStore) MoveFilesToBlob(rctx request.CTX, path string, contentHash string) ([]string, error) {
+	start := time.Now()
+
+	result, err := s.FileInfoStore.MoveFilesToBlob(rctx, path, contentHash)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.MoveFilesToBlob", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerFileInfoStore) PermanentDelete(rctx request.CTX, fileID string) error {
 	start := time.Now()
 
@@ -4780,6 +4990,38 @@ func (s *TimerLayerFileInfoStore) RefreshFileStats() error {
 	return err
 }
 
+func (s *TimerLayerFileInfoStore) ReleaseBlob(contentHash string, removeBlob func() error) (int64, error) {
+	start := time.Now()
+
+	result, err := s.FileInfoStore.ReleaseBlob(contentHash, removeBlob)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.ReleaseBlob", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerFileInfoStore) RemoveBlobIfUnreferenced(contentHash string, removeBlob func() error) (bool, error) {
+	start := time.Now()
+
+	result, err := s.FileInfoStore.RemoveBlobIfUnreferenced(contentHash, removeBlob)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.RemoveBlobIfUnreferenced", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerFileInfoStore) RestoreForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
 	start := time.Now()
 
@@ -4844,6 +5086,38 @@ func (s *TimerLayerFileInfoStore) SetContent(rctx request.CTX, fileID string, co
 	return err
 }
 
+func (s *TimerLayerFileInfoStore) SetContentHash(rctx request.CTX, fileID string, contentHash string, path string) error {
+	start := time.Now()
+
+	err := s.FileInfoStore.SetContentHash(rctx, fileID, contentHash, path)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.SetContentHash", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerFileInfoStore) SetScanStatus(rctx request.CTX, fileID string, status string, scannedAt int64) error {
+	start := time.Now()
+
+	err := s.FileInfoStore.SetScanStatus(rctx, fileID, status, scannedAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("FileInfoStore.SetScanStatus", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerFileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
 	start := time.Now()
 
@@ -6108,6 +6382,54 @@ func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 	return result, err
 }
 
+func (s *TimerLayerMfaRecoveryCodeStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.MfaRecoveryCodeStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerMfaRecoveryCodeStore) SaveForUser(userID string, codeHashes []string) error {
+	start := time.Now()
+
+	err := s.MfaRecoveryCodeStore.SaveForUser(userID, codeHashes)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.SaveForUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerMfaRecoveryCodeStore) Use(userID string, codeHash string) (bool, error) {
+	start := time.Now()
+
+	result, err := s.MfaRecoveryCodeStore.Use(userID, codeHash)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.Use", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
 	start := time.Now()
 
@@ -6524,6 +6846,118 @@ func (s *TimerLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp,
 	return result, err
 }
 
+func (s *TimerLayerOutgoingEmailStore) Delete(id string) error {
+	start := time.Now()
+
+	err := s.OutgoingEmailStore.Delete(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerOutgoingEmailStore) Get(id string) (*model.OutgoingEmail, error) {
+	start := time.Now()
+
+	result, err := s.OutgoingEmailStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOutgoingEmailStore) GetDue(before int64, limit int) ([]*model.OutgoingEmail, error) {
+	start := time.Now()
+
+	result, err := s.OutgoingEmailStore.GetDue(before, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.GetDue", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOutgoingEmailStore) GetFailed(offset int, limit int) ([]*model.OutgoingEmail, error) {
+	start := time.Now()
+
+	result, err := s.OutgoingEmailStore.GetFailed(offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.GetFailed", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOutgoingEmailStore) PermanentDeleteFailedBefore(before int64, limit int) (int64, error) {
+	start := time.Now()
+
+	result, err := s.OutgoingEmailStore.PermanentDeleteFailedBefore(before, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.PermanentDeleteFailedBefore", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOutgoingEmailStore) Save(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
+	start := time.Now()
+
+	result, err := s.OutgoingEmailStore.Save(email)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOutgoingEmailStore) Update(email *model.OutgoingEmail) (*model.OutgoingEmail, error) {
+	start := time.Now()
+
+	result, err := s.OutgoingEmailStore.Update(email)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingEmailStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerOutgoingOAuthConnectionStore) DeleteConnection(rctx request.CTX, id string) error {
 	start := time.Now()
 
@@ -6572,10 +7006,106 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) GetConnections(rctx request.CTX
 	return result, err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+func (s *TimerLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+	start := time.Now()
+
+	result, err := s.OutgoingOAuthConnectionStore.SaveConnection(rctx, conn)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.SaveConnection", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+	start := time.Now()
+
+	result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(rctx, conn)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.UpdateConnection", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPendingEmailNotificationStore) Delete(ids []string) (int64, error) {
+	start := time.Now()
+
+	result, err := s.PendingEmailNotificationStore.Delete(ids)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmailNotificationStore.Delete", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPendingEmailNotificationStore) GetBatchStartTimes() (map[string]int64, error) {
+	start := time.Now()
+
+	result, err := s.PendingEmailNotificationStore.GetBatchStartTimes()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmailNotificationStore.GetBatchStartTimes", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPendingEmailNotificationStore) GetForUser(userID string) ([]*model.PendingEmailNotification, error) {
+	start := time.Now()
+
+	result, err := s.PendingEmailNotificationStore.GetForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmailNotificationStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPendingEmailNotificationStore) IncrementAttempts(ids []string) error {
+	start := time.Now()
+
+	err := s.PendingEmailNotificationStore.IncrementAttempts(ids)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmailNotificationStore.IncrementAttempts", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPendingEmailNotificationStore) PermanentDeleteByUser(userID string) error {
 	start := time.Now()
 
-	result, err := s.OutgoingOAuthConnectionStore.SaveConnection(rctx, conn)
+	err := s.PendingEmailNotificationStore.PermanentDeleteByUser(userID)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6583,15 +7113,15 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.SaveConnection", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmailNotificationStore.PermanentDeleteByUser", success, elapsed)
 	}
-	return result, err
+	return err
 }
 
-func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+func (s *TimerLayerPendingEmailNotificationStore) Save(notification *model.PendingEmailNotification) (*model.PendingEmailNotification, error) {
 	start := time.Now()
 
-	result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(rctx, conn)
+	result, err := s.PendingEmailNotificationStore.Save(notification)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6599,7 +7129,7 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.C
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("OutgoingOAuthConnectionStore.UpdateConnection", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PendingEmailNotificationStore.Save", success, elapsed)
 	}
 	return result, err
 }
@@ -6875,10 +7405,26 @@ func (s *TimerLayerPostStore) Get(rctx request.CTX, id string, opts model.GetPos
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+func (s *TimerLayerPostStore) GetDeletedForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.DeletedPostForExport, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetDeletedForExportAfter(limit, afterID, opts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetDeletedForExportAfter", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.DirectPostForExport, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels)
+	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -7035,10 +7581,10 @@ func (s *TimerLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
 	return result, err
 }
 
-func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
+func (s *TimerLayerPostStore) GetParentsForExportAfter(limit int, afterID string, opts model.PostExportOpts) ([]*model.PostForExport, error) {
 	start := time.Now()
 
-	result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels)
+	result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, opts)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -8602,6 +9148,22 @@ func (s *TimerLayerPropertyValueStore) Get(groupID string, id string) (*model.Pr
 	return result, err
 }
 
+func (s *TimerLayerPropertyValueStore) GetForChannelPostsSince(groupID string, channelID string, since int64, afterID string, limit int) ([]*model.PropertyValue, error) {
+	start := time.Now()
+
+	result, err := s.PropertyValueStore.GetForChannelPostsSince(groupID, channelID, since, afterID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PropertyValueStore.GetForChannelPostsSince", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPropertyValueStore) GetMany(groupID string, ids []string) ([]*model.PropertyValue, error) {
 	start := time.Now()
 
@@ -10249,6 +10811,22 @@ func (s *TimerLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) (
 	return result, err
 }
 
+func (s *TimerLayerSessionStore) GetSessionsWithWebPushSubscriptions(userID string) ([]*model.Session, error) {
+	start := time.Now()
+
+	result, err := s.SessionStore.GetSessionsWithWebPushSubscriptions(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetSessionsWithWebPushSubscriptions", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
 	start := time.Now()
 
@@ -10841,6 +11419,22 @@ func (s *TimerLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelR
 	return result, err
 }
 
+func (s *TimerLayerSharedChannelStore) UpdateRemoteContentCursor(id string, cursor model.SharedChannelContentCursor) error {
+	start := time.Now()
+
+	err := s.SharedChannelStore.UpdateRemoteContentCursor(id, cursor)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemoteContentCursor", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
 	start := time.Now()
 
@@ -14513,6 +15107,38 @@ func (s *TimerLayerViewStore) GetForChannel(channelID string, opts model.ViewQue
 	return result, err
 }
 
+func (s *TimerLayerViewStore) GetPostsForCalendar(channelID string, props *model.CalendarProps, opts model.ViewPostsQueryOpts) ([]*model.Post, error) {
+	start := time.Now()
+
+	result, err := s.ViewStore.GetPostsForCalendar(channelID, props, opts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ViewStore.GetPostsForCalendar", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerViewStore) GetPostsForTable(channelID string, props *model.TableProps, opts model.ViewPostsQueryOpts) ([]*model.Post, error) {
+	start := time.Now()
+
+	result, err := s.ViewStore.GetPostsForTable(channelID, props, opts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ViewStore.GetPostsForTable", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerViewStore) Save(view *model.View) (*model.View, error) {
 	start := time.Now()
 
@@ -14561,6 +15187,118 @@ func (s *TimerLayerViewStore) UpdateSortOrder(viewID string, channelID string, n
 	return result, err
 }
 
+func (s *TimerLayerWebAuthnCredentialStore) Delete(id string) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.Delete(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) Get(id string) (*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) GetByCredentialId(credentialID string) (*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.GetByCredentialId(credentialID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.GetByCredentialId", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) GetForUser(userID string) ([]*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.GetForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.Save(credential)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) UpdateLastUsed(id string, signCount int64, lastUsedAt int64) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.UpdateLastUsed(id, signCount, lastUsedAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.UpdateLastUsed", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerWebhookStore) AnalyticsIncomingCount(teamID string, userID string) (int64, error) {
 	start := time.Now()
 
@@ -14816,6 +15554,38 @@ func (s *TimerLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, userID s
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) GetOutgoingDeliveriesByHook(hookID string, offset int, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.GetOutgoingDeliveriesByHook(hookID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingDeliveriesByHook", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebhookStore) GetOutgoingDeliveriesForRetry(before int64, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.GetOutgoingDeliveriesForRetry(before, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.GetOutgoingDeliveriesForRetry", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
 	start := time.Now()
 
@@ -14848,6 +15618,22 @@ func (s *TimerLayerWebhookStore) GetOutgoingListByUser(userID string, offset int
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) ImportIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.ImportIncoming(webhook)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.ImportIncoming", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) InvalidateWebhookCache(webhook string) {
 	start := time.Now()
 
@@ -14927,6 +15713,22 @@ func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingByUser(userID string) er
 	return err
 }
 
+func (s *TimerLayerWebhookStore) PermanentDeleteOutgoingDeliveriesBefore(before int64, limit int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.PermanentDeleteOutgoingDeliveriesBefore(before, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.PermanentDeleteOutgoingDeliveriesBefore", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	start := time.Now()
 
@@ -14959,6 +15761,22 @@ func (s *TimerLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) SaveOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.SaveOutgoingDelivery(delivery)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.SaveOutgoingDelivery", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
 	start := time.Now()
 
@@ -15007,6 +15825,22 @@ func (s *TimerLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*m
 	return result, err
 }
 
+func (s *TimerLayerWebhookStore) UpdateOutgoingDelivery(delivery *model.OutgoingWebhookDelivery) (*model.OutgoingWebhookDelivery, error) {
+	start := time.Now()
+
+	result, err := s.WebhookStore.UpdateOutgoingDelivery(delivery)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebhookStore.UpdateOutgoingDelivery", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayer) Close() {
 	s.Store.Close()
 }
@@ -15056,6 +15890,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.ChannelJoinRequestStore = &TimerLayerChannelJoinRequestStore{ChannelJoinRequestStore: childStore.ChannelJoinRequest(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &TimerLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &TimerLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
+	newStore.ClusterMessageStore = &TimerLayerClusterMessageStore{ClusterMessageStore: childStore.ClusterMessage(), Root: &newStore}
 	newStore.CommandStore = &TimerLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
 	newStore.CommandWebhookStore = &TimerLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
 	newStore.ComplianceStore = &TimerLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
@@ -15068,9 +15903,12 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.JobStore = &TimerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &TimerLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.MfaRecoveryCodeStore = &TimerLayerMfaRecoveryCodeStore{MfaRecoveryCodeStore: childStore.MfaRecoveryCode(), Root: &newStore}
 	newStore.NotifyAdminStore = &TimerLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OutgoingEmailStore = &TimerLayerOutgoingEmailStore{OutgoingEmailStore: childStore.OutgoingEmail(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &TimerLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PendingEmailNotificationStore = &TimerLayerPendingEmailNotificationStore{PendingEmailNotificationStore: childStore.PendingEmailNotification(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PostAcknowledgementStore = &TimerLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
@@ -15104,6 +15942,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.UserAccessTokenStore = &TimerLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &TimerLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
 	newStore.ViewStore = &TimerLayerViewStore{ViewStore: childStore.View(), Root: &newStore}
+	newStore.WebAuthnCredentialStore = &TimerLayerWebAuthnCredentialStore{WebAuthnCredentialStore: childStore.WebAuthnCredential(), Root: &newStore}
 	newStore.WebhookStore = &TimerLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
 	return &newStore
 }
@@ -612,6 +612,30 @@ func (c *Context) RequireHookId() *Context {
 	return c
 }
 
+func (c *Context) RequireOutgoingEmailId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.OutgoingEmailId) {
+		c.SetInvalidURLParam("outgoing_email_id")
+	}
+
+	return c
+}
+
+func (c *Context) RequireWebAuthnCredentialId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.WebAuthnCredentialId) {
+		c.SetInvalidURLParam("webauthn_credential_id")
+	}
+
+	return c
+}
+
 func (c *Context) RequireCommandId() *Context {
 	if c.Err != nil {
 		return c
@@ -78,6 +78,7 @@ type Handler struct {
 	IsLocal                   bool
 	DisableWhenBusy           bool
 	FileAPI                   bool
+	RateLimitClass            app.RateLimitClass
 
 	cspShaDirective string
 }
@@ -356,6 +357,13 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if c.Err == nil && h.RateLimitClass != "" && c.App.Srv().RateLimiter != nil {
+		rateLimitExceeded = c.App.Srv().RateLimiter.ClassRateLimit(r.Context(), h.RateLimitClass, c.AppContext.Session().UserId, w, r)
+		if rateLimitExceeded {
+			return
+		}
+	}
+
 	if c.Err == nil {
 		h.HandleFunc(c, w, r)
 	}
@@ -42,6 +42,8 @@ type Params struct {
 	PluginId                           string
 	CommandId                          string
 	HookId                             string
+	OutgoingEmailId                    string
+	WebAuthnCredentialId               string
 	ReportId                           string
 	EmojiId                            string
 	AppId                              string
@@ -169,6 +171,8 @@ func ParamsFromRequest(r *http.Request) *Params {
 	}
 	params.CommandId = props["command_id"]
 	params.HookId = props["hook_id"]
+	params.OutgoingEmailId = props["outgoing_email_id"]
+	params.WebAuthnCredentialId = props["webauthn_credential_id"]
 	params.ReportId = props["report_id"]
 	params.EmojiId = props["emoji_id"]
 	params.AppId = props["app_id"]
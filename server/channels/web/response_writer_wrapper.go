@@ -60,3 +60,9 @@ func (rw *responseWriterWrapper) Flush() {
 		rw.flusher.Flush()
 	}
 }
+
+// Unwrap allows http.ResponseController to reach the wrapped ResponseWriter,
+// e.g. to set write deadlines on long-lived responses.
+func (rw *responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -94,3 +94,13 @@ func TestForSupportedFlush(t *testing.T) {
 	handler.ServeHTTP(resp, req)
 	assert.Equal(t, http.StatusOK, resp.StatusCode())
 }
+
+func TestForUnwrap(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	resp := newWrappedWriter(recorder)
+	assert.Equal(t, recorder, resp.Unwrap())
+
+	rc := http.NewResponseController(resp)
+	require.NoError(t, rc.Flush())
+	assert.True(t, recorder.Flushed)
+}
@@ -80,14 +80,14 @@ func slackImportCmdF(command *cobra.Command, args []string) error {
 
 	CommandPrettyPrintln("Running Slack Import. This may take a long time for large teams or teams with many messages.")
 
-	importErr, log := a.SlackImport(rctx, fileReader, fileInfo.Size(), team.Id)
+	importErr, report := a.SlackImport(rctx, fileReader, fileInfo.Size(), team.Id)
 
 	if importErr != nil {
 		return err
 	}
 
 	CommandPrettyPrintln("")
-	CommandPrintln(log.String())
+	CommandPrintln(report.String())
 	CommandPrettyPrintln("")
 
 	CommandPrettyPrintln("Finished Slack Import.")
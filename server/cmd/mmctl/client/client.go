@@ -58,6 +58,14 @@ type Client interface {
 	GetRoleByName(ctx context.Context, name string) (*model.Role, *model.Response, error)
 	GetAllRoles(ctx context.Context) ([]*model.Role, *model.Response, error)
 	PatchRole(ctx context.Context, roleID string, patch *model.RolePatch) (*model.Role, *model.Response, error)
+	GetRolesByNames(ctx context.Context, roleNames []string) ([]*model.Role, *model.Response, error)
+	CreateScheme(ctx context.Context, scheme *model.Scheme) (*model.Scheme, *model.Response, error)
+	GetScheme(ctx context.Context, id string) (*model.Scheme, *model.Response, error)
+	GetSchemes(ctx context.Context, scope string, page int, perPage int) ([]*model.Scheme, *model.Response, error)
+	PatchScheme(ctx context.Context, id string, patch *model.SchemePatch) (*model.Scheme, *model.Response, error)
+	DeleteScheme(ctx context.Context, id string) (*model.Response, error)
+	UpdateTeamScheme(ctx context.Context, teamID, schemeID string) (*model.Response, error)
+	UpdateChannelScheme(ctx context.Context, channelID, schemeID string) (*model.Response, error)
 	UploadPlugin(ctx context.Context, file io.Reader) (*model.Manifest, *model.Response, error)
 	UploadPluginForced(ctx context.Context, file io.Reader) (*model.Manifest, *model.Response, error)
 	RemovePlugin(ctx context.Context, id string) (*model.Response, error)
@@ -154,6 +162,10 @@ type Client interface {
 	GetOutgoingWebhooksForTeam(ctx context.Context, teamID string, page int, perPage int, etag string) ([]*model.OutgoingWebhook, *model.Response, error)
 	RegenOutgoingHookToken(ctx context.Context, hookID string) (*model.OutgoingWebhook, *model.Response, error)
 	DeleteOutgoingWebhook(ctx context.Context, hookID string) (*model.Response, error)
+	GetOutgoingWebhookDeliveries(ctx context.Context, hookID string, page int, perPage int) ([]*model.OutgoingWebhookDelivery, *model.Response, error)
+	GetFailedOutgoingEmails(ctx context.Context, page int, perPage int) ([]*model.OutgoingEmail, *model.Response, error)
+	RetryOutgoingEmail(ctx context.Context, id string) (*model.OutgoingEmail, *model.Response, error)
+	DeleteOutgoingEmail(ctx context.Context, id string) (*model.Response, error)
 	ListExports(ctx context.Context) ([]string, *model.Response, error)
 	DeleteExport(ctx context.Context, name string) (*model.Response, error)
 	DownloadExport(ctx context.Context, name string, wr io.Writer, offset int64) (int64, *model.Response, error)
@@ -0,0 +1,115 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/commands/workspace"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/spf13/cobra"
+)
+
+var PlanCmd = &cobra.Command{
+	Use:   "plan <manifest-file>",
+	Short: "Show the changes applying a workspace manifest would make",
+	Long: `Compare the teams, channels, roles, bots, webhooks and slash commands declared in a YAML manifest with the current state of the server, and show the changes that "mmctl apply" would make to converge it. Nothing is modified.
+
+Resources that the manifest doesn't declare are left untouched unless --prune is set, in which case the channels, webhooks and slash commands of the teams of the manifest that it doesn't declare are deleted. Teams, roles and bots are never deleted.`,
+	Example: `  # Show the changes needed to converge the server to the manifest
+  $ mmctl plan workspace.yaml
+
+  # Also show the undeclared channels, webhooks and commands that would be deleted
+  $ mmctl plan workspace.yaml --prune`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(planCmdF),
+}
+
+var ApplyCmd = &cobra.Command{
+	Use:   "apply <manifest-file>",
+	Short: "Apply a workspace manifest",
+	Long: `Converge the teams, channels, roles, bots, webhooks and slash commands of the server to the state declared in a YAML manifest. The changes are shown and confirmed before being applied, and applying the same manifest again makes no changes.
+
+Resources that the manifest doesn't declare are left untouched unless --prune is set, in which case the channels, webhooks and slash commands of the teams of the manifest that it doesn't declare are deleted. Teams, roles and bots are never deleted.`,
+	Example: `  # Apply a manifest after confirming its changes
+  $ mmctl apply workspace.yaml
+
+  # Apply a manifest without confirmation, deleting the undeclared channels, webhooks and commands
+  $ mmctl apply workspace.yaml --prune --confirm`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(applyCmdF),
+}
+
+func init() {
+	PlanCmd.Flags().Bool("prune", false, "Delete the channels, webhooks and slash commands of the teams of the manifest that it doesn't declare.")
+
+	ApplyCmd.Flags().Bool("prune", false, "Delete the channels, webhooks and slash commands of the teams of the manifest that it doesn't declare.")
+	ApplyCmd.Flags().Bool("confirm", false, "Apply the changes without asking for confirmation.")
+
+	RootCmd.AddCommand(
+		PlanCmd,
+		ApplyCmd,
+	)
+}
+
+func newWorkspacePlan(c client.Client, cmd *cobra.Command, manifestPath string) (*workspace.Plan, error) {
+	file, err := os.Open(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the manifest: %w", err)
+	}
+	defer file.Close()
+
+	manifest, err := workspace.LoadManifest(file)
+	if err != nil {
+		return nil, err
+	}
+
+	prune, _ := cmd.Flags().GetBool("prune")
+	return workspace.NewPlan(context.TODO(), c, manifest, workspace.Options{Prune: prune})
+}
+
+func planCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	plan, err := newWorkspacePlan(c, cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	printer.SetSingle(true)
+	printer.PrintT(workspace.PlanTemplate, plan)
+	return nil
+}
+
+func applyCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	plan, err := newWorkspacePlan(c, cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	printer.SetSingle(true)
+	printer.PrintT(workspace.PlanTemplate, plan)
+	if len(plan.Changes) == 0 {
+		return nil
+	}
+
+	confirmFlag, _ := cmd.Flags().GetBool("confirm")
+	if !confirmFlag {
+		// The plan has to be shown before asking to apply it.
+		printer.Flush()
+		if err := getConfirmation("Are you sure you want to apply these changes?", false); err != nil {
+			return err
+		}
+	}
+
+	applied, err := plan.Apply(context.TODO())
+	if err != nil {
+		return fmt.Errorf("applied %d of %d changes: %w", applied, len(plan.Changes), err)
+	}
+
+	printer.PrintT("Applied {{.}} changes.", applied)
+	return nil
+}
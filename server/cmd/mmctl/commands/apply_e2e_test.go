@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/commands/workspace"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) TestApplyCmd() {
+	s.SetupTestHelper().InitBasic(s.T())
+
+	newCmd := func(prune bool) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("prune", prune, "")
+		cmd.Flags().Bool("confirm", true, "")
+		return cmd
+	}
+
+	s.RunForSystemAdminAndLocal("Apply a manifest twice", func(c client.Client) {
+		teamName := "team" + model.NewId()
+		path := filepath.Join(s.T().TempDir(), "workspace.yaml")
+		s.Require().NoError(os.WriteFile(path, fmt.Appendf(nil, `
+teams:
+  - name: %s
+    display_name: Engineering
+    private: true
+    channels:
+      - name: releases
+        display_name: Releases
+        purpose: Release coordination
+      - name: incidents
+        display_name: Incidents
+        private: true
+    commands:
+      - trigger: oncall
+        url: https://oncall.example.com
+        owner: %s
+`, teamName, s.th.BasicUser.Username), 0600))
+
+		printer.Clean()
+		err := applyCmdF(c, newCmd(false), []string{path})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(4, printer.GetLines()[1])
+
+		team, _, err := s.th.SystemAdminClient.GetTeamByName(context.Background(), teamName, "")
+		s.Require().NoError(err)
+		s.Require().Equal(model.TeamInvite, team.Type)
+
+		channel, _, err := s.th.SystemAdminClient.GetChannelByName(context.Background(), "incidents", team.Id, "")
+		s.Require().NoError(err)
+		s.Require().Equal(model.ChannelTypePrivate, channel.Type)
+
+		printer.Clean()
+		err = planCmdF(c, newCmd(false), []string{path})
+		s.Require().NoError(err)
+		s.Require().Empty(printer.GetLines()[0].(*workspace.Plan).Changes)
+
+		// Only the undeclared default channels other than town square are
+		// pruned.
+		printer.Clean()
+		err = planCmdF(c, newCmd(true), []string{path})
+		s.Require().NoError(err)
+		for _, change := range printer.GetLines()[0].(*workspace.Plan).Changes {
+			s.Require().Equal(workspace.ActionDelete, change.Action)
+			s.Require().Equal(workspace.KindChannel, change.Kind)
+			s.Require().NotEqual(teamName+":"+model.DefaultChannelName, change.Name)
+		}
+	})
+
+	s.Run("Apply a manifest without permissions", func() {
+		path := filepath.Join(s.T().TempDir(), "workspace.yaml")
+		s.Require().NoError(os.WriteFile(path, []byte("teams:\n  - name: team"+model.NewId()+"\n    display_name: Engineering\n    private: true\n"), 0600))
+
+		s.th.RemovePermissionFromRole(s.T(), model.PermissionCreateTeam.Id, model.SystemUserRoleId)
+		defer s.th.AddPermissionToRole(s.T(), model.PermissionCreateTeam.Id, model.SystemUserRoleId)
+
+		printer.Clean()
+		err := applyCmdF(s.th.Client, newCmd(false), []string{path})
+		s.Require().ErrorContains(err, "applied 0 of 1 changes")
+	})
+}
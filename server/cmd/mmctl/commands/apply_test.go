@@ -0,0 +1,166 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/commands/workspace"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/spf13/cobra"
+)
+
+func (s *MmctlUnitTestSuite) writeManifest(manifest string) string {
+	path := filepath.Join(s.T().TempDir(), "workspace.yaml")
+	s.Require().NoError(os.WriteFile(path, []byte(manifest), 0600))
+	return path
+}
+
+func (s *MmctlUnitTestSuite) TestPlanCmd() {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("prune", false, "")
+		return cmd
+	}
+
+	s.Run("Plan the creation of a team", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "engineering", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+
+		path := s.writeManifest("teams:\n  - name: engineering\n    display_name: Engineering\n")
+		err := planCmdF(s.client, newCmd(), []string{path})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		plan := printer.GetLines()[0].(*workspace.Plan)
+		s.Require().Len(plan.Changes, 1)
+		s.Require().Equal(workspace.ActionCreate, plan.Changes[0].Action)
+		s.Require().Equal(workspace.KindTeam, plan.Changes[0].Kind)
+	})
+
+	s.Run("Plan an invalid manifest", func() {
+		printer.Clean()
+
+		path := s.writeManifest("teams:\n  - display_name: Engineering\n")
+		err := planCmdF(s.client, newCmd(), []string{path})
+		s.Require().EqualError(err, "a team has no name")
+		s.Require().Empty(printer.GetLines())
+	})
+
+	s.Run("Plan a missing manifest", func() {
+		printer.Clean()
+
+		err := planCmdF(s.client, newCmd(), []string{filepath.Join(s.T().TempDir(), "missing.yaml")})
+		s.Require().ErrorContains(err, "failed to open the manifest")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestApplyCmd() {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("prune", false, "")
+		cmd.Flags().Bool("confirm", true, "")
+		return cmd
+	}
+	path := s.writeManifest("teams:\n  - name: engineering\n    display_name: Engineering\n")
+
+	s.Run("Apply a manifest", func() {
+		printer.Clean()
+
+		team := &model.Team{Id: model.NewId(), Name: "engineering", DisplayName: "Engineering", Type: model.TeamOpen}
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "engineering", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateTeam(context.TODO(), &model.Team{Name: "engineering", DisplayName: "Engineering", Type: model.TeamOpen, AllowOpenInvite: true}).
+			Return(team, &model.Response{}, nil).
+			Times(1)
+
+		err := applyCmdF(s.client, newCmd(), []string{path})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(1, printer.GetLines()[1])
+	})
+
+	s.Run("Apply a manifest failing", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "engineering", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateTeam(context.TODO(), gomock.Any()).
+			Return(nil, &model.Response{StatusCode: http.StatusForbidden}, errors.New("mock error")).
+			Times(1)
+
+		err := applyCmdF(s.client, newCmd(), []string{path})
+		s.Require().EqualError(err, "applied 0 of 1 changes: failed to create team engineering: mock error")
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("Apply a manifest without changes", func() {
+		printer.Clean()
+
+		team := &model.Team{Id: model.NewId(), Name: "engineering", DisplayName: "Engineering", Type: model.TeamOpen}
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "engineering", "").
+			Return(team, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetPublicChannelsForTeam(context.TODO(), team.Id, 0, 200, "").
+			Return(nil, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetPrivateChannelsForTeam(context.TODO(), team.Id, 0, 200, "").
+			Return(nil, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetDeletedChannelsForTeam(context.TODO(), team.Id, 0, 200, "").
+			Return(nil, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetIncomingWebhooksForTeam(context.TODO(), team.Id, 0, 200, "").
+			Return(nil, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetOutgoingWebhooksForTeam(context.TODO(), team.Id, 0, 200, "").
+			Return(nil, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			ListCommands(context.TODO(), team.Id, true).
+			Return(nil, &model.Response{}, nil).
+			Times(1)
+
+		err := applyCmdF(s.client, newCmd(), []string{path})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Empty(printer.GetLines()[0].(*workspace.Plan).Changes)
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Management of audit logs",
+}
+
+var AuditVerifyCmd = &cobra.Command{
+	Use:   "verify [files]",
+	Short: "Verify the integrity of audit log files",
+	Long: "Verify the hash chain of audit log files written with ExperimentalAuditSettings.HashChainEnabled, reporting records that were deleted, modified or reordered. " +
+		"Rotated files must be given in the order they were written, oldest first. Chains that don't start at their first record are reported as a problem unless --allow-partial-chains is set. " +
+		"When --public-key is set, unsigned checkpoints and chains without any signed checkpoint are reported as problems. " +
+		"This command reads the files locally and does not connect to a server.",
+	Example: "  audit verify audit.log\n  audit verify audit-2026-10-01.log audit.log --public-key audit_signing_public.pem\n  audit verify audit.log --allow-partial-chains",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    auditVerifyCmdF,
+}
+
+func init() {
+	AuditVerifyCmd.Flags().String("public-key", "", "Path to the PEM encoded Ed25519 public key that checks the checkpoint signatures")
+	AuditVerifyCmd.Flags().Bool("allow-partial-chains", false, "Only warn about chains that don't start at their first record, for when older rotated logs are no longer available")
+
+	AuditCmd.AddCommand(
+		AuditVerifyCmd,
+	)
+
+	RootCmd.AddCommand(AuditCmd)
+}
+
+func auditVerifyCmdF(command *cobra.Command, args []string) error {
+	var opts audit.VerifyOptions
+	keyPath, err := command.Flags().GetString("public-key")
+	if err != nil {
+		return err
+	}
+	if keyPath != "" {
+		data, err := os.ReadFile(keyPath)
+		if err != nil {
+			return errors.Wrap(err, "unable to read the public key")
+		}
+		if opts.PublicKey, err = audit.ParseVerifyKey(data); err != nil {
+			return errors.Wrap(err, "invalid public key")
+		}
+	}
+
+	if opts.AllowPartialChains, err = command.Flags().GetBool("allow-partial-chains"); err != nil {
+		return err
+	}
+
+	verifier := audit.NewVerifier(opts)
+	for _, name := range args {
+		if err := verifyAuditLogFile(verifier, name); err != nil {
+			return err
+		}
+	}
+	result := verifier.Result()
+
+	for _, chain := range result.Chains {
+		printer.PrintT("Chain {{.ChainID}} ({{.Level}}): records {{.FirstSeq}} to {{.LastSeq}}, {{.Checkpoints}} checkpoint(s), {{.SignedCheckpoints}} with a verified signature", chain)
+		if !chain.EndsWithCheckpoint {
+			printer.PrintWarning(fmt.Sprintf("Chain %s (%s) does not end with a checkpoint. The log may have been truncated, or the server is still running or did not shut down cleanly.", chain.ChainID, chain.Level))
+		}
+	}
+	if result.UnchainedRecords > 0 {
+		printer.PrintWarning(fmt.Sprintf("%d record(s) are not part of a hash chain and could not be verified.", result.UnchainedRecords))
+	}
+	if opts.PublicKey == nil {
+		printer.PrintWarning("No public key given, checkpoint signatures were not verified.")
+	}
+	for _, warning := range result.Warnings {
+		printer.PrintWarning(fmt.Sprintf("%s:%d: %s", warning.File, warning.Line, warning.Message))
+	}
+
+	for _, problem := range result.Problems {
+		printer.PrintError(fmt.Sprintf("%s:%d: %s", problem.File, problem.Line, problem.Message))
+	}
+	if len(result.Problems) != 0 {
+		return fmt.Errorf("found %d problem(s) in the audit logs", len(result.Problems))
+	}
+
+	printer.Print("Audit logs verified successfully")
+	return nil
+}
+
+func verifyAuditLogFile(verifier *audit.Verifier, name string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return errors.Wrapf(err, "unable to open audit log %s", name)
+	}
+	defer file.Close()
+
+	return verifier.Verify(name, file)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestAuditVerifyCmd() {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+
+	dir := s.T().TempDir()
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	s.Require().NoError(err)
+	keyPath := filepath.Join(dir, "public.pem")
+	s.Require().NoError(os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0600))
+
+	logPath := filepath.Join(dir, "audit.log")
+	adt := &audit.Audit{}
+	adt.Init(audit.DefMaxQueueSize)
+	s.Require().NoError(adt.Configure(map[string]mlog.TargetCfg{"file": {
+		Type:    "file",
+		Format:  "json",
+		Levels:  []mlog.Level{mlog.LvlAuditAPI},
+		Options: json.RawMessage(fmt.Sprintf(`{"filename": "%s"}`, logPath)),
+	}}))
+	adt.EnableHashChain(audit.HashChainOptions{CheckpointInterval: 2, SigningKey: privateKey})
+	for range 4 {
+		adt.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: "login", Status: model.AuditStatusSuccess})
+	}
+	s.Require().NoError(adt.Shutdown())
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("public-key", keyPath, "")
+		cmd.Flags().Bool("allow-partial-chains", false, "")
+		return cmd
+	}
+
+	s.Run("Successfully verify an intact log", func() {
+		printer.Clean()
+
+		err := auditVerifyCmdF(newCmd(), []string{logPath})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal("Audit logs verified successfully", printer.GetLines()[1])
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Report a modified log", func() {
+		printer.Clean()
+
+		data, err := os.ReadFile(logPath)
+		s.Require().NoError(err)
+		tamperedPath := filepath.Join(dir, "tampered.log")
+		tampered := strings.Replace(string(data), `"event_name":"login"`, `"event_name":"logout"`, 1)
+		s.Require().NoError(os.WriteFile(tamperedPath, []byte(tampered), 0600))
+
+		err = auditVerifyCmdF(newCmd(), []string{tamperedPath})
+		s.Require().EqualError(err, "found 1 problem(s) in the audit logs")
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Contains(printer.GetErrorLines()[0], "tampered.log:1:")
+	})
+
+	s.Run("Report a log missing the start of its chain", func() {
+		printer.Clean()
+
+		data, err := os.ReadFile(logPath)
+		s.Require().NoError(err)
+		lines := strings.SplitAfter(string(data), "\n")
+		truncatedPath := filepath.Join(dir, "truncated.log")
+		s.Require().NoError(os.WriteFile(truncatedPath, []byte(strings.Join(lines[2:], "")), 0600))
+
+		err = auditVerifyCmdF(newCmd(), []string{truncatedPath})
+		s.Require().EqualError(err, "found 1 problem(s) in the audit logs")
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Contains(printer.GetErrorLines()[0], "are missing at the start of the chain")
+
+		printer.Clean()
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("allow-partial-chains", "true"))
+		err = auditVerifyCmdF(cmd, []string{truncatedPath})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Fail with a missing file", func() {
+		printer.Clean()
+
+		err := auditVerifyCmdF(newCmd(), []string{filepath.Join(dir, "missing.log")})
+		s.Require().Error(err)
+		s.Require().Contains(err.Error(), "unable to open audit log")
+	})
+}
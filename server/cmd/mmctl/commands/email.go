@@ -0,0 +1,128 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+var EmailCmd = &cobra.Command{
+	Use:   "email",
+	Short: "Management of outgoing emails",
+}
+
+var EmailDeadLettersCmd = &cobra.Command{
+	Use:   "dead-letters",
+	Short: "Management of the emails the mail queue failed to send",
+	Long:  "Management of the emails the mail queue gave up on sending, either because they were rejected or because they ran out of attempts",
+}
+
+var EmailDeadLettersListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List the emails that failed to send",
+	Long:    "List the emails the mail queue gave up on sending, most recent first, with their number of attempts and last error",
+	Args:    cobra.NoArgs,
+	Example: "  email dead-letters list --page 0 --per-page 20",
+	RunE:    withClient(emailDeadLettersListCmdF),
+}
+
+var EmailDeadLettersRetryCmd = &cobra.Command{
+	Use:     "retry [emailIDs]",
+	Short:   "Retry sending emails",
+	Long:    "Move emails that failed to send back to the mail queue, to be sent with its next run",
+	Args:    cobra.MinimumNArgs(1),
+	Example: "  email dead-letters retry w16zb5tu3n1zkqo18goqry1je",
+	RunE:    withClient(emailDeadLettersRetryCmdF),
+}
+
+var EmailDeadLettersDeleteCmd = &cobra.Command{
+	Use:     "delete [emailIDs]",
+	Short:   "Delete emails",
+	Long:    "Delete emails that failed to send, so that they are never sent",
+	Args:    cobra.MinimumNArgs(1),
+	Example: "  email dead-letters delete w16zb5tu3n1zkqo18goqry1je",
+	RunE:    withClient(emailDeadLettersDeleteCmdF),
+}
+
+func init() {
+	EmailDeadLettersListCmd.Flags().Int("page", 0, "Page number to fetch for the list of emails")
+	EmailDeadLettersListCmd.Flags().Int("per-page", DefaultPageSize, "Number of emails to be fetched")
+
+	EmailDeadLettersCmd.AddCommand(
+		EmailDeadLettersListCmd,
+		EmailDeadLettersRetryCmd,
+		EmailDeadLettersDeleteCmd,
+	)
+
+	EmailCmd.AddCommand(
+		EmailDeadLettersCmd,
+	)
+
+	RootCmd.AddCommand(EmailCmd)
+}
+
+func emailDeadLettersListCmdF(c client.Client, command *cobra.Command, args []string) error {
+	page, err := command.Flags().GetInt("page")
+	if err != nil {
+		return err
+	}
+	perPage, err := command.Flags().GetInt("per-page")
+	if err != nil {
+		return err
+	}
+
+	emails, _, err := c.GetFailedOutgoingEmails(context.TODO(), page, perPage)
+	if err != nil {
+		return errors.Wrap(err, "unable to list the emails that failed to send")
+	}
+
+	if len(emails) == 0 {
+		printer.Print("No emails found")
+		return nil
+	}
+
+	for _, email := range emails {
+		printer.PrintT("{{.Id}}\t{{.To}}\t{{.Subject}}\t{{.Attempts}} attempt(s)\t{{.LastError}}", email)
+	}
+
+	return nil
+}
+
+func emailDeadLettersRetryCmdF(c client.Client, command *cobra.Command, args []string) error {
+	var result *multierror.Error
+	for _, id := range args {
+		email, _, err := c.RetryOutgoingEmail(context.TODO(), id)
+		if err != nil {
+			printer.PrintError("Unable to retry email '" + id + "': " + err.Error())
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		printer.PrintT("Email {{.Id}} successfully queued", email)
+	}
+
+	return result.ErrorOrNil()
+}
+
+func emailDeadLettersDeleteCmdF(c client.Client, command *cobra.Command, args []string) error {
+	var result *multierror.Error
+	for _, id := range args {
+		if _, err := c.DeleteOutgoingEmail(context.TODO(), id); err != nil {
+			printer.PrintError("Unable to delete email '" + id + "': " + err.Error())
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		printer.Print("Email " + id + " successfully deleted")
+	}
+
+	return result.ErrorOrNil()
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestEmailDeadLettersListCmd() {
+	s.Run("Successfully list failed emails", func() {
+		printer.Clean()
+
+		mockEmails := []*model.OutgoingEmail{
+			{Id: "email1", To: "user1@example.com", Status: model.OutgoingEmailStatusFailed, Attempts: 10, LastError: "connection refused"},
+			{Id: "email2", To: "user2@example.com", Status: model.OutgoingEmailStatusFailed, Attempts: 1, LastError: "mailbox unavailable"},
+		}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 1, "")
+		cmd.Flags().Int("per-page", 2, "")
+
+		s.client.
+			EXPECT().
+			GetFailedOutgoingEmails(context.TODO(), 1, 2).
+			Return(mockEmails, &model.Response{}, nil).
+			Times(1)
+
+		err := emailDeadLettersListCmdF(s.client, cmd, []string{})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Len(printer.GetErrorLines(), 0)
+		s.Require().Equal(mockEmails[0], printer.GetLines()[0])
+		s.Require().Equal(mockEmails[1], printer.GetLines()[1])
+	})
+
+	s.Run("No failed emails found", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 200, "")
+
+		s.client.
+			EXPECT().
+			GetFailedOutgoingEmails(context.TODO(), 0, 200).
+			Return([]*model.OutgoingEmail{}, &model.Response{}, nil).
+			Times(1)
+
+		err := emailDeadLettersListCmdF(s.client, cmd, []string{})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("No emails found", printer.GetLines()[0])
+	})
+
+	s.Run("Error listing failed emails", func() {
+		printer.Clean()
+
+		mockError := errors.New("mock error")
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 200, "")
+
+		s.client.
+			EXPECT().
+			GetFailedOutgoingEmails(context.TODO(), 0, 200).
+			Return(nil, &model.Response{}, mockError).
+			Times(1)
+
+		err := emailDeadLettersListCmdF(s.client, cmd, []string{})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 0)
+		s.Require().Contains(err.Error(), mockError.Error())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmailDeadLettersRetryCmd() {
+	s.Run("Successfully retry failed emails", func() {
+		printer.Clean()
+
+		email1 := &model.OutgoingEmail{Id: "email1", Status: model.OutgoingEmailStatusPending}
+		email2 := &model.OutgoingEmail{Id: "email2", Status: model.OutgoingEmailStatusPending}
+
+		s.client.
+			EXPECT().
+			RetryOutgoingEmail(context.TODO(), email1.Id).
+			Return(email1, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			RetryOutgoingEmail(context.TODO(), email2.Id).
+			Return(email2, &model.Response{}, nil).
+			Times(1)
+
+		err := emailDeadLettersRetryCmdF(s.client, &cobra.Command{}, []string{email1.Id, email2.Id})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(email1, printer.GetLines()[0])
+		s.Require().Equal(email2, printer.GetLines()[1])
+		s.Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Error retrying a failed email", func() {
+		printer.Clean()
+
+		mockError := errors.New("mock error")
+		email2 := &model.OutgoingEmail{Id: "email2", Status: model.OutgoingEmailStatusPending}
+
+		s.client.
+			EXPECT().
+			RetryOutgoingEmail(context.TODO(), "email1").
+			Return(nil, &model.Response{}, mockError).
+			Times(1)
+		s.client.
+			EXPECT().
+			RetryOutgoingEmail(context.TODO(), email2.Id).
+			Return(email2, &model.Response{}, nil).
+			Times(1)
+
+		err := emailDeadLettersRetryCmdF(s.client, &cobra.Command{}, []string{"email1", email2.Id})
+		s.Require().Error(err)
+		s.Require().Contains(err.Error(), mockError.Error())
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(email2, printer.GetLines()[0])
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Equal("Unable to retry email 'email1': mock error", printer.GetErrorLines()[0])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmailDeadLettersDeleteCmd() {
+	s.Run("Successfully delete a failed email", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			DeleteOutgoingEmail(context.TODO(), "email1").
+			Return(&model.Response{StatusCode: 200}, nil).
+			Times(1)
+
+		err := emailDeadLettersDeleteCmdF(s.client, &cobra.Command{}, []string{"email1"})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("Email email1 successfully deleted", printer.GetLines()[0])
+		s.Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Error deleting a failed email", func() {
+		printer.Clean()
+
+		mockError := errors.New("mock error")
+
+		s.client.
+			EXPECT().
+			DeleteOutgoingEmail(context.TODO(), "email1").
+			Return(&model.Response{StatusCode: 404}, mockError).
+			Times(1)
+
+		err := emailDeadLettersDeleteCmdF(s.client, &cobra.Command{}, []string{"email1"})
+		s.Require().Error(err)
+		s.Require().Contains(err.Error(), mockError.Error())
+		s.Len(printer.GetLines(), 0)
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Equal("Unable to delete email 'email1': mock error", printer.GetErrorLines()[0])
+	})
+}
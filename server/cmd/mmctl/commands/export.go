@@ -5,9 +5,12 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
@@ -99,6 +102,9 @@ func init() {
 	ExportCreateCmd.Flags().Bool("include-archived-channels", false, "Include archived channels in the export file.")
 	ExportCreateCmd.Flags().Bool("include-profile-pictures", false, "Include profile pictures in the export file.")
 	ExportCreateCmd.Flags().Bool("no-roles-and-schemes", false, "Exclude roles and custom permission schemes from the export file.")
+	ExportCreateCmd.Flags().StringSlice("team", nil, "Only export the given teams, along with their channels and members. Can be used multiple times.")
+	ExportCreateCmd.Flags().StringSlice("channel", nil, "Only export the given channels, in team:channel format or by ID, along with their members. Can be used multiple times.")
+	ExportCreateCmd.Flags().Int64("since", 0, "Only export what was created, changed or deleted after the given Unix timestamp in milliseconds. Use the start time of the previous export job to chain exports.")
 
 	ExportDownloadCmd.Flags().Int("num-retries", 5, "Number of retries to do to resume a download.")
 
@@ -145,6 +151,40 @@ func exportCreateCmdF(c client.Client, command *cobra.Command, args []string) er
 		data["include_profile_pictures"] = "true"
 	}
 
+	teamArgs, _ := command.Flags().GetStringSlice("team")
+	if len(teamArgs) > 0 {
+		teamIDs := make([]string, 0, len(teamArgs))
+		for _, teamArg := range teamArgs {
+			team := getTeamFromTeamArg(c, teamArg)
+			if team == nil {
+				return fmt.Errorf("unable to find team %q", teamArg)
+			}
+			teamIDs = append(teamIDs, team.Id)
+		}
+		data["team_ids"] = strings.Join(teamIDs, ",")
+	}
+
+	channelArgs, _ := command.Flags().GetStringSlice("channel")
+	if len(channelArgs) > 0 {
+		channelIDs := make([]string, 0, len(channelArgs))
+		for _, channelArg := range channelArgs {
+			channel := getChannelFromChannelArg(c, channelArg)
+			if channel == nil {
+				return fmt.Errorf("unable to find channel %q", channelArg)
+			}
+			channelIDs = append(channelIDs, channel.Id)
+		}
+		data["channel_ids"] = strings.Join(channelIDs, ",")
+	}
+
+	since, _ := command.Flags().GetInt64("since")
+	if since < 0 {
+		return errors.New("since must be a positive timestamp")
+	}
+	if since > 0 {
+		data["since"] = strconv.FormatInt(since, 10)
+	}
+
 	job, _, err := c.CreateJob(context.TODO(), &model.Job{
 		Type: model.JobTypeExportProcess,
 		Data: data,
@@ -5,6 +5,7 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -87,6 +88,86 @@ func (s *MmctlUnitTestSuite) TestExportCreateCmdF() {
 		s.Empty(printer.GetErrorLines())
 		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
 	})
+
+	s.Run("create incremental export of a team and a channel", func() {
+		printer.Clean()
+		team := &model.Team{Id: model.NewId(), Name: "team"}
+		channel := &model.Channel{Id: model.NewId(), Name: "channel"}
+		mockJob := &model.Job{
+			Type: model.JobTypeExportProcess,
+			Data: map[string]string{
+				"include_attachments":       "true",
+				"include_roles_and_schemes": "true",
+				"team_ids":                  team.Id,
+				"channel_ids":               channel.Id,
+				"since":                     "1700000000000",
+			},
+		}
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), team.Id, "").
+			Return(team, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), "other", "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "other", "").
+			Return(&model.Team{Id: "otherteamid"}, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetChannelByNameIncludeDeleted(context.TODO(), channel.Name, "otherteamid", "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), mockJob).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("team", []string{team.Id}, "")
+		cmd.Flags().StringSlice("channel", []string{"other:" + channel.Name}, "")
+		cmd.Flags().Int64("since", 1700000000000, "")
+
+		err := exportCreateCmdF(s.client, cmd, nil)
+		s.Require().Nil(err)
+		s.Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+		s.Equal(mockJob, printer.GetLines()[0].(*model.Job))
+	})
+
+	s.Run("fail to create export of an unknown team", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), "unknown", "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "unknown", "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("team", []string{"unknown"}, "")
+
+		err := exportCreateCmdF(s.client, cmd, nil)
+		s.Require().EqualError(err, `unable to find team "unknown"`)
+		s.Empty(printer.GetLines())
+	})
 }
 func (s *MmctlUnitTestSuite) TestExportDeleteCmdF() {
 	printer.Clean()
@@ -89,6 +89,14 @@ const (
 	LineTypeDirectChannel = "direct_channel"
 	LineTypeDirectPost    = "direct_post"
 	LineTypeEmoji         = "emoji"
+	LineTypeDelete        = "delete"
+
+	LineTypeChannelBookmark = "channel_bookmark"
+	LineTypeDraft           = "draft"
+	LineTypeScheduledPost   = "scheduled_post"
+	LineTypeIncomingWebhook = "incoming_webhook"
+	LineTypeOutgoingWebhook = "outgoing_webhook"
+	LineTypeCommand         = "command"
 )
 
 func NewValidator(
@@ -421,6 +429,20 @@ func (v *Validator) validateLine(info ImportFileInfo, line imports.LineImportDat
 		err = v.validateDirectPost(info, line)
 	case LineTypeEmoji:
 		err = v.validateEmoji(info, line)
+	case LineTypeDelete:
+		err = v.validateDelete(info, line)
+	case LineTypeChannelBookmark:
+		err = v.validateChannelBookmark(info, line)
+	case LineTypeDraft:
+		err = v.validateDraft(info, line)
+	case LineTypeScheduledPost:
+		err = v.validateScheduledPost(info, line)
+	case LineTypeIncomingWebhook:
+		err = v.validateIncomingWebhook(info, line)
+	case LineTypeOutgoingWebhook:
+		err = v.validateOutgoingWebhook(info, line)
+	case LineTypeCommand:
+		err = v.validateCommand(info, line)
 	default:
 		err = v.onError(&ImportValidationError{
 			ImportFileInfo: info,
@@ -1034,6 +1056,162 @@ func (v *Validator) validateEmoji(info ImportFileInfo, line imports.LineImportDa
 	return nil
 }
 
+func (v *Validator) validateDelete(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "delete", line.Delete, func(data imports.DeleteImportData) *ImportValidationError {
+		appErr := imports.ValidateDeleteImportData(&data)
+		if appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "delete",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateChannelBookmark(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "channel_bookmark", line.ChannelBookmark, func(data imports.ChannelBookmarkImportData) *ImportValidationError {
+		appErr := imports.ValidateChannelBookmarkImportData(&data)
+		if appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "channel_bookmark",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	if !v.ignoreAttachments && line.ChannelBookmark != nil && line.ChannelBookmark.File != nil && line.ChannelBookmark.File.Path != nil {
+		attachmentPath := utils.NormalizeFilename(*line.ChannelBookmark.File.Path)
+		if _, ok := v.attachments[attachmentPath]; !ok {
+			attachmentPath = utils.NormalizeFilename(path.Join("data", *line.ChannelBookmark.File.Path))
+		}
+
+		if _, ok := v.attachments[attachmentPath]; !ok {
+			return v.onError(&ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "channel_bookmark.file",
+				Err:            fmt.Errorf("missing attachment file %q", attachmentPath),
+			})
+		}
+		v.attachmentsUsed[attachmentPath]++
+	}
+
+	return nil
+}
+
+func (v *Validator) validateDraft(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "draft", line.Draft, func(data imports.DraftImportData) *ImportValidationError {
+		appErr := imports.ValidateDraftImportData(&data, v.maxPostSize)
+		if appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "draft",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateScheduledPost(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "scheduled_post", line.ScheduledPost, func(data imports.ScheduledPostImportData) *ImportValidationError {
+		appErr := imports.ValidateScheduledPostImportData(&data, v.maxPostSize)
+		if appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "scheduled_post",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateIncomingWebhook(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "incoming_webhook", line.IncomingWebhook, func(data imports.IncomingWebhookImportData) *ImportValidationError {
+		appErr := imports.ValidateIncomingWebhookImportData(&data)
+		if appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "incoming_webhook",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateOutgoingWebhook(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "outgoing_webhook", line.OutgoingWebhook, func(data imports.OutgoingWebhookImportData) *ImportValidationError {
+		appErr := imports.ValidateOutgoingWebhookImportData(&data)
+		if appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "outgoing_webhook",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
+func (v *Validator) validateCommand(info ImportFileInfo, line imports.LineImportData) (err error) {
+	ivErr := validateNotNil(info, "command", line.Command, func(data imports.CommandImportData) *ImportValidationError {
+		appErr := imports.ValidateCommandImportData(&data)
+		if appErr != nil {
+			return &ImportValidationError{
+				ImportFileInfo: info,
+				FieldName:      "command",
+				Err:            appErr,
+			}
+		}
+
+		return nil
+	})
+	if ivErr != nil {
+		return v.onError(ivErr)
+	}
+
+	return nil
+}
+
 func (v *Validator) Attachments() []string {
 	used := make([]string, 0, len(v.attachmentsUsed))
 	for attachment := range v.attachmentsUsed {
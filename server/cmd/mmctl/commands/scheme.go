@@ -0,0 +1,415 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/spf13/cobra"
+)
+
+var SchemeCmd = &cobra.Command{
+	Use:   "scheme",
+	Short: "Management of permission schemes",
+}
+
+var SchemeCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a scheme",
+	Long:  "Create a team or channel permission scheme, along with its default roles.",
+	Example: `  # Create a team scheme
+  $ mmctl scheme create --name restricted_teams --display-name "Restricted teams" --scope team
+
+  # Create a channel scheme with a description
+  $ mmctl scheme create --name read_only --display-name "Read only" --description "Members can't post" --scope channel`,
+	Args: cobra.NoArgs,
+	RunE: withClient(schemeCreateCmdF),
+}
+
+var SchemeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List schemes",
+	Long:  "List the permission schemes, optionally restricted to a scope.",
+	Example: `  $ mmctl scheme list
+  $ mmctl scheme list --scope channel --json`,
+	Args: cobra.NoArgs,
+	RunE: withClient(schemeListCmdF),
+}
+
+var SchemeShowCmd = &cobra.Command{
+	Use:     "show [scheme]",
+	Short:   "Show a scheme",
+	Long:    "Show the scheme specified by its ID or name.",
+	Example: "  $ mmctl scheme show restricted_teams",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(schemeShowCmdF),
+}
+
+var SchemeModifyCmd = &cobra.Command{
+	Use:     "modify [scheme]",
+	Short:   "Modify a scheme",
+	Long:    "Modify the name, display name or description of a scheme.",
+	Example: `  $ mmctl scheme modify restricted_teams --display-name "Locked down teams" --description "Only admins can create channels"`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(schemeModifyCmdF),
+}
+
+var SchemeDeleteCmd = &cobra.Command{
+	Use:   "delete [schemes]",
+	Short: "Delete schemes",
+	Long: `Delete schemes along with their roles.
+The teams and channels using a deleted scheme fall back to the permissions of the system scheme.`,
+	Example: "  $ mmctl scheme delete restricted_teams read_only --confirm",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(schemeDeleteCmdF),
+}
+
+var SchemeAssignTeamCmd = &cobra.Command{
+	Use:     "assign-team [scheme] [teams]",
+	Short:   "Assign a team scheme to teams",
+	Long:    "Make teams use the permissions of a team scheme.",
+	Example: "  $ mmctl scheme assign-team restricted_teams myteam otherteam",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(schemeAssignTeamCmdF),
+}
+
+var SchemeAssignChannelCmd = &cobra.Command{
+	Use:     "assign-channel [scheme] [channels]",
+	Short:   "Assign a channel scheme to channels",
+	Long:    "Make channels use the permissions of a channel scheme. Channels can be specified by [team]:[channel], e.g. myteam:mychannel, or by channel ID.",
+	Example: "  $ mmctl scheme assign-channel read_only myteam:announcements myteam:news",
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(schemeAssignChannelCmdF),
+}
+
+var SchemeRolesCmd = &cobra.Command{
+	Use:   "roles [scheme]",
+	Short: "List the roles of a scheme",
+	Long: `List the default roles of the members of the teams and channels using a scheme.
+The permissions of these roles can be changed with the permissions commands.`,
+	Example: `  $ mmctl scheme roles restricted_teams
+  $ mmctl permissions role show <role_name>`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(schemeRolesCmdF),
+}
+
+func init() {
+	SchemeCreateCmd.Flags().String("name", "", "Scheme name, made of lowercase letters, numbers and underscores (required)")
+	_ = SchemeCreateCmd.MarkFlagRequired("name")
+	SchemeCreateCmd.Flags().String("display-name", "", "Scheme display name (required)")
+	_ = SchemeCreateCmd.MarkFlagRequired("display-name")
+	SchemeCreateCmd.Flags().String("description", "", "Scheme description")
+	SchemeCreateCmd.Flags().String("scope", "", "Scheme scope, team or channel (required)")
+	_ = SchemeCreateCmd.MarkFlagRequired("scope")
+
+	SchemeListCmd.Flags().String("scope", "", "List only the schemes of this scope, team or channel")
+
+	SchemeModifyCmd.Flags().String("name", "", "Scheme name")
+	SchemeModifyCmd.Flags().String("display-name", "", "Scheme display name")
+	SchemeModifyCmd.Flags().String("description", "", "Scheme description")
+
+	SchemeDeleteCmd.Flags().Bool("confirm", false, "Confirm you really want to delete the schemes.")
+
+	SchemeCmd.AddCommand(
+		SchemeCreateCmd,
+		SchemeListCmd,
+		SchemeShowCmd,
+		SchemeModifyCmd,
+		SchemeDeleteCmd,
+		SchemeAssignTeamCmd,
+		SchemeAssignChannelCmd,
+		SchemeRolesCmd,
+	)
+
+	RootCmd.AddCommand(SchemeCmd)
+}
+
+const schemeTemplate = `Id: {{.Id}}
+Name: {{.Name}}
+Display Name: {{.DisplayName}}
+Description: {{.Description}}
+Scope: {{.Scope}}`
+
+func validateSchemeScope(scope string) error {
+	switch scope {
+	case model.SchemeScopeTeam, model.SchemeScopeChannel:
+		return nil
+	}
+	return fmt.Errorf("invalid scope %q, must be %s or %s", scope, model.SchemeScopeTeam, model.SchemeScopeChannel)
+}
+
+// getSchemeFromArg returns the scheme with the given ID or, failing that, the
+// scheme with the given name.
+func getSchemeFromArg(c client.Client, schemeArg string) (*model.Scheme, error) {
+	if model.IsValidId(schemeArg) {
+		scheme, response, err := c.GetScheme(context.TODO(), schemeArg)
+		if err == nil {
+			return scheme, nil
+		}
+		nErr := ExtractErrorFromResponse(response, err)
+		var nfErr *NotFoundError
+		if !errors.As(nErr, &nfErr) {
+			return nil, nErr
+		}
+	}
+
+	if !model.IsValidSchemeName(schemeArg) {
+		return nil, ErrEntityNotFound{Type: "scheme", ID: schemeArg}
+	}
+
+	schemes, err := getPages(func(page, numPerPage int, _ string) ([]*model.Scheme, *model.Response, error) {
+		return c.GetSchemes(context.TODO(), "", page, numPerPage)
+	}, DefaultPageSize)
+	if err != nil {
+		return nil, err
+	}
+	for _, scheme := range schemes {
+		if scheme.Name == schemeArg {
+			return scheme, nil
+		}
+	}
+
+	return nil, ErrEntityNotFound{Type: "scheme", ID: schemeArg}
+}
+
+func schemeCreateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	name, _ := cmd.Flags().GetString("name")
+	displayName, _ := cmd.Flags().GetString("display-name")
+	description, _ := cmd.Flags().GetString("description")
+	scope, _ := cmd.Flags().GetString("scope")
+	if err := validateSchemeScope(scope); err != nil {
+		return err
+	}
+
+	scheme := &model.Scheme{
+		Name:        name,
+		DisplayName: displayName,
+		Description: description,
+		Scope:       scope,
+	}
+
+	createdScheme, _, err := c.CreateScheme(context.TODO(), scheme)
+	if err != nil {
+		return fmt.Errorf("unable to create scheme %q: %w", name, err)
+	}
+
+	printer.PrintT(schemeTemplate, createdScheme)
+	return nil
+}
+
+func schemeListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	scope, _ := cmd.Flags().GetString("scope")
+	if scope != "" {
+		if err := validateSchemeScope(scope); err != nil {
+			return err
+		}
+	}
+
+	schemes, err := getPages(func(page, numPerPage int, _ string) ([]*model.Scheme, *model.Response, error) {
+		return c.GetSchemes(context.TODO(), scope, page, numPerPage)
+	}, DefaultPageSize)
+	if err != nil {
+		return fmt.Errorf("failed to get schemes: %w", err)
+	}
+
+	for _, scheme := range schemes {
+		printer.PrintT("{{.Name}} ({{.Scope}}): {{.DisplayName}}", scheme)
+	}
+
+	return nil
+}
+
+func schemeShowCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	scheme, err := getSchemeFromArg(c, args[0])
+	if err != nil {
+		return err
+	}
+
+	printer.PrintT(schemeTemplate, scheme)
+	return nil
+}
+
+func schemeModifyCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+
+	scheme, err := getSchemeFromArg(c, args[0])
+	if err != nil {
+		return err
+	}
+
+	patch := &model.SchemePatch{}
+	if cmd.Flags().Changed("name") {
+		name, _ := cmd.Flags().GetString("name")
+		patch.Name = &name
+	}
+	if cmd.Flags().Changed("display-name") {
+		displayName, _ := cmd.Flags().GetString("display-name")
+		patch.DisplayName = &displayName
+	}
+	if cmd.Flags().Changed("description") {
+		description, _ := cmd.Flags().GetString("description")
+		patch.Description = &description
+	}
+	if patch.Name == nil && patch.DisplayName == nil && patch.Description == nil {
+		return errors.New("at least one of --name, --display-name or --description must be specified")
+	}
+
+	patchedScheme, _, err := c.PatchScheme(context.TODO(), scheme.Id, patch)
+	if err != nil {
+		return fmt.Errorf("unable to modify scheme %q: %w", args[0], err)
+	}
+
+	printer.PrintT(schemeTemplate, patchedScheme)
+	return nil
+}
+
+func schemeDeleteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	confirmFlag, _ := cmd.Flags().GetBool("confirm")
+	if !confirmFlag {
+		if err := getConfirmation("Are you sure you want to delete the schemes specified?", false); err != nil {
+			return err
+		}
+	}
+
+	var errs *multierror.Error
+	for _, arg := range args {
+		scheme, err := getSchemeFromArg(c, arg)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			printer.PrintError(err.Error())
+			continue
+		}
+
+		if _, err := c.DeleteScheme(context.TODO(), scheme.Id); err != nil {
+			deleteErr := fmt.Errorf("unable to delete scheme %q: %w", arg, err)
+			errs = multierror.Append(errs, deleteErr)
+			printer.PrintError(deleteErr.Error())
+			continue
+		}
+
+		printer.PrintT("Deleted scheme '{{.Name}}'", scheme)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func schemeAssignTeamCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	scheme, err := getSchemeFromArg(c, args[0])
+	if err != nil {
+		return err
+	}
+	if scheme.Scope != model.SchemeScopeTeam {
+		return fmt.Errorf("scheme %q is a %s scheme, only team schemes can be assigned to teams", args[0], scheme.Scope)
+	}
+
+	var errs *multierror.Error
+	teams := getTeamsFromTeamArgs(c, args[1:])
+	for i, team := range teams {
+		if team == nil {
+			teamErr := fmt.Errorf("unable to find team %q", args[i+1])
+			errs = multierror.Append(errs, teamErr)
+			printer.PrintError(teamErr.Error())
+			continue
+		}
+
+		if _, err := c.UpdateTeamScheme(context.TODO(), team.Id, scheme.Id); err != nil {
+			updateErr := fmt.Errorf("unable to assign scheme %q to team %q: %w", scheme.Name, team.Name, err)
+			errs = multierror.Append(errs, updateErr)
+			printer.PrintError(updateErr.Error())
+			continue
+		}
+
+		printer.PrintT("Scheme '"+scheme.Name+"' assigned to team '{{.Name}}'", team)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func schemeAssignChannelCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	scheme, err := getSchemeFromArg(c, args[0])
+	if err != nil {
+		return err
+	}
+	if scheme.Scope != model.SchemeScopeChannel {
+		return fmt.Errorf("scheme %q is a %s scheme, only channel schemes can be assigned to channels", args[0], scheme.Scope)
+	}
+
+	var errs *multierror.Error
+	channels := getChannelsFromChannelArgs(c, args[1:])
+	for i, channel := range channels {
+		if channel == nil {
+			channelErr := fmt.Errorf("unable to find channel %q", args[i+1])
+			errs = multierror.Append(errs, channelErr)
+			printer.PrintError(channelErr.Error())
+			continue
+		}
+
+		if _, err := c.UpdateChannelScheme(context.TODO(), channel.Id, scheme.Id); err != nil {
+			updateErr := fmt.Errorf("unable to assign scheme %q to channel %q: %w", scheme.Name, channel.Name, err)
+			errs = multierror.Append(errs, updateErr)
+			printer.PrintError(updateErr.Error())
+			continue
+		}
+
+		printer.PrintT("Scheme '"+scheme.Name+"' assigned to channel '{{.Name}}'", channel)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// schemeRoleNames returns the names of the default roles of a scheme, the
+// team roles coming first for team schemes.
+func schemeRoleNames(scheme *model.Scheme) []string {
+	var names []string
+	if scheme.Scope == model.SchemeScopeTeam {
+		names = append(names, scheme.DefaultTeamAdminRole, scheme.DefaultTeamUserRole, scheme.DefaultTeamGuestRole)
+	}
+	names = append(names, scheme.DefaultChannelAdminRole, scheme.DefaultChannelUserRole, scheme.DefaultChannelGuestRole)
+
+	roleNames := make([]string, 0, len(names))
+	for _, name := range names {
+		if name != "" {
+			roleNames = append(roleNames, name)
+		}
+	}
+	return roleNames
+}
+
+func schemeRolesCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	scheme, err := getSchemeFromArg(c, args[0])
+	if err != nil {
+		return err
+	}
+
+	roleNames := schemeRoleNames(scheme)
+	roles, _, err := c.GetRolesByNames(context.TODO(), roleNames)
+	if err != nil {
+		return fmt.Errorf("failed to get the roles of scheme %q: %w", args[0], err)
+	}
+
+	// The roles are returned in no particular order.
+	rolesByName := make(map[string]*model.Role, len(roles))
+	for _, role := range roles {
+		rolesByName[role.Name] = role
+	}
+	for _, name := range roleNames {
+		if role, ok := rolesByName[name]; ok {
+			printer.PrintT("{{.Name}}", role)
+		}
+	}
+
+	return nil
+}
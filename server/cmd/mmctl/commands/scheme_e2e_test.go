@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) TestSchemeCmds() {
+	s.SetupTestHelper().InitBasic(s.T())
+	s.th.App.Srv().SetLicense(model.NewTestLicense("custom_permissions_schemes"))
+	s.Require().NoError(s.th.App.SetPhase2PermissionsMigrationStatus(true))
+
+	createScheme := func(c client.Client, scope string) *model.Scheme {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "scheme_"+model.NewId(), "")
+		cmd.Flags().String("display-name", "Scheme", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().String("scope", scope, "")
+
+		printer.Clean()
+		err := schemeCreateCmdF(c, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		return printer.GetLines()[0].(*model.Scheme)
+	}
+
+	s.RunForSystemAdminAndLocal("Create, modify and delete a scheme", func(c client.Client) {
+		scheme := createScheme(c, model.SchemeScopeChannel)
+
+		printer.Clean()
+		err := schemeShowCmdF(c, &cobra.Command{}, []string{scheme.Name})
+		s.Require().NoError(err)
+		s.Require().Equal(scheme.Id, printer.GetLines()[0].(*model.Scheme).Id)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "", "")
+		cmd.Flags().String("display-name", "", "")
+		cmd.Flags().String("description", "", "")
+		s.Require().NoError(cmd.Flags().Set("description", "modified"))
+
+		printer.Clean()
+		err = schemeModifyCmdF(c, cmd, []string{scheme.Id})
+		s.Require().NoError(err)
+		s.Require().Equal("modified", printer.GetLines()[0].(*model.Scheme).Description)
+
+		cmd = &cobra.Command{}
+		cmd.Flags().Bool("confirm", true, "")
+
+		printer.Clean()
+		err = schemeDeleteCmdF(c, cmd, []string{scheme.Id})
+		s.Require().NoError(err)
+		s.Require().Empty(printer.GetErrorLines())
+
+		deletedScheme, appErr := s.th.App.GetScheme(scheme.Id)
+		s.Require().Nil(appErr)
+		s.Require().NotZero(deletedScheme.DeleteAt)
+	})
+
+	s.RunForSystemAdminAndLocal("List the schemes of a scope", func(c client.Client) {
+		teamScheme := createScheme(c, model.SchemeScopeTeam)
+		channelScheme := createScheme(c, model.SchemeScopeChannel)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("scope", model.SchemeScopeTeam, "")
+
+		printer.Clean()
+		err := schemeListCmdF(c, cmd, []string{})
+		s.Require().NoError(err)
+
+		var ids []string
+		for _, line := range printer.GetLines() {
+			ids = append(ids, line.(*model.Scheme).Id)
+		}
+		s.Require().Contains(ids, teamScheme.Id)
+		s.Require().NotContains(ids, channelScheme.Id)
+	})
+
+	s.RunForSystemAdminAndLocal("Assign schemes and list their roles", func(c client.Client) {
+		teamScheme := createScheme(c, model.SchemeScopeTeam)
+		channelScheme := createScheme(c, model.SchemeScopeChannel)
+
+		printer.Clean()
+		err := schemeAssignTeamCmdF(c, &cobra.Command{}, []string{teamScheme.Name, s.th.BasicTeam.Name})
+		s.Require().NoError(err)
+
+		team, _, err := s.th.SystemAdminClient.GetTeam(context.Background(), s.th.BasicTeam.Id, "")
+		s.Require().NoError(err)
+		s.Require().Equal(teamScheme.Id, *team.SchemeId)
+
+		printer.Clean()
+		err = schemeAssignChannelCmdF(c, &cobra.Command{}, []string{channelScheme.Name, s.th.BasicTeam.Name + ":" + s.th.BasicChannel.Name})
+		s.Require().NoError(err)
+
+		channel, _, err := s.th.SystemAdminClient.GetChannel(context.Background(), s.th.BasicChannel.Id)
+		s.Require().NoError(err)
+		s.Require().Equal(channelScheme.Id, *channel.SchemeId)
+
+		printer.Clean()
+		err = schemeRolesCmdF(c, &cobra.Command{}, []string{teamScheme.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 6)
+		s.Require().Equal(teamScheme.DefaultTeamAdminRole, printer.GetLines()[0].(*model.Role).Name)
+	})
+
+	s.Run("Create a scheme without permissions", func() {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "scheme_"+model.NewId(), "")
+		cmd.Flags().String("display-name", "Scheme", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().String("scope", model.SchemeScopeTeam, "")
+
+		printer.Clean()
+		err := schemeCreateCmdF(s.th.Client, cmd, []string{})
+		s.Require().Error(err)
+		s.Require().Empty(printer.GetLines())
+	})
+}
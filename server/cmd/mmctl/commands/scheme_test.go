@@ -0,0 +1,429 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/spf13/cobra"
+)
+
+func (s *MmctlUnitTestSuite) TestSchemeCreateCmd() {
+	newCmd := func(scope string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "restricted_teams", "")
+		cmd.Flags().String("display-name", "Restricted teams", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().String("scope", scope, "")
+		return cmd
+	}
+
+	s.Run("Create a scheme", func() {
+		printer.Clean()
+
+		mockScheme := &model.Scheme{Id: model.NewId(), Name: "restricted_teams", DisplayName: "Restricted teams", Scope: model.SchemeScopeTeam}
+
+		s.client.
+			EXPECT().
+			CreateScheme(context.TODO(), &model.Scheme{Name: "restricted_teams", DisplayName: "Restricted teams", Scope: model.SchemeScopeTeam}).
+			Return(mockScheme, &model.Response{StatusCode: http.StatusCreated}, nil).
+			Times(1)
+
+		err := schemeCreateCmdF(s.client, newCmd(model.SchemeScopeTeam), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(mockScheme, printer.GetLines()[0])
+	})
+
+	s.Run("Create a scheme with an invalid scope", func() {
+		printer.Clean()
+
+		err := schemeCreateCmdF(s.client, newCmd("playbook"), []string{})
+		s.Require().EqualError(err, `invalid scope "playbook", must be team or channel`)
+		s.Require().Empty(printer.GetLines())
+	})
+
+	s.Run("Create a scheme failing", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			CreateScheme(context.TODO(), gomock.Any()).
+			Return(nil, &model.Response{StatusCode: http.StatusNotImplemented}, errors.New("license error")).
+			Times(1)
+
+		err := schemeCreateCmdF(s.client, newCmd(model.SchemeScopeChannel), []string{})
+		s.Require().EqualError(err, `unable to create scheme "restricted_teams": license error`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeListCmd() {
+	s.Run("List the schemes of a scope over several pages", func() {
+		printer.Clean()
+
+		page := make([]*model.Scheme, DefaultPageSize)
+		for i := range page {
+			page[i] = &model.Scheme{Id: model.NewId(), Name: model.NewId(), Scope: model.SchemeScopeChannel}
+		}
+
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), model.SchemeScopeChannel, 0, DefaultPageSize).
+			Return(page, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), model.SchemeScopeChannel, 1, DefaultPageSize).
+			Return(page[:1], &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), model.SchemeScopeChannel, 2, DefaultPageSize).
+			Return([]*model.Scheme{}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("scope", model.SchemeScopeChannel, "")
+
+		err := schemeListCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), DefaultPageSize+1)
+		s.Require().Equal(page[0], printer.GetLines()[0])
+	})
+
+	s.Run("List the schemes of an invalid scope", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("scope", "system", "")
+
+		err := schemeListCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, `invalid scope "system", must be team or channel`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeShowCmd() {
+	mockScheme := &model.Scheme{Id: model.NewId(), Name: "restricted_teams", Scope: model.SchemeScopeTeam}
+
+	s.Run("Show a scheme by ID", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), mockScheme.Id).
+			Return(mockScheme, &model.Response{}, nil).
+			Times(1)
+
+		err := schemeShowCmdF(s.client, &cobra.Command{}, []string{mockScheme.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(mockScheme, printer.GetLines()[0])
+	})
+
+	s.Run("Show a scheme by name", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), "", 0, DefaultPageSize).
+			Return([]*model.Scheme{{Id: model.NewId(), Name: "other"}, mockScheme}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), "", 1, DefaultPageSize).
+			Return([]*model.Scheme{}, &model.Response{}, nil).
+			Times(1)
+
+		err := schemeShowCmdF(s.client, &cobra.Command{}, []string{mockScheme.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(mockScheme, printer.GetLines()[0])
+	})
+
+	s.Run("Show a scheme by a name looking like an ID", func() {
+		printer.Clean()
+
+		name := model.NewId()
+		scheme := &model.Scheme{Id: model.NewId(), Name: name}
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), name).
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), "", 0, DefaultPageSize).
+			Return([]*model.Scheme{scheme}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), "", 1, DefaultPageSize).
+			Return([]*model.Scheme{}, &model.Response{}, nil).
+			Times(1)
+
+		err := schemeShowCmdF(s.client, &cobra.Command{}, []string{name})
+		s.Require().NoError(err)
+		s.Require().Equal(scheme, printer.GetLines()[0])
+	})
+
+	s.Run("Show a scheme that doesn't exist", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), "", 0, DefaultPageSize).
+			Return([]*model.Scheme{mockScheme}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSchemes(context.TODO(), "", 1, DefaultPageSize).
+			Return([]*model.Scheme{}, &model.Response{}, nil).
+			Times(1)
+
+		err := schemeShowCmdF(s.client, &cobra.Command{}, []string{"missing"})
+		s.Require().EqualError(err, "scheme missing not found")
+		s.Require().Empty(printer.GetLines())
+	})
+
+	s.Run("Show a scheme failing with another error than not found", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), mockScheme.Id).
+			Return(nil, &model.Response{StatusCode: http.StatusForbidden}, errors.New("forbidden")).
+			Times(1)
+
+		err := schemeShowCmdF(s.client, &cobra.Command{}, []string{mockScheme.Id})
+		s.Require().EqualError(err, "forbidden")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeModifyCmd() {
+	mockScheme := &model.Scheme{Id: model.NewId(), Name: "restricted_teams", Scope: model.SchemeScopeTeam}
+
+	s.Run("Modify the display name and the description of a scheme", func() {
+		printer.Clean()
+
+		patchedScheme := &model.Scheme{Id: mockScheme.Id, Name: mockScheme.Name, DisplayName: "Locked down", Scope: model.SchemeScopeTeam}
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), mockScheme.Id).
+			Return(mockScheme, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			PatchScheme(context.TODO(), mockScheme.Id, &model.SchemePatch{DisplayName: model.NewPointer("Locked down"), Description: model.NewPointer("")}).
+			Return(patchedScheme, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "", "")
+		cmd.Flags().String("display-name", "", "")
+		cmd.Flags().String("description", "", "")
+		s.Require().NoError(cmd.Flags().Set("display-name", "Locked down"))
+		s.Require().NoError(cmd.Flags().Set("description", ""))
+
+		err := schemeModifyCmdF(s.client, cmd, []string{mockScheme.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(patchedScheme, printer.GetLines()[0])
+	})
+
+	s.Run("Modify a scheme without changes", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), mockScheme.Id).
+			Return(mockScheme, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "", "")
+		cmd.Flags().String("display-name", "", "")
+		cmd.Flags().String("description", "", "")
+
+		err := schemeModifyCmdF(s.client, cmd, []string{mockScheme.Id})
+		s.Require().EqualError(err, "at least one of --name, --display-name or --description must be specified")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeDeleteCmd() {
+	s.Run("Delete schemes", func() {
+		printer.Clean()
+
+		scheme1 := &model.Scheme{Id: model.NewId(), Name: "scheme1"}
+		scheme2 := &model.Scheme{Id: model.NewId(), Name: "scheme2"}
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), scheme1.Id).
+			Return(scheme1, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), scheme2.Id).
+			Return(scheme2, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteScheme(context.TODO(), scheme1.Id).
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteScheme(context.TODO(), scheme2.Id).
+			Return(&model.Response{StatusCode: http.StatusInternalServerError}, errors.New("mock error")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("confirm", true, "")
+
+		err := schemeDeleteCmdF(s.client, cmd, []string{scheme1.Id, scheme2.Id})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(scheme1, printer.GetLines()[0])
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Equal(`unable to delete scheme "`+scheme2.Id+`": mock error`, printer.GetErrorLines()[0])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeAssignTeamCmd() {
+	teamScheme := &model.Scheme{Id: model.NewId(), Name: "team_scheme", Scope: model.SchemeScopeTeam}
+	channelScheme := &model.Scheme{Id: model.NewId(), Name: "channel_scheme", Scope: model.SchemeScopeChannel}
+
+	s.Run("Assign a team scheme to teams", func() {
+		printer.Clean()
+
+		team := &model.Team{Id: model.NewId(), Name: "myteam"}
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), teamScheme.Id).
+			Return(teamScheme, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), team.Id, "").
+			Return(team, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), "missing", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "missing", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateTeamScheme(context.TODO(), team.Id, teamScheme.Id).
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := schemeAssignTeamCmdF(s.client, &cobra.Command{}, []string{teamScheme.Id, team.Id, "missing"})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(team, printer.GetLines()[0])
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Equal(`unable to find team "missing"`, printer.GetErrorLines()[0])
+	})
+
+	s.Run("Assign a channel scheme to a team", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), channelScheme.Id).
+			Return(channelScheme, &model.Response{}, nil).
+			Times(1)
+
+		err := schemeAssignTeamCmdF(s.client, &cobra.Command{}, []string{channelScheme.Id, "myteam"})
+		s.Require().EqualError(err, `scheme "`+channelScheme.Id+`" is a channel scheme, only team schemes can be assigned to teams`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeAssignChannelCmd() {
+	channelScheme := &model.Scheme{Id: model.NewId(), Name: "channel_scheme", Scope: model.SchemeScopeChannel}
+
+	s.Run("Assign a channel scheme to a channel", func() {
+		printer.Clean()
+
+		channel := &model.Channel{Id: model.NewId(), Name: "mychannel"}
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), channelScheme.Id).
+			Return(channelScheme, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id).
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateChannelScheme(context.TODO(), channel.Id, channelScheme.Id).
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := schemeAssignChannelCmdF(s.client, &cobra.Command{}, []string{channelScheme.Id, channel.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(channel, printer.GetLines()[0])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestSchemeRolesCmd() {
+	s.Run("List the roles of a team scheme in order", func() {
+		printer.Clean()
+
+		scheme := &model.Scheme{
+			Id:                      model.NewId(),
+			Scope:                   model.SchemeScopeTeam,
+			DefaultTeamAdminRole:    "team_admin_role",
+			DefaultTeamUserRole:     "team_user_role",
+			DefaultTeamGuestRole:    "team_guest_role",
+			DefaultChannelAdminRole: "channel_admin_role",
+			DefaultChannelUserRole:  "channel_user_role",
+			DefaultChannelGuestRole: "channel_guest_role",
+		}
+		roleNames := []string{"team_admin_role", "team_user_role", "team_guest_role", "channel_admin_role", "channel_user_role", "channel_guest_role"}
+
+		var roles []*model.Role
+		for i := len(roleNames) - 1; i >= 0; i-- {
+			roles = append(roles, &model.Role{Name: roleNames[i]})
+		}
+
+		s.client.
+			EXPECT().
+			GetScheme(context.TODO(), scheme.Id).
+			Return(scheme, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetRolesByNames(context.TODO(), roleNames).
+			Return(roles, &model.Response{}, nil).
+			Times(1)
+
+		err := schemeRolesCmdF(s.client, &cobra.Command{}, []string{scheme.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), len(roleNames))
+		for i, name := range roleNames {
+			s.Require().Equal(name, printer.GetLines()[i].(*model.Role).Name)
+		}
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/mattermost/mattermost/server/public/model"
 
@@ -113,10 +114,23 @@ func cpaValueSetCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 
 	// Prepare the value for marshaling
 	var valueToMarshal any
-	if field.Type == model.PropertyFieldTypeMultiselect || field.Type == model.PropertyFieldTypeMultiuser {
+	switch field.Type {
+	case model.PropertyFieldTypeMultiselect, model.PropertyFieldTypeMultiuser:
 		// Multiple values
 		valueToMarshal = resolvedValues
-	} else {
+	case model.PropertyFieldTypeNumber:
+		number, pErr := strconv.ParseFloat(resolvedValues[0], 64)
+		if pErr != nil {
+			return fmt.Errorf("invalid number value %q: %w", resolvedValues[0], pErr)
+		}
+		valueToMarshal = number
+	case model.PropertyFieldTypeCheckbox:
+		checked, pErr := strconv.ParseBool(resolvedValues[0])
+		if pErr != nil {
+			return fmt.Errorf("invalid checkbox value %q: %w", resolvedValues[0], pErr)
+		}
+		valueToMarshal = checked
+	default:
 		// Single value
 		valueToMarshal = resolvedValues[0]
 	}
@@ -435,6 +435,93 @@ func (s *MmctlUnitTestSuite) TestCPAValueSetCmd() {
 		s.Require().NoError(err)
 	})
 
+	s.Run("Should send number and checkbox values as JSON numbers and booleans", func() {
+		for fieldType, tc := range map[model.PropertyFieldType]struct {
+			input    string
+			expected string
+		}{
+			model.PropertyFieldTypeNumber:   {input: "42.5", expected: `42.5`},
+			model.PropertyFieldTypeCheckbox: {input: "true", expected: `true`},
+		} {
+			printer.Clean()
+			printer.SetFormat(printer.FormatPlain)
+
+			mockUser := &model.User{
+				Id:       "user123",
+				Username: "testuser",
+			}
+
+			fieldID := model.NewId()
+			mockFields := []*model.PropertyField{
+				{
+					ID:   fieldID,
+					Name: "Field",
+					Type: fieldType,
+				},
+			}
+
+			cmd := &cobra.Command{}
+			cmd.Flags().StringSlice("value", []string{tc.input}, "")
+
+			s.client.
+				EXPECT().
+				GetUserByEmail(context.TODO(), "testuser@example.com", "").
+				Return(mockUser, &model.Response{}, nil).
+				Times(1)
+
+			s.client.
+				EXPECT().
+				ListCPAFields(context.TODO()).
+				Return(mockFields, &model.Response{}, nil).
+				Times(2)
+
+			s.client.
+				EXPECT().
+				PatchCPAValuesForUser(context.TODO(), "user123", map[string]json.RawMessage{fieldID: json.RawMessage(tc.expected)}).
+				Return(map[string]json.RawMessage{fieldID: json.RawMessage(tc.expected)}, &model.Response{}, nil).
+				Times(1)
+
+			err := cpaValueSetCmdF(s.client, cmd, []string{"testuser@example.com", fieldID})
+			s.Require().NoError(err)
+		}
+	})
+
+	s.Run("Should reject a number value that does not parse", func() {
+		printer.Clean()
+
+		mockUser := &model.User{
+			Id:       "user123",
+			Username: "testuser",
+		}
+
+		fieldID := model.NewId()
+		mockFields := []*model.PropertyField{
+			{
+				ID:   fieldID,
+				Name: "Age",
+				Type: model.PropertyFieldTypeNumber,
+			},
+		}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("value", []string{"forty"}, "")
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), "testuser@example.com", "").
+			Return(mockUser, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			ListCPAFields(context.TODO()).
+			Return(mockFields, &model.Response{}, nil).
+			Times(2)
+
+		err := cpaValueSetCmdF(s.client, cmd, []string{"testuser@example.com", fieldID})
+		s.Require().ErrorContains(err, "invalid number value")
+	})
+
 	s.Run("Should handle field not found error", func() {
 		printer.Clean()
 
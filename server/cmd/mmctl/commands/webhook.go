@@ -86,6 +86,15 @@ var DeleteWebhookCmd = &cobra.Command{
 	RunE:    withClient(deleteWebhookCmdF),
 }
 
+var ListOutgoingWebhookDeliveriesCmd = &cobra.Command{
+	Use:     "deliveries [webhookId]",
+	Short:   "List outgoing webhook deliveries",
+	Long:    "List the most recent deliveries of the outgoing webhook specified by [webhookId], with their status code, latency and number of attempts",
+	Args:    cobra.ExactArgs(1),
+	Example: "  webhook deliveries w16zb5tu3n1zkqo18goqry1je --page 0 --per-page 20",
+	RunE:    withClient(listOutgoingWebhookDeliveriesCmdF),
+}
+
 func listWebhookCmdF(c client.Client, command *cobra.Command, args []string) error {
 	var teams []*model.Team
 
@@ -426,6 +435,34 @@ func showWebhookCmdF(c client.Client, command *cobra.Command, args []string) err
 	return errors.New("Webhook with id '" + webhookID + "' not found")
 }
 
+func listOutgoingWebhookDeliveriesCmdF(c client.Client, command *cobra.Command, args []string) error {
+	page, err := command.Flags().GetInt("page")
+	if err != nil {
+		return err
+	}
+	perPage, err := command.Flags().GetInt("per-page")
+	if err != nil {
+		return err
+	}
+
+	webhookID := args[0]
+	deliveries, _, err := c.GetOutgoingWebhookDeliveries(context.TODO(), webhookID, page, perPage)
+	if err != nil {
+		return errors.Wrap(err, "unable to list deliveries for outgoing webhook '"+webhookID+"'")
+	}
+
+	if len(deliveries) == 0 {
+		printer.Print("No deliveries found")
+		return nil
+	}
+
+	for _, delivery := range deliveries {
+		printer.PrintT("{{.Id}}\t{{.Status}}\t{{.StatusCode}}\t{{.LatencyMs}}ms\t{{.Attempts}} attempt(s)\t{{.CallbackURL}}", delivery)
+	}
+
+	return nil
+}
+
 func init() {
 	CreateIncomingWebhookCmd.Flags().String("channel", "", "Channel ID (required)")
 	_ = CreateIncomingWebhookCmd.MarkFlagRequired("channel")
@@ -467,6 +504,9 @@ func init() {
 	ModifyOutgoingWebhookCmd.Flags().StringArray("url", []string{}, "Callback URL")
 	ModifyOutgoingWebhookCmd.Flags().String("content-type", "", "Content-type")
 
+	ListOutgoingWebhookDeliveriesCmd.Flags().Int("page", 0, "Page number to fetch for the list of deliveries")
+	ListOutgoingWebhookDeliveriesCmd.Flags().Int("per-page", DefaultPageSize, "Number of deliveries to be fetched")
+
 	WebhookCmd.AddCommand(
 		ListWebhookCmd,
 		CreateIncomingWebhookCmd,
@@ -475,6 +515,7 @@ func init() {
 		ModifyOutgoingWebhookCmd,
 		DeleteWebhookCmd,
 		ShowWebhookCmd,
+		ListOutgoingWebhookDeliveriesCmd,
 	)
 
 	RootCmd.AddCommand(WebhookCmd)
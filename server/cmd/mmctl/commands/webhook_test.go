@@ -788,3 +788,73 @@ func (s *MmctlUnitTestSuite) TestShowWebhookCmd() {
 		s.Require().Equal("Webhook with id '"+nonExistentID+"' not found", err.Error())
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestListOutgoingWebhookDeliveriesCmd() {
+	outgoingWebhookID := "outgoingWebhookID"
+
+	s.Run("Successfully list outgoing webhook deliveries", func() {
+		printer.Clean()
+
+		mockDeliveries := []*model.OutgoingWebhookDelivery{
+			{Id: "delivery1", HookId: outgoingWebhookID, Status: model.OutgoingWebhookDeliveryStatusSuccess, StatusCode: 200, Attempts: 1},
+			{Id: "delivery2", HookId: outgoingWebhookID, Status: model.OutgoingWebhookDeliveryStatusRetrying, StatusCode: 503, Attempts: 2},
+		}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 1, "")
+		cmd.Flags().Int("per-page", 2, "")
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), outgoingWebhookID, 1, 2).
+			Return(mockDeliveries, &model.Response{}, nil).
+			Times(1)
+
+		err := listOutgoingWebhookDeliveriesCmdF(s.client, cmd, []string{outgoingWebhookID})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Len(printer.GetErrorLines(), 0)
+		s.Require().Equal(mockDeliveries[0], printer.GetLines()[0])
+		s.Require().Equal(mockDeliveries[1], printer.GetLines()[1])
+	})
+
+	s.Run("No deliveries found", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 200, "")
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), outgoingWebhookID, 0, 200).
+			Return([]*model.OutgoingWebhookDelivery{}, &model.Response{}, nil).
+			Times(1)
+
+		err := listOutgoingWebhookDeliveriesCmdF(s.client, cmd, []string{outgoingWebhookID})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("No deliveries found", printer.GetLines()[0])
+	})
+
+	s.Run("Error listing deliveries", func() {
+		printer.Clean()
+
+		mockError := errors.New("mock error")
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 200, "")
+
+		s.client.
+			EXPECT().
+			GetOutgoingWebhookDeliveries(context.TODO(), outgoingWebhookID, 0, 200).
+			Return(nil, &model.Response{}, mockError).
+			Times(1)
+
+		err := listOutgoingWebhookDeliveriesCmdF(s.client, cmd, []string{outgoingWebhookID})
+		s.Require().Error(err)
+		s.Len(printer.GetLines(), 0)
+		s.Require().Contains(err.Error(), mockError.Error())
+	})
+}
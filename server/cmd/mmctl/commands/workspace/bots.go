@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// planBots creates, enables and updates the bots of the manifest. The owner
+// of an existing bot is only changed when the manifest declares one.
+func (p *planner) planBots(ctx context.Context, bots []*BotManifest) error {
+	if len(bots) == 0 {
+		return nil
+	}
+
+	existing, err := getPages(func(page, perPage int) ([]*model.Bot, *model.Response, error) {
+		return p.c.GetBotsIncludeDeleted(ctx, page, perPage, "")
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get bots: %w", err)
+	}
+	byUsername := make(map[string]*model.Bot, len(existing))
+	for _, bot := range existing {
+		byUsername[bot.Username] = bot
+	}
+
+	for _, manifest := range bots {
+		ownerID, err := p.userID(ctx, manifest.Owner)
+		if err != nil {
+			return err
+		}
+
+		bot, ok := byUsername[manifest.Username]
+		if !ok {
+			p.add(&Change{
+				Action: ActionCreate,
+				Kind:   KindBot,
+				Name:   manifest.Username,
+				apply: func(ctx context.Context) error {
+					created, _, err := p.c.CreateBot(ctx, &model.Bot{
+						Username:    manifest.Username,
+						DisplayName: manifest.DisplayName,
+						Description: manifest.Description,
+					})
+					if err != nil {
+						return err
+					}
+					if ownerID != "" && ownerID != created.OwnerId {
+						if _, _, err := p.c.AssignBot(ctx, created.UserId, ownerID); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			})
+			continue
+		}
+
+		var fields fieldChanges
+		fields.bool("disabled", bot.DeleteAt != 0, false)
+		fields.string("display_name", bot.DisplayName, manifest.DisplayName)
+		fields.string("description", bot.Description, manifest.Description)
+		changeOwner := ownerID != "" && ownerID != bot.OwnerId
+		if changeOwner {
+			owner := bot.OwnerId
+			if user, _, err := p.c.GetUser(ctx, bot.OwnerId, ""); err == nil {
+				owner = user.Username
+			}
+			fields.string("owner", owner, manifest.Owner)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		p.add(&Change{
+			Action: ActionUpdate,
+			Kind:   KindBot,
+			Name:   manifest.Username,
+			Fields: fields,
+			apply: func(ctx context.Context) error {
+				if bot.DeleteAt != 0 {
+					if _, _, err := p.c.EnableBot(ctx, bot.UserId); err != nil {
+						return err
+					}
+				}
+				if bot.DisplayName != manifest.DisplayName || bot.Description != manifest.Description {
+					patch := &model.BotPatch{DisplayName: &manifest.DisplayName, Description: &manifest.Description}
+					if _, _, err := p.c.PatchBot(ctx, bot.UserId, patch); err != nil {
+						return err
+					}
+				}
+				if changeOwner {
+					if _, _, err := p.c.AssignBot(ctx, bot.UserId, ownerID); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		})
+	}
+	return nil
+}
@@ -0,0 +1,356 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// planIntegrations plans the webhooks and slash commands of a team. It runs
+// after the teams and channels are planned, as the integrations reference
+// them.
+func (p *planner) planIntegrations(ctx context.Context, manifest *TeamManifest) error {
+	// A team that doesn't exist yet has no integrations.
+	team := p.teams[manifest.Name]
+
+	if err := p.planIncomingWebhooks(ctx, team, manifest); err != nil {
+		return err
+	}
+	if err := p.planOutgoingWebhooks(ctx, team, manifest); err != nil {
+		return err
+	}
+	return p.planCommands(ctx, team, manifest)
+}
+
+// channelID returns the ID of a channel of a team of the manifest, which may
+// have been created by an earlier change of the plan.
+func (p *planner) channelID(ctx context.Context, teamName, channelName string) (string, error) {
+	if channel, ok := p.channels[channelKey(teamName, channelName)]; ok {
+		return channel.Id, nil
+	}
+
+	// The default channels of the teams created by the plan aren't known.
+	team, ok := p.teams[teamName]
+	if !ok {
+		return "", fmt.Errorf("team %s not found", teamName)
+	}
+	channel, _, err := p.c.GetChannelByName(ctx, channelName, team.Id, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get channel %s: %w", channelKey(teamName, channelName), err)
+	}
+	p.channels[channelKey(teamName, channelName)] = channel
+	return channel.Id, nil
+}
+
+// channelName returns the name of the channel of a team with the given ID.
+func (p *planner) channelName(teamName, channelID string) string {
+	for key, channel := range p.channels {
+		if channel.Id == channelID && key == channelKey(teamName, channel.Name) {
+			return channel.Name
+		}
+	}
+	return channelID
+}
+
+func (p *planner) planIncomingWebhooks(ctx context.Context, team *model.Team, manifest *TeamManifest) error {
+	existing := map[string]*model.IncomingWebhook{}
+	if team != nil {
+		hooks, err := getPages(func(page, perPage int) ([]*model.IncomingWebhook, *model.Response, error) {
+			return p.c.GetIncomingWebhooksForTeam(ctx, team.Id, page, perPage, "")
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get the incoming webhooks of team %s: %w", manifest.Name, err)
+		}
+		for _, hook := range hooks {
+			existing[p.channelName(manifest.Name, hook.ChannelId)+"/"+hook.DisplayName] = hook
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, hookManifest := range manifest.IncomingWebhooks {
+		key := hookManifest.Channel + "/" + hookManifest.DisplayName
+		declared[key] = true
+		name := channelKey(manifest.Name, hookManifest.Channel) + "/" + hookManifest.DisplayName
+
+		hook, ok := existing[key]
+		if !ok {
+			ownerID, err := p.userID(ctx, hookManifest.Owner)
+			if err != nil {
+				return err
+			}
+			p.add(&Change{
+				Action: ActionCreate,
+				Kind:   KindIncomingWebhook,
+				Name:   name,
+				apply: func(ctx context.Context) error {
+					channelID, err := p.channelID(ctx, manifest.Name, hookManifest.Channel)
+					if err != nil {
+						return err
+					}
+					_, _, err = p.c.CreateIncomingWebhook(ctx, &model.IncomingWebhook{
+						UserId:        ownerID,
+						ChannelId:     channelID,
+						DisplayName:   hookManifest.DisplayName,
+						Description:   hookManifest.Description,
+						Username:      hookManifest.Username,
+						IconURL:       hookManifest.IconURL,
+						ChannelLocked: hookManifest.LockToChannel,
+					})
+					return err
+				},
+			})
+			continue
+		}
+
+		var fields fieldChanges
+		fields.string("description", hook.Description, hookManifest.Description)
+		fields.string("username", hook.Username, hookManifest.Username)
+		fields.string("icon_url", hook.IconURL, hookManifest.IconURL)
+		fields.bool("lock_to_channel", hook.ChannelLocked, hookManifest.LockToChannel)
+		if len(fields) == 0 {
+			continue
+		}
+
+		p.add(&Change{
+			Action: ActionUpdate,
+			Kind:   KindIncomingWebhook,
+			Name:   name,
+			Fields: fields,
+			apply: func(ctx context.Context) error {
+				updated := *hook
+				updated.Description = hookManifest.Description
+				updated.Username = hookManifest.Username
+				updated.IconURL = hookManifest.IconURL
+				updated.ChannelLocked = hookManifest.LockToChannel
+				_, _, err := p.c.UpdateIncomingWebhook(ctx, &updated)
+				return err
+			},
+		})
+	}
+
+	if !p.options.Prune {
+		return nil
+	}
+	for _, key := range slices.Sorted(maps.Keys(existing)) {
+		hook := existing[key]
+		if declared[key] {
+			continue
+		}
+		p.add(&Change{
+			Action: ActionDelete,
+			Kind:   KindIncomingWebhook,
+			Name:   channelKey(manifest.Name, p.channelName(manifest.Name, hook.ChannelId)) + "/" + hook.DisplayName,
+			apply: func(ctx context.Context) error {
+				_, err := p.c.DeleteIncomingWebhook(ctx, hook.Id)
+				return err
+			},
+		})
+	}
+	return nil
+}
+
+func (p *planner) planOutgoingWebhooks(ctx context.Context, team *model.Team, manifest *TeamManifest) error {
+	existing := map[string]*model.OutgoingWebhook{}
+	if team != nil {
+		hooks, err := getPages(func(page, perPage int) ([]*model.OutgoingWebhook, *model.Response, error) {
+			return p.c.GetOutgoingWebhooksForTeam(ctx, team.Id, page, perPage, "")
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get the outgoing webhooks of team %s: %w", manifest.Name, err)
+		}
+		for _, hook := range hooks {
+			existing[hook.DisplayName] = hook
+		}
+	}
+
+	for _, hookManifest := range manifest.OutgoingWebhooks {
+		name := manifest.Name + "/" + hookManifest.DisplayName
+		hook, ok := existing[hookManifest.DisplayName]
+		if !ok {
+			ownerID, err := p.userID(ctx, hookManifest.Owner)
+			if err != nil {
+				return err
+			}
+			p.add(&Change{
+				Action: ActionCreate,
+				Kind:   KindOutgoingWebhook,
+				Name:   name,
+				apply: func(ctx context.Context) error {
+					hook := &model.OutgoingWebhook{CreatorId: ownerID, TeamId: p.teams[manifest.Name].Id}
+					if err := p.setOutgoingWebhook(ctx, hook, manifest.Name, hookManifest); err != nil {
+						return err
+					}
+					_, _, err := p.c.CreateOutgoingWebhook(ctx, hook)
+					return err
+				},
+			})
+			continue
+		}
+
+		var channel string
+		if hook.ChannelId != "" {
+			channel = p.channelName(manifest.Name, hook.ChannelId)
+		}
+		var fields fieldChanges
+		fields.string("channel", channel, hookManifest.Channel)
+		fields.string("description", hook.Description, hookManifest.Description)
+		fields.strings("trigger_words", hook.TriggerWords, hookManifest.TriggerWords)
+		fields.string("trigger_when", strconv.Itoa(hook.TriggerWhen), strconv.Itoa(hookManifest.triggerWhen()))
+		fields.strings("callback_urls", hook.CallbackURLs, hookManifest.CallbackURLs)
+		fields.string("content_type", hook.ContentType, hookManifest.ContentType)
+		fields.string("username", hook.Username, hookManifest.Username)
+		fields.string("icon_url", hook.IconURL, hookManifest.IconURL)
+		if len(fields) == 0 {
+			continue
+		}
+
+		p.add(&Change{
+			Action: ActionUpdate,
+			Kind:   KindOutgoingWebhook,
+			Name:   name,
+			Fields: fields,
+			apply: func(ctx context.Context) error {
+				updated := *hook
+				if err := p.setOutgoingWebhook(ctx, &updated, manifest.Name, hookManifest); err != nil {
+					return err
+				}
+				_, _, err := p.c.UpdateOutgoingWebhook(ctx, &updated)
+				return err
+			},
+		})
+	}
+
+	if !p.options.Prune {
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(existing)) {
+		hook := existing[name]
+		if slices.ContainsFunc(manifest.OutgoingWebhooks, func(h *OutgoingWebhookManifest) bool { return h.DisplayName == hook.DisplayName }) {
+			continue
+		}
+		p.add(&Change{
+			Action: ActionDelete,
+			Kind:   KindOutgoingWebhook,
+			Name:   manifest.Name + "/" + hook.DisplayName,
+			apply: func(ctx context.Context) error {
+				_, err := p.c.DeleteOutgoingWebhook(ctx, hook.Id)
+				return err
+			},
+		})
+	}
+	return nil
+}
+
+func (p *planner) setOutgoingWebhook(ctx context.Context, hook *model.OutgoingWebhook, teamName string, manifest *OutgoingWebhookManifest) error {
+	hook.ChannelId = ""
+	if manifest.Channel != "" {
+		channelID, err := p.channelID(ctx, teamName, manifest.Channel)
+		if err != nil {
+			return err
+		}
+		hook.ChannelId = channelID
+	}
+	hook.DisplayName = manifest.DisplayName
+	hook.Description = manifest.Description
+	hook.TriggerWords = manifest.TriggerWords
+	hook.TriggerWhen = manifest.triggerWhen()
+	hook.CallbackURLs = manifest.CallbackURLs
+	hook.ContentType = manifest.ContentType
+	hook.Username = manifest.Username
+	hook.IconURL = manifest.IconURL
+	return nil
+}
+
+func (p *planner) planCommands(ctx context.Context, team *model.Team, manifest *TeamManifest) error {
+	existing := map[string]*model.Command{}
+	if team != nil {
+		commands, _, err := p.c.ListCommands(ctx, team.Id, true)
+		if err != nil {
+			return fmt.Errorf("failed to get the commands of team %s: %w", manifest.Name, err)
+		}
+		for _, command := range commands {
+			// Plugin commands are managed by their plugin.
+			if command.PluginId != "" {
+				continue
+			}
+			existing[command.Trigger] = command
+		}
+	}
+
+	for _, commandManifest := range manifest.Commands {
+		name := manifest.Name + ":/" + commandManifest.Trigger
+		command, ok := existing[commandManifest.Trigger]
+		if !ok {
+			ownerID, err := p.userID(ctx, commandManifest.Owner)
+			if err != nil {
+				return err
+			}
+			p.add(&Change{
+				Action: ActionCreate,
+				Kind:   KindCommand,
+				Name:   name,
+				apply: func(ctx context.Context) error {
+					command := &model.Command{CreatorId: ownerID, TeamId: p.teams[manifest.Name].Id}
+					commandManifest.set(command)
+					_, _, err := p.c.CreateCommand(ctx, command)
+					return err
+				},
+			})
+			continue
+		}
+
+		var fields fieldChanges
+		fields.string("url", command.URL, commandManifest.URL)
+		fields.string("method", command.Method, commandManifest.method())
+		fields.string("display_name", command.DisplayName, commandManifest.DisplayName)
+		fields.string("description", command.Description, commandManifest.Description)
+		fields.string("username", command.Username, commandManifest.Username)
+		fields.string("icon_url", command.IconURL, commandManifest.IconURL)
+		fields.bool("autocomplete", command.AutoComplete, commandManifest.AutoComplete)
+		fields.string("autocomplete_description", command.AutoCompleteDesc, commandManifest.AutoCompleteDesc)
+		fields.string("autocomplete_hint", command.AutoCompleteHint, commandManifest.AutoCompleteHint)
+		if len(fields) == 0 {
+			continue
+		}
+
+		p.add(&Change{
+			Action: ActionUpdate,
+			Kind:   KindCommand,
+			Name:   name,
+			Fields: fields,
+			apply: func(ctx context.Context) error {
+				updated := *command
+				commandManifest.set(&updated)
+				_, _, err := p.c.UpdateCommand(ctx, &updated)
+				return err
+			},
+		})
+	}
+
+	if !p.options.Prune {
+		return nil
+	}
+	for _, trigger := range slices.Sorted(maps.Keys(existing)) {
+		command := existing[trigger]
+		if slices.ContainsFunc(manifest.Commands, func(c *CommandManifest) bool { return c.Trigger == trigger }) {
+			continue
+		}
+		p.add(&Change{
+			Action: ActionDelete,
+			Kind:   KindCommand,
+			Name:   manifest.Name + ":/" + trigger,
+			apply: func(ctx context.Context) error {
+				_, err := p.c.DeleteCommand(ctx, command.Id)
+				return err
+			},
+		})
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package workspace converges the teams, channels, roles, bots, webhooks and
+// slash commands of a server to the state declared in a YAML manifest.
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// Manifest is the declared state of a workspace.
+type Manifest struct {
+	Teams []*TeamManifest `yaml:"teams"`
+	Roles []*RoleManifest `yaml:"roles"`
+	Bots  []*BotManifest  `yaml:"bots"`
+}
+
+type TeamManifest struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"display_name"`
+	Description string `yaml:"description"`
+	// Private teams can only be joined by invitation.
+	Private bool `yaml:"private"`
+
+	Channels         []*ChannelManifest         `yaml:"channels"`
+	IncomingWebhooks []*IncomingWebhookManifest `yaml:"incoming_webhooks"`
+	OutgoingWebhooks []*OutgoingWebhookManifest `yaml:"outgoing_webhooks"`
+	Commands         []*CommandManifest         `yaml:"commands"`
+}
+
+type ChannelManifest struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"display_name"`
+	Private     bool   `yaml:"private"`
+	Purpose     string `yaml:"purpose"`
+	Header      string `yaml:"header"`
+}
+
+// RoleManifest declares the complete list of permissions of an existing role. An
+// empty list has to be given explicitly to remove all of them.
+type RoleManifest struct {
+	Name        string   `yaml:"name"`
+	Permissions []string `yaml:"permissions"`
+}
+
+type BotManifest struct {
+	Username    string `yaml:"username"`
+	DisplayName string `yaml:"display_name"`
+	Description string `yaml:"description"`
+	// Owner is the username of the owner of the bot, defaulting to the user
+	// applying the manifest.
+	Owner string `yaml:"owner"`
+}
+
+// IncomingWebhookManifest declares an incoming webhook, identified by its
+// channel and display name.
+type IncomingWebhookManifest struct {
+	DisplayName   string `yaml:"display_name"`
+	Channel       string `yaml:"channel"`
+	Description   string `yaml:"description"`
+	Username      string `yaml:"username"`
+	IconURL       string `yaml:"icon_url"`
+	LockToChannel bool   `yaml:"lock_to_channel"`
+	// Owner is the username of the user the webhook posts as, defaulting to
+	// the user applying the manifest. It is required in local mode.
+	Owner string `yaml:"owner"`
+}
+
+// OutgoingWebhookManifest declares an outgoing webhook, identified by its
+// display name.
+type OutgoingWebhookManifest struct {
+	DisplayName  string   `yaml:"display_name"`
+	Channel      string   `yaml:"channel"`
+	Description  string   `yaml:"description"`
+	TriggerWords []string `yaml:"trigger_words"`
+	// TriggerWhen is exact, the default, or start.
+	TriggerWhen  string   `yaml:"trigger_when"`
+	CallbackURLs []string `yaml:"callback_urls"`
+	ContentType  string   `yaml:"content_type"`
+	Username     string   `yaml:"username"`
+	IconURL      string   `yaml:"icon_url"`
+	// Owner is the username of the creator of the webhook, defaulting to the
+	// user applying the manifest. It is required in local mode.
+	Owner string `yaml:"owner"`
+}
+
+// CommandManifest declares a custom slash command, identified by its trigger.
+type CommandManifest struct {
+	// Trigger is in lowercase, as the server stores it.
+	Trigger string `yaml:"trigger"`
+	URL     string `yaml:"url"`
+	// Method is post, the default, or get.
+	Method           string `yaml:"method"`
+	DisplayName      string `yaml:"display_name"`
+	Description      string `yaml:"description"`
+	Username         string `yaml:"username"`
+	IconURL          string `yaml:"icon_url"`
+	AutoComplete     bool   `yaml:"autocomplete"`
+	AutoCompleteDesc string `yaml:"autocomplete_description"`
+	AutoCompleteHint string `yaml:"autocomplete_hint"`
+	// Owner is the username of the creator of the command, defaulting to the
+	// user applying the manifest. It is required in local mode.
+	Owner string `yaml:"owner"`
+}
+
+const (
+	triggerWhenExact = "exact"
+	triggerWhenStart = "start"
+
+	commandMethodPost = "post"
+	commandMethodGet  = "get"
+)
+
+// LoadManifest reads and validates a manifest.
+func LoadManifest(r io.Reader) (*Manifest, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := yaml.UnmarshalWithOptions(data, &manifest, yaml.DisallowUnknownField()); err != nil {
+		return nil, fmt.Errorf("failed to parse the manifest: %w", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// Validate checks that the manifest declares every resource once, with the
+// fields required to create it.
+func (m *Manifest) Validate() error {
+	var errs []error
+
+	teams := map[string]bool{}
+	for _, team := range m.Teams {
+		switch {
+		case team.Name == "":
+			errs = append(errs, errors.New("a team has no name"))
+			continue
+		case teams[team.Name]:
+			errs = append(errs, fmt.Errorf("team %s is declared more than once", team.Name))
+		case team.DisplayName == "":
+			errs = append(errs, fmt.Errorf("team %s has no display name", team.Name))
+		}
+		teams[team.Name] = true
+
+		errs = append(errs, team.validate()...)
+	}
+
+	roles := map[string]bool{}
+	for _, role := range m.Roles {
+		switch {
+		case role.Name == "":
+			errs = append(errs, errors.New("a role has no name"))
+		case roles[role.Name]:
+			errs = append(errs, fmt.Errorf("role %s is declared more than once", role.Name))
+		case role.Permissions == nil:
+			// The permissions replace those of the role, so an omitted list would
+			// silently remove all of them.
+			errs = append(errs, fmt.Errorf("role %s has no permissions, use \"permissions: []\" to remove all of them", role.Name))
+		}
+		roles[role.Name] = true
+	}
+
+	bots := map[string]bool{}
+	for _, bot := range m.Bots {
+		switch {
+		case bot.Username == "":
+			errs = append(errs, errors.New("a bot has no username"))
+		case bots[bot.Username]:
+			errs = append(errs, fmt.Errorf("bot %s is declared more than once", bot.Username))
+		}
+		bots[bot.Username] = true
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *TeamManifest) validate() []error {
+	var errs []error
+
+	channels := map[string]bool{}
+	for _, channel := range t.Channels {
+		switch {
+		case channel.Name == "":
+			errs = append(errs, fmt.Errorf("a channel of team %s has no name", t.Name))
+			continue
+		case channels[channel.Name]:
+			errs = append(errs, fmt.Errorf("channel %s:%s is declared more than once", t.Name, channel.Name))
+		case channel.DisplayName == "":
+			errs = append(errs, fmt.Errorf("channel %s:%s has no display name", t.Name, channel.Name))
+		}
+		channels[channel.Name] = true
+	}
+
+	incomingWebhooks := map[string]bool{}
+	for _, hook := range t.IncomingWebhooks {
+		key := hook.Channel + "/" + hook.DisplayName
+		switch {
+		case hook.DisplayName == "" || hook.Channel == "":
+			errs = append(errs, fmt.Errorf("an incoming webhook of team %s has no display name or channel", t.Name))
+		case incomingWebhooks[key]:
+			errs = append(errs, fmt.Errorf("incoming webhook %s of channel %s:%s is declared more than once", hook.DisplayName, t.Name, hook.Channel))
+		}
+		incomingWebhooks[key] = true
+	}
+
+	outgoingWebhooks := map[string]bool{}
+	for _, hook := range t.OutgoingWebhooks {
+		switch {
+		case hook.DisplayName == "":
+			errs = append(errs, fmt.Errorf("an outgoing webhook of team %s has no display name", t.Name))
+		case outgoingWebhooks[hook.DisplayName]:
+			errs = append(errs, fmt.Errorf("outgoing webhook %s of team %s is declared more than once", hook.DisplayName, t.Name))
+		case len(hook.CallbackURLs) == 0:
+			errs = append(errs, fmt.Errorf("outgoing webhook %s of team %s has no callback URL", hook.DisplayName, t.Name))
+		case hook.TriggerWhen != "" && hook.TriggerWhen != triggerWhenExact && hook.TriggerWhen != triggerWhenStart:
+			errs = append(errs, fmt.Errorf("outgoing webhook %s of team %s has an invalid trigger_when %q, must be %s or %s", hook.DisplayName, t.Name, hook.TriggerWhen, triggerWhenExact, triggerWhenStart))
+		}
+		outgoingWebhooks[hook.DisplayName] = true
+	}
+
+	commands := map[string]bool{}
+	for _, command := range t.Commands {
+		switch {
+		case command.Trigger == "":
+			errs = append(errs, fmt.Errorf("a command of team %s has no trigger", t.Name))
+		case commands[command.Trigger]:
+			errs = append(errs, fmt.Errorf("command /%s of team %s is declared more than once", command.Trigger, t.Name))
+		case command.Trigger != strings.ToLower(command.Trigger):
+			// The server lowercases triggers, so an uppercase one would never match the existing command.
+			errs = append(errs, fmt.Errorf("command /%s of team %s must have a lowercase trigger", command.Trigger, t.Name))
+		case command.URL == "":
+			errs = append(errs, fmt.Errorf("command /%s of team %s has no URL", command.Trigger, t.Name))
+		case command.Method != "" && command.Method != commandMethodPost && command.Method != commandMethodGet:
+			errs = append(errs, fmt.Errorf("command /%s of team %s has an invalid method %q, must be %s or %s", command.Trigger, t.Name, command.Method, commandMethodPost, commandMethodGet))
+		}
+		commands[command.Trigger] = true
+	}
+
+	return errs
+}
+
+func (t *TeamManifest) teamType() string {
+	if t.Private {
+		return model.TeamInvite
+	}
+	return model.TeamOpen
+}
+
+func (c *ChannelManifest) channelType() model.ChannelType {
+	if c.Private {
+		return model.ChannelTypePrivate
+	}
+	return model.ChannelTypeOpen
+}
+
+func (h *OutgoingWebhookManifest) triggerWhen() int {
+	if h.TriggerWhen == triggerWhenStart {
+		return 1
+	}
+	return 0
+}
+
+func (c *CommandManifest) method() string {
+	if c.Method == commandMethodGet {
+		return model.CommandMethodGet
+	}
+	return model.CommandMethodPost
+}
+
+func (c *CommandManifest) set(command *model.Command) {
+	command.Trigger = c.Trigger
+	command.URL = c.URL
+	command.Method = c.method()
+	command.DisplayName = c.DisplayName
+	command.Description = c.Description
+	command.Username = c.Username
+	command.IconURL = c.IconURL
+	command.AutoComplete = c.AutoComplete
+	command.AutoCompleteDesc = c.AutoCompleteDesc
+	command.AutoCompleteHint = c.AutoCompleteHint
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package workspace
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadManifest(t *testing.T) {
+	t.Run("valid manifest", func(t *testing.T) {
+		manifest, err := LoadManifest(strings.NewReader(`
+teams:
+  - name: engineering
+    display_name: Engineering
+    private: true
+    channels:
+      - name: releases
+        display_name: Releases
+        purpose: Release coordination
+    incoming_webhooks:
+      - display_name: CI
+        channel: releases
+    outgoing_webhooks:
+      - display_name: Deploy
+        trigger_words: [deploy]
+        trigger_when: start
+        callback_urls: [https://deploy.example.com]
+    commands:
+      - trigger: oncall
+        url: https://oncall.example.com
+        method: get
+roles:
+  - name: system_user
+    permissions: [create_team]
+bots:
+  - username: releasebot
+    display_name: Release Bot
+`))
+		require.NoError(t, err)
+		require.Len(t, manifest.Teams, 1)
+		require.True(t, manifest.Teams[0].Private)
+		require.Equal(t, "Release coordination", manifest.Teams[0].Channels[0].Purpose)
+		require.Equal(t, 1, manifest.Teams[0].OutgoingWebhooks[0].triggerWhen())
+		require.Equal(t, []string{"create_team"}, manifest.Roles[0].Permissions)
+		require.Equal(t, "releasebot", manifest.Bots[0].Username)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := LoadManifest(strings.NewReader(`
+teams:
+  - name: engineering
+    display_name: Engineering
+    visibility: private
+`))
+		require.ErrorContains(t, err, "failed to parse the manifest")
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		_, err := LoadManifest(strings.NewReader(`
+teams:
+  - name: engineering
+    channels:
+      - name: releases
+        display_name: Releases
+      - name: releases
+        display_name: Releases
+    commands:
+      - trigger: oncall
+        url: https://oncall.example.com
+        method: put
+      - trigger: Deploy
+        url: https://deploy.example.com
+bots:
+  - display_name: Release Bot
+`))
+		require.ErrorContains(t, err, "team engineering has no display name")
+		require.ErrorContains(t, err, "channel engineering:releases is declared more than once")
+		require.ErrorContains(t, err, `command /oncall of team engineering has an invalid method "put"`)
+		require.ErrorContains(t, err, "command /Deploy of team engineering must have a lowercase trigger")
+		require.ErrorContains(t, err, "a bot has no username")
+	})
+
+	t.Run("role permissions", func(t *testing.T) {
+		_, err := LoadManifest(strings.NewReader(`
+roles:
+  - name: system_user
+  - name: system_guest
+    permissions:
+`))
+		require.ErrorContains(t, err, "role system_user has no permissions")
+		require.ErrorContains(t, err, "role system_guest has no permissions")
+
+		manifest, err := LoadManifest(strings.NewReader(`
+roles:
+  - name: system_user
+    permissions: []
+`))
+		require.NoError(t, err)
+		require.NotNil(t, manifest.Roles[0].Permissions)
+		require.Empty(t, manifest.Roles[0].Permissions)
+	})
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+type Kind string
+
+const (
+	KindTeam            Kind = "team"
+	KindChannel         Kind = "channel"
+	KindRole            Kind = "role"
+	KindBot             Kind = "bot"
+	KindIncomingWebhook Kind = "incoming_webhook"
+	KindOutgoingWebhook Kind = "outgoing_webhook"
+	KindCommand         Kind = "command"
+)
+
+// FieldChange is the change of a field of a resource being updated.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Change is a change of a resource needed to converge the workspace.
+type Change struct {
+	Action Action        `json:"action"`
+	Kind   Kind          `json:"kind"`
+	Name   string        `json:"name"`
+	Fields []FieldChange `json:"fields,omitempty"`
+
+	apply func(ctx context.Context) error
+}
+
+// Plan is the list of changes converging the workspace to a manifest, in the
+// order they are applied.
+type Plan struct {
+	Changes []*Change `json:"changes"`
+}
+
+// Count returns the number of changes of the plan with the given action.
+func (p *Plan) Count(action Action) int {
+	var count int
+	for _, change := range p.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// Apply applies the changes of the plan in order, stopping at the first
+// failure as the following changes may depend on it. It returns the number of
+// changes applied.
+func (p *Plan) Apply(ctx context.Context) (int, error) {
+	for i, change := range p.Changes {
+		if err := change.apply(ctx); err != nil {
+			return i, fmt.Errorf("failed to %s %s %s: %w", change.Action, change.Kind, change.Name, err)
+		}
+	}
+	return len(p.Changes), nil
+}
+
+// PlanTemplate renders a plan as text.
+const PlanTemplate = `{{if .Changes}}{{range .Changes}}{{if eq .Action "create"}}+{{else if eq .Action "update"}}~{{else}}-{{end}} {{.Action}} {{.Kind}} {{.Name}}
+{{range .Fields}}    {{.Field}}: {{printf "%q" .Old}} -> {{printf "%q" .New}}
+{{end}}{{end}}
+Plan: {{.Count "create"}} to create, {{.Count "update"}} to update, {{.Count "delete"}} to delete.{{else}}No changes, the workspace matches the manifest.{{end}}`
+
+type Options struct {
+	// Prune deletes the channels, webhooks and slash commands of the teams of
+	// the manifest that the manifest doesn't declare. The teams, roles and
+	// bots that the manifest doesn't declare are always left untouched, as a
+	// manifest may only describe a part of the workspace.
+	Prune bool
+}
+
+// planner computes a plan from the current state of the workspace. The
+// changes resolve the IDs of the teams and channels when they are applied,
+// through the maps of the planner that the changes creating them fill.
+type planner struct {
+	c       client.Client
+	options Options
+	plan    *Plan
+	// deletions are appended to the plan last, so that the resources
+	// depending on the deleted ones are deleted or moved first.
+	deletions []*Change
+
+	teams    map[string]*model.Team
+	channels map[string]*model.Channel
+	users    map[string]string
+}
+
+// NewPlan reads the current state of the workspace and computes the changes
+// converging it to the manifest.
+func NewPlan(ctx context.Context, c client.Client, manifest *Manifest, options Options) (*Plan, error) {
+	p := &planner{
+		c:        c,
+		options:  options,
+		plan:     &Plan{Changes: []*Change{}},
+		teams:    map[string]*model.Team{},
+		channels: map[string]*model.Channel{},
+		users:    map[string]string{},
+	}
+
+	if err := p.planRoles(ctx, manifest.Roles); err != nil {
+		return nil, err
+	}
+	if err := p.planBots(ctx, manifest.Bots); err != nil {
+		return nil, err
+	}
+	for _, team := range manifest.Teams {
+		if err := p.planTeam(ctx, team); err != nil {
+			return nil, err
+		}
+	}
+	for _, team := range manifest.Teams {
+		if err := p.planIntegrations(ctx, team); err != nil {
+			return nil, err
+		}
+	}
+
+	p.plan.Changes = append(p.plan.Changes, p.deletions...)
+	return p.plan, nil
+}
+
+func (p *planner) add(change *Change) {
+	if change.Action == ActionDelete {
+		p.deletions = append(p.deletions, change)
+		return
+	}
+	p.plan.Changes = append(p.plan.Changes, change)
+}
+
+// userID returns the ID of the user with the given username, or an empty
+// string for an empty username.
+func (p *planner) userID(ctx context.Context, username string) (string, error) {
+	if username == "" {
+		return "", nil
+	}
+	if id, ok := p.users[username]; ok {
+		return id, nil
+	}
+
+	user, _, err := p.c.GetUserByUsername(ctx, username, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get user %s: %w", username, err)
+	}
+	p.users[username] = user.Id
+	return user.Id, nil
+}
+
+// getPages returns all the items of the pages returned by fn.
+func getPages[T any](fn func(page, perPage int) ([]T, *model.Response, error)) ([]T, error) {
+	const perPage = 200
+
+	var items []T
+	for page := 0; ; page++ {
+		pageItems, _, err := fn(page, perPage)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		if len(pageItems) < perPage {
+			return items, nil
+		}
+	}
+}
+
+// fieldChanges accumulates the changes of the fields of a resource.
+type fieldChanges []FieldChange
+
+func (f *fieldChanges) string(field, from, to string) {
+	if from != to {
+		*f = append(*f, FieldChange{Field: field, Old: from, New: to})
+	}
+}
+
+func (f *fieldChanges) bool(field string, from, to bool) {
+	f.string(field, strconv.FormatBool(from), strconv.FormatBool(to))
+}
+
+func (f *fieldChanges) strings(field string, from, to []string) {
+	if !slices.Equal(from, to) {
+		*f = append(*f, FieldChange{Field: field, Old: strings.Join(from, ", "), New: strings.Join(to, ", ")})
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package workspace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/mocks"
+)
+
+func TestNewPlan(t *testing.T) {
+	ctx := context.Background()
+	team := &model.Team{Id: model.NewId(), Name: "engineering", DisplayName: "Engineering", Type: model.TeamOpen}
+	townSquare := &model.Channel{Id: model.NewId(), TeamId: team.Id, Name: model.DefaultChannelName, DisplayName: "Town Square", Type: model.ChannelTypeOpen}
+	releases := &model.Channel{Id: model.NewId(), TeamId: team.Id, Name: "releases", DisplayName: "Releases", Type: model.ChannelTypeOpen}
+	random := &model.Channel{Id: model.NewId(), TeamId: team.Id, Name: "random", DisplayName: "Random", Type: model.ChannelTypeOpen}
+	hook := &model.IncomingWebhook{Id: model.NewId(), TeamId: team.Id, ChannelId: releases.Id, DisplayName: "CI"}
+	command := &model.Command{Id: model.NewId(), TeamId: team.Id, Trigger: "oncall", URL: "https://oncall.example.com", Method: model.CommandMethodPost}
+
+	manifest := func() *Manifest {
+		return &Manifest{Teams: []*TeamManifest{{
+			Name:             "engineering",
+			DisplayName:      "Engineering",
+			Channels:         []*ChannelManifest{{Name: "releases", DisplayName: "Releases"}},
+			IncomingWebhooks: []*IncomingWebhookManifest{{DisplayName: "CI", Channel: "releases"}},
+			Commands:         []*CommandManifest{{Trigger: "oncall", URL: "https://oncall.example.com"}},
+		}}}
+	}
+
+	expectTeam := func(c *mocks.MockClient, channels ...*model.Channel) {
+		c.EXPECT().GetTeamByName(ctx, team.Name, "").Return(team, &model.Response{}, nil).Times(1)
+		c.EXPECT().GetPublicChannelsForTeam(ctx, team.Id, 0, 200, "").Return(channels, &model.Response{}, nil).Times(1)
+		c.EXPECT().GetPrivateChannelsForTeam(ctx, team.Id, 0, 200, "").Return(nil, &model.Response{}, nil).Times(1)
+		c.EXPECT().GetDeletedChannelsForTeam(ctx, team.Id, 0, 200, "").Return(nil, &model.Response{}, nil).Times(1)
+		c.EXPECT().GetIncomingWebhooksForTeam(ctx, team.Id, 0, 200, "").Return([]*model.IncomingWebhook{hook}, &model.Response{}, nil).Times(1)
+		c.EXPECT().GetOutgoingWebhooksForTeam(ctx, team.Id, 0, 200, "").Return(nil, &model.Response{}, nil).Times(1)
+		c.EXPECT().ListCommands(ctx, team.Id, true).Return([]*model.Command{command}, &model.Response{}, nil).Times(1)
+	}
+
+	t.Run("create a team with its channels and integrations", func(t *testing.T) {
+		c := mocks.NewMockClient(gomock.NewController(t))
+		c.EXPECT().GetTeamByName(ctx, team.Name, "").Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).Times(1)
+
+		plan, err := NewPlan(ctx, c, manifest(), Options{})
+		require.NoError(t, err)
+		require.Equal(t, 4, plan.Count(ActionCreate))
+		require.Zero(t, plan.Count(ActionUpdate))
+		require.Equal(t, []Kind{KindTeam, KindChannel, KindIncomingWebhook, KindCommand}, []Kind{
+			plan.Changes[0].Kind, plan.Changes[1].Kind, plan.Changes[2].Kind, plan.Changes[3].Kind,
+		})
+
+		createdTeam := &model.Team{Id: model.NewId(), Name: team.Name}
+		createdChannel := &model.Channel{Id: model.NewId(), TeamId: createdTeam.Id, Name: "releases"}
+		gomock.InOrder(
+			c.EXPECT().CreateTeam(ctx, gomock.Any()).Return(createdTeam, &model.Response{}, nil).Times(1),
+			c.EXPECT().CreateChannel(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, channel *model.Channel) (*model.Channel, *model.Response, error) {
+				require.Equal(t, createdTeam.Id, channel.TeamId)
+				return createdChannel, &model.Response{}, nil
+			}).Times(1),
+			c.EXPECT().CreateIncomingWebhook(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, hook *model.IncomingWebhook) (*model.IncomingWebhook, *model.Response, error) {
+				require.Equal(t, createdChannel.Id, hook.ChannelId)
+				return hook, &model.Response{}, nil
+			}).Times(1),
+			c.EXPECT().CreateCommand(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, command *model.Command) (*model.Command, *model.Response, error) {
+				require.Equal(t, createdTeam.Id, command.TeamId)
+				require.Equal(t, model.CommandMethodPost, command.Method)
+				return command, &model.Response{}, nil
+			}).Times(1),
+		)
+
+		applied, err := plan.Apply(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 4, applied)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		c := mocks.NewMockClient(gomock.NewController(t))
+		expectTeam(c, townSquare, releases, random)
+
+		plan, err := NewPlan(ctx, c, manifest(), Options{})
+		require.NoError(t, err)
+		require.Empty(t, plan.Changes)
+	})
+
+	t.Run("update a channel", func(t *testing.T) {
+		c := mocks.NewMockClient(gomock.NewController(t))
+		expectTeam(c, townSquare, releases)
+
+		m := manifest()
+		m.Teams[0].Channels[0].Purpose = "Release coordination"
+		m.Teams[0].Channels[0].Private = true
+
+		plan, err := NewPlan(ctx, c, m, Options{})
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, 1)
+		require.Equal(t, ActionUpdate, plan.Changes[0].Action)
+		require.Equal(t, "engineering:releases", plan.Changes[0].Name)
+		require.Equal(t, []FieldChange{
+			{Field: "purpose", Old: "", New: "Release coordination"},
+			{Field: "private", Old: "false", New: "true"},
+		}, plan.Changes[0].Fields)
+
+		c.EXPECT().PatchChannel(ctx, releases.Id, gomock.Any()).Return(releases, &model.Response{}, nil).Times(1)
+		c.EXPECT().UpdateChannelPrivacy(ctx, releases.Id, model.ChannelTypePrivate).Return(releases, &model.Response{}, nil).Times(1)
+
+		applied, err := plan.Apply(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, applied)
+	})
+
+	t.Run("prune the undeclared resources", func(t *testing.T) {
+		c := mocks.NewMockClient(gomock.NewController(t))
+		announcements := &model.Channel{Id: model.NewId(), TeamId: team.Id, Name: "announcements", DisplayName: "Announcements", Type: model.ChannelTypeOpen}
+		expectTeam(c, townSquare, releases, random, announcements)
+
+		m := manifest()
+		m.Teams[0].IncomingWebhooks = nil
+		m.Teams[0].Commands = nil
+
+		plan, err := NewPlan(ctx, c, m, Options{Prune: true})
+		require.NoError(t, err)
+		require.Equal(t, 4, plan.Count(ActionDelete))
+
+		// Deletions are planned in a stable order, sorted by name within each kind.
+		var names []string
+		for _, change := range plan.Changes {
+			names = append(names, change.Name)
+		}
+		require.Equal(t, []string{"engineering:announcements", "engineering:random", "engineering:releases/CI", "engineering:/oncall"}, names)
+	})
+
+	t.Run("stop at the first failure", func(t *testing.T) {
+		c := mocks.NewMockClient(gomock.NewController(t))
+		c.EXPECT().GetTeamByName(ctx, team.Name, "").Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).Times(1)
+
+		plan, err := NewPlan(ctx, c, manifest(), Options{})
+		require.NoError(t, err)
+
+		c.EXPECT().CreateTeam(ctx, gomock.Any()).Return(nil, &model.Response{}, errors.New("mock error")).Times(1)
+
+		applied, err := plan.Apply(ctx)
+		require.EqualError(t, err, "failed to create team engineering: mock error")
+		require.Zero(t, applied)
+	})
+}
+
+func TestPlanRoles(t *testing.T) {
+	ctx := context.Background()
+	c := mocks.NewMockClient(gomock.NewController(t))
+	role := &model.Role{Id: model.NewId(), Name: model.SystemUserRoleId, Permissions: []string{"create_team", "list_public_teams"}}
+	c.EXPECT().GetRoleByName(ctx, role.Name).Return(role, &model.Response{}, nil).Times(1)
+
+	permissions := []string{"list_public_teams", "create_emojis"}
+	plan, err := NewPlan(ctx, c, &Manifest{Roles: []*RoleManifest{{Name: role.Name, Permissions: permissions}}}, Options{})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, []FieldChange{
+		{Field: "permission create_emojis", Old: "false", New: "true"},
+		{Field: "permission create_team", Old: "true", New: "false"},
+	}, plan.Changes[0].Fields)
+
+	c.EXPECT().PatchRole(ctx, role.Id, &model.RolePatch{Permissions: &permissions}).Return(role, &model.Response{}, nil).Times(1)
+
+	_, err = plan.Apply(ctx)
+	require.NoError(t, err)
+}
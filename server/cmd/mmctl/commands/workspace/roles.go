@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// planRoles updates the permissions of the roles of the manifest. Roles
+// can't be created or deleted, so they are only ever updated.
+func (p *planner) planRoles(ctx context.Context, roles []*RoleManifest) error {
+	for _, manifest := range roles {
+		role, _, err := p.c.GetRoleByName(ctx, manifest.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get role %s: %w", manifest.Name, err)
+		}
+
+		var fields fieldChanges
+		for _, permission := range manifest.Permissions {
+			if !slices.Contains(role.Permissions, permission) {
+				fields.bool("permission "+permission, false, true)
+			}
+		}
+		for _, permission := range role.Permissions {
+			if !slices.Contains(manifest.Permissions, permission) {
+				fields.bool("permission "+permission, true, false)
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		permissions := slices.Clone(manifest.Permissions)
+		p.add(&Change{
+			Action: ActionUpdate,
+			Kind:   KindRole,
+			Name:   manifest.Name,
+			Fields: fields,
+			apply: func(ctx context.Context) error {
+				_, _, err := p.c.PatchRole(ctx, role.Id, &model.RolePatch{Permissions: &permissions})
+				return err
+			},
+		})
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func isNotFound(resp *model.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusNotFound
+}
+
+func channelKey(teamName, channelName string) string {
+	return teamName + ":" + channelName
+}
+
+func (p *planner) planTeam(ctx context.Context, manifest *TeamManifest) error {
+	team, resp, err := p.c.GetTeamByName(ctx, manifest.Name, "")
+	if err != nil && !isNotFound(resp) {
+		return fmt.Errorf("failed to get team %s: %w", manifest.Name, err)
+	}
+
+	if team == nil {
+		p.add(&Change{
+			Action: ActionCreate,
+			Kind:   KindTeam,
+			Name:   manifest.Name,
+			apply: func(ctx context.Context) error {
+				created, _, err := p.c.CreateTeam(ctx, &model.Team{
+					Name:            manifest.Name,
+					DisplayName:     manifest.DisplayName,
+					Description:     manifest.Description,
+					Type:            manifest.teamType(),
+					AllowOpenInvite: !manifest.Private,
+				})
+				if err != nil {
+					return err
+				}
+				p.teams[manifest.Name] = created
+				return nil
+			},
+		})
+
+		// The team has no channels yet.
+		for _, channel := range manifest.Channels {
+			p.planChannelCreation(manifest.Name, channel)
+		}
+		return nil
+	}
+
+	p.teams[manifest.Name] = team
+
+	var fields fieldChanges
+	fields.bool("archived", team.DeleteAt != 0, false)
+	fields.string("display_name", team.DisplayName, manifest.DisplayName)
+	fields.string("description", team.Description, manifest.Description)
+	fields.bool("private", team.Type == model.TeamInvite, manifest.Private)
+	if len(fields) > 0 {
+		p.add(&Change{
+			Action: ActionUpdate,
+			Kind:   KindTeam,
+			Name:   manifest.Name,
+			Fields: fields,
+			apply: func(ctx context.Context) error {
+				if team.DeleteAt != 0 {
+					if _, _, err := p.c.RestoreTeam(ctx, team.Id); err != nil {
+						return err
+					}
+				}
+				if team.DisplayName != manifest.DisplayName || team.Description != manifest.Description {
+					patch := &model.TeamPatch{DisplayName: &manifest.DisplayName, Description: &manifest.Description}
+					if _, _, err := p.c.PatchTeam(ctx, team.Id, patch); err != nil {
+						return err
+					}
+				}
+				if team.Type != manifest.teamType() {
+					if _, _, err := p.c.UpdateTeamPrivacy(ctx, team.Id, manifest.teamType()); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		})
+	}
+
+	return p.planChannels(ctx, team, manifest)
+}
+
+func (p *planner) planChannels(ctx context.Context, team *model.Team, manifest *TeamManifest) error {
+	channels := map[string]*model.Channel{}
+	for _, list := range []func(page, perPage int) ([]*model.Channel, *model.Response, error){
+		func(page, perPage int) ([]*model.Channel, *model.Response, error) {
+			return p.c.GetPublicChannelsForTeam(ctx, team.Id, page, perPage, "")
+		},
+		func(page, perPage int) ([]*model.Channel, *model.Response, error) {
+			return p.c.GetPrivateChannelsForTeam(ctx, team.Id, page, perPage, "")
+		},
+		func(page, perPage int) ([]*model.Channel, *model.Response, error) {
+			return p.c.GetDeletedChannelsForTeam(ctx, team.Id, page, perPage, "")
+		},
+	} {
+		teamChannels, err := getPages(list)
+		if err != nil {
+			return fmt.Errorf("failed to get the channels of team %s: %w", manifest.Name, err)
+		}
+		for _, channel := range teamChannels {
+			channels[channel.Name] = channel
+			p.channels[channelKey(manifest.Name, channel.Name)] = channel
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, channelManifest := range manifest.Channels {
+		declared[channelManifest.Name] = true
+
+		channel, ok := channels[channelManifest.Name]
+		if !ok {
+			p.planChannelCreation(manifest.Name, channelManifest)
+			continue
+		}
+		p.planChannelUpdate(manifest.Name, channel, channelManifest)
+	}
+
+	if !p.options.Prune {
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(channels)) {
+		channel := channels[name]
+		// The default channel of a team can't be archived.
+		if declared[channel.Name] || channel.DeleteAt != 0 || channel.Name == model.DefaultChannelName {
+			continue
+		}
+		p.add(&Change{
+			Action: ActionDelete,
+			Kind:   KindChannel,
+			Name:   channelKey(manifest.Name, channel.Name),
+			apply: func(ctx context.Context) error {
+				_, err := p.c.DeleteChannel(ctx, channel.Id)
+				return err
+			},
+		})
+	}
+	return nil
+}
+
+func (p *planner) planChannelCreation(teamName string, manifest *ChannelManifest) {
+	key := channelKey(teamName, manifest.Name)
+	p.add(&Change{
+		Action: ActionCreate,
+		Kind:   KindChannel,
+		Name:   key,
+		apply: func(ctx context.Context) error {
+			created, _, err := p.c.CreateChannel(ctx, &model.Channel{
+				TeamId:      p.teams[teamName].Id,
+				Name:        manifest.Name,
+				DisplayName: manifest.DisplayName,
+				Type:        manifest.channelType(),
+				Purpose:     manifest.Purpose,
+				Header:      manifest.Header,
+			})
+			if err != nil {
+				return err
+			}
+			p.channels[key] = created
+			return nil
+		},
+	})
+}
+
+func (p *planner) planChannelUpdate(teamName string, channel *model.Channel, manifest *ChannelManifest) {
+	var fields fieldChanges
+	fields.bool("archived", channel.DeleteAt != 0, false)
+	fields.string("display_name", channel.DisplayName, manifest.DisplayName)
+	fields.string("purpose", channel.Purpose, manifest.Purpose)
+	fields.string("header", channel.Header, manifest.Header)
+	fields.bool("private", channel.Type == model.ChannelTypePrivate, manifest.Private)
+	if len(fields) == 0 {
+		return
+	}
+
+	p.add(&Change{
+		Action: ActionUpdate,
+		Kind:   KindChannel,
+		Name:   channelKey(teamName, manifest.Name),
+		Fields: fields,
+		apply: func(ctx context.Context) error {
+			if channel.DeleteAt != 0 {
+				if _, _, err := p.c.RestoreChannel(ctx, channel.Id); err != nil {
+					return err
+				}
+			}
+			if channel.DisplayName != manifest.DisplayName || channel.Purpose != manifest.Purpose || channel.Header != manifest.Header {
+				patch := &model.ChannelPatch{DisplayName: &manifest.DisplayName, Purpose: &manifest.Purpose, Header: &manifest.Header}
+				if _, _, err := p.c.PatchChannel(ctx, channel.Id, patch); err != nil {
+					return err
+				}
+			}
+			if channel.Type != manifest.channelType() {
+				if _, _, err := p.c.UpdateChannelPrivacy(ctx, channel.Id, manifest.channelType()); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+}
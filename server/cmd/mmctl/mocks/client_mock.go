@@ -295,6 +295,22 @@ func (mr *MockClientMockRecorder) CreatePost(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePost", reflect.TypeOf((*MockClient)(nil).CreatePost), arg0, arg1)
 }
 
+// CreateScheme mocks base method.
+func (m *MockClient) CreateScheme(arg0 context.Context, arg1 *model.Scheme) (*model.Scheme, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheme", arg0, arg1)
+	ret0, _ := ret[0].(*model.Scheme)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateScheme indicates an expected call of CreateScheme.
+func (mr *MockClientMockRecorder) CreateScheme(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheme", reflect.TypeOf((*MockClient)(nil).CreateScheme), arg0, arg1)
+}
+
 // CreateTeam mocks base method.
 func (m *MockClient) CreateTeam(arg0 context.Context, arg1 *model.Team) (*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -449,6 +465,21 @@ func (mr *MockClientMockRecorder) DeleteIncomingWebhook(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncomingWebhook", reflect.TypeOf((*MockClient)(nil).DeleteIncomingWebhook), arg0, arg1)
 }
 
+// DeleteOutgoingEmail mocks base method.
+func (m *MockClient) DeleteOutgoingEmail(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutgoingEmail", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOutgoingEmail indicates an expected call of DeleteOutgoingEmail.
+func (mr *MockClientMockRecorder) DeleteOutgoingEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutgoingEmail", reflect.TypeOf((*MockClient)(nil).DeleteOutgoingEmail), arg0, arg1)
+}
+
 // DeleteOutgoingWebhook mocks base method.
 func (m *MockClient) DeleteOutgoingWebhook(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -494,6 +525,21 @@ func (mr *MockClientMockRecorder) DeletePreferences(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreferences", reflect.TypeOf((*MockClient)(nil).DeletePreferences), arg0, arg1, arg2)
 }
 
+// DeleteScheme mocks base method.
+func (m *MockClient) DeleteScheme(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheme", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteScheme indicates an expected call of DeleteScheme.
+func (mr *MockClientMockRecorder) DeleteScheme(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheme", reflect.TypeOf((*MockClient)(nil).DeleteScheme), arg0, arg1)
+}
+
 // DemoteUserToGuest mocks base method.
 func (m *MockClient) DemoteUserToGuest(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -890,6 +936,22 @@ func (mr *MockClientMockRecorder) GetDeletedChannelsForTeam(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedChannelsForTeam", reflect.TypeOf((*MockClient)(nil).GetDeletedChannelsForTeam), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetFailedOutgoingEmails mocks base method.
+func (m *MockClient) GetFailedOutgoingEmails(arg0 context.Context, arg1, arg2 int) ([]*model.OutgoingEmail, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFailedOutgoingEmails", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.OutgoingEmail)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFailedOutgoingEmails indicates an expected call of GetFailedOutgoingEmails.
+func (mr *MockClientMockRecorder) GetFailedOutgoingEmails(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFailedOutgoingEmails", reflect.TypeOf((*MockClient)(nil).GetFailedOutgoingEmails), arg0, arg1, arg2)
+}
+
 // GetGroupsByChannel mocks base method.
 func (m *MockClient) GetGroupsByChannel(arg0 context.Context, arg1 string, arg2 model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1116,6 +1178,22 @@ func (mr *MockClientMockRecorder) GetOutgoingWebhook(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhook", reflect.TypeOf((*MockClient)(nil).GetOutgoingWebhook), arg0, arg1)
 }
 
+// GetOutgoingWebhookDeliveries mocks base method.
+func (m *MockClient) GetOutgoingWebhookDeliveries(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*model.OutgoingWebhookDelivery, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhookDeliveries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.OutgoingWebhookDelivery)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOutgoingWebhookDeliveries indicates an expected call of GetOutgoingWebhookDeliveries.
+func (mr *MockClientMockRecorder) GetOutgoingWebhookDeliveries(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhookDeliveries", reflect.TypeOf((*MockClient)(nil).GetOutgoingWebhookDeliveries), arg0, arg1, arg2, arg3)
+}
+
 // GetOutgoingWebhooks mocks base method.
 func (m *MockClient) GetOutgoingWebhooks(arg0 context.Context, arg1, arg2 int, arg3 string) ([]*model.OutgoingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1388,6 +1466,54 @@ func (mr *MockClientMockRecorder) GetRoleByName(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleByName", reflect.TypeOf((*MockClient)(nil).GetRoleByName), arg0, arg1)
 }
 
+// GetRolesByNames mocks base method.
+func (m *MockClient) GetRolesByNames(arg0 context.Context, arg1 []string) ([]*model.Role, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolesByNames", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Role)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRolesByNames indicates an expected call of GetRolesByNames.
+func (mr *MockClientMockRecorder) GetRolesByNames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolesByNames", reflect.TypeOf((*MockClient)(nil).GetRolesByNames), arg0, arg1)
+}
+
+// GetScheme mocks base method.
+func (m *MockClient) GetScheme(arg0 context.Context, arg1 string) (*model.Scheme, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheme", arg0, arg1)
+	ret0, _ := ret[0].(*model.Scheme)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetScheme indicates an expected call of GetScheme.
+func (mr *MockClientMockRecorder) GetScheme(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheme", reflect.TypeOf((*MockClient)(nil).GetScheme), arg0, arg1)
+}
+
+// GetSchemes mocks base method.
+func (m *MockClient) GetSchemes(arg0 context.Context, arg1 string, arg2, arg3 int) ([]*model.Scheme, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Scheme)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSchemes indicates an expected call of GetSchemes.
+func (mr *MockClientMockRecorder) GetSchemes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemes", reflect.TypeOf((*MockClient)(nil).GetSchemes), arg0, arg1, arg2, arg3)
+}
+
 // GetServerBusy mocks base method.
 func (m *MockClient) GetServerBusy(arg0 context.Context) (*model.ServerBusyState, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1926,6 +2052,22 @@ func (mr *MockClientMockRecorder) PatchRole(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchRole", reflect.TypeOf((*MockClient)(nil).PatchRole), arg0, arg1, arg2)
 }
 
+// PatchScheme mocks base method.
+func (m *MockClient) PatchScheme(arg0 context.Context, arg1 string, arg2 *model.SchemePatch) (*model.Scheme, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchScheme", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Scheme)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PatchScheme indicates an expected call of PatchScheme.
+func (mr *MockClientMockRecorder) PatchScheme(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchScheme", reflect.TypeOf((*MockClient)(nil).PatchScheme), arg0, arg1, arg2)
+}
+
 // PatchTeam mocks base method.
 func (m *MockClient) PatchTeam(arg0 context.Context, arg1 string, arg2 *model.TeamPatch) (*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2187,6 +2329,22 @@ func (mr *MockClientMockRecorder) RestoreTeam(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTeam", reflect.TypeOf((*MockClient)(nil).RestoreTeam), arg0, arg1)
 }
 
+// RetryOutgoingEmail mocks base method.
+func (m *MockClient) RetryOutgoingEmail(arg0 context.Context, arg1 string) (*model.OutgoingEmail, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryOutgoingEmail", arg0, arg1)
+	ret0, _ := ret[0].(*model.OutgoingEmail)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RetryOutgoingEmail indicates an expected call of RetryOutgoingEmail.
+func (mr *MockClientMockRecorder) RetryOutgoingEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryOutgoingEmail", reflect.TypeOf((*MockClient)(nil).RetryOutgoingEmail), arg0, arg1)
+}
+
 // RevealPost mocks base method.
 func (m *MockClient) RevealPost(arg0 context.Context, arg1 string) (*model.Post, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2310,6 +2468,21 @@ func (mr *MockClientMockRecorder) UpdateChannelPrivacy(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelPrivacy", reflect.TypeOf((*MockClient)(nil).UpdateChannelPrivacy), arg0, arg1, arg2)
 }
 
+// UpdateChannelScheme mocks base method.
+func (m *MockClient) UpdateChannelScheme(arg0 context.Context, arg1, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannelScheme", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChannelScheme indicates an expected call of UpdateChannelScheme.
+func (mr *MockClientMockRecorder) UpdateChannelScheme(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelScheme", reflect.TypeOf((*MockClient)(nil).UpdateChannelScheme), arg0, arg1, arg2)
+}
+
 // UpdateCommand mocks base method.
 func (m *MockClient) UpdateCommand(arg0 context.Context, arg1 *model.Command) (*model.Command, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2436,6 +2609,21 @@ func (mr *MockClientMockRecorder) UpdateTeamPrivacy(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamPrivacy", reflect.TypeOf((*MockClient)(nil).UpdateTeamPrivacy), arg0, arg1, arg2)
 }
 
+// UpdateTeamScheme mocks base method.
+func (m *MockClient) UpdateTeamScheme(arg0 context.Context, arg1, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamScheme", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTeamScheme indicates an expected call of UpdateTeamScheme.
+func (mr *MockClientMockRecorder) UpdateTeamScheme(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamScheme", reflect.TypeOf((*MockClient)(nil).UpdateTeamScheme), arg0, arg1, arg2)
+}
+
 // UpdateUser mocks base method.
 func (m *MockClient) UpdateUser(arg0 context.Context, arg1 *model.User) (*model.User, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -67,6 +68,7 @@ func GenerateClientConfig(c *model.Config, telemetryID string, license *model.Li
 
 	props["SendEmailNotifications"] = strconv.FormatBool(*c.EmailSettings.SendEmailNotifications)
 	props["SendPushNotifications"] = strconv.FormatBool(*c.EmailSettings.SendPushNotifications)
+	props["SendWebPushNotifications"] = strconv.FormatBool(*c.EmailSettings.SendWebPushNotifications)
 	props["RequireEmailVerification"] = strconv.FormatBool(*c.EmailSettings.RequireEmailVerification)
 	props["EnableEmailBatching"] = strconv.FormatBool(*c.EmailSettings.EnableEmailBatching)
 	props["EnablePreviewModeBanner"] = strconv.FormatBool(*c.EmailSettings.EnablePreviewModeBanner)
@@ -387,6 +389,7 @@ func GenerateLimitedClientConfig(c *model.Config, telemetryID string, license *m
 	props["HideGuestTags"] = strconv.FormatBool(*c.GuestAccountsSettings.HideTags)
 	props["GuestAccountsEnforceMultifactorAuthentication"] = strconv.FormatBool(*c.GuestAccountsSettings.EnforceMultifactorAuthentication)
 	props["EnableGuestMagicLink"] = strconv.FormatBool(*c.GuestAccountsSettings.EnableGuestMagicLink)
+	props["OpenIdProviders"] = openIdProvidersClientConfig(c.OpenIdProvidersSettings)
 
 	if license != nil {
 		if *license.Features.LDAP {
@@ -469,6 +472,35 @@ func GenerateLimitedClientConfig(c *model.Config, telemetryID string, license *m
 	return props
 }
 
+// openIdProvidersClientConfig returns the enabled OpenID Connect providers as JSON,
+// for the login page to show a button for each of them.
+func openIdProvidersClientConfig(s model.OpenIdProvidersSettings) string {
+	type clientProvider struct {
+		Service     string `json:"service"`
+		ButtonText  string `json:"button_text"`
+		ButtonColor string `json:"button_color"`
+	}
+
+	providers := []clientProvider{}
+	for _, provider := range s.Providers {
+		if !*provider.Enable {
+			continue
+		}
+
+		providers = append(providers, clientProvider{
+			Service:     provider.Service(),
+			ButtonText:  *provider.ButtonText,
+			ButtonColor: *provider.ButtonColor,
+		})
+	}
+
+	b, err := json.Marshal(providers)
+	if err != nil {
+		return "[]"
+	}
+	return string(b)
+}
+
 func getGiphySdkKey(ss model.ServiceSettings) string {
 	if model.GetServiceEnvironment() == model.ServiceEnvironmentProduction {
 		if *ss.GiphySdkKey != "" {
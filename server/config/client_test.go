@@ -839,6 +839,22 @@ func TestGetLimitedClientConfig(t *testing.T) {
 				"ExperimentalEnableWatermark": "true",
 			},
 		},
+		{
+			"openid providers",
+			&model.Config{
+				OpenIdProvidersSettings: model.OpenIdProvidersSettings{
+					Providers: []*model.OpenIdProviderSettings{
+						{Name: new("staff"), Enable: new(true), ButtonText: new("Staff"), Secret: new("secret")},
+						{Name: new("contractors"), Enable: new(false)},
+					},
+				},
+			},
+			"",
+			nil,
+			map[string]string{
+				"OpenIdProviders": `[{"service":"oidcstaff","button_text":"Staff","button_color":"#145DBF"}]`,
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -47,10 +47,12 @@ var configSensitivePaths = map[string]bool{
 	"SqlSettings.DataSourceReplicas":                         true,
 	"SqlSettings.DataSourceSearchReplicas":                   true,
 	"EmailSettings.SMTPPassword":                             true,
+	"EmailSettings.HTTPMailTransportAPIKey":                  true,
 	"GitLabSettings.Secret":                                  true,
 	"GoogleSettings.Secret":                                  true,
 	"Office365Settings.Secret":                               true,
 	"OpenIdSettings.Secret":                                  true,
+	"OpenIdProvidersSettings.Providers":                      true,
 	"ElasticsearchSettings.Password":                         true,
 	"MessageExportSettings.GlobalRelaySettings.SMTPUsername": true,
 	"MessageExportSettings.GlobalRelaySettings.SMTPPassword": true,
@@ -49,6 +49,10 @@ func Desanitize(actual, target *model.Config) {
 		target.EmailSettings.SMTPPassword = actual.EmailSettings.SMTPPassword
 	}
 
+	if target.EmailSettings.HTTPMailTransportAPIKey != nil && *target.EmailSettings.HTTPMailTransportAPIKey == model.FakeSetting {
+		target.EmailSettings.HTTPMailTransportAPIKey = actual.EmailSettings.HTTPMailTransportAPIKey
+	}
+
 	if target.GitLabSettings.Secret != nil && *target.GitLabSettings.Secret == model.FakeSetting {
 		target.GitLabSettings.Secret = actual.GitLabSettings.Secret
 	}
@@ -65,6 +69,17 @@ func Desanitize(actual, target *model.Config) {
 		target.OpenIdSettings.Secret = actual.OpenIdSettings.Secret
 	}
 
+	for _, provider := range target.OpenIdProvidersSettings.Providers {
+		if provider.Secret == nil || *provider.Secret != model.FakeSetting {
+			continue
+		}
+		for _, actualProvider := range actual.OpenIdProvidersSettings.Providers {
+			if model.SafeDereference(actualProvider.Name) == model.SafeDereference(provider.Name) {
+				provider.Secret = actualProvider.Secret
+			}
+		}
+	}
+
 	if target.SqlSettings.DataSource != nil && *target.SqlSettings.DataSource == model.FakeSetting && actual.SqlSettings.DataSource != nil {
 		*target.SqlSettings.DataSource = *actual.SqlSettings.DataSource
 	}
@@ -31,8 +31,13 @@ func TestDesanitize(t *testing.T) {
 	actual.FileSettings.AzureAccessKey = new("azure_access_key")
 	actual.FileSettings.ExportAzureAccessKey = new("export_azure_access_key")
 	actual.EmailSettings.SMTPPassword = new("smtp_password")
+	actual.EmailSettings.HTTPMailTransportAPIKey = new("http_mail_transport_api_key")
 	actual.GitLabSettings.Secret = new("secret")
 	actual.OpenIdSettings.Secret = new("secret")
+	actual.OpenIdProvidersSettings.Providers = []*model.OpenIdProviderSettings{
+		{Name: new("staff"), Secret: new("staff_secret")},
+		{Name: new("contractors"), Secret: new("contractors_secret")},
+	}
 	actual.SqlSettings.DataSource = new("data_source")
 	actual.SqlSettings.AtRestEncryptKey = new("at_rest_encrypt_key")
 	actual.ElasticsearchSettings.Password = new("password")
@@ -64,8 +69,13 @@ func TestDesanitize(t *testing.T) {
 	target.FileSettings.AzureAccessKey = model.NewPointer(model.FakeSetting)
 	target.FileSettings.ExportAzureAccessKey = model.NewPointer(model.FakeSetting)
 	target.EmailSettings.SMTPPassword = model.NewPointer(model.FakeSetting)
+	target.EmailSettings.HTTPMailTransportAPIKey = model.NewPointer(model.FakeSetting)
 	target.GitLabSettings.Secret = model.NewPointer(model.FakeSetting)
 	target.OpenIdSettings.Secret = model.NewPointer(model.FakeSetting)
+	target.OpenIdProvidersSettings.Providers = []*model.OpenIdProviderSettings{
+		{Name: new("contractors"), Secret: model.NewPointer(model.FakeSetting)},
+		{Name: new("staff"), Secret: new("new_staff_secret")},
+	}
 	target.SqlSettings.DataSource = model.NewPointer(model.FakeSetting)
 	target.SqlSettings.AtRestEncryptKey = model.NewPointer(model.FakeSetting)
 	target.ElasticsearchSettings.Password = model.NewPointer(model.FakeSetting)
@@ -96,8 +106,11 @@ func TestDesanitize(t *testing.T) {
 	assert.Equal(t, *actual.FileSettings.AzureAccessKey, *target.FileSettings.AzureAccessKey)
 	assert.Equal(t, *actual.FileSettings.ExportAzureAccessKey, *target.FileSettings.ExportAzureAccessKey)
 	assert.Equal(t, *actual.EmailSettings.SMTPPassword, *target.EmailSettings.SMTPPassword)
+	assert.Equal(t, *actual.EmailSettings.HTTPMailTransportAPIKey, *target.EmailSettings.HTTPMailTransportAPIKey)
 	assert.Equal(t, *actual.GitLabSettings.Secret, *target.GitLabSettings.Secret)
 	assert.Equal(t, *actual.OpenIdSettings.Secret, *target.OpenIdSettings.Secret)
+	assert.Equal(t, "contractors_secret", *target.OpenIdProvidersSettings.Providers[0].Secret)
+	assert.Equal(t, "new_staff_secret", *target.OpenIdProvidersSettings.Providers[1].Secret)
 	assert.Equal(t, *actual.SqlSettings.DataSource, *target.SqlSettings.DataSource)
 	assert.Equal(t, *actual.SqlSettings.AtRestEncryptKey, *target.SqlSettings.AtRestEncryptKey)
 	assert.Equal(t, *actual.ElasticsearchSettings.Password, *target.ElasticsearchSettings.Password)
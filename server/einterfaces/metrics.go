@@ -50,6 +50,7 @@ type MetricsInterface interface {
 	IncrementWebSocketBroadcastUsersRegistered(hub string, amount float64)
 	DecrementWebSocketBroadcastUsersRegistered(hub string, amount float64)
 	IncrementWebsocketReconnectEventWithDisconnectErrCode(eventType string, disconnectErrCode string)
+	ObserveWebSocketCompression(originClient string, uncompressedSize, compressedSize int)
 
 	IncrementHTTPWebSockets(originClient string)
 	DecrementHTTPWebSockets(originClient string)
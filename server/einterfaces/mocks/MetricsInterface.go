@@ -592,6 +592,11 @@ func (_m *MetricsInterface) ObserveStoreMethodDuration(method string, success st
 	_m.Called(method, success, elapsed)
 }
 
+// ObserveWebSocketCompression provides a mock function with given fields: originClient, uncompressedSize, compressedSize
+func (_m *MetricsInterface) ObserveWebSocketCompression(originClient string, uncompressedSize int, compressedSize int) {
+	_m.Called(originClient, uncompressedSize, compressedSize)
+}
+
 // Register provides a mock function with no fields
 func (_m *MetricsInterface) Register() {
 	_m.Called()
@@ -13,6 +13,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
@@ -62,6 +64,9 @@ type ESPost struct {
 	Attachments string   `json:"attachments"`
 	URLs        []string `json:"urls"`
 	ChannelType string   `json:"channel_type"`
+	RootId      string   `json:"root_id,omitempty"`
+	IsPinned    bool     `json:"is_pinned"`
+	HasFiles    bool     `json:"has_files"`
 }
 
 type ESFile struct {
@@ -118,6 +123,9 @@ func ESPostFromPostForIndexing(post *model.PostForIndexing) *ESPost {
 		Type:        post.Type,
 		Hashtags:    strings.Fields(post.Hashtags),
 		ChannelType: post.ChannelType,
+		RootId:      post.RootId,
+		IsPinned:    post.IsPinned,
+		HasFiles:    len(post.FileIds) > 0 || len(post.Filenames) > 0,
 	}
 
 	var searchAttachments []string
@@ -205,6 +213,30 @@ func ESPostFromPostForIndexing(post *model.PostForIndexing) *ESPost {
 	return &searchPost
 }
 
+// PostAttributeFilters returns the queries that posts must and must not match
+// for the has: and is: flags of a search. Flagged posts and reactions aren't
+// indexed, so searches using those flags are left to the database.
+func PostAttributeFilters(params *model.SearchParams) (filters, notFilters []types.Query) {
+	addFilter := func(include *bool, query types.Query) {
+		if include == nil {
+			return
+		}
+		if *include {
+			filters = append(filters, query)
+		} else {
+			notFilters = append(notFilters, query)
+		}
+	}
+
+	addFilter(params.HasFile, types.Query{Term: map[string]types.TermQuery{"has_files": {Value: true}}})
+	addFilter(params.HasLink, types.Query{Exists: &types.ExistsQuery{Field: "urls"}})
+	addFilter(params.IsPinned, types.Query{Term: map[string]types.TermQuery{"is_pinned": {Value: true}}})
+	// root_id is left out of the documents of root posts
+	addFilter(params.IsReply, types.Query{Exists: &types.ExistsQuery{Field: "root_id"}})
+
+	return filters, notFilters
+}
+
 func extractURLsFromMessage(message string) []string {
 	message = markdownLinkRe.ReplaceAllString(message, "")
 	urls := urlRe.FindAllString(message, -1)
@@ -410,6 +410,62 @@ func TestESPostFromPostForIndexing(t *testing.T) {
 		assert.Contains(t, espost.Attachments, "Second")
 		assert.Contains(t, espost.Attachments, "two")
 	})
+
+	t.Run("indexes reply, pinned and file attributes", func(t *testing.T) {
+		post := model.PostForIndexing{
+			TeamId: model.NewId(),
+			Post: model.Post{
+				Id:        model.NewId(),
+				ChannelId: model.NewId(),
+				UserId:    model.NewId(),
+				RootId:    model.NewId(),
+				CreateAt:  model.GetMillis(),
+				Message:   "message",
+				IsPinned:  true,
+				FileIds:   model.StringArray{model.NewId()},
+			},
+		}
+
+		espost := ESPostFromPostForIndexing(&post)
+
+		assert.Equal(t, post.RootId, espost.RootId)
+		assert.True(t, espost.IsPinned)
+		assert.True(t, espost.HasFiles)
+
+		post.RootId = ""
+		post.IsPinned = false
+		post.FileIds = nil
+
+		espost = ESPostFromPostForIndexing(&post)
+
+		assert.Empty(t, espost.RootId)
+		assert.False(t, espost.IsPinned)
+		assert.False(t, espost.HasFiles)
+	})
+}
+
+func TestPostAttributeFilters(t *testing.T) {
+	t.Run("no attributes", func(t *testing.T) {
+		filters, notFilters := PostAttributeFilters(&model.SearchParams{Terms: "test"})
+		assert.Empty(t, filters)
+		assert.Empty(t, notFilters)
+	})
+
+	t.Run("included and excluded attributes", func(t *testing.T) {
+		filters, notFilters := PostAttributeFilters(&model.SearchParams{
+			HasFile:   model.NewPointer(true),
+			HasLink:   model.NewPointer(false),
+			IsPinned:  model.NewPointer(true),
+			IsReply:   model.NewPointer(false),
+			IsFlagged: model.NewPointer(true),
+		})
+		require.Len(t, filters, 2)
+		require.Len(t, notFilters, 2)
+		assert.Contains(t, filters[0].Term, "has_files")
+		assert.Contains(t, filters[1].Term, "is_pinned")
+		assert.Equal(t, "urls", notFilters[0].Exists.Field)
+		assert.Equal(t, "root_id", notFilters[1].Exists.Field)
+	})
 }
 
 // TestESPostFromPost_CreatePostJSONRoundTrip simulates the exact flow that happens
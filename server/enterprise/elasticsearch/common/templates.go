@@ -186,6 +186,15 @@ func GetPostTemplate(cfg *model.Config, opts ...func(*types.IndexTemplateMapping
 				"channel_type": types.KeywordProperty{
 					Type: "keyword",
 				},
+				"root_id": types.KeywordProperty{
+					Type: "keyword",
+				},
+				"is_pinned": types.BooleanProperty{
+					Type: "boolean",
+				},
+				"has_files": types.BooleanProperty{
+					Type: "boolean",
+				},
 			},
 		},
 	}
@@ -443,7 +443,7 @@ func (es *ElasticsearchInterfaceImpl) SearchPosts(channels model.ChannelList, se
 			termOperator = operator.Or
 		}
 
-		// Date, channels and FromUsers filters come in all
+		// Date, channels, FromUsers and post attribute filters come in all
 		// searchParams iteration, and as they are global to the
 		// query, we only need to process them once
 		if i == 0 {
@@ -471,6 +471,10 @@ func (es *ElasticsearchInterfaceImpl) SearchPosts(channels model.ChannelList, se
 				})
 			}
 
+			attributeFilters, attributeNotFilters := common.PostAttributeFilters(params)
+			filters = append(filters, attributeFilters...)
+			notFilters = append(notFilters, attributeNotFilters...)
+
 			if params.OnDate != "" {
 				before, after := params.GetOnDateMillis()
 				filters = append(filters, types.Query{
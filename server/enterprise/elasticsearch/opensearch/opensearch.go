@@ -452,7 +452,7 @@ func (os *OpensearchInterfaceImpl) SearchPosts(channels model.ChannelList, searc
 			termOperator = operator.Or
 		}
 
-		// Date, channels and FromUsers filters come in all
+		// Date, channels, FromUsers and post attribute filters come in all
 		// searchParams iteration, and as they are global to the
 		// query, we only need to process them once
 		if i == 0 {
@@ -480,6 +480,10 @@ func (os *OpensearchInterfaceImpl) SearchPosts(channels model.ChannelList, searc
 				})
 			}
 
+			attributeFilters, attributeNotFilters := common.PostAttributeFilters(params)
+			filters = append(filters, attributeFilters...)
+			notFilters = append(notFilters, attributeNotFilters...)
+
 			if params.OnDate != "" {
 				before, after := params.GetOnDateMillis()
 				filters = append(filters, types.Query{
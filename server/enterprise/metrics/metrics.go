@@ -154,6 +154,8 @@ type MetricsInterfaceImpl struct {
 	WebSocketBroadcastBufferGauge                *prometheus.GaugeVec
 	WebSocketBroadcastBufferUsersRegisteredGauge *prometheus.GaugeVec
 	WebSocketReconnectCounter                    *prometheus.CounterVec
+	WebSocketCompressionBytesCounter             *prometheus.CounterVec
+	WebSocketCompressionRatio                    *prometheus.HistogramVec
 
 	SearchEngineStatusGauge    prometheus.GaugeFunc
 	SearchPostSearchesCounter  prometheus.Counter
@@ -765,6 +767,31 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	)
 	m.Registry.MustRegister(m.WebSocketReconnectCounter)
 
+	m.WebSocketCompressionBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemWebsocket,
+			Name:        "compression_bytes_total",
+			Help:        "Total size in bytes of compressed websocket messages, before and after compression",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"origin_client", "type"},
+	)
+	m.Registry.MustRegister(m.WebSocketCompressionBytesCounter)
+
+	m.WebSocketCompressionRatio = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemWebsocket,
+			Name:        "compression_ratio",
+			Help:        "Ratio of the compressed size to the uncompressed size of websocket messages",
+			Buckets:     prometheus.LinearBuckets(0.1, 0.1, 10),
+			ConstLabels: additionalLabels,
+		},
+		[]string{"origin_client"},
+	)
+	m.Registry.MustRegister(m.WebSocketCompressionRatio)
+
 	// Search Subsystem
 
 	m.SearchEngineStatusGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
@@ -1976,6 +2003,15 @@ func (mi *MetricsInterfaceImpl) IncrementWebsocketReconnectEventWithDisconnectEr
 	}).Inc()
 }
 
+func (mi *MetricsInterfaceImpl) ObserveWebSocketCompression(originClient string, uncompressedSize, compressedSize int) {
+	if uncompressedSize <= 0 {
+		return
+	}
+	mi.WebSocketCompressionBytesCounter.With(prometheus.Labels{"origin_client": originClient, "type": "uncompressed"}).Add(float64(uncompressedSize))
+	mi.WebSocketCompressionBytesCounter.With(prometheus.Labels{"origin_client": originClient, "type": "compressed"}).Add(float64(compressedSize))
+	mi.WebSocketCompressionRatio.With(prometheus.Labels{"origin_client": originClient}).Observe(float64(compressedSize) / float64(uncompressedSize))
+}
+
 func (mi *MetricsInterfaceImpl) IncrementWebSocketBroadcastBufferSize(hub string, amount float64) {
 	mi.WebSocketBroadcastBufferGauge.With(prometheus.Labels{"hub": hub}).Add(math.Abs(amount))
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
 	"github.com/redis/rueidis"
+	"github.com/throttled/throttled/v2"
 )
 
 // CacheOptions contains options for initializing a cache
@@ -122,6 +123,14 @@ func (r *redisProvider) NewCache(opts *CacheOptions) (Cache, error) {
 	return rr, err
 }
 
+// NewRateLimitStore creates a rate limit store sharing the provider's Redis connection.
+func (r *redisProvider) NewRateLimitStore(name string) (throttled.GCRAStoreCtx, error) {
+	if r.cachePrefix != "" {
+		name = r.cachePrefix + ":" + name
+	}
+	return NewRedisRateLimitStore(name, r.client)
+}
+
 // Connect opens a new connection to the cache using specific provider parameters.
 func (r *redisProvider) Connect() (string, error) {
 	res, err := r.client.Do(context.Background(), r.client.B().Ping().Build()).ToString()
@@ -0,0 +1,148 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/throttled/throttled/v2"
+)
+
+// RateLimitStoreProvider is implemented by cache providers which are able to
+// back a rate limiter shared by every node of the cluster.
+type RateLimitStoreProvider interface {
+	// NewRateLimitStore returns a GCRA store whose keys are namespaced under name.
+	NewRateLimitStore(name string) (throttled.GCRAStoreCtx, error)
+}
+
+// getWithTimeScript reads the key together with the Redis server time so that
+// every node computes the rate against the same clock.
+var getWithTimeScript = rueidis.NewLuaScript(`
+local v = redis.call('GET', KEYS[1])
+local t = redis.call('TIME')
+if v == false then
+	v = '-1'
+end
+return {v, t[1], t[2]}
+`)
+
+// compareAndSwapScript atomically replaces the value of the key if it still
+// holds the expected one. It returns 0 when the key is missing or has changed.
+var compareAndSwapScript = rueidis.NewLuaScript(`
+local v = redis.call('GET', KEYS[1])
+if v == false or v ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1
+`)
+
+// RedisRateLimitStore implements throttled.GCRAStoreCtx on top of Redis,
+// allowing the GCRA rate limiter to enforce quotas cluster-wide.
+type RedisRateLimitStore struct {
+	name   string
+	client rueidis.Client
+}
+
+var _ throttled.GCRAStoreCtx = (*RedisRateLimitStore)(nil)
+
+func NewRedisRateLimitStore(name string, client rueidis.Client) (*RedisRateLimitStore, error) {
+	if name == "" {
+		return nil, errors.New("no name specified for rate limit store")
+	}
+	return &RedisRateLimitStore{
+		name:   name,
+		client: client,
+	}, nil
+}
+
+// GetWithTime returns the value of the key if it is in the store or -1 if it
+// does not exist, along with the current time of the Redis server.
+func (r *RedisRateLimitStore) GetWithTime(ctx context.Context, key string) (int64, time.Time, error) {
+	vals, err := getWithTimeScript.Exec(ctx, r.client, []string{r.name + ":" + key}, nil).ToArray()
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if len(vals) != 3 {
+		return 0, time.Time{}, errors.New("unexpected response length from rate limit store")
+	}
+
+	value, err := parseRedisInt(vals[0])
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	sec, err := parseRedisInt(vals[1])
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	usec, err := parseRedisInt(vals[2])
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return value, time.Unix(sec, usec*int64(time.Microsecond)), nil
+}
+
+// SetIfNotExistsWithTTL sets the value of the key if it is not already set.
+// It returns false if the key was already present.
+func (r *RedisRateLimitStore) SetIfNotExistsWithTTL(ctx context.Context, key string, value int64, ttl time.Duration) (bool, error) {
+	err := r.client.Do(ctx,
+		r.client.B().Set().
+			Key(r.name+":"+key).
+			Value(strconv.FormatInt(value, 10)).
+			Nx().
+			PxMilliseconds(ttlMilliseconds(ttl)).
+			Build(),
+	).Error()
+	if rueidis.IsRedisNil(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CompareAndSwapWithTTL atomically sets the value of the key to new if its
+// current value is old. It returns false if the key is missing or the value
+// has been changed in the meantime.
+func (r *RedisRateLimitStore) CompareAndSwapWithTTL(ctx context.Context, key string, old, new int64, ttl time.Duration) (bool, error) {
+	swapped, err := compareAndSwapScript.Exec(ctx, r.client,
+		[]string{r.name + ":" + key},
+		[]string{
+			strconv.FormatInt(old, 10),
+			strconv.FormatInt(new, 10),
+			strconv.FormatInt(ttlMilliseconds(ttl), 10),
+		},
+	).AsInt64()
+	if err != nil {
+		return false, err
+	}
+	return swapped == 1, nil
+}
+
+func parseRedisInt(msg rueidis.RedisMessage) (int64, error) {
+	s, err := msg.ToString()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// ttlMilliseconds rounds the TTL up to the millisecond resolution supported
+// by Redis, since a zero or negative expiry is rejected by the server.
+func ttlMilliseconds(ttl time.Duration) int64 {
+	ms := ttl.Milliseconds()
+	if ttl%time.Millisecond != 0 {
+		ms++
+	}
+	if ms < 1 {
+		ms = 1
+	}
+	return ms
+}
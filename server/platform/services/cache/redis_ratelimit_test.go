@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled/v2"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// setupRedisRateLimitStore returns a rate limit store backed by the Redis test container,
+// namespaced under a random prefix so that tests don't share keys.
+func setupRedisRateLimitStore(t *testing.T) (*RedisRateLimitStore, rueidis.Client) {
+	t.Helper()
+
+	redisHost := "localhost"
+	if os.Getenv("IS_CI") == "true" {
+		redisHost = "redis"
+	}
+
+	client, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:       []string{redisHost + ":6379"},
+		ForceSingleClient: true,
+		DisableCache:      true,
+		DisableRetry:      true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	store, err := NewRedisRateLimitStore(model.NewId(), client)
+	require.NoError(t, err)
+
+	return store, client
+}
+
+func TestNewRedisRateLimitStore(t *testing.T) {
+	_, err := NewRedisRateLimitStore("", nil)
+	require.Error(t, err)
+}
+
+func TestRedisRateLimitStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("missing key", func(t *testing.T) {
+		store, _ := setupRedisRateLimitStore(t)
+
+		value, now, err := store.GetWithTime(ctx, "missing")
+		require.NoError(t, err)
+		assert.Equal(t, int64(-1), value)
+		assert.WithinDuration(t, time.Now(), now, time.Minute)
+	})
+
+	t.Run("set if not exists", func(t *testing.T) {
+		store, _ := setupRedisRateLimitStore(t)
+
+		set, err := store.SetIfNotExistsWithTTL(ctx, "key", 10, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, set)
+
+		set, err = store.SetIfNotExistsWithTTL(ctx, "key", 20, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, set)
+
+		value, _, err := store.GetWithTime(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), value)
+	})
+
+	t.Run("compare and swap", func(t *testing.T) {
+		store, client := setupRedisRateLimitStore(t)
+
+		swapped, err := store.CompareAndSwapWithTTL(ctx, "key", 10, 20, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, swapped, "a missing key should not be swapped")
+
+		_, err = store.SetIfNotExistsWithTTL(ctx, "key", 10, time.Second)
+		require.NoError(t, err)
+
+		swapped, err = store.CompareAndSwapWithTTL(ctx, "key", 15, 20, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, swapped, "a changed value should not be swapped")
+
+		swapped, err = store.CompareAndSwapWithTTL(ctx, "key", 10, 20, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, swapped)
+
+		value, _, err := store.GetWithTime(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), value)
+
+		// The swap also refreshes the TTL of the key.
+		ttl, err := client.Do(ctx, client.B().Pttl().Key(store.name+":key").Build()).AsInt64()
+		require.NoError(t, err)
+		assert.Greater(t, ttl, int64(time.Second/time.Millisecond))
+	})
+
+	t.Run("keys expire", func(t *testing.T) {
+		store, _ := setupRedisRateLimitStore(t)
+
+		// Sub-millisecond TTLs are rounded up rather than rejected by Redis.
+		set, err := store.SetIfNotExistsWithTTL(ctx, "key", 10, time.Microsecond)
+		require.NoError(t, err)
+		assert.True(t, set)
+
+		require.Eventually(t, func() bool {
+			value, _, err := store.GetWithTime(ctx, "key")
+			return err == nil && value == -1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("keys are namespaced", func(t *testing.T) {
+		store, client := setupRedisRateLimitStore(t)
+		other, err := NewRedisRateLimitStore(model.NewId(), client)
+		require.NoError(t, err)
+
+		_, err = store.SetIfNotExistsWithTTL(ctx, "key", 10, time.Minute)
+		require.NoError(t, err)
+
+		value, _, err := other.GetWithTime(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, int64(-1), value)
+	})
+
+	t.Run("rate limiter", func(t *testing.T) {
+		store, _ := setupRedisRateLimitStore(t)
+
+		// Two limiters sharing the store behave like two nodes of a cluster.
+		quota := throttled.RateQuota{MaxRate: throttled.PerMin(1), MaxBurst: 2}
+		node1, err := throttled.NewGCRARateLimiterCtx(store, quota)
+		require.NoError(t, err)
+		node2, err := throttled.NewGCRARateLimiterCtx(store, quota)
+		require.NoError(t, err)
+
+		// MaxBurst=2 → effective limit of 3
+		for i, node := range []*throttled.GCRARateLimiterCtx{node1, node2, node1} {
+			limited, result, err := node.RateLimitCtx(ctx, "user", 1)
+			require.NoError(t, err)
+			require.False(t, limited, "request %d should not be rate limited", i)
+			assert.Equal(t, 3, result.Limit)
+		}
+
+		limited, result, err := node2.RateLimitCtx(ctx, "user", 1)
+		require.NoError(t, err)
+		assert.True(t, limited)
+		assert.Equal(t, 0, result.Remaining)
+		assert.Greater(t, result.RetryAfter, time.Duration(0))
+
+		limited, _, err = node1.RateLimitCtx(ctx, "other-user", 1)
+		require.NoError(t, err)
+		assert.False(t, limited)
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+)
+
+// csvExtractor extracts the records of CSV files, treating the first record as the
+// header row. Each value is prefixed with the header of its column so that the
+// extracted text keeps the meaning of the values, e.g. "Owner: Alice".
+type csvExtractor struct{}
+
+func (ce *csvExtractor) Name() string {
+	return "csvExtractor"
+}
+
+func (ce *csvExtractor) Match(filename string) bool {
+	return fileExtension(filename) == "csv"
+}
+
+func (ce *csvExtractor) Extract(filename string, r io.ReadSeeker, maxFileSize int64) (string, error) {
+	var reader io.Reader = r
+	if maxFileSize > 0 {
+		reader = utils.NewLimitedReaderWithError(r, maxFileSize)
+	}
+	br := bufio.NewReader(reader)
+
+	// Peek at the header row to detect the separator, which depends on the locale
+	// of the application that exported the file.
+	firstLine, _ := br.Peek(br.Size())
+	if i := bytes.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+
+	cr := csv.NewReader(br)
+	cr.Comma = csvSeparator(firstLine)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.ReuseRecord = true
+
+	var header []string
+	w := newTableWriter(maxFileSize)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if header == nil {
+			header = make([]string, len(record))
+			for i, name := range record {
+				header[i] = strings.TrimSpace(name)
+			}
+			if err := w.WriteLine(strings.Join(header, "\t")); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		cells := make([]string, 0, len(record))
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if i < len(header) && header[i] != "" {
+				value = header[i] + ": " + value
+			}
+			cells = append(cells, value)
+		}
+		if len(cells) == 0 {
+			continue
+		}
+		if err := w.WriteLine(strings.Join(cells, "\t")); err != nil {
+			return "", err
+		}
+	}
+
+	if header == nil {
+		return "", errors.New("empty csv file")
+	}
+
+	return w.String(), nil
+}
+
+// csvSeparator returns the most frequent separator of the header row.
+func csvSeparator(header []byte) rune {
+	separator := ','
+	count := bytes.Count(header, []byte{','})
+	for _, candidate := range []rune{';', '\t'} {
+		if c := bytes.Count(header, []byte{byte(candidate)}); c > count {
+			separator = candidate
+			count = c
+		}
+	}
+	return separator
+}
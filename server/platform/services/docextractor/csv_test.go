@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+)
+
+func TestCsvFile(t *testing.T) {
+	extractor := csvExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.csv")
+	require.NoError(t, err)
+
+	text, err := extractor.Extract("sample-doc.csv", bytes.NewReader(content), 0)
+	require.NoError(t, err)
+	expected := "Item\tOwner\tAmount\tNotes\n" +
+		"Item: Hosting\tOwner: Alice\tAmount: 1200\tNotes: This simple document contains, among others, a quoted value\n" +
+		"Item: Licenses\tAmount: 600\n"
+	require.Equal(t, expected, text)
+}
+
+func TestCsvSeparators(t *testing.T) {
+	extractor := csvExtractor{}
+	for name, content := range map[string]string{
+		"comma":     "Name,City\nAlice,\"Paris, France\"\n",
+		"semicolon": "Name;City\nAlice;\"Paris, France\"\n",
+		"tab":       "Name\tCity\nAlice\t\"Paris, France\"\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			text, err := extractor.Extract("cities.csv", bytes.NewReader([]byte(content)), 0)
+			require.NoError(t, err)
+			assert.Equal(t, "Name\tCity\nName: Alice\tCity: Paris, France\n", text)
+		})
+	}
+}
+
+func TestCsvExtraColumns(t *testing.T) {
+	extractor := csvExtractor{}
+	text, err := extractor.Extract("extra.csv", bytes.NewReader([]byte("Name\nAlice,Bob\n")), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Name\nName: Alice\tBob\n", text)
+}
+
+func TestCsvMaxFileSize(t *testing.T) {
+	extractor := csvExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.csv")
+	require.NoError(t, err)
+
+	t.Run("a generous limit extracts the content", func(t *testing.T) {
+		text, err := extractor.Extract("sample-doc.csv", bytes.NewReader(content), 10*1024*1024)
+		require.NoError(t, err)
+		assert.Contains(t, text, "Owner: Alice")
+	})
+
+	t.Run("a tiny limit prevents the content from being extracted", func(t *testing.T) {
+		text, err := extractor.Extract("sample-doc.csv", bytes.NewReader(content), 16)
+		require.Error(t, err)
+		assert.Empty(t, text)
+	})
+}
@@ -51,6 +51,11 @@ func ExtractWithExtraExtractors(logger mlog.LoggerIFace, filename string, r io.R
 	}
 	enabledExtractors.Add(&documentExtractor{})
 	enabledExtractors.Add(&pdfExtractor{})
+	enabledExtractors.Add(&xlsxExtractor{})
+	enabledExtractors.Add(&openDocumentExtractor{})
+	enabledExtractors.Add(&csvExtractor{})
+	enabledExtractors.Add(&epubExtractor{})
+	enabledExtractors.Add(&emlExtractor{})
 
 	if settings.ArchiveRecursion {
 		enabledExtractors.Add(&archiveExtractor{SubExtractor: enabledExtractors})
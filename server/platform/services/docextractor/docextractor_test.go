@@ -126,6 +126,54 @@ func TestExtract(t *testing.T) {
 			[]string{},
 			false,
 		},
+		{
+			"Xlsx file",
+			"sample-doc.xlsx",
+			ExtractSettings{},
+			[]string{"simple", "document", "contains"},
+			[]string{},
+			false,
+		},
+		{
+			"Ods file",
+			"sample-doc.ods",
+			ExtractSettings{},
+			[]string{"simple", "document", "contains"},
+			[]string{},
+			false,
+		},
+		{
+			"Odp file",
+			"sample-doc.odp",
+			ExtractSettings{},
+			[]string{"simple", "document", "contains"},
+			[]string{},
+			false,
+		},
+		{
+			"Csv file",
+			"sample-doc.csv",
+			ExtractSettings{},
+			[]string{"simple", "document", "contains"},
+			[]string{},
+			false,
+		},
+		{
+			"Epub file",
+			"sample-doc.epub",
+			ExtractSettings{},
+			[]string{"simple", "document", "contains"},
+			[]string{},
+			false,
+		},
+		{
+			"Eml file",
+			"sample-doc.eml",
+			ExtractSettings{},
+			[]string{"simple", "document", "contains"},
+			[]string{},
+			false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,221 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+)
+
+// maxEmailPartDepth bounds the nesting of multipart bodies and forwarded messages.
+const maxEmailPartDepth = 10
+
+// emailHeaders are the headers of a message that are included in the extracted text.
+var emailHeaders = []string{"Subject", "From", "To", "Cc", "Date"}
+
+// emlExtractor extracts the headers and the text body of email messages, including
+// those of the messages forwarded as attachments. Other attachments are only listed
+// by file name.
+type emlExtractor struct{}
+
+func (ee *emlExtractor) Name() string {
+	return "emlExtractor"
+}
+
+func (ee *emlExtractor) Match(filename string) bool {
+	return fileExtension(filename) == "eml"
+}
+
+func (ee *emlExtractor) Extract(filename string, r io.ReadSeeker, maxFileSize int64) (out string, outErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = ""
+			outErr = errors.New("error extracting email text")
+		}
+	}()
+
+	var reader io.Reader = r
+	if maxFileSize > 0 {
+		reader = utils.NewLimitedReaderWithError(r, maxFileSize)
+	}
+
+	w := newTableWriter(maxFileSize)
+	if err := writeEmailMessage(w, reader, 0); err != nil {
+		return "", err
+	}
+
+	return w.String(), nil
+}
+
+var emailWordDecoder = &mime.WordDecoder{
+	CharsetReader: charset.NewReaderLabel,
+}
+
+func writeEmailMessage(w *tableWriter, r io.Reader, depth int) error {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range emailHeaders {
+		value := msg.Header.Get(name)
+		if value == "" {
+			continue
+		}
+		if decoded, err := emailWordDecoder.DecodeHeader(value); err == nil {
+			value = decoded
+		}
+		if err := w.WriteLine(name + ": " + value); err != nil {
+			return err
+		}
+	}
+
+	return writeEmailPart(w, textproto.MIMEHeader(msg.Header), msg.Body, depth)
+}
+
+func writeEmailPart(w *tableWriter, header textproto.MIMEHeader, body io.Reader, depth int) error {
+	if depth > maxEmailPartDepth {
+		return nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		// Messages without a valid content type are plain text.
+		mediaType = "text/plain"
+		params = map[string]string{}
+	}
+
+	if _, dispositionParams, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil {
+		if filename := dispositionParams["filename"]; filename != "" && mediaType != "message/rfc822" {
+			if decoded, err := emailWordDecoder.DecodeHeader(filename); err == nil {
+				filename = decoded
+			}
+			return w.WriteLine(filename)
+		}
+	}
+
+	body = emailPartDecoder(header.Get("Content-Transfer-Encoding"), body)
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		return writeEmailMultipart(w, mediaType, params["boundary"], body, depth)
+	case mediaType == "message/rfc822":
+		return writeEmailMessage(w, body, depth+1)
+	case mediaType == "text/plain" || mediaType == "text/html":
+		text, err := emailPartText(mediaType, params["charset"], body)
+		if err != nil {
+			return err
+		}
+		if text == "" {
+			return nil
+		}
+		return w.WriteLine(text)
+	default:
+		if name := params["name"]; name != "" {
+			return w.WriteLine(name)
+		}
+		return nil
+	}
+}
+
+func writeEmailMultipart(w *tableWriter, mediaType, boundary string, body io.Reader, depth int) error {
+	if boundary == "" {
+		return errors.New("multipart email body without boundary")
+	}
+
+	mr := multipart.NewReader(body, boundary)
+	var alternatives []emailAlternative
+	for {
+		part, err := mr.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if mediaType != "multipart/alternative" {
+			if err := writeEmailPart(w, part.Header, part, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Only one of the alternatives is written, so they are buffered until the
+		// preferred one is known.
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return err
+		}
+		alternatives = append(alternatives, emailAlternative{header: part.Header, body: data})
+	}
+
+	if len(alternatives) == 0 {
+		return nil
+	}
+
+	// Prefer the plain text version of the message, falling back to the last one
+	// which is meant to be the richest.
+	preferred := alternatives[len(alternatives)-1]
+	for _, alternative := range alternatives {
+		if mediaType, _, err := mime.ParseMediaType(alternative.header.Get("Content-Type")); err == nil && mediaType == "text/plain" {
+			preferred = alternative
+			break
+		}
+	}
+
+	return writeEmailPart(w, preferred.header, bytes.NewReader(preferred.body), depth+1)
+}
+
+type emailAlternative struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+func emailPartDecoder(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+func emailPartText(mediaType, charsetLabel string, body io.Reader) (string, error) {
+	if charsetLabel != "" && !strings.EqualFold(charsetLabel, "utf-8") && !strings.EqualFold(charsetLabel, "us-ascii") {
+		decoded, err := charset.NewReaderLabel(charsetLabel, body)
+		if err == nil {
+			body = decoded
+		}
+	}
+
+	if mediaType == "text/html" {
+		text, err := htmlText(body)
+		if err != nil {
+			return "", fmt.Errorf("error converting html email body: %w", err)
+		}
+		return strings.TrimSpace(text), nil
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
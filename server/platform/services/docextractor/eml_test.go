@@ -0,0 +1,58 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+)
+
+func TestEmlFile(t *testing.T) {
+	extractor := emlExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.eml")
+	require.NoError(t, err)
+
+	text, err := extractor.Extract("sample-doc.eml", bytes.NewReader(content), 0)
+	require.NoError(t, err)
+	expected := "Subject: Fwd: Quarterly résumé\n" +
+		"From: Alice Sender <alice@example.com>\n" +
+		"To: Bob Receiver <bob@example.com>\n" +
+		"Date: Mon, 1 Jan 2024 10:00:00 +0000\n" +
+		"This simple document contains the quarterly résumé and a forwarded message.\n" +
+		"budget-report.pdf\n" +
+		"Subject: Original planning notes\n" +
+		"From: Carol <carol@example.com>\n" +
+		"To: Alice Sender <alice@example.com>\n" +
+		"Carol's planning notes for the offsite in Zürich.\n"
+	require.Equal(t, expected, text)
+}
+
+func TestEmlHTMLBody(t *testing.T) {
+	extractor := emlExtractor{}
+	content := strings.Join([]string{
+		"Subject: Newsletter",
+		"Content-Type: text/html; charset=utf-8",
+		"",
+		"<html><head><style>p { color: red; }</style></head><body><h1>News</h1><p>The <b>launch</b> is&nbsp;today.</p><script>alert(1)</script></body></html>",
+	}, "\r\n")
+
+	text, err := extractor.Extract("newsletter.eml", bytes.NewReader([]byte(content)), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Subject: Newsletter\nNews\nThe launch is today.\n", text)
+}
+
+func TestEmlMaxFileSize(t *testing.T) {
+	extractor := emlExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.eml")
+	require.NoError(t, err)
+
+	_, err = extractor.Extract("sample-doc.eml", bytes.NewReader(content), 16)
+	require.Error(t, err)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// epubExtractor extracts the metadata and the text of the chapters of EPUB books,
+// in reading order.
+type epubExtractor struct{}
+
+func (ee *epubExtractor) Name() string {
+	return "epubExtractor"
+}
+
+func (ee *epubExtractor) Match(filename string) bool {
+	return fileExtension(filename) == "epub"
+}
+
+func (ee *epubExtractor) Extract(filename string, r io.ReadSeeker, maxFileSize int64) (out string, outErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = ""
+			outErr = errors.New("error extracting epub text")
+		}
+	}()
+
+	zr, err := openZip(r, maxFileSize)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := readZipFile(zr, "META-INF/container.xml", maxFileSize)
+	if err != nil {
+		return "", err
+	}
+	var container struct {
+		RootFiles []struct {
+			FullPath string `xml:"full-path,attr"`
+		} `xml:"rootfiles>rootfile"`
+	}
+	if err = xml.Unmarshal(data, &container); err != nil {
+		return "", err
+	}
+	if len(container.RootFiles) == 0 {
+		return "", errors.New("epub package document not found")
+	}
+
+	packagePath := container.RootFiles[0].FullPath
+	data, err = readZipFile(zr, packagePath, maxFileSize)
+	if err != nil {
+		return "", err
+	}
+	var pkg struct {
+		Titles   []string `xml:"metadata>title"`
+		Creators []string `xml:"metadata>creator"`
+		Items    []struct {
+			ID   string `xml:"id,attr"`
+			Href string `xml:"href,attr"`
+		} `xml:"manifest>item"`
+		ItemRefs []struct {
+			IDRef string `xml:"idref,attr"`
+		} `xml:"spine>itemref"`
+	}
+	if err = xml.Unmarshal(data, &pkg); err != nil {
+		return "", err
+	}
+
+	w := newTableWriter(maxFileSize)
+	for _, line := range append(pkg.Titles, pkg.Creators...) {
+		if err = w.WriteLine(strings.TrimSpace(line)); err != nil {
+			return "", err
+		}
+	}
+
+	hrefs := make(map[string]string, len(pkg.Items))
+	for _, item := range pkg.Items {
+		hrefs[item.ID] = item.Href
+	}
+
+	for _, itemRef := range pkg.ItemRefs {
+		href, ok := hrefs[itemRef.IDRef]
+		if !ok {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(href); err == nil {
+			href = unescaped
+		}
+
+		data, err := readZipFile(zr, path.Join(path.Dir(packagePath), href), maxFileSize)
+		if err != nil {
+			return "", err
+		}
+		text, err := htmlText(bytes.NewReader(data))
+		if err != nil {
+			return "", fmt.Errorf("error converting %s: %w", href, err)
+		}
+		if text = strings.TrimSpace(text); text == "" {
+			continue
+		}
+		if err = w.WriteLine(text); err != nil {
+			return "", err
+		}
+	}
+
+	return w.String(), nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+)
+
+func TestEpubFile(t *testing.T) {
+	extractor := epubExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.epub")
+	require.NoError(t, err)
+
+	// The chapters are extracted in reading order, not in the order of the manifest.
+	text, err := extractor.Extract("sample-doc.epub", bytes.NewReader(content), 0)
+	require.NoError(t, err)
+	expected := "Sample Handbook\n" +
+		"Jane Writer\n" +
+		"Introduction\n" +
+		"This simple document contains two chapters.\n" +
+		"Conclusion\n" +
+		"The second chapter wraps up the handbook.\n"
+	require.Equal(t, expected, text)
+}
+
+func TestWrongEpubFile(t *testing.T) {
+	extractor := epubExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.docx")
+	require.NoError(t, err)
+	_, err = extractor.Extract("sample-doc.epub", bytes.NewReader(content), 0)
+	require.Error(t, err)
+}
+
+func TestEpubMaxFileSize(t *testing.T) {
+	extractor := epubExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.epub")
+	require.NoError(t, err)
+
+	_, err = extractor.Extract("sample-doc.epub", bytes.NewReader(content), 16)
+	require.Error(t, err)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlText returns the visible text of an HTML or XHTML document, one line per block
+// element. Unlike docconv.ConvertHTML, it doesn't depend on the tidy binary being
+// installed.
+func htmlText(r io.Reader) (string, error) {
+	var text, line strings.Builder
+	flushLine := func() {
+		if s := strings.Join(strings.Fields(line.String()), " "); s != "" {
+			text.WriteString(s + "\n")
+		}
+		line.Reset()
+	}
+
+	// skipDepth counts the open elements whose content isn't visible.
+	skipDepth := 0
+	tokenizer := html.NewTokenizer(r)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", err
+			}
+			flushLine()
+			return text.String(), nil
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			a := atom.Lookup(name)
+			if htmlHiddenElements[a] {
+				skipDepth++
+			} else if htmlBlockElements[a] {
+				flushLine()
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			a := atom.Lookup(name)
+			if htmlHiddenElements[a] {
+				skipDepth = max(skipDepth-1, 0)
+			} else if htmlBlockElements[a] {
+				flushLine()
+			} else if a == atom.Td || a == atom.Th {
+				line.WriteString(" ")
+			}
+		case html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			if htmlBlockElements[atom.Lookup(name)] {
+				flushLine()
+			}
+		case html.TextToken:
+			if skipDepth == 0 {
+				line.Write(tokenizer.Text())
+			}
+		}
+	}
+}
+
+var htmlHiddenElements = map[atom.Atom]bool{
+	atom.Head:     true,
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Template: true,
+	atom.Noscript: true,
+}
+
+var htmlBlockElements = map[atom.Atom]bool{
+	atom.Address:    true,
+	atom.Article:    true,
+	atom.Aside:      true,
+	atom.Blockquote: true,
+	atom.Br:         true,
+	atom.Dd:         true,
+	atom.Div:        true,
+	atom.Dt:         true,
+	atom.Figcaption: true,
+	atom.Footer:     true,
+	atom.H1:         true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.H4:         true,
+	atom.H5:         true,
+	atom.H6:         true,
+	atom.Header:     true,
+	atom.Hr:         true,
+	atom.Li:         true,
+	atom.P:          true,
+	atom.Pre:        true,
+	atom.Section:    true,
+	atom.Tr:         true,
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	odfTableNamespace = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	odfTextNamespace  = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+)
+
+// openDocumentExtractor extracts the text of OpenDocument spreadsheets and presentations.
+// Tables are written one line per row, and paragraphs one per line.
+type openDocumentExtractor struct{}
+
+func (oe *openDocumentExtractor) Name() string {
+	return "openDocumentExtractor"
+}
+
+func (oe *openDocumentExtractor) Match(filename string) bool {
+	switch fileExtension(filename) {
+	case "ods", "odp":
+		return true
+	}
+	return false
+}
+
+func (oe *openDocumentExtractor) Extract(filename string, r io.ReadSeeker, maxFileSize int64) (out string, outErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = ""
+			outErr = errors.New("error extracting OpenDocument text")
+		}
+	}()
+
+	zr, err := openZip(r, maxFileSize)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := readZipFile(zr, "content.xml", maxFileSize)
+	if err != nil {
+		return "", err
+	}
+
+	return odfContentText(data, maxFileSize)
+}
+
+// odfContentText returns the text of the content.xml file of an OpenDocument file.
+func odfContentText(data []byte, maxFileSize int64) (string, error) {
+	w := newTableWriter(maxFileSize)
+
+	var (
+		// tableDepth counts the nested tables, only the cells of the outermost
+		// tables are written as such.
+		tableDepth   int
+		rowRepeat    int
+		cellRepeat   int
+		cellText     []string
+		paragraph    strings.Builder
+		paragraphs   int
+		defaultValue string
+	)
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return w.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Space {
+			case odfTableNamespace:
+				switch t.Name.Local {
+				case "table":
+					tableDepth++
+					if tableDepth == 1 {
+						if err := w.StartTable(odfAttr(t, odfTableNamespace, "name")); err != nil {
+							return "", err
+						}
+					}
+				case "table-row":
+					if tableDepth == 1 {
+						rowRepeat = odfRepeat(t, "number-rows-repeated")
+					}
+				case "table-cell", "covered-table-cell":
+					if tableDepth == 1 {
+						cellRepeat = odfRepeat(t, "number-columns-repeated")
+						cellText = cellText[:0]
+						defaultValue = odfCellValue(t)
+					}
+				}
+			case odfTextNamespace:
+				switch t.Name.Local {
+				case "p", "h":
+					paragraphs++
+					if paragraphs == 1 {
+						paragraph.Reset()
+					}
+				case "s":
+					paragraph.WriteString(" ")
+				case "tab":
+					paragraph.WriteString("\t")
+				case "line-break":
+					paragraph.WriteString(" ")
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Space {
+			case odfTableNamespace:
+				switch t.Name.Local {
+				case "table":
+					tableDepth--
+				case "table-row":
+					if tableDepth == 1 {
+						if err := w.EndRow(rowRepeat); err != nil {
+							return "", err
+						}
+					}
+				case "table-cell", "covered-table-cell":
+					if tableDepth == 1 {
+						value := strings.Join(cellText, " ")
+						if value == "" {
+							value = defaultValue
+						}
+						w.AddCell(value, cellRepeat)
+					}
+				}
+			case odfTextNamespace:
+				switch t.Name.Local {
+				case "p", "h":
+					paragraphs--
+					if paragraphs > 0 {
+						continue
+					}
+					text := strings.TrimSpace(paragraph.String())
+					if text == "" {
+						continue
+					}
+					if tableDepth > 0 {
+						cellText = append(cellText, text)
+					} else if err := w.WriteLine(text); err != nil {
+						return "", err
+					}
+				}
+			}
+		case xml.CharData:
+			if paragraphs > 0 {
+				paragraph.Write(t)
+			}
+		}
+	}
+}
+
+func odfAttr(element xml.StartElement, space, local string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Space == space && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func odfRepeat(element xml.StartElement, name string) int {
+	repeat, err := strconv.Atoi(odfAttr(element, odfTableNamespace, name))
+	if err != nil || repeat < 1 {
+		return 1
+	}
+	return repeat
+}
+
+// odfCellValue returns the value of a cell that has no text, such as a cell whose
+// content is hidden by its format.
+func odfCellValue(element xml.StartElement) string {
+	for _, attr := range element.Attr {
+		switch attr.Name.Local {
+		case "value", "date-value", "time-value", "boolean-value", "string-value":
+			return attr.Value
+		}
+	}
+	return ""
+}
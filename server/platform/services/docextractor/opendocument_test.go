@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+)
+
+func TestOpenDocumentSpreadsheet(t *testing.T) {
+	extractor := openDocumentExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.ods")
+	require.NoError(t, err)
+
+	// The fixture pads its rows with repeated empty cells and ends with a run of
+	// more than a million repeated empty rows, as spreadsheet applications do.
+	text, err := extractor.Extract("sample-doc.ods", bytes.NewReader(content), 0)
+	require.NoError(t, err)
+	expected := "Budget\n" +
+		"Item\tOwner\tAmount\n" +
+		"Hosting\tAlice\t1200\n" +
+		"Licenses\t\t600\n" +
+		"\n" +
+		"Notes\n" +
+		"This simple document contains two sheets\n"
+	require.Equal(t, expected, text)
+}
+
+func TestOpenDocumentPresentation(t *testing.T) {
+	extractor := openDocumentExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.odp")
+	require.NoError(t, err)
+
+	text, err := extractor.Extract("sample-doc.odp", bytes.NewReader(content), 0)
+	require.NoError(t, err)
+	require.Equal(t, "Title\nThis is a simple document that contains some text.\n", text)
+}
+
+func TestOpenDocumentRepeatedCells(t *testing.T) {
+	content := []byte(`<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+<office:body><office:spreadsheet><table:table table:name="Sheet1">
+<table:table-row table:number-rows-repeated="1000000"><table:table-cell table:number-columns-repeated="1000000"><text:p>x</text:p></table:table-cell></table:table-row>
+</table:table></office:spreadsheet></office:body></office:document-content>`)
+
+	t.Run("repeated cells and rows are capped", func(t *testing.T) {
+		text, err := odfContentText(content, 0)
+		require.NoError(t, err)
+		assert.Less(t, len(text), maxRepeatedCells*maxRepeatedCells*2+100)
+	})
+
+	t.Run("the extracted text is limited to the max file size", func(t *testing.T) {
+		text, err := odfContentText(content, 1024)
+		require.Error(t, err)
+		assert.Empty(t, text)
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+)
+
+const (
+	// maxRepeatedCells bounds how many times a repeated cell or row is written out, since
+	// spreadsheets commonly declare runs of thousands of identical cells and rows.
+	maxRepeatedCells = 256
+	// maxColumns is the largest number of columns a spreadsheet row can have.
+	maxColumns = 16384
+)
+
+// tableWriter renders tables as one line per row, with the cells separated by tabs,
+// so that the content of adjacent cells isn't glued together in the extracted text.
+type tableWriter struct {
+	text    strings.Builder
+	maxSize int64
+
+	row []string
+	// emptyCells counts the empty cells added after the last non-empty one of the
+	// current row, which are only written if another value follows them.
+	emptyCells int
+}
+
+func newTableWriter(maxSize int64) *tableWriter {
+	return &tableWriter{maxSize: maxSize}
+}
+
+// StartTable writes the name of a new table, such as the name of a sheet.
+func (w *tableWriter) StartTable(name string) error {
+	if w.text.Len() > 0 {
+		w.text.WriteString("\n")
+	}
+	if name != "" {
+		w.text.WriteString(name + "\n")
+	}
+	return w.checkSize()
+}
+
+// SetCell sets the value of the cell at the given column of the current row.
+func (w *tableWriter) SetCell(column int, value string) {
+	if column < 0 || column >= maxColumns || value == "" {
+		return
+	}
+	for len(w.row) <= column {
+		w.row = append(w.row, "")
+	}
+	w.row[column] = value
+}
+
+// AddCell appends a cell to the current row, repeated the given number of times.
+func (w *tableWriter) AddCell(value string, repeat int) {
+	if value == "" {
+		w.emptyCells += repeat
+		return
+	}
+
+	for ; w.emptyCells > 0 && len(w.row) < maxColumns; w.emptyCells-- {
+		w.row = append(w.row, "")
+	}
+	w.emptyCells = 0
+
+	for range min(repeat, maxRepeatedCells) {
+		if len(w.row) >= maxColumns {
+			break
+		}
+		w.row = append(w.row, value)
+	}
+}
+
+// EndRow writes the current row, repeated the given number of times. Empty rows
+// are skipped.
+func (w *tableWriter) EndRow(repeat int) error {
+	row := w.row
+	w.row = w.row[:0]
+	w.emptyCells = 0
+
+	if len(row) == 0 {
+		return nil
+	}
+
+	line := strings.Join(row, "\t") + "\n"
+	for range min(repeat, maxRepeatedCells) {
+		w.text.WriteString(line)
+		if err := w.checkSize(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteLine writes a line of text outside of any table.
+func (w *tableWriter) WriteLine(line string) error {
+	w.text.WriteString(line + "\n")
+	return w.checkSize()
+}
+
+func (w *tableWriter) String() string {
+	return w.text.String()
+}
+
+func (w *tableWriter) checkSize() error {
+	if w.maxSize > 0 && int64(w.text.Len()) > w.maxSize {
+		return utils.ErrSizeLimitExceeded
+	}
+	return nil
+}
+
+// openZip reads a zip based document, such as an Office Open XML or an OpenDocument
+// file, in memory.
+func openZip(r io.Reader, maxFileSize int64) (*zip.Reader, error) {
+	if maxFileSize > 0 {
+		r = utils.NewLimitedReaderWithError(r, maxFileSize)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return zip.NewReader(bytes.NewReader(data), int64(len(data)))
+}
+
+// readZipFile returns the decompressed content of the named zip entry, limited to
+// maxFileSize to prevent memory exhaustion from zip bombs.
+func readZipFile(zr *zip.Reader, name string, maxFileSize int64) ([]byte, error) {
+	name = path.Clean(strings.TrimPrefix(name, "/"))
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+
+		file, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		var reader io.Reader = file
+		if maxFileSize > 0 {
+			reader = utils.NewLimitedReaderWithError(file, maxFileSize)
+		}
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", name, err)
+		}
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("%s not found", name)
+}
+
+// fileExtension returns the lower cased extension of filename without the leading dot.
+func fileExtension(filename string) string {
+	return strings.ToLower(strings.TrimPrefix(path.Ext(filename), "."))
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// xlsxExtractor extracts the cells of Office Open XML spreadsheets, one line per row.
+type xlsxExtractor struct{}
+
+func (xe *xlsxExtractor) Name() string {
+	return "xlsxExtractor"
+}
+
+func (xe *xlsxExtractor) Match(filename string) bool {
+	return fileExtension(filename) == "xlsx"
+}
+
+func (xe *xlsxExtractor) Extract(filename string, r io.ReadSeeker, maxFileSize int64) (out string, outErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out = ""
+			outErr = errors.New("error extracting xlsx text")
+		}
+	}()
+
+	zr, err := openZip(r, maxFileSize)
+	if err != nil {
+		return "", err
+	}
+
+	sheets, err := xlsxSheets(zr, maxFileSize)
+	if err != nil {
+		return "", err
+	}
+
+	// The shared strings are missing from workbooks without any text cell.
+	var sharedStrings []string
+	if data, err := readZipFile(zr, "xl/sharedStrings.xml", maxFileSize); err == nil {
+		if sharedStrings, err = xlsxSharedStrings(data); err != nil {
+			return "", err
+		}
+	}
+
+	w := newTableWriter(maxFileSize)
+	for _, sheet := range sheets {
+		data, err := readZipFile(zr, sheet.path, maxFileSize)
+		if err != nil {
+			return "", err
+		}
+		if err := w.StartTable(sheet.name); err != nil {
+			return "", err
+		}
+		if err := xlsxWriteSheet(w, data, sharedStrings); err != nil {
+			return "", err
+		}
+	}
+
+	return w.String(), nil
+}
+
+type xlsxSheet struct {
+	name string
+	path string
+}
+
+// xlsxSheets returns the sheets of the workbook, in order.
+func xlsxSheets(zr *zip.Reader, maxFileSize int64) ([]xlsxSheet, error) {
+	data, err := readZipFile(zr, "xl/workbook.xml", maxFileSize)
+	if err != nil {
+		return nil, err
+	}
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := xml.Unmarshal(data, &workbook); err != nil {
+		return nil, err
+	}
+
+	data, err = readZipFile(zr, "xl/_rels/workbook.xml.rels", maxFileSize)
+	if err != nil {
+		return nil, err
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := xml.Unmarshal(data, &rels); err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]string, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		target := rel.Target
+		if !strings.HasPrefix(target, "/") {
+			target = path.Join("xl", target)
+		}
+		targets[rel.ID] = target
+	}
+
+	sheets := make([]xlsxSheet, 0, len(workbook.Sheets))
+	for _, sheet := range workbook.Sheets {
+		if target, ok := targets[sheet.RID]; ok {
+			sheets = append(sheets, xlsxSheet{name: sheet.Name, path: target})
+		}
+	}
+	return sheets, nil
+}
+
+// xlsxSharedStrings returns the strings that text cells refer to by index.
+func xlsxSharedStrings(data []byte) ([]string, error) {
+	var sharedStrings []string
+	var current strings.Builder
+	inText := false
+	// Phonetic runs repeat the text of the string for East Asian languages.
+	inPhonetic := false
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return sharedStrings, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "si":
+				current.Reset()
+			case "t":
+				inText = !inPhonetic
+			case "rPh":
+				inPhonetic = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "si":
+				sharedStrings = append(sharedStrings, current.String())
+			case "t":
+				inText = false
+			case "rPh":
+				inPhonetic = false
+			}
+		case xml.CharData:
+			if inText {
+				current.Write(t)
+			}
+		}
+	}
+}
+
+// xlsxWriteSheet writes the rows of a worksheet.
+func xlsxWriteSheet(w *tableWriter, data []byte, sharedStrings []string) error {
+	var (
+		cellType   string
+		column     int
+		nextColumn int
+		value      strings.Builder
+		inValue    bool
+	)
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "row":
+				nextColumn = 0
+			case "c":
+				cellType = ""
+				column = nextColumn
+				value.Reset()
+				for _, attr := range t.Attr {
+					switch attr.Name.Local {
+					case "t":
+						cellType = attr.Value
+					case "r":
+						if c, ok := xlsxColumnIndex(attr.Value); ok {
+							column = c
+						}
+					}
+				}
+				nextColumn = column + 1
+			case "v", "t":
+				inValue = true
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "row":
+				if err := w.EndRow(1); err != nil {
+					return err
+				}
+			case "c":
+				w.SetCell(column, xlsxCellValue(cellType, strings.TrimSpace(value.String()), sharedStrings))
+			case "v", "t":
+				inValue = false
+			}
+		case xml.CharData:
+			if inValue {
+				value.Write(t)
+			}
+		}
+	}
+}
+
+func xlsxCellValue(cellType, value string, sharedStrings []string) string {
+	switch cellType {
+	case "s":
+		index, err := strconv.Atoi(value)
+		if err != nil || index < 0 || index >= len(sharedStrings) {
+			return ""
+		}
+		return sharedStrings[index]
+	case "b":
+		if value == "1" {
+			return "TRUE"
+		}
+		return "FALSE"
+	default:
+		return value
+	}
+}
+
+// xlsxColumnIndex returns the zero based column of a cell reference such as "AB12".
+func xlsxColumnIndex(reference string) (int, bool) {
+	column := 0
+	letters := 0
+	for _, c := range reference {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		column = column*26 + int(c-'A'+1)
+		letters++
+		if column > maxColumns {
+			return 0, false
+		}
+	}
+	if letters == 0 {
+		return 0, false
+	}
+	return column - 1, true
+}
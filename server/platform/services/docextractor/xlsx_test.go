@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package docextractor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+)
+
+func TestXlsxFile(t *testing.T) {
+	extractor := xlsxExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.xlsx")
+	require.NoError(t, err)
+
+	text, err := extractor.Extract("sample-doc.xlsx", bytes.NewReader(content), 0)
+	require.NoError(t, err)
+	expected := "Budget\n" +
+		"Item\tOwner\tAmount\tApproved\n" +
+		"Hosting\tAlice\t1200\tTRUE\n" +
+		"Licenses\t\t600\tFALSE\n" +
+		"Total\t\t1800\n" +
+		"\n" +
+		"Notes\n" +
+		"This simple document contains two sheets\n"
+	require.Equal(t, expected, text)
+}
+
+func TestWrongXlsxFile(t *testing.T) {
+	extractor := xlsxExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.pdf")
+	require.NoError(t, err)
+	_, err = extractor.Extract("sample-doc.xlsx", bytes.NewReader(content), 0)
+	require.Error(t, err)
+}
+
+func TestXlsxMaxFileSize(t *testing.T) {
+	extractor := xlsxExtractor{}
+	content, err := testutils.ReadTestFile("sample-doc.xlsx")
+	require.NoError(t, err)
+
+	t.Run("a generous limit extracts the content", func(t *testing.T) {
+		text, err := extractor.Extract("sample-doc.xlsx", bytes.NewReader(content), 10*1024*1024)
+		require.NoError(t, err)
+		assert.Contains(t, text, "Hosting")
+	})
+
+	t.Run("a tiny limit prevents the content from being extracted", func(t *testing.T) {
+		text, err := extractor.Extract("sample-doc.xlsx", bytes.NewReader(content), 16)
+		require.Error(t, err)
+		assert.Empty(t, text)
+	})
+}
+
+func TestXlsxColumnIndex(t *testing.T) {
+	for reference, expected := range map[string]int{
+		"A1":      0,
+		"B7":      1,
+		"Z3":      25,
+		"AA10":    26,
+		"XFD1":    maxColumns - 1,
+		"XFE1":    -1,
+		"12":      -1,
+		"ZZZZZZ1": -1,
+	} {
+		column, ok := xlsxColumnIndex(reference)
+		if expected < 0 {
+			assert.False(t, ok, reference)
+			continue
+		}
+		assert.True(t, ok, reference)
+		assert.Equal(t, expected, column, reference)
+	}
+}
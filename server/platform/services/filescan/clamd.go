@@ -0,0 +1,105 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filescan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// clamdScanner scans files with the INSTREAM command of clamd, the ClamAV daemon.
+type clamdScanner struct {
+	network string
+	address string
+}
+
+func newClamdScanner(address *url.URL) (*clamdScanner, error) {
+	switch address.Scheme {
+	case "tcp":
+		return &clamdScanner{network: "tcp", address: address.Host}, nil
+	case "unix":
+		return &clamdScanner{network: "unix", address: address.Path}, nil
+	default:
+		return nil, fmt.Errorf("unsupported clamd address scheme %q", address.Scheme)
+	}
+}
+
+func (s *clamdScanner) Scan(ctx context.Context, _ string, file io.Reader) (*Result, error) {
+	conn, closeConn, err := dial(ctx, s.network, s.address)
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	writeErr := writeClamdStream(conn, file)
+
+	// clamd replies and closes the connection as soon as the stream exceeds its
+	// size limit, so its reply is read even when the file could not be written.
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		if writeErr != nil {
+			return nil, errors.Wrap(writeErr, "failed to send the file to clamd")
+		}
+		return nil, errors.Wrap(err, "failed to read the reply of clamd")
+	}
+
+	return parseClamdReply(reply)
+}
+
+// writeClamdStream sends a file with the INSTREAM command: the file is sent in chunks,
+// each prefixed by its length, and a chunk of length zero ends the stream.
+func writeClamdStream(w io.Writer, file io.Reader) error {
+	bw := bufio.NewWriterSize(w, chunkSize+4)
+	if _, err := bw.WriteString("zINSTREAM\x00"); err != nil {
+		return err
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(file, buf)
+		if n > 0 {
+			if err := binary.Write(bw, binary.BigEndian, uint32(n)); err != nil {
+				return err
+			}
+			if _, err := bw.Write(buf[:n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			return errors.Wrap(err, "failed to read the file")
+		}
+	}
+
+	if err := binary.Write(bw, binary.BigEndian, uint32(0)); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// parseClamdReply parses replies such as "stream: OK" or "stream: Eicar-Signature FOUND".
+func parseClamdReply(reply string) (*Result, error) {
+	reply = strings.TrimRight(reply, "\x00\n")
+	reply = strings.TrimPrefix(reply, "stream: ")
+
+	switch {
+	case reply == "OK":
+		return &Result{}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return &Result{Infected: true, Threat: strings.TrimSuffix(reply, " FOUND")}, nil
+	case strings.HasSuffix(reply, " ERROR"):
+		return nil, fmt.Errorf("clamd failed to scan the file: %s", strings.TrimSuffix(reply, " ERROR"))
+	default:
+		return nil, fmt.Errorf("unexpected reply from clamd: %q", reply)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filescan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// startFakeClamd starts a clamd server that reads INSTREAM commands and replies with the result of reply.
+func startFakeClamd(t *testing.T, reply func(data []byte) string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				r := bufio.NewReader(conn)
+				command, err := r.ReadString(0)
+				if err != nil || command != "zINSTREAM\x00" {
+					conn.Write([]byte("UNKNOWN COMMAND\x00"))
+					return
+				}
+
+				var data []byte
+				for {
+					var size uint32
+					if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+						return
+					}
+					if size == 0 {
+						break
+					}
+					chunk := make([]byte, size)
+					if _, err := io.ReadFull(r, chunk); err != nil {
+						return
+					}
+					data = append(data, chunk...)
+				}
+
+				conn.Write([]byte(reply(data) + "\x00"))
+			}()
+		}
+	}()
+
+	return "tcp://" + listener.Addr().String()
+}
+
+func TestClamdScanner(t *testing.T) {
+	address := startFakeClamd(t, func(data []byte) string {
+		switch {
+		case bytes.Contains(data, []byte("EICAR")):
+			return "stream: Eicar-Signature FOUND"
+		case bytes.Contains(data, []byte("too large")):
+			return "INSTREAM size limit exceeded. ERROR"
+		default:
+			return "stream: OK"
+		}
+	})
+
+	scanner, err := New(model.FileScanSettings{
+		Type:    new(model.FileScanTypeClamd),
+		Address: new(address),
+	})
+	require.NoError(t, err)
+
+	t.Run("clean file", func(t *testing.T) {
+		// Larger than a chunk, to send the file in several chunks.
+		data := bytes.Repeat([]byte("a"), chunkSize*2+10)
+
+		result, err := scanner.Scan(context.Background(), "file.txt", bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.False(t, result.Infected)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		result, err := scanner.Scan(context.Background(), "file.txt", bytes.NewReader(nil))
+		require.NoError(t, err)
+		assert.False(t, result.Infected)
+	})
+
+	t.Run("infected file", func(t *testing.T) {
+		result, err := scanner.Scan(context.Background(), "file.txt", bytes.NewReader([]byte("EICAR test file")))
+		require.NoError(t, err)
+		assert.True(t, result.Infected)
+		assert.Equal(t, "Eicar-Signature", result.Threat)
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		_, err := scanner.Scan(context.Background(), "file.txt", bytes.NewReader([]byte("too large")))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "INSTREAM size limit exceeded")
+	})
+
+	t.Run("unreachable server", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		listener.Close()
+
+		scanner, err := newClamdScanner(&url.URL{Scheme: "tcp", Host: listener.Addr().String()})
+		require.NoError(t, err)
+
+		_, err = scanner.Scan(context.Background(), "file.txt", bytes.NewReader([]byte("data")))
+		require.Error(t, err)
+	})
+
+	t.Run("server that does not reply", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			io.Copy(io.Discard, conn)
+		}()
+
+		scanner, err := newClamdScanner(&url.URL{Scheme: "tcp", Host: listener.Addr().String()})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err = scanner.Scan(ctx, "file.txt", bytes.NewReader([]byte("data")))
+		require.Error(t, err)
+	})
+}
+
+func TestParseClamdReply(t *testing.T) {
+	for reply, expected := range map[string]*Result{
+		"stream: OK\x00":                             {},
+		"stream: OK\n":                               {},
+		"stream: Win.Test.EICAR_HDB-1 FOUND\x00":     {Infected: true, Threat: "Win.Test.EICAR_HDB-1"},
+		"stream: Heuristics.Encrypted.Zip FOUND\x00": {Infected: true, Threat: "Heuristics.Encrypted.Zip"},
+		"INSTREAM size limit exceeded. ERROR\x00":    nil,
+		"stream: Can't allocate memory ERROR\x00":    nil,
+		"UNKNOWN COMMAND\x00":                        nil,
+	} {
+		t.Run(reply, func(t *testing.T) {
+			result, err := parseClamdReply(reply)
+			if expected == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, expected, result)
+		})
+	}
+}
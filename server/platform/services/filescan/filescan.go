@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package filescan implements clients of the protocols used by antivirus and
+// content scanning servers to scan uploaded files.
+package filescan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// chunkSize is the size of the chunks files are streamed to the scanning server in.
+const chunkSize = 64 * 1024
+
+// A Scanner scans files for malware or any other content the scanning server rejects.
+type Scanner interface {
+	// Scan reads the file until EOF and returns the verdict of the scanning server.
+	// Errors are returned when the server could not scan the file.
+	Scan(ctx context.Context, name string, file io.Reader) (*Result, error)
+}
+
+// Result is the verdict of the scanning server for a file.
+type Result struct {
+	Infected bool
+	// Threat is the name of the threat found in an infected file, when the server reports it.
+	Threat string
+}
+
+// New returns the scanner of the scanning server configured by the settings.
+func New(settings model.FileScanSettings) (Scanner, error) {
+	address, err := url.Parse(model.SafeDereference(settings.Address))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid scanning server address")
+	}
+
+	switch model.SafeDereference(settings.Type) {
+	case model.FileScanTypeClamd:
+		return newClamdScanner(address)
+	case model.FileScanTypeICAP:
+		return newICAPScanner(address)
+	default:
+		return nil, fmt.Errorf("unsupported scanning server type %q", model.SafeDereference(settings.Type))
+	}
+}
+
+// dial connects to the scanning server. The connection is closed when the
+// context is done, so that a server that stops responding cannot block the scan.
+func dial(ctx context.Context, network, address string) (net.Conn, func(), error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to connect to the scanning server")
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+	}
+
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	closeConn := func() {
+		stop()
+		conn.Close()
+	}
+
+	return conn, closeConn, nil
+}
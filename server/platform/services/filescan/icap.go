@@ -0,0 +1,178 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filescan
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/textproto"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const icapDefaultPort = "1344"
+
+// icapScanner scans files with RESPMOD requests to an ICAP service, as defined by RFC 3507.
+// Files are sent as the body of an HTTP response that the service either leaves unmodified
+// when they are clean, or replaces when they are infected.
+type icapScanner struct {
+	address    string
+	serviceURL string
+}
+
+func newICAPScanner(address *url.URL) (*icapScanner, error) {
+	if address.Scheme != "icap" {
+		return nil, fmt.Errorf("unsupported ICAP address scheme %q", address.Scheme)
+	}
+
+	host := address.Host
+	if address.Port() == "" {
+		host = net.JoinHostPort(address.Hostname(), icapDefaultPort)
+	}
+
+	return &icapScanner{
+		address:    host,
+		serviceURL: "icap://" + host + address.EscapedPath(),
+	}, nil
+}
+
+func (s *icapScanner) Scan(ctx context.Context, name string, file io.Reader) (*Result, error) {
+	conn, closeConn, err := dial(ctx, "tcp", s.address)
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	if err := s.writeRequest(conn, name, file); err != nil {
+		return nil, errors.Wrap(err, "failed to send the file to the ICAP service")
+	}
+
+	return readICAPResponse(bufio.NewReader(conn))
+}
+
+func (s *icapScanner) writeRequest(w io.Writer, name string, file io.Reader) error {
+	reqHeader := "GET /" + url.PathEscape(path.Base(name)) + " HTTP/1.1\r\n" +
+		"Host: mattermost\r\n" +
+		"\r\n"
+	resHeader := "HTTP/1.1 200 OK\r\n" +
+		"Content-Type: application/octet-stream\r\n" +
+		"Transfer-Encoding: chunked\r\n" +
+		"\r\n"
+
+	bw := bufio.NewWriterSize(w, chunkSize)
+	fmt.Fprintf(bw, "RESPMOD %s ICAP/1.0\r\n", s.serviceURL)
+	fmt.Fprintf(bw, "Host: %s\r\n", s.address)
+	bw.WriteString("Allow: 204\r\n")
+	bw.WriteString("Connection: close\r\n")
+	fmt.Fprintf(bw, "Encapsulated: req-hdr=0, res-hdr=%d, res-body=%d\r\n", len(reqHeader), len(reqHeader)+len(resHeader))
+	bw.WriteString("\r\n")
+	bw.WriteString(reqHeader)
+	bw.WriteString(resHeader)
+
+	body := httputil.NewChunkedWriter(bw)
+	if _, err := io.Copy(body, file); err != nil {
+		return err
+	}
+	if err := body.Close(); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString("\r\n"); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// readICAPResponse reads the verdict of the ICAP service. A 204 response means the service
+// has no modification to make, so the file is clean. A 200 response carries the modified
+// response: infected files are reported in headers, or replaced by an error page.
+func readICAPResponse(r *bufio.Reader) (*Result, error) {
+	tp := textproto.NewReader(r)
+
+	statusLine, err := tp.ReadLine()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the response of the ICAP service")
+	}
+
+	proto, status, _ := strings.Cut(statusLine, " ")
+	if !strings.HasPrefix(proto, "ICAP/") {
+		return nil, fmt.Errorf("unexpected response from the ICAP service: %q", statusLine)
+	}
+	code, _, _ := strings.Cut(status, " ")
+
+	header, err := tp.ReadMIMEHeader()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the headers of the ICAP response")
+	}
+
+	switch code {
+	case "204":
+		return &Result{}, nil
+	case "200":
+	default:
+		return nil, fmt.Errorf("the ICAP service failed to scan the file: %s", status)
+	}
+
+	if threat, ok := icapThreat(header); ok {
+		return &Result{Infected: true, Threat: threat}, nil
+	}
+
+	// Without headers reporting a threat, the status of the encapsulated response tells
+	// whether the service let the file through or replaced it.
+	if !strings.Contains(header.Get("Encapsulated"), "res-hdr") {
+		return &Result{}, nil
+	}
+
+	res, err := http.ReadResponse(r, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the response encapsulated in the ICAP response")
+	}
+	res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return &Result{}, nil
+	}
+
+	return &Result{Infected: true}, nil
+}
+
+// icapThreat returns the threat reported by the de facto standard headers of ICAP services.
+func icapThreat(header textproto.MIMEHeader) (string, bool) {
+	// X-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Test-Signature;
+	if infection := header.Get("X-Infection-Found"); infection != "" {
+		for field := range strings.SplitSeq(infection, ";") {
+			if key, value, ok := strings.Cut(strings.TrimSpace(field), "="); ok && strings.EqualFold(key, "Threat") {
+				return value, true
+			}
+		}
+		return "", true
+	}
+
+	if virus := header.Get("X-Virus-ID"); virus != "" {
+		return virus, true
+	}
+
+	// X-Violations-Found: 1\r\n\tfile\r\n\tEicar-Test-Signature\r\n\t0\r\n\t2
+	if violations := header.Get("X-Violations-Found"); violations != "" {
+		fields := strings.Fields(violations)
+		if count, err := strconv.Atoi(fields[0]); err == nil && count == 0 {
+			return "", false
+		}
+		if len(fields) >= 3 {
+			return fields[2], true
+		}
+		return "", true
+	}
+
+	return "", false
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filescan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http/httputil"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+type icapRequest struct {
+	line   string
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// startFakeICAP starts an ICAP service that reads RESPMOD requests and writes the response returned by respond.
+func startFakeICAP(t *testing.T, respond func(req *icapRequest) string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				r := bufio.NewReader(conn)
+				tp := textproto.NewReader(r)
+
+				var req icapRequest
+				if req.line, err = tp.ReadLine(); err != nil {
+					return
+				}
+				if req.header, err = tp.ReadMIMEHeader(); err != nil {
+					return
+				}
+
+				// The encapsulated request and response headers, then the chunked body.
+				for range 2 {
+					for {
+						line, err := tp.ReadLine()
+						if err != nil {
+							return
+						}
+						if line == "" {
+							break
+						}
+					}
+				}
+				body, err := io.ReadAll(httputil.NewChunkedReader(r))
+				if err != nil {
+					return
+				}
+				req.body = body
+
+				conn.Write([]byte(respond(&req)))
+			}()
+		}
+	}()
+
+	return "icap://" + listener.Addr().String() + "/avscan"
+}
+
+func TestICAPScanner(t *testing.T) {
+	var mut sync.Mutex
+	var lastRequest *icapRequest
+	address := startFakeICAP(t, func(req *icapRequest) string {
+		mut.Lock()
+		lastRequest = req
+		mut.Unlock()
+
+		body := string(req.body)
+		switch {
+		case strings.Contains(body, "EICAR"):
+			return "ICAP/1.0 200 OK\r\n" +
+				"X-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Test-Signature;\r\n" +
+				"Encapsulated: res-hdr=0, res-body=26\r\n" +
+				"\r\n" +
+				"HTTP/1.1 403 Forbidden\r\n" +
+				"\r\n" +
+				"0\r\n\r\n"
+		case strings.Contains(body, "blocked"):
+			return "ICAP/1.0 200 OK\r\n" +
+				"Encapsulated: res-hdr=0, null-body=45\r\n" +
+				"\r\n" +
+				"HTTP/1.1 403 Forbidden\r\n" +
+				"Content-Length: 0\r\n" +
+				"\r\n"
+		case strings.Contains(body, "unmodified"):
+			return "ICAP/1.0 200 OK\r\n" +
+				"Encapsulated: res-hdr=0, null-body=38\r\n" +
+				"\r\n" +
+				"HTTP/1.1 200 OK\r\n" +
+				"Content-Length: 0\r\n" +
+				"\r\n"
+		case strings.Contains(body, "error"):
+			return "ICAP/1.0 500 Server Error\r\n" +
+				"Encapsulated: null-body=0\r\n" +
+				"\r\n"
+		default:
+			return "ICAP/1.0 204 No Content\r\n" +
+				"Encapsulated: null-body=0\r\n" +
+				"\r\n"
+		}
+	})
+
+	scanner, err := New(model.FileScanSettings{
+		Type:    new(model.FileScanTypeICAP),
+		Address: new(address),
+	})
+	require.NoError(t, err)
+
+	t.Run("clean file", func(t *testing.T) {
+		data := bytes.Repeat([]byte("a"), chunkSize*2+10)
+
+		result, err := scanner.Scan(context.Background(), "report 2024.pdf", bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.False(t, result.Infected)
+
+		mut.Lock()
+		defer mut.Unlock()
+		require.NotNil(t, lastRequest)
+		assert.Equal(t, "RESPMOD "+address+" ICAP/1.0", lastRequest.line)
+		assert.Equal(t, "204", lastRequest.header.Get("Allow"))
+		assert.Equal(t, data, lastRequest.body)
+	})
+
+	t.Run("infected file", func(t *testing.T) {
+		result, err := scanner.Scan(context.Background(), "file.txt", strings.NewReader("EICAR test file"))
+		require.NoError(t, err)
+		assert.True(t, result.Infected)
+		assert.Equal(t, "Eicar-Test-Signature", result.Threat)
+	})
+
+	t.Run("file replaced by the service", func(t *testing.T) {
+		result, err := scanner.Scan(context.Background(), "file.txt", strings.NewReader("blocked"))
+		require.NoError(t, err)
+		assert.True(t, result.Infected)
+		assert.Empty(t, result.Threat)
+	})
+
+	t.Run("file returned unmodified", func(t *testing.T) {
+		result, err := scanner.Scan(context.Background(), "file.txt", strings.NewReader("unmodified"))
+		require.NoError(t, err)
+		assert.False(t, result.Infected)
+	})
+
+	t.Run("scan error", func(t *testing.T) {
+		_, err := scanner.Scan(context.Background(), "file.txt", strings.NewReader("error"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "500 Server Error")
+	})
+}
+
+func TestICAPThreat(t *testing.T) {
+	for name, test := range map[string]struct {
+		header   map[string]string
+		infected bool
+		threat   string
+	}{
+		"no header": {},
+		"x-infection-found": {
+			header:   map[string]string{"X-Infection-Found": "Type=0; Resolution=2; Threat=Win.Test.EICAR_HDB-1;"},
+			infected: true,
+			threat:   "Win.Test.EICAR_HDB-1",
+		},
+		"x-virus-id": {
+			header:   map[string]string{"X-Virus-Id": "Eicar-Test-Signature"},
+			infected: true,
+			threat:   "Eicar-Test-Signature",
+		},
+		"x-violations-found": {
+			header:   map[string]string{"X-Violations-Found": "1 file.txt Eicar-Test-Signature 0 2"},
+			infected: true,
+			threat:   "Eicar-Test-Signature",
+		},
+		"no violations found": {
+			header: map[string]string{"X-Violations-Found": "0"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			header := textproto.MIMEHeader{}
+			for key, value := range test.header {
+				header.Set(key, value)
+			}
+
+			threat, infected := icapThreat(header)
+			assert.Equal(t, test.infected, infected)
+			assert.Equal(t, test.threat, threat)
+		})
+	}
+}
+
+func TestNewICAPScanner(t *testing.T) {
+	scanner, err := New(model.FileScanSettings{
+		Type:    new(model.FileScanTypeICAP),
+		Address: new("icap://scanner.example.com/avscan"),
+	})
+	require.NoError(t, err)
+
+	icap := scanner.(*icapScanner)
+	assert.Equal(t, "scanner.example.com:1344", icap.address)
+	assert.Equal(t, "icap://scanner.example.com:1344/avscan", icap.serviceURL)
+
+	_, err = New(model.FileScanSettings{
+		Type:    new("unknown"),
+		Address: new("icap://scanner.example.com/avscan"),
+	})
+	require.Error(t, err)
+}
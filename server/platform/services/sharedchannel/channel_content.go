@@ -0,0 +1,309 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// fetchChannelContentForSync populates the sync data with the channel header/purpose, the
+// bookmarks and the board property values of posts that changed since the last sync.
+func (scs *Service) fetchChannelContentForSync(sd *syncData) error {
+	start := time.Now()
+	defer func() {
+		if metrics := scs.server.GetMetrics(); metrics != nil {
+			metrics.ObserveSharedChannelsSyncCollectionStepDuration(sd.rc.RemoteId, "ChannelContent", time.Since(start).Seconds())
+		}
+	}()
+
+	channel, err := scs.server.GetStore().Channel().Get(sd.task.channelID, true)
+	if err != nil {
+		return fmt.Errorf("could not get channel for sync: %w", err)
+	}
+
+	if channel.UpdateAt > sd.scr.LastChannelUpdateAt {
+		sd.channelInfo = &model.SyncChannelInfo{
+			Header:   channel.Header,
+			Purpose:  channel.Purpose,
+			UpdateAt: channel.UpdateAt,
+		}
+		sd.resultNextContentCursor.LastChannelUpdateAt = channel.UpdateAt
+	}
+
+	if err := scs.fetchBookmarksForSync(sd); err != nil {
+		return err
+	}
+
+	return scs.fetchPropertyValuesForSync(sd)
+}
+
+// fetchBookmarksForSync populates the sync data with the link bookmarks created, updated or
+// deleted since the last sync. File bookmarks are not synced since their files are not
+// transferred, and board bookmarks point to channels that only exist locally.
+func (scs *Service) fetchBookmarksForSync(sd *syncData) error {
+	since := sd.scr.LastBookmarkUpdateAt
+	if since > 0 {
+		// GetBookmarksForChannelSince includes bookmarks changed at exactly `since`.
+		since++
+	}
+
+	bookmarks, err := scs.server.GetStore().ChannelBookmark().GetBookmarksForChannelSince(sd.task.channelID, since)
+	if err != nil {
+		return fmt.Errorf("could not fetch bookmarks for sync: %w", err)
+	}
+
+	for _, bookmark := range bookmarks {
+		sd.resultNextContentCursor.LastBookmarkUpdateAt = max(sd.resultNextContentCursor.LastBookmarkUpdateAt, bookmark.UpdateAt, bookmark.DeleteAt)
+
+		if bookmark.Type != model.ChannelBookmarkLink {
+			continue
+		}
+		sd.bookmarks = append(sd.bookmarks, bookmark.ChannelBookmark.Clone())
+	}
+	return nil
+}
+
+// fetchPropertyValuesForSync populates the sync data with the values of the boards property
+// group set on the channel's posts since the last sync.
+func (scs *Service) fetchPropertyValuesForSync(sd *syncData) error {
+	ss := scs.server.GetStore()
+
+	group, err := ss.PropertyGroup().Get(model.BoardsPropertyGroupName)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("could not get boards property group for sync: %w", err)
+	}
+
+	values, err := ss.PropertyValue().GetForChannelPostsSince(group.ID, sd.task.channelID, sd.scr.LastPropertyValueUpdateAt, sd.scr.LastPropertyValueId, MaxPropertyValuesPerSync)
+	if err != nil {
+		return fmt.Errorf("could not fetch property values for sync: %w", err)
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+	if len(values) >= MaxPropertyValuesPerSync {
+		sd.resultRepeat = true
+	}
+
+	// values are ordered by change time and id, so the cursor resumes right after the last
+	// one even when more values were changed at the same time.
+	last := values[len(values)-1]
+	sd.resultNextContentCursor.LastPropertyValueUpdateAt = propertyValueChangeAt(last)
+	sd.resultNextContentCursor.LastPropertyValueId = last.ID
+
+	postIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		postIDs = append(postIDs, value.TargetID)
+	}
+	posts, err := ss.Post().GetPostsByIds(postIDs)
+	if err != nil && !isNotFoundError(err) {
+		return fmt.Errorf("could not fetch posts of property values for sync: %w", err)
+	}
+	syncedPosts := make(map[string]bool, len(posts))
+	for _, post := range posts {
+		// cards are not synced, so neither are their values.
+		syncedPosts[post.Id] = post.Type != model.PostTypeCard
+	}
+
+	fields := make(map[string]*model.PropertyField)
+	for _, value := range values {
+		if !syncedPosts[value.TargetID] {
+			continue
+		}
+
+		field, ok := fields[value.FieldID]
+		if !ok {
+			field, err = ss.PropertyField().Get(context.Background(), group.ID, value.FieldID)
+			if err != nil {
+				scs.server.Log().LogM(mlog.MlvlSharedChannelServiceWarn, "Cannot get property field for sync",
+					mlog.String("field_id", value.FieldID),
+					mlog.String("channel_id", sd.task.channelID),
+					mlog.Err(err),
+				)
+				continue
+			}
+			fields[value.FieldID] = field
+		}
+
+		// fields scoped to another channel or a team can't be resolved by the remote.
+		if field.TargetID != "" && field.TargetID != sd.task.channelID {
+			continue
+		}
+
+		syncValue := &model.SyncPropertyValue{
+			PostId:        value.TargetID,
+			GroupName:     group.Name,
+			FieldName:     field.Name,
+			FieldTargetId: field.TargetID,
+			UpdateAt:      value.UpdateAt,
+			DeleteAt:      value.DeleteAt,
+		}
+		if value.DeleteAt == 0 {
+			syncValue.Value = propertyOptionIDsToNames(field, value.Value)
+		}
+		sd.propertyValues = append(sd.propertyValues, syncValue)
+	}
+	return nil
+}
+
+// sendChannelContentSyncData sends the collected channel header/purpose, bookmarks and
+// property values to the remote cluster.
+func (scs *Service) sendChannelContentSyncData(sd *syncData) error {
+	start := time.Now()
+	defer func() {
+		if metrics := scs.server.GetMetrics(); metrics != nil {
+			metrics.ObserveSharedChannelsSyncSendStepDuration(sd.rc.RemoteId, "ChannelContent", time.Since(start).Seconds())
+		}
+	}()
+
+	msg := model.NewSyncMsg(sd.task.channelID)
+	msg.ChannelInfo = sd.channelInfo
+	msg.Bookmarks = sd.bookmarks
+	msg.PropertyValues = sd.propertyValues
+
+	return scs.sendSyncMsgToRemote(msg, sd.rc, func(syncResp model.SyncResponse, errResp error) {
+		if len(syncResp.ChannelInfoErrors) != 0 || len(syncResp.BookmarkErrors) != 0 || len(syncResp.PropertyValueErrors) != 0 {
+			scs.server.Log().LogM(mlog.MlvlSharedChannelServiceWarn, "Response indicates error for channel content sync",
+				mlog.String("channel_id", sd.task.channelID),
+				mlog.String("remote_id", sd.rc.RemoteId),
+				mlog.Array("channel_info_errors", syncResp.ChannelInfoErrors),
+				mlog.Array("bookmark_errors", syncResp.BookmarkErrors),
+				mlog.Array("property_value_errors", syncResp.PropertyValueErrors),
+			)
+		}
+
+		if errResp == nil {
+			scs.updateContentCursorForRemote(sd.scr.Id, sd.rc, sd.resultNextContentCursor)
+		}
+	})
+}
+
+func (scs *Service) updateContentCursorForRemote(scrID string, rc *model.RemoteCluster, cursor model.SharedChannelContentCursor) {
+	if cursor.IsEmpty() {
+		return
+	}
+
+	if err := scs.server.GetStore().SharedChannel().UpdateRemoteContentCursor(scrID, cursor); err != nil {
+		scs.server.Log().LogM(mlog.MlvlSharedChannelServiceError, "error updating content cursor for shared channel remote",
+			mlog.String("remote", rc.DisplayName),
+			mlog.Err(err),
+		)
+		return
+	}
+	scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "updated content cursor for remote",
+		mlog.String("remote_id", rc.RemoteId),
+		mlog.String("remote", rc.DisplayName),
+		mlog.Int("last_channel_update_at", cursor.LastChannelUpdateAt),
+		mlog.Int("last_bookmark_update_at", cursor.LastBookmarkUpdateAt),
+		mlog.Int("last_property_value_update_at", cursor.LastPropertyValueUpdateAt),
+		mlog.String("last_property_value_id", cursor.LastPropertyValueId),
+	)
+}
+
+// propertyValueChangeAt returns the time of the last change to a property value, which is
+// its deletion time for deleted values.
+func propertyValueChangeAt(value *model.PropertyValue) int64 {
+	return max(value.UpdateAt, value.DeleteAt)
+}
+
+type propertyFieldOption struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// propertyFieldOptions returns the options of a select-like field. Options are decoded through
+// JSON since field attrs hold them either as typed options or as generic maps.
+func propertyFieldOptions(field *model.PropertyField) []propertyFieldOption {
+	if !field.Type.SupportsOptions() || field.Attrs == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(field.Attrs[model.PropertyFieldAttributeOptions])
+	if err != nil {
+		return nil
+	}
+
+	var options []propertyFieldOption
+	if err := json.Unmarshal(raw, &options); err != nil {
+		return nil
+	}
+	return options
+}
+
+// propertyOptionIDsToNames replaces the option IDs held by a value of a select-like field
+// with the option names, since option IDs differ between clusters.
+func propertyOptionIDsToNames(field *model.PropertyField, value json.RawMessage) json.RawMessage {
+	options := propertyFieldOptions(field)
+	if len(options) == 0 {
+		return value
+	}
+
+	names := make(map[string]string, len(options))
+	for _, option := range options {
+		names[option.ID] = option.Name
+	}
+
+	converted, err := mapPropertyOptionValue(value, func(id string) (string, bool) {
+		name, ok := names[id]
+		return name, ok
+	})
+	if err != nil {
+		return value
+	}
+	return converted
+}
+
+// propertyOptionNamesToIDs is the inverse of propertyOptionIDsToNames, mapping received option
+// names to the IDs of the local field's options.
+func propertyOptionNamesToIDs(field *model.PropertyField, value json.RawMessage) (json.RawMessage, error) {
+	options := propertyFieldOptions(field)
+	if len(options) == 0 {
+		return value, nil
+	}
+
+	ids := make(map[string]string, len(options))
+	for _, option := range options {
+		ids[option.Name] = option.ID
+	}
+
+	return mapPropertyOptionValue(value, func(name string) (string, bool) {
+		id, ok := ids[name]
+		return id, ok
+	})
+}
+
+// mapPropertyOptionValue applies fn to a value holding a single option or a list of options.
+// Options that fn can't map are reported as an error.
+func mapPropertyOptionValue(value json.RawMessage, fn func(string) (string, bool)) (json.RawMessage, error) {
+	var single string
+	if err := json.Unmarshal(value, &single); err == nil {
+		mapped, ok := fn(single)
+		if !ok {
+			return nil, fmt.Errorf("unknown option %q", single)
+		}
+		return json.Marshal(mapped)
+	}
+
+	var list []string
+	if err := json.Unmarshal(value, &list); err != nil {
+		return nil, fmt.Errorf("unexpected value for option field: %w", err)
+	}
+	for i, option := range list {
+		mapped, ok := fn(option)
+		if !ok {
+			return nil, fmt.Errorf("unknown option %q", option)
+		}
+		list[i] = mapped
+	}
+	return json.Marshal(list)
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+var (
+	ErrBookmarkTypeNotSynced      = errors.New("bookmark type not synced")
+	ErrPropertyGroupNotSynced     = errors.New("property group not synced")
+	ErrPropertyValueTargetInvalid = errors.New("property value target invalid")
+)
+
+// Conflicts between changes made on both sides of a shared channel are resolved by keeping the
+// most recent change (last writer wins). Changes that are not newer than the local data, or that
+// don't change it, are ignored so that changes received from a remote are not sent back to it
+// over and over.
+
+// upsertSyncChannelInfo updates the header and purpose of the channel from a sync message.
+// It returns true if the channel was changed.
+func (scs *Service) upsertSyncChannelInfo(rctx request.CTX, info *model.SyncChannelInfo, channel *model.Channel) (bool, error) {
+	if channel.Header == info.Header && channel.Purpose == info.Purpose {
+		return false, nil
+	}
+	if channel.UpdateAt > info.UpdateAt {
+		// the local channel was changed more recently.
+		return false, nil
+	}
+
+	patched := channel.DeepCopy()
+	patched.Header = info.Header
+	patched.Purpose = info.Purpose
+
+	updated, err := scs.server.GetStore().Channel().Update(rctx, patched)
+	if err != nil {
+		return false, fmt.Errorf("error updating channel info for sync: %w", err)
+	}
+
+	scs.platform.InvalidateCacheForChannel(updated)
+
+	messageWs := model.NewWebSocketEvent(model.WebsocketEventChannelUpdated, "", updated.Id, "", nil, "")
+	channelJSON, err := json.Marshal(updated)
+	if err != nil {
+		scs.server.Log().LogM(mlog.MlvlSharedChannelServiceWarn, "Cannot marshal channel to notify clients",
+			mlog.String("channel_id", updated.Id),
+			mlog.Err(err),
+		)
+		return true, nil
+	}
+	messageWs.Add("channel", string(channelJSON))
+	scs.app.Publish(messageWs)
+
+	return true, nil
+}
+
+// upsertSyncBookmark creates, updates or deletes a link bookmark from a sync message.
+func (scs *Service) upsertSyncBookmark(bookmark *model.ChannelBookmark, channel *model.Channel) error {
+	if bookmark.ChannelId != channel.Id {
+		return fmt.Errorf("bookmark sync failed: %w", ErrChannelIDMismatch)
+	}
+	if bookmark.Type != model.ChannelBookmarkLink {
+		return fmt.Errorf("bookmark sync failed: %w: %s", ErrBookmarkTypeNotSynced, bookmark.Type)
+	}
+
+	bookmarkStore := scs.server.GetStore().ChannelBookmark()
+
+	existing, err := bookmarkStore.Get(bookmark.Id, true)
+	if err != nil && !isNotFoundError(err) {
+		return fmt.Errorf("error fetching bookmark for sync: %w", err)
+	}
+
+	if existing == nil {
+		if bookmark.DeleteAt != 0 {
+			return nil
+		}
+
+		// only the fields a link bookmark uses are kept.
+		newBookmark := &model.ChannelBookmark{
+			Id:          bookmark.Id,
+			CreateAt:    bookmark.CreateAt,
+			ChannelId:   channel.Id,
+			OwnerId:     bookmark.OwnerId,
+			DisplayName: bookmark.DisplayName,
+			SortOrder:   bookmark.SortOrder,
+			LinkUrl:     bookmark.LinkUrl,
+			ImageUrl:    bookmark.ImageUrl,
+			Emoji:       bookmark.Emoji,
+			Type:        model.ChannelBookmarkLink,
+		}
+		saved, err := bookmarkStore.Save(newBookmark, false)
+		if err != nil {
+			return fmt.Errorf("error saving bookmark for sync: %w", err)
+		}
+		scs.publishBookmarkEvent(model.WebsocketEventChannelBookmarkCreated, "bookmark", channel.Id, saved)
+		return nil
+	}
+
+	if existing.ChannelId != channel.Id {
+		return fmt.Errorf("bookmark sync failed: %w", ErrChannelIDMismatch)
+	}
+	if existing.UpdateAt > max(bookmark.UpdateAt, bookmark.DeleteAt) {
+		// the local bookmark was changed more recently.
+		return nil
+	}
+
+	if bookmark.DeleteAt != 0 {
+		if existing.DeleteAt != 0 {
+			return nil
+		}
+		if err := bookmarkStore.Delete(existing.Id, false); err != nil {
+			return fmt.Errorf("error deleting bookmark for sync: %w", err)
+		}
+		existing.DeleteAt = bookmark.DeleteAt
+		scs.publishBookmarkEvent(model.WebsocketEventChannelBookmarkDeleted, "bookmark", channel.Id, existing)
+		return nil
+	}
+
+	if existing.DeleteAt != 0 {
+		// bookmarks are not restored once deleted.
+		return nil
+	}
+
+	if existing.DisplayName == bookmark.DisplayName && existing.LinkUrl == bookmark.LinkUrl &&
+		existing.ImageUrl == bookmark.ImageUrl && existing.Emoji == bookmark.Emoji && existing.SortOrder == bookmark.SortOrder {
+		return nil
+	}
+
+	updated := existing.ChannelBookmark.Clone()
+	updated.DisplayName = bookmark.DisplayName
+	updated.LinkUrl = bookmark.LinkUrl
+	updated.ImageUrl = bookmark.ImageUrl
+	updated.Emoji = bookmark.Emoji
+	updated.SortOrder = bookmark.SortOrder
+	if err := bookmarkStore.Update(updated); err != nil {
+		return fmt.Errorf("error updating bookmark for sync: %w", err)
+	}
+
+	scs.publishBookmarkEvent(model.WebsocketEventChannelBookmarkUpdated, "bookmarks", channel.Id, &model.UpdateChannelBookmarkResponse{
+		Updated: updated.ToBookmarkWithFileInfo(nil),
+	})
+	return nil
+}
+
+func (scs *Service) publishBookmarkEvent(event model.WebsocketEventType, key, channelID string, data any) {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		scs.server.Log().LogM(mlog.MlvlSharedChannelServiceWarn, "Cannot marshal bookmark to notify clients",
+			mlog.String("channel_id", channelID),
+			mlog.Err(err),
+		)
+		return
+	}
+
+	message := model.NewWebSocketEvent(event, "", channelID, "", nil, "")
+	message.Add(key, string(dataJSON))
+	scs.app.Publish(message)
+}
+
+// upsertSyncPropertyValue sets or deletes the value of a board property of a post from a sync
+// message. Fields are matched by name, and option values by option name.
+func (scs *Service) upsertSyncPropertyValue(rctx request.CTX, syncValue *model.SyncPropertyValue, channel *model.Channel) error {
+	// only board properties are synced; other groups may hold data that remotes must not change.
+	if syncValue.GroupName != model.BoardsPropertyGroupName {
+		return fmt.Errorf("property value sync failed: %w: %s", ErrPropertyGroupNotSynced, syncValue.GroupName)
+	}
+	if syncValue.FieldTargetId != "" && syncValue.FieldTargetId != channel.Id {
+		return fmt.Errorf("property value sync failed: %w", ErrPropertyValueTargetInvalid)
+	}
+
+	ss := scs.server.GetStore()
+
+	post, err := ss.Post().GetSingle(rctx, syncValue.PostId, true)
+	if err != nil {
+		return fmt.Errorf("error fetching post for property value sync: %w", err)
+	}
+	if post.ChannelId != channel.Id {
+		return fmt.Errorf("property value sync failed: %w", ErrChannelIDMismatch)
+	}
+	if post.Type == model.PostTypeCard {
+		return fmt.Errorf("property value sync failed: %w", ErrPropertyValueTargetInvalid)
+	}
+
+	group, err := ss.PropertyGroup().Get(syncValue.GroupName)
+	if err != nil {
+		return fmt.Errorf("error fetching property group for sync: %w", err)
+	}
+
+	field, err := ss.PropertyField().GetFieldByName(context.Background(), group.ID, syncValue.FieldTargetId, syncValue.FieldName)
+	if err != nil {
+		return fmt.Errorf("error fetching property field for sync: %w", err)
+	}
+
+	existingValues, err := ss.PropertyValue().SearchPropertyValues(model.PropertyValueSearchOpts{
+		GroupID:    group.ID,
+		TargetType: model.PropertyValueTargetTypePost,
+		TargetIDs:  []string{post.Id},
+		FieldID:    field.ID,
+		PerPage:    1,
+	})
+	if err != nil {
+		return fmt.Errorf("error fetching property value for sync: %w", err)
+	}
+	var existing *model.PropertyValue
+	if len(existingValues) != 0 {
+		existing = existingValues[0]
+	}
+
+	if existing != nil && existing.UpdateAt > max(syncValue.UpdateAt, syncValue.DeleteAt) {
+		// the local value was changed more recently.
+		return nil
+	}
+
+	var values []*model.PropertyValue
+	if syncValue.DeleteAt != 0 {
+		if existing == nil {
+			return nil
+		}
+		if err := ss.PropertyValue().Delete(group.ID, existing.ID); err != nil {
+			return fmt.Errorf("error deleting property value for sync: %w", err)
+		}
+		existing.DeleteAt = syncValue.DeleteAt
+		values = []*model.PropertyValue{existing}
+	} else {
+		value, err := propertyOptionNamesToIDs(field, syncValue.Value)
+		if err != nil {
+			return fmt.Errorf("invalid property value for sync: %w", err)
+		}
+		if existing != nil && bytes.Equal(existing.Value, value) {
+			return nil
+		}
+
+		values, err = ss.PropertyValue().Upsert([]*model.PropertyValue{{
+			TargetID:   post.Id,
+			TargetType: model.PropertyValueTargetTypePost,
+			GroupID:    group.ID,
+			FieldID:    field.ID,
+			Value:      value,
+		}})
+		if err != nil {
+			return fmt.Errorf("error saving property value for sync: %w", err)
+		}
+	}
+
+	valuesJSON, err := json.Marshal(values)
+	if err != nil {
+		scs.server.Log().LogM(mlog.MlvlSharedChannelServiceWarn, "Cannot marshal property values to notify clients",
+			mlog.String("post_id", post.Id),
+			mlog.Err(err),
+		)
+		return nil
+	}
+	message := model.NewWebSocketEvent(model.WebsocketEventPropertyValuesUpdated, channel.TeamId, channel.Id, "", nil, "")
+	message.Add("object_type", model.PropertyValueTargetTypePost)
+	message.Add("target_id", post.Id)
+	message.Add("values", string(valuesJSON))
+	scs.app.Publish(message)
+
+	return nil
+}
@@ -0,0 +1,372 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func setupChannelContentService(t *testing.T, mockStore *mocks.Store) (*Service, *MockAppIface) {
+	t.Helper()
+
+	mockServer := &MockServerIface{}
+	mockServer.On("GetStore").Return(mockStore)
+	mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+
+	mockApp := &MockAppIface{}
+	mockApp.On("Publish", mock.Anything).Return()
+
+	return &Service{server: mockServer, app: mockApp, platform: testNoopPlatform{}}, mockApp
+}
+
+func TestUpsertSyncChannelInfo(t *testing.T) {
+	channelID := model.NewId()
+
+	makeChannel := func() *model.Channel {
+		return &model.Channel{
+			Id:          channelID,
+			TeamId:      model.NewId(),
+			Type:        model.ChannelTypeOpen,
+			Name:        "shared",
+			DisplayName: "Shared",
+			Header:      "local header",
+			Purpose:     "local purpose",
+			CreateAt:    1000,
+			UpdateAt:    2000,
+		}
+	}
+
+	t.Run("applies newer remote change", func(t *testing.T) {
+		channel := makeChannel()
+
+		mockChannelStore := &mocks.ChannelStore{}
+		mockChannelStore.On("Update", mock.Anything, mock.AnythingOfType("*model.Channel")).Return(func(_ request.CTX, c *model.Channel) (*model.Channel, error) {
+			return c, nil
+		})
+		mockStore := &mocks.Store{}
+		mockStore.On("Channel").Return(mockChannelStore)
+
+		scs, mockApp := setupChannelContentService(t, mockStore)
+
+		changed, err := scs.upsertSyncChannelInfo(request.TestContext(t), &model.SyncChannelInfo{Header: "remote header", Purpose: "local purpose", UpdateAt: 3000}, channel)
+		require.NoError(t, err)
+		assert.True(t, changed)
+
+		mockChannelStore.AssertCalled(t, "Update", mock.Anything, mock.MatchedBy(func(c *model.Channel) bool {
+			return c.Header == "remote header" && c.Purpose == "local purpose"
+		}))
+		mockApp.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("ignores older remote change", func(t *testing.T) {
+		mockChannelStore := &mocks.ChannelStore{}
+		mockStore := &mocks.Store{}
+		mockStore.On("Channel").Return(mockChannelStore)
+
+		scs, mockApp := setupChannelContentService(t, mockStore)
+
+		changed, err := scs.upsertSyncChannelInfo(request.TestContext(t), &model.SyncChannelInfo{Header: "remote header", UpdateAt: 1500}, makeChannel())
+		require.NoError(t, err)
+		assert.False(t, changed)
+
+		mockChannelStore.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockApp.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+
+	t.Run("ignores identical change", func(t *testing.T) {
+		mockChannelStore := &mocks.ChannelStore{}
+		mockStore := &mocks.Store{}
+		mockStore.On("Channel").Return(mockChannelStore)
+
+		scs, _ := setupChannelContentService(t, mockStore)
+
+		changed, err := scs.upsertSyncChannelInfo(request.TestContext(t), &model.SyncChannelInfo{Header: "local header", Purpose: "local purpose", UpdateAt: 5000}, makeChannel())
+		require.NoError(t, err)
+		assert.False(t, changed)
+
+		mockChannelStore.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpsertSyncBookmark(t *testing.T) {
+	channel := &model.Channel{Id: model.NewId(), Type: model.ChannelTypeOpen}
+
+	makeBookmark := func() *model.ChannelBookmark {
+		return &model.ChannelBookmark{
+			Id:          model.NewId(),
+			ChannelId:   channel.Id,
+			OwnerId:     model.NewId(),
+			DisplayName: "docs",
+			LinkUrl:     "https://example.com/docs",
+			Type:        model.ChannelBookmarkLink,
+			CreateAt:    1000,
+			UpdateAt:    2000,
+		}
+	}
+
+	t.Run("creates new bookmark", func(t *testing.T) {
+		bookmark := makeBookmark()
+
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("Get", bookmark.Id, true).Return(nil, store.NewErrNotFound("ChannelBookmark", bookmark.Id))
+		mockBookmarkStore.On("Save", mock.AnythingOfType("*model.ChannelBookmark"), false).Return(bookmark.ToBookmarkWithFileInfo(nil), nil)
+		mockStore := &mocks.Store{}
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+
+		scs, mockApp := setupChannelContentService(t, mockStore)
+
+		require.NoError(t, scs.upsertSyncBookmark(bookmark, channel))
+		mockBookmarkStore.AssertCalled(t, "Save", mock.MatchedBy(func(b *model.ChannelBookmark) bool {
+			return b.Id == bookmark.Id && b.LinkUrl == bookmark.LinkUrl
+		}), false)
+		mockApp.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("rejects file bookmarks", func(t *testing.T) {
+		bookmark := makeBookmark()
+		bookmark.Type = model.ChannelBookmarkFile
+
+		scs, _ := setupChannelContentService(t, &mocks.Store{})
+
+		err := scs.upsertSyncBookmark(bookmark, channel)
+		require.ErrorIs(t, err, ErrBookmarkTypeNotSynced)
+	})
+
+	t.Run("rejects bookmark of another channel", func(t *testing.T) {
+		bookmark := makeBookmark()
+		existing := bookmark.Clone()
+		existing.ChannelId = model.NewId()
+
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("Get", bookmark.Id, true).Return(existing.ToBookmarkWithFileInfo(nil), nil)
+		mockStore := &mocks.Store{}
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+
+		scs, _ := setupChannelContentService(t, mockStore)
+
+		err := scs.upsertSyncBookmark(bookmark, channel)
+		require.ErrorIs(t, err, ErrChannelIDMismatch)
+	})
+
+	t.Run("keeps local bookmark changed more recently", func(t *testing.T) {
+		bookmark := makeBookmark()
+		existing := bookmark.Clone()
+		existing.DisplayName = "local docs"
+		existing.UpdateAt = 3000
+
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("Get", bookmark.Id, true).Return(existing.ToBookmarkWithFileInfo(nil), nil)
+		mockStore := &mocks.Store{}
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+
+		scs, mockApp := setupChannelContentService(t, mockStore)
+
+		require.NoError(t, scs.upsertSyncBookmark(bookmark, channel))
+		mockBookmarkStore.AssertNotCalled(t, "Update", mock.Anything)
+		mockApp.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+
+	t.Run("updates bookmark with newer remote change", func(t *testing.T) {
+		bookmark := makeBookmark()
+		existing := bookmark.Clone()
+		existing.DisplayName = "old docs"
+		existing.UpdateAt = 1500
+
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("Get", bookmark.Id, true).Return(existing.ToBookmarkWithFileInfo(nil), nil)
+		mockBookmarkStore.On("Update", mock.AnythingOfType("*model.ChannelBookmark")).Return(nil)
+		mockStore := &mocks.Store{}
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+
+		scs, mockApp := setupChannelContentService(t, mockStore)
+
+		require.NoError(t, scs.upsertSyncBookmark(bookmark, channel))
+		mockBookmarkStore.AssertCalled(t, "Update", mock.MatchedBy(func(b *model.ChannelBookmark) bool {
+			return b.DisplayName == "docs"
+		}))
+		mockApp.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("ignores identical bookmark", func(t *testing.T) {
+		bookmark := makeBookmark()
+		existing := bookmark.Clone()
+		existing.UpdateAt = 1500
+
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("Get", bookmark.Id, true).Return(existing.ToBookmarkWithFileInfo(nil), nil)
+		mockStore := &mocks.Store{}
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+
+		scs, _ := setupChannelContentService(t, mockStore)
+
+		require.NoError(t, scs.upsertSyncBookmark(bookmark, channel))
+		mockBookmarkStore.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("deletes bookmark", func(t *testing.T) {
+		bookmark := makeBookmark()
+		existing := bookmark.Clone()
+		bookmark.DeleteAt = 2500
+
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("Get", bookmark.Id, true).Return(existing.ToBookmarkWithFileInfo(nil), nil)
+		mockBookmarkStore.On("Delete", bookmark.Id, false).Return(nil)
+		mockStore := &mocks.Store{}
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+
+		scs, mockApp := setupChannelContentService(t, mockStore)
+
+		require.NoError(t, scs.upsertSyncBookmark(bookmark, channel))
+		mockBookmarkStore.AssertCalled(t, "Delete", bookmark.Id, false)
+		mockApp.AssertNumberOfCalls(t, "Publish", 1)
+	})
+}
+
+func TestUpsertSyncPropertyValue(t *testing.T) {
+	channel := &model.Channel{Id: model.NewId(), TeamId: model.NewId(), Type: model.ChannelTypeOpen}
+	post := &model.Post{Id: model.NewId(), ChannelId: channel.Id}
+	group := &model.PropertyGroup{ID: model.NewId(), Name: model.BoardsPropertyGroupName}
+	field := &model.PropertyField{
+		ID:      model.NewId(),
+		GroupID: group.ID,
+		Name:    "Status",
+		Type:    model.PropertyFieldTypeSelect,
+		Attrs: model.StringInterface{
+			model.PropertyFieldAttributeOptions: []map[string]any{
+				{"id": "local_todo", "name": "To do"},
+				{"id": "local_done", "name": "Done"},
+			},
+		},
+	}
+
+	setupStore := func(existing []*model.PropertyValue) (*mocks.Store, *mocks.PropertyValueStore) {
+		mockPostStore := &mocks.PostStore{}
+		mockPostStore.On("GetSingle", mock.Anything, post.Id, true).Return(post, nil)
+		mockGroupStore := &mocks.PropertyGroupStore{}
+		mockGroupStore.On("Get", model.BoardsPropertyGroupName).Return(group, nil)
+		mockFieldStore := &mocks.PropertyFieldStore{}
+		mockFieldStore.On("GetFieldByName", mock.Anything, group.ID, "", field.Name).Return(field, nil)
+		mockValueStore := &mocks.PropertyValueStore{}
+		mockValueStore.On("SearchPropertyValues", mock.AnythingOfType("model.PropertyValueSearchOpts")).Return(existing, nil)
+
+		mockStore := &mocks.Store{}
+		mockStore.On("Post").Return(mockPostStore)
+		mockStore.On("PropertyGroup").Return(mockGroupStore)
+		mockStore.On("PropertyField").Return(mockFieldStore)
+		mockStore.On("PropertyValue").Return(mockValueStore)
+		return mockStore, mockValueStore
+	}
+
+	makeSyncValue := func(value string) *model.SyncPropertyValue {
+		return &model.SyncPropertyValue{
+			PostId:    post.Id,
+			GroupName: model.BoardsPropertyGroupName,
+			FieldName: field.Name,
+			Value:     json.RawMessage(value),
+			UpdateAt:  2000,
+		}
+	}
+
+	t.Run("rejects other property groups", func(t *testing.T) {
+		scs, _ := setupChannelContentService(t, &mocks.Store{})
+
+		syncValue := makeSyncValue(`"Done"`)
+		syncValue.GroupName = "content_flagging"
+
+		err := scs.upsertSyncPropertyValue(request.TestContext(t), syncValue, channel)
+		require.ErrorIs(t, err, ErrPropertyGroupNotSynced)
+	})
+
+	t.Run("maps option names to local option ids", func(t *testing.T) {
+		mockStore, mockValueStore := setupStore(nil)
+		mockValueStore.On("Upsert", mock.Anything).Return(func(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+			return values, nil
+		})
+
+		scs, mockApp := setupChannelContentService(t, mockStore)
+
+		require.NoError(t, scs.upsertSyncPropertyValue(request.TestContext(t), makeSyncValue(`"Done"`), channel))
+		mockValueStore.AssertCalled(t, "Upsert", mock.MatchedBy(func(values []*model.PropertyValue) bool {
+			return len(values) == 1 && string(values[0].Value) == `"local_done"` && values[0].FieldID == field.ID
+		}))
+		mockApp.AssertNumberOfCalls(t, "Publish", 1)
+	})
+
+	t.Run("rejects unknown option", func(t *testing.T) {
+		mockStore, _ := setupStore(nil)
+		scs, _ := setupChannelContentService(t, mockStore)
+
+		err := scs.upsertSyncPropertyValue(request.TestContext(t), makeSyncValue(`"Blocked"`), channel)
+		require.Error(t, err)
+	})
+
+	t.Run("keeps local value changed more recently", func(t *testing.T) {
+		existing := &model.PropertyValue{ID: model.NewId(), Value: json.RawMessage(`"local_todo"`), UpdateAt: 3000}
+		mockStore, mockValueStore := setupStore([]*model.PropertyValue{existing})
+		scs, mockApp := setupChannelContentService(t, mockStore)
+
+		require.NoError(t, scs.upsertSyncPropertyValue(request.TestContext(t), makeSyncValue(`"Done"`), channel))
+		mockValueStore.AssertNotCalled(t, "Upsert", mock.Anything)
+		mockApp.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+
+	t.Run("ignores identical value", func(t *testing.T) {
+		existing := &model.PropertyValue{ID: model.NewId(), Value: json.RawMessage(`"local_done"`), UpdateAt: 1000}
+		mockStore, mockValueStore := setupStore([]*model.PropertyValue{existing})
+		scs, _ := setupChannelContentService(t, mockStore)
+
+		require.NoError(t, scs.upsertSyncPropertyValue(request.TestContext(t), makeSyncValue(`"Done"`), channel))
+		mockValueStore.AssertNotCalled(t, "Upsert", mock.Anything)
+	})
+
+	t.Run("deletes value", func(t *testing.T) {
+		existing := &model.PropertyValue{ID: model.NewId(), Value: json.RawMessage(`"local_done"`), UpdateAt: 1000}
+		mockStore, mockValueStore := setupStore([]*model.PropertyValue{existing})
+		mockValueStore.On("Delete", group.ID, existing.ID).Return(nil)
+		scs, _ := setupChannelContentService(t, mockStore)
+
+		syncValue := makeSyncValue("")
+		syncValue.Value = nil
+		syncValue.DeleteAt = 2500
+
+		require.NoError(t, scs.upsertSyncPropertyValue(request.TestContext(t), syncValue, channel))
+		mockValueStore.AssertCalled(t, "Delete", group.ID, existing.ID)
+	})
+}
+
+func TestPropertyOptionValueMapping(t *testing.T) {
+	field := &model.PropertyField{
+		Type: model.PropertyFieldTypeMultiselect,
+		Attrs: model.StringInterface{
+			model.PropertyFieldAttributeOptions: []map[string]any{
+				{"id": "opt1", "name": "Red"},
+				{"id": "opt2", "name": "Blue"},
+			},
+		},
+	}
+
+	names := propertyOptionIDsToNames(field, json.RawMessage(`["opt2","opt1"]`))
+	assert.JSONEq(t, `["Blue","Red"]`, string(names))
+
+	ids, err := propertyOptionNamesToIDs(field, names)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["opt2","opt1"]`, string(ids))
+
+	_, err = propertyOptionNamesToIDs(field, json.RawMessage(`["Green"]`))
+	require.Error(t, err)
+
+	text := &model.PropertyField{Type: model.PropertyFieldTypeText}
+	assert.Equal(t, `"hello"`, string(propertyOptionIDsToNames(text, json.RawMessage(`"hello"`))))
+}
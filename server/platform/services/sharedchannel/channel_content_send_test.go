@@ -0,0 +1,285 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func setupChannelContentSendTest(t *testing.T, mockStore *mocks.Store) *Service {
+	t.Helper()
+
+	mockServer := &MockServerIface{}
+	mockServer.On("GetStore").Return(mockStore)
+	mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+	mockServer.On("GetMetrics").Return(nil)
+
+	return &Service{server: mockServer, app: &MockAppIface{}}
+}
+
+func newChannelContentSyncData(channelID string, scr *model.SharedChannelRemote) *syncData {
+	return newSyncData(syncTask{channelID: channelID}, &model.RemoteCluster{RemoteId: model.NewId()}, scr)
+}
+
+func TestFetchChannelContentForSync(t *testing.T) {
+	channelID := model.NewId()
+
+	setupStore := func(channel *model.Channel, since int64, bookmarks []*model.ChannelBookmarkWithFileInfo) *mocks.Store {
+		mockChannelStore := &mocks.ChannelStore{}
+		mockChannelStore.On("Get", channelID, true).Return(channel, nil)
+		mockBookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockBookmarkStore.On("GetBookmarksForChannelSince", channelID, since).Return(bookmarks, nil)
+		mockGroupStore := &mocks.PropertyGroupStore{}
+		mockGroupStore.On("Get", model.BoardsPropertyGroupName).Return(nil, store.NewErrNotFound("PropertyGroup", model.BoardsPropertyGroupName))
+
+		mockStore := &mocks.Store{}
+		mockStore.On("Channel").Return(mockChannelStore)
+		mockStore.On("ChannelBookmark").Return(mockBookmarkStore)
+		mockStore.On("PropertyGroup").Return(mockGroupStore)
+		return mockStore
+	}
+
+	t.Run("collects changed channel info and link bookmarks", func(t *testing.T) {
+		channel := &model.Channel{Id: channelID, Header: "header", Purpose: "purpose", UpdateAt: 2000}
+		link := &model.ChannelBookmark{Id: model.NewId(), ChannelId: channelID, DisplayName: "docs", LinkUrl: "https://example.com", Type: model.ChannelBookmarkLink, UpdateAt: 1500}
+		file := &model.ChannelBookmark{Id: model.NewId(), ChannelId: channelID, DisplayName: "file", FileId: model.NewId(), Type: model.ChannelBookmarkFile, UpdateAt: 1800}
+		deleted := &model.ChannelBookmark{Id: model.NewId(), ChannelId: channelID, DisplayName: "old", LinkUrl: "https://example.com/old", Type: model.ChannelBookmarkLink, UpdateAt: 1200, DeleteAt: 1700}
+
+		// bookmarks changed at exactly the cursor were sent with the previous sync.
+		mockStore := setupStore(channel, 1001, []*model.ChannelBookmarkWithFileInfo{
+			{ChannelBookmark: link},
+			{ChannelBookmark: file},
+			{ChannelBookmark: deleted},
+		})
+		scs := setupChannelContentSendTest(t, mockStore)
+
+		sd := newChannelContentSyncData(channelID, &model.SharedChannelRemote{LastChannelUpdateAt: 1000, LastBookmarkUpdateAt: 1000})
+		require.NoError(t, scs.fetchChannelContentForSync(sd))
+
+		require.NotNil(t, sd.channelInfo)
+		assert.Equal(t, "header", sd.channelInfo.Header)
+		assert.Equal(t, "purpose", sd.channelInfo.Purpose)
+		assert.Equal(t, int64(2000), sd.channelInfo.UpdateAt)
+
+		require.Len(t, sd.bookmarks, 2)
+		assert.Equal(t, link.Id, sd.bookmarks[0].Id)
+		assert.Equal(t, deleted.Id, sd.bookmarks[1].Id)
+
+		assert.Equal(t, int64(2000), sd.resultNextContentCursor.LastChannelUpdateAt)
+		assert.Equal(t, int64(1800), sd.resultNextContentCursor.LastBookmarkUpdateAt)
+		assert.Empty(t, sd.propertyValues)
+		assert.True(t, sd.hasChannelContent())
+	})
+
+	t.Run("skips unchanged content", func(t *testing.T) {
+		channel := &model.Channel{Id: channelID, Header: "header", UpdateAt: 1000}
+		mockStore := setupStore(channel, 1001, nil)
+		scs := setupChannelContentSendTest(t, mockStore)
+
+		sd := newChannelContentSyncData(channelID, &model.SharedChannelRemote{LastChannelUpdateAt: 1000, LastBookmarkUpdateAt: 1000})
+		require.NoError(t, scs.fetchChannelContentForSync(sd))
+
+		assert.Nil(t, sd.channelInfo)
+		assert.Empty(t, sd.bookmarks)
+		assert.False(t, sd.hasChannelContent())
+		assert.True(t, sd.resultNextContentCursor.IsEmpty())
+	})
+
+	t.Run("fails when the channel can't be fetched", func(t *testing.T) {
+		mockChannelStore := &mocks.ChannelStore{}
+		mockChannelStore.On("Get", channelID, true).Return(nil, errors.New("boom"))
+		mockStore := &mocks.Store{}
+		mockStore.On("Channel").Return(mockChannelStore)
+		scs := setupChannelContentSendTest(t, mockStore)
+
+		sd := newChannelContentSyncData(channelID, &model.SharedChannelRemote{})
+		require.Error(t, scs.fetchChannelContentForSync(sd))
+	})
+}
+
+func TestFetchPropertyValuesForSync(t *testing.T) {
+	channelID := model.NewId()
+	group := &model.PropertyGroup{ID: model.NewId(), Name: model.BoardsPropertyGroupName}
+	statusField := &model.PropertyField{
+		ID:      model.NewId(),
+		GroupID: group.ID,
+		Name:    "Status",
+		Type:    model.PropertyFieldTypeSelect,
+		Attrs: model.StringInterface{
+			model.PropertyFieldAttributeOptions: []map[string]any{
+				{"id": "local_todo", "name": "To do"},
+			},
+		},
+	}
+	otherChannelField := &model.PropertyField{ID: model.NewId(), GroupID: group.ID, Name: "Other", Type: model.PropertyFieldTypeText, TargetID: model.NewId()}
+
+	setupStore := func(mockValueStore *mocks.PropertyValueStore, posts []*model.Post) *mocks.Store {
+		mockGroupStore := &mocks.PropertyGroupStore{}
+		mockGroupStore.On("Get", model.BoardsPropertyGroupName).Return(group, nil)
+		mockPostStore := &mocks.PostStore{}
+		mockPostStore.On("GetPostsByIds", mock.Anything).Return(posts, nil)
+		mockFieldStore := &mocks.PropertyFieldStore{}
+		mockFieldStore.On("Get", mock.Anything, group.ID, statusField.ID).Return(statusField, nil)
+		mockFieldStore.On("Get", mock.Anything, group.ID, otherChannelField.ID).Return(otherChannelField, nil)
+
+		mockStore := &mocks.Store{}
+		mockStore.On("PropertyGroup").Return(mockGroupStore)
+		mockStore.On("PropertyValue").Return(mockValueStore)
+		mockStore.On("Post").Return(mockPostStore)
+		mockStore.On("PropertyField").Return(mockFieldStore)
+		return mockStore
+	}
+
+	t.Run("converts values and skips cards and foreign fields", func(t *testing.T) {
+		post := &model.Post{Id: model.NewId(), ChannelId: channelID}
+		card := &model.Post{Id: model.NewId(), ChannelId: channelID, Type: model.PostTypeCard}
+
+		values := []*model.PropertyValue{
+			{ID: model.NewId(), TargetID: post.Id, GroupID: group.ID, FieldID: statusField.ID, Value: json.RawMessage(`"local_todo"`), UpdateAt: 1000},
+			{ID: model.NewId(), TargetID: card.Id, GroupID: group.ID, FieldID: statusField.ID, Value: json.RawMessage(`"local_todo"`), UpdateAt: 1100},
+			{ID: model.NewId(), TargetID: post.Id, GroupID: group.ID, FieldID: otherChannelField.ID, Value: json.RawMessage(`"text"`), UpdateAt: 1200},
+			{ID: model.NewId(), TargetID: post.Id, GroupID: group.ID, FieldID: statusField.ID, Value: json.RawMessage(`"local_todo"`), UpdateAt: 1000, DeleteAt: 1300},
+		}
+		mockValueStore := &mocks.PropertyValueStore{}
+		mockValueStore.On("GetForChannelPostsSince", group.ID, channelID, int64(0), "", MaxPropertyValuesPerSync).Return(values, nil)
+		scs := setupChannelContentSendTest(t, setupStore(mockValueStore, []*model.Post{post, card}))
+
+		sd := newChannelContentSyncData(channelID, &model.SharedChannelRemote{})
+		require.NoError(t, scs.fetchPropertyValuesForSync(sd))
+
+		require.Len(t, sd.propertyValues, 2)
+		assert.Equal(t, post.Id, sd.propertyValues[0].PostId)
+		assert.Equal(t, "Status", sd.propertyValues[0].FieldName)
+		assert.Equal(t, model.BoardsPropertyGroupName, sd.propertyValues[0].GroupName)
+		assert.JSONEq(t, `"To do"`, string(sd.propertyValues[0].Value))
+		assert.Equal(t, int64(1300), sd.propertyValues[1].DeleteAt)
+		assert.Nil(t, sd.propertyValues[1].Value)
+
+		assert.False(t, sd.resultRepeat)
+		assert.Equal(t, int64(1300), sd.resultNextContentCursor.LastPropertyValueUpdateAt)
+		assert.Equal(t, values[3].ID, sd.resultNextContentCursor.LastPropertyValueId)
+	})
+
+	t.Run("pages through a full batch changed at the same time", func(t *testing.T) {
+		post := &model.Post{Id: model.NewId(), ChannelId: channelID}
+
+		var values []*model.PropertyValue
+		for range MaxPropertyValuesPerSync + 1 {
+			values = append(values, &model.PropertyValue{ID: model.NewId(), TargetID: post.Id, GroupID: group.ID, FieldID: statusField.ID, Value: json.RawMessage(`"local_todo"`), UpdateAt: 5000})
+		}
+		slices.SortFunc(values, func(a, b *model.PropertyValue) int {
+			if a.ID < b.ID {
+				return -1
+			}
+			return 1
+		})
+		firstPage := values[:MaxPropertyValuesPerSync]
+		lastPage := values[MaxPropertyValuesPerSync:]
+
+		mockValueStore := &mocks.PropertyValueStore{}
+		mockValueStore.On("GetForChannelPostsSince", group.ID, channelID, int64(4000), "", MaxPropertyValuesPerSync).Return(firstPage, nil)
+		mockValueStore.On("GetForChannelPostsSince", group.ID, channelID, int64(5000), firstPage[len(firstPage)-1].ID, MaxPropertyValuesPerSync).Return(lastPage, nil)
+		scs := setupChannelContentSendTest(t, setupStore(mockValueStore, []*model.Post{post}))
+
+		sd := newChannelContentSyncData(channelID, &model.SharedChannelRemote{LastPropertyValueUpdateAt: 4000})
+		require.NoError(t, scs.fetchPropertyValuesForSync(sd))
+
+		require.True(t, sd.resultRepeat)
+		require.Len(t, sd.propertyValues, MaxPropertyValuesPerSync)
+		require.Equal(t, int64(5000), sd.resultNextContentCursor.LastPropertyValueUpdateAt)
+		require.Equal(t, firstPage[len(firstPage)-1].ID, sd.resultNextContentCursor.LastPropertyValueId)
+
+		next := newChannelContentSyncData(channelID, &model.SharedChannelRemote{
+			LastPropertyValueUpdateAt: sd.resultNextContentCursor.LastPropertyValueUpdateAt,
+			LastPropertyValueId:       sd.resultNextContentCursor.LastPropertyValueId,
+		})
+		require.NoError(t, scs.fetchPropertyValuesForSync(next))
+
+		assert.False(t, next.resultRepeat)
+		require.Len(t, next.propertyValues, 1)
+		assert.Equal(t, int64(5000), next.resultNextContentCursor.LastPropertyValueUpdateAt)
+		assert.Equal(t, lastPage[0].ID, next.resultNextContentCursor.LastPropertyValueId)
+		mockValueStore.AssertNumberOfCalls(t, "GetForChannelPostsSince", 2)
+	})
+
+	t.Run("leaves the cursor alone when nothing changed", func(t *testing.T) {
+		mockValueStore := &mocks.PropertyValueStore{}
+		mockValueStore.On("GetForChannelPostsSince", group.ID, channelID, int64(5000), "abc", MaxPropertyValuesPerSync).Return([]*model.PropertyValue{}, nil)
+		scs := setupChannelContentSendTest(t, setupStore(mockValueStore, nil))
+
+		sd := newChannelContentSyncData(channelID, &model.SharedChannelRemote{LastPropertyValueUpdateAt: 5000, LastPropertyValueId: "abc"})
+		require.NoError(t, scs.fetchPropertyValuesForSync(sd))
+
+		assert.Empty(t, sd.propertyValues)
+		assert.True(t, sd.resultNextContentCursor.IsEmpty())
+	})
+
+	t.Run("does nothing without the boards group", func(t *testing.T) {
+		mockGroupStore := &mocks.PropertyGroupStore{}
+		mockGroupStore.On("Get", model.BoardsPropertyGroupName).Return(nil, store.NewErrNotFound("PropertyGroup", model.BoardsPropertyGroupName))
+		mockStore := &mocks.Store{}
+		mockStore.On("PropertyGroup").Return(mockGroupStore)
+		scs := setupChannelContentSendTest(t, mockStore)
+
+		sd := newChannelContentSyncData(channelID, &model.SharedChannelRemote{})
+		require.NoError(t, scs.fetchPropertyValuesForSync(sd))
+		assert.Empty(t, sd.propertyValues)
+	})
+}
+
+func TestUpdateContentCursorForRemote(t *testing.T) {
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), DisplayName: "remote"}
+	scrID := model.NewId()
+
+	t.Run("does not update an empty cursor", func(t *testing.T) {
+		mockSCStore := &mocks.SharedChannelStore{}
+		mockStore := &mocks.Store{}
+		mockStore.On("SharedChannel").Return(mockSCStore)
+		scs := setupChannelContentSendTest(t, mockStore)
+
+		scs.updateContentCursorForRemote(scrID, rc, model.SharedChannelContentCursor{})
+		mockSCStore.AssertNotCalled(t, "UpdateRemoteContentCursor", mock.Anything, mock.Anything)
+	})
+
+	t.Run("stores the cursor", func(t *testing.T) {
+		cursor := model.SharedChannelContentCursor{
+			LastChannelUpdateAt:       100,
+			LastBookmarkUpdateAt:      200,
+			LastPropertyValueUpdateAt: 300,
+			LastPropertyValueId:       model.NewId(),
+		}
+		mockSCStore := &mocks.SharedChannelStore{}
+		mockSCStore.On("UpdateRemoteContentCursor", scrID, cursor).Return(nil)
+		mockStore := &mocks.Store{}
+		mockStore.On("SharedChannel").Return(mockSCStore)
+		scs := setupChannelContentSendTest(t, mockStore)
+
+		scs.updateContentCursorForRemote(scrID, rc, cursor)
+		mockSCStore.AssertCalled(t, "UpdateRemoteContentCursor", scrID, cursor)
+	})
+
+	t.Run("tolerates a store error", func(t *testing.T) {
+		cursor := model.SharedChannelContentCursor{LastChannelUpdateAt: 100}
+		mockSCStore := &mocks.SharedChannelStore{}
+		mockSCStore.On("UpdateRemoteContentCursor", scrID, cursor).Return(errors.New("boom"))
+		mockStore := &mocks.Store{}
+		mockStore.On("SharedChannel").Return(mockSCStore)
+		scs := setupChannelContentSendTest(t, mockStore)
+
+		scs.updateContentCursorForRemote(scrID, rc, cursor)
+		mockSCStore.AssertNumberOfCalls(t, "UpdateRemoteContentCursor", 1)
+	})
+}
@@ -92,6 +92,9 @@ func (scs *Service) ProcessSyncMessage(rctx request.CTX, syncMsg *model.SyncMsg,
 		ReactionErrors:        make([]string, 0),
 		AcknowledgementErrors: make([]string, 0),
 		MembershipErrors:      make([]string, 0),
+		ChannelInfoErrors:     make([]string, 0),
+		BookmarkErrors:        make([]string, 0),
+		PropertyValueErrors:   make([]string, 0),
 	}
 
 	// Check if feature flag is enabled for membership changes
@@ -112,14 +115,20 @@ func (scs *Service) ProcessSyncMessage(rctx request.CTX, syncMsg *model.SyncMsg,
 		mlog.Int("acknowledgement_count", len(syncMsg.Acknowledgements)),
 		mlog.Int("status_count", len(syncMsg.Statuses)),
 		mlog.Int("membership_change_count", len(syncMsg.MembershipChanges)),
+		mlog.Bool("channel_info", syncMsg.ChannelInfo != nil),
+		mlog.Int("bookmark_count", len(syncMsg.Bookmarks)),
+		mlog.Int("property_value_count", len(syncMsg.PropertyValues)),
 	)
 
 	// Check if this is a global user sync message (no channel ID and only users)
 	if syncMsg.ChannelId == "" {
 		if len(syncMsg.Posts) != 0 ||
 			len(syncMsg.Reactions) != 0 ||
-			len(syncMsg.Statuses) != 0 {
-			return syncResp, fmt.Errorf("global user sync message should not contain posts, reactions or statuses")
+			len(syncMsg.Statuses) != 0 ||
+			syncMsg.ChannelInfo != nil ||
+			len(syncMsg.Bookmarks) != 0 ||
+			len(syncMsg.PropertyValues) != 0 {
+			return syncResp, fmt.Errorf("global user sync message should not contain posts, reactions, statuses or channel content")
 		}
 
 		if len(syncMsg.Users) == 0 {
@@ -295,6 +304,47 @@ func (scs *Service) ProcessSyncMessage(rctx request.CTX, syncMsg *model.SyncMsg,
 		}
 	}
 
+	// channel content is processed last since property values refer to posts
+	if syncMsg.ChannelInfo != nil {
+		if _, err := scs.upsertSyncChannelInfo(rctx, syncMsg.ChannelInfo, targetChannel); err != nil {
+			scs.server.Log().LogM(mlog.MlvlSharedChannelServiceError, "Error updating sync channel info",
+				mlog.String("remote", rc.Name),
+				mlog.String("channel_id", syncMsg.ChannelId),
+				mlog.Err(err),
+			)
+			syncResp.ChannelInfoErrors = append(syncResp.ChannelInfoErrors, syncMsg.ChannelId)
+		}
+	}
+
+	for _, bookmark := range syncMsg.Bookmarks {
+		if err := scs.upsertSyncBookmark(bookmark, targetChannel); err != nil {
+			scs.server.Log().LogM(mlog.MlvlSharedChannelServiceError, "Error upserting sync bookmark",
+				mlog.String("remote", rc.Name),
+				mlog.String("channel_id", syncMsg.ChannelId),
+				mlog.String("bookmark_id", bookmark.Id),
+				mlog.Err(err),
+			)
+			syncResp.BookmarkErrors = append(syncResp.BookmarkErrors, bookmark.Id)
+		} else if changeAt := max(bookmark.UpdateAt, bookmark.DeleteAt); syncResp.BookmarksLastUpdateAt < changeAt {
+			syncResp.BookmarksLastUpdateAt = changeAt
+		}
+	}
+
+	for _, value := range syncMsg.PropertyValues {
+		if err := scs.upsertSyncPropertyValue(rctx, value, targetChannel); err != nil {
+			scs.server.Log().LogM(mlog.MlvlSharedChannelServiceError, "Error upserting sync property value",
+				mlog.String("remote", rc.Name),
+				mlog.String("channel_id", syncMsg.ChannelId),
+				mlog.String("post_id", value.PostId),
+				mlog.String("field_name", value.FieldName),
+				mlog.Err(err),
+			)
+			syncResp.PropertyValueErrors = append(syncResp.PropertyValueErrors, value.PostId)
+		} else if changeAt := max(value.UpdateAt, value.DeleteAt); syncResp.PropertyValuesLastUpdateAt < changeAt {
+			syncResp.PropertyValuesLastUpdateAt = changeAt
+		}
+	}
+
 	return syncResp, nil
 }
 
@@ -44,6 +44,11 @@ type syncData struct {
 	membershipChanges          []*model.MembershipChangeMsg
 	resultNextMembershipCursor int64
 
+	channelInfo             *model.SyncChannelInfo
+	bookmarks               []*model.ChannelBookmark
+	propertyValues          []*model.SyncPropertyValue
+	resultNextContentCursor model.SharedChannelContentCursor
+
 	resultRepeat                bool
 	resultNextCursor            model.GetPostsSinceForSyncCursor
 	GlobalUserSyncLastTimestamp int64
@@ -65,7 +70,12 @@ func newSyncData(task syncTask, rc *model.RemoteCluster, scr *model.SharedChanne
 }
 
 func (sd *syncData) isEmpty() bool {
-	return len(sd.users) == 0 && len(sd.profileImages) == 0 && len(sd.posts) == 0 && len(sd.reactions) == 0 && len(sd.acknowledgements) == 0 && len(sd.attachments) == 0 && len(sd.membershipChanges) == 0
+	return len(sd.users) == 0 && len(sd.profileImages) == 0 && len(sd.posts) == 0 && len(sd.reactions) == 0 && len(sd.acknowledgements) == 0 && len(sd.attachments) == 0 && len(sd.membershipChanges) == 0 &&
+		!sd.hasChannelContent()
+}
+
+func (sd *syncData) hasChannelContent() bool {
+	return sd.channelInfo != nil || len(sd.bookmarks) != 0 || len(sd.propertyValues) != 0
 }
 
 func (sd *syncData) isCursorChanged() bool {
@@ -83,6 +93,9 @@ func (sd *syncData) setDataFromMsg(msg *model.SyncMsg) {
 	sd.reactions = msg.Reactions
 	sd.acknowledgements = msg.Acknowledgements
 	sd.statuses = msg.Statuses
+	sd.channelInfo = msg.ChannelInfo
+	sd.bookmarks = msg.Bookmarks
+	sd.propertyValues = msg.PropertyValues
 }
 
 // syncForRemote updates a remote cluster with any new posts/reactions for a specific
@@ -215,6 +228,11 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 		return fmt.Errorf("cannot fetch post attachments for sync %v: %w", sd, err)
 	}
 
+	// fetch channel header/purpose, bookmarks and property values
+	if err := scs.fetchChannelContentForSync(sd); err != nil {
+		return fmt.Errorf("cannot fetch channel content for sync %v: %w", sd, err)
+	}
+
 	if sd.isEmpty() {
 		scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Not sending sync data; everything filtered out",
 			mlog.String("remote", rc.DisplayName),
@@ -224,6 +242,7 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 		if sd.isCursorChanged() {
 			scs.updateCursorForRemote(sd.scr.Id, sd.rc, sd.resultNextCursor)
 		}
+		scs.updateContentCursorForRemote(sd.scr.Id, sd.rc, sd.resultNextContentCursor)
 		return nil
 	}
 
@@ -237,6 +256,9 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 		mlog.Int("reactions", len(sd.reactions)),
 		mlog.Int("acknowledgements", len(sd.acknowledgements)),
 		mlog.Int("attachments", len(sd.attachments)),
+		mlog.Bool("channel_info", sd.channelInfo != nil),
+		mlog.Int("bookmarks", len(sd.bookmarks)),
+		mlog.Int("property_values", len(sd.propertyValues)),
 	)
 
 	if !metricsRecorded && metrics != nil {
@@ -829,6 +851,15 @@ func (scs *Service) sendSyncData(sd *syncData) error {
 		scs.sendProfileImageSyncData(sd)
 	}
 
+	// send channel content last so that property values refer to posts the remote already has
+	if sd.hasChannelContent() {
+		if err := scs.sendChannelContentSyncData(sd); err != nil {
+			merr.Append(fmt.Errorf("cannot send channel content sync data: %w", err))
+		}
+	} else {
+		scs.updateContentCursorForRemote(sd.scr.Id, sd.rc, sd.resultNextContentCursor)
+	}
+
 	return merr.ErrorOrNil()
 }
 
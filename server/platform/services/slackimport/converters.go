@@ -22,6 +22,28 @@ func slackConvertTimeStamp(ts string) int64 {
 	return timeStamp * 1000 // Convert to milliseconds
 }
 
+// slackTimeStampLess returns whether the timestamp a is before b. Unlike comparing the
+// converted timestamps, it orders the posts made within the same second, such as a
+// message and the replies a bot made to it.
+func slackTimeStampLess(a, b string) bool {
+	aSeconds, aFraction, _ := strings.Cut(a, ".")
+	bSeconds, bFraction, _ := strings.Cut(b, ".")
+	if aSeconds != bSeconds {
+		return slackConvertTimeStamp(a) < slackConvertTimeStamp(b)
+	}
+
+	aMicros, _ := strconv.ParseInt(aFraction, 10, 64)
+	bMicros, _ := strconv.ParseInt(bFraction, 10, 64)
+	return aMicros < bMicros
+}
+
+// slackConvertEmojiName returns the name of the emoji a Slack reaction is made with.
+// Skin tones are dropped as reactions don't support them.
+func slackConvertEmojiName(name string) string {
+	emojiName, _, _ := strings.Cut(name, "::")
+	return emojiName
+}
+
 func slackConvertChannelName(channelName string, channelId string) string {
 	newName := strings.Trim(channelName, "_-")
 	if len(newName) == 1 {
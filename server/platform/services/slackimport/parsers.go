@@ -38,6 +38,17 @@ func slackParseUsers(data io.Reader) ([]slackUser, error) {
 	return users, err
 }
 
+func slackParseUserGroups(data io.Reader) ([]slackUserGroup, error) {
+	decoder := json.NewDecoder(data)
+
+	var groups []slackUserGroup
+	if err := decoder.Decode(&groups); err != nil {
+		mlog.Warn("Slack Import: Error occurred when parsing some Slack user groups. Import may work anyway.", mlog.Err(err))
+		return groups, err
+	}
+	return groups, nil
+}
+
 func slackParsePosts(data io.Reader) ([]slackPost, error) {
 	decoder := json.NewDecoder(data)
 
@@ -0,0 +1,159 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package slackimport
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+)
+
+// ReportSection is the part of the import a ReportEntry relates to.
+type ReportSection string
+
+const (
+	ReportSectionImport   ReportSection = "import"
+	ReportSectionUsers    ReportSection = "users"
+	ReportSectionGroups   ReportSection = "groups"
+	ReportSectionChannels ReportSection = "channels"
+	ReportSectionPosts    ReportSection = "posts"
+)
+
+// ReportLevel is the severity of a ReportEntry.
+type ReportLevel string
+
+const (
+	ReportLevelInfo    ReportLevel = "info"
+	ReportLevelWarning ReportLevel = "warning"
+	ReportLevelError   ReportLevel = "error"
+)
+
+// ReportEntry is a single event of the import, such as a user being created
+// or a channel failing to be imported.
+type ReportEntry struct {
+	Section ReportSection `json:"section"`
+	Level   ReportLevel   `json:"level"`
+	Id      string        `json:"id"`
+	Message string        `json:"message"`
+}
+
+// ReportCount counts the items of one kind found in the export.
+type ReportCount struct {
+	Imported int `json:"imported"`
+	Merged   int `json:"merged"`
+	Failed   int `json:"failed"`
+}
+
+// Report is the outcome of a Slack import.
+type Report struct {
+	// Workspaces lists the workspaces of an Enterprise Grid export.
+	// It is empty for the export of a single workspace.
+	Workspaces  []string      `json:"workspaces"`
+	Users       ReportCount   `json:"users"`
+	Groups      ReportCount   `json:"groups"`
+	Channels    ReportCount   `json:"channels"`
+	Posts       ReportCount   `json:"posts"`
+	Replies     int           `json:"replies"`
+	PinnedPosts int           `json:"pinned_posts"`
+	EditedPosts int           `json:"edited_posts"`
+	Reactions   ReportCount   `json:"reactions"`
+	Files       ReportCount   `json:"files"`
+	Canvases    ReportCount   `json:"canvases"`
+	Entries     []ReportEntry `json:"entries"`
+}
+
+func newReport() *Report {
+	return &Report{
+		Workspaces: []string{},
+		Entries:    []ReportEntry{},
+	}
+}
+
+func (r *Report) add(section ReportSection, level ReportLevel, id string, params map[string]any) {
+	r.Entries = append(r.Entries, ReportEntry{
+		Section: section,
+		Level:   level,
+		Id:      id,
+		Message: i18n.T(id, params),
+	})
+}
+
+func (r *Report) info(section ReportSection, id string, params map[string]any) {
+	r.add(section, ReportLevelInfo, id, params)
+}
+
+func (r *Report) warn(section ReportSection, id string, params map[string]any) {
+	r.add(section, ReportLevelWarning, id, params)
+}
+
+func (r *Report) fail(section ReportSection, id string, params map[string]any) {
+	r.add(section, ReportLevelError, id, params)
+}
+
+// String renders the report as the human readable log of the import.
+func (r *Report) String() string {
+	var sb strings.Builder
+	sb.WriteString(i18n.T("api.slackimport.slack_import.log"))
+
+	r.writeSection(&sb, ReportSectionImport, "", "", nil)
+	r.writeSection(&sb, ReportSectionUsers, "api.slackimport.slack_add_users.created", "api.slackimport.slack_add_users.created_summary", map[string]any{
+		"Imported": r.Users.Imported,
+		"Merged":   r.Users.Merged,
+		"Failed":   r.Users.Failed,
+	})
+	r.writeSection(&sb, ReportSectionGroups, "api.slackimport.slack_add_groups.added", "api.slackimport.slack_add_groups.added_summary", map[string]any{
+		"Imported": r.Groups.Imported,
+		"Merged":   r.Groups.Merged,
+		"Failed":   r.Groups.Failed,
+	})
+	r.writeSection(&sb, ReportSectionChannels, "api.slackimport.slack_add_channels.added", "api.slackimport.slack_add_channels.added_summary", map[string]any{
+		"Imported": r.Channels.Imported,
+		"Merged":   r.Channels.Merged,
+		"Failed":   r.Channels.Failed,
+	})
+	r.writeSection(&sb, ReportSectionPosts, "api.slackimport.slack_add_posts.added", "api.slackimport.slack_add_posts.added_summary", map[string]any{
+		"Imported":        r.Posts.Imported,
+		"Failed":          r.Posts.Failed,
+		"Replies":         r.Replies,
+		"PinnedPosts":     r.PinnedPosts,
+		"EditedPosts":     r.EditedPosts,
+		"Reactions":       r.Reactions.Imported,
+		"ReactionsFailed": r.Reactions.Failed,
+		"Files":           r.Files.Imported,
+		"FilesFailed":     r.Files.Failed,
+		"Canvases":        r.Canvases.Imported,
+		"CanvasesFailed":  r.Canvases.Failed,
+	})
+
+	title := i18n.T("api.slackimport.slack_import.notes")
+	sb.WriteString(title)
+	sb.WriteString(underline(title))
+	sb.WriteString(i18n.T("api.slackimport.slack_import.note1"))
+	sb.WriteString(i18n.T("api.slackimport.slack_import.note2"))
+	sb.WriteString(i18n.T("api.slackimport.slack_import.note3"))
+
+	return sb.String()
+}
+
+// writeSection writes the title and summary of a section followed by its entries.
+// Sections without a title only have their entries written.
+func (r *Report) writeSection(sb *strings.Builder, section ReportSection, titleID, summaryID string, counts map[string]any) {
+	if titleID != "" {
+		title := i18n.T(titleID)
+		sb.WriteString(title)
+		sb.WriteString(underline(title))
+		sb.WriteString(i18n.T(summaryID, counts))
+	}
+
+	for _, entry := range r.Entries {
+		if entry.Section == section {
+			sb.WriteString(entry.Message)
+		}
+	}
+}
+
+func underline(title string) string {
+	return strings.Repeat("=", utf8.RuneCountInString(strings.TrimSpace(title))) + "\r\n\r\n"
+}
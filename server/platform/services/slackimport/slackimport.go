@@ -11,15 +11,16 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	"github.com/mattermost/mattermost/server/public/model"
-	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
@@ -33,13 +34,22 @@ type slackChannel struct {
 	Members []string        `json:"members"`
 	Purpose slackChannelSub `json:"purpose"`
 	Topic   slackChannelSub `json:"topic"`
+	Pins    []slackPin      `json:"pins"`
 	Type    model.ChannelType
+
+	// workspaces are the folders of the export the channel was found in. There is more than
+	// one for the channels shared between the workspaces of an Enterprise Grid export.
+	workspaces []string
 }
 
 type slackChannelSub struct {
 	Value string `json:"value"`
 }
 
+type slackPin struct {
+	Id string `json:"id"`
+}
+
 type slackProfile struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
@@ -52,9 +62,35 @@ type slackUser struct {
 	Profile  slackProfile `json:"profile"`
 }
 
+type slackUserGroup struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Handle      string   `json:"handle"`
+	Description string   `json:"description"`
+	Users       []string `json:"users"`
+	DateDelete  int64    `json:"date_delete"`
+}
+
 type slackFile struct {
-	Id    string `json:"id"`
-	Title string `json:"title"`
+	Id       string `json:"id"`
+	Title    string `json:"title"`
+	Filetype string `json:"filetype"`
+}
+
+// isCanvas returns whether the file is a Slack canvas, which is shared in
+// messages like any other file.
+func (f *slackFile) isCanvas() bool {
+	return f.Filetype == "canvas" || f.Filetype == "quip"
+}
+
+type slackReaction struct {
+	Name  string   `json:"name"`
+	Users []string `json:"users"`
+}
+
+type slackEdited struct {
+	User      string `json:"user"`
+	TimeStamp string `json:"ts"`
 }
 
 type slackPost struct {
@@ -71,12 +107,29 @@ type slackPost struct {
 	File        *slackFile                 `json:"file"`
 	Files       []*slackFile               `json:"files"`
 	Attachments []*model.MessageAttachment `json:"attachments"`
+	Reactions   []slackReaction            `json:"reactions"`
+	PinnedTo    []string                   `json:"pinned_to"`
+	Edited      *slackEdited               `json:"edited"`
 }
 
 var isValidChannelNameCharacters = regexp.MustCompile(`^[a-zA-Z0-9\-_]+$`).MatchString
 
 const slackImportMaxFileSize = 1024 * 1024 * 70
 
+// slackChannelFiles are the files listing the channels of a workspace, by the type of their channels.
+var slackChannelFiles = map[string]model.ChannelType{
+	"channels.json": model.ChannelTypeOpen,
+	"dms.json":      model.ChannelTypeDirect,
+	"groups.json":   model.ChannelTypePrivate,
+	"mpims.json":    model.ChannelTypeGroup,
+}
+
+const (
+	slackUsersFile      = "users.json"
+	slackUserGroupsFile = "usergroups.json"
+	slackUploadsFolder  = "__uploads"
+)
+
 type slackComment struct {
 	User    string `json:"user"`
 	Comment string `json:"comment"`
@@ -97,6 +150,10 @@ type Actions struct {
 	MaxPostSize            func() int
 	SendPasswordReset      func(string) (bool, *model.AppError)
 	PrepareImage           func(fileData []byte) (image.Image, string, func(), error)
+	// CreateGroupWithUserIds and UpsertGroupMembers are left unset when custom
+	// groups are not available, in which case user groups are not imported.
+	CreateGroupWithUserIds func(*model.GroupWithUserIds) (*model.Group, *model.AppError)
+	UpsertGroupMembers     func(string, []string) ([]*model.GroupMember, *model.AppError)
 }
 
 // SlackImporter is a service that allows to import slack dumps into mattermost
@@ -128,85 +185,91 @@ func NewWithAdminFlag(store store.Store, actions Actions, config *model.Config,
 	}
 }
 
-func (si *SlackImporter) SlackImport(rctx request.CTX, fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *bytes.Buffer) {
-	// Create log file
-	log := bytes.NewBufferString(i18n.T("api.slackimport.slack_import.log"))
+// SlackImport imports the Slack export in fileData into the team, and returns
+// a report of what was imported.
+func (si *SlackImporter) SlackImport(rctx request.CTX, fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *Report) {
+	report := newReport()
 
 	zipreader, err := zip.NewReader(fileData, fileSize)
 	if err != nil || zipreader.File == nil {
-		log.WriteString(i18n.T("api.slackimport.slack_import.zip.app_error"))
-		return model.NewAppError("SlackImport", "api.slackimport.slack_import.zip.app_error", nil, "", http.StatusBadRequest).Wrap(err), log
+		report.fail(ReportSectionImport, "api.slackimport.slack_import.zip.app_error", nil)
+		return model.NewAppError("SlackImport", "api.slackimport.slack_import.zip.app_error", nil, "", http.StatusBadRequest).Wrap(err), report
 	}
 
-	var channels []slackChannel
-	var publicChannels []slackChannel
-	var privateChannels []slackChannel
-	var groupChannels []slackChannel
-	var directChannels []slackChannel
+	workspaces := slackWorkspaces(zipreader.File)
+	for workspace := range workspaces {
+		if workspace != "." {
+			report.Workspaces = append(report.Workspaces, workspace)
+		}
+	}
+	sort.Strings(report.Workspaces)
+	if len(report.Workspaces) > 0 {
+		report.info(ReportSectionImport, "api.slackimport.slack_import.workspaces", map[string]any{"Workspaces": strings.Join(report.Workspaces, ", ")})
+	}
 
+	var channels []slackChannel
 	var users []slackUser
+	var userGroups []slackUserGroup
 	posts := make(map[string][]slackPost)
 	uploads := make(map[string]*zip.File)
 	for _, file := range zipreader.File {
+		dir, name := path.Dir(file.Name), path.Base(file.Name)
+
+		var isPostsFile bool
+		switch {
+		case workspaces[dir]:
+			// Metadata of the workspace.
+			if _, ok := slackChannelFiles[name]; !ok && name != slackUsersFile && name != slackUserGroupsFile {
+				continue
+			}
+		case workspaces[path.Dir(dir)] && strings.HasSuffix(name, ".json"):
+			// Posts of a single day, in the folder of their channel.
+			isPostsFile = true
+		case path.Base(path.Dir(dir)) == slackUploadsFolder && workspaces[path.Dir(path.Dir(dir))]:
+			// Files, in a folder named after their ID.
+			uploads[path.Base(dir)] = file
+			continue
+		default:
+			continue
+		}
+
 		fileReader, err := file.Open()
 		if err != nil {
-			log.WriteString(i18n.T("api.slackimport.slack_import.open.app_error", map[string]any{"Filename": file.Name}))
-			return model.NewAppError("SlackImport", "api.slackimport.slack_import.open.app_error", map[string]any{"Filename": file.Name}, "", http.StatusInternalServerError).Wrap(err), log
+			report.fail(ReportSectionImport, "api.slackimport.slack_import.open.app_error", map[string]any{"Filename": file.Name})
+			return model.NewAppError("SlackImport", "api.slackimport.slack_import.open.app_error", map[string]any{"Filename": file.Name}, "", http.StatusInternalServerError).Wrap(err), report
 		}
 		defer fileReader.Close()
 
 		reader := utils.NewLimitedReaderWithError(fileReader, slackImportMaxFileSize)
-		if file.Name == "channels.json" {
-			publicChannels, err = slackParseChannels(reader, model.ChannelTypeOpen)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, publicChannels...)
-		} else if file.Name == "dms.json" {
-			directChannels, err = slackParseChannels(reader, model.ChannelTypeDirect)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, directChannels...)
-		} else if file.Name == "groups.json" {
-			privateChannels, err = slackParseChannels(reader, model.ChannelTypePrivate)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, privateChannels...)
-		} else if file.Name == "mpims.json" {
-			groupChannels, err = slackParseChannels(reader, model.ChannelTypeGroup)
-			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-				continue
-			}
-			channels = append(channels, groupChannels...)
-		} else if file.Name == "users.json" {
-			users, err = slackParseUsers(reader)
+		if isPostsFile {
+			newposts, err := slackParsePosts(reader)
 			if errors.Is(err, utils.ErrSizeLimitExceeded) {
-				log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
+				report.warn(ReportSectionImport, "api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name})
 				continue
 			}
-		} else {
-			spl := strings.Split(file.Name, "/")
-			if len(spl) == 2 && strings.HasSuffix(spl[1], ".json") {
-				newposts, err := slackParsePosts(reader)
-				if errors.Is(err, utils.ErrSizeLimitExceeded) {
-					log.WriteString(i18n.T("api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name}))
-					continue
-				}
-				channel := spl[0]
-				if _, ok := posts[channel]; !ok {
-					posts[channel] = newposts
-				} else {
-					posts[channel] = append(posts[channel], newposts...)
-				}
-			} else if len(spl) == 3 && spl[0] == "__uploads" {
-				uploads[spl[1]] = file
+			posts[dir] = append(posts[dir], newposts...)
+			continue
+		}
+
+		switch name {
+		case slackUsersFile:
+			var newusers []slackUser
+			newusers, err = slackParseUsers(reader)
+			users = slackMergeUsers(users, newusers)
+		case slackUserGroupsFile:
+			var newgroups []slackUserGroup
+			newgroups, err = slackParseUserGroups(reader)
+			userGroups = append(userGroups, newgroups...)
+		default:
+			var newchannels []slackChannel
+			newchannels, err = slackParseChannels(reader, slackChannelFiles[name])
+			for i := range newchannels {
+				newchannels[i].workspaces = []string{dir}
 			}
+			channels = slackMergeChannels(channels, newchannels)
+		}
+		if errors.Is(err, utils.ErrSizeLimitExceeded) {
+			report.warn(ReportSectionImport, "api.slackimport.slack_import.zip.file_too_large", map[string]any{"Filename": file.Name})
 		}
 	}
 
@@ -214,27 +277,77 @@ func (si *SlackImporter) SlackImport(rctx request.CTX, fileData multipart.File,
 	posts = slackConvertChannelMentions(channels, posts)
 	posts = slackConvertPostsMarkup(posts)
 
-	addedUsers := si.slackAddUsers(rctx, teamID, users, log)
-	botUser := si.slackAddBotUser(rctx, teamID, log)
+	addedUsers := si.slackAddUsers(rctx, teamID, users, report)
+	botUser := si.slackAddBotUser(rctx, teamID, report)
 
-	si.slackAddChannels(rctx, teamID, channels, posts, addedUsers, uploads, botUser, log)
+	si.slackAddGroups(rctx, userGroups, addedUsers, report)
+	si.slackAddChannels(rctx, teamID, channels, posts, addedUsers, uploads, botUser, report)
 
 	if botUser != nil {
 		si.deactivateSlackBotUser(rctx, botUser)
 	}
 
 	if err := si.actions.InvalidateAllCaches(); err != nil {
-		return err, log
+		return err, report
+	}
+
+	return nil, report
+}
+
+// slackWorkspaces returns the folders of the export holding the metadata of a workspace.
+// That is the root of the export, along with the folder of each workspace of an
+// Enterprise Grid export, whose layout is the same as the export of a single workspace.
+func slackWorkspaces(files []*zip.File) map[string]bool {
+	workspaces := map[string]bool{".": true}
+	for _, file := range files {
+		name := path.Base(file.Name)
+		if _, ok := slackChannelFiles[name]; ok || name == slackUsersFile {
+			workspaces[path.Dir(file.Name)] = true
+		}
+	}
+	return workspaces
+}
+
+// slackMergeUsers adds the users missing from users, as the users of an
+// Enterprise Grid export are listed by each of the workspaces they are a member of.
+func slackMergeUsers(users []slackUser, newusers []slackUser) []slackUser {
+	known := make(map[string]bool, len(users))
+	for _, user := range users {
+		known[user.Id] = true
 	}
 
-	log.WriteString(i18n.T("api.slackimport.slack_import.notes"))
-	log.WriteString("=======\r\n\r\n")
+	for _, newuser := range newusers {
+		if !known[newuser.Id] {
+			known[newuser.Id] = true
+			users = append(users, newuser)
+		}
+	}
+	return users
+}
 
-	log.WriteString(i18n.T("api.slackimport.slack_import.note1"))
-	log.WriteString(i18n.T("api.slackimport.slack_import.note2"))
-	log.WriteString(i18n.T("api.slackimport.slack_import.note3"))
+// slackMergeChannels adds the channels missing from channels. The channels shared between
+// the workspaces of an Enterprise Grid export are merged, keeping the posts of every workspace.
+func slackMergeChannels(channels []slackChannel, newchannels []slackChannel) []slackChannel {
+	known := make(map[string]int, len(channels))
+	for i, channel := range channels {
+		known[channel.Id] = i
+	}
 
-	return nil, log
+	for _, newchannel := range newchannels {
+		i, ok := known[newchannel.Id]
+		if !ok {
+			known[newchannel.Id] = len(channels)
+			channels = append(channels, newchannel)
+			continue
+		}
+		for _, workspace := range newchannel.workspaces {
+			if !slices.Contains(channels[i].workspaces, workspace) {
+				channels[i].workspaces = append(channels[i].workspaces, workspace)
+			}
+		}
+		channels[i].Pins = append(channels[i].Pins, newchannel.Pins...)
+	}
+	return channels
 }
 
 func truncateRunes(s string, i int) string {
@@ -245,17 +358,13 @@ func truncateRunes(s string, i int) string {
 	return s
 }
 
-func (si *SlackImporter) slackAddUsers(rctx request.CTX, teamId string, slackusers []slackUser, importerLog *bytes.Buffer) map[string]*model.User {
-	// Log header
-	importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.created"))
-	importerLog.WriteString("===============\r\n\r\n")
-
+func (si *SlackImporter) slackAddUsers(rctx request.CTX, teamId string, slackusers []slackUser, report *Report) map[string]*model.User {
 	addedUsers := make(map[string]*model.User)
 
 	// Need the team
 	team, err := si.store.Team().Get(teamId)
 	if err != nil {
-		importerLog.WriteString(i18n.T("api.slackimport.slack_import.team_fail"))
+		report.fail(ReportSectionUsers, "api.slackimport.slack_import.team_fail", nil)
 		return addedUsers
 	}
 
@@ -265,17 +374,18 @@ func (si *SlackImporter) slackAddUsers(rctx request.CTX, teamId string, slackuse
 		email := sUser.Profile.Email
 		if email == "" {
 			email = sUser.Username + "@example.com"
-			importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.missing_email_address", map[string]any{"Email": email, "Username": sUser.Username}))
+			report.warn(ReportSectionUsers, "api.slackimport.slack_add_users.missing_email_address", map[string]any{"Email": email, "Username": sUser.Username})
 			rctx.Logger().Warn("Slack Import: User does not have an email address in the Slack export. Used username as a placeholder. The user should update their email address once logged in to the system.", mlog.String("user_email", email), mlog.String("user_name", sUser.Username))
 		}
 
 		// Check for email conflict and use existing user if found
 		if existingUser, err := si.store.User().GetByEmail(email); err == nil {
 			addedUsers[sUser.Id] = existingUser
+			report.Users.Merged++
 			if _, err := si.actions.JoinUserToTeam(team, addedUsers[sUser.Id], ""); err != nil {
-				importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.merge_existing_failed", map[string]any{"Email": existingUser.Email, "Username": existingUser.Username}))
+				report.warn(ReportSectionUsers, "api.slackimport.slack_add_users.merge_existing_failed", map[string]any{"Email": existingUser.Email, "Username": existingUser.Username})
 			} else {
-				importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.merge_existing", map[string]any{"Email": existingUser.Email, "Username": existingUser.Username}))
+				report.info(ReportSectionUsers, "api.slackimport.slack_add_users.merge_existing", map[string]any{"Email": existingUser.Email, "Username": existingUser.Username})
 			}
 			continue
 		}
@@ -291,7 +401,8 @@ func (si *SlackImporter) slackAddUsers(rctx request.CTX, teamId string, slackuse
 
 		mUser := si.oldImportUser(rctx, team, &newUser)
 		if mUser == nil {
-			importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.unable_import", map[string]any{"Username": sUser.Username}))
+			report.Users.Failed++
+			report.fail(ReportSectionUsers, "api.slackimport.slack_add_users.unable_import", map[string]any{"Username": sUser.Username})
 			continue
 		}
 
@@ -301,20 +412,21 @@ func (si *SlackImporter) slackAddUsers(rctx request.CTX, teamId string, slackuse
 		}
 
 		if !sent {
-			importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.send_reset_email_failed", map[string]any{"Username": sUser.Username, "Email": newUser.Email}))
+			report.warn(ReportSectionUsers, "api.slackimport.slack_add_users.send_reset_email_failed", map[string]any{"Username": sUser.Username, "Email": newUser.Email})
 		}
 
 		addedUsers[sUser.Id] = mUser
-		importerLog.WriteString(i18n.T("api.slackimport.slack_add_users.email", map[string]any{"Email": newUser.Email}))
+		report.Users.Imported++
+		report.info(ReportSectionUsers, "api.slackimport.slack_add_users.email", map[string]any{"Email": newUser.Email})
 	}
 
 	return addedUsers
 }
 
-func (si *SlackImporter) slackAddBotUser(rctx request.CTX, teamId string, log *bytes.Buffer) *model.User {
+func (si *SlackImporter) slackAddBotUser(rctx request.CTX, teamId string, report *Report) *model.User {
 	team, err := si.store.Team().Get(teamId)
 	if err != nil {
-		log.WriteString(i18n.T("api.slackimport.slack_import.team_fail"))
+		report.fail(ReportSectionUsers, "api.slackimport.slack_import.team_fail", nil)
 		return nil
 	}
 
@@ -332,114 +444,151 @@ func (si *SlackImporter) slackAddBotUser(rctx request.CTX, teamId string, log *b
 
 	mUser := si.oldImportUser(rctx, team, &botUser)
 	if mUser == nil {
-		log.WriteString(i18n.T("api.slackimport.slack_add_bot_user.unable_import", map[string]any{"Username": username}))
+		report.fail(ReportSectionUsers, "api.slackimport.slack_add_bot_user.unable_import", map[string]any{"Username": username})
 		return nil
 	}
 
-	log.WriteString(i18n.T("api.slackimport.slack_add_bot_user.email", map[string]any{"Email": botUser.Email}))
+	report.info(ReportSectionUsers, "api.slackimport.slack_add_bot_user.email", map[string]any{"Email": botUser.Email})
 	return mUser
 }
 
-func (si *SlackImporter) slackAddPosts(rctx request.CTX, teamId string, channel *model.Channel, posts []slackPost, users map[string]*model.User, uploads map[string]*zip.File, botUser *model.User) {
-	sort.Slice(posts, func(i, j int) bool {
-		return slackConvertTimeStamp(posts[i].TimeStamp) < slackConvertTimeStamp(posts[j].TimeStamp)
+func (si *SlackImporter) slackAddGroups(rctx request.CTX, slackgroups []slackUserGroup, users map[string]*model.User, report *Report) {
+	if len(slackgroups) == 0 {
+		return
+	}
+
+	if si.actions.CreateGroupWithUserIds == nil || si.actions.UpsertGroupMembers == nil {
+		report.warn(ReportSectionGroups, "api.slackimport.slack_add_groups.unavailable", nil)
+		report.Groups.Failed += len(slackgroups)
+		return
+	}
+
+	for _, sGroup := range slackgroups {
+		// Groups which were deleted in Slack are still part of the export.
+		if sGroup.DateDelete > 0 {
+			continue
+		}
+
+		var userIds []string
+		for _, member := range sGroup.Users {
+			if user, ok := users[member]; ok {
+				userIds = append(userIds, user.Id)
+			}
+		}
+
+		name := strings.ToLower(sGroup.Handle)
+		if existingGroup, err := si.store.Group().GetByName(name, model.GroupSearchOpts{}); err == nil {
+			// The group already exists. Merge with the existing one if it is a custom group.
+			if existingGroup.Source != model.GroupSourceCustom {
+				report.Groups.Failed++
+				report.fail(ReportSectionGroups, "api.slackimport.slack_add_groups.import_failed", map[string]any{"Name": name})
+				continue
+			}
+			if len(userIds) > 0 {
+				if _, appErr := si.actions.UpsertGroupMembers(existingGroup.Id, userIds); appErr != nil {
+					rctx.Logger().Warn("Slack Import: Unable to add the members of the Slack user group.", mlog.String("group_name", name), mlog.Err(appErr))
+					report.Groups.Failed++
+					report.fail(ReportSectionGroups, "api.slackimport.slack_add_groups.import_failed", map[string]any{"Name": name})
+					continue
+				}
+			}
+			report.Groups.Merged++
+			report.info(ReportSectionGroups, "api.slackimport.slack_add_groups.merge", map[string]any{"Name": name})
+			continue
+		}
+
+		group := &model.GroupWithUserIds{
+			Group: model.Group{
+				Name:           new(name),
+				DisplayName:    sGroup.Name,
+				Description:    sGroup.Description,
+				Source:         model.GroupSourceCustom,
+				AllowReference: true,
+			},
+			UserIds: userIds,
+		}
+		if _, appErr := si.actions.CreateGroupWithUserIds(group); appErr != nil {
+			rctx.Logger().Warn("Slack Import: Unable to import the Slack user group.", mlog.String("group_name", name), mlog.Err(appErr))
+			report.Groups.Failed++
+			report.fail(ReportSectionGroups, "api.slackimport.slack_add_groups.import_failed", map[string]any{"Name": name})
+			continue
+		}
+
+		report.Groups.Imported++
+		report.info(ReportSectionGroups, "api.slackimport.slack_add_groups.imported", map[string]any{"Name": name})
+	}
+}
+
+func (si *SlackImporter) slackAddPosts(rctx request.CTX, teamId string, channel *model.Channel, posts []slackPost, pinned map[string]bool, users map[string]*model.User, uploads map[string]*zip.File, botUser *model.User, report *Report) {
+	sort.SliceStable(posts, func(i, j int) bool {
+		return slackTimeStampLess(posts[i].TimeStamp, posts[j].TimeStamp)
 	})
 	threads := make(map[string]string)
 	for _, sPost := range posts {
+		var newPost *model.Post
+		// Props are only set for posts imported as incoming webhook posts.
+		var props model.StringInterface
+
 		switch {
-		case sPost.Type == "message" && (sPost.SubType == "" || sPost.SubType == "file_share"):
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
+		case sPost.Type == "message" && (sPost.SubType == "" || sPost.SubType == "file_share" || sPost.SubType == "thread_broadcast"):
+			author := slackPostAuthor(rctx, sPost.User, users)
+			if author == nil {
+				report.Posts.Failed++
 				continue
 			}
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
+			newPost = &model.Post{
+				UserId:    author.Id,
 				ChannelId: channel.Id,
 				Message:   sPost.Text,
 				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
 			}
-			if sPost.Upload {
-				if sPost.File != nil {
-					if fileInfo, ok := si.slackUploadFile(rctx, sPost.File, uploads, teamId, newPost.ChannelId, newPost.UserId, sPost.TimeStamp); ok {
-						newPost.FileIds = append(newPost.FileIds, fileInfo.Id)
-					}
-				} else if sPost.Files != nil {
-					for _, file := range sPost.Files {
-						if fileInfo, ok := si.slackUploadFile(rctx, file, uploads, teamId, newPost.ChannelId, newPost.UserId, sPost.TimeStamp); ok {
-							newPost.FileIds = append(newPost.FileIds, fileInfo.Id)
-						}
-					}
-				}
-			}
-			// If post in thread
-			if sPost.ThreadTS != "" && sPost.ThreadTS != sPost.TimeStamp {
-				newPost.RootId = threads[sPost.ThreadTS]
-			}
-			postId := si.oldImportPost(rctx, &newPost)
-			// If post is thread starter
-			if sPost.ThreadTS == sPost.TimeStamp {
-				threads[sPost.ThreadTS] = postId
-			}
+			si.slackAddPostFiles(rctx, sPost, newPost, uploads, teamId, report)
 		case sPost.Type == "message" && sPost.SubType == "file_comment":
 			if sPost.Comment == nil {
 				rctx.Logger().Debug("Slack Import: Unable to import the message as it has no comments.")
+				report.Posts.Failed++
 				continue
 			}
-			if sPost.Comment.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.Comment.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
+			author := slackPostAuthor(rctx, sPost.Comment.User, users)
+			if author == nil {
+				report.Posts.Failed++
 				continue
 			}
-			newPost := model.Post{
-				UserId:    users[sPost.Comment.User].Id,
+			newPost = &model.Post{
+				UserId:    author.Id,
 				ChannelId: channel.Id,
 				Message:   sPost.Comment.Comment,
 				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
 			}
-			si.oldImportPost(rctx, &newPost)
 		case sPost.Type == "message" && sPost.SubType == "bot_message":
 			if botUser == nil {
 				rctx.Logger().Warn("Slack Import: Unable to import the bot message as the bot user does not exist.")
+				report.Posts.Failed++
 				continue
 			}
 			if sPost.BotId == "" {
 				rctx.Logger().Warn("Slack Import: Unable to import bot message as the BotId field is missing.")
+				report.Posts.Failed++
 				continue
 			}
 
-			props := make(model.StringInterface)
+			props = make(model.StringInterface)
 			props[model.PostPropsOverrideUsername] = sPost.BotUsername
 			if len(sPost.Attachments) > 0 {
 				props[model.PostPropsAttachments] = sPost.Attachments
 			}
 
-			post := &model.Post{
+			newPost = &model.Post{
 				UserId:    botUser.Id,
 				ChannelId: channel.Id,
 				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
 				Message:   sPost.Text,
 				Type:      model.PostTypeMessageAttachment,
 			}
-
-			postId := si.oldImportIncomingWebhookPost(rctx, post, props)
-			// If post is thread starter
-			if sPost.ThreadTS == sPost.TimeStamp {
-				threads[sPost.ThreadTS] = postId
-			}
 		case sPost.Type == "message" && (sPost.SubType == "channel_join" || sPost.SubType == "channel_leave"):
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
+			author := slackPostAuthor(rctx, sPost.User, users)
+			if author == nil {
+				report.Posts.Failed++
 				continue
 			}
 
@@ -450,94 +599,173 @@ func (si *SlackImporter) slackAddPosts(rctx request.CTX, teamId string, channel
 				postType = model.PostTypeLeaveChannel
 			}
 
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
+			newPost = &model.Post{
+				UserId:    author.Id,
 				ChannelId: channel.Id,
 				Message:   sPost.Text,
 				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
 				Type:      postType,
 				Props: model.StringInterface{
-					"username": users[sPost.User].Username,
+					"username": author.Username,
 				},
 			}
-			si.oldImportPost(rctx, &newPost)
 		case sPost.Type == "message" && sPost.SubType == "me_message":
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
+			author := slackPostAuthor(rctx, sPost.User, users)
+			if author == nil {
+				report.Posts.Failed++
 				continue
 			}
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
+			newPost = &model.Post{
+				UserId:    author.Id,
 				ChannelId: channel.Id,
 				Message:   "*" + sPost.Text + "*",
 				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
 			}
-			postId := si.oldImportPost(rctx, &newPost)
-			// If post is thread starter
-			if sPost.ThreadTS == sPost.TimeStamp {
-				threads[sPost.ThreadTS] = postId
-			}
-		case sPost.Type == "message" && sPost.SubType == "channel_topic":
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
-				continue
-			}
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
-				ChannelId: channel.Id,
-				Message:   sPost.Text,
-				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
-				Type:      model.PostTypeHeaderChange,
-			}
-			si.oldImportPost(rctx, &newPost)
-		case sPost.Type == "message" && sPost.SubType == "channel_purpose":
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
-				continue
-			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
-				continue
-			}
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
-				ChannelId: channel.Id,
-				Message:   sPost.Text,
-				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
-				Type:      model.PostTypePurposeChange,
-			}
-			si.oldImportPost(rctx, &newPost)
-		case sPost.Type == "message" && sPost.SubType == "channel_name":
-			if sPost.User == "" {
-				rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
+		case sPost.Type == "message" && (sPost.SubType == "channel_topic" || sPost.SubType == "channel_purpose" || sPost.SubType == "channel_name"):
+			author := slackPostAuthor(rctx, sPost.User, users)
+			if author == nil {
+				report.Posts.Failed++
 				continue
 			}
-			if users[sPost.User] == nil {
-				rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", sPost.User))
-				continue
+
+			var postType string
+			switch sPost.SubType {
+			case "channel_topic":
+				postType = model.PostTypeHeaderChange
+			case "channel_purpose":
+				postType = model.PostTypePurposeChange
+			default:
+				postType = model.PostTypeDisplaynameChange
 			}
-			newPost := model.Post{
-				UserId:    users[sPost.User].Id,
+
+			newPost = &model.Post{
+				UserId:    author.Id,
 				ChannelId: channel.Id,
 				Message:   sPost.Text,
 				CreateAt:  slackConvertTimeStamp(sPost.TimeStamp),
-				Type:      model.PostTypeDisplaynameChange,
+				Type:      postType,
 			}
-			si.oldImportPost(rctx, &newPost)
 		default:
 			rctx.Logger().Warn(
 				"Slack Import: Unable to import the message as its type is not supported",
 				mlog.String("post_type", sPost.Type),
 				mlog.String("post_subtype", sPost.SubType),
 			)
+			continue
+		}
+
+		// If post in thread
+		if sPost.ThreadTS != "" && sPost.ThreadTS != sPost.TimeStamp {
+			newPost.RootId = threads[sPost.ThreadTS]
+		}
+		newPost.IsPinned = len(sPost.PinnedTo) > 0 || pinned[sPost.TimeStamp]
+		if sPost.Edited != nil && sPost.Edited.TimeStamp != "" {
+			newPost.EditAt = slackConvertTimeStamp(sPost.Edited.TimeStamp)
+		}
+
+		createAt := newPost.CreateAt
+		isReply := newPost.RootId != ""
+		var postId string
+		if props != nil {
+			postId = si.oldImportIncomingWebhookPost(rctx, newPost, props)
+		} else {
+			postId = si.oldImportPost(rctx, newPost)
+		}
+		if postId == "" {
+			report.Posts.Failed++
+			continue
+		}
+
+		report.Posts.Imported++
+		if isReply {
+			report.Replies++
+		}
+		if newPost.IsPinned {
+			report.PinnedPosts++
+		}
+		if newPost.EditAt != 0 {
+			report.EditedPosts++
+		}
+
+		// If post is thread starter
+		if sPost.ThreadTS == sPost.TimeStamp {
+			threads[sPost.ThreadTS] = postId
+		}
+
+		si.slackAddReactions(rctx, postId, channel.Id, createAt, sPost.Reactions, users, report)
+	}
+}
+
+// slackPostAuthor returns the user a post was made by, or nil if they were not imported.
+func slackPostAuthor(rctx request.CTX, slackUserId string, users map[string]*model.User) *model.User {
+	if slackUserId == "" {
+		rctx.Logger().Debug("Slack Import: Unable to import the message as the user field is missing.")
+		return nil
+	}
+	if users[slackUserId] == nil {
+		rctx.Logger().Debug("Slack Import: Unable to add the message as the Slack user does not exist in Mattermost.", mlog.String("user", slackUserId))
+		return nil
+	}
+	return users[slackUserId]
+}
+
+// slackAddPostFiles uploads the files of the post and attaches them to it.
+// Canvases shared in the post are attached the same way when they are part of the export.
+func (si *SlackImporter) slackAddPostFiles(rctx request.CTX, sPost slackPost, post *model.Post, uploads map[string]*zip.File, teamId string, report *Report) {
+	files := sPost.Files
+	if sPost.File != nil {
+		files = []*slackFile{sPost.File}
+	}
+
+	for _, file := range files {
+		if file == nil {
+			continue
+		}
+
+		count := &report.Files
+		if file.isCanvas() {
+			count = &report.Canvases
+		} else if !sPost.Upload {
+			continue
+		}
+
+		fileInfo, ok := si.slackUploadFile(rctx, file, uploads, teamId, post.ChannelId, post.UserId, sPost.TimeStamp)
+		if !ok {
+			count.Failed++
+			if file.isCanvas() {
+				report.warn(ReportSectionPosts, "api.slackimport.slack_add_posts.canvas_missing", map[string]any{"Title": file.Title})
+			}
+			continue
+		}
+		count.Imported++
+		post.FileIds = append(post.FileIds, fileInfo.Id)
+	}
+}
+
+func (si *SlackImporter) slackAddReactions(rctx request.CTX, postId string, channelId string, createAt int64, reactions []slackReaction, users map[string]*model.User, report *Report) {
+	for _, sReaction := range reactions {
+		emojiName := slackConvertEmojiName(sReaction.Name)
+		for _, slackUserId := range sReaction.Users {
+			user, ok := users[slackUserId]
+			if !ok {
+				rctx.Logger().Debug("Slack Import: Unable to add the reaction as the Slack user does not exist in Mattermost.", mlog.String("user", slackUserId))
+				report.Reactions.Failed++
+				continue
+			}
+
+			reaction := &model.Reaction{
+				UserId:    user.Id,
+				PostId:    postId,
+				ChannelId: channelId,
+				EmojiName: emojiName,
+				CreateAt:  createAt,
+			}
+			if _, err := si.store.Reaction().Save(reaction); err != nil {
+				rctx.Logger().Debug("Slack Import: Unable to save the reaction.", mlog.String("post_id", postId), mlog.String("emoji_name", emojiName), mlog.Err(err))
+				report.Reactions.Failed++
+				continue
+			}
+			report.Reactions.Imported++
 		}
 	}
 }
@@ -577,15 +805,15 @@ func (si *SlackImporter) deactivateSlackBotUser(rctx request.CTX, user *model.Us
 	}
 }
 
-func (si *SlackImporter) addSlackUsersToChannel(rctx request.CTX, members []string, users map[string]*model.User, channel *model.Channel, log *bytes.Buffer) {
+func (si *SlackImporter) addSlackUsersToChannel(rctx request.CTX, members []string, users map[string]*model.User, channel *model.Channel, report *Report) {
 	for _, member := range members {
 		user, ok := users[member]
 		if !ok {
-			log.WriteString(i18n.T("api.slackimport.slack_add_channels.failed_to_add_user", map[string]any{"Username": "?"}))
+			report.warn(ReportSectionChannels, "api.slackimport.slack_add_channels.failed_to_add_user", map[string]any{"Username": "?"})
 			continue
 		}
 		if _, err := si.actions.AddUserToChannel(rctx, user, channel, false); err != nil {
-			log.WriteString(i18n.T("api.slackimport.slack_add_channels.failed_to_add_user", map[string]any{"Username": user.Username}))
+			report.warn(ReportSectionChannels, "api.slackimport.slack_add_channels.failed_to_add_user", map[string]any{"Username": user.Username})
 		}
 	}
 }
@@ -614,11 +842,7 @@ func slackSanitiseChannelProperties(rctx request.CTX, channel model.Channel) mod
 	return channel
 }
 
-func (si *SlackImporter) slackAddChannels(rctx request.CTX, teamId string, slackchannels []slackChannel, posts map[string][]slackPost, users map[string]*model.User, uploads map[string]*zip.File, botUser *model.User, importerLog *bytes.Buffer) map[string]*model.Channel {
-	// Write Header
-	importerLog.WriteString(i18n.T("api.slackimport.slack_add_channels.added"))
-	importerLog.WriteString("=================\r\n\r\n")
-
+func (si *SlackImporter) slackAddChannels(rctx request.CTX, teamId string, slackchannels []slackChannel, posts map[string][]slackPost, users map[string]*model.User, uploads map[string]*zip.File, botUser *model.User, report *Report) map[string]*model.Channel {
 	addedChannels := make(map[string]*model.Channel)
 	for _, sChannel := range slackchannels {
 		newChannel := model.Channel{
@@ -641,7 +865,8 @@ func (si *SlackImporter) slackAddChannels(rctx request.CTX, teamId string, slack
 		var err error
 		if mChannel, err = si.store.Channel().GetByName(teamId, sChannel.Name, true); err == nil {
 			// The channel already exists as an active channel. Merge with the existing one.
-			importerLog.WriteString(i18n.T("api.slackimport.slack_add_channels.merge", map[string]any{"DisplayName": newChannel.DisplayName}))
+			report.Channels.Merged++
+			report.info(ReportSectionChannels, "api.slackimport.slack_add_channels.merge", map[string]any{"DisplayName": newChannel.DisplayName})
 		} else if _, nErr := si.store.Channel().GetDeletedByName(teamId, sChannel.Name); nErr == nil {
 			// The channel already exists but has been deleted. Generate a random string for the handle instead.
 			newChannel.Name = model.NewId()
@@ -653,36 +878,67 @@ func (si *SlackImporter) slackAddChannels(rctx request.CTX, teamId string, slack
 			mChannel = si.oldImportChannel(rctx, &newChannel, sChannel, users)
 			if mChannel == nil {
 				rctx.Logger().Warn("Slack Import: Unable to import Slack channel.", mlog.String("channel_display_name", newChannel.DisplayName))
-				importerLog.WriteString(i18n.T("api.slackimport.slack_add_channels.import_failed", map[string]any{"DisplayName": newChannel.DisplayName}))
+				report.Channels.Failed++
+				report.fail(ReportSectionChannels, "api.slackimport.slack_add_channels.import_failed", map[string]any{"DisplayName": newChannel.DisplayName})
 				continue
 			}
+			report.Channels.Imported++
 		}
 
 		// Members for direct and group channels are added during the creation of the channel in the oldImportChannel function
 		if sChannel.Type == model.ChannelTypeOpen || sChannel.Type == model.ChannelTypePrivate {
-			si.addSlackUsersToChannel(rctx, sChannel.Members, users, mChannel, importerLog)
+			si.addSlackUsersToChannel(rctx, sChannel.Members, users, mChannel, report)
 		}
-		importerLog.WriteString(newChannel.DisplayName + "\r\n")
+		report.info(ReportSectionChannels, "api.slackimport.slack_add_channels.imported", map[string]any{"DisplayName": newChannel.DisplayName})
 		addedChannels[sChannel.Id] = mChannel
-		si.slackAddPosts(rctx, teamId, mChannel, posts[sChannel.Name], users, uploads, botUser)
+
+		pinned := make(map[string]bool, len(sChannel.Pins))
+		for _, pin := range sChannel.Pins {
+			pinned[pin.Id] = true
+		}
+		si.slackAddPosts(rctx, teamId, mChannel, slackChannelPosts(sChannel, posts), pinned, users, uploads, botUser, report)
 	}
 
 	return addedChannels
 }
 
+// slackChannelPosts returns the posts of the channel from every workspace it was exported from.
+// Posts of shared channels exported by several workspaces are only returned once.
+func slackChannelPosts(sChannel slackChannel, posts map[string][]slackPost) []slackPost {
+	if len(sChannel.workspaces) == 0 {
+		return posts[sChannel.Name]
+	}
+	if len(sChannel.workspaces) == 1 {
+		return posts[path.Join(sChannel.workspaces[0], sChannel.Name)]
+	}
+
+	var channelPosts []slackPost
+	seen := make(map[string]bool)
+	for _, workspace := range sChannel.workspaces {
+		for _, sPost := range posts[path.Join(workspace, sChannel.Name)] {
+			if seen[sPost.TimeStamp] {
+				continue
+			}
+			seen[sPost.TimeStamp] = true
+			channelPosts = append(channelPosts, sPost)
+		}
+	}
+	return channelPosts
+}
+
 //
 // -- Old SlackImport Functions --
 // Import functions are suitable for entering posts and users into the database without
 // some of the usual checks. (IsValid is still run)
 //
 
+// oldImportPost saves the post, split in several ones when the message is too long,
+// and returns the ID of the first one or an empty string if it couldn't be saved.
 func (si *SlackImporter) oldImportPost(rctx request.CTX, post *model.Post) string {
 	// Workaround for empty messages, which may be the case if they are webhook posts.
 	firstIteration := true
 	firstPostId := ""
-	if post.RootId != "" {
-		firstPostId = post.RootId
-	}
+	rootId := post.RootId
 	maxPostSize := si.actions.MaxPostSize()
 	for messageRuneCount := utf8.RuneCountInString(post.Message); messageRuneCount > 0 || firstIteration; messageRuneCount = utf8.RuneCountInString(post.Message) {
 		var remainder string
@@ -695,16 +951,21 @@ func (si *SlackImporter) oldImportPost(rctx request.CTX, post *model.Post) strin
 
 		post.Hashtags, _ = model.ParseHashtags(post.Message)
 
-		post.RootId = firstPostId
+		post.RootId = rootId
 
 		_, err := si.store.Post().Save(rctx, post)
 		if err != nil {
 			rctx.Logger().Debug("Error saving post.", mlog.String("user_id", post.UserId), mlog.String("message", post.Message))
+			if firstIteration {
+				return ""
+			}
 		}
 
 		if firstIteration {
-			if firstPostId == "" {
-				firstPostId = post.Id
+			firstPostId = post.Id
+			// The rest of a message too long for a single post is imported as replies to it.
+			if rootId == "" {
+				rootId = post.Id
 			}
 			for _, fileId := range post.FileIds {
 				if err := si.store.FileInfo().AttachToPost(rctx, fileId, post.Id, post.ChannelId, post.UserId); err != nil {
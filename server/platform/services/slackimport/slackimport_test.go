@@ -26,6 +26,18 @@ func TestSlackConvertTimeStamp(t *testing.T) {
 	assert.EqualValues(t, slackConvertTimeStamp("1469785419.000033"), 1469785419000)
 }
 
+func TestSlackTimeStampLess(t *testing.T) {
+	assert.True(t, slackTimeStampLess("1469785419.000033", "1469785419.000034"))
+	assert.True(t, slackTimeStampLess("1469785419.999999", "1469785420.000000"))
+	assert.False(t, slackTimeStampLess("1469785419.000033", "1469785419.000033"))
+	assert.False(t, slackTimeStampLess("1469785420.000000", "1469785419.999999"))
+}
+
+func TestSlackConvertEmojiName(t *testing.T) {
+	assert.Equal(t, "+1", slackConvertEmojiName("+1"))
+	assert.Equal(t, "wave", slackConvertEmojiName("wave::skin-tone-3"))
+}
+
 func TestSlackConvertChannelName(t *testing.T) {
 	for _, tc := range []struct {
 		nameInput string
@@ -818,10 +830,10 @@ func TestSlackAddUsersLogContainsProperUserCreationMessage(t *testing.T) {
 	s := newSlackAddUsersTestSetup(t)
 
 	importer := s.newImporter(s.defaultActions())
-	importerLog := new(bytes.Buffer)
-	importer.slackAddUsers(rctx, "test-team-id", defaultSlackUsers(), importerLog)
+	report := newReport()
+	importer.slackAddUsers(rctx, "test-team-id", defaultSlackUsers(), report)
 
-	logOutput := importerLog.String()
+	logOutput := report.String()
 	assert.Contains(t, logOutput, "api.slackimport.slack_add_users.email", "import log should contain the user creation message")
 	assert.NotContains(t, logOutput, "api.slackimport.slack_add_users.email_pwd", "import log must not use the old user creation message")
 }
@@ -836,10 +848,10 @@ func TestSlackAddUsersLogsSendResetEmailFailure(t *testing.T) {
 	}
 
 	importer := s.newImporter(actions)
-	importerLog := new(bytes.Buffer)
-	importer.slackAddUsers(rctx, "test-team-id", defaultSlackUsers(), importerLog)
+	report := newReport()
+	importer.slackAddUsers(rctx, "test-team-id", defaultSlackUsers(), report)
 
-	assert.Contains(t, importerLog.String(), "api.slackimport.slack_add_users.send_reset_email_failed")
+	assert.Contains(t, report.String(), "api.slackimport.slack_add_users.send_reset_email_failed")
 }
 
 func TestSlackAddUsersGeneratesUserWithEmptyPassword(t *testing.T) {
@@ -847,8 +859,7 @@ func TestSlackAddUsersGeneratesUserWithEmptyPassword(t *testing.T) {
 	s := newSlackAddUsersTestSetup(t)
 
 	importer := s.newImporter(s.defaultActions())
-	importerLog := new(bytes.Buffer)
-	importer.slackAddUsers(rctx, "test-team-id", defaultSlackUsers(), importerLog)
+	importer.slackAddUsers(rctx, "test-team-id", defaultSlackUsers(), newReport())
 
 	s.userStore.AssertCalled(t, "Save", mock.AnythingOfType("*request.Context"), mock.MatchedBy(func(u *model.User) bool {
 		return u.Password == ""
@@ -867,8 +878,213 @@ func TestSlackAddUsersTriggersPasswordResetFlow(t *testing.T) {
 	}
 
 	importer := s.newImporter(actions)
-	importerLog := new(bytes.Buffer)
-	importer.slackAddUsers(rctx, "test-team-id", defaultSlackUsers(), importerLog)
+	importer.slackAddUsers(rctx, "test-team-id", defaultSlackUsers(), newReport())
 
 	assert.True(t, passwordResetCalled, "SendPasswordReset should be called for each imported user")
 }
+
+func TestSlackWorkspaces(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	for _, name := range []string{
+		"users.json",
+		"workspace-1/channels.json",
+		"workspace-1/users.json",
+		"workspace-1/general/2024-01-01.json",
+		"workspace-2/groups.json",
+		"workspace-2/__uploads/F1/file.txt",
+		"workspace-2/private/2024-01-01.json",
+	} {
+		_, err := zipWriter.Create(name)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]bool{
+		".":           true,
+		"workspace-1": true,
+		"workspace-2": true,
+	}, slackWorkspaces(zipReader.File))
+}
+
+func TestSlackMergeChannels(t *testing.T) {
+	channels := slackMergeChannels(nil, []slackChannel{
+		{Id: "C1", Name: "general", Pins: []slackPin{{Id: "1.1"}}, workspaces: []string{"workspace-1"}},
+		{Id: "C2", Name: "random", workspaces: []string{"workspace-1"}},
+	})
+	channels = slackMergeChannels(channels, []slackChannel{
+		{Id: "C1", Name: "general", Pins: []slackPin{{Id: "2.1"}}, workspaces: []string{"workspace-2"}},
+		{Id: "C3", Name: "private", workspaces: []string{"workspace-2"}},
+	})
+
+	require.Len(t, channels, 3)
+	assert.Equal(t, []string{"workspace-1", "workspace-2"}, channels[0].workspaces)
+	assert.Equal(t, []slackPin{{Id: "1.1"}, {Id: "2.1"}}, channels[0].Pins)
+	assert.Equal(t, "C3", channels[2].Id)
+
+	posts := map[string][]slackPost{
+		"workspace-1/general": {{TimeStamp: "1.1"}, {TimeStamp: "2.1"}},
+		"workspace-2/general": {{TimeStamp: "2.1"}, {TimeStamp: "3.1"}},
+	}
+	assert.Equal(t, []slackPost{{TimeStamp: "1.1"}, {TimeStamp: "2.1"}, {TimeStamp: "3.1"}}, slackChannelPosts(channels[0], posts))
+}
+
+func TestSlackMergeUsers(t *testing.T) {
+	users := slackMergeUsers(nil, []slackUser{{Id: "U1"}, {Id: "U2"}})
+	users = slackMergeUsers(users, []slackUser{{Id: "U2"}, {Id: "U3"}})
+	assert.Equal(t, []slackUser{{Id: "U1"}, {Id: "U2"}, {Id: "U3"}}, users)
+}
+
+func TestSlackAddPosts(t *testing.T) {
+	rctx := request.TestContext(t)
+	config := &model.Config{}
+	config.SetDefaults()
+
+	store := &mocks.Store{}
+	postStore := &mocks.PostStore{}
+	reactionStore := &mocks.ReactionStore{}
+	store.On("Post").Return(postStore)
+	store.On("Reaction").Return(reactionStore)
+
+	var savedPosts []*model.Post
+	postStore.On("Save", mock.Anything, mock.AnythingOfType("*model.Post")).Return(nil, nil).Run(func(args mock.Arguments) {
+		post := args.Get(1).(*model.Post)
+		post.Id = model.NewId()
+		savedPosts = append(savedPosts, post.Clone())
+	})
+	var savedReactions []*model.Reaction
+	reactionStore.On("Save", mock.AnythingOfType("*model.Reaction")).Return(nil, nil).Run(func(args mock.Arguments) {
+		savedReactions = append(savedReactions, args.Get(0).(*model.Reaction))
+	})
+
+	importer := New(store, Actions{
+		MaxPostSize: func() int { return model.PostMessageMaxRunesV2 },
+	}, config)
+
+	channel := &model.Channel{Id: model.NewId()}
+	users := map[string]*model.User{
+		"U1": {Id: model.NewId(), Username: "user1"},
+		"U2": {Id: model.NewId(), Username: "user2"},
+	}
+	botUser := &model.User{Id: model.NewId()}
+	posts := []slackPost{
+		{Type: "message", User: "U2", Text: "reply", TimeStamp: "1700000000.000002", ThreadTS: "1700000000.000001"},
+		{Type: "message", User: "U1", Text: "root", TimeStamp: "1700000000.000001", ThreadTS: "1700000000.000001", PinnedTo: []string{"C1"}},
+		{Type: "message", SubType: "bot_message", BotId: "B1", BotUsername: "bot", Text: "bot reply", TimeStamp: "1700000000.000003", ThreadTS: "1700000000.000001"},
+		{Type: "message", User: "U1", Text: "edited", TimeStamp: "1700000001.000000", Edited: &slackEdited{User: "U1", TimeStamp: "1700000100.000000"}, Reactions: []slackReaction{
+			{Name: "+1::skin-tone-2", Users: []string{"U1", "U2"}},
+			{Name: "tada", Users: []string{"U3"}},
+		}},
+		{Type: "message", User: "U2", Text: "pinned", TimeStamp: "1700000002.000000"},
+		{Type: "message", User: "U3", Text: "unknown user", TimeStamp: "1700000003.000000"},
+	}
+
+	report := newReport()
+	importer.slackAddPosts(rctx, "team-id", channel, posts, map[string]bool{"1700000002.000000": true}, users, nil, botUser, report)
+
+	require.Len(t, savedPosts, 5)
+	root := savedPosts[0]
+	assert.Equal(t, "root", root.Message)
+	assert.True(t, root.IsPinned)
+	assert.Empty(t, root.RootId)
+	assert.Equal(t, root.Id, savedPosts[1].RootId)
+	assert.Equal(t, root.Id, savedPosts[2].RootId)
+	assert.Equal(t, botUser.Id, savedPosts[2].UserId)
+	assert.Equal(t, int64(1700000100000), savedPosts[3].EditAt)
+	assert.True(t, savedPosts[4].IsPinned)
+
+	require.Len(t, savedReactions, 2)
+	for _, reaction := range savedReactions {
+		assert.Equal(t, "+1", reaction.EmojiName)
+		assert.Equal(t, savedPosts[3].Id, reaction.PostId)
+		assert.Equal(t, channel.Id, reaction.ChannelId)
+	}
+
+	assert.Equal(t, ReportCount{Imported: 5, Failed: 1}, report.Posts)
+	assert.Equal(t, 2, report.Replies)
+	assert.Equal(t, 2, report.PinnedPosts)
+	assert.Equal(t, 1, report.EditedPosts)
+	assert.Equal(t, ReportCount{Imported: 2, Failed: 1}, report.Reactions)
+}
+
+func TestSlackAddGroups(t *testing.T) {
+	rctx := request.TestContext(t)
+	config := &model.Config{}
+	config.SetDefaults()
+
+	users := map[string]*model.User{
+		"U1": {Id: model.NewId()},
+		"U2": {Id: model.NewId()},
+	}
+	slackGroups := []slackUserGroup{
+		{Id: "S1", Name: "Engineering", Handle: "Engineering", Users: []string{"U1", "U2", "U3"}},
+		{Id: "S2", Name: "Design", Handle: "design", Users: []string{"U2"}},
+		{Id: "S3", Name: "Deleted", Handle: "deleted", DateDelete: 1700000000},
+	}
+
+	t.Run("custom groups are not available", func(t *testing.T) {
+		importer := New(&mocks.Store{}, Actions{}, config)
+
+		report := newReport()
+		importer.slackAddGroups(rctx, slackGroups, users, report)
+
+		assert.Equal(t, ReportCount{Failed: 3}, report.Groups)
+		assert.Contains(t, report.String(), "api.slackimport.slack_add_groups.unavailable")
+	})
+
+	t.Run("groups are created or merged", func(t *testing.T) {
+		store := &mocks.Store{}
+		groupStore := &mocks.GroupStore{}
+		store.On("Group").Return(groupStore)
+
+		existingGroup := &model.Group{Id: model.NewId(), Source: model.GroupSourceCustom}
+		groupStore.On("GetByName", "engineering", model.GroupSearchOpts{}).Return(nil, fmt.Errorf("not found"))
+		groupStore.On("GetByName", "design", model.GroupSearchOpts{}).Return(existingGroup, nil)
+
+		var createdGroup *model.GroupWithUserIds
+		var upsertedGroupID string
+		var upsertedUserIDs []string
+		importer := New(store, Actions{
+			CreateGroupWithUserIds: func(group *model.GroupWithUserIds) (*model.Group, *model.AppError) {
+				createdGroup = group
+				return &group.Group, nil
+			},
+			UpsertGroupMembers: func(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError) {
+				upsertedGroupID = groupID
+				upsertedUserIDs = userIDs
+				return nil, nil
+			},
+		}, config)
+
+		report := newReport()
+		importer.slackAddGroups(rctx, slackGroups, users, report)
+
+		require.NotNil(t, createdGroup)
+		assert.Equal(t, "engineering", createdGroup.GetName())
+		assert.Equal(t, "Engineering", createdGroup.DisplayName)
+		assert.Equal(t, model.GroupSourceCustom, createdGroup.Source)
+		assert.True(t, createdGroup.AllowReference)
+		assert.ElementsMatch(t, []string{users["U1"].Id, users["U2"].Id}, createdGroup.UserIds)
+
+		assert.Equal(t, existingGroup.Id, upsertedGroupID)
+		assert.Equal(t, []string{users["U2"].Id}, upsertedUserIDs)
+
+		assert.Equal(t, ReportCount{Imported: 1, Merged: 1}, report.Groups)
+	})
+}
+
+func TestReportString(t *testing.T) {
+	report := newReport()
+	report.Users.Imported = 2
+	report.info(ReportSectionUsers, "api.slackimport.slack_add_users.email", map[string]any{"Email": "user@example.com"})
+	report.fail(ReportSectionChannels, "api.slackimport.slack_add_channels.import_failed", map[string]any{"DisplayName": "general"})
+
+	output := report.String()
+	assert.Contains(t, output, "api.slackimport.slack_import.log")
+	assert.Less(t, strings.Index(output, "api.slackimport.slack_add_users.created"), strings.Index(output, "api.slackimport.slack_add_users.email"))
+	assert.Less(t, strings.Index(output, "api.slackimport.slack_add_channels.added"), strings.Index(output, "api.slackimport.slack_add_channels.import_failed"))
+	assert.Contains(t, output, "api.slackimport.slack_import.notes")
+}
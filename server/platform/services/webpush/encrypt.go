@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// recordSize is the size of the single aes128gcm record a message is encrypted into.
+	recordSize = 4096
+
+	saltLength = 16
+	keyLength  = 65 // An uncompressed P-256 point.
+	tagLength  = 16
+
+	// headerLength is the size of the aes128gcm header: salt, record size, key id length and key id.
+	headerLength = saltLength + 4 + 1 + keyLength
+
+	// MaxPayloadSize is the largest payload that can be sent in a push message, given that
+	// push services only accept messages of up to 4096 bytes once encrypted.
+	MaxPayloadSize = recordSize - headerLength - tagLength - 1
+)
+
+var ErrPayloadTooLarge = errors.New("webpush: payload too large")
+
+// Encrypt encrypts the payload for the user agent identified by its public key and
+// authentication secret, using the aes128gcm content coding as described in RFC 8291.
+func Encrypt(payload, userAgentPublicKey, authSecret []byte) ([]byte, error) {
+	// A new key pair is used for every message.
+	privateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	return encrypt(payload, userAgentPublicKey, authSecret, privateKey, salt)
+}
+
+func encrypt(payload, userAgentPublicKey, authSecret []byte, privateKey *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+
+	uaPublicKey, err := ecdh.P256().NewPublicKey(userAgentPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user agent public key: %w", err)
+	}
+
+	sharedSecret, err := privateKey.ECDH(uaPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute shared secret: %w", err)
+	}
+
+	asPublicKey := privateKey.PublicKey().Bytes()
+
+	keyInfo := "WebPush: info\x00" + string(userAgentPublicKey) + string(asPublicKey)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	contentEncryptionKey, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, headerLength+len(payload)+1+tagLength)
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublicKey)))
+	header = append(header, asPublicKey...)
+
+	// The payload fits in a single record, which is terminated by the 0x02 padding delimiter.
+	record := make([]byte, 0, len(payload)+1)
+	record = append(record, payload...)
+	record = append(record, 0x02)
+
+	return gcm.Seal(header, nonce, record, nil), nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	// DefaultTTL is how long push services keep a message for a user agent that isn't connected.
+	DefaultTTL = 24 * time.Hour
+
+	UrgencyNormal = "normal"
+	UrgencyHigh   = "high"
+
+	// vapidExpiry is the lifetime of the VAPID tokens, which RFC 8292 limits to 24 hours.
+	vapidExpiry = 12 * time.Hour
+)
+
+// ErrSubscriptionGone is returned when the push service reports that the subscription
+// has expired or has been removed by the user, and should no longer be used.
+var ErrSubscriptionGone = errors.New("webpush: subscription is no longer valid")
+
+// Message is a push message to be sent to a subscription.
+type Message struct {
+	Payload []byte
+	TTL     time.Duration
+	Urgency string
+	// Topic allows a newer message to replace a pending one with the same topic.
+	Topic string
+}
+
+// Sender sends encrypted push messages to Web Push subscriptions, identifying the
+// server with VAPID as described in RFC 8292.
+type Sender struct {
+	client  *http.Client
+	key     *ecdsa.PrivateKey
+	subject string
+}
+
+// NewSender creates a Sender. Subscription endpoints are provided by clients, so the
+// HTTP client should not be allowed to reach internal addresses. The subject is a
+// mailto: or https: URL at which the push services can contact the server operator.
+func NewSender(client *http.Client, key *ecdsa.PrivateKey, subject string) *Sender {
+	return &Sender{
+		client:  client,
+		key:     key,
+		subject: subject,
+	}
+}
+
+// Send encrypts the message and sends it to the endpoint of the subscription.
+func (s *Sender) Send(ctx context.Context, subscription *model.WebPushSubscription, msg *Message) error {
+	userAgentPublicKey, err := subscription.P256dhKey()
+	if err != nil {
+		return fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	authSecret, err := subscription.AuthKey()
+	if err != nil {
+		return fmt.Errorf("invalid auth key: %w", err)
+	}
+
+	body, err := Encrypt(msg.Payload, userAgentPublicKey, authSecret)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := s.vapidAuthorization(subscription.Endpoint, time.Now().Add(vapidExpiry))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	ttl := msg.TTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+	if msg.Urgency != "" {
+		req.Header.Set("Urgency", msg.Urgency)
+	}
+	if msg.Topic != "" {
+		req.Header.Set("Topic", msg.Topic)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("webpush: push service returned status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// vapidAuthorization builds the value of the Authorization header for the given
+// endpoint: a JWT signed with the VAPID key along with its public key.
+func (s *Sender) vapidAuthorization(endpoint string, expiresAt time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	// The audience must be a single string rather than the array RegisteredClaims produces.
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": expiresAt.Unix(),
+		"sub": s.subject,
+	}).SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+
+	publicKey, err := s.key.PublicKey.ECDH()
+	if err != nil {
+		return "", fmt.Errorf("invalid VAPID key: %w", err)
+	}
+
+	return "vapid t=" + token + ", k=" + base64.RawURLEncoding.EncodeToString(publicKey.Bytes()), nil
+}
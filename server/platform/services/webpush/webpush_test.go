@@ -0,0 +1,199 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func decodeB64(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// decrypt reverses Encrypt the way a user agent does.
+func decrypt(t *testing.T, body []byte, uaPrivateKey *ecdh.PrivateKey, authSecret []byte) []byte {
+	t.Helper()
+	require.Greater(t, len(body), headerLength)
+
+	salt := body[:saltLength]
+	require.Equal(t, uint32(recordSize), binary.BigEndian.Uint32(body[saltLength:saltLength+4]))
+	require.Equal(t, byte(keyLength), body[saltLength+4])
+	asPublicKeyBytes := body[saltLength+5 : headerLength]
+
+	asPublicKey, err := ecdh.P256().NewPublicKey(asPublicKeyBytes)
+	require.NoError(t, err)
+	sharedSecret, err := uaPrivateKey.ECDH(asPublicKey)
+	require.NoError(t, err)
+
+	keyInfo := "WebPush: info\x00" + string(uaPrivateKey.PublicKey().Bytes()) + string(asPublicKeyBytes)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	require.NoError(t, err)
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	require.NoError(t, err)
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	record, err := gcm.Open(nil, nonce, body[headerLength:], nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x02), record[len(record)-1])
+	return record[:len(record)-1]
+}
+
+func TestEncrypt(t *testing.T) {
+	t.Run("RFC 8291 test vector", func(t *testing.T) {
+		privateKey, err := ecdh.P256().NewPrivateKey(decodeB64(t, "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+		require.NoError(t, err)
+
+		body, err := encrypt(
+			[]byte("When I grow up, I want to be a watermelon"),
+			decodeB64(t, "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"),
+			decodeB64(t, "BTBZMqHH6r4Tts7J_aSIgg"),
+			privateKey,
+			decodeB64(t, "DGv6ra1nlYgDCS1FRnbzlw"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN", base64.RawURLEncoding.EncodeToString(body))
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		uaPrivateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		authSecret := make([]byte, 16)
+		_, err = rand.Read(authSecret)
+		require.NoError(t, err)
+
+		payload := []byte(`{"message":"hello"}`)
+		body, err := Encrypt(payload, uaPrivateKey.PublicKey().Bytes(), authSecret)
+		require.NoError(t, err)
+		assert.Equal(t, payload, decrypt(t, body, uaPrivateKey, authSecret))
+
+		body, err = Encrypt(make([]byte, MaxPayloadSize), uaPrivateKey.PublicKey().Bytes(), authSecret)
+		require.NoError(t, err)
+		assert.Len(t, body, 4096, "the largest payload should fill a whole push message")
+	})
+
+	t.Run("payload too large", func(t *testing.T) {
+		uaPrivateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, err = Encrypt(make([]byte, MaxPayloadSize+1), uaPrivateKey.PublicKey().Bytes(), make([]byte, 16))
+		require.ErrorIs(t, err, ErrPayloadTooLarge)
+	})
+}
+
+func TestSenderSend(t *testing.T) {
+	vapidKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	uaPrivateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, err = rand.Read(authSecret)
+	require.NoError(t, err)
+
+	newSubscription := func(endpoint string) *model.WebPushSubscription {
+		return &model.WebPushSubscription{
+			Endpoint: endpoint,
+			Keys: model.WebPushSubscriptionKeys{
+				P256dh: base64.RawURLEncoding.EncodeToString(uaPrivateKey.PublicKey().Bytes()),
+				Auth:   base64.RawURLEncoding.EncodeToString(authSecret),
+			},
+		}
+	}
+
+	t.Run("should send an encrypted message with a VAPID authorization", func(t *testing.T) {
+		var received []byte
+		var header http.Header
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, readErr := io.ReadAll(r.Body)
+			require.NoError(t, readErr)
+			header = r.Header
+			received = body
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer ts.Close()
+
+		sender := NewSender(ts.Client(), vapidKey, "mailto:admin@example.com")
+		err := sender.Send(t.Context(), newSubscription(ts.URL+"/push/abc"), &Message{
+			Payload: []byte("hello"),
+			Urgency: UrgencyHigh,
+			Topic:   "channel",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []byte("hello"), decrypt(t, received, uaPrivateKey, authSecret))
+		assert.Equal(t, "aes128gcm", header.Get("Content-Encoding"))
+		assert.Equal(t, "86400", header.Get("TTL"))
+		assert.Equal(t, UrgencyHigh, header.Get("Urgency"))
+		assert.Equal(t, "channel", header.Get("Topic"))
+
+		authorization, ok := strings.CutPrefix(header.Get("Authorization"), "vapid t=")
+		require.True(t, ok)
+		token, publicKey, ok := strings.Cut(authorization, ", k=")
+		require.True(t, ok)
+
+		vapidPublicKey, err := vapidKey.PublicKey.ECDH()
+		require.NoError(t, err)
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(vapidPublicKey.Bytes()), publicKey)
+
+		claims := jwt.MapClaims{}
+		_, err = jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+			return &vapidKey.PublicKey, nil
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}))
+		require.NoError(t, err)
+		assert.Equal(t, ts.URL, claims["aud"])
+		assert.Equal(t, "mailto:admin@example.com", claims["sub"])
+	})
+
+	t.Run("should report expired subscriptions", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer ts.Close()
+
+		sender := NewSender(ts.Client(), vapidKey, "mailto:admin@example.com")
+		err := sender.Send(t.Context(), newSubscription(ts.URL), &Message{Payload: []byte("hello")})
+		require.ErrorIs(t, err, ErrSubscriptionGone)
+	})
+
+	t.Run("should fail on other errors", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer ts.Close()
+
+		sender := NewSender(ts.Client(), vapidKey, "mailto:admin@example.com")
+		err := sender.Send(t.Context(), newSubscription(ts.URL), &Message{Payload: []byte("hello")})
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrSubscriptionGone)
+	})
+}
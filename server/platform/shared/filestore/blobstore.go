@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// BlobRefCounter keeps track of the references to each content-addressed blob. Files
+// stored in a blob reference it on their own, while AcquireBlob and ReleaseBlob hold the
+// blob until files are stored in it and return the number of holds after the change.
+// ReleaseBlob and RemoveBlobIfUnreferenced call removeBlob once the blob is neither held
+// nor referenced by a file, while preventing it from being acquired until removeBlob returns.
+type BlobRefCounter interface {
+	AcquireBlob(hash string, size int64) (int64, error)
+	ReleaseBlob(hash string, removeBlob func() error) (int64, error)
+	RemoveBlobIfUnreferenced(hash string, removeBlob func() error) (bool, error)
+}
+
+// BlobStore stores file contents once per SHA-256 hash under model.FileBlobDirectory,
+// sharing a single object between every file with the same contents.
+type BlobStore struct {
+	backend FileBackend
+	refs    BlobRefCounter
+}
+
+func NewBlobStore(backend FileBackend, refs BlobRefCounter) *BlobStore {
+	return &BlobStore{
+		backend: backend,
+		refs:    refs,
+	}
+}
+
+// Store holds the blob with the given hash, copying the file at path into the
+// blob when it doesn't exist yet, and returns the blob path. The blob is held
+// until Release is called, once the files are stored in it. The file at path is
+// left in place for the caller to remove.
+func (s *BlobStore) Store(path, hash string, size int64) (string, error) {
+	if !model.IsValidFileContentHash(hash) {
+		return "", errors.Errorf("invalid content hash %q", hash)
+	}
+
+	if _, err := s.refs.AcquireBlob(hash, size); err != nil {
+		return "", errors.Wrapf(err, "failed to acquire blob %s", hash)
+	}
+
+	blobPath := model.FileBlobPath(hash)
+	exists, err := s.backend.FileExists(blobPath)
+	if err != nil {
+		_ = s.Release(hash)
+		return "", errors.Wrapf(err, "failed to check blob %s", hash)
+	}
+	if !exists {
+		if err := s.backend.CopyFile(path, blobPath); err != nil {
+			_ = s.Release(hash)
+			return "", errors.Wrapf(err, "failed to copy %s to blob %s", path, hash)
+		}
+	}
+
+	return blobPath, nil
+}
+
+// Release drops a hold on the blob with the given hash, removing the blob when
+// no file is stored in it.
+func (s *BlobStore) Release(hash string) error {
+	if _, err := s.refs.ReleaseBlob(hash, s.removeBlob(hash)); err != nil {
+		return errors.Wrapf(err, "failed to release blob %s", hash)
+	}
+	return nil
+}
+
+// Collect removes the blob with the given hash once it is neither held nor has
+// files stored in it. It is called after removing the files stored in a blob.
+func (s *BlobStore) Collect(hash string) error {
+	if _, err := s.refs.RemoveBlobIfUnreferenced(hash, s.removeBlob(hash)); err != nil {
+		return errors.Wrapf(err, "failed to collect blob %s", hash)
+	}
+	return nil
+}
+
+func (s *BlobStore) removeBlob(hash string) func() error {
+	blobPath := model.FileBlobPath(hash)
+	return func() error {
+		// The blob is already gone if a previous attempt to remove it was interrupted.
+		if exists, err := s.backend.FileExists(blobPath); err != nil || !exists {
+			return err
+		}
+		return s.backend.RemoveFile(blobPath)
+	}
+}
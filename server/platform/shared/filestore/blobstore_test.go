@@ -0,0 +1,216 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// fakeBlobRefCounter locks the reference counts while removing a blob, as the store does.
+type fakeBlobRefCounter struct {
+	mut   sync.Mutex
+	holds map[string]int64
+	files map[string]int
+}
+
+func newFakeBlobRefCounter() *fakeBlobRefCounter {
+	return &fakeBlobRefCounter{
+		holds: map[string]int64{},
+		files: map[string]int{},
+	}
+}
+
+func (c *fakeBlobRefCounter) AcquireBlob(hash string, size int64) (int64, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.holds[hash]++
+	return c.holds[hash], nil
+}
+
+func (c *fakeBlobRefCounter) ReleaseBlob(hash string, removeBlob func() error) (int64, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if _, ok := c.holds[hash]; !ok {
+		return 0, fmt.Errorf("blob %s not found", hash)
+	}
+	if c.holds[hash] > 1 {
+		c.holds[hash]--
+		return c.holds[hash], nil
+	}
+	c.holds[hash] = 0
+	if _, err := c.removeBlobIfUnreferenced(hash, removeBlob); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+func (c *fakeBlobRefCounter) RemoveBlobIfUnreferenced(hash string, removeBlob func() error) (bool, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if holds, ok := c.holds[hash]; !ok || holds > 0 {
+		return false, nil
+	}
+	return c.removeBlobIfUnreferenced(hash, removeBlob)
+}
+
+func (c *fakeBlobRefCounter) removeBlobIfUnreferenced(hash string, removeBlob func() error) (bool, error) {
+	if c.files[hash] > 0 {
+		return false, nil
+	}
+	if err := removeBlob(); err != nil {
+		return false, err
+	}
+	delete(c.holds, hash)
+	return true, nil
+}
+
+func (c *fakeBlobRefCounter) count(hash string) int64 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.holds[hash]
+}
+
+func (c *fakeBlobRefCounter) setFiles(hash string, files int) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.files[hash] = files
+}
+
+func TestBlobStore(t *testing.T) {
+	backend, err := NewFileBackend(FileBackendSettings{
+		DriverName: driverLocal,
+		Directory:  t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	refs := newFakeBlobRefCounter()
+	blobs := NewBlobStore(backend, refs)
+
+	data := []byte("deduplicated contents")
+	hash := fmt.Sprintf("%x", sha256.Sum256(data))
+	for _, path := range []string{"a/file.txt", "b/file.txt"} {
+		_, err = backend.WriteFile(bytes.NewReader(data), path)
+		require.NoError(t, err)
+	}
+
+	t.Run("invalid hash", func(t *testing.T) {
+		_, err := blobs.Store("a/file.txt", "not-a-hash", int64(len(data)))
+		require.Error(t, err)
+		assert.Zero(t, refs.count(hash))
+	})
+
+	t.Run("store and release", func(t *testing.T) {
+		blobPath, err := blobs.Store("a/file.txt", hash, int64(len(data)))
+		require.NoError(t, err)
+		assert.Equal(t, model.FileBlobPath(hash), blobPath)
+
+		secondPath, err := blobs.Store("b/file.txt", hash, int64(len(data)))
+		require.NoError(t, err)
+		assert.Equal(t, blobPath, secondPath)
+		assert.Equal(t, int64(2), refs.count(hash))
+
+		read, err := backend.ReadFile(blobPath)
+		require.NoError(t, err)
+		assert.Equal(t, data, read)
+
+		require.NoError(t, blobs.Release(hash))
+		exists, err := backend.FileExists(blobPath)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		require.NoError(t, blobs.Release(hash))
+		exists, err = backend.FileExists(blobPath)
+		require.NoError(t, err)
+		assert.False(t, exists)
+		assert.Zero(t, refs.count(hash))
+	})
+
+	t.Run("files keep the blob", func(t *testing.T) {
+		blobPath, err := blobs.Store("a/file.txt", hash, int64(len(data)))
+		require.NoError(t, err)
+
+		// The files stored in the blob reference it once it is released.
+		refs.setFiles(hash, 1)
+		require.NoError(t, blobs.Release(hash))
+		exists, err := backend.FileExists(blobPath)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		require.NoError(t, blobs.Collect(hash))
+		exists, err = backend.FileExists(blobPath)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		refs.setFiles(hash, 0)
+		require.NoError(t, blobs.Collect(hash))
+		exists, err = backend.FileExists(blobPath)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		// Collecting a removed blob does nothing.
+		require.NoError(t, blobs.Collect(hash))
+	})
+
+	t.Run("missing blob is restored", func(t *testing.T) {
+		_, err := refs.AcquireBlob(hash, int64(len(data)))
+		require.NoError(t, err)
+
+		blobPath, err := blobs.Store("a/file.txt", hash, int64(len(data)))
+		require.NoError(t, err)
+		exists, err := backend.FileExists(blobPath)
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, int64(2), refs.count(hash))
+
+		require.NoError(t, blobs.Release(hash))
+		require.NoError(t, blobs.Release(hash))
+	})
+
+	t.Run("failed copy releases the reference", func(t *testing.T) {
+		otherHash := fmt.Sprintf("%x", sha256.Sum256([]byte("other")))
+		_, err := blobs.Store("missing.txt", otherHash, 5)
+		require.Error(t, err)
+		assert.Zero(t, refs.count(otherHash))
+	})
+	t.Run("concurrent store and release", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Go(func() {
+				for range 50 {
+					blobPath, err := blobs.Store("a/file.txt", hash, int64(len(data)))
+					if !assert.NoError(t, err) {
+						return
+					}
+
+					// The blob can't be removed while referenced.
+					read, err := backend.ReadFile(blobPath)
+					assert.NoError(t, err)
+					assert.Equal(t, data, read)
+
+					assert.NoError(t, blobs.Release(hash))
+				}
+			})
+		}
+		wg.Wait()
+
+		assert.Zero(t, refs.count(hash))
+		exists, err := backend.FileExists(model.FileBlobPath(hash))
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
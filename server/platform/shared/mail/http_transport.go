@@ -0,0 +1,192 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mail
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// maxHTTPTransportErrorBodySize bounds how much of an error response is included
+// in the returned error.
+const maxHTTPTransportErrorBodySize = 1024
+
+// HTTPTransport delivers email messages by posting them as JSON to the HTTP API of
+// an email delivery service, or to a relay translating them for such a service.
+//
+// The request is authenticated with the API key as a bearer token. Any 2xx response
+// means the message was accepted. 408, 429 and 5xx responses are transient failures,
+// while any other response is a PermanentError.
+type HTTPTransport struct {
+	client   *http.Client
+	url      string
+	apiKey   string
+	hostname string
+}
+
+// NewHTTPTransport creates an HTTPTransport posting messages to the given URL. The
+// hostname is used to generate the Message-ID of messages that don't have one.
+func NewHTTPTransport(client *http.Client, url, apiKey, hostname string) *HTTPTransport {
+	return &HTTPTransport{
+		client:   client,
+		url:      url,
+		apiKey:   apiKey,
+		hostname: hostname,
+	}
+}
+
+type httpMessageAddress struct {
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address"`
+}
+
+type httpMessageAttachment struct {
+	Filename  string `json:"filename"`
+	ContentID string `json:"content_id"`
+	Content   []byte `json:"content"`
+}
+
+type httpMessage struct {
+	From        httpMessageAddress      `json:"from"`
+	ReplyTo     *httpMessageAddress     `json:"reply_to,omitempty"`
+	To          []string                `json:"to"`
+	Cc          []string                `json:"cc,omitempty"`
+	Subject     string                  `json:"subject"`
+	HTML        string                  `json:"html"`
+	Text        string                  `json:"text"`
+	Headers     map[string]string       `json:"headers"`
+	Category    string                  `json:"category,omitempty"`
+	Attachments []httpMessageAttachment `json:"attachments,omitempty"`
+}
+
+func (t *HTTPTransport) Send(msg *Message) error {
+	mlog.Info("sending mail", mlog.String("to", msg.To))
+
+	body, err := t.encode(msg)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return &PermanentError{Err: errors.Wrap(err, "failed to create the mail delivery request")}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if t.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send the mail delivery request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxHTTPTransportErrorBodySize))
+	err = fmt.Errorf("mail delivery request failed with status code %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	switch {
+	case resp.StatusCode == http.StatusRequestTimeout,
+		resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode >= 500:
+		return err
+	default:
+		return &PermanentError{Err: err}
+	}
+}
+
+func (t *HTTPTransport) encode(msg *Message) ([]byte, error) {
+	headers := map[string]string{
+		"Auto-Submitted": "auto-generated",
+		"Precedence":     "bulk",
+		"Message-ID":     msg.MessageID,
+	}
+	if msg.MessageID == "" {
+		headers["Message-ID"] = NewMessageID(t.hostname)
+	}
+	if msg.InReplyTo != "" {
+		headers["In-Reply-To"] = msg.InReplyTo
+	}
+	if msg.References != "" {
+		headers["References"] = msg.References
+	}
+
+	payload := httpMessage{
+		From:     httpMessageAddress{Name: msg.From.Name, Address: msg.From.Address},
+		To:       splitAddressList(msg.To),
+		Cc:       splitAddressList(msg.Cc),
+		Subject:  msg.Subject,
+		HTML:     msg.HTMLBody,
+		Text:     htmlToText(msg.HTMLBody),
+		Headers:  headers,
+		Category: msg.Category,
+	}
+	if msg.ReplyTo.Address != "" {
+		payload.ReplyTo = &httpMessageAddress{Name: msg.ReplyTo.Name, Address: msg.ReplyTo.Address}
+	}
+
+	// Sort the attachments so that the same message always has the same payload.
+	names := make([]string, 0, len(msg.EmbeddedFiles))
+	for name := range msg.EmbeddedFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		content, err := io.ReadAll(msg.EmbeddedFiles[name])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read embedded file %s", name)
+		}
+		payload.Attachments = append(payload.Attachments, httpMessageAttachment{
+			Filename:  name,
+			ContentID: "<" + name + ">",
+			Content:   content,
+		})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode the email message")
+	}
+	return body, nil
+}
+
+// splitAddressList splits a comma separated list of addresses, keeping the addresses
+// as they are if the list can't be parsed.
+func splitAddressList(list string) []string {
+	if strings.TrimSpace(list) == "" {
+		return nil
+	}
+
+	addresses, err := mail.ParseAddressList(list)
+	if err != nil {
+		var result []string
+		for address := range strings.SplitSeq(list, ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				result = append(result, address)
+			}
+		}
+		return result
+	}
+
+	result := make([]string, len(addresses))
+	for i, address := range addresses {
+		result[i] = address.String()
+	}
+	return result
+}
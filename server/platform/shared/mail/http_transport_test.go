@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mail
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPTransportSend(t *testing.T) {
+	var received httpMessage
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	transport := NewHTTPTransport(server.Client(), server.URL, "secret", "localhost")
+	err := transport.Send(&Message{
+		From:          mail.Address{Name: "Mattermost", Address: "noreply@example.com"},
+		ReplyTo:       mail.Address{Address: "reply@example.com"},
+		To:            "user1@example.com",
+		Cc:            "user2@example.com, User 3 <user3@example.com>",
+		Subject:       "Hello",
+		HTMLBody:      "<p>Hello world</p>",
+		EmbeddedFiles: map[string]io.Reader{"logo.png": strings.NewReader("image")},
+		InReplyTo:     "<abc@example.com>",
+		Category:      "NotificationEmail",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Equal(t, httpMessageAddress{Name: "Mattermost", Address: "noreply@example.com"}, received.From)
+	require.NotNil(t, received.ReplyTo)
+	assert.Equal(t, "reply@example.com", received.ReplyTo.Address)
+	assert.Equal(t, []string{"<user1@example.com>"}, received.To)
+	assert.Equal(t, []string{"<user2@example.com>", `"User 3" <user3@example.com>`}, received.Cc)
+	assert.Equal(t, "Hello", received.Subject)
+	assert.Equal(t, "<p>Hello world</p>", received.HTML)
+	assert.Equal(t, "NotificationEmail", received.Category)
+	assert.Equal(t, "<abc@example.com>", received.Headers["In-Reply-To"])
+	assert.True(t, strings.HasSuffix(received.Headers["Message-ID"], "@localhost>"))
+	assert.Equal(t, []httpMessageAttachment{{Filename: "logo.png", ContentID: "<logo.png>", Content: []byte("image")}}, received.Attachments)
+}
+
+func TestHTTPTransportErrors(t *testing.T) {
+	testCases := map[string]struct {
+		statusCode int
+		permanent  bool
+	}{
+		"bad request is permanent":       {http.StatusBadRequest, true},
+		"unauthorized is permanent":      {http.StatusUnauthorized, true},
+		"request timeout is transient":   {http.StatusRequestTimeout, false},
+		"too many requests is transient": {http.StatusTooManyRequests, false},
+		"server error is transient":      {http.StatusServiceUnavailable, false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte("rejected"))
+			}))
+			defer server.Close()
+
+			err := NewHTTPTransport(server.Client(), server.URL, "", "localhost").Send(&Message{To: "user@example.com"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "rejected")
+			assert.Equal(t, tc.permanent, IsPermanentError(err))
+		})
+	}
+
+	t.Run("connection failure is transient", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		err := NewHTTPTransport(server.Client(), server.URL, "", "localhost").Send(&Message{To: "user@example.com"})
+		require.Error(t, err)
+		assert.False(t, IsPermanentError(err))
+	})
+}
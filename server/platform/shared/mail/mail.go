@@ -235,25 +235,14 @@ func TestConnection(config *SMTPConfig) error {
 }
 
 func SendMailWithEmbeddedFilesUsingConfig(to, subject, htmlBody string, embeddedFiles map[string]io.Reader, config *SMTPConfig, enableComplianceFeatures bool, messageID string, inReplyTo string, references string, ccMail string, category string) error {
-	fromMail := mail.Address{Name: config.FeedbackName, Address: config.FeedbackEmail}
-	replyTo := mail.Address{Name: config.FeedbackName, Address: config.ReplyToAddress}
-
-	mail := mailData{
-		mimeTo:        to,
-		smtpTo:        to,
-		from:          fromMail,
-		cc:            ccMail,
-		replyTo:       replyTo,
-		subject:       subject,
-		htmlBody:      htmlBody,
-		embeddedFiles: embeddedFiles,
-		messageID:     messageID,
-		inReplyTo:     inReplyTo,
-		references:    references,
-		category:      category,
-	}
-
-	return sendMailUsingConfigAdvanced(mail, config)
+	msg := NewMessage(config, to, subject, htmlBody, category)
+	msg.Cc = ccMail
+	msg.EmbeddedFiles = embeddedFiles
+	msg.MessageID = messageID
+	msg.InReplyTo = inReplyTo
+	msg.References = references
+
+	return NewSMTPTransport(config).Send(msg)
 }
 
 func SendMailUsingConfig(to, subject, htmlBody string, config *SMTPConfig, enableComplianceFeatures bool, messageID string, inReplyTo string, references string, ccMail, category string) error {
@@ -290,15 +279,27 @@ func sendMailUsingConfigAdvanced(mail mailData, config *SMTPConfig) error {
 
 const SendGridXSMTPAPIHeader = "X-SMTPAPI"
 
+// htmlToText returns the plain text alternative of an HTML email body.
+func htmlToText(htmlBody string) string {
+	text, _, err := docconv.ConvertHTML(strings.NewReader(htmlBody), true)
+	if err != nil {
+		mlog.Warn("Unable to convert html body to text", mlog.Err(err))
+		return ""
+	}
+	return text
+}
+
+// NewMessageID returns a new unique Message-ID for an email sent from the given host.
+func NewMessageID(hostname string) string {
+	randomStringLength := 16
+	return fmt.Sprintf("<%s-%d@%s>", model.NewRandomString(randomStringLength), time.Now().Unix(), hostname)
+}
+
 func sendMail(c smtpClient, mail mailData, date time.Time, config *SMTPConfig) error {
 	mlog.Info("sending mail", mlog.String("to", mail.smtpTo))
 
 	htmlMessage := mail.htmlBody
-	text, _, err := docconv.ConvertHTML(strings.NewReader(htmlMessage), true)
-	if err != nil {
-		mlog.Warn("Unable to convert html body to text", mlog.Err(err))
-		text = ""
-	}
+	text := htmlToText(htmlMessage)
 
 	headers := map[string][]string{
 		"From":                      {mail.from.String()},
@@ -325,9 +326,7 @@ func sendMail(c smtpClient, mail mailData, date time.Time, config *SMTPConfig) e
 	if mail.messageID != "" {
 		headers["Message-ID"] = []string{mail.messageID}
 	} else {
-		randomStringLength := 16
-		msgID := fmt.Sprintf("<%s-%d@%s>", model.NewRandomString(randomStringLength), time.Now().Unix(), config.Hostname)
-		headers["Message-ID"] = []string{msgID}
+		headers["Message-ID"] = []string{NewMessageID(config.Hostname)}
 	}
 
 	if mail.inReplyTo != "" {
@@ -352,12 +351,12 @@ func sendMail(c smtpClient, mail mailData, date time.Time, config *SMTPConfig) e
 		m.EmbedReader(name, reader)
 	}
 
-	if err = c.Mail(mail.from.Address); err != nil {
+	if err := c.Mail(mail.from.Address); err != nil {
 		return errors.Wrap(err, "failed to set the from address")
 	}
 
-	if err = c.Rcpt(mail.smtpTo); err != nil {
-		return errors.Wrap(err, "failed to set the to address")
+	if err := c.Rcpt(mail.smtpTo); err != nil {
+		return smtpRecipientError(errors.Wrap(err, "failed to set the to address"))
 	}
 
 	w, err := c.Data()
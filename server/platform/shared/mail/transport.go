@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mail
+
+import (
+	"io"
+	"net/mail"
+	"net/textproto"
+
+	"github.com/pkg/errors"
+)
+
+// Message is an email to be delivered by a Transport.
+type Message struct {
+	From          mail.Address
+	ReplyTo       mail.Address
+	To            string
+	Cc            string
+	Subject       string
+	HTMLBody      string
+	EmbeddedFiles map[string]io.Reader
+	MessageID     string
+	InReplyTo     string
+	References    string
+	Category      string
+}
+
+// NewMessage returns a message sent from the feedback address of the given configuration.
+func NewMessage(config *SMTPConfig, to, subject, htmlBody, category string) *Message {
+	return &Message{
+		From:     mail.Address{Name: config.FeedbackName, Address: config.FeedbackEmail},
+		ReplyTo:  mail.Address{Name: config.FeedbackName, Address: config.ReplyToAddress},
+		To:       to,
+		Subject:  subject,
+		HTMLBody: htmlBody,
+		Category: category,
+	}
+}
+
+// Transport delivers email messages, e.g. to an SMTP server or to the HTTP API of
+// an email delivery service.
+type Transport interface {
+	Send(msg *Message) error
+}
+
+// PermanentError is returned by a Transport when a message was rejected in a way
+// that sending it again won't fix, such as an invalid recipient address.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanentError reports whether sending a message failed with a PermanentError,
+// as opposed to a transient failure such as the mail server being unreachable.
+func IsPermanentError(err error) bool {
+	var permanentErr *PermanentError
+	return errors.As(err, &permanentErr)
+}
+
+// SMTPTransport delivers email messages to an SMTP server.
+type SMTPTransport struct {
+	config *SMTPConfig
+}
+
+func NewSMTPTransport(config *SMTPConfig) *SMTPTransport {
+	return &SMTPTransport{config: config}
+}
+
+func (t *SMTPTransport) Send(msg *Message) error {
+	return sendMailUsingConfigAdvanced(mailData{
+		mimeTo:        msg.To,
+		smtpTo:        msg.To,
+		from:          msg.From,
+		cc:            msg.Cc,
+		replyTo:       msg.ReplyTo,
+		subject:       msg.Subject,
+		htmlBody:      msg.HTMLBody,
+		embeddedFiles: msg.EmbeddedFiles,
+		messageID:     msg.MessageID,
+		inReplyTo:     msg.InReplyTo,
+		references:    msg.References,
+		category:      msg.Category,
+	}, t.config)
+}
+
+// smtpRecipientError marks the rejection of a recipient by the SMTP server as
+// permanent, unless the server replied with a transient (4xx) status code.
+func smtpRecipientError(err error) error {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+		return &PermanentError{Err: err}
+	}
+	return err
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mail
+
+import (
+	"errors"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rejectingMailer struct {
+	mockMailer
+	err error
+}
+
+func (m *rejectingMailer) Rcpt(string) error { return m.err }
+
+func TestSendMailRecipientRejected(t *testing.T) {
+	t.Run("permanent rejection", func(t *testing.T) {
+		mailer := &rejectingMailer{err: &textproto.Error{Code: 550, Msg: "no such user"}}
+		err := sendMail(mailer, mailData{smtpTo: "user@example.com"}, time.Now(), getConfig())
+		require.Error(t, err)
+		assert.True(t, IsPermanentError(err))
+	})
+
+	t.Run("transient rejection", func(t *testing.T) {
+		mailer := &rejectingMailer{err: &textproto.Error{Code: 451, Msg: "try again later"}}
+		err := sendMail(mailer, mailData{smtpTo: "user@example.com"}, time.Now(), getConfig())
+		require.Error(t, err)
+		assert.False(t, IsPermanentError(err))
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		mailer := &rejectingMailer{err: errors.New("connection reset")}
+		err := sendMail(mailer, mailData{smtpTo: "user@example.com"}, time.Now(), getConfig())
+		require.Error(t, err)
+		assert.False(t, IsPermanentError(err))
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// This will result in 50 bits of entropy per code, as each one can only be used once.
+	recoveryCodeSize       = 10
+	recoveryCodeRandomSize = 7
+	recoveryCodeAlphabet   = "abcdefghijklmnopqrstuvwxyz234567"
+)
+
+var recoveryCodeEncoding = base32.NewEncoding(recoveryCodeAlphabet).WithPadding(base32.NoPadding)
+
+// GenerateRecoveryCodes returns count new random recovery codes, formatted as two
+// groups of five characters, e.g. "abcde-23456".
+func GenerateRecoveryCodes(count int) []string {
+	codes := make([]string, count)
+	for i := range codes {
+		data := make([]byte, recoveryCodeRandomSize)
+		rand.Read(data)
+		code := recoveryCodeEncoding.EncodeToString(data)[:recoveryCodeSize]
+		codes[i] = code[:recoveryCodeSize/2] + "-" + code[recoveryCodeSize/2:]
+	}
+	return codes
+}
+
+// normalizeRecoveryCode removes the formatting of a recovery code as typed by a user.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// IsRecoveryCode reports whether the given token is formatted as a recovery code, as
+// opposed to e.g. a TOTP code.
+func IsRecoveryCode(token string) bool {
+	code := normalizeRecoveryCode(token)
+	if len(code) != recoveryCodeSize {
+		return false
+	}
+
+	for _, r := range code {
+		if !strings.ContainsRune(recoveryCodeAlphabet, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// HashRecoveryCode returns the hash a recovery code is stored as. Recovery codes are
+// random enough not to require a slow password hash.
+func HashRecoveryCode(code string) string {
+	hash := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,418 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// InvalidWebAuthnResponse indicates the case where the verification of a WebAuthn
+// registration or assertion has failed.
+var InvalidWebAuthnResponse = errors.New("invalid webauthn response")
+
+// COSE algorithm identifiers of the supported public key types.
+const (
+	coseAlgES256 = -7
+	coseAlgEdDSA = -8
+	coseAlgRS256 = -257
+)
+
+// Flags of the authenticator data.
+const (
+	authDataFlagUserPresent            = 0x01
+	authDataFlagAttestedCredentialData = 0x40
+)
+
+const (
+	webAuthnCredentialType = "public-key"
+	rpIDHashSize           = 32
+	aaguidSize             = 16
+	authDataMinSize        = rpIDHashSize + 1 + 4
+)
+
+// WebAuthn implements the relying party side of the WebAuthn registration and
+// authentication ceremonies for the server at a given site URL. Attestation statements
+// aren't verified: authenticators are trusted on first use, as with TOTP secrets.
+type WebAuthn struct {
+	rpID   string
+	rpName string
+	origin string
+}
+
+// NewWebAuthn returns a relying party named rpName, identified by the host of siteURL.
+func NewWebAuthn(siteURL, rpName string) (*WebAuthn, error) {
+	u, err := url.Parse(siteURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse the site url")
+	}
+	if u.Scheme == "" || u.Hostname() == "" {
+		return nil, errors.New("the site url must be an absolute url")
+	}
+
+	return &WebAuthn{
+		rpID:   u.Hostname(),
+		rpName: rpName,
+		origin: u.Scheme + "://" + u.Host,
+	}, nil
+}
+
+// EncodeWebAuthnBinary encodes a binary value as it is serialized by the WebAuthn browser API.
+func EncodeWebAuthnBinary(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeWebAuthnBinary decodes a binary value serialized by the WebAuthn browser API,
+// with or without padding.
+func DecodeWebAuthnBinary(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// CreationOptions returns the options of a ceremony registering a new credential for
+// the given user, excluding the authenticators they already registered.
+func (w *WebAuthn) CreationOptions(user *model.User, challenge []byte, existing []*model.WebAuthnCredential) *model.WebAuthnCreationOptions {
+	displayName := user.GetFullName()
+	if displayName == "" {
+		displayName = user.Username
+	}
+
+	return &model.WebAuthnCreationOptions{
+		Challenge: EncodeWebAuthnBinary(challenge),
+		RP:        model.WebAuthnRelyingParty{Id: w.rpID, Name: w.rpName},
+		User: model.WebAuthnUserEntity{
+			Id:          EncodeWebAuthnBinary([]byte(user.Id)),
+			Name:        user.Username,
+			DisplayName: displayName,
+		},
+		PubKeyCredParams: []model.WebAuthnCredentialParameter{
+			{Type: webAuthnCredentialType, Alg: coseAlgES256},
+			{Type: webAuthnCredentialType, Alg: coseAlgEdDSA},
+			{Type: webAuthnCredentialType, Alg: coseAlgRS256},
+		},
+		Timeout:            model.WebAuthnTimeout,
+		ExcludeCredentials: credentialDescriptors(existing),
+		AuthenticatorSelection: model.WebAuthnAuthenticatorSelection{
+			ResidentKey:      "discouraged",
+			UserVerification: "discouraged",
+		},
+		Attestation: "none",
+	}
+}
+
+// RequestOptions returns the options of a ceremony asserting one of the given credentials.
+func (w *WebAuthn) RequestOptions(challenge []byte, credentials []*model.WebAuthnCredential) *model.WebAuthnRequestOptions {
+	return &model.WebAuthnRequestOptions{
+		Challenge:        EncodeWebAuthnBinary(challenge),
+		Timeout:          model.WebAuthnTimeout,
+		RPId:             w.rpID,
+		AllowCredentials: credentialDescriptors(credentials),
+		UserVerification: "discouraged",
+	}
+}
+
+func credentialDescriptors(credentials []*model.WebAuthnCredential) []model.WebAuthnCredentialDescriptor {
+	descriptors := make([]model.WebAuthnCredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		descriptors = append(descriptors, model.WebAuthnCredentialDescriptor{Type: webAuthnCredentialType, Id: credential.CredentialId})
+	}
+	return descriptors
+}
+
+type collectedClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// WebAuthnChallenge returns the challenge a WebAuthn response was created for, to look up
+// the ceremony it completes.
+func WebAuthnChallenge(clientDataJSON string) ([]byte, error) {
+	clientData, _, err := parseClientData(clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := DecodeWebAuthnBinary(clientData.Challenge)
+	if err != nil {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "invalid challenge encoding")
+	}
+
+	return challenge, nil
+}
+
+func parseClientData(clientDataJSON string) (*collectedClientData, []byte, error) {
+	raw, err := DecodeWebAuthnBinary(clientDataJSON)
+	if err != nil {
+		return nil, nil, errors.Wrap(InvalidWebAuthnResponse, "invalid client data encoding")
+	}
+
+	var clientData collectedClientData
+	if err := json.Unmarshal(raw, &clientData); err != nil {
+		return nil, nil, errors.Wrap(InvalidWebAuthnResponse, "invalid client data")
+	}
+
+	return &clientData, raw, nil
+}
+
+// verifyClientData checks that the client data was collected by a browser on the site
+// for the given ceremony type and challenge, returning its hash.
+func (w *WebAuthn) verifyClientData(clientDataJSON, ceremonyType string, challenge []byte) ([]byte, error) {
+	clientData, raw, err := parseClientData(clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if clientData.Type != ceremonyType {
+		return nil, errors.Wrapf(InvalidWebAuthnResponse, "unexpected ceremony type %q", clientData.Type)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(EncodeWebAuthnBinary(challenge))) != 1 {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "challenge mismatch")
+	}
+
+	if clientData.Origin != w.origin || clientData.CrossOrigin {
+		return nil, errors.Wrapf(InvalidWebAuthnResponse, "unexpected origin %q", clientData.Origin)
+	}
+
+	hash := sha256.Sum256(raw)
+	return hash[:], nil
+}
+
+type authenticatorData struct {
+	flags        byte
+	signCount    uint32
+	credentialID []byte
+	publicKey    []byte
+}
+
+// parseAuthenticatorData parses authenticator data created for the relying party,
+// requiring the user to have been present.
+func (w *WebAuthn) parseAuthenticatorData(data []byte) (*authenticatorData, error) {
+	if len(data) < authDataMinSize {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "authenticator data too short")
+	}
+
+	rpIDHash := sha256.Sum256([]byte(w.rpID))
+	if subtle.ConstantTimeCompare(data[:rpIDHashSize], rpIDHash[:]) != 1 {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "relying party id mismatch")
+	}
+
+	authData := &authenticatorData{
+		flags:     data[rpIDHashSize],
+		signCount: binary.BigEndian.Uint32(data[rpIDHashSize+1:]),
+	}
+	if authData.flags&authDataFlagUserPresent == 0 {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "user not present")
+	}
+
+	if authData.flags&authDataFlagAttestedCredentialData != 0 {
+		rest := data[authDataMinSize:]
+		if len(rest) < aaguidSize+2 {
+			return nil, errors.Wrap(InvalidWebAuthnResponse, "attested credential data too short")
+		}
+		rest = rest[aaguidSize:]
+
+		idLength := int(binary.BigEndian.Uint16(rest))
+		rest = rest[2:]
+		if len(rest) < idLength {
+			return nil, errors.Wrap(InvalidWebAuthnResponse, "attested credential data too short")
+		}
+		authData.credentialID = rest[:idLength]
+		rest = rest[idLength:]
+
+		// The public key is the CBOR item following the credential ID, possibly followed
+		// by extensions.
+		_, extensions, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, errors.Wrap(InvalidWebAuthnResponse, "invalid credential public key")
+		}
+		authData.publicKey = rest[:len(rest)-len(extensions)]
+	}
+
+	return authData, nil
+}
+
+// VerifyRegistration verifies the response of a registration ceremony created with the
+// given challenge, returning the new credential without name or owner.
+func (w *WebAuthn) VerifyRegistration(attestation *model.WebAuthnAttestation, challenge []byte) (*model.WebAuthnCredential, error) {
+	if attestation == nil || attestation.Type != webAuthnCredentialType {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "unexpected credential type")
+	}
+
+	if _, err := w.verifyClientData(attestation.Response.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+
+	rawObject, err := DecodeWebAuthnBinary(attestation.Response.AttestationObject)
+	if err != nil {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "invalid attestation object encoding")
+	}
+	object, _, err := decodeCBOR(rawObject)
+	if err != nil {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "invalid attestation object")
+	}
+	objectMap, ok := object.(map[any]any)
+	if !ok {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "invalid attestation object")
+	}
+	rawAuthData, ok := objectMap["authData"].([]byte)
+	if !ok {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "missing authenticator data")
+	}
+
+	authData, err := w.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if authData.credentialID == nil {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "missing attested credential data")
+	}
+
+	rawID, err := DecodeWebAuthnBinary(attestation.RawId)
+	if err != nil || !bytes.Equal(rawID, authData.credentialID) {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "credential id mismatch")
+	}
+
+	if _, _, err := parseCOSEKey(authData.publicKey); err != nil {
+		return nil, err
+	}
+
+	return &model.WebAuthnCredential{
+		CredentialId: EncodeWebAuthnBinary(authData.credentialID),
+		PublicKey:    authData.publicKey,
+		SignCount:    int64(authData.signCount),
+	}, nil
+}
+
+// VerifyAssertion verifies the response of an authentication ceremony created with the
+// given challenge against the credential it was made with, returning the new signature
+// counter of the credential.
+func (w *WebAuthn) VerifyAssertion(assertion *model.WebAuthnAssertion, challenge []byte, credential *model.WebAuthnCredential) (int64, error) {
+	if assertion == nil || assertion.Type != webAuthnCredentialType {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "unexpected credential type")
+	}
+
+	rawID, err := DecodeWebAuthnBinary(assertion.RawId)
+	if err != nil || EncodeWebAuthnBinary(rawID) != credential.CredentialId {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "credential id mismatch")
+	}
+
+	clientDataHash, err := w.verifyClientData(assertion.Response.ClientDataJSON, "webauthn.get", challenge)
+	if err != nil {
+		return 0, err
+	}
+
+	rawAuthData, err := DecodeWebAuthnBinary(assertion.Response.AuthenticatorData)
+	if err != nil {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "invalid authenticator data encoding")
+	}
+	authData, err := w.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return 0, err
+	}
+
+	signature, err := DecodeWebAuthnBinary(assertion.Response.Signature)
+	if err != nil {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "invalid signature encoding")
+	}
+
+	alg, publicKey, err := parseCOSEKey(credential.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+	signed := append(append([]byte(nil), rawAuthData...), clientDataHash...)
+	if !verifySignature(alg, publicKey, signed, signature) {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "invalid signature")
+	}
+
+	// Authenticators without a signature counter always report zero. Otherwise, a counter
+	// that didn't increase hints at a cloned authenticator.
+	signCount := int64(authData.signCount)
+	if (signCount != 0 || credential.SignCount != 0) && signCount <= credential.SignCount {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "signature counter did not increase")
+	}
+
+	return signCount, nil
+}
+
+// parseCOSEKey parses a COSE (RFC 9053) encoded public key of one of the supported
+// algorithms.
+func parseCOSEKey(data []byte) (int64, crypto.PublicKey, error) {
+	decoded, _, err := decodeCBOR(data)
+	if err != nil {
+		return 0, nil, errors.Wrap(InvalidWebAuthnResponse, "invalid credential public key")
+	}
+	key, ok := decoded.(map[any]any)
+	if !ok {
+		return 0, nil, errors.Wrap(InvalidWebAuthnResponse, "invalid credential public key")
+	}
+
+	kty, _ := key[int64(1)].(int64)
+	alg, _ := key[int64(3)].(int64)
+	crv, _ := key[int64(-1)].(int64)
+
+	switch {
+	case kty == 2 && alg == coseAlgES256 && crv == 1:
+		x, _ := key[int64(-2)].([]byte)
+		y, _ := key[int64(-3)].([]byte)
+		if len(x) != 32 || len(y) != 32 {
+			return 0, nil, errors.Wrap(InvalidWebAuthnResponse, "invalid ec2 public key")
+		}
+		publicKey, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return 0, nil, errors.Wrap(InvalidWebAuthnResponse, "invalid ec2 public key")
+		}
+		return alg, publicKey, nil
+	case kty == 1 && alg == coseAlgEdDSA && crv == 6:
+		x, _ := key[int64(-2)].([]byte)
+		if len(x) != ed25519.PublicKeySize {
+			return 0, nil, errors.Wrap(InvalidWebAuthnResponse, "invalid okp public key")
+		}
+		return alg, ed25519.PublicKey(x), nil
+	case kty == 3 && alg == coseAlgRS256:
+		n, _ := key[int64(-1)].([]byte)
+		e, _ := key[int64(-2)].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return 0, nil, errors.Wrap(InvalidWebAuthnResponse, "invalid rsa public key")
+		}
+		exponent := 0
+		for _, b := range e {
+			exponent = exponent<<8 | int(b)
+		}
+		return alg, &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}, nil
+	}
+
+	return 0, nil, errors.Wrapf(InvalidWebAuthnResponse, "unsupported public key algorithm %d", alg)
+}
+
+func verifySignature(alg int64, publicKey crypto.PublicKey, signed, signature []byte) bool {
+	switch alg {
+	case coseAlgES256:
+		hash := sha256.Sum256(signed)
+		return ecdsa.VerifyASN1(publicKey.(*ecdsa.PublicKey), hash[:], signature)
+	case coseAlgEdDSA:
+		return ed25519.Verify(publicKey.(ed25519.PublicKey), signed, signature)
+	case coseAlgRS256:
+		hash := sha256.Sum256(signed)
+		return rsa.VerifyPKCS1v15(publicKey.(*rsa.PublicKey), crypto.SHA256, hash[:], signature) == nil
+	}
+	return false
+}
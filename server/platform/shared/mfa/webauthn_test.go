@@ -0,0 +1,366 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const testSiteURL = "https://chat.example.com"
+
+// encodeCBOR encodes the subset of CBOR values decodeCBOR supports.
+func encodeCBOR(t *testing.T, value any) []byte {
+	t.Helper()
+
+	head := func(major byte, arg uint64) []byte {
+		switch {
+		case arg < 24:
+			return []byte{major<<5 | byte(arg)}
+		case arg <= 0xff:
+			return []byte{major<<5 | 24, byte(arg)}
+		case arg <= 0xffff:
+			return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(arg))
+		default:
+			return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(arg))
+		}
+	}
+
+	switch v := value.(type) {
+	case int:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case []any:
+		out := head(4, uint64(len(v)))
+		for _, item := range v {
+			out = append(out, encodeCBOR(t, item)...)
+		}
+		return out
+	case map[any]any:
+		out := head(5, uint64(len(v)))
+		for key, item := range v {
+			out = append(out, encodeCBOR(t, key)...)
+			out = append(out, encodeCBOR(t, item)...)
+		}
+		return out
+	case bool:
+		if v {
+			return []byte{0xf5}
+		}
+		return []byte{0xf4}
+	}
+
+	require.FailNow(t, "unsupported cbor value")
+	return nil
+}
+
+// testAuthenticator emulates a WebAuthn authenticator and the browser talking to it.
+type testAuthenticator struct {
+	t            *testing.T
+	origin       string
+	rpID         string
+	credentialID []byte
+	ecdsaKey     *ecdsa.PrivateKey
+	ed25519Key   ed25519.PrivateKey
+	signCount    uint32
+	publicKey    []byte
+}
+
+func newTestAuthenticator(t *testing.T, useEd25519 bool) *testAuthenticator {
+	a := &testAuthenticator{
+		t:            t,
+		origin:       testSiteURL,
+		rpID:         "chat.example.com",
+		credentialID: make([]byte, 32),
+	}
+	rand.Read(a.credentialID)
+
+	var err error
+	if useEd25519 {
+		_, a.ed25519Key, err = ed25519.GenerateKey(rand.Reader)
+	} else {
+		a.ecdsaKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	require.NoError(t, err)
+
+	return a
+}
+
+// coseKey returns the encoded public key of the authenticator. It is encoded once, as
+// encodeCBOR doesn't sort map keys.
+func (a *testAuthenticator) coseKey() []byte {
+	if a.publicKey != nil {
+		return a.publicKey
+	}
+
+	if a.ed25519Key != nil {
+		a.publicKey = encodeCBOR(a.t, map[any]any{1: 1, 3: coseAlgEdDSA, -1: 6, -2: []byte(a.ed25519Key.Public().(ed25519.PublicKey))})
+		return a.publicKey
+	}
+
+	point, err := a.ecdsaKey.PublicKey.Bytes()
+	require.NoError(a.t, err)
+	a.publicKey = encodeCBOR(a.t, map[any]any{1: 2, 3: coseAlgES256, -1: 1, -2: point[1:33], -3: point[33:]})
+	return a.publicKey
+}
+
+func (a *testAuthenticator) clientData(ceremonyType string, challenge string) []byte {
+	data, err := json.Marshal(map[string]any{"type": ceremonyType, "challenge": challenge, "origin": a.origin})
+	require.NoError(a.t, err)
+	return data
+}
+
+func (a *testAuthenticator) authData(attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+	data := append(rpIDHash[:], authDataFlagUserPresent)
+	if attested {
+		data[rpIDHashSize] |= authDataFlagAttestedCredentialData
+	}
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+
+	if attested {
+		data = append(data, make([]byte, aaguidSize)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, a.coseKey()...)
+	}
+	return data
+}
+
+func (a *testAuthenticator) create(options *model.WebAuthnCreationOptions) *model.WebAuthnAttestation {
+	attestationObject := encodeCBOR(a.t, map[any]any{
+		"fmt":      "none",
+		"attStmt":  map[any]any{},
+		"authData": a.authData(true),
+	})
+
+	return &model.WebAuthnAttestation{
+		Id:    EncodeWebAuthnBinary(a.credentialID),
+		RawId: EncodeWebAuthnBinary(a.credentialID),
+		Type:  "public-key",
+		Response: model.WebAuthnAttestationResponse{
+			ClientDataJSON:    EncodeWebAuthnBinary(a.clientData("webauthn.create", options.Challenge)),
+			AttestationObject: EncodeWebAuthnBinary(attestationObject),
+		},
+	}
+}
+
+func (a *testAuthenticator) get(options *model.WebAuthnRequestOptions) *model.WebAuthnAssertion {
+	a.signCount++
+	authData := a.authData(false)
+	clientData := a.clientData("webauthn.get", options.Challenge)
+	clientDataHash := sha256.Sum256(clientData)
+	signed := append(append([]byte(nil), authData...), clientDataHash[:]...)
+
+	var signature []byte
+	if a.ed25519Key != nil {
+		signature = ed25519.Sign(a.ed25519Key, signed)
+	} else {
+		hash := sha256.Sum256(signed)
+		var err error
+		signature, err = ecdsa.SignASN1(rand.Reader, a.ecdsaKey, hash[:])
+		require.NoError(a.t, err)
+	}
+
+	return &model.WebAuthnAssertion{
+		Id:    EncodeWebAuthnBinary(a.credentialID),
+		RawId: EncodeWebAuthnBinary(a.credentialID),
+		Type:  "public-key",
+		Response: model.WebAuthnAssertionResponse{
+			ClientDataJSON:    EncodeWebAuthnBinary(clientData),
+			AuthenticatorData: EncodeWebAuthnBinary(authData),
+			Signature:         EncodeWebAuthnBinary(signature),
+		},
+	}
+}
+
+func TestNewWebAuthn(t *testing.T) {
+	w, err := NewWebAuthn("https://chat.example.com:8443/subpath", "Mattermost")
+	require.NoError(t, err)
+	assert.Equal(t, "chat.example.com", w.rpID)
+	assert.Equal(t, "https://chat.example.com:8443", w.origin)
+
+	_, err = NewWebAuthn("", "Mattermost")
+	require.Error(t, err)
+}
+
+func TestWebAuthnCeremonies(t *testing.T) {
+	user := &model.User{Id: model.NewId(), Username: "sample", FirstName: "Sample", LastName: "User"}
+
+	for name, useEd25519 := range map[string]bool{"ES256": false, "EdDSA": true} {
+		t.Run(name, func(t *testing.T) {
+			w, err := NewWebAuthn(testSiteURL, "Mattermost")
+			require.NoError(t, err)
+			authenticator := newTestAuthenticator(t, useEd25519)
+
+			challenge := []byte(model.NewRandomString(model.TokenSize))
+			creationOptions := w.CreationOptions(user, challenge, nil)
+			assert.Equal(t, "chat.example.com", creationOptions.RP.Id)
+			assert.Equal(t, "Sample User", creationOptions.User.DisplayName)
+			assert.Empty(t, creationOptions.ExcludeCredentials)
+
+			credential, err := w.VerifyRegistration(authenticator.create(creationOptions), challenge)
+			require.NoError(t, err)
+			assert.Equal(t, EncodeWebAuthnBinary(authenticator.credentialID), credential.CredentialId)
+			assert.Equal(t, authenticator.coseKey(), credential.PublicKey)
+
+			challenge = []byte(model.NewRandomString(model.TokenSize))
+			requestOptions := w.RequestOptions(challenge, []*model.WebAuthnCredential{credential})
+			require.Len(t, requestOptions.AllowCredentials, 1)
+			assert.Equal(t, credential.CredentialId, requestOptions.AllowCredentials[0].Id)
+
+			assertion := authenticator.get(requestOptions)
+			signCount, err := w.VerifyAssertion(assertion, challenge, credential)
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, signCount)
+
+			parsedChallenge, err := WebAuthnChallenge(assertion.Response.ClientDataJSON)
+			require.NoError(t, err)
+			assert.Equal(t, challenge, parsedChallenge)
+
+			t.Run("replayed assertion", func(t *testing.T) {
+				credential.SignCount = signCount
+				_, err := w.VerifyAssertion(assertion, challenge, credential)
+				require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+			})
+		})
+	}
+}
+
+func TestWebAuthnVerificationFailures(t *testing.T) {
+	user := &model.User{Id: model.NewId(), Username: "sample"}
+	w, err := NewWebAuthn(testSiteURL, "Mattermost")
+	require.NoError(t, err)
+
+	register := func(t *testing.T, authenticator *testAuthenticator) *model.WebAuthnCredential {
+		challenge := []byte(model.NewRandomString(model.TokenSize))
+		credential, err := w.VerifyRegistration(authenticator.create(w.CreationOptions(user, challenge, nil)), challenge)
+		require.NoError(t, err)
+		return credential
+	}
+
+	t.Run("wrong challenge", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, false)
+		challenge := []byte(model.NewRandomString(model.TokenSize))
+		attestation := authenticator.create(w.CreationOptions(user, challenge, nil))
+
+		_, err := w.VerifyRegistration(attestation, []byte(model.NewRandomString(model.TokenSize)))
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("wrong origin", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, false)
+		authenticator.origin = "https://evil.example.com"
+		challenge := []byte(model.NewRandomString(model.TokenSize))
+
+		_, err := w.VerifyRegistration(authenticator.create(w.CreationOptions(user, challenge, nil)), challenge)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("wrong relying party", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, false)
+		authenticator.rpID = "evil.example.com"
+		challenge := []byte(model.NewRandomString(model.TokenSize))
+
+		_, err := w.VerifyRegistration(authenticator.create(w.CreationOptions(user, challenge, nil)), challenge)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("assertion of another credential", func(t *testing.T) {
+		credential := register(t, newTestAuthenticator(t, false))
+		other := newTestAuthenticator(t, false)
+
+		challenge := []byte(model.NewRandomString(model.TokenSize))
+		_, err := w.VerifyAssertion(other.get(w.RequestOptions(challenge, nil)), challenge, credential)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, false)
+		credential := register(t, authenticator)
+
+		challenge := []byte(model.NewRandomString(model.TokenSize))
+		assertion := authenticator.get(w.RequestOptions(challenge, nil))
+		authenticator.ecdsaKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		forged := authenticator.get(w.RequestOptions(challenge, nil))
+		assertion.Response.Signature = forged.Response.Signature
+
+		_, err := w.VerifyAssertion(assertion, challenge, credential)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+
+	t.Run("malformed attestation object", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t, false)
+		challenge := []byte(model.NewRandomString(model.TokenSize))
+		attestation := authenticator.create(w.CreationOptions(user, challenge, nil))
+		attestation.Response.AttestationObject = EncodeWebAuthnBinary([]byte{0xa1, 0x63, 'f'})
+
+		_, err := w.VerifyRegistration(attestation, challenge)
+		require.True(t, errors.Is(err, InvalidWebAuthnResponse))
+	})
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	value, rest, err := decodeCBOR(append(encodeCBOR(t, []any{1, -300, "text", []byte{1, 2}, true}), 0xff))
+	require.NoError(t, err)
+	assert.Equal(t, []any{int64(1), int64(-300), "text", []byte{1, 2}, true}, value)
+	assert.Equal(t, []byte{0xff}, rest)
+
+	t.Run("truncated", func(t *testing.T) {
+		_, _, err := decodeCBOR([]byte{0x5a, 0xff, 0xff, 0xff, 0xff})
+		require.Error(t, err)
+	})
+
+	t.Run("indefinite length", func(t *testing.T) {
+		_, _, err := decodeCBOR([]byte{0x9f, 0x01, 0xff})
+		require.Error(t, err)
+	})
+
+	t.Run("nested too deeply", func(t *testing.T) {
+		data := make([]byte, maxCBORDepth+2)
+		for i := range data {
+			data[i] = 0x81
+		}
+		_, _, err := decodeCBOR(append(data, 0x01))
+		require.Error(t, err)
+	})
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes := GenerateRecoveryCodes(model.MfaRecoveryCodeCount)
+	require.Len(t, codes, model.MfaRecoveryCodeCount)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		assert.True(t, IsRecoveryCode(code))
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+
+	assert.Equal(t, HashRecoveryCode(codes[0]), HashRecoveryCode(" "+codes[0][:5]+codes[0][6:]+" "))
+	assert.NotEqual(t, HashRecoveryCode(codes[0]), HashRecoveryCode(codes[1]))
+
+	assert.False(t, IsRecoveryCode("123456"))
+	assert.False(t, IsRecoveryCode("abcde-00000"))
+	assert.False(t, IsRecoveryCode(`{"id":"abc"}`))
+}
@@ -389,6 +389,7 @@ const (
 	AuditEventClearServerBusy            = "clearServerBusy"            // clear server busy status to allow normal operations
 	AuditEventCompleteOnboarding         = "completeOnboarding"         // complete system onboarding process
 	AuditEventDatabaseRecycle            = "databaseRecycle"            // closes active connections
+	AuditEventDeleteOutgoingEmail        = "deleteOutgoingEmail"        // delete an email from the outbound mail queue
 	AuditEventDownloadLogs               = "downloadLogs"               // download server log files
 	AuditEventGenerateSupportPacket      = "generateSupportPacket"      // generate support packet with server diagnostics and logs
 	AuditEventGetAppliedSchemaMigrations = "getAppliedSchemaMigrations" // get list of applied database schema migrations
@@ -398,6 +399,7 @@ const (
 	AuditEventLocalCheckIntegrity        = "localCheckIntegrity"        // check database integrity locally
 	AuditEventQueryLogs                  = "queryLogs"                  // search server log entries
 	AuditEventRestartServer              = "restartServer"              // restart Mattermost server process
+	AuditEventRetryOutgoingEmail         = "retryOutgoingEmail"         // move a failed email back to the outbound mail queue
 	AuditEventSetServerBusy              = "setServerBusy"              // set server busy status to disallow any operations
 	AuditEventUpdateViewedProductNotices = "updateViewedProductNotices" // update viewed status of product notices
 	AuditEventUpgradeToEnterprise        = "upgradeToEnterprise"        // upgrade server to Enterprise edition
@@ -454,13 +456,17 @@ const (
 // Users
 const (
 	AuditEventAttachDeviceId               = "attachDeviceId"               // attach device IDs (standard or VoIP) to user session for mobile app
+	AuditEventAttachWebPushSubscription    = "attachWebPushSubscription"    // attach browser web push subscription to user session
 	AuditEventCreateUser                   = "createUser"                   // create user account
 	AuditEventCreateUserAccessToken        = "createUserAccessToken"        // create personal access token for user API access
 	AuditEventDeleteUser                   = "deleteUser"                   // delete user account
+	AuditEventDeleteWebAuthnCredential     = "deleteWebAuthnCredential"     // delete user security key used as second factor
 	AuditEventDemoteUserToGuest            = "demoteUserToGuest"            // demote regular user to guest account with limited permissions
+	AuditEventDetachWebPushSubscription    = "detachWebPushSubscription"    // detach browser web push subscription from user session
 	AuditEventDisableUserAccessToken       = "disableUserAccessToken"       // disable user personal access token
 	AuditEventEnableUserAccessToken        = "enableUserAccessToken"        // enable user personal access token
 	AuditEventExtendSessionExpiry          = "extendSessionExpiry"          // extend user session expiration time
+	AuditEventGenerateMfaRecoveryCodes     = "generateMfaRecoveryCodes"     // generate new multi-factor authentication recovery codes for user
 	AuditEventLocalDeleteUser              = "localDeleteUser"              // delete user locally
 	AuditEventLocalPermanentDeleteAllUsers = "localPermanentDeleteAllUsers" // permanently delete all users locally
 	AuditEventLogin                        = "login"                        // user login to system
@@ -472,6 +478,7 @@ const (
 	AuditEventMigrateAuthToSaml            = "migrateAuthToSaml"            // migrate user authentication method to SAML
 	AuditEventPatchUser                    = "patchUser"                    // update user properties
 	AuditEventPromoteGuestToUser           = "promoteGuestToUser"           // promote guest account to regular user
+	AuditEventRegisterWebAuthnCredential   = "registerWebAuthnCredential"   // register user security key used as second factor
 	AuditEventResetPassword                = "resetPassword"                // reset user password
 	AuditEventResetPasswordFailedAttempts  = "resetPasswordFailedAttempts"  // reset failed password attempt counter
 	AuditEventRevokeAllSessionsAllUsers    = "revokeAllSessionsAllUsers"    // revoke all active sessions for all users
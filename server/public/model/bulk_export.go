@@ -13,4 +13,32 @@ type BulkExportOpts struct {
 	IncludeArchivedChannels bool
 	IncludeRolesAndSchemes  bool
 	CreateArchive           bool
+
+	// TeamIds and ChannelIds restrict the export to the given teams and channels, and to the
+	// users that are members of them. Everything is exported when both are empty.
+	TeamIds    []string
+	ChannelIds []string
+
+	// Since restricts the export to the entities created or updated after the given timestamp,
+	// in milliseconds. Entities deleted since then are exported as delete lines, so that the
+	// export can be imported on top of the previous ones.
+	Since int64
+}
+
+// IsScoped returns true if the export is restricted to some teams or channels.
+func (o *BulkExportOpts) IsScoped() bool {
+	return len(o.TeamIds) > 0 || len(o.ChannelIds) > 0
+}
+
+// PostExportOpts filters the posts returned by the export queries of the store.
+type PostExportOpts struct {
+	IncludeArchivedChannels bool
+
+	// TeamIds and ChannelIds restrict the posts to the given teams and channels. Posts of all
+	// the channels are returned when both are empty.
+	TeamIds    []string
+	ChannelIds []string
+
+	// Since restricts the posts to the threads updated after the given timestamp.
+	Since int64
 }
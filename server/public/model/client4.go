@@ -1,6 +1,8 @@
 // Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
 // See LICENSE.txt for license information.
 
+//go:generate go run ./client4_paginators_generator
+
 package model
 
 import (
@@ -79,6 +81,9 @@ type Client4 struct {
 
 	// FalseString is the string value sent to the server for false boolean query parameters.
 	falseString string
+
+	// retryPolicy is the policy used to retry the failed requests, if any.
+	retryPolicy *RetryPolicy
 }
 
 // SetBoolString is a helper method for overriding how true and false query string parameters are
@@ -117,7 +122,7 @@ func closeBody(r *http.Response) {
 
 func NewAPIv4Client(url string) *Client4 {
 	url = strings.TrimRight(url, "/")
-	return &Client4{url, url + APIURLSuffix, &http.Client{}, "", "", map[string]string{}, "", ""}
+	return &Client4{url, url + APIURLSuffix, &http.Client{}, "", "", map[string]string{}, "", "", nil}
 }
 
 func NewAPIv4SocketClient(socketPath string) *Client4 {
@@ -369,6 +374,10 @@ func (c *Client4) testEmailRoute() clientRoute {
 	return newClientRoute("email").Join("test")
 }
 
+func (c *Client4) outgoingEmailDeadLettersRoute() clientRoute {
+	return newClientRoute("email").Join("dead_letters")
+}
+
 func (c *Client4) testNotificationRoute() clientRoute {
 	return newClientRoute("notifications").Join("test")
 }
@@ -942,7 +951,7 @@ func (c *Client4) doAPIRequestReader(ctx context.Context, method, url, contentTy
 		}
 	}
 
-	rp, err := c.HTTPClient.Do(rq)
+	rp, err := c.do(rq)
 	if err != nil {
 		return rp, err
 	}
@@ -1042,6 +1051,17 @@ func (c *Client4) LoginWithMFA(ctx context.Context, loginId, password, mfaToken
 	return c.login(ctx, m)
 }
 
+// BeginWebAuthnLogin returns the options of a security key assertion for the user with
+// the given login id. The resulting assertion is sent as the MFA token of LoginWithMFA.
+func (c *Client4) BeginWebAuthnLogin(ctx context.Context, loginId string) (*WebAuthnRequestOptions, *Response, error) {
+	r, err := c.doAPIPostJSON(ctx, c.usersRoute().Join("login", "webauthn"), map[string]string{"login_id": loginId})
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*WebAuthnRequestOptions](r)
+}
+
 func (c *Client4) login(ctx context.Context, m map[string]string) (*User, *Response, error) {
 	r, err := c.doAPIPostJSON(ctx, c.usersRoute().Join("login"), m)
 	if err != nil {
@@ -1580,6 +1600,58 @@ func (c *Client4) GenerateMfaSecret(ctx context.Context, userId string) (*MfaSec
 	return DecodeJSONFromResponse[*MfaSecret](r)
 }
 
+// GetWebAuthnCredentials returns the security keys a user registered as second factors.
+func (c *Client4) GetWebAuthnCredentials(ctx context.Context, userId string) ([]*WebAuthnCredential, *Response, error) {
+	r, err := c.doAPIGet(ctx, c.userRoute(userId).Join("mfa", "webauthn"), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*WebAuthnCredential](r)
+}
+
+// BeginWebAuthnRegistration returns the options to register a new security key for a user.
+func (c *Client4) BeginWebAuthnRegistration(ctx context.Context, userId string) (*WebAuthnCreationOptions, *Response, error) {
+	r, err := c.doAPIPost(ctx, c.userRoute(userId).Join("mfa", "webauthn", "begin"), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*WebAuthnCreationOptions](r)
+}
+
+// FinishWebAuthnRegistration registers a new security key for a user with the response
+// of the authenticator to the options of BeginWebAuthnRegistration.
+func (c *Client4) FinishWebAuthnRegistration(ctx context.Context, userId string, registration *WebAuthnRegistration) (*WebAuthnCredential, *Response, error) {
+	r, err := c.doAPIPostJSON(ctx, c.userRoute(userId).Join("mfa", "webauthn"), registration)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*WebAuthnCredential](r)
+}
+
+// DeleteWebAuthnCredential removes a security key of a user.
+func (c *Client4) DeleteWebAuthnCredential(ctx context.Context, userId, credentialId string) (*Response, error) {
+	r, err := c.doAPIDelete(ctx, c.userRoute(userId).Join("mfa", "webauthn", credentialId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// GenerateMfaRecoveryCodes replaces the recovery codes of a user with new ones, which
+// are only returned once.
+func (c *Client4) GenerateMfaRecoveryCodes(ctx context.Context, userId string) (*MfaRecoveryCodes, *Response, error) {
+	r, err := c.doAPIPost(ctx, c.userRoute(userId).Join("mfa", "recovery_codes"), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*MfaRecoveryCodes](r)
+}
+
 // UpdateUserPassword updates a user's password. Must be logged in as the user or be a system administrator.
 func (c *Client4) UpdateUserPassword(ctx context.Context, userId, currentPassword, newPassword string) (*Response, error) {
 	requestBody := map[string]string{"current_password": currentPassword, "new_password": newPassword}
@@ -1800,6 +1872,28 @@ func (c *Client4) AttachDeviceProps(ctx context.Context, newProps map[string]str
 	return BuildResponse(r), nil
 }
 
+// AttachWebPushSubscription attaches the Web Push subscription of the browser to the
+// current session, so that notifications are pushed to it. Must be authenticated.
+func (c *Client4) AttachWebPushSubscription(ctx context.Context, subscription *WebPushSubscription) (*Response, error) {
+	r, err := c.doAPIPutJSON(ctx, c.usersRoute().Join("sessions", "web_push"), subscription)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// DetachWebPushSubscription removes the Web Push subscription attached to the current
+// session. Must be authenticated.
+func (c *Client4) DetachWebPushSubscription(ctx context.Context) (*Response, error) {
+	r, err := c.doAPIDelete(ctx, c.usersRoute().Join("sessions", "web_push"))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // GetTeamsUnreadForUser will return an array with TeamUnread objects that contain the amount
 // of unread messages and mentions the current user has for the teams it belongs to.
 // An optional team ID can be set to exclude that team from the results.
@@ -4405,6 +4499,39 @@ func (c *Client4) TestEmail(ctx context.Context, config *Config) (*Response, err
 	return BuildResponse(r), nil
 }
 
+// GetFailedOutgoingEmails returns a page of the emails the mail queue gave up on sending.
+func (c *Client4) GetFailedOutgoingEmails(ctx context.Context, page int, perPage int) ([]*OutgoingEmail, *Response, error) {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	r, err := c.doAPIGetWithQuery(ctx, c.outgoingEmailDeadLettersRoute(), values, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*OutgoingEmail](r)
+}
+
+// RetryOutgoingEmail moves an email the mail queue gave up on sending back to the queue.
+func (c *Client4) RetryOutgoingEmail(ctx context.Context, id string) (*OutgoingEmail, *Response, error) {
+	r, err := c.doAPIPost(ctx, c.outgoingEmailDeadLettersRoute().Join(id, "retry"), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*OutgoingEmail](r)
+}
+
+// DeleteOutgoingEmail removes an email from the mail queue.
+func (c *Client4) DeleteOutgoingEmail(ctx context.Context, id string) (*Response, error) {
+	r, err := c.doAPIDelete(ctx, c.outgoingEmailDeadLettersRoute().Join(id))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 func (c *Client4) TestNotifications(ctx context.Context) (*Response, error) {
 	r, err := c.doAPIPost(ctx, c.testNotificationRoute(), "")
 	if err != nil {
@@ -4826,6 +4953,20 @@ func (c *Client4) RegenOutgoingHookToken(ctx context.Context, hookId string) (*O
 	return DecodeJSONFromResponse[*OutgoingWebhook](r)
 }
 
+// GetOutgoingWebhookDeliveries returns a page of the delivery log of an outgoing webhook,
+// most recent deliveries first. Page counting starts at 0.
+func (c *Client4) GetOutgoingWebhookDeliveries(ctx context.Context, hookId string, page int, perPage int) ([]*OutgoingWebhookDelivery, *Response, error) {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	r, err := c.doAPIGetWithQuery(ctx, c.outgoingWebhookRoute(hookId).Join("deliveries"), values, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*OutgoingWebhookDelivery](r)
+}
+
 // DeleteOutgoingWebhook delete the outgoing webhook on the system requested by Hook Id.
 func (c *Client4) DeleteOutgoingWebhook(ctx context.Context, hookId string) (*Response, error) {
 	r, err := c.doAPIDelete(ctx, c.outgoingWebhookRoute(hookId))
@@ -7993,7 +8134,7 @@ func (c *Client4) viewQueryValues(opts ...ViewQueryOpts) url.Values {
 }
 
 // GetPostsForView gets a page of posts for a specific view (board) in a channel.
-// TODO: Pagination will change once we support filtering/sorting by property values.
+// Posts of table views are filtered and sorted according to the view's configuration.
 func (c *Client4) GetPostsForView(ctx context.Context, channelId, viewId string, page, perPage int) (*PostList, *Response, error) {
 	values := url.Values{}
 	values.Set("page", strconv.Itoa(page))
@@ -8006,6 +8147,22 @@ func (c *Client4) GetPostsForView(ctx context.Context, channelId, viewId string,
 	return DecodeJSONFromResponse[*PostList](r)
 }
 
+// GetPostsForCalendarView gets a page of the posts of a calendar view whose date falls
+// between start (inclusive) and end (exclusive), given in milliseconds.
+func (c *Client4) GetPostsForCalendarView(ctx context.Context, channelId, viewId string, start, end int64, page, perPage int) (*PostList, *Response, error) {
+	values := url.Values{}
+	values.Set("start", strconv.FormatInt(start, 10))
+	values.Set("end", strconv.FormatInt(end, 10))
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	r, err := c.doAPIGetWithQuery(ctx, c.viewRoute(channelId, viewId).Join("posts"), values, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*PostList](r)
+}
+
 // UpdateView patches a view.
 func (c *Client4) UpdateView(ctx context.Context, channelId, viewId string, patch *ViewPatch) (*View, *Response, error) {
 	r, err := c.doAPIPatchJSON(ctx, c.viewRoute(channelId, viewId), patch)
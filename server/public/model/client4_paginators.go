@@ -0,0 +1,56 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"iter"
+)
+
+// The default and maximum numbers of items per page of the paged list endpoints.
+const (
+	client4PerPageDefault = 60
+	client4PerPageMaximum = 200
+)
+
+// paginate iterates over the items of the pages returned by getPage, until a page has fewer
+// items than requested. An error ends the iteration. perPage is brought within the range
+// accepted by the server, as a page of fewer items than requested would end the iteration.
+func paginate[T any](perPage int, getPage func(page, perPage int) ([]T, error)) iter.Seq2[T, error] {
+	if perPage <= 0 {
+		perPage = client4PerPageDefault
+	}
+	perPage = min(perPage, client4PerPageMaximum)
+
+	return func(yield func(T, error) bool) {
+		for page := 0; ; page++ {
+			items, err := getPage(page, perPage)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) < perPage {
+				return
+			}
+		}
+	}
+}
+
+// paginatePostList iterates over the posts of the post lists returned by getPage, in their order.
+func paginatePostList(perPage int, getPage func(page, perPage int) (*PostList, error)) iter.Seq2[*Post, error] {
+	return paginate(perPage, func(page, perPage int) ([]*Post, error) {
+		list, err := getPage(page, perPage)
+		if err != nil || list == nil {
+			return nil, err
+		}
+		return list.ToSlice(), nil
+	})
+}
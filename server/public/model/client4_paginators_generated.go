@@ -0,0 +1,318 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make client4-paginators"
+// DO NOT EDIT
+
+package model
+
+import (
+	"context"
+	"iter"
+)
+
+// GetUsersIter iterates over the items of all the pages returned by GetUsers,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUsersIter(ctx context.Context, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetUsers(ctx, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetUsersWithCustomQueryParametersIter iterates over the items of all the pages returned by GetUsersWithCustomQueryParameters,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUsersWithCustomQueryParametersIter(ctx context.Context, perPage int, queryParameters string) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetUsersWithCustomQueryParameters(ctx, page, perPage, queryParameters, "")
+		return list, err
+	})
+}
+
+// GetUsersInTeamIter iterates over the items of all the pages returned by GetUsersInTeam,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUsersInTeamIter(ctx context.Context, teamId string, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetUsersInTeam(ctx, teamId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetNewUsersInTeamIter iterates over the items of all the pages returned by GetNewUsersInTeam,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetNewUsersInTeamIter(ctx context.Context, teamId string, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetNewUsersInTeam(ctx, teamId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetRecentlyActiveUsersInTeamIter iterates over the items of all the pages returned by GetRecentlyActiveUsersInTeam,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetRecentlyActiveUsersInTeamIter(ctx context.Context, teamId string, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetRecentlyActiveUsersInTeam(ctx, teamId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetActiveUsersInTeamIter iterates over the items of all the pages returned by GetActiveUsersInTeam,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetActiveUsersInTeamIter(ctx context.Context, teamId string, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetActiveUsersInTeam(ctx, teamId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetUsersNotInTeamIter iterates over the items of all the pages returned by GetUsersNotInTeam,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUsersNotInTeamIter(ctx context.Context, teamId string, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetUsersNotInTeam(ctx, teamId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetUsersInChannelIter iterates over the items of all the pages returned by GetUsersInChannel,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUsersInChannelIter(ctx context.Context, channelId string, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetUsersInChannel(ctx, channelId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetUsersInChannelByStatusIter iterates over the items of all the pages returned by GetUsersInChannelByStatus,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUsersInChannelByStatusIter(ctx context.Context, channelId string, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetUsersInChannelByStatus(ctx, channelId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetUsersNotInChannelIter iterates over the items of all the pages returned by GetUsersNotInChannel,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUsersNotInChannelIter(ctx context.Context, teamId string, channelId string, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetUsersNotInChannel(ctx, teamId, channelId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetUsersWithoutTeamIter iterates over the items of all the pages returned by GetUsersWithoutTeam,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUsersWithoutTeamIter(ctx context.Context, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetUsersWithoutTeam(ctx, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetUsersInGroupIter iterates over the items of all the pages returned by GetUsersInGroup,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUsersInGroupIter(ctx context.Context, groupID string, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetUsersInGroup(ctx, groupID, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetUsersInGroupByDisplayNameIter iterates over the items of all the pages returned by GetUsersInGroupByDisplayName,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUsersInGroupByDisplayNameIter(ctx context.Context, groupID string, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetUsersInGroupByDisplayName(ctx, groupID, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetUserAuditsIter iterates over the items of all the pages returned by GetUserAudits,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUserAuditsIter(ctx context.Context, userId string, perPage int) iter.Seq2[Audit, error] {
+	return paginate(perPage, func(page, perPage int) ([]Audit, error) {
+		list, _, err := c.GetUserAudits(ctx, userId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetAllTeamsIter iterates over the items of all the pages returned by GetAllTeams,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetAllTeamsIter(ctx context.Context, perPage int) iter.Seq2[*Team, error] {
+	return paginate(perPage, func(page, perPage int) ([]*Team, error) {
+		list, _, err := c.GetAllTeams(ctx, "", page, perPage)
+		return list, err
+	})
+}
+
+// GetAllTeamsExcludePolicyConstrainedIter iterates over the items of all the pages returned by GetAllTeamsExcludePolicyConstrained,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetAllTeamsExcludePolicyConstrainedIter(ctx context.Context, perPage int) iter.Seq2[*Team, error] {
+	return paginate(perPage, func(page, perPage int) ([]*Team, error) {
+		list, _, err := c.GetAllTeamsExcludePolicyConstrained(ctx, "", page, perPage)
+		return list, err
+	})
+}
+
+// GetAllChannelsIter iterates over the items of all the pages returned by GetAllChannels,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetAllChannelsIter(ctx context.Context, perPage int) iter.Seq2[*ChannelWithTeamData, error] {
+	return paginate(perPage, func(page, perPage int) ([]*ChannelWithTeamData, error) {
+		list, _, err := c.GetAllChannels(ctx, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetAllChannelsIncludeDeletedIter iterates over the items of all the pages returned by GetAllChannelsIncludeDeleted,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetAllChannelsIncludeDeletedIter(ctx context.Context, perPage int) iter.Seq2[*ChannelWithTeamData, error] {
+	return paginate(perPage, func(page, perPage int) ([]*ChannelWithTeamData, error) {
+		list, _, err := c.GetAllChannelsIncludeDeleted(ctx, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetAllChannelsExcludePolicyConstrainedIter iterates over the items of all the pages returned by GetAllChannelsExcludePolicyConstrained,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetAllChannelsExcludePolicyConstrainedIter(ctx context.Context, perPage int) iter.Seq2[*ChannelWithTeamData, error] {
+	return paginate(perPage, func(page, perPage int) ([]*ChannelWithTeamData, error) {
+		list, _, err := c.GetAllChannelsExcludePolicyConstrained(ctx, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetPrivateChannelsForTeamIter iterates over the items of all the pages returned by GetPrivateChannelsForTeam,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetPrivateChannelsForTeamIter(ctx context.Context, teamId string, perPage int) iter.Seq2[*Channel, error] {
+	return paginate(perPage, func(page, perPage int) ([]*Channel, error) {
+		list, _, err := c.GetPrivateChannelsForTeam(ctx, teamId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetPublicChannelsForTeamIter iterates over the items of all the pages returned by GetPublicChannelsForTeam,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetPublicChannelsForTeamIter(ctx context.Context, teamId string, perPage int) iter.Seq2[*Channel, error] {
+	return paginate(perPage, func(page, perPage int) ([]*Channel, error) {
+		list, _, err := c.GetPublicChannelsForTeam(ctx, teamId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetDeletedChannelsForTeamIter iterates over the items of all the pages returned by GetDeletedChannelsForTeam,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetDeletedChannelsForTeamIter(ctx context.Context, teamId string, perPage int) iter.Seq2[*Channel, error] {
+	return paginate(perPage, func(page, perPage int) ([]*Channel, error) {
+		list, _, err := c.GetDeletedChannelsForTeam(ctx, teamId, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetPostsForChannelIter iterates over the items of all the pages returned by GetPostsForChannel,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetPostsForChannelIter(ctx context.Context, channelId string, perPage int, collapsedThreads bool, includeDeleted bool) iter.Seq2[*Post, error] {
+	return paginatePostList(perPage, func(page, perPage int) (*PostList, error) {
+		list, _, err := c.GetPostsForChannel(ctx, channelId, page, perPage, "", collapsedThreads, includeDeleted)
+		return list, err
+	})
+}
+
+// GetFlaggedPostsForUserIter iterates over the items of all the pages returned by GetFlaggedPostsForUser,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetFlaggedPostsForUserIter(ctx context.Context, userId string, perPage int) iter.Seq2[*Post, error] {
+	return paginatePostList(perPage, func(page, perPage int) (*PostList, error) {
+		list, _, err := c.GetFlaggedPostsForUser(ctx, userId, page, perPage)
+		return list, err
+	})
+}
+
+// GetFlaggedPostsForUserInTeamIter iterates over the items of all the pages returned by GetFlaggedPostsForUserInTeam,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetFlaggedPostsForUserInTeamIter(ctx context.Context, userId string, teamId string, perPage int) iter.Seq2[*Post, error] {
+	return paginatePostList(perPage, func(page, perPage int) (*PostList, error) {
+		list, _, err := c.GetFlaggedPostsForUserInTeam(ctx, userId, teamId, page, perPage)
+		return list, err
+	})
+}
+
+// GetFlaggedPostsForUserInChannelIter iterates over the items of all the pages returned by GetFlaggedPostsForUserInChannel,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetFlaggedPostsForUserInChannelIter(ctx context.Context, userId string, channelId string, perPage int) iter.Seq2[*Post, error] {
+	return paginatePostList(perPage, func(page, perPage int) (*PostList, error) {
+		list, _, err := c.GetFlaggedPostsForUserInChannel(ctx, userId, channelId, page, perPage)
+		return list, err
+	})
+}
+
+// GetPostsAfterIter iterates over the items of all the pages returned by GetPostsAfter,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetPostsAfterIter(ctx context.Context, channelId string, postId string, perPage int, collapsedThreads bool, includeDeleted bool) iter.Seq2[*Post, error] {
+	return paginatePostList(perPage, func(page, perPage int) (*PostList, error) {
+		list, _, err := c.GetPostsAfter(ctx, channelId, postId, page, perPage, "", collapsedThreads, includeDeleted)
+		return list, err
+	})
+}
+
+// GetPostsBeforeIter iterates over the items of all the pages returned by GetPostsBefore,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetPostsBeforeIter(ctx context.Context, channelId string, postId string, perPage int, collapsedThreads bool, includeDeleted bool) iter.Seq2[*Post, error] {
+	return paginatePostList(perPage, func(page, perPage int) (*PostList, error) {
+		list, _, err := c.GetPostsBefore(ctx, channelId, postId, page, perPage, "", collapsedThreads, includeDeleted)
+		return list, err
+	})
+}
+
+// GetAuditsIter iterates over the items of all the pages returned by GetAudits,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetAuditsIter(ctx context.Context, perPage int) iter.Seq2[Audit, error] {
+	return paginate(perPage, func(page, perPage int) ([]Audit, error) {
+		list, _, err := c.GetAudits(ctx, page, perPage, "")
+		return list, err
+	})
+}
+
+// GetTeamsForSchemeIter iterates over the items of all the pages returned by GetTeamsForScheme,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetTeamsForSchemeIter(ctx context.Context, schemeId string, perPage int) iter.Seq2[*Team, error] {
+	return paginate(perPage, func(page, perPage int) ([]*Team, error) {
+		list, _, err := c.GetTeamsForScheme(ctx, schemeId, page, perPage)
+		return list, err
+	})
+}
+
+// GetChannelsForSchemeIter iterates over the items of all the pages returned by GetChannelsForScheme,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetChannelsForSchemeIter(ctx context.Context, schemeId string, perPage int) iter.Seq2[*Channel, error] {
+	return paginate(perPage, func(page, perPage int) ([]*Channel, error) {
+		list, _, err := c.GetChannelsForScheme(ctx, schemeId, page, perPage)
+		return list, err
+	})
+}
+
+// GetUsersWithInvalidEmailsIter iterates over the items of all the pages returned by GetUsersWithInvalidEmails,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetUsersWithInvalidEmailsIter(ctx context.Context, perPage int) iter.Seq2[*User, error] {
+	return paginate(perPage, func(page, perPage int) ([]*User, error) {
+		list, _, err := c.GetUsersWithInvalidEmails(ctx, page, perPage)
+		return list, err
+	})
+}
+
+// GetPostsForViewIter iterates over the items of all the pages returned by GetPostsForView,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetPostsForViewIter(ctx context.Context, channelId string, viewId string, perPage int) iter.Seq2[*Post, error] {
+	return paginatePostList(perPage, func(page, perPage int) (*PostList, error) {
+		list, _, err := c.GetPostsForView(ctx, channelId, viewId, page, perPage)
+		return list, err
+	})
+}
+
+// GetPostsForCalendarViewIter iterates over the items of all the pages returned by GetPostsForCalendarView,
+// requesting the pages of perPage items one after the other.
+func (c *Client4) GetPostsForCalendarViewIter(ctx context.Context, channelId string, viewId string, start int64, end int64, perPage int) iter.Seq2[*Post, error] {
+	return paginatePostList(perPage, func(page, perPage int) (*PostList, error) {
+		list, _, err := c.GetPostsForCalendarView(ctx, channelId, viewId, start, end, page, perPage)
+		return list, err
+	})
+}
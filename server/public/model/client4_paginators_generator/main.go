@@ -0,0 +1,244 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// The client4_paginators_generator generates the iterators over the pages of the paged
+// list methods of Client4, which it reads from client4.go. It is run by the go:generate
+// directive of that file.
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+const (
+	clientFile = "client4.go"
+	outputFile = "client4_paginators_generated.go"
+)
+
+// paginatedTypes are the types of the items of the paged list methods to generate iterators for.
+var paginatedTypes = []string{
+	"Audit",
+	"Channel",
+	"ChannelWithTeamData",
+	"Post",
+	"Team",
+	"User",
+}
+
+type param struct {
+	Name string
+	Type string
+}
+
+type paginator struct {
+	Method string
+	Params []param
+	// Args are the arguments of the call to the paged list method.
+	Args []string
+	// ItemType is the type of the items of the pages.
+	ItemType string
+	// PostList is set when the pages are post lists rather than slices.
+	PostList bool
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != outputFile
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pkg, ok := pkgs["model"]
+	if !ok {
+		log.Fatal("the model package was not found, the generator must be run from its directory")
+	}
+
+	// The named slice types, like Audits, are resolved to their element type.
+	namedTypes := map[string]ast.Expr{}
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				namedTypes[typeSpec.Name.Name] = typeSpec.Type
+			}
+		}
+	}
+
+	var file *ast.File
+	for name, f := range pkg.Files {
+		if name == clientFile {
+			file = f
+		}
+	}
+	if file == nil {
+		log.Fatalf("%s was not found", clientFile)
+	}
+
+	var paginators []paginator
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || !isClient4Method(funcDecl) || !funcDecl.Name.IsExported() {
+			continue
+		}
+
+		p, ok := makePaginator(fset, funcDecl, namedTypes)
+		if !ok {
+			continue
+		}
+		paginators = append(paginators, p)
+	}
+
+	var buf bytes.Buffer
+	if err := outputTemplate.Execute(&buf, paginators); err != nil {
+		log.Fatal(err)
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format the generated code: %s\n%s", err, buf.String())
+	}
+	if err := os.WriteFile(outputFile, out, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func isClient4Method(funcDecl *ast.FuncDecl) bool {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) != 1 {
+		return false
+	}
+	star, ok := funcDecl.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	return ok && ident.Name == "Client4"
+}
+
+// makePaginator returns the paginator of a method taking page and perPage parameters and
+// returning a page of items of the paginated types, a response and an error.
+func makePaginator(fset *token.FileSet, funcDecl *ast.FuncDecl, namedTypes map[string]ast.Expr) (paginator, bool) {
+	p := paginator{Method: funcDecl.Name.Name}
+
+	results := funcDecl.Type.Results
+	if results == nil || len(results.List) != 3 || exprString(fset, results.List[1].Type) != "*Response" || exprString(fset, results.List[2].Type) != "error" {
+		return p, false
+	}
+
+	if exprString(fset, results.List[0].Type) == "*PostList" {
+		p.PostList = true
+		p.ItemType = "*Post"
+	} else {
+		p.ItemType = sliceItemType(fset, results.List[0].Type, namedTypes)
+	}
+	if !slices.Contains(paginatedTypes, strings.TrimPrefix(p.ItemType, "*")) {
+		return p, false
+	}
+
+	var hasPage, hasPerPage bool
+	for _, field := range funcDecl.Type.Params.List {
+		typ := exprString(fset, field.Type)
+		for _, name := range field.Names {
+			switch {
+			case name.Name == "page" && typ == "int":
+				// The iterators request the pages one after the other.
+				hasPage = true
+				p.Args = append(p.Args, "page")
+				continue
+			case name.Name == "etag" && typ == "string":
+				// Etags are meaningless across pages.
+				p.Args = append(p.Args, `""`)
+				continue
+			case name.Name == "perPage" && typ == "int":
+				hasPerPage = true
+			}
+
+			p.Params = append(p.Params, param{Name: name.Name, Type: typ})
+			p.Args = append(p.Args, name.Name)
+		}
+	}
+
+	return p, hasPage && hasPerPage
+}
+
+func sliceItemType(fset *token.FileSet, expr ast.Expr, namedTypes map[string]ast.Expr) string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		if underlying, ok := namedTypes[ident.Name]; ok {
+			expr = underlying
+		}
+	}
+
+	arrayType, ok := expr.(*ast.ArrayType)
+	if !ok || arrayType.Len != nil {
+		return ""
+	}
+	return exprString(fset, arrayType.Elt)
+}
+
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		panic(err)
+	}
+	return buf.String()
+}
+
+var outputTemplate = template.Must(template.New("paginators").Funcs(template.FuncMap{
+	"params": func(params []param) string {
+		var s []string
+		for _, p := range params {
+			s = append(s, p.Name+" "+p.Type)
+		}
+		return strings.Join(s, ", ")
+	},
+	"join": func(args []string) string {
+		return strings.Join(args, ", ")
+	},
+	"paginate": func(p paginator) string {
+		if p.PostList {
+			return "paginatePostList"
+		}
+		return "paginate"
+	},
+	"pageType": func(p paginator) string {
+		if p.PostList {
+			return "*PostList"
+		}
+		return "[]" + p.ItemType
+	},
+}).Parse(`// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make client4-paginators"
+// DO NOT EDIT
+
+package model
+
+import (
+	"context"
+	"iter"
+)
+{{range .}}
+// {{.Method}}Iter iterates over the items of all the pages returned by {{.Method}},
+// requesting the pages of perPage items one after the other.
+func (c *Client4) {{.Method}}Iter({{params .Params}}) iter.Seq2[{{.ItemType}}, error] {
+	return {{paginate .}}(perPage, func(page, perPage int) ({{pageType .}}, error) {
+		list, _, err := c.{{.Method}}({{join .Args}})
+		return list, err
+	})
+}
+{{end}}`))
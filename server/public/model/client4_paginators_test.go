@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestClient4Paginators(t *testing.T) {
+	// newServer starts a server paginating the given number of items with encode,
+	// and counting the requests.
+	newServer := func(t *testing.T, nItems int, encode func(ids []string) any) (*httptest.Server, *atomic.Int32) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+
+			page, err := strconv.Atoi(r.URL.Query().Get("page"))
+			require.NoError(t, err)
+			perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+			require.NoError(t, err)
+			assert.Empty(t, r.Header.Get(model.HeaderEtagClient))
+
+			if page < 0 || perPage <= 0 || perPage > 200 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			ids := []string{}
+			for i := page * perPage; i < min((page+1)*perPage, nItems); i++ {
+				ids = append(ids, fmt.Sprintf("id%d", i))
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(encode(ids)))
+		}))
+		t.Cleanup(server.Close)
+		return server, &requests
+	}
+
+	encodeUsers := func(ids []string) any {
+		users := []*model.User{}
+		for _, id := range ids {
+			users = append(users, &model.User{Id: id})
+		}
+		return users
+	}
+
+	t.Run("all the items", func(t *testing.T) {
+		server, requests := newServer(t, 5, encodeUsers)
+		client := model.NewAPIv4Client(server.URL)
+
+		var ids []string
+		for user, err := range client.GetUsersInTeamIter(context.Background(), "teamid", 2) {
+			require.NoError(t, err)
+			ids = append(ids, user.Id)
+		}
+		assert.Equal(t, []string{"id0", "id1", "id2", "id3", "id4"}, ids)
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("last page full", func(t *testing.T) {
+		server, requests := newServer(t, 4, encodeUsers)
+		client := model.NewAPIv4Client(server.URL)
+
+		var count int
+		for _, err := range client.GetUsersIter(context.Background(), 2) {
+			require.NoError(t, err)
+			count++
+		}
+		assert.Equal(t, 4, count)
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("stops requesting pages when the loop breaks", func(t *testing.T) {
+		server, requests := newServer(t, 10, encodeUsers)
+		client := model.NewAPIv4Client(server.URL)
+
+		for user, err := range client.GetUsersIter(context.Background(), 2) {
+			require.NoError(t, err)
+			if user.Id == "id2" {
+				break
+			}
+		}
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("per page brought within the range of the server", func(t *testing.T) {
+		server, requests := newServer(t, 250, encodeUsers)
+		client := model.NewAPIv4Client(server.URL)
+
+		var count int
+		for _, err := range client.GetUsersIter(context.Background(), 1000) {
+			require.NoError(t, err)
+			count++
+		}
+		assert.Equal(t, 250, count)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+		client := model.NewAPIv4Client(server.URL)
+
+		var errs []error
+		for user, err := range client.GetAuditsIter(context.Background(), 2) {
+			assert.Empty(t, user)
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		require.Error(t, errs[0])
+	})
+
+	t.Run("posts", func(t *testing.T) {
+		server, requests := newServer(t, 3, func(ids []string) any {
+			list := model.NewPostList()
+			for _, id := range ids {
+				list.AddPost(&model.Post{Id: id})
+				list.AddOrder(id)
+			}
+			return list
+		})
+		client := model.NewAPIv4Client(server.URL)
+
+		var ids []string
+		for post, err := range client.GetPostsForChannelIter(context.Background(), "channelid", 2, false, false) {
+			require.NoError(t, err)
+			ids = append(ids, post.Id)
+		}
+		assert.Equal(t, []string{"id0", "id1", "id2"}, ids)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy makes a Client4 retry the requests rejected because the server is rate limiting
+// the client or is unavailable.
+//
+// Requests answered with 429 Too Many Requests are retried whatever their method, as the
+// server rejected them without processing them. Requests that failed with a network error or
+// were answered with 502 Bad Gateway, 503 Service Unavailable or 504 Gateway Timeout may have
+// been processed, so they are only retried when their method is idempotent. The delay given
+// by the Retry-After header of the response is honoured, and a jittered exponential backoff
+// is used otherwise. Requests whose body can't be read again, like streamed uploads, are not retried.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times a request is retried.
+	MaxRetries int
+	// MinDelay and MaxDelay bound the exponential backoff between retries. Each delay is
+	// jittered between half of its value and its value.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy recommended for integrations.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: 3,
+		MinDelay:   500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// SetRetryPolicy opts the client into retrying the failed requests with the given policy.
+// A nil policy disables the retries, which is the default.
+func (c *Client4) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// isIdempotentMethod returns whether sending a request with the method several times has the
+// same effect as sending it once.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryDelay returns the delay before retrying a request after the given number of retries,
+// and false if the request should not be retried.
+func (p *RetryPolicy) retryDelay(rq *http.Request, rp *http.Response, err error, retries int) (time.Duration, bool) {
+	if retries >= p.MaxRetries || rq.Context().Err() != nil {
+		return 0, false
+	}
+
+	// The body is read again from GetBody on retries.
+	if rq.Body != nil && rq.Body != http.NoBody && rq.GetBody == nil {
+		return 0, false
+	}
+
+	switch {
+	case err != nil:
+		if !isIdempotentMethod(rq.Method) {
+			return 0, false
+		}
+	case rp.StatusCode == http.StatusTooManyRequests:
+		if delay, ok := parseRetryAfter(rp.Header.Get("Retry-After")); ok {
+			return delay, true
+		}
+	case rp.StatusCode == http.StatusBadGateway, rp.StatusCode == http.StatusServiceUnavailable, rp.StatusCode == http.StatusGatewayTimeout:
+		if !isIdempotentMethod(rq.Method) {
+			return 0, false
+		}
+		if delay, ok := parseRetryAfter(rp.Header.Get("Retry-After")); ok {
+			return delay, true
+		}
+	default:
+		return 0, false
+	}
+
+	return p.backoff(retries), true
+}
+
+// backoff returns the jittered delay before the retry following the given number of retries.
+func (p *RetryPolicy) backoff(retries int) time.Duration {
+	delay := max(p.MinDelay, 0)
+	for range retries {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			delay = p.MaxDelay
+			break
+		}
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, either a number of seconds or a date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// do sends a request, retrying it according to the retry policy of the client.
+func (c *Client4) do(rq *http.Request) (*http.Response, error) {
+	policy := c.retryPolicy
+	if policy == nil {
+		return c.HTTPClient.Do(rq)
+	}
+
+	for retries := 0; ; retries++ {
+		rp, err := c.HTTPClient.Do(rq)
+
+		delay, retry := policy.retryDelay(rq, rp, err, retries)
+		if !retry {
+			return rp, err
+		}
+		if rp != nil {
+			closeBody(rp)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-rq.Context().Done():
+			timer.Stop()
+			return nil, rq.Context().Err()
+		}
+
+		rq = rq.Clone(rq.Context())
+		if rq.GetBody != nil {
+			body, err := rq.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			rq.Body = body
+		}
+	}
+}
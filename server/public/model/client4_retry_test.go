@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestClient4RetryPolicy(t *testing.T) {
+	// newServer starts a server answering the first failures requests with status,
+	// then answering with the users, and counting the requests.
+	newServer := func(t *testing.T, status int, header http.Header, failures int32) (*httptest.Server, *atomic.Int32) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, `["userid"]`, string(body))
+			}
+
+			if requests.Add(1) <= failures {
+				for key, values := range header {
+					w.Header()[key] = values
+				}
+				w.WriteHeader(status)
+				return
+			}
+			w.Write([]byte(`[{"id":"userid"}]`))
+		}))
+		t.Cleanup(server.Close)
+		return server, &requests
+	}
+
+	newClient := func(url string) *model.Client4 {
+		client := model.NewAPIv4Client(url)
+		client.SetRetryPolicy(&model.RetryPolicy{
+			MaxRetries: 2,
+			MinDelay:   time.Millisecond,
+			MaxDelay:   10 * time.Millisecond,
+		})
+		return client
+	}
+
+	t.Run("no retries by default", func(t *testing.T) {
+		server, requests := newServer(t, http.StatusServiceUnavailable, nil, 1)
+		client := model.NewAPIv4Client(server.URL)
+
+		_, resp, err := client.GetUsers(context.Background(), 0, 60, "")
+		require.Error(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("idempotent request retried when unavailable", func(t *testing.T) {
+		server, requests := newServer(t, http.StatusServiceUnavailable, nil, 2)
+		client := newClient(server.URL)
+
+		users, _, err := client.GetUsers(context.Background(), 0, 60, "")
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("retries limited to the maximum", func(t *testing.T) {
+		server, requests := newServer(t, http.StatusBadGateway, nil, 5)
+		client := newClient(server.URL)
+
+		_, resp, err := client.GetUsers(context.Background(), 0, 60, "")
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("non idempotent request not retried when unavailable", func(t *testing.T) {
+		server, requests := newServer(t, http.StatusServiceUnavailable, nil, 1)
+		client := newClient(server.URL)
+
+		_, resp, err := client.GetUsersByIds(context.Background(), []string{"userid"})
+		require.Error(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("non idempotent request retried with its body when rate limited", func(t *testing.T) {
+		server, requests := newServer(t, http.StatusTooManyRequests, nil, 1)
+		client := newClient(server.URL)
+
+		users, _, err := client.GetUsersByIds(context.Background(), []string{"userid"})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("client errors not retried", func(t *testing.T) {
+		server, requests := newServer(t, http.StatusBadRequest, nil, 1)
+		client := newClient(server.URL)
+
+		_, _, err := client.GetUsers(context.Background(), 0, 60, "")
+		require.Error(t, err)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("retry after honoured", func(t *testing.T) {
+		server, requests := newServer(t, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}}, 1)
+		client := newClient(server.URL)
+
+		start := time.Now()
+		_, _, err := client.GetUsers(context.Background(), 0, 60, "")
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("context canceled while waiting", func(t *testing.T) {
+		server, requests := newServer(t, http.StatusTooManyRequests, http.Header{"Retry-After": {"60"}}, 1)
+		client := newClient(server.URL)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, _, err := client.GetUsers(ctx, 0, 60, "")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+}
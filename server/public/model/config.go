@@ -4,6 +4,7 @@
 package model
 
 import (
+	"compress/flate"
 	"crypto/tls"
 	"encoding/json"
 	"io"
@@ -70,6 +71,10 @@ const (
 	ServiceOffice365 = "office365"
 	ServiceOpenid    = "openid"
 
+	// ServiceOpenIdProviderPrefix prefixes the name of a configured OpenID Connect provider
+	// to form the auth service of its users.
+	ServiceOpenIdProviderPrefix = "oidc"
+
 	GenericNoChannelNotification = "generic_no_channel"
 	GenericNotification          = "generic"
 	GenericNotificationServer    = "https://push-test.mattermost.com"
@@ -120,25 +125,35 @@ const (
 	EmailSMTPDefaultServer = "localhost"
 	EmailSMTPDefaultPort   = "10025"
 
+	MailTransportSMTP = "smtp"
+	MailTransportHTTP = "http"
+
+	EmailMailQueueDefaultMaxAttempts = 10
+
 	CacheTypeLRU   = "lru"
 	CacheTypeRedis = "redis"
 
+	RateLimitStoreTypeMemory = "memory"
+	RateLimitStoreTypeRedis  = "redis"
+
 	SitenameMaxLength = 30
 
-	ServiceSettingsDefaultSiteURL                = "http://localhost:8065"
-	ServiceSettingsDefaultTLSCertFile            = ""
-	ServiceSettingsDefaultTLSKeyFile             = ""
-	ServiceSettingsDefaultReadTimeout            = 300
-	ServiceSettingsDefaultWriteTimeout           = 300
-	ServiceSettingsDefaultIdleTimeout            = 60
-	ServiceSettingsDefaultMaxLoginAttempts       = 10
-	ServiceSettingsDefaultAllowCorsFrom          = ""
-	ServiceSettingsDefaultListenAndAddress       = ":8065"
-	ServiceSettingsDefaultGiphySdkKeyTest        = "s0glxvzVg9azvPipKxcPLpXV0q1x1fVP"
-	ServiceSettingsDefaultDeveloperFlags         = ""
-	ServiceSettingsDefaultUniqueReactionsPerPost = 50
-	ServiceSettingsDefaultMaxURLLength           = 2048
-	ServiceSettingsMaxUniqueReactionsPerPost     = 500
+	ServiceSettingsDefaultSiteURL                            = "http://localhost:8065"
+	ServiceSettingsDefaultTLSCertFile                        = ""
+	ServiceSettingsDefaultTLSKeyFile                         = ""
+	ServiceSettingsDefaultReadTimeout                        = 300
+	ServiceSettingsDefaultWriteTimeout                       = 300
+	ServiceSettingsDefaultIdleTimeout                        = 60
+	ServiceSettingsDefaultMaxLoginAttempts                   = 10
+	ServiceSettingsDefaultAllowCorsFrom                      = ""
+	ServiceSettingsDefaultListenAndAddress                   = ":8065"
+	ServiceSettingsDefaultGiphySdkKeyTest                    = "s0glxvzVg9azvPipKxcPLpXV0q1x1fVP"
+	ServiceSettingsDefaultDeveloperFlags                     = ""
+	ServiceSettingsDefaultUniqueReactionsPerPost             = 50
+	ServiceSettingsDefaultMaxURLLength                       = 2048
+	ServiceSettingsDefaultWebSocketCompressionThresholdBytes = 1024
+	ServiceSettingsDefaultWebSocketCompressionLevel          = flate.BestSpeed
+	ServiceSettingsMaxUniqueReactionsPerPost                 = 500
 
 	TeamSettingsDefaultSiteName              = "Mattermost"
 	TeamSettingsDefaultMaxUsersPerTeam       = 50
@@ -260,6 +275,7 @@ const (
 	DataRetentionSettingsDefaultRetentionIdsBatchSize          = 100
 
 	OutgoingIntegrationRequestsDefaultTimeout = 30
+	OutgoingWebhookDefaultMaxAttempts         = 5
 
 	PluginSettingsDefaultDirectory          = "./plugins"
 	PluginSettingsDefaultClientDirectory    = "./client/plugins"
@@ -285,6 +301,11 @@ const (
 	ImageProxyTypeLocal     = "local"
 	ImageProxyTypeAtmosCamo = "atmos/camo"
 
+	FileScanTypeClamd = "clamd"
+	FileScanTypeICAP  = "icap"
+
+	FileScanSettingsDefaultTimeoutSeconds = 60
+
 	GoogleSettingsDefaultScope           = "profile email"
 	GoogleSettingsDefaultAuthEndpoint    = "https://accounts.google.com/o/oauth2/v2/auth"
 	GoogleSettingsDefaultTokenEndpoint   = "https://www.googleapis.com/oauth2/v4/token"
@@ -302,6 +323,17 @@ const (
 
 	OpenidSettingsDefaultScope = "profile openid email"
 
+	OpenIdProviderScope                         = "openid"
+	OpenIdProviderSettingsDefaultScope          = "openid profile email"
+	OpenIdProviderSettingsDefaultButtonColor    = "#145DBF"
+	OpenIdProviderSettingsDefaultIdClaim        = "sub"
+	OpenIdProviderSettingsDefaultEmailClaim     = "email"
+	OpenIdProviderSettingsDefaultUsernameClaim  = "preferred_username"
+	OpenIdProviderSettingsDefaultFirstNameClaim = "given_name"
+	OpenIdProviderSettingsDefaultLastNameClaim  = "family_name"
+	OpenIdProviderSettingsDefaultNicknameClaim  = "nickname"
+	OpenIdProviderNameMaxLength                 = 24
+
 	LocalModeSocketPath = "/var/tmp/mattermost_local.socket"
 
 	ConnectedWorkspacesSettingsDefaultMaxPostsPerSync     = 50 // a bit more than 4 typical screenfulls of posts
@@ -386,6 +418,7 @@ type ServiceSettings struct {
 	EnableOutgoingOAuthConnections         *bool    `access:"integrations_integration_management"`
 	EnableCommands                         *bool    `access:"integrations_integration_management"`
 	OutgoingIntegrationRequestsTimeout     *int64   `access:"integrations_integration_management"` // In seconds.
+	OutgoingWebhookMaxAttempts             *int     `access:"integrations_integration_management"`
 	EnablePostUsernameOverride             *bool    `access:"integrations_integration_management"`
 	EnablePostIconOverride                 *bool    `access:"integrations_integration_management"`
 	GoogleDeveloperKey                     *string  `access:"site_posts,write_restrictable,cloud_restrictable"`
@@ -488,6 +521,9 @@ type ServiceSettings struct {
 	MaximumURLLength                                  *int    `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
 	ScheduledPosts                                    *bool   `access:"site_posts"`
 	EnableWebHubChannelIteration                      *bool   `access:"write_restrictable,cloud_restrictable"` // telemetry: none
+	EnableWebSocketCompression                        *bool   `access:"environment_web_server,write_restrictable,cloud_restrictable"`
+	WebSocketCompressionThresholdBytes                *int    `access:"environment_web_server,write_restrictable,cloud_restrictable"`
+	WebSocketCompressionLevel                         *int    `access:"environment_web_server,write_restrictable,cloud_restrictable"`
 	FrameAncestors                                    *string `access:"write_restrictable,cloud_restrictable"` // telemetry: none
 	DeleteAccountLink                                 *string `access:"site_users_and_teams,write_restrictable,cloud_restrictable"`
 }
@@ -616,6 +652,10 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.OutgoingIntegrationRequestsTimeout = new(int64(OutgoingIntegrationRequestsDefaultTimeout))
 	}
 
+	if s.OutgoingWebhookMaxAttempts == nil {
+		s.OutgoingWebhookMaxAttempts = new(OutgoingWebhookDefaultMaxAttempts)
+	}
+
 	if s.ConnectionSecurity == nil {
 		s.ConnectionSecurity = new("")
 	}
@@ -1058,6 +1098,18 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnableWebHubChannelIteration = new(false)
 	}
 
+	if s.EnableWebSocketCompression == nil {
+		s.EnableWebSocketCompression = new(false)
+	}
+
+	if s.WebSocketCompressionThresholdBytes == nil {
+		s.WebSocketCompressionThresholdBytes = new(ServiceSettingsDefaultWebSocketCompressionThresholdBytes)
+	}
+
+	if s.WebSocketCompressionLevel == nil {
+		s.WebSocketCompressionLevel = new(ServiceSettingsDefaultWebSocketCompressionLevel)
+	}
+
 	if s.FrameAncestors == nil {
 		s.FrameAncestors = new("")
 	}
@@ -1442,6 +1494,193 @@ func (s *Office365Settings) SSOSettings() *SSOSettings {
 	return &ssoSettings
 }
 
+// OpenIdProviderSettings configures one of the named OpenID Connect providers users can sign in with.
+// Claims are looked up by name in the ID token and the user info of the provider, and nested claims
+// can be reached with a dotted path such as "address.country".
+type OpenIdProviderSettings struct {
+	Name              *string `access:"authentication_openid"` // telemetry: none
+	Enable            *bool   `access:"authentication_openid"`
+	ButtonText        *string `access:"authentication_openid"` // telemetry: none
+	ButtonColor       *string `access:"authentication_openid"` // telemetry: none
+	Id                *string `access:"authentication_openid"` // telemetry: none
+	Secret            *string `access:"authentication_openid"` // telemetry: none
+	DiscoveryEndpoint *string `access:"authentication_openid"` // telemetry: none
+	Scope             *string `access:"authentication_openid"` // telemetry: none
+	UsePKCE           *bool   `access:"authentication_openid"`
+	IdClaim           *string `access:"authentication_openid"` // telemetry: none
+	EmailClaim        *string `access:"authentication_openid"` // telemetry: none
+	UsernameClaim     *string `access:"authentication_openid"` // telemetry: none
+	FirstNameClaim    *string `access:"authentication_openid"` // telemetry: none
+	LastNameClaim     *string `access:"authentication_openid"` // telemetry: none
+	NicknameClaim     *string `access:"authentication_openid"` // telemetry: none
+	PositionClaim     *string `access:"authentication_openid"` // telemetry: none
+	// GroupsClaim holds the names of the groups of the user, which are synchronized
+	// to groups of source GroupSourceOpenIdPrefix followed by the provider name.
+	GroupsClaim *string `access:"authentication_openid"` // telemetry: none
+	// AttributeClaims maps the names of custom profile attributes to the claims they are set from.
+	AttributeClaims map[string]string `access:"authentication_openid"` // telemetry: none
+}
+
+func (s *OpenIdProviderSettings) SetDefaults() {
+	if s.Name == nil {
+		s.Name = new("")
+	}
+
+	if s.Enable == nil {
+		s.Enable = new(false)
+	}
+
+	if s.ButtonText == nil {
+		s.ButtonText = new("")
+	}
+
+	if s.ButtonColor == nil {
+		s.ButtonColor = new(OpenIdProviderSettingsDefaultButtonColor)
+	}
+
+	if s.Id == nil {
+		s.Id = new("")
+	}
+
+	if s.Secret == nil {
+		s.Secret = new("")
+	}
+
+	if s.DiscoveryEndpoint == nil {
+		s.DiscoveryEndpoint = new("")
+	}
+
+	if s.Scope == nil {
+		s.Scope = new(OpenIdProviderSettingsDefaultScope)
+	}
+
+	if s.UsePKCE == nil {
+		s.UsePKCE = new(true)
+	}
+
+	if s.IdClaim == nil {
+		s.IdClaim = new(OpenIdProviderSettingsDefaultIdClaim)
+	}
+
+	if s.EmailClaim == nil {
+		s.EmailClaim = new(OpenIdProviderSettingsDefaultEmailClaim)
+	}
+
+	if s.UsernameClaim == nil {
+		s.UsernameClaim = new(OpenIdProviderSettingsDefaultUsernameClaim)
+	}
+
+	if s.FirstNameClaim == nil {
+		s.FirstNameClaim = new(OpenIdProviderSettingsDefaultFirstNameClaim)
+	}
+
+	if s.LastNameClaim == nil {
+		s.LastNameClaim = new(OpenIdProviderSettingsDefaultLastNameClaim)
+	}
+
+	if s.NicknameClaim == nil {
+		s.NicknameClaim = new(OpenIdProviderSettingsDefaultNicknameClaim)
+	}
+
+	if s.PositionClaim == nil {
+		s.PositionClaim = new("")
+	}
+
+	if s.GroupsClaim == nil {
+		s.GroupsClaim = new("")
+	}
+
+	if s.AttributeClaims == nil {
+		s.AttributeClaims = map[string]string{}
+	}
+}
+
+// Service returns the auth service of the users signing in with the provider.
+func (s *OpenIdProviderSettings) Service() string {
+	return ServiceOpenIdProviderPrefix + SafeDereference(s.Name)
+}
+
+// GroupSource returns the source of the groups synchronized from the groups claim of the provider.
+func (s *OpenIdProviderSettings) GroupSource() GroupSource {
+	return GroupSourceOpenIdPrefix + GroupSource(SafeDereference(s.Name))
+}
+
+// SSOSettings returns the settings of the provider for the OAuth flow. The endpoints
+// are not part of them, as they are read from the discovery document of the provider.
+func (s *OpenIdProviderSettings) SSOSettings() *SSOSettings {
+	ssoSettings := SSOSettings{}
+	ssoSettings.Enable = s.Enable
+	ssoSettings.Secret = s.Secret
+	ssoSettings.Id = s.Id
+	ssoSettings.Scope = s.Scope
+	ssoSettings.DiscoveryEndpoint = s.DiscoveryEndpoint
+	ssoSettings.AuthEndpoint = new("")
+	ssoSettings.TokenEndpoint = new("")
+	ssoSettings.UserAPIEndpoint = new("")
+	ssoSettings.ButtonText = s.ButtonText
+	ssoSettings.ButtonColor = s.ButtonColor
+	ssoSettings.UsePreferredUsername = new(false)
+	return &ssoSettings
+}
+
+func (s *OpenIdProviderSettings) isValid() *AppError {
+	if !IsOpenIdProviderService(s.Service()) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_name.app_error", map[string]any{"Name": *s.Name, "MaxLength": OpenIdProviderNameMaxLength}, "", http.StatusBadRequest)
+	}
+
+	if !*s.Enable {
+		return nil
+	}
+
+	if *s.Id == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_id.app_error", map[string]any{"Name": *s.Name}, "", http.StatusBadRequest)
+	}
+
+	if !IsValidHTTPURL(*s.DiscoveryEndpoint) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_discovery_endpoint.app_error", map[string]any{"Name": *s.Name}, "", http.StatusBadRequest)
+	}
+
+	if !slices.Contains(strings.Fields(*s.Scope), OpenIdProviderScope) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_scope.app_error", map[string]any{"Name": *s.Name}, "", http.StatusBadRequest)
+	}
+
+	if *s.IdClaim == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_id_claim.app_error", map[string]any{"Name": *s.Name}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+type OpenIdProvidersSettings struct {
+	Providers []*OpenIdProviderSettings `access:"authentication_openid"` // telemetry: none
+}
+
+func (s *OpenIdProvidersSettings) SetDefaults() {
+	if s.Providers == nil {
+		s.Providers = []*OpenIdProviderSettings{}
+	}
+
+	for _, provider := range s.Providers {
+		provider.SetDefaults()
+	}
+}
+
+func (s *OpenIdProvidersSettings) isValid() *AppError {
+	names := make(map[string]bool, len(s.Providers))
+	for _, provider := range s.Providers {
+		if appErr := provider.isValid(); appErr != nil {
+			return appErr
+		}
+
+		if names[*provider.Name] {
+			return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_duplicate_name.app_error", map[string]any{"Name": *provider.Name}, "", http.StatusBadRequest)
+		}
+		names[*provider.Name] = true
+	}
+
+	return nil
+}
+
 type IntuneSettings struct {
 	Enable      *bool   `access:"environment_mobile_security"`
 	TenantId    *string `access:"environment_mobile_security"` // telemetry: none
@@ -1707,6 +1946,12 @@ type ExperimentalAuditSettings struct {
 	FileName            *string         `access:"experimental_features,write_restrictable,cloud_restrictable"` // telemetry: none
 	AdvancedLoggingJSON json.RawMessage `access:"experimental_features"`
 	Certificate         *string         `access:"experimental_features"` // telemetry: none
+
+	// HashChainEnabled links each audit record to the previous one through a hash, so that
+	// deleted or edited records can be detected with `mmctl audit verify`.
+	HashChainEnabled            *bool   `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	HashChainCheckpointInterval *int    `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	HashChainSigningKey         *string `access:"experimental_features,write_restrictable,cloud_restrictable"` // telemetry: none
 }
 
 func (s *ExperimentalAuditSettings) isValid() *AppError {
@@ -1720,6 +1965,10 @@ func (s *ExperimentalAuditSettings) isValid() *AppError {
 		}
 	}
 
+	if *s.HashChainEnabled && *s.HashChainCheckpointInterval <= 0 {
+		return NewAppError("ExperimentalAuditSettings.isValid", "model.config.is_valid.experimental_audit_settings.hash_chain_checkpoint_interval", nil, "", http.StatusBadRequest)
+	}
+
 	cfg := make(mlog.LoggerConfiguration)
 	err := json.Unmarshal(s.AdvancedLoggingJSON, &cfg)
 	if err != nil {
@@ -1750,6 +1999,18 @@ func (s *ExperimentalAuditSettings) SetDefaults() {
 	if s.Certificate == nil {
 		s.Certificate = new("")
 	}
+
+	if s.HashChainEnabled == nil {
+		s.HashChainEnabled = new(false)
+	}
+
+	if s.HashChainCheckpointInterval == nil {
+		s.HashChainCheckpointInterval = new(1000)
+	}
+
+	if s.HashChainSigningKey == nil {
+		s.HashChainSigningKey = new("")
+	}
 }
 
 // GetAdvancedLoggingConfig returns the advanced logging config as a []byte.
@@ -1813,6 +2074,7 @@ type FileSettings struct {
 	ExtractContent                     *bool   `access:"environment_file_storage,write_restrictable"`
 	ExtractContentTimeout              *int    `access:"environment_file_storage,write_restrictable"` // In seconds. 0 disables the timeout.
 	ArchiveRecursion                   *bool   `access:"environment_file_storage,write_restrictable"`
+	DeduplicateFiles                   *bool   `access:"environment_file_storage,write_restrictable"`
 	PublicLinkSalt                     *string `access:"site_public_links,cloud_restrictable"`                           // telemetry: none
 	InitialFont                        *string `access:"environment_file_storage,cloud_restrictable"`                    // telemetry: none
 	AmazonS3AccessKeyId                *string `access:"environment_file_storage,write_restrictable,cloud_restrictable"` // telemetry: none
@@ -1916,6 +2178,10 @@ func (s *FileSettings) SetDefaults(isUpdate bool) {
 		s.ArchiveRecursion = new(false)
 	}
 
+	if s.DeduplicateFiles == nil {
+		s.DeduplicateFiles = new(false)
+	}
+
 	if isUpdate {
 		// When updating an existing configuration, ensure link salt has been specified.
 		if s.PublicLinkSalt == nil || *s.PublicLinkSalt == "" {
@@ -2154,8 +2420,9 @@ type EmailSettings struct {
 	PushNotificationServer            *string `access:"environment_push_notification_server"` // telemetry: none
 	PushNotificationContents          *string `access:"site_notifications"`
 	PushNotificationBuffer            *int    // telemetry: none
+	SendWebPushNotifications          *bool   `access:"environment_push_notification_server"`
 	EnableEmailBatching               *bool   `access:"site_notifications"`
-	EmailBatchingBufferSize           *int    `access:"experimental_features"`
+	EmailBatchingBufferSize           *int    `access:"experimental_features"` // Deprecated: batched notifications are kept in the database.
 	EmailBatchingInterval             *int    `access:"experimental_features"`
 	EnablePreviewModeBanner           *bool   `access:"site_notifications"`
 	SkipServerCertificateVerification *bool   `access:"environment_smtp,write_restrictable,cloud_restrictable"`
@@ -2163,6 +2430,11 @@ type EmailSettings struct {
 	LoginButtonColor                  *string `access:"experimental_features"`
 	LoginButtonBorderColor            *string `access:"experimental_features"`
 	LoginButtonTextColor              *string `access:"experimental_features"`
+	MailTransport                     *string `access:"environment_smtp,write_restrictable,cloud_restrictable"`
+	HTTPMailTransportURL              *string `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	HTTPMailTransportAPIKey           *string `access:"environment_smtp,write_restrictable,cloud_restrictable"` // telemetry: none
+	EnableMailQueue                   *bool   `access:"environment_smtp,write_restrictable,cloud_restrictable"`
+	MailQueueMaxAttempts              *int    `access:"environment_smtp,write_restrictable,cloud_restrictable"`
 }
 
 func (s *EmailSettings) SetDefaults(isUpdate bool) {
@@ -2258,6 +2530,10 @@ func (s *EmailSettings) SetDefaults(isUpdate bool) {
 		s.PushNotificationBuffer = new(1000)
 	}
 
+	if s.SendWebPushNotifications == nil {
+		s.SendWebPushNotifications = new(false)
+	}
+
 	if s.EnableEmailBatching == nil {
 		s.EnableEmailBatching = new(false)
 	}
@@ -2305,16 +2581,59 @@ func (s *EmailSettings) SetDefaults(isUpdate bool) {
 	if s.LoginButtonTextColor == nil {
 		s.LoginButtonTextColor = new("#2389D7")
 	}
+
+	if s.MailTransport == nil {
+		s.MailTransport = new(MailTransportSMTP)
+	}
+
+	if s.HTTPMailTransportURL == nil {
+		s.HTTPMailTransportURL = new("")
+	}
+
+	if s.HTTPMailTransportAPIKey == nil {
+		s.HTTPMailTransportAPIKey = new("")
+	}
+
+	if s.EnableMailQueue == nil {
+		s.EnableMailQueue = new(false)
+	}
+
+	if s.MailQueueMaxAttempts == nil {
+		s.MailQueueMaxAttempts = new(EmailMailQueueDefaultMaxAttempts)
+	}
 }
 
 type RateLimitSettings struct {
-	Enable           *bool  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	PerSec           *int   `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	MaxBurst         *int   `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	MemoryStoreSize  *int   `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	VaryByRemoteAddr *bool  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	VaryByUser       *bool  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
-	VaryByHeader     string `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	Enable           *bool   `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	PerSec           *int    `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	MaxBurst         *int    `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	MemoryStoreSize  *int    `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	VaryByRemoteAddr *bool   `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	VaryByUser       *bool   `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	VaryByHeader     string  `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	StoreType        *string `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	// The quotas of route classes are enforced per user, or per IP address for
+	// unauthenticated requests, on top of the global quota.
+	LoginQuota      *RateLimitQuota `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	PostCreateQuota *RateLimitQuota `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	FileUploadQuota *RateLimitQuota `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	SearchQuota     *RateLimitQuota `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+}
+
+// RateLimitQuota is the quota of a class of rate limited routes.
+type RateLimitQuota struct {
+	PerSec   *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+	MaxBurst *int `access:"environment_rate_limiting,write_restrictable,cloud_restrictable"`
+}
+
+func (q *RateLimitQuota) SetDefaults(perSec, maxBurst int) {
+	if q.PerSec == nil {
+		q.PerSec = new(perSec)
+	}
+
+	if q.MaxBurst == nil {
+		q.MaxBurst = new(maxBurst)
+	}
 }
 
 func (s *RateLimitSettings) SetDefaults() {
@@ -2341,6 +2660,30 @@ func (s *RateLimitSettings) SetDefaults() {
 	if s.VaryByUser == nil {
 		s.VaryByUser = new(false)
 	}
+
+	if s.StoreType == nil {
+		s.StoreType = new(RateLimitStoreTypeMemory)
+	}
+
+	if s.LoginQuota == nil {
+		s.LoginQuota = &RateLimitQuota{}
+	}
+	s.LoginQuota.SetDefaults(5, 10)
+
+	if s.PostCreateQuota == nil {
+		s.PostCreateQuota = &RateLimitQuota{}
+	}
+	s.PostCreateQuota.SetDefaults(10, 50)
+
+	if s.FileUploadQuota == nil {
+		s.FileUploadQuota = &RateLimitQuota{}
+	}
+	s.FileUploadQuota.SetDefaults(2, 20)
+
+	if s.SearchQuota == nil {
+		s.SearchQuota = &RateLimitQuota{}
+	}
+	s.SearchQuota.SetDefaults(2, 10)
 }
 
 type PrivacySettings struct {
@@ -4033,6 +4376,41 @@ func (s *ImageProxySettings) SetDefaults() {
 	}
 }
 
+// FileScanSettings configures the scanning of uploaded files by an antivirus or
+// content scanning server, reached with the clamd or the ICAP protocol.
+type FileScanSettings struct {
+	Enable *bool   `access:"environment_file_storage"`
+	Type   *string `access:"environment_file_storage"`
+	// Address is the URL of the scanning server: tcp://host:port or unix:///path/to/socket
+	// for clamd, and icap://host:port/service for ICAP.
+	Address        *string `access:"environment_file_storage"`
+	TimeoutSeconds *int    `access:"environment_file_storage"`
+	// AllowDownloadOfFailedScans allows downloading the files the scanning server failed to scan.
+	AllowDownloadOfFailedScans *bool `access:"environment_file_storage"`
+}
+
+func (s *FileScanSettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = new(false)
+	}
+
+	if s.Type == nil {
+		s.Type = new(FileScanTypeClamd)
+	}
+
+	if s.Address == nil {
+		s.Address = new("")
+	}
+
+	if s.TimeoutSeconds == nil {
+		s.TimeoutSeconds = new(FileScanSettingsDefaultTimeoutSeconds)
+	}
+
+	if s.AllowDownloadOfFailedScans == nil {
+		s.AllowDownloadOfFailedScans = new(false)
+	}
+}
+
 // ImportSettings defines configuration settings for file imports.
 type ImportSettings struct {
 	// The directory where to store the imported files.
@@ -4186,6 +4564,7 @@ type Config struct {
 	GoogleSettings              SSOSettings
 	Office365Settings           Office365Settings
 	OpenIdSettings              SSOSettings
+	OpenIdProvidersSettings     OpenIdProvidersSettings
 	LdapSettings                LdapSettings
 	ComplianceSettings          ComplianceSettings
 	LocalizationSettings        LocalizationSettings
@@ -4206,6 +4585,7 @@ type Config struct {
 	DisplaySettings             DisplaySettings
 	GuestAccountsSettings       GuestAccountsSettings
 	ImageProxySettings          ImageProxySettings
+	FileScanSettings            FileScanSettings
 	CloudSettings               CloudSettings  // telemetry: none
 	FeatureFlags                *FeatureFlags  `access:"*_read" json:",omitempty"`
 	ImportSettings              ImportSettings // telemetry: none
@@ -4259,6 +4639,26 @@ func (o *Config) GetSSOService(service string) *SSOSettings {
 		return &o.OpenIdSettings
 	}
 
+	if provider := o.GetOpenIdProvider(service); provider != nil {
+		return provider.SSOSettings()
+	}
+
+	return nil
+}
+
+// GetOpenIdProvider returns the settings of the OpenID Connect provider
+// whose users have the given auth service, or nil if there is none.
+func (o *Config) GetOpenIdProvider(service string) *OpenIdProviderSettings {
+	if !IsOpenIdProviderService(service) {
+		return nil
+	}
+
+	for _, provider := range o.OpenIdProvidersSettings.Providers {
+		if provider.Service() == service {
+			return provider
+		}
+	}
+
 	return nil
 }
 
@@ -4296,6 +4696,7 @@ func (o *Config) SetDefaults() {
 	o.GitLabSettings.setDefaults("", "", "", "", "")
 	o.GoogleSettings.setDefaults(GoogleSettingsDefaultScope, GoogleSettingsDefaultAuthEndpoint, GoogleSettingsDefaultTokenEndpoint, GoogleSettingsDefaultUserAPIEndpoint, "")
 	o.OpenIdSettings.setDefaults(OpenidSettingsDefaultScope, "", "", "", "#145DBF")
+	o.OpenIdProvidersSettings.SetDefaults()
 	o.ServiceSettings.SetDefaults(isUpdate)
 	o.PasswordSettings.SetDefaults()
 	o.TeamSettings.SetDefaults()
@@ -4324,6 +4725,7 @@ func (o *Config) SetDefaults() {
 	o.DisplaySettings.SetDefaults()
 	o.GuestAccountsSettings.SetDefaults()
 	o.ImageProxySettings.SetDefaults()
+	o.FileScanSettings.SetDefaults()
 	o.CloudSettings.SetDefaults()
 	if o.FeatureFlags == nil {
 		o.FeatureFlags = &FeatureFlags{}
@@ -4342,10 +4744,6 @@ func (o *Config) IsValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.site_url_email_batching.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if *o.ClusterSettings.Enable && *o.EmailSettings.EnableEmailBatching {
-		return NewAppError("Config.IsValid", "model.config.is_valid.cluster_email_batching.app_error", nil, "", http.StatusBadRequest)
-	}
-
 	if appErr := o.MetricsSettings.isValid(); appErr != nil {
 		return appErr
 	}
@@ -4386,6 +4784,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.OpenIdProvidersSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	// Validate IntuneSettings
 	if appErr := o.IntuneSettings.IsValid(); appErr != nil {
 		return appErr
@@ -4423,6 +4825,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if *o.RateLimitSettings.StoreType == RateLimitStoreTypeRedis && *o.CacheSettings.CacheType != CacheTypeRedis {
+		return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_store_requires_redis.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if appErr := o.ServiceSettings.isValid(); appErr != nil {
 		return appErr
 	}
@@ -4463,6 +4869,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.FileScanSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	if appErr := o.ImportSettings.isValid(); appErr != nil {
 		return appErr
 	}
@@ -4729,10 +5139,6 @@ func (s *EmailSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.email_security.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if *s.EmailBatchingBufferSize <= 0 {
-		return NewAppError("Config.IsValid", "model.config.is_valid.email_batching_buffer_size.app_error", nil, "", http.StatusBadRequest)
-	}
-
 	if *s.EmailBatchingInterval < 30 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.email_batching_interval.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -4741,6 +5147,20 @@ func (s *EmailSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.email_notification_contents_type.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	switch *s.MailTransport {
+	case MailTransportSMTP:
+	case MailTransportHTTP:
+		if !IsValidHTTPURL(*s.HTTPMailTransportURL) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.http_mail_transport_url.app_error", nil, "", http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.mail_transport.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.MailQueueMaxAttempts <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.mail_queue_max_attempts.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -4757,6 +5177,25 @@ func (s *RateLimitSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_burst.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.StoreType != RateLimitStoreTypeMemory && *s.StoreType != RateLimitStoreTypeRedis {
+		return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_store_type.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	quotas := []struct {
+		name  string
+		quota *RateLimitQuota
+	}{
+		{"LoginQuota", s.LoginQuota},
+		{"PostCreateQuota", s.PostCreateQuota},
+		{"FileUploadQuota", s.FileUploadQuota},
+		{"SearchQuota", s.SearchQuota},
+	}
+	for _, q := range quotas {
+		if *q.quota.PerSec <= 0 || *q.quota.MaxBurst < 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.rate_limit_quota.app_error", map[string]any{"Name": q.name}, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -4938,6 +5377,14 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.max_url_length.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.WebSocketCompressionThresholdBytes < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.websocket_compression_threshold.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.WebSocketCompressionLevel < flate.HuffmanOnly || *s.WebSocketCompressionLevel > flate.BestCompression {
+		return NewAppError("Config.IsValid", "model.config.is_valid.websocket_compression_level.app_error", map[string]any{"Min": flate.HuffmanOnly, "Max": flate.BestCompression}, "", http.StatusBadRequest)
+	}
+
 	if *s.ReadTimeout <= 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.read_timeout.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -4988,6 +5435,10 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_integrations_request_timeout.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.OutgoingWebhookMaxAttempts <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.outgoing_webhook_max_attempts.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDisabled &&
 		*s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDefaultOn &&
 		*s.ExperimentalGroupUnreadChannels != GroupUnreadChannelsDefaultOff {
@@ -5264,6 +5715,36 @@ func (s *DisplaySettings) isValid() *AppError {
 	return nil
 }
 
+func (s *FileScanSettings) isValid() *AppError {
+	if !*s.Enable {
+		return nil
+	}
+
+	address, err := url.Parse(*s.Address)
+	if err != nil || *s.Address == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.file_scan_address.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	switch *s.Type {
+	case FileScanTypeClamd:
+		if (address.Scheme != "tcp" || address.Host == "") && (address.Scheme != "unix" || address.Path == "") {
+			return NewAppError("Config.IsValid", "model.config.is_valid.file_scan_address.app_error", nil, "", http.StatusBadRequest)
+		}
+	case FileScanTypeICAP:
+		if address.Scheme != "icap" || address.Host == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.file_scan_address.app_error", nil, "", http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.file_scan_type.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.TimeoutSeconds <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.file_scan_timeout.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func (s *ImageProxySettings) isValid() *AppError {
 	if *s.Enable {
 		switch *s.ImageProxyType {
@@ -5361,6 +5842,10 @@ func (o *Config) Sanitize(pluginManifests []*Manifest, opts *SanitizeOptions) {
 		*o.EmailSettings.SMTPPassword = FakeSetting
 	}
 
+	if o.EmailSettings.HTTPMailTransportAPIKey != nil && *o.EmailSettings.HTTPMailTransportAPIKey != "" {
+		*o.EmailSettings.HTTPMailTransportAPIKey = FakeSetting
+	}
+
 	if o.GitLabSettings.Secret != nil && *o.GitLabSettings.Secret != "" {
 		*o.GitLabSettings.Secret = FakeSetting
 	}
@@ -5377,6 +5862,12 @@ func (o *Config) Sanitize(pluginManifests []*Manifest, opts *SanitizeOptions) {
 		*o.OpenIdSettings.Secret = FakeSetting
 	}
 
+	for _, provider := range o.OpenIdProvidersSettings.Providers {
+		if provider.Secret != nil && *provider.Secret != "" {
+			*provider.Secret = FakeSetting
+		}
+	}
+
 	if o.SqlSettings.DataSource != nil {
 		*o.SqlSettings.DataSource = sanitizeDataSourceField(*o.SqlSettings.DataSource, "SqlSettings.DataSource")
 	}
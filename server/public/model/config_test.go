@@ -667,6 +667,124 @@ func TestWranglerSettingsIsValid(t *testing.T) {
 	}
 }
 
+func TestFileScanSettingsIsValid(t *testing.T) {
+	for name, test := range map[string]struct {
+		Type          string
+		Address       string
+		Timeout       int
+		ExpectedError string
+	}{
+		"clamd over tcp": {
+			Type:    FileScanTypeClamd,
+			Address: "tcp://localhost:3310",
+		},
+		"clamd over a unix socket": {
+			Type:    FileScanTypeClamd,
+			Address: "unix:///var/run/clamav/clamd.ctl",
+		},
+		"icap": {
+			Type:    FileScanTypeICAP,
+			Address: "icap://localhost:1344/avscan",
+		},
+		"missing address": {
+			Type:          FileScanTypeClamd,
+			ExpectedError: "model.config.is_valid.file_scan_address.app_error",
+		},
+		"clamd with an icap address": {
+			Type:          FileScanTypeClamd,
+			Address:       "icap://localhost:1344/avscan",
+			ExpectedError: "model.config.is_valid.file_scan_address.app_error",
+		},
+		"icap with a tcp address": {
+			Type:          FileScanTypeICAP,
+			Address:       "tcp://localhost:1344",
+			ExpectedError: "model.config.is_valid.file_scan_address.app_error",
+		},
+		"unknown type": {
+			Type:          "unknown",
+			Address:       "tcp://localhost:3310",
+			ExpectedError: "model.config.is_valid.file_scan_type.app_error",
+		},
+		"invalid timeout": {
+			Type:          FileScanTypeClamd,
+			Address:       "tcp://localhost:3310",
+			Timeout:       -1,
+			ExpectedError: "model.config.is_valid.file_scan_timeout.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			settings := &FileScanSettings{
+				Enable:  new(true),
+				Type:    new(test.Type),
+				Address: new(test.Address),
+			}
+			if test.Timeout != 0 {
+				settings.TimeoutSeconds = new(test.Timeout)
+			}
+			settings.SetDefaults()
+
+			appErr := settings.isValid()
+			if test.ExpectedError == "" {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				require.Equal(t, test.ExpectedError, appErr.Id)
+			}
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		settings := &FileScanSettings{}
+		settings.SetDefaults()
+		require.Nil(t, settings.isValid())
+	})
+}
+
+func TestRateLimitSettingsIsValid(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		settings := &RateLimitSettings{}
+		settings.SetDefaults()
+		require.Nil(t, settings.isValid())
+		require.Equal(t, 5, *settings.LoginQuota.PerSec)
+		require.Equal(t, 10, *settings.LoginQuota.MaxBurst)
+	})
+
+	t.Run("partially configured quota", func(t *testing.T) {
+		settings := &RateLimitSettings{
+			SearchQuota: &RateLimitQuota{PerSec: new(1)},
+		}
+		settings.SetDefaults()
+		require.Nil(t, settings.isValid())
+		require.Equal(t, 1, *settings.SearchQuota.PerSec)
+		require.Equal(t, 10, *settings.SearchQuota.MaxBurst)
+	})
+
+	for name, test := range map[string]struct {
+		Quota    RateLimitQuota
+		Expected bool
+	}{
+		"valid":          {Quota: RateLimitQuota{PerSec: new(1), MaxBurst: new(0)}, Expected: true},
+		"zero rate":      {Quota: RateLimitQuota{PerSec: new(0), MaxBurst: new(10)}},
+		"negative burst": {Quota: RateLimitQuota{PerSec: new(1), MaxBurst: new(-1)}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			settings := &RateLimitSettings{
+				PostCreateQuota: &test.Quota,
+			}
+			settings.SetDefaults()
+
+			appErr := settings.isValid()
+			if test.Expected {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				require.Equal(t, "model.config.is_valid.rate_limit_quota.app_error", appErr.Id)
+				require.Equal(t, "PostCreateQuota", appErr.params["Name"])
+			}
+		})
+	}
+}
+
 func TestConfigIsValidDefaultAlgorithms(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -1922,6 +2040,7 @@ func TestConfigSanitize(t *testing.T) {
 	*c.FileSettings.AmazonS3SecretAccessKey = "bar"
 	*c.FileSettings.ExportAmazonS3SecretAccessKey = "export-secret"
 	*c.EmailSettings.SMTPPassword = "baz"
+	*c.EmailSettings.HTTPMailTransportAPIKey = "mail-api-key"
 	*c.GitLabSettings.Secret = "bingo"
 	*c.OpenIdSettings.Secret = "secret"
 	*c.ServiceSettings.GoogleDeveloperKey = "google-api-key"
@@ -1942,6 +2061,7 @@ func TestConfigSanitize(t *testing.T) {
 	assert.Equal(t, FakeSetting, *c.FileSettings.AmazonS3SecretAccessKey)
 	assert.Equal(t, FakeSetting, *c.FileSettings.ExportAmazonS3SecretAccessKey)
 	assert.Equal(t, FakeSetting, *c.EmailSettings.SMTPPassword)
+	assert.Equal(t, FakeSetting, *c.EmailSettings.HTTPMailTransportAPIKey)
 	assert.Equal(t, FakeSetting, *c.GitLabSettings.Secret)
 	assert.Equal(t, FakeSetting, *c.OpenIdSettings.Secret)
 	assert.Equal(t, FakeSetting, *c.AutoTranslationSettings.LibreTranslate.APIKey)
@@ -3457,3 +3577,123 @@ func TestElasticsearchSettingsSetDefaults(t *testing.T) {
 		require.False(t, *s.EnableSearchPublicChannelsWithoutMembership)
 	})
 }
+
+func TestOpenIdProvidersSettings(t *testing.T) {
+	newProvider := func(name string) *OpenIdProviderSettings {
+		provider := &OpenIdProviderSettings{
+			Name:              new(name),
+			Enable:            new(true),
+			Id:                new("client-id"),
+			Secret:            new("client-secret"),
+			DiscoveryEndpoint: new("https://idp.example.com/.well-known/openid-configuration"),
+		}
+		provider.SetDefaults()
+		return provider
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg := Config{}
+		cfg.SetDefaults()
+		require.Empty(t, cfg.OpenIdProvidersSettings.Providers)
+
+		cfg.OpenIdProvidersSettings.Providers = []*OpenIdProviderSettings{{Name: new("staff")}}
+		cfg.SetDefaults()
+		provider := cfg.OpenIdProvidersSettings.Providers[0]
+		assert.False(t, *provider.Enable)
+		assert.True(t, *provider.UsePKCE)
+		assert.Equal(t, OpenIdProviderSettingsDefaultScope, *provider.Scope)
+		assert.Equal(t, OpenIdProviderSettingsDefaultIdClaim, *provider.IdClaim)
+		assert.Empty(t, *provider.GroupsClaim)
+		assert.NotNil(t, provider.AttributeClaims)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		cfg := Config{}
+		cfg.SetDefaults()
+		cfg.OpenIdProvidersSettings.Providers = []*OpenIdProviderSettings{newProvider("staff"), newProvider("contractors")}
+		require.Nil(t, cfg.IsValid())
+	})
+
+	for name, test := range map[string]struct {
+		update func(*OpenIdProviderSettings)
+		errID  string
+	}{
+		"invalid name": {
+			update: func(s *OpenIdProviderSettings) { *s.Name = "Staff IdP" },
+			errID:  "model.config.is_valid.openid_provider_name.app_error",
+		},
+		"missing client id": {
+			update: func(s *OpenIdProviderSettings) { *s.Id = "" },
+			errID:  "model.config.is_valid.openid_provider_id.app_error",
+		},
+		"invalid discovery endpoint": {
+			update: func(s *OpenIdProviderSettings) { *s.DiscoveryEndpoint = "idp.example.com" },
+			errID:  "model.config.is_valid.openid_provider_discovery_endpoint.app_error",
+		},
+		"missing openid scope": {
+			update: func(s *OpenIdProviderSettings) { *s.Scope = "profile email" },
+			errID:  "model.config.is_valid.openid_provider_scope.app_error",
+		},
+		"missing id claim": {
+			update: func(s *OpenIdProviderSettings) { *s.IdClaim = "" },
+			errID:  "model.config.is_valid.openid_provider_id_claim.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{}
+			cfg.SetDefaults()
+			provider := newProvider("staff")
+			test.update(provider)
+			cfg.OpenIdProvidersSettings.Providers = []*OpenIdProviderSettings{provider}
+
+			appErr := cfg.IsValid()
+			require.NotNil(t, appErr)
+			assert.Equal(t, test.errID, appErr.Id)
+		})
+	}
+
+	t.Run("disabled provider is only checked for its name", func(t *testing.T) {
+		cfg := Config{}
+		cfg.SetDefaults()
+		provider := newProvider("staff")
+		*provider.Enable = false
+		*provider.DiscoveryEndpoint = ""
+		cfg.OpenIdProvidersSettings.Providers = []*OpenIdProviderSettings{provider}
+		require.Nil(t, cfg.IsValid())
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		cfg := Config{}
+		cfg.SetDefaults()
+		cfg.OpenIdProvidersSettings.Providers = []*OpenIdProviderSettings{newProvider("staff"), newProvider("staff")}
+
+		appErr := cfg.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.config.is_valid.openid_provider_duplicate_name.app_error", appErr.Id)
+	})
+
+	t.Run("get provider", func(t *testing.T) {
+		cfg := Config{}
+		cfg.SetDefaults()
+		staff := newProvider("staff")
+		cfg.OpenIdProvidersSettings.Providers = []*OpenIdProviderSettings{staff, newProvider("contractors")}
+
+		assert.Same(t, staff, cfg.GetOpenIdProvider("oidcstaff"))
+		assert.Nil(t, cfg.GetOpenIdProvider("oidcother"))
+		assert.Nil(t, cfg.GetOpenIdProvider("staff"))
+		assert.Equal(t, GroupSource("openid_staff"), staff.GroupSource())
+
+		sso := cfg.GetSSOService("oidcstaff")
+		require.NotNil(t, sso)
+		assert.Equal(t, "client-id", *sso.Id)
+		assert.Equal(t, *staff.DiscoveryEndpoint, *sso.DiscoveryEndpoint)
+	})
+
+	t.Run("sanitize", func(t *testing.T) {
+		cfg := Config{}
+		cfg.SetDefaults()
+		cfg.OpenIdProvidersSettings.Providers = []*OpenIdProviderSettings{newProvider("staff")}
+		cfg.Sanitize(nil, nil)
+		assert.Equal(t, FakeSetting, *cfg.OpenIdProvidersSettings.Providers[0].Secret)
+	})
+}
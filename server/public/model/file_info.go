@@ -21,6 +21,22 @@ const (
 	// sanitized FileInfo.Name. It matches the VARCHAR(256) width of the
 	// fileinfo.name column.
 	MaxFilenameLength = 256
+
+	// FileQuarantineDirectory is the directory of the file store that files found
+	// to be infected by the file scanner are moved to.
+	FileQuarantineDirectory = "quarantine/"
+
+	// FileBlobDirectory is the directory of the file store holding the content of
+	// deduplicated files, stored once under the SHA-256 hash of their content.
+	FileBlobDirectory = "blobs/"
+)
+
+// Scan statuses of a file. Files uploaded while file scanning is disabled have no scan status.
+const (
+	FileScanStatusPending  = "pending"
+	FileScanStatusClean    = "clean"
+	FileScanStatusInfected = "infected"
+	FileScanStatusFailed   = "failed"
 )
 
 // FileDownloadType represents the type of file download or access being performed.
@@ -79,20 +95,24 @@ type FileInfo struct {
 	Content         string  `json:"-" xml:"-"`
 	RemoteId        *string `json:"remote_id" xml:"RemoteId"`
 	Archived        bool    `json:"archived" xml:"Archived"`
+	ScanStatus      string  `json:"scan_status,omitempty" xml:"ScanStatus,omitempty"`
+	ScannedAt       int64   `json:"scanned_at,omitempty" xml:"ScannedAt,omitempty"`
+	ContentHash     string  `json:"-" xml:"-"` // hex encoded SHA-256 of the content, not sent back to the client
 }
 
 func (fi *FileInfo) Auditable() map[string]any {
 	return map[string]any{
-		"id":         fi.Id,
-		"creator_id": fi.CreatorId,
-		"post_id":    fi.PostId,
-		"channel_id": fi.ChannelId,
-		"create_at":  fi.CreateAt,
-		"update_at":  fi.UpdateAt,
-		"delete_at":  fi.DeleteAt,
-		"name":       fi.Name,
-		"extension":  fi.Extension,
-		"size":       fi.Size,
+		"id":          fi.Id,
+		"creator_id":  fi.CreatorId,
+		"post_id":     fi.PostId,
+		"channel_id":  fi.ChannelId,
+		"create_at":   fi.CreateAt,
+		"update_at":   fi.UpdateAt,
+		"delete_at":   fi.DeleteAt,
+		"name":        fi.Name,
+		"extension":   fi.Extension,
+		"size":        fi.Size,
+		"scan_status": fi.ScanStatus,
 	}
 }
 
@@ -143,6 +163,14 @@ func (fi *FileInfo) IsValid() *AppError {
 		return NewAppError("FileInfo.IsValid", "model.file_info.is_valid.name.app_error", nil, "id="+fi.Id, http.StatusBadRequest)
 	}
 
+	if !IsValidFileScanStatus(fi.ScanStatus) {
+		return NewAppError("FileInfo.IsValid", "model.file_info.is_valid.scan_status.app_error", nil, "id="+fi.Id, http.StatusBadRequest)
+	}
+
+	if fi.ContentHash != "" && !IsValidFileContentHash(fi.ContentHash) {
+		return NewAppError("FileInfo.IsValid", "model.file_info.is_valid.content_hash.app_error", nil, "id="+fi.Id, http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -200,6 +228,65 @@ func SanitizeFilename(name string) string {
 	return name
 }
 
+// IsValidFileScanStatus reports whether status is one of the scan statuses of a file, or empty.
+func IsValidFileScanStatus(status string) bool {
+	switch status {
+	case "", FileScanStatusPending, FileScanStatusClean, FileScanStatusInfected, FileScanStatusFailed:
+		return true
+	}
+	return false
+}
+
+// IsQuarantined reports whether the file was moved to the quarantine directory of the file store.
+func (fi *FileInfo) IsQuarantined() bool {
+	return fi.ScanStatus == FileScanStatusInfected
+}
+
+// QuarantinePath returns the path of the quarantined copy of a file stored at path.
+func QuarantinePath(path string) string {
+	if path == "" {
+		return ""
+	}
+	return FileQuarantineDirectory + path
+}
+
+// IsValidFileContentHash reports whether hash is a hex encoded SHA-256 hash.
+func IsValidFileContentHash(hash string) bool {
+	if len(hash) != 64 {
+		return false
+	}
+	for _, r := range hash {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// FileBlobPath returns the path of the blob storing the content of the files with the given
+// content hash. Blobs are spread over two levels of directories to keep directories small.
+func FileBlobPath(hash string) string {
+	return FileBlobDirectory + hash[:2] + "/" + hash[2:4] + "/" + hash
+}
+
+// FileBlobHash returns the content hash of the blob stored at path, if path is the path of a blob.
+func FileBlobHash(path string) (string, bool) {
+	if !strings.HasPrefix(path, FileBlobDirectory) {
+		return "", false
+	}
+	hash := filepath.Base(path)
+	if !IsValidFileContentHash(hash) || path != FileBlobPath(hash) {
+		return "", false
+	}
+	return hash, true
+}
+
+// IsDeduplicated reports whether the content of the file is stored in a blob shared with the
+// files having the same content.
+func (fi *FileInfo) IsDeduplicated() bool {
+	return fi.ContentHash != "" && fi.Path == FileBlobPath(fi.ContentHash)
+}
+
 func (fi *FileInfo) IsImage() bool {
 	return strings.HasPrefix(fi.MimeType, "image")
 }
@@ -85,6 +85,56 @@ func TestFileInfoIsValid(t *testing.T) {
 			assert.NotNilf(t, info.IsValid(), "expected %q to be rejected", bad)
 		}
 	})
+
+	t.Run("Scan status must be empty or a known status", func(t *testing.T) {
+		defer func() { info.ScanStatus = "" }()
+
+		for _, status := range []string{FileScanStatusPending, FileScanStatusClean, FileScanStatusInfected, FileScanStatusFailed} {
+			info.ScanStatus = status
+			assert.Nil(t, info.IsValid())
+		}
+
+		info.ScanStatus = "unknown"
+		assert.NotNil(t, info.IsValid(), "unknown scan status isn't valid")
+	})
+
+	t.Run("Content hash must be empty or a SHA-256 hash", func(t *testing.T) {
+		defer func() { info.ContentHash = "" }()
+
+		info.ContentHash = strings.Repeat("ab", 32)
+		assert.Nil(t, info.IsValid())
+
+		info.ContentHash = strings.Repeat("AB", 32)
+		assert.NotNil(t, info.IsValid(), "upper case hash isn't valid")
+
+		info.ContentHash = "abcd"
+		assert.NotNil(t, info.IsValid(), "short hash isn't valid")
+	})
+}
+
+func TestFileBlobPath(t *testing.T) {
+	hash := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	path := FileBlobPath(hash)
+	assert.Equal(t, "blobs/2c/f2/"+hash, path)
+
+	blobHash, ok := FileBlobHash(path)
+	assert.True(t, ok)
+	assert.Equal(t, hash, blobHash)
+
+	for _, path := range []string{
+		"20260101/teams/noteam/channels/channel/users/user/file/" + hash,
+		"blobs/00/00/" + hash,
+		"blobs/2c/f2/invalid",
+		"quarantine/blobs/2c/f2/" + hash,
+	} {
+		_, ok := FileBlobHash(path)
+		assert.False(t, ok, path)
+	}
+
+	info := &FileInfo{ContentHash: hash, Path: path}
+	assert.True(t, info.IsDeduplicated())
+	info.Path = "20260101/teams/noteam/channels/channel/users/user/file/hello.txt"
+	assert.False(t, info.IsDeduplicated())
 }
 
 func TestIsValidFilename(t *testing.T) {
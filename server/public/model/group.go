@@ -16,6 +16,10 @@ const (
 	// plugin groups must prefix their source with this
 	GroupSourcePluginPrefix GroupSource = "plugin_"
 
+	// groups synchronized from an OpenID Connect provider prefix their source with this,
+	// followed by the name of the provider
+	GroupSourceOpenIdPrefix GroupSource = "openid_"
+
 	GroupNameMaxLength        = 64
 	GroupSourceMaxLength      = 64
 	GroupDisplayNameMaxLength = 128
@@ -214,7 +218,8 @@ func (group *Group) IsValidForCreate() *AppError {
 	isValidSource := false
 	if group.Source == GroupSourceLdap ||
 		group.Source == GroupSourceCustom ||
-		strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix)) {
+		strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix)) ||
+		strings.HasPrefix(string(group.Source), string(GroupSourceOpenIdPrefix)) {
 		isValidSource = true
 	}
 
@@ -230,7 +235,7 @@ func (group *Group) IsValidForCreate() *AppError {
 }
 
 func (group *Group) requiresRemoteId() bool {
-	return group.Source == GroupSourceLdap || strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix))
+	return group.IsSyncable()
 }
 
 func GetSyncableGroupSources() []GroupSource {
@@ -238,11 +243,21 @@ func GetSyncableGroupSources() []GroupSource {
 }
 
 func GetSyncableGroupSourcePrefixes() []GroupSource {
-	return []GroupSource{GroupSourcePluginPrefix}
+	return []GroupSource{GroupSourcePluginPrefix, GroupSourceOpenIdPrefix}
 }
 
 func (group *Group) IsSyncable() bool {
-	return group.Source == GroupSourceLdap || strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix))
+	if group.Source == GroupSourceLdap {
+		return true
+	}
+
+	for _, prefix := range GetSyncableGroupSourcePrefixes() {
+		if strings.HasPrefix(string(group.Source), string(prefix)) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (group *Group) IsValidForUpdate() *AppError {
@@ -97,3 +97,25 @@ func TestGroupLogClone(t *testing.T) {
 		assert.Equal(t, expected, m)
 	})
 }
+
+func TestGroupIsSyncable(t *testing.T) {
+	for source, syncable := range map[GroupSource]bool{
+		GroupSourceLdap:                    true,
+		GroupSourcePluginPrefix + "plugin": true,
+		GroupSourceOpenIdPrefix + "staff":  true,
+		GroupSourceCustom:                  false,
+	} {
+		group := &Group{
+			Name:        new(NewId()),
+			DisplayName: "group",
+			Source:      source,
+		}
+		assert.Equal(t, syncable, group.IsSyncable(), source)
+
+		if syncable {
+			require.NotNil(t, group.IsValidForCreate(), source)
+			group.RemoteId = new(NewId())
+		}
+		require.Nil(t, group.IsValidForCreate(), source)
+	}
+}
@@ -49,6 +49,11 @@ const (
 	JobTypeDeleteExpiredPosts            = "delete_expired_posts"
 	JobTypeAutoTranslationRecovery       = "autotranslation_recovery"
 	JobTypeCleanupExpiredAccessTokens    = "cleanup_expired_access_tokens"
+	JobTypeOutgoingWebhookRetry          = "outgoing_webhook_retry"
+	JobTypeEmailBatching                 = "email_batching"
+	JobTypeOutgoingEmailQueue            = "outgoing_email_queue"
+	JobTypeFileScan                      = "file_scan"
+	JobTypeFileDeduplication             = "file_deduplication"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -82,6 +87,11 @@ var AllJobTypes = [...]string{
 	JobTypeCleanupExpiredAccessTokens,
 	JobTypeRefreshMaterializedViews,
 	JobTypeMobileSessionMetadata,
+	JobTypeOutgoingWebhookRetry,
+	JobTypeEmailBatching,
+	JobTypeOutgoingEmailQueue,
+	JobTypeFileScan,
+	JobTypeFileDeduplication,
 }
 
 type Job struct {
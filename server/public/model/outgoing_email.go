@@ -0,0 +1,142 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	OutgoingEmailStatusPending = "pending"
+	OutgoingEmailStatusFailed  = "failed"
+
+	OutgoingEmailInitialBackoff = time.Minute
+	OutgoingEmailMaxBackoff     = time.Hour
+	// OutgoingEmailRetention is how long emails that failed permanently are kept for
+	// an administrator to retry them, as they hold the full body of the email.
+	OutgoingEmailRetention = 7 * 24 * time.Hour
+
+	OutgoingEmailErrorMaxLength = 1024
+)
+
+// OutgoingEmail is an email waiting in the outbound mail queue, either to be retried
+// after a failed delivery attempt, or, once it failed permanently, in the dead-letter
+// list until an administrator retries or deletes it.
+type OutgoingEmail struct {
+	Id            string             `json:"id"`
+	To            string             `json:"to"`
+	Cc            string             `json:"cc"`
+	ReplyTo       string             `json:"reply_to"`
+	Subject       string             `json:"subject"`
+	HTMLBody      string             `json:"-"`
+	EmbeddedFiles OutgoingEmailFiles `json:"-"`
+	MessageId     string             `json:"message_id"`
+	InReplyTo     string             `json:"in_reply_to"`
+	References    string             `json:"references"`
+	Category      string             `json:"category"`
+	Status        string             `json:"status"`
+	Attempts      int                `json:"attempts"`
+	LastError     string             `json:"last_error"`
+	NextAttemptAt int64              `json:"next_attempt_at"`
+	CreateAt      int64              `json:"create_at"`
+	UpdateAt      int64              `json:"update_at"`
+}
+
+// OutgoingEmailFiles are the files embedded in an OutgoingEmail, keyed by name.
+type OutgoingEmailFiles map[string][]byte
+
+func (f *OutgoingEmailFiles) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, f)
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	}
+
+	return errors.New("received value is neither a byte slice nor string")
+}
+
+func (f OutgoingEmailFiles) Value() (driver.Value, error) {
+	if f == nil {
+		return "{}", nil
+	}
+
+	j, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
+
+func (o *OutgoingEmail) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Status == "" {
+		o.Status = OutgoingEmailStatusPending
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+
+	if len(o.LastError) > OutgoingEmailErrorMaxLength {
+		o.LastError = o.LastError[:OutgoingEmailErrorMaxLength]
+	}
+}
+
+func (o *OutgoingEmail) PreUpdate() {
+	o.UpdateAt = GetMillis()
+
+	if len(o.LastError) > OutgoingEmailErrorMaxLength {
+		o.LastError = o.LastError[:OutgoingEmailErrorMaxLength]
+	}
+}
+
+func (o *OutgoingEmail) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.To == "" {
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.to.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Status {
+	case OutgoingEmailStatusPending, OutgoingEmailStatusFailed:
+	default:
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.status.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("OutgoingEmail.IsValid", "model.outgoing_email.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// OutgoingEmailBackoff returns how long to wait before the next delivery attempt of
+// a queued email, doubling after every failed attempt up to a maximum.
+func OutgoingEmailBackoff(attempts int) time.Duration {
+	backoff := OutgoingEmailInitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= OutgoingEmailMaxBackoff {
+			return OutgoingEmailMaxBackoff
+		}
+	}
+	return backoff
+}
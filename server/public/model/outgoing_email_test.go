@@ -0,0 +1,62 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutgoingEmailIsValid(t *testing.T) {
+	o := OutgoingEmail{
+		To:      "user@example.com",
+		Subject: "Hello",
+	}
+	o.PreSave()
+	require.Nil(t, o.IsValid())
+	assert.Equal(t, OutgoingEmailStatusPending, o.Status)
+
+	o.Status = "unknown"
+	require.NotNil(t, o.IsValid())
+	o.Status = OutgoingEmailStatusFailed
+	require.Nil(t, o.IsValid())
+
+	o.To = ""
+	require.NotNil(t, o.IsValid())
+	o.To = "user@example.com"
+
+	o.Id = "123"
+	require.NotNil(t, o.IsValid())
+}
+
+func TestOutgoingEmailPreUpdate(t *testing.T) {
+	o := OutgoingEmail{LastError: strings.Repeat("a", OutgoingEmailErrorMaxLength+10)}
+	o.PreUpdate()
+	assert.Len(t, o.LastError, OutgoingEmailErrorMaxLength)
+	assert.NotZero(t, o.UpdateAt)
+}
+
+func TestOutgoingEmailBackoff(t *testing.T) {
+	assert.Equal(t, OutgoingEmailInitialBackoff, OutgoingEmailBackoff(1))
+	assert.Equal(t, 2*OutgoingEmailInitialBackoff, OutgoingEmailBackoff(2))
+	assert.Equal(t, 4*OutgoingEmailInitialBackoff, OutgoingEmailBackoff(3))
+	assert.Equal(t, OutgoingEmailMaxBackoff, OutgoingEmailBackoff(100))
+}
+
+func TestOutgoingEmailFiles(t *testing.T) {
+	files := OutgoingEmailFiles{"logo.png": []byte{0, 1, 2}}
+	value, err := files.Value()
+	require.NoError(t, err)
+
+	var scanned OutgoingEmailFiles
+	require.NoError(t, scanned.Scan([]byte(value.(string))))
+	assert.Equal(t, files, scanned)
+
+	value, err = OutgoingEmailFiles(nil).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "{}", value)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	OutgoingWebhookDeliveryStatusPending  = "pending"
+	OutgoingWebhookDeliveryStatusSuccess  = "success"
+	OutgoingWebhookDeliveryStatusRetrying = "retrying"
+	OutgoingWebhookDeliveryStatusFailed   = "failed"
+
+	OutgoingWebhookDeliveryInitialBackoff = 30 * time.Second
+	OutgoingWebhookDeliveryMaxBackoff     = 30 * time.Minute
+	OutgoingWebhookDeliveryRetention      = 7 * 24 * time.Hour
+
+	OutgoingWebhookDeliveryErrorMaxLength = 1024
+
+	HeaderOutgoingWebhookSignature  = "X-Mattermost-Signature"
+	HeaderOutgoingWebhookTimestamp  = "X-Mattermost-Timestamp"
+	HeaderOutgoingWebhookDeliveryId = "X-Mattermost-Delivery-Id"
+
+	outgoingWebhookSignatureVersion = "v1"
+)
+
+// OutgoingWebhookDelivery records the delivery of a post to one of the callback
+// URLs of an outgoing webhook, across all the attempts made to deliver it.
+type OutgoingWebhookDelivery struct {
+	Id          string `json:"id"`
+	HookId      string `json:"hook_id"`
+	PostId      string `json:"post_id"`
+	CallbackURL string `json:"callback_url"`
+	ContentType string `json:"content_type"`
+	Payload     string `json:"-"`
+	Status      string `json:"status"`
+	StatusCode  int    `json:"status_code"`
+	LatencyMs   int64  `json:"latency_ms"`
+	Attempts    int    `json:"attempts"`
+	LastError   string `json:"last_error"`
+	NextRetryAt int64  `json:"next_retry_at"`
+	CreateAt    int64  `json:"create_at"`
+	UpdateAt    int64  `json:"update_at"`
+}
+
+func (o *OutgoingWebhookDelivery) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Status == "" {
+		o.Status = OutgoingWebhookDeliveryStatusPending
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *OutgoingWebhookDelivery) PreUpdate() {
+	o.UpdateAt = GetMillis()
+
+	if len(o.LastError) > OutgoingWebhookDeliveryErrorMaxLength {
+		o.LastError = o.LastError[:OutgoingWebhookDeliveryErrorMaxLength]
+	}
+}
+
+func (o *OutgoingWebhookDelivery) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.HookId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.hook_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.PostId) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.post_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidHTTPURL(o.CallbackURL) {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.callback_url.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	switch o.Status {
+	case OutgoingWebhookDeliveryStatusPending, OutgoingWebhookDeliveryStatusSuccess, OutgoingWebhookDeliveryStatusRetrying, OutgoingWebhookDeliveryStatusFailed:
+	default:
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.status.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("OutgoingWebhookDelivery.IsValid", "model.outgoing_hook_delivery.is_valid.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// OutgoingWebhookDeliveryBackoff returns how long to wait before the next
+// delivery attempt, doubling after every failed attempt up to a maximum.
+func OutgoingWebhookDeliveryBackoff(attempts int) time.Duration {
+	backoff := OutgoingWebhookDeliveryInitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= OutgoingWebhookDeliveryMaxBackoff {
+			return OutgoingWebhookDeliveryMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// SignOutgoingWebhookPayload computes the value of the X-Mattermost-Signature header
+// for the given payload: an HMAC-SHA256 keyed with the hook token over the
+// timestamp and the body, so that receivers can check both origin and freshness.
+func SignOutgoingWebhookPayload(token string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return outgoingWebhookSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyOutgoingWebhookSignature checks a signature produced by SignOutgoingWebhookPayload.
+func VerifyOutgoingWebhookSignature(token string, timestamp int64, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, outgoingWebhookSignatureVersion+"=") {
+		return false
+	}
+	expected := SignOutgoingWebhookPayload(token, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
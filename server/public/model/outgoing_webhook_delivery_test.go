@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutgoingWebhookDeliveryIsValid(t *testing.T) {
+	d := OutgoingWebhookDelivery{
+		HookId:      NewId(),
+		PostId:      NewId(),
+		CallbackURL: "http://nowhere.com/",
+		ContentType: "application/json",
+	}
+	d.PreSave()
+	require.Nil(t, d.IsValid())
+	assert.Equal(t, OutgoingWebhookDeliveryStatusPending, d.Status)
+
+	d.Status = "unknown"
+	require.NotNil(t, d.IsValid())
+	d.Status = OutgoingWebhookDeliveryStatusRetrying
+	require.Nil(t, d.IsValid())
+
+	d.CallbackURL = "nowhere"
+	require.NotNil(t, d.IsValid())
+	d.CallbackURL = "http://nowhere.com/"
+
+	d.HookId = "123"
+	require.NotNil(t, d.IsValid())
+}
+
+func TestOutgoingWebhookDeliveryPreUpdate(t *testing.T) {
+	d := OutgoingWebhookDelivery{LastError: strings.Repeat("a", OutgoingWebhookDeliveryErrorMaxLength+10)}
+	d.PreUpdate()
+	assert.Len(t, d.LastError, OutgoingWebhookDeliveryErrorMaxLength)
+	assert.NotZero(t, d.UpdateAt)
+}
+
+func TestOutgoingWebhookDeliveryBackoff(t *testing.T) {
+	assert.Equal(t, OutgoingWebhookDeliveryInitialBackoff, OutgoingWebhookDeliveryBackoff(1))
+	assert.Equal(t, 2*OutgoingWebhookDeliveryInitialBackoff, OutgoingWebhookDeliveryBackoff(2))
+	assert.Equal(t, 4*OutgoingWebhookDeliveryInitialBackoff, OutgoingWebhookDeliveryBackoff(3))
+	assert.Equal(t, OutgoingWebhookDeliveryMaxBackoff, OutgoingWebhookDeliveryBackoff(100))
+
+	for attempts := 1; attempts < 20; attempts++ {
+		assert.LessOrEqual(t, OutgoingWebhookDeliveryBackoff(attempts), 30*time.Minute)
+	}
+}
+
+func TestSignOutgoingWebhookPayload(t *testing.T) {
+	body := []byte(`{"text":"hello"}`)
+
+	signature := SignOutgoingWebhookPayload("token", 1700000000, body)
+	assert.True(t, strings.HasPrefix(signature, "v1="))
+	assert.Equal(t, signature, SignOutgoingWebhookPayload("token", 1700000000, body))
+
+	assert.True(t, VerifyOutgoingWebhookSignature("token", 1700000000, body, signature))
+	assert.False(t, VerifyOutgoingWebhookSignature("other", 1700000000, body, signature))
+	assert.False(t, VerifyOutgoingWebhookSignature("token", 1700000001, body, signature))
+	assert.False(t, VerifyOutgoingWebhookSignature("token", 1700000000, []byte(`{"text":"bye"}`), signature))
+	assert.False(t, VerifyOutgoingWebhookSignature("token", 1700000000, body, strings.TrimPrefix(signature, "v1=")))
+}
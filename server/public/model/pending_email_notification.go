@@ -0,0 +1,54 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+// PendingEmailNotification is a notification about a post that is waiting to be
+// sent to a user as part of their next batched email.
+type PendingEmailNotification struct {
+	Id       string `json:"id"`
+	UserId   string `json:"user_id"`
+	PostId   string `json:"post_id"`
+	TeamId   string `json:"team_id"`
+	CreateAt int64  `json:"create_at"`
+	// Attempts is the number of times sending the batch of the notification failed.
+	Attempts int `json:"attempts"`
+}
+
+func (o *PendingEmailNotification) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.CreateAt == 0 {
+		o.CreateAt = GetMillis()
+	}
+}
+
+func (o *PendingEmailNotification) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("PendingEmailNotification.IsValid", "model.pending_email_notification.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.UserId) {
+		return NewAppError("PendingEmailNotification.IsValid", "model.pending_email_notification.is_valid.user_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.PostId) {
+		return NewAppError("PendingEmailNotification.IsValid", "model.pending_email_notification.is_valid.post_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.TeamId) {
+		return NewAppError("PendingEmailNotification.IsValid", "model.pending_email_notification.is_valid.team_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("PendingEmailNotification.IsValid", "model.pending_email_notification.is_valid.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
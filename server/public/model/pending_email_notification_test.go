@@ -0,0 +1,40 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingEmailNotificationIsValid(t *testing.T) {
+	n := PendingEmailNotification{
+		UserId: NewId(),
+		PostId: NewId(),
+		TeamId: NewId(),
+	}
+	n.PreSave()
+	require.Nil(t, n.IsValid())
+	assert.NotZero(t, n.CreateAt)
+
+	n.UserId = "123"
+	require.NotNil(t, n.IsValid())
+	n.UserId = NewId()
+
+	n.PostId = ""
+	require.NotNil(t, n.IsValid())
+	n.PostId = NewId()
+
+	n.TeamId = "123"
+	require.NotNil(t, n.IsValid())
+}
+
+func TestPendingEmailNotificationPreSave(t *testing.T) {
+	n := PendingEmailNotification{CreateAt: 1234}
+	n.PreSave()
+	assert.True(t, IsValidId(n.Id))
+	assert.Equal(t, int64(1234), n.CreateAt)
+}
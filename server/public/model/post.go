@@ -307,6 +307,17 @@ type DirectPostForExport struct {
 	FlaggedBy      StringArray
 }
 
+// DeletedPostForExport identifies a deleted post the way the importer finds it: by channel,
+// author and creation time.
+type DeletedPostForExport struct {
+	Post
+	TeamName       string
+	ChannelName    string
+	ChannelType    ChannelType
+	Username       string
+	ChannelMembers *[]string
+}
+
 type ReplyForExport struct {
 	Post
 	Username  string
@@ -29,6 +29,9 @@ const (
 	PropertyFieldTypeUser        PropertyFieldType = "user"
 	PropertyFieldTypeMultiuser   PropertyFieldType = "multiuser"
 	PropertyFieldTypeRank        PropertyFieldType = "rank"
+	PropertyFieldTypeNumber      PropertyFieldType = "number"
+	PropertyFieldTypeURL         PropertyFieldType = "url"
+	PropertyFieldTypeCheckbox    PropertyFieldType = "checkbox"
 
 	PropertyFieldNameMaxRunes       = 255
 	PropertyFieldTargetIDMaxRunes   = 255
@@ -276,10 +279,19 @@ func (pf *PropertyField) IsValid() error {
 		pf.Type != PropertyFieldTypeDate &&
 		pf.Type != PropertyFieldTypeUser &&
 		pf.Type != PropertyFieldTypeMultiuser &&
-		pf.Type != PropertyFieldTypeRank {
+		pf.Type != PropertyFieldTypeRank &&
+		pf.Type != PropertyFieldTypeNumber &&
+		pf.Type != PropertyFieldTypeURL &&
+		pf.Type != PropertyFieldTypeCheckbox {
 		return NewAppError("PropertyField.IsValid", "model.property_field.is_valid.app_error", map[string]any{"FieldName": "type", "Reason": "unknown value"}, "id="+pf.ID, http.StatusBadRequest)
 	}
 
+	if pf.Type == PropertyFieldTypeNumber {
+		if err := ValidatePropertyFieldNumberAttrs(pf); err != nil {
+			return NewAppError("PropertyField.IsValid", "model.property_field.is_valid.app_error", map[string]any{"FieldName": "attrs", "Reason": err.Error()}, "id="+pf.ID, http.StatusBadRequest)
+		}
+	}
+
 	// LinkedFieldID validation: if set, must be a valid 26-char ID.
 	// Empty string is allowed as a transient signal for unlinking; callers
 	// must canonicalize it to nil before persistence.
@@ -374,7 +386,10 @@ func (pfp *PropertyFieldPatch) IsValid() error {
 		*pfp.Type != PropertyFieldTypeDate &&
 		*pfp.Type != PropertyFieldTypeUser &&
 		*pfp.Type != PropertyFieldTypeMultiuser &&
-		*pfp.Type != PropertyFieldTypeRank {
+		*pfp.Type != PropertyFieldTypeRank &&
+		*pfp.Type != PropertyFieldTypeNumber &&
+		*pfp.Type != PropertyFieldTypeURL &&
+		*pfp.Type != PropertyFieldTypeCheckbox {
 		return NewAppError("PropertyFieldPatch.IsValid", "model.property_field.is_valid.app_error", map[string]any{"FieldName": "type", "Reason": "unknown value"}, "", http.StatusBadRequest)
 	}
 
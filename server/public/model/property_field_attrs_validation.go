@@ -6,7 +6,9 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/url"
+	"strconv"
 	"strings"
 )
 
@@ -20,6 +22,9 @@ const (
 	PropertyFieldAttrSAML        = "saml"
 	PropertyFieldAttrManaged     = "managed"
 	PropertyFieldAttrDisplayName = "display_name"
+	PropertyFieldAttrMin         = "min"
+	PropertyFieldAttrMax         = "max"
+	PropertyFieldAttrPrecision   = "precision"
 )
 
 // Valid visibility values for property fields.
@@ -39,6 +44,18 @@ const (
 // PropertyFieldValueTypeTextMaxLength is the maximum character length for text field values.
 const PropertyFieldValueTypeTextMaxLength = 64
 
+// PropertyFieldNumberMaxPrecision is the maximum number of decimal places a
+// number field can be configured to accept.
+const PropertyFieldNumberMaxPrecision = 10
+
+// PropertyFieldNumberAttrs holds the constraints of a number field. Each of
+// them is nil when the field doesn't set it.
+type PropertyFieldNumberAttrs struct {
+	Min       *float64
+	Max       *float64
+	Precision *int
+}
+
 // IsValidPropertyFieldVisibility reports whether the given string is a known visibility value.
 func IsValidPropertyFieldVisibility(v string) bool {
 	switch v {
@@ -136,16 +153,7 @@ func ValidatePropertyValueForValueType(valueType string, value json.RawMessage)
 			return fmt.Errorf("invalid email: %q", str)
 		}
 	case PropertyFieldValueTypeURL:
-		// ParseRequestURI rejects relative references (url.Parse accepts them),
-		// and we additionally require a non-empty Host so bare schemes like
-		// "http:" or "file:///..." without an authority are rejected.
-		u, err := url.ParseRequestURI(str)
-		if err != nil {
-			return fmt.Errorf("invalid url: %w", err)
-		}
-		if u.Scheme == "" || u.Host == "" {
-			return fmt.Errorf("invalid url: %q", str)
-		}
+		return validatePropertyURL(str)
 	case PropertyFieldValueTypePhone:
 		// Phone values are accepted as-is; no structural validation.
 	default:
@@ -155,6 +163,170 @@ func ValidatePropertyValueForValueType(valueType string, value json.RawMessage)
 	return nil
 }
 
+// validatePropertyURL checks that the string is an absolute http or https URL, as
+// other schemes such as javascript: or data: would run or embed content when opened.
+func validatePropertyURL(str string) error {
+	// ParseRequestURI rejects relative references (url.Parse accepts them),
+	// and we additionally require a non-empty Host so bare schemes like
+	// "http:" or "file:///..." without an authority are rejected.
+	u, err := url.ParseRequestURI(str)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid url: %q", str)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid url scheme: %q", u.Scheme)
+	}
+	return nil
+}
+
+// GetPropertyFieldNumberAttrs extracts the min, max and precision attrs of a
+// number field, failing if any of them is set to something other than a
+// number.
+func GetPropertyFieldNumberAttrs(field *PropertyField) (PropertyFieldNumberAttrs, error) {
+	var attrs PropertyFieldNumberAttrs
+	if field.Attrs == nil {
+		return attrs, nil
+	}
+
+	getBound := func(key string) (*float64, error) {
+		raw, ok := field.Attrs[key]
+		if !ok || raw == nil {
+			return nil, nil
+		}
+		f, ok := propertyAttrToFloat(raw)
+		if !ok {
+			return nil, fmt.Errorf("%s must be numeric, got %T", key, raw)
+		}
+		return &f, nil
+	}
+
+	var err error
+	if attrs.Min, err = getBound(PropertyFieldAttrMin); err != nil {
+		return attrs, err
+	}
+	if attrs.Max, err = getBound(PropertyFieldAttrMax); err != nil {
+		return attrs, err
+	}
+
+	if raw, ok := field.Attrs[PropertyFieldAttrPrecision]; ok && raw != nil {
+		f, ok := propertyAttrToFloat(raw)
+		if !ok || f != math.Trunc(f) {
+			return attrs, fmt.Errorf("precision must be an integer, got %v", raw)
+		}
+		precision := int(f)
+		attrs.Precision = &precision
+	}
+
+	return attrs, nil
+}
+
+// ValidatePropertyFieldNumberAttrs checks that the min, max and precision
+// attrs of a number field are numeric when set, that min doesn't exceed max,
+// and that precision is between 0 and PropertyFieldNumberMaxPrecision.
+func ValidatePropertyFieldNumberAttrs(field *PropertyField) error {
+	attrs, err := GetPropertyFieldNumberAttrs(field)
+	if err != nil {
+		return err
+	}
+
+	if attrs.Min != nil && attrs.Max != nil && *attrs.Min > *attrs.Max {
+		return fmt.Errorf("min %v must not be greater than max %v", *attrs.Min, *attrs.Max)
+	}
+
+	if attrs.Precision != nil && (*attrs.Precision < 0 || *attrs.Precision > PropertyFieldNumberMaxPrecision) {
+		return fmt.Errorf("precision must be between 0 and %d, got %d", PropertyFieldNumberMaxPrecision, *attrs.Precision)
+	}
+
+	return nil
+}
+
+// ValidatePropertyValueForFieldType validates a raw JSON value against the
+// constraints of the number, url and checkbox field types:
+//   - number: a JSON number within the min/max attrs, with no more decimal
+//     places than the precision attr
+//   - url: a JSON string holding an absolute URL, or empty
+//   - checkbox: a JSON boolean
+//
+// A null value is accepted for all of them, and values of other field types
+// are not checked.
+func ValidatePropertyValueForFieldType(field *PropertyField, value json.RawMessage) error {
+	if strings.TrimSpace(string(value)) == "null" {
+		return nil
+	}
+
+	switch field.Type {
+	case PropertyFieldTypeNumber:
+		var number float64
+		if err := json.Unmarshal(value, &number); err != nil {
+			return fmt.Errorf("expected number value: %w", err)
+		}
+
+		attrs, err := GetPropertyFieldNumberAttrs(field)
+		if err != nil {
+			return err
+		}
+		if attrs.Min != nil && number < *attrs.Min {
+			return fmt.Errorf("value %v is less than the minimum of %v", number, *attrs.Min)
+		}
+		if attrs.Max != nil && number > *attrs.Max {
+			return fmt.Errorf("value %v is greater than the maximum of %v", number, *attrs.Max)
+		}
+		if attrs.Precision != nil {
+			if decimals := numberDecimalPlaces(number); decimals > *attrs.Precision {
+				return fmt.Errorf("value %v has more than %d decimal places", number, *attrs.Precision)
+			}
+		}
+
+	case PropertyFieldTypeURL:
+		var str string
+		if err := json.Unmarshal(value, &str); err != nil {
+			return fmt.Errorf("expected string value for url field: %w", err)
+		}
+		if str = strings.TrimSpace(str); str != "" {
+			return validatePropertyURL(str)
+		}
+
+	case PropertyFieldTypeCheckbox:
+		var checked bool
+		if err := json.Unmarshal(value, &checked); err != nil {
+			return fmt.Errorf("expected boolean value for checkbox field: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// propertyAttrToFloat converts the numeric types an attr can hold once
+// decoded from JSON or set in code.
+func propertyAttrToFloat(raw any) (float64, bool) {
+	switch v := raw.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// numberDecimalPlaces returns the number of decimal places of the shortest
+// representation of the number, so that 0.1 counts as one.
+func numberDecimalPlaces(number float64) int {
+	str := strconv.FormatFloat(number, 'f', -1, 64)
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		return len(str) - i - 1
+	}
+	return 0
+}
+
 // GetPropertyFieldValueType extracts the value_type string from a
 // PropertyField's attrs. Returns empty string if not set.
 func GetPropertyFieldValueType(field *PropertyField) string {
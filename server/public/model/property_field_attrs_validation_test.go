@@ -103,6 +103,92 @@ func TestValidatePropertyValueForValueType(t *testing.T) {
 	}
 }
 
+func TestValidatePropertyFieldNumberAttrs(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   StringInterface
+		wantErr bool
+	}{
+		{name: "nil attrs", attrs: nil},
+		{name: "no number attrs", attrs: StringInterface{"other": "val"}},
+		{name: "min and max", attrs: StringInterface{PropertyFieldAttrMin: float64(-1.5), PropertyFieldAttrMax: 10}},
+		{name: "min equals max", attrs: StringInterface{PropertyFieldAttrMin: 5, PropertyFieldAttrMax: int64(5)}},
+		{name: "null bounds", attrs: StringInterface{PropertyFieldAttrMin: nil, PropertyFieldAttrMax: nil}},
+		{name: "json.Number precision", attrs: StringInterface{PropertyFieldAttrPrecision: json.Number("2")}},
+		{name: "zero precision", attrs: StringInterface{PropertyFieldAttrPrecision: float64(0)}},
+		{name: "max precision", attrs: StringInterface{PropertyFieldAttrPrecision: PropertyFieldNumberMaxPrecision}},
+		{name: "min greater than max", attrs: StringInterface{PropertyFieldAttrMin: 10, PropertyFieldAttrMax: 1}, wantErr: true},
+		{name: "string min", attrs: StringInterface{PropertyFieldAttrMin: "1"}, wantErr: true},
+		{name: "bool max", attrs: StringInterface{PropertyFieldAttrMax: true}, wantErr: true},
+		{name: "fractional precision", attrs: StringInterface{PropertyFieldAttrPrecision: 1.5}, wantErr: true},
+		{name: "negative precision", attrs: StringInterface{PropertyFieldAttrPrecision: -1}, wantErr: true},
+		{name: "precision too large", attrs: StringInterface{PropertyFieldAttrPrecision: PropertyFieldNumberMaxPrecision + 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := &PropertyField{Type: PropertyFieldTypeNumber, Attrs: tt.attrs}
+			err := ValidatePropertyFieldNumberAttrs(field)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidatePropertyValueForFieldType(t *testing.T) {
+	numberAttrs := StringInterface{
+		PropertyFieldAttrMin:       float64(0),
+		PropertyFieldAttrMax:       float64(100),
+		PropertyFieldAttrPrecision: float64(2),
+	}
+
+	tests := []struct {
+		name      string
+		fieldType PropertyFieldType
+		attrs     StringInterface
+		value     string
+		wantErr   bool
+	}{
+		{name: "number", fieldType: PropertyFieldTypeNumber, value: `-12.345`},
+		{name: "number within constraints", fieldType: PropertyFieldTypeNumber, attrs: numberAttrs, value: `42.5`},
+		{name: "number at bounds", fieldType: PropertyFieldTypeNumber, attrs: numberAttrs, value: `100`},
+		{name: "number with exact precision", fieldType: PropertyFieldTypeNumber, attrs: numberAttrs, value: `0.01`},
+		{name: "null number", fieldType: PropertyFieldTypeNumber, attrs: numberAttrs, value: `null`},
+		{name: "number below min", fieldType: PropertyFieldTypeNumber, attrs: numberAttrs, value: `-0.5`, wantErr: true},
+		{name: "number above max", fieldType: PropertyFieldTypeNumber, attrs: numberAttrs, value: `100.01`, wantErr: true},
+		{name: "number too precise", fieldType: PropertyFieldTypeNumber, attrs: numberAttrs, value: `1.005`, wantErr: true},
+		{name: "numeric string", fieldType: PropertyFieldTypeNumber, value: `"42"`, wantErr: true},
+		{name: "valid url", fieldType: PropertyFieldTypeURL, value: `"https://example.com/path?q=1"`},
+		{name: "empty url", fieldType: PropertyFieldTypeURL, value: `""`},
+		{name: "relative url", fieldType: PropertyFieldTypeURL, value: `"/relative/path"`, wantErr: true},
+		{name: "url missing scheme", fieldType: PropertyFieldTypeURL, value: `"example.com"`, wantErr: true},
+		{name: "http url with uppercase scheme", fieldType: PropertyFieldTypeURL, value: `"HTTP://example.com"`},
+		{name: "javascript url", fieldType: PropertyFieldTypeURL, value: `"javascript://example.com/%0Aalert(1)"`, wantErr: true},
+		{name: "data url", fieldType: PropertyFieldTypeURL, value: `"data:text/html,<script>alert(1)</script>"`, wantErr: true},
+		{name: "ftp url", fieldType: PropertyFieldTypeURL, value: `"ftp://example.com/file"`, wantErr: true},
+		{name: "non-string url", fieldType: PropertyFieldTypeURL, value: `42`, wantErr: true},
+		{name: "checked checkbox", fieldType: PropertyFieldTypeCheckbox, value: `true`},
+		{name: "unchecked checkbox", fieldType: PropertyFieldTypeCheckbox, value: `false`},
+		{name: "string checkbox", fieldType: PropertyFieldTypeCheckbox, value: `"true"`, wantErr: true},
+		{name: "other field types are not checked", fieldType: PropertyFieldTypeText, value: `42`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := &PropertyField{Type: tt.fieldType, Attrs: tt.attrs}
+			err := ValidatePropertyValueForFieldType(field, json.RawMessage(tt.value))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestIsPropertyFieldSynced(t *testing.T) {
 	assert.False(t, IsPropertyFieldSynced(&PropertyField{}))
 	assert.False(t, IsPropertyFieldSynced(&PropertyField{Attrs: StringInterface{}}))
@@ -118,6 +118,32 @@ func TestPropertyField_IsValid(t *testing.T) {
 		require.Error(t, pf.IsValid())
 	})
 
+	t.Run("number type with valid attrs", func(t *testing.T) {
+		pf := &PropertyField{
+			ID:       NewId(),
+			GroupID:  NewId(),
+			Name:     "test field",
+			Type:     PropertyFieldTypeNumber,
+			Attrs:    StringInterface{PropertyFieldAttrMin: 0, PropertyFieldAttrMax: 10, PropertyFieldAttrPrecision: 2},
+			CreateAt: GetMillis(),
+			UpdateAt: GetMillis(),
+		}
+		require.NoError(t, pf.IsValid())
+	})
+
+	t.Run("number type with min greater than max", func(t *testing.T) {
+		pf := &PropertyField{
+			ID:       NewId(),
+			GroupID:  NewId(),
+			Name:     "test field",
+			Type:     PropertyFieldTypeNumber,
+			Attrs:    StringInterface{PropertyFieldAttrMin: 10, PropertyFieldAttrMax: 0},
+			CreateAt: GetMillis(),
+			UpdateAt: GetMillis(),
+		}
+		require.Error(t, pf.IsValid())
+	})
+
 	t.Run("zero CreateAt", func(t *testing.T) {
 		pf := &PropertyField{
 			ID:       NewId(),
@@ -1463,6 +1489,9 @@ func TestPropertyFieldType_SupportsOptions(t *testing.T) {
 		PropertyFieldTypeDate:        false,
 		PropertyFieldTypeUser:        false,
 		PropertyFieldTypeMultiuser:   false,
+		PropertyFieldTypeNumber:      false,
+		PropertyFieldTypeURL:         false,
+		PropertyFieldTypeCheckbox:    false,
 		PropertyFieldType("bogus"):   false,
 	}
 
@@ -136,6 +136,10 @@ type PropertyValueSearchOpts struct {
 	Cursor         PropertyValueSearchCursor
 	PerPage        int
 	Value          json.RawMessage
+	// ValueMin and ValueMax restrict the search to numeric values within the
+	// inclusive range they define, leaving out values of any other type.
+	ValueMin *float64
+	ValueMax *float64
 }
 
 // PropertyValueSearch captures the parameters provided by a client for
@@ -12,6 +12,7 @@ const (
 	PushNotifyAndroid            = "android"
 	PushNotifyAppleReactNative   = "apple_rn"
 	PushNotifyAndroidReactNative = "android_rn"
+	PushNotifyWebPush            = "webpush"
 
 	PushTypeMessage     = "message"
 	PushTypeClear       = "clear"
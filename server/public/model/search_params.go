@@ -35,6 +35,69 @@ type SearchParams struct {
 	// True if this search doesn't originate from a "current user".
 	SearchWithoutUserId bool   `json:"search_without_user_id,omitempty"`
 	Modifier            string `json:"modifier"`
+
+	// Post attribute filters set by the has: and is: flags. A nil value doesn't
+	// filter, while true and false only match posts with or without the attribute.
+	HasFile     *bool `json:"has_file,omitempty"`
+	HasLink     *bool `json:"has_link,omitempty"`
+	HasReaction *bool `json:"has_reaction,omitempty"`
+	IsPinned    *bool `json:"is_pinned,omitempty"`
+	IsReply     *bool `json:"is_reply,omitempty"`
+	// IsFlagged matches the posts flagged by the user running the search.
+	IsFlagged         *bool    `json:"is_flagged,omitempty"`
+	Reactions         []string `json:"reactions,omitempty"`
+	ExcludedReactions []string `json:"excluded_reactions,omitempty"`
+}
+
+// HasPostAttributeFilters returns true if the search is restricted by any of
+// the has: or is: flags.
+func (p *SearchParams) HasPostAttributeFilters() bool {
+	return p.HasFile != nil || p.HasLink != nil || p.HasReaction != nil ||
+		p.IsPinned != nil || p.IsReply != nil || p.IsFlagged != nil ||
+		len(p.Reactions) != 0 || len(p.ExcludedReactions) != 0
+}
+
+// setPostAttributeFilter applies the value of a has: or is: flag. Values that
+// don't name a known attribute are ignored. Threads are filtered with is:reply
+// and is:root rather than in:thread, as in: names the channels to search and
+// in:thread would hide a channel named "thread".
+func (p *SearchParams) setPostAttributeFilter(f flag) {
+	value := strings.ToLower(f.value)
+	include := !f.exclude
+
+	if f.name == "has" {
+		if emoji, ok := strings.CutPrefix(value, "reaction:"); ok && strings.Trim(emoji, ":") != "" {
+			emoji = strings.Trim(emoji, ":")
+			if f.exclude {
+				p.ExcludedReactions = append(p.ExcludedReactions, emoji)
+			} else {
+				p.Reactions = append(p.Reactions, emoji)
+			}
+			return
+		}
+
+		switch strings.TrimSuffix(value, ":") {
+		case "file", "files":
+			p.HasFile = &include
+		case "link", "links":
+			p.HasLink = &include
+		case "reaction", "reactions":
+			p.HasReaction = &include
+		}
+		return
+	}
+
+	switch value {
+	case "pinned":
+		p.IsPinned = &include
+	case "reply":
+		p.IsReply = &include
+	case "root":
+		isReply := f.exclude
+		p.IsReply = &isReply
+	case "flagged", "saved":
+		p.IsFlagged = &include
+	}
 }
 
 // Returns the epoch timestamp of the start of the day specified by SearchParams.AfterDate
@@ -109,7 +172,7 @@ func (p *SearchParams) GetExcludedDateMillis() (int64, int64) {
 	return GetStartOfDayMillis(date, p.TimeZoneOffset), GetEndOfDayMillis(date, p.TimeZoneOffset)
 }
 
-var searchFlags = [...]string{"from", "channel", "in", "before", "after", "on", "ext"}
+var searchFlags = [...]string{"from", "channel", "in", "before", "after", "on", "ext", "has", "is"}
 
 type flag struct {
 	name    string
@@ -270,6 +333,7 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 	excludedDate := ""
 	excludedExtensions := []string{}
 	extensions := []string{}
+	attributes := &SearchParams{}
 
 	for _, flag := range flags {
 		if flag.name == "in" || flag.name == "channel" {
@@ -308,6 +372,8 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			} else {
 				extensions = append(extensions, flag.value)
 			}
+		} else if flag.name == "has" || flag.name == "is" {
+			attributes.setPostAttributeFilter(flag)
 		}
 	}
 
@@ -331,6 +397,14 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			OnDate:             onDate,
 			ExcludedDate:       excludedDate,
 			TimeZoneOffset:     timeZoneOffset,
+			HasFile:            attributes.HasFile,
+			HasLink:            attributes.HasLink,
+			HasReaction:        attributes.HasReaction,
+			IsPinned:           attributes.IsPinned,
+			IsReply:            attributes.IsReply,
+			IsFlagged:          attributes.IsFlagged,
+			Reactions:          attributes.Reactions,
+			ExcludedReactions:  attributes.ExcludedReactions,
 		})
 	}
 
@@ -352,6 +426,14 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			OnDate:             onDate,
 			ExcludedDate:       excludedDate,
 			TimeZoneOffset:     timeZoneOffset,
+			HasFile:            attributes.HasFile,
+			HasLink:            attributes.HasLink,
+			HasReaction:        attributes.HasReaction,
+			IsPinned:           attributes.IsPinned,
+			IsReply:            attributes.IsReply,
+			IsFlagged:          attributes.IsFlagged,
+			Reactions:          attributes.Reactions,
+			ExcludedReactions:  attributes.ExcludedReactions,
 		})
 	}
 
@@ -363,7 +445,8 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			len(extensions) != 0 || len(excludedExtensions) != 0 ||
 			afterDate != "" || excludedAfterDate != "" ||
 			beforeDate != "" || excludedBeforeDate != "" ||
-			onDate != "" || excludedDate != "") {
+			onDate != "" || excludedDate != "" ||
+			attributes.HasPostAttributeFilters()) {
 		paramsList = append(paramsList, &SearchParams{
 			Terms:              "",
 			ExcludedTerms:      "",
@@ -381,6 +464,14 @@ func ParseSearchParams(text string, timeZoneOffset int) []*SearchParams {
 			OnDate:             onDate,
 			ExcludedDate:       excludedDate,
 			TimeZoneOffset:     timeZoneOffset,
+			HasFile:            attributes.HasFile,
+			HasLink:            attributes.HasLink,
+			HasReaction:        attributes.HasReaction,
+			IsPinned:           attributes.IsPinned,
+			IsReply:            attributes.IsReply,
+			IsFlagged:          attributes.IsFlagged,
+			Reactions:          attributes.Reactions,
+			ExcludedReactions:  attributes.ExcludedReactions,
 		})
 	}
 
@@ -1708,6 +1708,83 @@ func TestParseSearchParams(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:  "input with has: and is: flags should result in post attribute filters",
+			Input: "word has:file -has:link is:pinned is:root",
+			Output: []*SearchParams{
+				{
+					Terms:              "word",
+					ExcludedTerms:      "",
+					IsHashtag:          false,
+					InChannels:         []string{},
+					ExcludedChannels:   []string{},
+					FromUsers:          []string{},
+					ExcludedUsers:      []string{},
+					Extensions:         []string{},
+					ExcludedExtensions: []string{},
+					HasFile:            NewPointer(true),
+					HasLink:            NewPointer(false),
+					IsPinned:           NewPointer(true),
+					IsReply:            NewPointer(false),
+				},
+			},
+		},
+		{
+			Name:  "input with only is: flags should result in one param",
+			Input: "is:flagged -is:reply",
+			Output: []*SearchParams{
+				{
+					Terms:              "",
+					ExcludedTerms:      "",
+					IsHashtag:          false,
+					InChannels:         []string{},
+					ExcludedChannels:   []string{},
+					FromUsers:          []string{},
+					ExcludedUsers:      []string{},
+					Extensions:         []string{},
+					ExcludedExtensions: []string{},
+					IsReply:            NewPointer(false),
+					IsFlagged:          NewPointer(true),
+				},
+			},
+		},
+		{
+			Name:  "input with has:reaction flags should result in reaction filters",
+			Input: "has:reaction:smile -has:reaction::thumbsdown: has:reactions",
+			Output: []*SearchParams{
+				{
+					Terms:              "",
+					ExcludedTerms:      "",
+					IsHashtag:          false,
+					InChannels:         []string{},
+					ExcludedChannels:   []string{},
+					FromUsers:          []string{},
+					ExcludedUsers:      []string{},
+					Extensions:         []string{},
+					ExcludedExtensions: []string{},
+					HasReaction:        NewPointer(true),
+					Reactions:          []string{"smile"},
+					ExcludedReactions:  []string{"thumbsdown"},
+				},
+			},
+		},
+		{
+			Name:  "input with unknown has: value should be ignored",
+			Input: "has:nothing word",
+			Output: []*SearchParams{
+				{
+					Terms:              "word",
+					ExcludedTerms:      "",
+					IsHashtag:          false,
+					InChannels:         []string{},
+					ExcludedChannels:   []string{},
+					FromUsers:          []string{},
+					ExcludedUsers:      []string{},
+					Extensions:         []string{},
+					ExcludedExtensions: []string{},
+				},
+			},
+		},
 	} {
 		t.Run(testCase.Name, func(t *testing.T) {
 			require.Equal(t, testCase.Output, ParseSearchParams(testCase.Input, 0))
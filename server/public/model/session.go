@@ -4,6 +4,7 @@
 package model
 
 import (
+	"encoding/json"
 	"net/http"
 	"slices"
 	"strconv"
@@ -31,6 +32,7 @@ const (
 	SessionPropLastRemovedVoIPDeviceId    = "last_removed_voip_device_id"
 	SessionPropDeviceNotificationDisabled = "device_notification_disabled"
 	SessionPropMobileVersion              = "mobile_version"
+	SessionPropWebPushSubscription        = "web_push_subscription"
 	SessionTypeUserAccessToken            = "UserAccessToken"
 	SessionTypeCloudKey                   = "CloudKey"
 	SessionTypeRemoteclusterToken         = "RemoteClusterToken"
@@ -162,6 +164,8 @@ func (s *Session) PreSave() {
 
 func (s *Session) Sanitize() {
 	s.Token = ""
+	// The subscription holds the secret used to encrypt the notifications of the session.
+	delete(s.Props, SessionPropWebPushSubscription)
 }
 
 func (s *Session) IsExpired() bool {
@@ -277,6 +281,20 @@ func (s *Session) IsGuest() bool {
 	return val == "true"
 }
 
+// GetWebPushSubscription returns the Web Push subscription attached to the session, if any.
+func (s *Session) GetWebPushSubscription() *WebPushSubscription {
+	val := s.Props[SessionPropWebPushSubscription]
+	if val == "" {
+		return nil
+	}
+
+	var subscription WebPushSubscription
+	if err := json.Unmarshal([]byte(val), &subscription); err != nil || subscription.IsValid() != nil {
+		return nil
+	}
+	return &subscription
+}
+
 func (s *Session) GetUserRoles() []string {
 	return strings.Fields(s.Roles)
 }
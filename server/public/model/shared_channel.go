@@ -124,6 +124,11 @@ type SharedChannelRemote struct {
 	LastPostCreateAt  int64  `json:"last_post_create_at"`
 	LastPostCreateID  string `json:"last_post_create_id"`
 	LastMembersSyncAt int64  `json:"last_members_sync_at"`
+
+	LastChannelUpdateAt       int64  `json:"last_channel_update_at"`
+	LastBookmarkUpdateAt      int64  `json:"last_bookmark_update_at"`
+	LastPropertyValueUpdateAt int64  `json:"last_property_value_update_at"`
+	LastPropertyValueId       string `json:"last_property_value_id"`
 }
 
 func (sc *SharedChannelRemote) IsValid() *AppError {
@@ -298,6 +303,45 @@ type SyncMsg struct {
 	MembershipChanges []*MembershipChangeMsg `json:"membership_changes,omitempty"`
 	Acknowledgements  []*PostAcknowledgement `json:"acknowledgements,omitempty"`
 	MentionTransforms map[string]string      `json:"mention_transforms,omitempty"`
+	ChannelInfo       *SyncChannelInfo       `json:"channel_info,omitempty"`
+	Bookmarks         []*ChannelBookmark     `json:"bookmarks,omitempty"`
+	PropertyValues    []*SyncPropertyValue   `json:"property_values,omitempty"`
+}
+
+// SyncChannelInfo carries the channel attributes that are kept in sync between
+// the instances of a shared channel.
+type SyncChannelInfo struct {
+	Header   string `json:"header" xml:"Header"`
+	Purpose  string `json:"purpose" xml:"Purpose"`
+	UpdateAt int64  `json:"update_at" xml:"UpdateAt"`
+}
+
+// SyncPropertyValue is a post property value as sent to remote clusters. Group and
+// field IDs differ between clusters, so the value references them by name, and option
+// based values (select, multiselect) hold option names instead of option IDs.
+type SyncPropertyValue struct {
+	PostId        string          `json:"post_id" xml:"PostId"`
+	GroupName     string          `json:"group_name" xml:"GroupName"`
+	FieldName     string          `json:"field_name" xml:"FieldName"`
+	FieldTargetId string          `json:"field_target_id,omitempty" xml:"FieldTargetId,omitempty"`
+	Value         json.RawMessage `json:"value" xml:"Value"`
+	UpdateAt      int64           `json:"update_at" xml:"UpdateAt"`
+	DeleteAt      int64           `json:"delete_at" xml:"DeleteAt"`
+}
+
+// SharedChannelContentCursor tracks how far the channel attributes, bookmarks and
+// post property values of a shared channel have been sent to a remote. Property values
+// are tracked by the time of their last change and their id, since many values can
+// change at the same time.
+type SharedChannelContentCursor struct {
+	LastChannelUpdateAt       int64
+	LastBookmarkUpdateAt      int64
+	LastPropertyValueUpdateAt int64
+	LastPropertyValueId       string
+}
+
+func (c SharedChannelContentCursor) IsEmpty() bool {
+	return c.LastChannelUpdateAt == 0 && c.LastBookmarkUpdateAt == 0 && c.LastPropertyValueUpdateAt == 0 && c.LastPropertyValueId == ""
 }
 
 func NewSyncMsg(channelID string) *SyncMsg {
@@ -332,6 +376,9 @@ type xmlSyncMsgFields struct {
 	Statuses          []*Status              `xml:"Statuses>Status,omitempty"`
 	MembershipChanges []*MembershipChangeMsg `xml:"MembershipChanges>MembershipChangeMsg,omitempty"`
 	Acknowledgements  []*PostAcknowledgement `xml:"Acknowledgements>PostAcknowledgement,omitempty"`
+	ChannelInfo       *SyncChannelInfo       `xml:"ChannelInfo,omitempty"`
+	Bookmarks         []*ChannelBookmark     `xml:"Bookmarks>ChannelBookmark,omitempty"`
+	PropertyValues    []*SyncPropertyValue   `xml:"PropertyValues>SyncPropertyValue,omitempty"`
 }
 
 // xmlSyncMsgUser wraps a User with an ID attribute for XML map serialization.
@@ -361,6 +408,9 @@ func (sm *SyncMsg) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		Statuses:          sm.Statuses,
 		MembershipChanges: sm.MembershipChanges,
 		Acknowledgements:  sm.Acknowledgements,
+		ChannelInfo:       sm.ChannelInfo,
+		Bookmarks:         sm.Bookmarks,
+		PropertyValues:    sm.PropertyValues,
 	}
 	if err := e.EncodeElement(fields, xml.StartElement{Name: xml.Name{Local: "Fields"}}); err != nil {
 		return err
@@ -445,6 +495,9 @@ func (sm *SyncMsg) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 				sm.Statuses = fields.Statuses
 				sm.MembershipChanges = fields.MembershipChanges
 				sm.Acknowledgements = fields.Acknowledgements
+				sm.ChannelInfo = fields.ChannelInfo
+				sm.Bookmarks = fields.Bookmarks
+				sm.PropertyValues = fields.PropertyValues
 
 			case "Users":
 				sm.Users = make(map[string]*User)
@@ -539,6 +592,14 @@ type SyncResponse struct {
 
 	StatusErrors []string `json:"status_errors" xml:"StatusErrors>Error"` // user IDs for which the status sync failed
 
+	ChannelInfoErrors []string `json:"channel_info_errors,omitempty" xml:"ChannelInfoErrors>Error,omitempty"`
+
+	BookmarksLastUpdateAt int64    `json:"bookmarks_last_update_at,omitempty" xml:"BookmarksLastUpdateAt,omitempty"`
+	BookmarkErrors        []string `json:"bookmark_errors,omitempty" xml:"BookmarkErrors>Error,omitempty"`
+
+	PropertyValuesLastUpdateAt int64    `json:"property_values_last_update_at,omitempty" xml:"PropertyValuesLastUpdateAt,omitempty"`
+	PropertyValueErrors        []string `json:"property_value_errors,omitempty" xml:"PropertyValueErrors>Error,omitempty"`
+
 	MembershipErrors []string `json:"membership_errors,omitempty" xml:"MembershipErrors>Error,omitempty"`
 }
 
@@ -131,6 +131,29 @@ func TestSyncMsgXMLRoundTrip(t *testing.T) {
 			"@olduser": "@newuser",
 			"@admin":   "@remote_admin",
 		},
+		ChannelInfo: &SyncChannelInfo{
+			Header:   "channel header",
+			Purpose:  "channel purpose",
+			UpdateAt: 4000,
+		},
+		Bookmarks: []*ChannelBookmark{
+			{
+				Id:          NewId(),
+				ChannelId:   NewId(),
+				DisplayName: "docs",
+				LinkUrl:     "https://example.com",
+				Type:        ChannelBookmarkLink,
+			},
+		},
+		PropertyValues: []*SyncPropertyValue{
+			{
+				PostId:    NewId(),
+				GroupName: "boards",
+				FieldName: "status",
+				Value:     []byte(`"Todo"`),
+				UpdateAt:  5000,
+			},
+		},
 	}
 
 	data, err := xml.MarshalIndent(msg, "", "  ")
@@ -156,6 +179,12 @@ func TestSyncMsgXMLRoundTrip(t *testing.T) {
 	assert.Len(t, decoded.Acknowledgements, 1)
 	assert.Equal(t, msg.Acknowledgements[0].AcknowledgedAt, decoded.Acknowledgements[0].AcknowledgedAt)
 	assert.Equal(t, msg.MentionTransforms, decoded.MentionTransforms)
+	assert.Equal(t, msg.ChannelInfo, decoded.ChannelInfo)
+	assert.Len(t, decoded.Bookmarks, 1)
+	assert.Equal(t, msg.Bookmarks[0].LinkUrl, decoded.Bookmarks[0].LinkUrl)
+	assert.Len(t, decoded.PropertyValues, 1)
+	assert.Equal(t, msg.PropertyValues[0].FieldName, decoded.PropertyValues[0].FieldName)
+	assert.JSONEq(t, string(msg.PropertyValues[0].Value), string(decoded.PropertyValues[0].Value))
 }
 
 func TestSyncMsgXMLUsersMap(t *testing.T) {
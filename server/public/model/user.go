@@ -925,7 +925,8 @@ func (u *User) IsOAuthUser() bool {
 	return u.AuthService == ServiceGitlab ||
 		u.AuthService == ServiceGoogle ||
 		u.AuthService == ServiceOffice365 ||
-		u.AuthService == ServiceOpenid
+		u.AuthService == ServiceOpenid ||
+		IsOpenIdProviderService(u.AuthService)
 }
 
 func (u *User) IsLDAPUser() bool {
@@ -950,7 +951,16 @@ func IsValidUserAuthService(service string) bool {
 		ServiceOpenid:
 		return true
 	}
-	return false
+	return IsOpenIdProviderService(service)
+}
+
+var openIdProviderNameRegex = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// IsOpenIdProviderService reports whether service is the auth service
+// of the users of a named OpenID Connect provider.
+func IsOpenIdProviderService(service string) bool {
+	name, ok := strings.CutPrefix(service, ServiceOpenIdProviderPrefix)
+	return ok && len(name) <= OpenIdProviderNameMaxLength && openIdProviderNameRegex.MatchString(name)
 }
 
 func (u *User) GetPreferredTimezone() string {
@@ -655,6 +655,7 @@ func TestIsValidUserAuthService(t *testing.T) {
 		ServiceGoogle,
 		ServiceOffice365,
 		ServiceOpenid,
+		ServiceOpenIdProviderPrefix + "staff",
 	}
 	for _, s := range valid {
 		t.Run("valid/"+s, func(t *testing.T) {
@@ -662,7 +663,7 @@ func TestIsValidUserAuthService(t *testing.T) {
 		})
 	}
 
-	invalid := []string{"", "not-a-real-service", UserAuthServiceMagicLink, "EMAIL"}
+	invalid := []string{"", "not-a-real-service", UserAuthServiceMagicLink, "EMAIL", ServiceOpenIdProviderPrefix}
 	for _, s := range invalid {
 		t.Run("invalid/"+s, func(t *testing.T) {
 			require.False(t, IsValidUserAuthService(s))
@@ -670,6 +671,17 @@ func TestIsValidUserAuthService(t *testing.T) {
 	}
 }
 
+func TestIsOpenIdProviderService(t *testing.T) {
+	assert.True(t, IsOpenIdProviderService("oidcstaff"))
+	assert.True(t, IsOpenIdProviderService("oidc"+strings.Repeat("a", OpenIdProviderNameMaxLength)))
+
+	assert.False(t, IsOpenIdProviderService("oidc"))
+	assert.False(t, IsOpenIdProviderService("oidc"+strings.Repeat("a", OpenIdProviderNameMaxLength+1)))
+	assert.False(t, IsOpenIdProviderService("oidcStaff"))
+	assert.False(t, IsOpenIdProviderService("oidc_staff"))
+	assert.False(t, IsOpenIdProviderService(ServiceOpenid))
+}
+
 func TestUserAuthIsValid(t *testing.T) {
 	authData := "test@test.com"
 
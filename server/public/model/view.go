@@ -15,7 +15,9 @@ import (
 type ViewType string
 
 const (
-	ViewTypeKanban ViewType = "kanban"
+	ViewTypeKanban   ViewType = "kanban"
+	ViewTypeTable    ViewType = "table"
+	ViewTypeCalendar ViewType = "calendar"
 
 	ViewTitleMaxRunes       = 256
 	ViewDescriptionMaxRunes = 1024
@@ -31,6 +33,13 @@ const (
 	BoardsStatusOptionComplete   = "Complete"
 
 	MaxKanbanColumns = 100
+
+	MaxTableColumns     = 100
+	MaxViewFilters      = 20
+	MaxViewFilterValues = 100
+
+	ViewSortDirectionAsc  = "asc"
+	ViewSortDirectionDesc = "desc"
 )
 
 // KanbanColumn represents a single column in a kanban view.
@@ -54,28 +63,126 @@ type KanbanProps struct {
 
 // ToProps converts KanbanProps to a StringInterface map for storage in View.Props.
 func (kp *KanbanProps) ToProps() (StringInterface, error) {
-	raw, err := json.Marshal(kp)
+	return viewPropsToMap("kanban", kp)
+}
+
+// KanbanPropsFromProps parses View.Props into a typed KanbanProps.
+func KanbanPropsFromProps(props StringInterface) (*KanbanProps, error) {
+	var kp KanbanProps
+	if err := viewPropsFromMap("kanban", props, &kp); err != nil {
+		return nil, err
+	}
+	return &kp, nil
+}
+
+// TableColumn is a column of a table view, showing the values of a property field.
+type TableColumn struct {
+	FieldID string `json:"field_id"`
+	Width   int    `json:"width,omitempty"`
+}
+
+// ViewSort defines the property field by which the posts of a view are sorted.
+type ViewSort struct {
+	FieldID   string `json:"field_id"`
+	Direction string `json:"direction"`
+}
+
+// ViewFilter restricts the posts of a view to those with a value for the property
+// field matching one of Values. For fields holding several values, such as
+// multiselect fields, it is enough for one of them to match.
+type ViewFilter struct {
+	FieldID string   `json:"field_id"`
+	Values  []string `json:"values"`
+}
+
+// TableProps is the typed representation of View.Props for table views.
+type TableProps struct {
+	Columns []TableColumn `json:"columns"`
+	Sort    *ViewSort     `json:"sort,omitempty"`
+	Filters []ViewFilter  `json:"filters,omitempty"`
+}
+
+// ToProps converts TableProps to a StringInterface map for storage in View.Props.
+func (tp *TableProps) ToProps() (StringInterface, error) {
+	return viewPropsToMap("table", tp)
+}
+
+// FieldIDs returns the IDs of all the property fields referenced by the table view.
+func (tp *TableProps) FieldIDs() []string {
+	ids := make([]string, 0, len(tp.Columns)+len(tp.Filters)+1)
+	for _, col := range tp.Columns {
+		ids = append(ids, col.FieldID)
+	}
+	if tp.Sort != nil {
+		ids = append(ids, tp.Sort.FieldID)
+	}
+	for _, filter := range tp.Filters {
+		ids = append(ids, filter.FieldID)
+	}
+	return ids
+}
+
+// TablePropsFromProps parses View.Props into a typed TableProps.
+func TablePropsFromProps(props StringInterface) (*TableProps, error) {
+	var tp TableProps
+	if err := viewPropsFromMap("table", props, &tp); err != nil {
+		return nil, err
+	}
+	return &tp, nil
+}
+
+// CalendarProps is the typed representation of View.Props for calendar views.
+// Posts are placed on the calendar according to their value for the date field.
+type CalendarProps struct {
+	DateFieldID string       `json:"date_field_id"`
+	Filters     []ViewFilter `json:"filters,omitempty"`
+}
+
+// ToProps converts CalendarProps to a StringInterface map for storage in View.Props.
+func (cp *CalendarProps) ToProps() (StringInterface, error) {
+	return viewPropsToMap("calendar", cp)
+}
+
+// FieldIDs returns the IDs of all the property fields referenced by the calendar view.
+func (cp *CalendarProps) FieldIDs() []string {
+	ids := make([]string, 0, len(cp.Filters)+1)
+	ids = append(ids, cp.DateFieldID)
+	for _, filter := range cp.Filters {
+		ids = append(ids, filter.FieldID)
+	}
+	return ids
+}
+
+// CalendarPropsFromProps parses View.Props into a typed CalendarProps.
+func CalendarPropsFromProps(props StringInterface) (*CalendarProps, error) {
+	var cp CalendarProps
+	if err := viewPropsFromMap("calendar", props, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+func viewPropsToMap(viewType string, v any) (StringInterface, error) {
+	raw, err := json.Marshal(v)
 	if err != nil {
-		return nil, fmt.Errorf("kanban props marshal: %w", err)
+		return nil, fmt.Errorf("%s props marshal: %w", viewType, err)
 	}
 	var m StringInterface
 	if err := json.Unmarshal(raw, &m); err != nil {
-		return nil, fmt.Errorf("kanban props unmarshal: %w", err)
+		return nil, fmt.Errorf("%s props unmarshal: %w", viewType, err)
 	}
 	return m, nil
 }
 
-// KanbanPropsFromProps parses View.Props into a typed KanbanProps.
-func KanbanPropsFromProps(props StringInterface) (*KanbanProps, error) {
+func viewPropsFromMap(viewType string, props StringInterface, v any) error {
 	raw, err := json.Marshal(props)
 	if err != nil {
-		return nil, fmt.Errorf("kanban props marshal: %w", err)
+		return fmt.Errorf("%s props marshal: %w", viewType, err)
 	}
-	var kp KanbanProps
-	if err := json.Unmarshal(raw, &kp); err != nil {
-		return nil, fmt.Errorf("kanban props unmarshal: %w", err)
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%s props unmarshal: %w", viewType, err)
 	}
-	return &kp, nil
+	return nil
 }
 
 type View struct {
@@ -107,6 +214,13 @@ type ViewsWithCount struct {
 const ViewQueryDefaultPerPage = 20
 const ViewQueryMaxPerPage = 200
 
+const ViewPostsQueryDefaultPerPage = 60
+const ViewPostsQueryMaxPerPage = 200
+
+// ViewCalendarMaxRange is the longest period, in milliseconds, for which the posts
+// of a calendar view can be fetched at once.
+const ViewCalendarMaxRange = 366 * 24 * 60 * 60 * 1000
+
 type ViewQueryOpts struct {
 	// Page is the 0-based page number for limit/offset pagination.
 	Page int
@@ -115,6 +229,25 @@ type ViewQueryOpts struct {
 	PerPage int
 }
 
+// ViewPostsQueryOpts are the options used to fetch the posts of table and calendar views.
+type ViewPostsQueryOpts struct {
+	// Page is the 0-based page number for limit/offset pagination.
+	Page int
+	// PerPage specifies the page size. Zero defaults to ViewPostsQueryDefaultPerPage (60).
+	// Values above ViewPostsQueryMaxPerPage (200) are clamped to ViewPostsQueryMaxPerPage.
+	PerPage int
+	// Start and End bound, in milliseconds, the dates of the posts returned for a
+	// calendar view. Start is inclusive and End is exclusive.
+	Start int64
+	End   int64
+}
+
+// IsValidCalendarRange checks that the options select a period no longer than
+// ViewCalendarMaxRange.
+func (o *ViewPostsQueryOpts) IsValidCalendarRange() bool {
+	return o.End > o.Start && o.End-o.Start <= ViewCalendarMaxRange
+}
+
 func (o *View) Auditable() map[string]any {
 	return map[string]any{
 		"id":         o.Id,
@@ -152,7 +285,7 @@ func (o *View) IsValid() *AppError {
 		return NewAppError("View.IsValid", "model.view.is_valid.creator_id.app_error", nil, "id="+o.Id, http.StatusBadRequest)
 	}
 
-	if o.Type != ViewTypeKanban {
+	if o.Type != ViewTypeKanban && o.Type != ViewTypeTable && o.Type != ViewTypeCalendar {
 		return NewAppError("View.IsValid", "model.view.is_valid.type.app_error", nil, "id="+o.Id, http.StatusBadRequest)
 	}
 
@@ -181,8 +314,13 @@ func (o *View) IsValid() *AppError {
 
 // validateViewProps validates the props map based on the view type.
 func validateViewProps(viewType ViewType, props StringInterface) *AppError {
-	if viewType == ViewTypeKanban {
+	switch viewType {
+	case ViewTypeKanban:
 		return validateKanbanProps(props)
+	case ViewTypeTable:
+		return validateTableProps(props)
+	case ViewTypeCalendar:
+		return validateCalendarProps(props)
 	}
 	return nil
 }
@@ -224,6 +362,79 @@ func validateKanbanProps(props StringInterface) *AppError {
 	return nil
 }
 
+func validateTableProps(props StringInterface) *AppError {
+	if props == nil {
+		return NewAppError("View.IsValid", "model.view.is_valid.props.table_required.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	table, err := TablePropsFromProps(props)
+	if err != nil {
+		return NewAppError("View.IsValid", "model.view.is_valid.props.table_invalid.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	if len(table.Columns) == 0 {
+		return NewAppError("View.IsValid", "model.view.is_valid.props.table_columns_empty.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(table.Columns) > MaxTableColumns {
+		return NewAppError("View.IsValid", "model.view.is_valid.props.table_columns_max.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for i, col := range table.Columns {
+		if !IsValidId(col.FieldID) {
+			return NewAppError("View.IsValid", "model.view.is_valid.props.table_column_field_id.app_error", map[string]any{"Index": i}, "", http.StatusBadRequest)
+		}
+		if col.Width < 0 {
+			return NewAppError("View.IsValid", "model.view.is_valid.props.table_column_width.app_error", map[string]any{"Index": i}, "", http.StatusBadRequest)
+		}
+	}
+
+	if table.Sort != nil {
+		if !IsValidId(table.Sort.FieldID) {
+			return NewAppError("View.IsValid", "model.view.is_valid.props.sort_field_id.app_error", nil, "", http.StatusBadRequest)
+		}
+		if table.Sort.Direction != ViewSortDirectionAsc && table.Sort.Direction != ViewSortDirectionDesc {
+			return NewAppError("View.IsValid", "model.view.is_valid.props.sort_direction.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	return validateViewFilters(table.Filters)
+}
+
+func validateCalendarProps(props StringInterface) *AppError {
+	if props == nil {
+		return NewAppError("View.IsValid", "model.view.is_valid.props.calendar_required.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	calendar, err := CalendarPropsFromProps(props)
+	if err != nil {
+		return NewAppError("View.IsValid", "model.view.is_valid.props.calendar_invalid.app_error", nil, err.Error(), http.StatusBadRequest)
+	}
+
+	if !IsValidId(calendar.DateFieldID) {
+		return NewAppError("View.IsValid", "model.view.is_valid.props.calendar_date_field_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return validateViewFilters(calendar.Filters)
+}
+
+func validateViewFilters(filters []ViewFilter) *AppError {
+	if len(filters) > MaxViewFilters {
+		return NewAppError("View.IsValid", "model.view.is_valid.props.filters_max.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for i, filter := range filters {
+		if !IsValidId(filter.FieldID) {
+			return NewAppError("View.IsValid", "model.view.is_valid.props.filter_field_id.app_error", map[string]any{"Index": i}, "", http.StatusBadRequest)
+		}
+		if len(filter.Values) == 0 || len(filter.Values) > MaxViewFilterValues {
+			return NewAppError("View.IsValid", "model.view.is_valid.props.filter_values.app_error", map[string]any{"Index": i}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
 func (o *View) PreSave() {
 	if o.Id == "" {
 		o.Id = NewId()
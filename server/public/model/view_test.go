@@ -467,3 +467,161 @@ func TestKanbanPropsRoundTrip(t *testing.T) {
 	assert.Equal(t, original.GroupBy.Columns[0].Name, parsed.GroupBy.Columns[0].Name)
 	assert.Equal(t, original.GroupBy.Columns[1].OptionIDs, parsed.GroupBy.Columns[1].OptionIDs)
 }
+
+func validTableView(tp *TableProps) *View {
+	props, _ := tp.ToProps()
+	v := validView()
+	v.Type = ViewTypeTable
+	v.Props = props
+	return v
+}
+
+func TestTablePropsValidation(t *testing.T) {
+	validTable := func() *TableProps {
+		return &TableProps{
+			Columns: []TableColumn{{FieldID: NewId()}, {FieldID: NewId(), Width: 200}},
+		}
+	}
+
+	t.Run("valid table accepted", func(t *testing.T) {
+		require.Nil(t, validTableView(validTable()).IsValid())
+	})
+
+	t.Run("valid table with sort and filters accepted", func(t *testing.T) {
+		tp := validTable()
+		tp.Sort = &ViewSort{FieldID: NewId(), Direction: ViewSortDirectionDesc}
+		tp.Filters = []ViewFilter{{FieldID: NewId(), Values: []string{NewId(), NewId()}}}
+		require.Nil(t, validTableView(tp).IsValid())
+	})
+
+	t.Run("nil props rejected for table", func(t *testing.T) {
+		v := validTableView(validTable())
+		v.Props = nil
+		appErr := v.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.table_required.app_error", appErr.Id)
+	})
+
+	t.Run("empty columns rejected", func(t *testing.T) {
+		appErr := validTableView(&TableProps{}).IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.table_columns_empty.app_error", appErr.Id)
+	})
+
+	t.Run("too many columns rejected", func(t *testing.T) {
+		tp := &TableProps{}
+		for range MaxTableColumns + 1 {
+			tp.Columns = append(tp.Columns, TableColumn{FieldID: NewId()})
+		}
+		appErr := validTableView(tp).IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.table_columns_max.app_error", appErr.Id)
+	})
+
+	t.Run("column invalid field_id rejected", func(t *testing.T) {
+		tp := validTable()
+		tp.Columns[1].FieldID = "not-a-valid-id"
+		appErr := validTableView(tp).IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.table_column_field_id.app_error", appErr.Id)
+	})
+
+	t.Run("column negative width rejected", func(t *testing.T) {
+		tp := validTable()
+		tp.Columns[0].Width = -1
+		appErr := validTableView(tp).IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.table_column_width.app_error", appErr.Id)
+	})
+
+	t.Run("sort invalid field_id rejected", func(t *testing.T) {
+		tp := validTable()
+		tp.Sort = &ViewSort{FieldID: "", Direction: ViewSortDirectionAsc}
+		appErr := validTableView(tp).IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.sort_field_id.app_error", appErr.Id)
+	})
+
+	t.Run("sort invalid direction rejected", func(t *testing.T) {
+		tp := validTable()
+		tp.Sort = &ViewSort{FieldID: NewId(), Direction: "sideways"}
+		appErr := validTableView(tp).IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.sort_direction.app_error", appErr.Id)
+	})
+
+	t.Run("filter without values rejected", func(t *testing.T) {
+		tp := validTable()
+		tp.Filters = []ViewFilter{{FieldID: NewId()}}
+		appErr := validTableView(tp).IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.filter_values.app_error", appErr.Id)
+	})
+
+	t.Run("filter invalid field_id rejected", func(t *testing.T) {
+		tp := validTable()
+		tp.Filters = []ViewFilter{{FieldID: "x", Values: []string{"a"}}}
+		appErr := validTableView(tp).IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.filter_field_id.app_error", appErr.Id)
+	})
+
+	t.Run("too many filters rejected", func(t *testing.T) {
+		tp := validTable()
+		for range MaxViewFilters + 1 {
+			tp.Filters = append(tp.Filters, ViewFilter{FieldID: NewId(), Values: []string{"a"}})
+		}
+		appErr := validTableView(tp).IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.filters_max.app_error", appErr.Id)
+	})
+
+	t.Run("field ids include columns, sort and filters", func(t *testing.T) {
+		tp := validTable()
+		tp.Sort = &ViewSort{FieldID: NewId(), Direction: ViewSortDirectionAsc}
+		tp.Filters = []ViewFilter{{FieldID: NewId(), Values: []string{"a"}}}
+		assert.ElementsMatch(t, []string{tp.Columns[0].FieldID, tp.Columns[1].FieldID, tp.Sort.FieldID, tp.Filters[0].FieldID}, tp.FieldIDs())
+	})
+}
+
+func TestCalendarPropsValidation(t *testing.T) {
+	calendarView := func(cp *CalendarProps) *View {
+		props, _ := cp.ToProps()
+		v := validView()
+		v.Type = ViewTypeCalendar
+		v.Props = props
+		return v
+	}
+
+	t.Run("valid calendar accepted", func(t *testing.T) {
+		require.Nil(t, calendarView(&CalendarProps{DateFieldID: NewId()}).IsValid())
+	})
+
+	t.Run("nil props rejected for calendar", func(t *testing.T) {
+		v := calendarView(&CalendarProps{DateFieldID: NewId()})
+		v.Props = nil
+		appErr := v.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.calendar_required.app_error", appErr.Id)
+	})
+
+	t.Run("missing date_field_id rejected", func(t *testing.T) {
+		appErr := calendarView(&CalendarProps{}).IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.calendar_date_field_id.app_error", appErr.Id)
+	})
+
+	t.Run("invalid filter rejected", func(t *testing.T) {
+		appErr := calendarView(&CalendarProps{DateFieldID: NewId(), Filters: []ViewFilter{{FieldID: NewId()}}}).IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.view.is_valid.props.filter_values.app_error", appErr.Id)
+	})
+}
+
+func TestViewPostsQueryOptsIsValidCalendarRange(t *testing.T) {
+	assert.True(t, (&ViewPostsQueryOpts{Start: 0, End: 1}).IsValidCalendarRange())
+	assert.True(t, (&ViewPostsQueryOpts{Start: 1000, End: 1000 + ViewCalendarMaxRange}).IsValidCalendarRange())
+	assert.False(t, (&ViewPostsQueryOpts{Start: 1000, End: 1000}).IsValidCalendarRange())
+	assert.False(t, (&ViewPostsQueryOpts{Start: 1000, End: 999}).IsValidCalendarRange())
+	assert.False(t, (&ViewPostsQueryOpts{Start: 0, End: ViewCalendarMaxRange + 1}).IsValidCalendarRange())
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+)
+
+const (
+	webPushP256dhKeyLength = 65 // An uncompressed P-256 point.
+	webPushAuthKeyLength   = 16
+
+	WebPushSubscriptionEndpointMaxLength = 1024
+)
+
+// WebPushSubscription is the subscription of a browser to the Web Push
+// notifications of a session, as returned by PushSubscription.toJSON().
+type WebPushSubscription struct {
+	Endpoint string                  `json:"endpoint"`
+	Keys     WebPushSubscriptionKeys `json:"keys"`
+}
+
+// WebPushSubscriptionKeys holds the keys used to encrypt the notifications
+// sent to a subscription, base64url encoded.
+type WebPushSubscriptionKeys struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+func (s *WebPushSubscription) IsValid() *AppError {
+	if len(s.Endpoint) > WebPushSubscriptionEndpointMaxLength {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.endpoint.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	u, err := url.Parse(s.Endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.endpoint.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if key, err := s.P256dhKey(); err != nil || len(key) != webPushP256dhKeyLength {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.p256dh.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if key, err := s.AuthKey(); err != nil || len(key) != webPushAuthKeyLength {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.auth.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// P256dhKey returns the decoded public key of the user agent.
+func (s *WebPushSubscription) P256dhKey() ([]byte, error) {
+	return decodeWebPushKey(s.Keys.P256dh)
+}
+
+// AuthKey returns the decoded authentication secret of the user agent.
+func (s *WebPushSubscription) AuthKey() ([]byte, error) {
+	return decodeWebPushKey(s.Keys.Auth)
+}
+
+// Browsers encode keys as unpadded base64url, but some libraries add padding.
+func decodeWebPushKey(key string) ([]byte, error) {
+	if decoded, err := base64.RawURLEncoding.DecodeString(key); err == nil {
+		return decoded, nil
+	}
+	return base64.URLEncoding.DecodeString(key)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebPushSubscription(t *testing.T) *WebPushSubscription {
+	t.Helper()
+
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return &WebPushSubscription{
+		Endpoint: "https://push.example.com/send/" + NewId(),
+		Keys: WebPushSubscriptionKeys{
+			P256dh: base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+			Auth:   base64.RawURLEncoding.EncodeToString(make([]byte, 16)),
+		},
+	}
+}
+
+func TestWebPushSubscriptionIsValid(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		require.Nil(t, newTestWebPushSubscription(t).IsValid())
+	})
+
+	t.Run("padded keys", func(t *testing.T) {
+		s := newTestWebPushSubscription(t)
+		s.Keys.Auth = base64.URLEncoding.EncodeToString(make([]byte, 16))
+		require.Nil(t, s.IsValid())
+	})
+
+	t.Run("invalid endpoint", func(t *testing.T) {
+		for _, endpoint := range []string{
+			"",
+			"http://push.example.com/send",
+			"https://",
+			"https://push.example.com/" + strings.Repeat("a", WebPushSubscriptionEndpointMaxLength),
+		} {
+			s := newTestWebPushSubscription(t)
+			s.Endpoint = endpoint
+			appErr := s.IsValid()
+			require.NotNil(t, appErr, endpoint)
+			assert.Equal(t, "model.web_push_subscription.is_valid.endpoint.app_error", appErr.Id)
+		}
+	})
+
+	t.Run("invalid p256dh key", func(t *testing.T) {
+		s := newTestWebPushSubscription(t)
+		s.Keys.P256dh = base64.RawURLEncoding.EncodeToString(make([]byte, 32))
+		appErr := s.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.web_push_subscription.is_valid.p256dh.app_error", appErr.Id)
+	})
+
+	t.Run("invalid auth key", func(t *testing.T) {
+		s := newTestWebPushSubscription(t)
+		s.Keys.Auth = "not base64!"
+		appErr := s.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.web_push_subscription.is_valid.auth.app_error", appErr.Id)
+	})
+}
+
+func TestSessionGetWebPushSubscription(t *testing.T) {
+	subscription := newTestWebPushSubscription(t)
+	data, err := json.Marshal(subscription)
+	require.NoError(t, err)
+
+	t.Run("attached", func(t *testing.T) {
+		s := &Session{}
+		s.AddProp(SessionPropWebPushSubscription, string(data))
+		assert.Equal(t, subscription, s.GetWebPushSubscription())
+	})
+
+	t.Run("not attached", func(t *testing.T) {
+		s := &Session{}
+		assert.Nil(t, s.GetWebPushSubscription())
+
+		s.AddProp(SessionPropWebPushSubscription, "")
+		assert.Nil(t, s.GetWebPushSubscription())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		s := &Session{}
+		s.AddProp(SessionPropWebPushSubscription, `{"endpoint":"http://push.example.com"}`)
+		assert.Nil(t, s.GetWebPushSubscription())
+	})
+
+	t.Run("removed when sanitized", func(t *testing.T) {
+		s := &Session{}
+		s.AddProp(SessionPropWebPushSubscription, string(data))
+		s.Sanitize()
+		assert.Nil(t, s.GetWebPushSubscription())
+	})
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	WebAuthnCredentialNameMaxRunes = 64
+	WebAuthnCredentialIdMaxLength  = 1400
+	WebAuthnMaxCredentialsPerUser  = 20
+
+	// WebAuthnTimeout is how long, in milliseconds, the browser waits for the user to
+	// interact with their authenticator.
+	WebAuthnTimeout = 1000 * 60 * 2 // 2 minutes
+
+	MfaRecoveryCodeCount = 10
+)
+
+// WebAuthnCredential is a WebAuthn authenticator, such as a security key or a passkey,
+// registered by a user as a second factor.
+type WebAuthnCredential struct {
+	Id     string `json:"id"`
+	UserId string `json:"user_id"`
+	Name   string `json:"name"`
+	// CredentialId is the base64url encoded ID the authenticator identifies the credential with.
+	CredentialId string `json:"credential_id"`
+	// PublicKey is the COSE encoded public key of the credential.
+	PublicKey  []byte `json:"-"`
+	SignCount  int64  `json:"-"`
+	CreateAt   int64  `json:"create_at"`
+	LastUsedAt int64  `json:"last_used_at"`
+}
+
+func (c *WebAuthnCredential) PreSave() {
+	if c.Id == "" {
+		c.Id = NewId()
+	}
+
+	c.Name = strings.TrimSpace(c.Name)
+	c.CreateAt = GetMillis()
+}
+
+func (c *WebAuthnCredential) IsValid() *AppError {
+	if !IsValidId(c.Id) {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(c.UserId) {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.user_id.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.Name == "" || utf8.RuneCountInString(c.Name) > WebAuthnCredentialNameMaxRunes {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.name.app_error", map[string]any{"MaxLength": WebAuthnCredentialNameMaxRunes}, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CredentialId == "" || len(c.CredentialId) > WebAuthnCredentialIdMaxLength {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.credential_id.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if len(c.PublicKey) == 0 {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.public_key.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CreateAt == 0 {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.create_at.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// The following types mirror the JSON serialization of the WebAuthn browser API, as
+// produced by PublicKeyCredential.toJSON() and consumed by
+// PublicKeyCredential.parseCreationOptionsFromJSON() and parseRequestOptionsFromJSON(),
+// hence their camel-cased fields. Binary values are base64url encoded.
+
+type WebAuthnRelyingParty struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WebAuthnUserEntity struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type WebAuthnCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type WebAuthnCredentialDescriptor struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+type WebAuthnAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// WebAuthnCreationOptions are the options of a registration ceremony, passed to
+// navigator.credentials.create().
+type WebAuthnCreationOptions struct {
+	Challenge              string                         `json:"challenge"`
+	RP                     WebAuthnRelyingParty           `json:"rp"`
+	User                   WebAuthnUserEntity             `json:"user"`
+	PubKeyCredParams       []WebAuthnCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                          `json:"timeout"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection WebAuthnAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                         `json:"attestation"`
+}
+
+// WebAuthnRequestOptions are the options of an authentication ceremony, passed to
+// navigator.credentials.get().
+type WebAuthnRequestOptions struct {
+	Challenge        string                         `json:"challenge"`
+	Timeout          int64                          `json:"timeout"`
+	RPId             string                         `json:"rpId"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                         `json:"userVerification"`
+}
+
+type WebAuthnAttestationResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AttestationObject string `json:"attestationObject"`
+}
+
+// WebAuthnAttestation is the credential returned by navigator.credentials.create().
+type WebAuthnAttestation struct {
+	Id       string                      `json:"id"`
+	RawId    string                      `json:"rawId"`
+	Type     string                      `json:"type"`
+	Response WebAuthnAttestationResponse `json:"response"`
+}
+
+type WebAuthnAssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"userHandle,omitempty"`
+}
+
+// WebAuthnAssertion is the credential returned by navigator.credentials.get().
+type WebAuthnAssertion struct {
+	Id       string                    `json:"id"`
+	RawId    string                    `json:"rawId"`
+	Type     string                    `json:"type"`
+	Response WebAuthnAssertionResponse `json:"response"`
+}
+
+// WebAuthnRegistration completes the registration of a WebAuthn credential.
+type WebAuthnRegistration struct {
+	Name       string               `json:"name"`
+	Credential *WebAuthnAttestation `json:"credential"`
+}
+
+// MfaRecoveryCodes are single-use codes a user can sign in with in place of their
+// second factor, e.g. after losing their authenticator.
+type MfaRecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
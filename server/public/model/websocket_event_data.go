@@ -0,0 +1,184 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WebSocketHelloData is the data of the hello event, sent when a connection is opened
+// or when the server could not resume a connection.
+type WebSocketHelloData struct {
+	ConnectionID   string `json:"connection_id"`
+	ServerVersion  string `json:"server_version"`
+	ServerHostname string `json:"server_hostname"`
+}
+
+// WebSocketPostedData is the data of the posted event.
+type WebSocketPostedData struct {
+	Post               *Post
+	ChannelType        ChannelType
+	ChannelDisplayName string
+	ChannelName        string
+	SenderName         string
+	TeamID             string
+	SetOnline          bool
+	HasImage           bool
+	HasOtherFile       bool
+	// Mentions and Followers only contain the receiving user, when mentioned or following the thread.
+	Mentions  []string
+	Followers []string
+}
+
+// WebSocketPostData is the data of the post_edited and post_deleted events.
+type WebSocketPostData struct {
+	Post *Post
+}
+
+// WebSocketReactionData is the data of the reaction_added and reaction_removed events.
+type WebSocketReactionData struct {
+	Reaction *Reaction
+}
+
+// WebSocketTypingData is the data of the typing event. The channel is the one of the broadcast.
+type WebSocketTypingData struct {
+	UserID   string `json:"user_id"`
+	ParentID string `json:"parent_id"`
+}
+
+// WebSocketStatusChangeData is the data of the status_change event.
+type WebSocketStatusChangeData struct {
+	UserID string `json:"user_id"`
+	Status string `json:"status"`
+}
+
+// webSocketPostedDataJSON mirrors the data of the posted event, where the post, mentions
+// and followers are JSON encoded strings.
+type webSocketPostedDataJSON struct {
+	Post               string      `json:"post"`
+	ChannelType        ChannelType `json:"channel_type"`
+	ChannelDisplayName string      `json:"channel_display_name"`
+	ChannelName        string      `json:"channel_name"`
+	SenderName         string      `json:"sender_name"`
+	TeamID             string      `json:"team_id"`
+	SetOnline          bool        `json:"set_online"`
+	Image              string      `json:"image"`
+	OtherFile          string      `json:"otherFile"`
+	Mentions           string      `json:"mentions"`
+	Followers          string      `json:"followers"`
+}
+
+// DecodeData decodes the data of the event into the typed struct of its event type:
+// *WebSocketHelloData, *WebSocketPostedData, *WebSocketPostData, *WebSocketReactionData,
+// *WebSocketTypingData, *WebSocketStatusChangeData or *WebSocketGap. It returns nil
+// without error for the other event types, whose data is only available through GetData.
+func (ev *WebSocketEvent) DecodeData() (any, error) {
+	switch ev.EventType() {
+	case WebsocketEventHello:
+		var data WebSocketHelloData
+		if err := ev.decodeData(&data); err != nil {
+			return nil, err
+		}
+		return &data, nil
+
+	case WebsocketEventPosted:
+		var raw webSocketPostedDataJSON
+		if err := ev.decodeData(&raw); err != nil {
+			return nil, err
+		}
+		data := WebSocketPostedData{
+			ChannelType:        raw.ChannelType,
+			ChannelDisplayName: raw.ChannelDisplayName,
+			ChannelName:        raw.ChannelName,
+			SenderName:         raw.SenderName,
+			TeamID:             raw.TeamID,
+			SetOnline:          raw.SetOnline,
+			HasImage:           raw.Image == "true",
+			HasOtherFile:       raw.OtherFile == "true",
+		}
+		if err := decodeWebSocketJSONString("post", raw.Post, &data.Post); err != nil {
+			return nil, err
+		}
+		if err := decodeWebSocketJSONString("mentions", raw.Mentions, &data.Mentions); err != nil {
+			return nil, err
+		}
+		if err := decodeWebSocketJSONString("followers", raw.Followers, &data.Followers); err != nil {
+			return nil, err
+		}
+		return &data, nil
+
+	case WebsocketEventPostEdited, WebsocketEventPostDeleted:
+		var raw struct {
+			Post string `json:"post"`
+		}
+		if err := ev.decodeData(&raw); err != nil {
+			return nil, err
+		}
+		var data WebSocketPostData
+		if err := decodeWebSocketJSONString("post", raw.Post, &data.Post); err != nil {
+			return nil, err
+		}
+		return &data, nil
+
+	case WebsocketEventReactionAdded, WebsocketEventReactionRemoved:
+		var raw struct {
+			Reaction string `json:"reaction"`
+		}
+		if err := ev.decodeData(&raw); err != nil {
+			return nil, err
+		}
+		var data WebSocketReactionData
+		if err := decodeWebSocketJSONString("reaction", raw.Reaction, &data.Reaction); err != nil {
+			return nil, err
+		}
+		return &data, nil
+
+	case WebsocketEventTyping:
+		var data WebSocketTypingData
+		if err := ev.decodeData(&data); err != nil {
+			return nil, err
+		}
+		return &data, nil
+
+	case WebsocketEventStatusChange:
+		var data WebSocketStatusChangeData
+		if err := ev.decodeData(&data); err != nil {
+			return nil, err
+		}
+		return &data, nil
+
+	case WebsocketEventGap:
+		var data WebSocketGap
+		if err := ev.decodeData(&data); err != nil {
+			return nil, err
+		}
+		return &data, nil
+	}
+
+	return nil, nil
+}
+
+// decodeData decodes the data of the event into v, going through JSON so that the data
+// of events received from the server and of events built in process decode the same way.
+func (ev *WebSocketEvent) decodeData(v any) error {
+	buf, err := json.Marshal(ev.data)
+	if err != nil {
+		return fmt.Errorf("failed to encode the data of the %s event: %w", ev.event, err)
+	}
+	if err := json.Unmarshal(buf, v); err != nil {
+		return fmt.Errorf("failed to decode the data of the %s event: %w", ev.event, err)
+	}
+	return nil
+}
+
+func decodeWebSocketJSONString(key, value string, v any) error {
+	if value == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(value), v); err != nil {
+		return fmt.Errorf("failed to decode the %s field of the event data: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocketEventDecodeData(t *testing.T) {
+	post := &Post{Id: NewId(), ChannelId: NewId(), UserId: NewId(), Message: "hello"}
+	postJSON, err := post.ToJSON()
+	require.NoError(t, err)
+
+	reaction := &Reaction{UserId: NewId(), PostId: post.Id, EmojiName: "smile"}
+	reactionJSON, err := json.Marshal(reaction)
+	require.NoError(t, err)
+
+	// The events are sent through JSON, as they are received from the server.
+	roundTrip := func(t *testing.T, event *WebSocketEvent) *WebSocketEvent {
+		t.Helper()
+
+		buf, err := event.ToJSON()
+		require.NoError(t, err)
+		event, err = WebSocketEventFromJSON(bytes.NewReader(buf))
+		require.NoError(t, err)
+		return event
+	}
+
+	t.Run("hello", func(t *testing.T) {
+		event := NewWebSocketEvent(WebsocketEventHello, "", "", "", nil, "")
+		event.Add("connection_id", "connid")
+		event.Add("server_version", "10.0.0")
+
+		data, err := roundTrip(t, event).DecodeData()
+		require.NoError(t, err)
+		assert.Equal(t, &WebSocketHelloData{ConnectionID: "connid", ServerVersion: "10.0.0"}, data)
+	})
+
+	t.Run("posted", func(t *testing.T) {
+		event := NewWebSocketEvent(WebsocketEventPosted, "", post.ChannelId, "", nil, "")
+		event.Add("post", postJSON)
+		event.Add("channel_type", ChannelTypeOpen)
+		event.Add("channel_display_name", "Town Square")
+		event.Add("channel_name", "town-square")
+		event.Add("sender_name", "@user")
+		event.Add("team_id", "teamid")
+		event.Add("set_online", true)
+		event.Add("image", "true")
+		event.Add("mentions", ArrayToJSON([]string{"userid"}))
+
+		for name, event := range map[string]*WebSocketEvent{
+			"received": roundTrip(t, event),
+			"built":    event,
+		} {
+			t.Run(name, func(t *testing.T) {
+				data, err := event.DecodeData()
+				require.NoError(t, err)
+
+				posted, ok := data.(*WebSocketPostedData)
+				require.True(t, ok)
+				assert.Equal(t, post.Id, posted.Post.Id)
+				assert.Equal(t, "hello", posted.Post.Message)
+				assert.Equal(t, ChannelTypeOpen, posted.ChannelType)
+				assert.Equal(t, "Town Square", posted.ChannelDisplayName)
+				assert.Equal(t, "town-square", posted.ChannelName)
+				assert.Equal(t, "@user", posted.SenderName)
+				assert.Equal(t, "teamid", posted.TeamID)
+				assert.True(t, posted.SetOnline)
+				assert.True(t, posted.HasImage)
+				assert.False(t, posted.HasOtherFile)
+				assert.Equal(t, []string{"userid"}, posted.Mentions)
+				assert.Nil(t, posted.Followers)
+			})
+		}
+	})
+
+	t.Run("post edited and deleted", func(t *testing.T) {
+		for _, eventType := range []WebsocketEventType{WebsocketEventPostEdited, WebsocketEventPostDeleted} {
+			event := NewWebSocketEvent(eventType, "", post.ChannelId, "", nil, "")
+			event.Add("post", postJSON)
+
+			data, err := roundTrip(t, event).DecodeData()
+			require.NoError(t, err)
+			require.IsType(t, &WebSocketPostData{}, data)
+			assert.Equal(t, post.Id, data.(*WebSocketPostData).Post.Id)
+		}
+	})
+
+	t.Run("reaction", func(t *testing.T) {
+		event := NewWebSocketEvent(WebsocketEventReactionAdded, "", post.ChannelId, "", nil, "")
+		event.Add("reaction", string(reactionJSON))
+
+		data, err := roundTrip(t, event).DecodeData()
+		require.NoError(t, err)
+		assert.Equal(t, &WebSocketReactionData{Reaction: reaction}, data)
+	})
+
+	t.Run("typing", func(t *testing.T) {
+		event := NewWebSocketEvent(WebsocketEventTyping, "", post.ChannelId, "", nil, "")
+		event.Add("user_id", "userid")
+		event.Add("parent_id", "parentid")
+
+		data, err := roundTrip(t, event).DecodeData()
+		require.NoError(t, err)
+		assert.Equal(t, &WebSocketTypingData{UserID: "userid", ParentID: "parentid"}, data)
+	})
+
+	t.Run("status change", func(t *testing.T) {
+		event := NewWebSocketEvent(WebsocketEventStatusChange, "", "", "userid", nil, "")
+		event.Add("user_id", "userid")
+		event.Add("status", StatusAway)
+
+		data, err := roundTrip(t, event).DecodeData()
+		require.NoError(t, err)
+		assert.Equal(t, &WebSocketStatusChangeData{UserID: "userid", Status: StatusAway}, data)
+	})
+
+	t.Run("event without typed data", func(t *testing.T) {
+		event := NewWebSocketEvent(WebsocketEventMultipleChannelsViewed, "", "", "", nil, "")
+		event.Add("channel_id", "channelid")
+
+		data, err := roundTrip(t, event).DecodeData()
+		require.NoError(t, err)
+		assert.Nil(t, data)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		event := NewWebSocketEvent(WebsocketEventPosted, "", "", "", nil, "")
+		event.Add("post", "{")
+
+		_, err := roundTrip(t, event).DecodeData()
+		require.Error(t, err)
+	})
+}
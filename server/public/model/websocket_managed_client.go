@@ -0,0 +1,463 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	ManagedWebSocketDefaultMinReconnectDelay = 500 * time.Millisecond
+	ManagedWebSocketDefaultMaxReconnectDelay = 30 * time.Second
+
+	managedWebSocketWriteWait   = 10 * time.Second
+	managedWebSocketPingTimeout = (60 + PingTimeoutBufferSeconds) * time.Second
+)
+
+var ErrWebSocketNotConnected = errors.New("websocket client is not connected")
+
+// WebsocketEventGap is the type of the events a ManagedWebSocketClient sends on its EventChannel,
+// in place of the events it missed. Its data decodes to a *WebSocketGap.
+const WebsocketEventGap WebsocketEventType = "websocket_gap"
+
+// WebSocketGapReason tells why events were missed.
+type WebSocketGapReason string
+
+const (
+	// WebSocketGapConnectionReset means the server could not resume the connection, either because
+	// it was restarted, the connection expired or the missed events are no longer queued. All the
+	// events sent on the previous connection since ExpectedSequence may have been missed.
+	WebSocketGapConnectionReset WebSocketGapReason = "connection_reset"
+	// WebSocketGapSequence means the events from ExpectedSequence to ReceivedSequence excluded were missed.
+	WebSocketGapSequence WebSocketGapReason = "sequence"
+)
+
+// WebSocketGap reports events missed by a ManagedWebSocketClient. Clients that keep state
+// from the events should fetch it again through the REST API when receiving a gap.
+type WebSocketGap struct {
+	Reason WebSocketGapReason `json:"reason"`
+	// ConnectionID is the ID of the connection the events were missed on.
+	ConnectionID string `json:"connection_id"`
+	// NewConnectionID is the ID of the new connection when the connection was reset.
+	NewConnectionID string `json:"new_connection_id,omitempty"`
+	// ExpectedSequence is the sequence number of the first missed event.
+	ExpectedSequence int64 `json:"expected_sequence"`
+	// ReceivedSequence is the sequence number of the event received instead.
+	ReceivedSequence int64 `json:"received_sequence"`
+}
+
+func (gap *WebSocketGap) toEvent() *WebSocketEvent {
+	event := NewWebSocketEvent(WebsocketEventGap, "", "", "", nil, "")
+	event.Add("reason", gap.Reason)
+	event.Add("connection_id", gap.ConnectionID)
+	if gap.NewConnectionID != "" {
+		event.Add("new_connection_id", gap.NewConnectionID)
+	}
+	event.Add("expected_sequence", gap.ExpectedSequence)
+	event.Add("received_sequence", gap.ReceivedSequence)
+	return event
+}
+
+// ManagedWebSocketClientOptions configures a ManagedWebSocketClient. The zero value uses the defaults.
+type ManagedWebSocketClientOptions struct {
+	// Dialer opens the connections, websocket.DefaultDialer by default.
+	Dialer *websocket.Dialer
+	// MinReconnectDelay and MaxReconnectDelay bound the exponential backoff between reconnection
+	// attempts. Each delay is jittered between half of its value and its value.
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+	// MaxReconnectAttempts is the number of consecutive failed reconnection attempts after which
+	// the client gives up. Zero retries until the client is closed.
+	MaxReconnectAttempts int
+}
+
+// ManagedWebSocketClient is a WebSocket client that reconnects on its own when the connection
+// drops. It resumes the connection with its connection ID and the sequence number of the next
+// event, so that the server replays the events queued while the client was disconnected, and
+// reports the events that could not be replayed with a WebsocketEventGap event, sent in their place.
+// A client must read from EventChannel and ResponseChannel to prevent
+// deadlocks from occurring in the program. The channels are closed once the client stops.
+type ManagedWebSocketClient struct {
+	URL       string // The location of the server like "ws://localhost:8065"
+	AuthToken string // The token used to open the WebSocket connections
+
+	EventChannel    chan *WebSocketEvent    // The channel used to receive the events pushed from the server, in order and without duplicates
+	ResponseChannel chan *WebSocketResponse // The channel used to receive responses for requests made to the server
+
+	options ManagedWebSocketClientOptions
+
+	// mut guards the fields below, which are written by the read loop.
+	mut          sync.Mutex
+	conn         *websocket.Conn
+	connectionID string
+	nextSequence int64
+	sequence     int64
+	err          error
+
+	// writeMut serializes the writes on the connection.
+	writeMut sync.Mutex
+
+	quit      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewManagedWebSocketClient opens a WebSocket connection to the server and starts a client
+// that reconnects on its own. Connections are authenticated with the Authorization header,
+// which the server requires to resume them.
+func NewManagedWebSocketClient(url, authToken string, options *ManagedWebSocketClientOptions) (*ManagedWebSocketClient, error) {
+	client := &ManagedWebSocketClient{
+		URL:             url,
+		AuthToken:       authToken,
+		EventChannel:    make(chan *WebSocketEvent, 100),
+		ResponseChannel: make(chan *WebSocketResponse, 100),
+		sequence:        1,
+		quit:            make(chan struct{}),
+		done:            make(chan struct{}),
+	}
+	if options != nil {
+		client.options = *options
+	}
+	if client.options.Dialer == nil {
+		client.options.Dialer = websocket.DefaultDialer
+	}
+	if client.options.MinReconnectDelay <= 0 {
+		client.options.MinReconnectDelay = ManagedWebSocketDefaultMinReconnectDelay
+	}
+	if client.options.MaxReconnectDelay < client.options.MinReconnectDelay {
+		client.options.MaxReconnectDelay = max(ManagedWebSocketDefaultMaxReconnectDelay, client.options.MinReconnectDelay)
+	}
+
+	conn, err := client.dial()
+	if err != nil {
+		return nil, NewAppError("NewManagedWebSocketClient", "model.websocket_client.connect_fail.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	go client.run(conn)
+
+	return client, nil
+}
+
+// ConnectionID returns the ID of the connection, which is empty until the server says hello.
+func (c *ManagedWebSocketClient) ConnectionID() string {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.connectionID
+}
+
+// NextSequence returns the sequence number of the next event expected from the server.
+func (c *ManagedWebSocketClient) NextSequence() int64 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.nextSequence
+}
+
+// Done returns a channel closed once the client stops, either because it was closed or because
+// it gave up reconnecting.
+func (c *ManagedWebSocketClient) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns the error that made the client give up reconnecting, if any.
+func (c *ManagedWebSocketClient) Err() error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.err
+}
+
+// Close closes the connection and stops reconnecting. It waits for the client to stop.
+func (c *ManagedWebSocketClient) Close() {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+
+		c.mut.Lock()
+		conn := c.conn
+		c.mut.Unlock()
+		if conn != nil {
+			c.writeMut.Lock()
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(managedWebSocketWriteWait))
+			c.writeMut.Unlock()
+			conn.Close()
+		}
+	})
+	<-c.done
+}
+
+// SendMessage sends a request to the server. It fails when the client is reconnecting,
+// as requests are not queued until the connection is back.
+func (c *ManagedWebSocketClient) SendMessage(action string, data map[string]any) error {
+	c.mut.Lock()
+	conn := c.conn
+	req := &WebSocketRequest{
+		Seq:    c.sequence,
+		Action: action,
+		Data:   data,
+	}
+	c.sequence++
+	c.mut.Unlock()
+
+	if conn == nil {
+		return ErrWebSocketNotConnected
+	}
+
+	c.writeMut.Lock()
+	defer c.writeMut.Unlock()
+	if err := conn.SetWriteDeadline(time.Now().Add(managedWebSocketWriteWait)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(req)
+}
+
+// UserTyping will push a user_typing event out to all connected users
+// who are in the specified channel
+func (c *ManagedWebSocketClient) UserTyping(channelID, parentID string) error {
+	return c.SendMessage("user_typing", map[string]any{
+		"channel_id": channelID,
+		"parent_id":  parentID,
+	})
+}
+
+func (c *ManagedWebSocketClient) dial() (*websocket.Conn, error) {
+	c.mut.Lock()
+	query := url.Values{}
+	if c.connectionID != "" {
+		query.Set("connection_id", c.connectionID)
+		query.Set("sequence_number", strconv.FormatInt(c.nextSequence, 10))
+	}
+	c.mut.Unlock()
+
+	connectURL := c.URL + APIURLSuffix + "/websocket"
+	if len(query) > 0 {
+		connectURL += "?" + query.Encode()
+	}
+	header := http.Header{
+		"Authorization": []string{"Bearer " + c.AuthToken},
+	}
+
+	conn, resp, err := c.options.Dialer.Dial(connectURL, header)
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			return nil, &permanentWebSocketError{err}
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// permanentWebSocketError is an error that reconnecting can't solve.
+type permanentWebSocketError struct {
+	err error
+}
+
+func (e *permanentWebSocketError) Error() string {
+	return "websocket connection refused: " + e.err.Error()
+}
+
+func (e *permanentWebSocketError) Unwrap() error {
+	return e.err
+}
+
+func (c *ManagedWebSocketClient) run(conn *websocket.Conn) {
+	defer func() {
+		close(c.EventChannel)
+		close(c.ResponseChannel)
+		close(c.done)
+	}()
+
+	for conn != nil {
+		c.listen(conn)
+		conn = c.reconnect()
+	}
+}
+
+// reconnect opens a new connection, resuming the previous one. It returns nil if the client
+// was closed or gave up reconnecting.
+func (c *ManagedWebSocketClient) reconnect() *websocket.Conn {
+	for attempt := 0; ; attempt++ {
+		if !c.wait(c.reconnectDelay(attempt)) {
+			return nil
+		}
+
+		conn, err := c.dial()
+		if err == nil {
+			return conn
+		}
+		mlog.Warn("Failed to reconnect the websocket client", mlog.Int("attempt", attempt+1), mlog.Err(err))
+
+		var permanentErr *permanentWebSocketError
+		if errors.As(err, &permanentErr) || (c.options.MaxReconnectAttempts > 0 && attempt+1 >= c.options.MaxReconnectAttempts) {
+			c.mut.Lock()
+			c.err = err
+			c.mut.Unlock()
+			return nil
+		}
+	}
+}
+
+// reconnectDelay returns the jittered delay before the reconnection attempt following
+// the given number of consecutive failed attempts.
+func (c *ManagedWebSocketClient) reconnectDelay(failedAttempts int) time.Duration {
+	delay := c.options.MinReconnectDelay
+	for range failedAttempts {
+		delay *= 2
+		if delay >= c.options.MaxReconnectDelay {
+			delay = c.options.MaxReconnectDelay
+			break
+		}
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// wait waits for the delay and returns false if the client was closed in the meantime.
+func (c *ManagedWebSocketClient) wait(delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-c.quit:
+		return false
+	}
+}
+
+// listen reads from the connection until it breaks or the client is closed.
+func (c *ManagedWebSocketClient) listen(conn *websocket.Conn) {
+	c.mut.Lock()
+	c.conn = conn
+	c.mut.Unlock()
+
+	defer func() {
+		c.mut.Lock()
+		c.conn = nil
+		c.mut.Unlock()
+		conn.Close()
+	}()
+
+	// Close may have been called before the connection was set.
+	select {
+	case <-c.quit:
+		return
+	default:
+	}
+
+	// The server pings the connection regularly, so a connection without pings is a dead one.
+	conn.SetReadDeadline(time.Now().Add(managedWebSocketPingTimeout))
+	conn.SetPingHandler(func(appData string) error {
+		conn.SetReadDeadline(time.Now().Add(managedWebSocketPingTimeout))
+		err := conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(managedWebSocketWriteWait))
+		if errors.Is(err, websocket.ErrCloseSent) {
+			return nil
+		}
+		return err
+	})
+
+	var buf bytes.Buffer
+	buf.Grow(avgReadMsgSizeBytes)
+
+	for {
+		buf.Reset()
+		_, r, err := conn.NextReader()
+		if err != nil {
+			select {
+			case <-c.quit:
+			default:
+				mlog.Debug("Websocket connection lost", mlog.Err(err))
+			}
+			return
+		}
+		if _, err := buf.ReadFrom(r); err != nil {
+			mlog.Debug("Websocket connection lost", mlog.Err(err))
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(managedWebSocketPingTimeout))
+
+		event, err := WebSocketEventFromJSON(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			mlog.Warn("Failed to decode from JSON", mlog.Err(err))
+			continue
+		}
+		if event.IsValid() {
+			if !c.handleEvent(event) {
+				return
+			}
+			continue
+		}
+
+		var response WebSocketResponse
+		if err := json.Unmarshal(buf.Bytes(), &response); err == nil && response.IsValid() {
+			select {
+			case c.ResponseChannel <- &response:
+			case <-c.quit:
+				return
+			}
+		}
+	}
+}
+
+// handleEvent checks the sequence of an event, reports the missed events and forwards the event.
+// It returns false if the client was closed.
+func (c *ManagedWebSocketClient) handleEvent(event *WebSocketEvent) bool {
+	var gap *WebSocketGap
+	seq := event.GetSequence()
+
+	c.mut.Lock()
+	if event.EventType() == WebsocketEventHello {
+		// The server says hello on new connections, and when it could not resume one.
+		connectionID, _ := event.GetData()["connection_id"].(string)
+		if c.connectionID != "" && connectionID != c.connectionID {
+			gap = &WebSocketGap{
+				Reason:           WebSocketGapConnectionReset,
+				ConnectionID:     c.connectionID,
+				NewConnectionID:  connectionID,
+				ExpectedSequence: c.nextSequence,
+				ReceivedSequence: seq,
+			}
+		}
+		c.connectionID = connectionID
+	} else if seq < c.nextSequence {
+		// Already received before the connection was resumed.
+		c.mut.Unlock()
+		return true
+	} else if seq > c.nextSequence {
+		gap = &WebSocketGap{
+			Reason:           WebSocketGapSequence,
+			ConnectionID:     c.connectionID,
+			ExpectedSequence: c.nextSequence,
+			ReceivedSequence: seq,
+		}
+	}
+	c.nextSequence = seq + 1
+	c.mut.Unlock()
+
+	if gap != nil && !c.sendEvent(gap.toEvent()) {
+		return false
+	}
+	return c.sendEvent(event)
+}
+
+func (c *ManagedWebSocketClient) sendEvent(event *WebSocketEvent) bool {
+	select {
+	case c.EventChannel <- event:
+		return true
+	case <-c.quit:
+		return false
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// managedWebsocketHandler upgrades the requests and passes each connection, with its
+// 1-based index, to handle. The connection is closed when handle returns.
+func managedWebsocketHandler(t *testing.T, handle func(n int, req *http.Request, conn *websocket.Conn)) http.HandlerFunc {
+	var count atomic.Int32
+	return func(w http.ResponseWriter, req *http.Request) {
+		upgrader := &websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, req, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		handle(int(count.Add(1)), req, conn)
+	}
+}
+
+func writeManagedWebsocketEvent(t *testing.T, conn *websocket.Conn, event *WebSocketEvent, seq int64) {
+	t.Helper()
+
+	buf, err := event.SetSequence(seq).ToJSON()
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, buf))
+}
+
+func helloEvent(connectionID string) *WebSocketEvent {
+	event := NewWebSocketEvent(WebsocketEventHello, "", "", "", nil, "")
+	event.Add("connection_id", connectionID)
+	return event
+}
+
+func typingEvent(userID string) *WebSocketEvent {
+	event := NewWebSocketEvent(WebsocketEventTyping, "", "channelid", "", nil, "")
+	event.Add("user_id", userID)
+	return event
+}
+
+func receiveManagedWebsocketEvent(t *testing.T, client *ManagedWebSocketClient) *WebSocketEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-client.EventChannel:
+		require.True(t, ok, "the client stopped")
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for an event")
+	}
+	return nil
+}
+
+func receiveManagedWebsocketGap(t *testing.T, client *ManagedWebSocketClient) *WebSocketGap {
+	t.Helper()
+
+	event := receiveManagedWebsocketEvent(t, client)
+	require.Equal(t, WebsocketEventGap, event.EventType())
+
+	data, err := event.DecodeData()
+	require.NoError(t, err)
+	require.IsType(t, &WebSocketGap{}, data)
+	return data.(*WebSocketGap)
+}
+
+func TestManagedWebSocketClient(t *testing.T) {
+	requests := make(chan *WebSocketRequest, 1)
+	s := httptest.NewServer(managedWebsocketHandler(t, func(n int, req *http.Request, conn *websocket.Conn) {
+		assert.Equal(t, "Bearer authToken", req.Header.Get("Authorization"))
+
+		switch n {
+		case 1:
+			assert.Empty(t, req.URL.Query().Get("connection_id"))
+			writeManagedWebsocketEvent(t, conn, helloEvent("conn1"), 0)
+			writeManagedWebsocketEvent(t, conn, typingEvent("user1"), 1)
+		case 2:
+			// The queued events are replayed from the requested sequence number, then the
+			// server skips one.
+			assert.Equal(t, "conn1", req.URL.Query().Get("connection_id"))
+			assert.Equal(t, "2", req.URL.Query().Get("sequence_number"))
+			writeManagedWebsocketEvent(t, conn, typingEvent("user1"), 1)
+			writeManagedWebsocketEvent(t, conn, typingEvent("user2"), 2)
+			writeManagedWebsocketEvent(t, conn, typingEvent("user4"), 4)
+		case 3:
+			// The connection can't be resumed, so the server starts a new one.
+			assert.Equal(t, "conn1", req.URL.Query().Get("connection_id"))
+			assert.Equal(t, "5", req.URL.Query().Get("sequence_number"))
+			writeManagedWebsocketEvent(t, conn, helloEvent("conn2"), 0)
+			writeManagedWebsocketEvent(t, conn, typingEvent("user1"), 1)
+
+			var req WebSocketRequest
+			if assert.NoError(t, conn.ReadJSON(&req)) {
+				requests <- &req
+			}
+			conn.ReadMessage()
+			return
+		default:
+			assert.Fail(t, "unexpected connection")
+			return
+		}
+
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""))
+	}))
+	defer s.Close()
+
+	url := strings.Replace(s.URL, "http://", "ws://", 1)
+	client, err := NewManagedWebSocketClient(url, "authToken", &ManagedWebSocketClientOptions{
+		MinReconnectDelay: time.Millisecond,
+		MaxReconnectDelay: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	event := receiveManagedWebsocketEvent(t, client)
+	assert.Equal(t, WebsocketEventHello, event.EventType())
+	assert.Equal(t, "conn1", client.ConnectionID())
+
+	event = receiveManagedWebsocketEvent(t, client)
+	assert.Equal(t, int64(1), event.GetSequence())
+
+	// The replayed event is not received twice.
+	event = receiveManagedWebsocketEvent(t, client)
+	assert.Equal(t, int64(2), event.GetSequence())
+
+	// The missed event is reported in its place.
+	gap := receiveManagedWebsocketGap(t, client)
+	assert.Equal(t, &WebSocketGap{
+		Reason:           WebSocketGapSequence,
+		ConnectionID:     "conn1",
+		ExpectedSequence: 3,
+		ReceivedSequence: 4,
+	}, gap)
+
+	event = receiveManagedWebsocketEvent(t, client)
+	assert.Equal(t, int64(4), event.GetSequence())
+
+	gap = receiveManagedWebsocketGap(t, client)
+	assert.Equal(t, &WebSocketGap{
+		Reason:           WebSocketGapConnectionReset,
+		ConnectionID:     "conn1",
+		NewConnectionID:  "conn2",
+		ExpectedSequence: 5,
+		ReceivedSequence: 0,
+	}, gap)
+
+	event = receiveManagedWebsocketEvent(t, client)
+	assert.Equal(t, WebsocketEventHello, event.EventType())
+	assert.Equal(t, "conn2", client.ConnectionID())
+
+	event = receiveManagedWebsocketEvent(t, client)
+	assert.Equal(t, int64(1), event.GetSequence())
+	assert.Equal(t, int64(2), client.NextSequence())
+
+	require.NoError(t, client.UserTyping("channelid", ""))
+	select {
+	case req := <-requests:
+		assert.Equal(t, "user_typing", req.Action)
+		assert.Equal(t, "channelid", req.Data["channel_id"])
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for the request")
+	}
+
+	client.Close()
+	client.Close()
+
+	_, ok := <-client.EventChannel
+	assert.False(t, ok)
+	assert.NoError(t, client.Err())
+	assert.ErrorIs(t, client.SendMessage("user_typing", nil), ErrWebSocketNotConnected)
+}
+
+func TestManagedWebSocketClientGivesUp(t *testing.T) {
+	newServer := func(status int) *httptest.Server {
+		var count atomic.Int32
+		upgrade := managedWebsocketHandler(t, func(n int, req *http.Request, conn *websocket.Conn) {
+			writeManagedWebsocketEvent(t, conn, helloEvent("conn1"), 0)
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""))
+		})
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if count.Add(1) > 1 {
+				w.WriteHeader(status)
+				return
+			}
+			upgrade(w, req)
+		}))
+	}
+
+	waitForDone := func(t *testing.T, client *ManagedWebSocketClient) {
+		t.Helper()
+
+		for range client.EventChannel {
+		}
+		select {
+		case <-client.Done():
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for the client to stop")
+		}
+	}
+
+	t.Run("after the maximum number of attempts", func(t *testing.T) {
+		s := newServer(http.StatusServiceUnavailable)
+		defer s.Close()
+
+		client, err := NewManagedWebSocketClient(strings.Replace(s.URL, "http://", "ws://", 1), "authToken", &ManagedWebSocketClientOptions{
+			MinReconnectDelay:    time.Millisecond,
+			MaxReconnectDelay:    10 * time.Millisecond,
+			MaxReconnectAttempts: 3,
+		})
+		require.NoError(t, err)
+
+		waitForDone(t, client)
+		require.Error(t, client.Err())
+		assert.ErrorIs(t, client.Err(), websocket.ErrBadHandshake)
+		client.Close()
+	})
+
+	t.Run("when unauthorized", func(t *testing.T) {
+		s := newServer(http.StatusUnauthorized)
+		defer s.Close()
+
+		client, err := NewManagedWebSocketClient(strings.Replace(s.URL, "http://", "ws://", 1), "authToken", &ManagedWebSocketClientOptions{
+			MinReconnectDelay: time.Millisecond,
+			MaxReconnectDelay: 10 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		waitForDone(t, client)
+		require.Error(t, client.Err())
+		client.Close()
+	})
+
+	t.Run("when the first connection fails", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer s.Close()
+
+		_, err := NewManagedWebSocketClient(strings.Replace(s.URL, "http://", "ws://", 1), "authToken", nil)
+		require.Error(t, err)
+	})
+}
+
+func TestManagedWebSocketClientReconnectDelay(t *testing.T) {
+	client := &ManagedWebSocketClient{
+		options: ManagedWebSocketClientOptions{
+			MinReconnectDelay: 100 * time.Millisecond,
+			MaxReconnectDelay: time.Second,
+		},
+	}
+
+	for attempt, expected := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		for range 10 {
+			delay := client.reconnectDelay(attempt)
+			assert.GreaterOrEqual(t, delay, expected/2)
+			assert.LessOrEqual(t, delay, expected)
+		}
+	}
+}
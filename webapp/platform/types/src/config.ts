@@ -172,6 +172,7 @@ export type ClientConfig = {
     GitLabButtonColor: string;
     OpenIdButtonText: string;
     OpenIdButtonColor: string;
+    OpenIdProviders: string;
     PasswordEnableForgotLink: string;
     PasswordMinimumLength: string;
     PasswordRequireLowercase: string;
@@ -199,6 +200,7 @@ export type ClientConfig = {
     SchemaVersion: string;
     SendEmailNotifications: string;
     SendPushNotifications: string;
+    SendWebPushNotifications: string;
     ShowEmailAddress: string;
     SiteName: string;
     SiteURL: string;
@@ -210,6 +212,7 @@ export type ClientConfig = {
     TimeBetweenUserTypingUpdatesMilliseconds: string;
     UpgradedFromTE: string;
     Version: string;
+    WebPushVAPIDPublicKey: string;
     WebsocketPort: string;
     WebsocketSecurePort: string;
     WebsocketURL: string;
@@ -353,6 +356,7 @@ export type ServiceSettings = {
     EnableOutgoingOAuthConnections: boolean;
     EnableCommands: boolean;
     OutgoingIntegrationRequestsTimeout: number;
+    OutgoingWebhookMaxAttempts: number;
     EnablePostUsernameOverride: boolean;
     EnablePostIconOverride: boolean;
     EnableLinkPreviews: boolean;
@@ -448,6 +452,9 @@ export type ServiceSettings = {
     MaximumURLLength: number;
     ScheduledPosts: boolean;
     EnableWebHubChannelIteration: boolean;
+    EnableWebSocketCompression: boolean;
+    WebSocketCompressionThresholdBytes: number;
+    WebSocketCompressionLevel: number;
     FrameAncestors: string;
     DeleteAccountLink: string;
     MinimumDesktopAppVersion: string;
@@ -526,6 +533,9 @@ export type ExperimentalAuditSettings = {
     FileName: string;
     AdvancedLoggingJSON: Record<string, any>;
     Certificate: string;
+    HashChainEnabled: boolean;
+    HashChainCheckpointInterval: number;
+    HashChainSigningKey: string;
 };
 
 export type PasswordSettings = {
@@ -570,6 +580,7 @@ export type FileSettings = {
     ExtractContent: boolean;
     ExtractContentTimeout: number;
     ArchiveRecursion: boolean;
+    DeduplicateFiles: boolean;
     PublicLinkSalt: string;
     InitialFont: string;
     AmazonS3AccessKeyId: string;
@@ -628,6 +639,7 @@ export type EmailSettings = {
     PushNotificationServerLocation: 'global' | 'us' | 'de' | 'jp';
     PushNotificationContents: string;
     PushNotificationBuffer: number;
+    SendWebPushNotifications: boolean;
     EnableEmailBatching: boolean;
     EmailBatchingBufferSize: number;
     EmailBatchingInterval: number;
@@ -637,6 +649,11 @@ export type EmailSettings = {
     LoginButtonColor: string;
     LoginButtonBorderColor: string;
     LoginButtonTextColor: string;
+    MailTransport: string;
+    HTTPMailTransportURL: string;
+    HTTPMailTransportAPIKey: string;
+    EnableMailQueue: boolean;
+    MailQueueMaxAttempts: number;
 };
 
 export type RateLimitSettings = {
@@ -647,6 +664,16 @@ export type RateLimitSettings = {
     VaryByRemoteAddr: boolean;
     VaryByUser: boolean;
     VaryByHeader: string;
+    StoreType: string;
+    LoginQuota: RateLimitQuota;
+    PostCreateQuota: RateLimitQuota;
+    FileUploadQuota: RateLimitQuota;
+    SearchQuota: RateLimitQuota;
+};
+
+export type RateLimitQuota = {
+    PerSec: number;
+    MaxBurst: number;
 };
 
 export type PrivacySettings = {
@@ -718,6 +745,31 @@ export type Office365Settings = {
     UsePreferredUsername: boolean;
 };
 
+export type OpenIdProviderSettings = {
+    Name: string;
+    Enable: boolean;
+    ButtonText: string;
+    ButtonColor: string;
+    Id: string;
+    Secret: string;
+    DiscoveryEndpoint: string;
+    Scope: string;
+    UsePKCE: boolean;
+    IdClaim: string;
+    EmailClaim: string;
+    UsernameClaim: string;
+    FirstNameClaim: string;
+    LastNameClaim: string;
+    NicknameClaim: string;
+    PositionClaim: string;
+    GroupsClaim: string;
+    AttributeClaims: Record<string, string>;
+};
+
+export type OpenIdProvidersSettings = {
+    Providers: OpenIdProviderSettings[];
+};
+
 export type LdapSettings = {
     Enable: boolean;
     EnableSync: boolean;
@@ -1017,6 +1069,14 @@ export type ImageProxySettings = {
     RemoteImageProxyOptions: string;
 };
 
+export type FileScanSettings = {
+    Enable: boolean;
+    Type: string;
+    Address: string;
+    TimeoutSeconds: number;
+    AllowDownloadOfFailedScans: boolean;
+};
+
 export type CloudSettings = {
     CWSURL: string;
     CWSAPIURL: string;
@@ -1094,6 +1154,7 @@ export type AdminConfig = {
     GoogleSettings: SSOSettings;
     Office365Settings: Office365Settings;
     OpenIdSettings: SSOSettings;
+    OpenIdProvidersSettings: OpenIdProvidersSettings;
     LdapSettings: LdapSettings;
     ComplianceSettings: ComplianceSettings;
     LocalizationSettings: LocalizationSettings;
@@ -1113,6 +1174,7 @@ export type AdminConfig = {
     DisplaySettings: DisplaySettings;
     GuestAccountsSettings: GuestAccountsSettings;
     ImageProxySettings: ImageProxySettings;
+    FileScanSettings: FileScanSettings;
     CloudSettings: CloudSettings;
     FeatureFlags: FeatureFlags;
     ImportSettings: ImportSettings;
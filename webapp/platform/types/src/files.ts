@@ -43,6 +43,8 @@ export type FileInfo = {
     mini_preview?: string;
     archived: boolean;
     link?: string;
+    scan_status?: 'pending' | 'clean' | 'infected' | 'failed';
+    scanned_at?: number;
 };
 export type FilesState = {
     files: Record<string, FileInfo>;
@@ -10,7 +10,10 @@ export type FieldType = (
     'date' |
     'user' |
     'multiuser' |
-    'rank'
+    'rank' |
+    'number' |
+    'url' |
+    'checkbox'
 );
 
 export type PropertyField = {